@@ -118,6 +118,20 @@ func ReadConfigs() (*Config, error) {
 		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.GlobalAdapter)).Elem(), "GlobalAdapter", true)
 		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Enforcer)).Elem(), "Enforcer", false)
 		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Analytics)).Elem(), "Analytics", false)
+
+		if validationErr := adapterConfig.Validate(); validationErr != nil {
+			if adapterConfig.Validation.Strict {
+				// the agent is not started in strict mode
+				loggerConfig.ErrorC(logging.ErrorDetails{
+					Message:   validationErr.Error(),
+					Severity:  logging.BLOCKER,
+					ErrorCode: 1004,
+				})
+			} else {
+				loggerConfig.Warnf("%s\nSet validation.strict to true to refuse starting the agent with "+
+					"such a configuration", validationErr.Error())
+			}
+		}
 	})
 	return adapterConfig, e
 }
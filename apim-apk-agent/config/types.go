@@ -66,6 +66,16 @@ const (
 	UnassignedAsDeprecated string = "unassigned-as-deprecated"
 )
 
+// Types of the brokers the control plane events can be consumed from
+const (
+	// JMSBrokerType is the JMS (AMQP) broker of the traffic manager
+	JMSBrokerType string = "jms"
+	// KafkaBrokerType is a Kafka cluster replacing the JMS broker of the traffic manager
+	KafkaBrokerType string = "kafka"
+	// NATSBrokerType is a NATS server with JetStream enabled replacing the JMS broker of the traffic manager
+	NATSBrokerType string = "nats"
+)
+
 // Config represents the adapter configuration.
 // It is created directly from the configuration toml file.
 // Note :
@@ -80,6 +90,10 @@ type Config struct {
 	GlobalAdapter globalAdapter `toml:"globalAdapter"`
 	Analytics     analytics     `toml:"analytics"`
 	Tracing       tracing
+	Notifier      notifier   `toml:"notifier"`
+	SyncStatus    syncStatus `toml:"syncStatus"`
+	Validation    validation `toml:"validation"`
+	Profiling     profiling  `toml:"profiling"`
 }
 
 // Adapter related Configurations
@@ -460,6 +474,8 @@ type analytics struct {
 	Type     string
 	Adapter  analyticsAdapter
 	Enforcer analyticsEnforcer
+	// APIRegistration contains the configurations to register the synced APIs with the analytics endpoint
+	APIRegistration analyticsAPIRegistration `toml:"apiRegistration"`
 }
 
 type tracing struct {
@@ -488,6 +504,17 @@ type analyticsEnforcer struct {
 	LogReceiver      authService
 }
 
+// Configurations used to register the APIs synced from the control plane with the analytics endpoint,
+// so that the metrics of the APIs are available in the analytics dashboards
+type analyticsAPIRegistration struct {
+	Enabled bool
+	// Endpoint to which the API details are posted. The analytics authToken is sent as a bearer token.
+	Endpoint string
+	// Properties are the analytics related properties added to each registered API
+	Properties          map[string]string
+	SkipSSLVerification bool
+}
+
 type analyticsCustomProperties struct {
 	Enabled          bool
 	RequestHeaders   []string
@@ -544,11 +571,35 @@ type controlPlane struct {
 	SyncApisOnStartUp          bool
 	SendRevisionUpdate         bool
 	EnvironmentLabels          []string
+	EnvironmentLabelsRefresh   environmentLabelsRefresh
 	RetryInterval              time.Duration
 	SkipSSLVerification        bool
 	BrokerConnectionParameters brokerConnectionParameters
 	HTTPClient                 httpClient
 	RequestWorkerPool          requestWorkerPool
+	DataPull                   dataPull
+}
+
+// environmentLabelsRefresh holds the configuration to retrieve the gateway environments from the Admin API, so
+// that a gateway environment added in the control plane is served without restarting the agent
+type environmentLabelsRefresh struct {
+	Enabled bool
+	// Interval is the time in seconds between two retrievals of the gateway environments
+	Interval time.Duration
+	// GatewayType is the gateway type of the environments served by the agent in addition to the EnvironmentLabels
+	GatewayType string
+}
+
+// dataPull holds the configuration to pull the subscriptions, applications and application key mappings from the
+// control plane in pages, so that the data of large tenants is pulled without timing out
+type dataPull struct {
+	// PageSize is the number of entries pulled in a request. The entries are pulled in a single request when 0.
+	PageSize int
+	// MaxConcurrentRequests is the maximum number of pages pulled in parallel
+	MaxConcurrentRequests int
+	// RequestsPerSecond limits the rate of the page requests sent to the control plane. The rate is not limited
+	// when 0.
+	RequestsPerSecond int
 }
 
 type requestWorkerPool struct {
@@ -570,9 +621,81 @@ type globalAdapter struct {
 }
 
 type brokerConnectionParameters struct {
+	// BrokerType is the type of the broker the control plane events are consumed from (jms, kafka or nats)
+	BrokerType              string
 	EventListeningEndpoints []string
-	ReconnectInterval       time.Duration
-	ReconnectRetryCount     int
+	// ReconnectInterval is the initial delay in milliseconds between two reconnect attempts
+	ReconnectInterval time.Duration
+	// MaxReconnectInterval is the upper bound in milliseconds for the exponentially growing reconnect delay
+	MaxReconnectInterval time.Duration
+	// ReconnectJitter is the fraction (0 to 1) by which each reconnect delay is randomized
+	ReconnectJitter float64
+	// ReconnectRetryCount is the number of reconnect attempts on a broker URL before failing over to the next
+	ReconnectRetryCount int
+	// Kafka holds the Kafka connection used when the broker type is kafka
+	Kafka kafkaConnectionParameters
+	// Nats holds the NATS JetStream connection used when the broker type is nats
+	Nats natsConnectionParameters
+}
+
+type kafkaConnectionParameters struct {
+	// Brokers are the addresses (host:port) of the Kafka brokers
+	Brokers []string
+	// ConsumerGroup must be unique to each agent, so that every agent receives all the events. A consumer group
+	// derived from the host name is used when not set.
+	ConsumerGroup string
+	Topics        kafkaTopics
+	TLS           brokerTLS
+	SASL          kafkaSASL
+}
+
+type kafkaTopics struct {
+	Notification    string
+	KeyManager      string
+	TokenRevocation string
+	ThrottleData    string
+}
+
+type natsConnectionParameters struct {
+	// Servers are the URLs of the NATS servers (ex: nats://nats:4222)
+	Servers []string
+	// Stream is the JetStream stream the control plane events are published to
+	Stream string
+	// Durable prefixes the names of the durable consumers of the agent. It must be unique to each agent, so that
+	// every agent receives all the events. A prefix derived from the host name is used when not set.
+	Durable  string
+	Subjects natsSubjects
+	// AckWait is the time in seconds after which an event that is not acknowledged is redelivered
+	AckWait time.Duration
+	// MaxDeliver is the maximum number of times an event is delivered. Events are redelivered until they are
+	// acknowledged when not set.
+	MaxDeliver int
+	Username   string
+	Password   string
+	Token      string
+	TLS        brokerTLS
+}
+
+type natsSubjects struct {
+	Notification    string
+	KeyManager      string
+	TokenRevocation string
+	ThrottleData    string
+}
+
+type brokerTLS struct {
+	Enabled          bool
+	CACertFile       string
+	CertFile         string
+	KeyFile          string
+	SkipVerification bool
+}
+
+type kafkaSASL struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	Mechanism string
+	Username  string
+	Password  string
 }
 
 // Configuration for Enforcer admin rest api
@@ -598,9 +721,101 @@ type httpClient struct {
 	RequestTimeOut time.Duration
 }
 
+// Notifier related configurations
+type notifier struct {
+	// RequestTimeout is the time in seconds to wait for a webhook to respond
+	RequestTimeout time.Duration
+	// Webhooks contains the list of webhooks to be invoked on API sync events
+	Webhooks []Webhook
+}
+
+// Webhook represents a webhook which is invoked when an API import or revision undeploy
+// succeeds or fails
+type Webhook struct {
+	Name string
+	// Type of the webhook. Supported values are "slack" and "http"
+	Type string
+	URL  string
+	// Events the webhook is subscribed to. Supported values are "success" and "failure".
+	// If empty, the webhook is invoked for all events.
+	Events []string
+	// PayloadTemplate is a Go text/template used to render the request body. The fields of the
+	// event are JSON escaped, so they can be placed inside JSON strings. If empty, the default
+	// payload of the webhook type is used.
+	PayloadTemplate     string
+	Headers             map[string]string
+	SkipSSLVerification bool
+}
+
+// Sync status related configurations
+type syncStatus struct {
+	// Enabled serves the sync status of each API at GET /apis/{uuid}/status and its deployment status compared with
+	// the control plane at GET /apis/{uuid}/deployment-status
+	Enabled bool
+	// Host name of the sync status server
+	Host string
+	// Port of the sync status server
+	Port string
+	// Token required as a bearer token by every endpoint except /healthz. The server is not started on a non
+	// loopback host when the token is empty.
+	Token string
+	// CRStatus contains the configurations to write the sync status back to the API custom resources
+	CRStatus crStatus
+	// DataCache contains the configurations of the applications and subscriptions served at GET /applications and
+	// GET /subscriptions
+	DataCache dataCache
+}
+
+// Configurations of the applications and subscriptions cached from the control plane
+type dataCache struct {
+	// RefreshInterval is how frequently the data is pulled again from the control plane (in seconds).
+	// The data is only pulled at startup when set to 0.
+	RefreshInterval time.Duration
+	// StaleAfter is the time since the last successful pull after which the data is flagged as stale (in seconds)
+	StaleAfter time.Duration
+	// Directory to persist the data in, so that it is served even if the control plane is unreachable at startup.
+	// The data is not persisted when empty.
+	Directory string
+}
+
+// Configurations used to update the status subresource of the API custom resources
+type crStatus struct {
+	Enabled bool
+	// Namespace of the API custom resources
+	Namespace string
+	// APIVersion (group/version) of the API custom resource definition
+	APIVersion string
+	// UUIDLabel is the label of the API custom resource which holds the control plane API UUID
+	UUIDLabel string
+}
+
 type mutualSSL struct {
 	CertificateHeader               string
 	EnableClientValidation          bool
 	ClientCertificateEncode         bool
 	EnableOutboundCertificateHeader bool
 }
+
+// Configuration validation related configurations
+type validation struct {
+	// Strict refuses to start the agent when the configuration has problems. The problems are only logged
+	// otherwise.
+	Strict bool
+}
+
+// Profiling related configurations used to diagnose the memory and CPU usage of the agent
+type profiling struct {
+	// Enabled serves the pprof endpoints at /debug/pprof/ on a separate port
+	Enabled bool
+	// Host name of the profiling server
+	Host string
+	// Port of the profiling server
+	Port string
+	// Username and Password of the basic authentication required to access the profiling server. The profiling
+	// server is not started when either of them is empty.
+	Username string
+	Password string
+	// MemoryLogInterval is how frequently the memory usage of the agent and its high watermark are logged
+	// (in seconds). The memory usage is not logged when set to 0.
+	MemoryLogInterval time.Duration
+}
@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ValidationError holds all the problems found in the configuration, so that they can be fixed at once
+type ValidationError struct {
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("%d problem(s) found in the configuration:\n  - %s", len(err.Problems),
+		strings.Join(err.Problems, "\n  - "))
+}
+
+// configValidator collects the problems found in the configuration
+type configValidator struct {
+	problems []string
+}
+
+func (v *configValidator) addProblem(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the configuration for problems which the agent would otherwise tolerate silently or fail on
+// later (ex: a missing control plane URL, an empty environment label list or a TLS file which does not exist).
+// All the problems are returned together in a ValidationError, and nil is returned if none is found.
+func (config *Config) Validate() error {
+	v := &configValidator{}
+	if config.ControlPlane.Enabled {
+		v.validateControlPlane(&config.ControlPlane, config.Adapter.Truststore.Location)
+	}
+	if config.Profiling.Enabled && (config.Profiling.Username == "" || config.Profiling.Password == "") {
+		v.addProblem("profiling.username and profiling.password are required to serve the profiling endpoints")
+	}
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (v *configValidator) validateControlPlane(cp *controlPlane, truststoreLocation string) {
+	if strings.TrimSpace(cp.ServiceURL) == "" {
+		v.addProblem("controlPlane.serviceURL is not set. Set it to the URL of the API Manager " +
+			"(ex: https://apim:9443/)")
+	} else {
+		v.validateURL("controlPlane.serviceURL", cp.ServiceURL, "http", "https")
+	}
+	if cp.Username == "" || cp.Password == "" {
+		v.addProblem("controlPlane.username and controlPlane.password are required to connect to the control plane")
+	}
+	if cp.EnvironmentLabelsRefresh.Enabled {
+		if cp.EnvironmentLabelsRefresh.Interval <= 0 {
+			v.addProblem("controlPlane.environmentLabelsRefresh.interval should be greater than 0")
+		}
+		if strings.TrimSpace(cp.EnvironmentLabelsRefresh.GatewayType) == "" {
+			v.addProblem("controlPlane.environmentLabelsRefresh.gatewayType is not set. Set the gateway type of " +
+				"the environments to serve (ex: APK)")
+		}
+	}
+	if len(cp.EnvironmentLabels) > 0 || !cp.EnvironmentLabelsRefresh.Enabled {
+		// The labels can be left empty when the environments are retrieved from the control plane
+		v.validateEnvironmentLabels(cp.EnvironmentLabels)
+	}
+	if !cp.SkipSSLVerification {
+		v.validatePath("adapter.truststore.location", truststoreLocation)
+	}
+	v.validateBroker(&cp.BrokerConnectionParameters)
+}
+
+func (v *configValidator) validateEnvironmentLabels(labels []string) {
+	if len(labels) == 0 {
+		v.addProblem("controlPlane.environmentLabels is empty. Set the gateway environments of the APIs to deploy "+
+			"(ex: [\"%s\"])", DefaultGatewayName)
+		return
+	}
+	seen := make(map[string]bool, len(labels))
+	for i, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			v.addProblem("controlPlane.environmentLabels[%d] is blank", i)
+			continue
+		}
+		if label != strings.TrimSpace(label) {
+			v.addProblem("controlPlane.environmentLabels[%d] %q has leading or trailing spaces", i, label)
+		}
+		if seen[label] {
+			v.addProblem("controlPlane.environmentLabels has the label %q more than once", label)
+		}
+		seen[label] = true
+	}
+}
+
+func (v *configValidator) validateBroker(broker *brokerConnectionParameters) {
+	switch broker.BrokerType {
+	case "", JMSBrokerType:
+		if len(broker.EventListeningEndpoints) == 0 {
+			v.addProblem("controlPlane.brokerConnectionParameters.eventListeningEndpoints is empty. Set the AMQP " +
+				"URLs of the traffic manager")
+		}
+	case KafkaBrokerType:
+		if len(broker.Kafka.Brokers) == 0 {
+			v.addProblem("controlPlane.brokerConnectionParameters.kafka.brokers is empty. Set the addresses " +
+				"(host:port) of the Kafka brokers")
+		}
+		v.validateBrokerTLS("controlPlane.brokerConnectionParameters.kafka.tls", &broker.Kafka.TLS)
+	case NATSBrokerType:
+		if len(broker.Nats.Servers) == 0 {
+			v.addProblem("controlPlane.brokerConnectionParameters.nats.servers is empty. Set the URLs of the " +
+				"NATS servers (ex: nats://nats:4222)")
+		}
+		for i, server := range broker.Nats.Servers {
+			v.validateURL(fmt.Sprintf("controlPlane.brokerConnectionParameters.nats.servers[%d]", i), server,
+				"nats", "tls")
+		}
+		v.validateBrokerTLS("controlPlane.brokerConnectionParameters.nats.tls", &broker.Nats.TLS)
+	default:
+		v.addProblem("controlPlane.brokerConnectionParameters.brokerType %q is not supported. Use one of %s, %s "+
+			"or %s", broker.BrokerType, JMSBrokerType, KafkaBrokerType, NATSBrokerType)
+	}
+}
+
+func (v *configValidator) validateBrokerTLS(key string, tlsConfig *brokerTLS) {
+	if !tlsConfig.Enabled {
+		return
+	}
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		v.addProblem("%s.certFile and %s.keyFile must be set together for mutual TLS", key, key)
+	}
+	v.validatePath(key+".caCertFile", tlsConfig.CACertFile)
+	v.validatePath(key+".certFile", tlsConfig.CertFile)
+	v.validatePath(key+".keyFile", tlsConfig.KeyFile)
+}
+
+func (v *configValidator) validateURL(key, value string, schemes ...string) {
+	parsedURL, err := url.Parse(value)
+	if err != nil || parsedURL.Host == "" {
+		v.addProblem("%s %q is not a valid URL", key, value)
+		return
+	}
+	for _, scheme := range schemes {
+		if parsedURL.Scheme == scheme {
+			return
+		}
+	}
+	v.addProblem("%s %q must use one of the schemes %s", key, value, strings.Join(schemes, ", "))
+}
+
+// validatePath adds a problem if the path is set but cannot be accessed
+func (v *configValidator) validatePath(key, path string) {
+	if path == "" {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			v.addProblem("%s %q does not exist", key, path)
+		} else {
+			v.addProblem("%s %q cannot be accessed: %v", key, path, err)
+		}
+	}
+}
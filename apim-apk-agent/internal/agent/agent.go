@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
@@ -31,7 +32,9 @@ import (
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
 	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/messaging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/profiling"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/synchronizer"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/syncstatus"
 )
 
 var (
@@ -83,12 +86,38 @@ func Run(conf *config.Config) {
 	logger.LoggerInternalMsg.Info("Starting apim-apk-agent ....")
 	eventHubEnabled := conf.ControlPlane.Enabled
 
+	if conf.SyncStatus.Enabled {
+		go syncstatus.StartSyncStatusServer(conf)
+	}
+	if conf.SyncStatus.CRStatus.Enabled {
+		syncstatus.StartCRStatusUpdater()
+	}
+
+	if conf.Profiling.Enabled {
+		go profiling.StartProfilingServer(conf)
+	}
+	if conf.Profiling.MemoryLogInterval > 0 {
+		go profiling.LogMemoryUsage(conf.Profiling.MemoryLogInterval * time.Second)
+	}
+
+	if eventHubEnabled && conf.ControlPlane.EnvironmentLabelsRefresh.Enabled {
+		// Retrieve the gateway environments before the initial data, so that their APIs are fetched on start up
+		eventhub.LoadEnvironmentLabels(conf)
+	}
+
 	// Load initial data from control plane
 	eventhub.LoadInitialData(conf)
 
+	if eventHubEnabled && conf.ControlPlane.EnvironmentLabelsRefresh.Enabled {
+		go eventhub.RefreshEnvironmentLabels(conf, conf.ControlPlane.EnvironmentLabelsRefresh.Interval*time.Second)
+	}
+
 	if eventHubEnabled {
-		var connectionURLList = conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
-		if strings.Contains(connectionURLList[0], amqpProtocol) {
+		brokerConnectionParameters := conf.ControlPlane.BrokerConnectionParameters
+		var connectionURLList = brokerConnectionParameters.EventListeningEndpoints
+		if strings.EqualFold(brokerConnectionParameters.BrokerType, config.KafkaBrokerType) ||
+			strings.EqualFold(brokerConnectionParameters.BrokerType, config.NATSBrokerType) ||
+			(len(connectionURLList) > 0 && strings.Contains(connectionURLList[0], amqpProtocol)) {
 			go messaging.ProcessEvents(conf)
 		}
 	}
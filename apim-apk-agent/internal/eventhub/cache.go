@@ -0,0 +1,207 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+)
+
+const persistedDataFileSuffix = ".json"
+
+// ResourceSyncState holds the control plane sync state of a resource (subscriptions, applications or
+// application-key-mappings) cached by the agent
+type ResourceSyncState struct {
+	// LastSync is the time the resource was last pulled from the control plane. It is the time the data was
+	// persisted when it was loaded from the persisted cache.
+	LastSync time.Time
+	// Reachable is false if the last attempt to pull the resource from the control plane failed
+	Reachable bool
+	// Persisted is true if the data was loaded from the persisted cache and has not been pulled since
+	Persisted bool
+}
+
+// IsStale returns true if the control plane could not be reached during the last pull, or if the data was not
+// pulled within staleAfter
+func (state ResourceSyncState) IsStale(staleAfter time.Duration, now time.Time) bool {
+	if state.LastSync.IsZero() {
+		return true
+	}
+	return !state.Reachable || state.Persisted || (staleAfter > 0 && now.Sub(state.LastSync) > staleAfter)
+}
+
+var (
+	cacheMutex         sync.RWMutex
+	resourceSyncStates = make(map[string]ResourceSyncState)
+)
+
+// GetApplications returns the cached applications sorted by UUID along with the sync state of the applications
+func GetApplications() ([]Application, ResourceSyncState) {
+	cacheMutex.RLock()
+	defer cacheMutex.RUnlock()
+	applications := make([]Application, 0, len(ApplicationMap))
+	for _, application := range ApplicationMap {
+		applications = append(applications, application)
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].UUID < applications[j].UUID })
+	return applications, resourceSyncStates[applicationsEndpoint]
+}
+
+// GetSubscriptions returns the cached subscriptions sorted by ID along with the sync state of the subscriptions
+func GetSubscriptions() ([]Subscription, ResourceSyncState) {
+	cacheMutex.RLock()
+	defer cacheMutex.RUnlock()
+	subscriptions := make([]Subscription, 0, len(SubscriptionMap))
+	for _, subscription := range SubscriptionMap {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].SubscriptionID < subscriptions[j].SubscriptionID
+	})
+	return subscriptions, resourceSyncStates[subscriptionsEndpoint]
+}
+
+// GetResourceSyncStates returns the sync state of each cached resource keyed by the resource name
+func GetResourceSyncStates() map[string]ResourceSyncState {
+	cacheMutex.RLock()
+	defer cacheMutex.RUnlock()
+	states := make(map[string]ResourceSyncState, len(resources))
+	for _, resource := range resources {
+		states[resource.endpoint] = resourceSyncStates[resource.endpoint]
+	}
+	return states
+}
+
+// UpdateApplication adds or replaces an application received through an event in the cache
+func UpdateApplication(app *types.Application) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	if ApplicationMap == nil {
+		ApplicationMap = make(map[string]Application)
+	}
+	ApplicationMap[app.UUID] = MarshalApplication(app)
+}
+
+// UpdateSubscription adds or replaces a subscription received through an event in the cache
+func UpdateSubscription(sub *types.Subscription) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	if SubscriptionMap == nil {
+		SubscriptionMap = make(map[int32]Subscription)
+	}
+	SubscriptionMap[sub.SubscriptionID] = MarshalSubscription(sub)
+}
+
+// RemoveApplication removes an application deleted in the control plane from the cache
+func RemoveApplication(uuid string) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	delete(ApplicationMap, uuid)
+}
+
+// RemoveSubscription removes a subscription deleted in the control plane from the cache
+func RemoveSubscription(subscriptionID int32) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	delete(SubscriptionMap, subscriptionID)
+}
+
+// recordResourceSyncFailure marks that the control plane could not be reached to pull the resource.
+// The cached data of the resource is kept as it is.
+func recordResourceSyncFailure(endpoint string) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	state := resourceSyncStates[endpoint]
+	state.Reachable = false
+	resourceSyncStates[endpoint] = state
+}
+
+// loadPersistedResources loads the data persisted in directory into the cache, so that it can be served
+// until the control plane is reachable
+func loadPersistedResources(directory string) {
+	for _, resource := range resources {
+		path := filepath.Join(directory, resource.endpoint+persistedDataFileSuffix)
+		info, err := os.Stat(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logger.LoggerSync.Warnf("Error reading the persisted %s from %s: %v", resource.endpoint, path, err)
+			}
+			continue
+		}
+		payload, err := ioutil.ReadFile(path)
+		if err != nil {
+			logger.LoggerSync.Warnf("Error reading the persisted %s from %s: %v", resource.endpoint, path, err)
+			continue
+		}
+		cacheMutex.Lock()
+		if retrieveDataFromResponseChannel(response{Payload: payload, Endpoint: resource.endpoint,
+			Type: resource.responseType}) {
+			resourceSyncStates[resource.endpoint] = ResourceSyncState{LastSync: info.ModTime().UTC(), Persisted: true}
+			logger.LoggerSync.Infof("Loaded the %s persisted at %v", resource.endpoint, info.ModTime().UTC())
+		}
+		cacheMutex.Unlock()
+	}
+}
+
+// persistResource writes the payload to a temporary file which is then renamed, so that a partially written
+// file is never loaded
+func persistResource(directory, endpoint string, payload []byte) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		logger.LoggerSync.Warnf("Error creating the directory %s to persist the %s: %v", directory, endpoint, err)
+		return
+	}
+	tmpFile, err := ioutil.TempFile(directory, endpoint)
+	if err != nil {
+		logger.LoggerSync.Warnf("Error persisting the %s in %s: %v", endpoint, directory, err)
+		return
+	}
+	_, err = tmpFile.Write(payload)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), filepath.Join(directory, endpoint+persistedDataFileSuffix))
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		logger.LoggerSync.Warnf("Error persisting the %s in %s: %v", endpoint, directory, err)
+	}
+}
+
+// refreshResources pulls each resource from the control plane at every interval. The cached data of a resource
+// is kept when it could not be pulled, except for the entries of the pages received before the failure.
+func refreshResources(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, resource := range resources {
+			if err := pullResource(resource); err != nil {
+				logger.LoggerSync.Warnf("Error refreshing the %s from the control plane, serving the cached data: %v",
+					resource.endpoint, err)
+				recordResourceSyncFailure(resource.endpoint)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistedResourcesAreServedAsStale(t *testing.T) {
+	directory := t.TempDir()
+	persistResource(directory, applicationsEndpoint, []byte(`{"list": [
+		{"uuid": "b-app", "name": "AppB", "tenanDomain": "carbon.super"},
+		{"uuid": "a-app", "name": "AppA", "tenanDomain": "carbon.super"}]}`))
+	loadPersistedResources(directory)
+
+	applications, state := GetApplications()
+	assert.Len(t, applications, 2)
+	assert.Equal(t, "a-app", applications[0].UUID, "applications should be sorted by UUID")
+	assert.True(t, state.Persisted)
+	assert.False(t, state.LastSync.IsZero(), "persisted time should be used as the last sync time")
+	assert.True(t, state.IsStale(0, time.Now()), "persisted data should be stale until it is pulled again")
+
+	recordResourceSyncFailure(applicationsEndpoint)
+	applications, state = GetApplications()
+	assert.Len(t, applications, 2, "cached data should be kept when the control plane is unreachable")
+	assert.True(t, state.Persisted)
+}
+
+func TestResourceSyncStateIsStale(t *testing.T) {
+	now := time.Now()
+	assert.True(t, ResourceSyncState{}.IsStale(time.Minute, now), "data never synced should be stale")
+	assert.False(t, ResourceSyncState{LastSync: now.Add(-30 * time.Second), Reachable: true}.IsStale(time.Minute, now))
+	assert.True(t, ResourceSyncState{LastSync: now.Add(-2 * time.Minute), Reachable: true}.IsStale(time.Minute, now))
+	assert.False(t, ResourceSyncState{LastSync: now.Add(-2 * time.Minute), Reachable: true}.IsStale(0, now),
+		"data should not go stale with time when staleAfter is not set")
+	assert.True(t, ResourceSyncState{LastSync: now, Reachable: false}.IsStale(time.Minute, now),
+		"data should be stale when the control plane is unreachable")
+}
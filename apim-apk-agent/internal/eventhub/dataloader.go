@@ -55,6 +55,10 @@ const (
 	APIUUIDParam string = "apiId"
 	// ApisEndpoint is the resource path of /apis endpoint
 	ApisEndpoint string = "apis"
+
+	subscriptionsEndpoint          string = "subscriptions"
+	applicationsEndpoint           string = "applications"
+	applicationKeyMappingsEndpoint string = "application-key-mappings"
 )
 
 const (
@@ -74,15 +78,15 @@ var (
 
 	resources = []resource{
 		{
-			endpoint:     "subscriptions",
+			endpoint:     subscriptionsEndpoint,
 			responseType: subList,
 		},
 		{
-			endpoint:     "applications",
+			endpoint:     applicationsEndpoint,
 			responseType: appList,
 		},
 		{
-			endpoint:     "application-key-mappings",
+			endpoint:     applicationKeyMappingsEndpoint,
 			responseType: appKeyMappingList,
 		},
 	}
@@ -115,43 +119,34 @@ func init() {
 func LoadInitialData(configFile *config.Config) {
 	conf = configFile
 	accessToken = pkgAuth.GetBasicAuth(configFile.ControlPlane.Username, configFile.ControlPlane.Password)
-	var responseChannel = make(chan response)
-	for _, url := range resources {
-		// Create a local copy of the loop variable
-		localURL := url
-
-		go InvokeService(localURL.endpoint, localURL.responseType, nil, responseChannel, 0)
-
+	// Serve the persisted data until the control plane is reachable
+	if conf.SyncStatus.DataCache.Directory != "" {
+		loadPersistedResources(conf.SyncStatus.DataCache.Directory)
+	}
+	for _, resource := range resources {
+		// Keep pulling the resource until it is received
 		for {
-			data := <-responseChannel
-			logger.LoggerSync.Info("Receiving subscription data for an environment")
-			if data.Payload != nil {
-				logger.LoggerSync.Info("Payload data information received")
-				retrieveDataFromResponseChannel(data)
+			err := pullResource(resource)
+			if err == nil {
 				break
-			} else if data.ErrorCode >= 400 && data.ErrorCode < 500 {
-				//Error handle
-				logger.LoggerSync.Info("Error data information received")
-				//health.SetControlPlaneRestAPIStatus(false)
-			} else {
-				// Keep the iteration going on until a response is received.
-				// Error handle
-				go func(d response, endpoint string, responseType interface{}) {
-					// Retry fetching from control plane after a configured time interval
-					if conf.ControlPlane.RetryInterval == 0 {
-						// Assign default retry interval
-						conf.ControlPlane.RetryInterval = 5
-					}
-					logger.LoggerSync.Debugf("Time Duration for retrying: %v", conf.ControlPlane.RetryInterval*time.Second)
-					time.Sleep(conf.ControlPlane.RetryInterval * time.Second)
-					logger.LoggerSync.Infof("Retrying to fetch APIs from control plane. Time Duration for the next retry: %v", conf.ControlPlane.RetryInterval*time.Second)
-					go InvokeService(endpoint, responseType, nil, responseChannel, 0)
-				}(data, localURL.endpoint, localURL.responseType)
 			}
+			recordResourceSyncFailure(resource.endpoint)
+			// Retry fetching from control plane after a configured time interval
+			if conf.ControlPlane.RetryInterval == 0 {
+				// Assign default retry interval
+				conf.ControlPlane.RetryInterval = 5
+			}
+			logger.LoggerSync.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error occurred while pulling the %s from the control plane: %v. Retrying in %v",
+					resource.endpoint, err, conf.ControlPlane.RetryInterval*time.Second),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1601,
+			})
+			time.Sleep(conf.ControlPlane.RetryInterval * time.Second)
 		}
 	}
 	// Take the configured labels from the adapter
-	configuredEnvs := conf.ControlPlane.EnvironmentLabels
+	configuredEnvs := GetEnvironmentLabels(conf)
 
 	// If no environments are configured, default gateway label value is assigned.
 	if len(configuredEnvs) == 0 {
@@ -198,6 +193,9 @@ func LoadInitialData(configFile *config.Config) {
 		}
 	}
 	FetchAPIsOnStartUp(conf, apiUUIDList)
+	if conf.SyncStatus.DataCache.RefreshInterval > 0 {
+		go refreshResources(conf.SyncStatus.DataCache.RefreshInterval * time.Second)
+	}
 }
 
 // InvokeService invokes the internal data resource
@@ -263,13 +261,16 @@ func InvokeService(endpoint string, responseType interface{}, queryParamMap map[
 	}
 }
 
-func retrieveDataFromResponseChannel(response response) {
+// retrieveDataFromResponseChannel updates the cached data of the resource in the response and returns false if
+// the response could not be read. It should be called while holding the cache write lock.
+func retrieveDataFromResponseChannel(response response) bool {
 	responseType := reflect.TypeOf(response.Type).Elem()
 	newResponse := reflect.New(responseType).Interface()
 	err := json.Unmarshal(response.Payload, &newResponse)
 
 	if err != nil {
 		loggers.Info("Error occurred while unmarshalling the response received for: "+response.Endpoint, err)
+		return false
 	} else {
 		switch t := newResponse.(type) {
 		case *types.SubscriptionList:
@@ -286,15 +287,17 @@ func retrieveDataFromResponseChannel(response response) {
 			MarshalMultipleApplicationKeyMappings(appKeyMappingList)
 		default:
 			logger.LoggerSubscription.Debugf("Unknown type %T", t)
+			return false
 		}
 	}
+	return true
 }
 
 // FetchAPIsOnStartUp APIs from control plane during the server start up and push them
 // to the router and enforcer components.
 func FetchAPIsOnStartUp(conf *config.Config, apiUUIDList []string) {
 	// Populate data from config.
-	envs := conf.ControlPlane.EnvironmentLabels
+	envs := GetEnvironmentLabels(conf)
 
 	// Create a channel for the byte slice (response from the APIs from control plane)
 	c := make(chan sync.SyncAPIResponse)
@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/auth"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/logging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/tlsutils"
+)
+
+const adminEnvironmentsEP string = "api/am/admin/v4/environments"
+
+// gatewayEnvironment is a gateway environment as listed by the Admin API
+type gatewayEnvironment struct {
+	Name        string `json:"name"`
+	GatewayType string `json:"gatewayType"`
+}
+
+// gatewayEnvironmentList is the payload of the Admin API environments endpoint
+type gatewayEnvironmentList struct {
+	List []gatewayEnvironment `json:"list"`
+}
+
+var (
+	environmentLabelsMutex sync.RWMutex
+	// retrievedEnvironmentLabels holds the gateway environments last retrieved from the Admin API
+	retrievedEnvironmentLabels []string
+)
+
+// GetEnvironmentLabels returns the gateway environments of the APIs served by the agent. These are the configured
+// environment labels along with the environments of the configured gateway type last retrieved from the Admin API
+// when the environment labels refresh is enabled.
+func GetEnvironmentLabels(conf *config.Config) []string {
+	environmentLabelsMutex.RLock()
+	defer environmentLabelsMutex.RUnlock()
+	return mergeEnvironmentLabels(conf.ControlPlane.EnvironmentLabels, retrievedEnvironmentLabels)
+}
+
+// mergeEnvironmentLabels returns the configured labels followed by the retrieved labels which are not configured
+func mergeEnvironmentLabels(configured, retrieved []string) []string {
+	labels := make([]string, 0, len(configured)+len(retrieved))
+	seen := make(map[string]bool, len(configured)+len(retrieved))
+	for _, label := range append(append([]string{}, configured...), retrieved...) {
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// LoadEnvironmentLabels retrieves the gateway environments from the Admin API, so that the APIs deployed in these
+// environments are fetched from the control plane on the start up
+func LoadEnvironmentLabels(conf *config.Config) {
+	if err := refreshEnvironmentLabels(conf, false); err != nil {
+		logEnvironmentRetrievalError(err)
+	}
+}
+
+// RefreshEnvironmentLabels retrieves the gateway environments from the Admin API at every interval, so that the
+// APIs deployed in a gateway environment added in the control plane are served without restarting the agent.
+// The APIs already deployed in a newly found environment are fetched from the control plane. The last retrieved
+// environments are kept when the Admin API cannot be reached.
+func RefreshEnvironmentLabels(conf *config.Config, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := refreshEnvironmentLabels(conf, true); err != nil {
+			logEnvironmentRetrievalError(err)
+		}
+	}
+}
+
+func logEnvironmentRetrievalError(err error) {
+	logger.LoggerSync.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("Error retrieving the gateway environments from the control plane, "+
+			"serving the last known environments: %v", err),
+		Severity:  logging.MINOR,
+		ErrorCode: 1603,
+	})
+}
+
+// refreshEnvironmentLabels replaces the retrieved gateway environments and fetches the APIs of the added
+// environments if fetchAddedAPIs is true
+func refreshEnvironmentLabels(conf *config.Config, fetchAddedAPIs bool) error {
+	cpConfigs := conf.ControlPlane
+	environments, err := getGatewayEnvironments(cpConfigs.ServiceURL, cpConfigs.Username, cpConfigs.Password,
+		cpConfigs.SkipSSLVerification)
+	if err != nil {
+		return err
+	}
+	previousLabels := GetEnvironmentLabels(conf)
+	environmentLabelsMutex.Lock()
+	retrievedEnvironmentLabels = filterEnvironmentLabels(environments, cpConfigs.EnvironmentLabelsRefresh.GatewayType)
+	environmentLabelsMutex.Unlock()
+
+	currentLabels := GetEnvironmentLabels(conf)
+	addedLabels := subtractEnvironmentLabels(currentLabels, previousLabels)
+	removedLabels := subtractEnvironmentLabels(previousLabels, currentLabels)
+	if len(addedLabels) == 0 && len(removedLabels) == 0 {
+		return nil
+	}
+	logger.LoggerSync.Infof("Gateway environments of the agent changed. Added: %v, removed: %v", addedLabels,
+		removedLabels)
+	if fetchAddedAPIs && len(addedLabels) > 0 {
+		fetchAPIsOfEnvironments(conf, addedLabels)
+	}
+	return nil
+}
+
+// getGatewayEnvironments retrieves the gateway environments from the Admin API
+func getGatewayEnvironments(serviceURL, username, password string, skipSSL bool) ([]gatewayEnvironment, error) {
+	environmentsEP := serviceURL
+	if !strings.HasSuffix(environmentsEP, "/") {
+		environmentsEP += "/"
+	}
+	environmentsEP += adminEnvironmentsEP
+
+	req, err := http.NewRequest(http.MethodGet, environmentsEP, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(authorizationHeaderDefault, authorizationBasic+auth.GetBasicAuth(username, password))
+	resp, err := tlsutils.InvokeControlPlane(req, skipSSL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, environmentsEP)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var environmentList gatewayEnvironmentList
+	if err := json.Unmarshal(body, &environmentList); err != nil {
+		return nil, err
+	}
+	return environmentList.List, nil
+}
+
+// filterEnvironmentLabels returns the names of the environments of the given gateway type
+func filterEnvironmentLabels(environments []gatewayEnvironment, gatewayType string) []string {
+	labels := make([]string, 0, len(environments))
+	for _, environment := range environments {
+		if strings.EqualFold(environment.GatewayType, gatewayType) && environment.Name != "" {
+			labels = append(labels, environment.Name)
+		}
+	}
+	return labels
+}
+
+// subtractEnvironmentLabels returns the labels which are not in excluded
+func subtractEnvironmentLabels(labels, excluded []string) []string {
+	excludedLabels := make(map[string]bool, len(excluded))
+	for _, label := range excluded {
+		excludedLabels[label] = true
+	}
+	var difference []string
+	for _, label := range labels {
+		if !excludedLabels[label] {
+			difference = append(difference, label)
+		}
+	}
+	return difference
+}
+
+// fetchAPIsOfEnvironments fetches the APIs deployed in the given environments from the control plane
+func fetchAPIsOfEnvironments(conf *config.Config, labels []string) {
+	var uuids []string
+	for _, label := range labels {
+		queryParamMap := map[string]string{
+			GatewayLabelParam: label,
+			organizationID:    commonOrganizationIDValue,
+		}
+		responseChannel := make(chan response, 1)
+		InvokeService(ApisEndpoint, apiList, queryParamMap, responseChannel, 0)
+		data := <-responseChannel
+		if data.Payload == nil {
+			logger.LoggerSync.Warnf("Error retrieving the APIs of the gateway environment %s: %v", label, data.Error)
+			continue
+		}
+		var apis types.APIList
+		if err := json.Unmarshal(data.Payload, &apis); err != nil {
+			logger.LoggerSync.Warnf("Error reading the APIs of the gateway environment %s: %v", label, err)
+			continue
+		}
+		for _, api := range apis.List {
+			uuids = append(uuids, api.UUID)
+		}
+	}
+	if len(uuids) > 0 {
+		logger.LoggerSync.Infof("Fetching the APIs of the added gateway environments: %v", uuids)
+		FetchAPIsOnStartUp(conf, uuids)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+)
+
+func TestRefreshEnvironmentLabels(t *testing.T) {
+	environments := `{"count": 3, "list": [
+		{"name": "Default", "gatewayType": "Regular"},
+		{"name": "apk-prod", "gatewayType": "APK"},
+		{"name": "apk-dev", "gatewayType": "APK"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+adminEnvironmentsEP, r.URL.Path)
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+		if environments == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(environments))
+	}))
+	defer server.Close()
+	defer func() { retrievedEnvironmentLabels = nil }()
+
+	conf := &config.Config{}
+	conf.ControlPlane.ServiceURL = server.URL
+	conf.ControlPlane.Username = "admin"
+	conf.ControlPlane.Password = "secret"
+	conf.ControlPlane.SkipSSLVerification = true
+	conf.ControlPlane.EnvironmentLabels = []string{"Default", "apk-prod"}
+	conf.ControlPlane.EnvironmentLabelsRefresh.GatewayType = "APK"
+	assert.Equal(t, []string{"Default", "apk-prod"}, GetEnvironmentLabels(conf))
+
+	assert.NoError(t, refreshEnvironmentLabels(conf, false))
+	assert.Equal(t, []string{"Default", "apk-prod", "apk-dev"}, GetEnvironmentLabels(conf),
+		"environments of the gateway type should be added to the configured labels")
+
+	environments = ""
+	assert.Error(t, refreshEnvironmentLabels(conf, false))
+	assert.Equal(t, []string{"Default", "apk-prod", "apk-dev"}, GetEnvironmentLabels(conf),
+		"last retrieved environments should be kept when the control plane is unreachable")
+
+	environments = `{"count": 0, "list": []}`
+	assert.NoError(t, refreshEnvironmentLabels(conf, false))
+	assert.Equal(t, []string{"Default", "apk-prod"}, GetEnvironmentLabels(conf),
+		"removed environments should no longer be served")
+}
+
+func TestSubtractEnvironmentLabels(t *testing.T) {
+	assert.Equal(t, []string{"apk-dev"}, subtractEnvironmentLabels([]string{"Default", "apk-dev"},
+		[]string{"Default"}))
+	assert.Nil(t, subtractEnvironmentLabels([]string{"Default"}, []string{"Default", "apk-dev"}))
+}
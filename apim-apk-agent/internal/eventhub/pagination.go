@@ -0,0 +1,309 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+)
+
+const (
+	// offsetParam is the query parameter of the index of the first entry of a page
+	offsetParam string = "offset"
+	// limitParam is the query parameter of the number of entries of a page
+	limitParam string = "limit"
+)
+
+// requestLimiter bounds the number of requests sent to the control plane in parallel and the rate at which they
+// are sent
+type requestLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+	mutex    sync.Mutex
+	next     time.Time
+}
+
+func newRequestLimiter(maxConcurrentRequests, requestsPerSecond int) *requestLimiter {
+	if maxConcurrentRequests <= 0 {
+		maxConcurrentRequests = 1
+	}
+	limiter := &requestLimiter{slots: make(chan struct{}, maxConcurrentRequests)}
+	if requestsPerSecond > 0 {
+		limiter.interval = time.Second / time.Duration(requestsPerSecond)
+	}
+	return limiter
+}
+
+// acquire blocks until a request can be sent without exceeding the limits
+func (limiter *requestLimiter) acquire() {
+	limiter.slots <- struct{}{}
+	if limiter.interval == 0 {
+		return
+	}
+	limiter.mutex.Lock()
+	now := time.Now()
+	if limiter.next.Before(now) {
+		limiter.next = now
+	}
+	wait := limiter.next.Sub(now)
+	limiter.next = limiter.next.Add(limiter.interval)
+	limiter.mutex.Unlock()
+	time.Sleep(wait)
+}
+
+// release frees the slot of a completed request
+func (limiter *requestLimiter) release() {
+	<-limiter.slots
+}
+
+// resourcePull holds the state of a resource pulled from the control plane in pages. The entries of a page are
+// added to the cache as soon as the page is received, and the cached entries which were not received are removed
+// once all the pages are pulled, unless a page repeated the entries of another page.
+type resourcePull struct {
+	resource resource
+	mutex    sync.Mutex
+	pages    map[int][]json.RawMessage
+	keys     map[string]bool
+	// repeatedEntries is set when a page has entries already received in another page, in which case the page
+	// boundaries of the control plane cannot be trusted to find the entries which were removed
+	repeatedEntries bool
+}
+
+// pullResource pulls the resource from the control plane in pages of controlPlane.dataPull.pageSize entries. The
+// first page is pulled alone to find out whether the control plane has more entries, and the rest of the pages are
+// pulled in batches of parallel requests until a page is not full or repeats the entries of another page. The
+// resource is pulled in a single request when the page size is 0, or when the control plane does not paginate the
+// resource.
+func pullResource(res resource) error {
+	dataPull := conf.ControlPlane.DataPull
+	pull := &resourcePull{
+		resource: res,
+		pages:    make(map[int][]json.RawMessage),
+		keys:     make(map[string]bool),
+	}
+	limiter := newRequestLimiter(dataPull.MaxConcurrentRequests, dataPull.RequestsPerSecond)
+
+	count, err := pull.pullPage(limiter, 0, dataPull.PageSize)
+	if err != nil {
+		return err
+	}
+	hasMorePages := dataPull.PageSize > 0 && count == dataPull.PageSize
+	if dataPull.PageSize > 0 && count > dataPull.PageSize {
+		logger.LoggerSync.Debugf("The control plane did not paginate the %s, received %d entries at once",
+			res.endpoint, count)
+	}
+	batchSize := dataPull.MaxConcurrentRequests
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	for page := 1; hasMorePages; page += batchSize {
+		counts := make([]int, batchSize)
+		errs := make([]error, batchSize)
+		var wg sync.WaitGroup
+		for i := 0; i < batchSize; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				counts[i], errs[i] = pull.pullPage(limiter, page+i, dataPull.PageSize)
+			}(i)
+		}
+		wg.Wait()
+		for i := 0; i < batchSize; i++ {
+			if errs[i] != nil {
+				return errs[i]
+			}
+			if counts[i] < dataPull.PageSize {
+				hasMorePages = false
+			}
+		}
+		// A control plane ignoring the offset returns the same entries for every page
+		if pull.repeatedEntries {
+			logger.LoggerSync.Warnf("The control plane returned the same %s in more than one page, the pull of "+
+				"the resource is stopped", res.endpoint)
+			hasMorePages = false
+		}
+	}
+	pull.complete()
+	return nil
+}
+
+// pullPage pulls a page of the resource and adds its entries to the cache. It returns the number of entries in
+// the page.
+func (pull *resourcePull) pullPage(limiter *requestLimiter, page, pageSize int) (int, error) {
+	var queryParamMap map[string]string
+	if pageSize > 0 {
+		queryParamMap = map[string]string{
+			offsetParam: strconv.Itoa(page * pageSize),
+			limitParam:  strconv.Itoa(pageSize),
+		}
+	}
+	responseChannel := make(chan response, 1)
+	limiter.acquire()
+	InvokeService(pull.resource.endpoint, pull.resource.responseType, queryParamMap, responseChannel, 0)
+	limiter.release()
+	data := <-responseChannel
+	if data.Payload == nil {
+		return 0, fmt.Errorf("error pulling page %d of the %s (status code: %d): %v", page, pull.resource.endpoint,
+			data.ErrorCode, data.Error)
+	}
+	return pull.addPage(page, data.Payload)
+}
+
+// addPage adds the entries of the page to the cache and returns the number of entries in the page
+func (pull *resourcePull) addPage(page int, payload []byte) (int, error) {
+	var list struct {
+		List []json.RawMessage `json:"list"`
+	}
+	if err := json.Unmarshal(payload, &list); err != nil {
+		return 0, fmt.Errorf("error reading page %d of the %s: %v", page, pull.resource.endpoint, err)
+	}
+	cacheMutex.Lock()
+	keys, err := addToCache(pull.resource.responseType, payload)
+	cacheMutex.Unlock()
+	if err != nil {
+		return 0, fmt.Errorf("error reading page %d of the %s: %v", page, pull.resource.endpoint, err)
+	}
+
+	pull.mutex.Lock()
+	defer pull.mutex.Unlock()
+	newEntries := false
+	for _, key := range keys {
+		if pull.keys[key] {
+			pull.repeatedEntries = true
+			continue
+		}
+		pull.keys[key] = true
+		newEntries = true
+	}
+	// A page with only the entries of other pages is not persisted again
+	if newEntries {
+		pull.pages[page] = list.List
+	}
+	logger.LoggerSync.Debugf("Received %d %s in page %d", len(list.List), pull.resource.endpoint, page)
+	return len(list.List), nil
+}
+
+// complete removes the cached entries which were not pulled, updates the sync state of the resource and persists
+// the pulled entries when a cache directory is configured. The cached entries are kept if a page repeated the
+// entries of another page, as the entries which were not pulled may still exist in the control plane.
+func (pull *resourcePull) complete() {
+	endpoint := pull.resource.endpoint
+	cacheMutex.Lock()
+	if !pull.repeatedEntries {
+		removeFromCache(pull.resource.responseType, pull.keys)
+	}
+	resourceSyncStates[endpoint] = ResourceSyncState{LastSync: time.Now().UTC(), Reachable: true}
+	cacheMutex.Unlock()
+	logger.LoggerSync.Infof("Pulled %d %s from the control plane in %d page(s)", len(pull.keys), endpoint,
+		len(pull.pages))
+
+	if conf.SyncStatus.DataCache.Directory == "" {
+		return
+	}
+	pages := make([]int, 0, len(pull.pages))
+	for page := range pull.pages {
+		pages = append(pages, page)
+	}
+	sort.Ints(pages)
+	var list struct {
+		List []json.RawMessage `json:"list"`
+	}
+	list.List = []json.RawMessage{}
+	for _, page := range pages {
+		list.List = append(list.List, pull.pages[page]...)
+	}
+	payload, err := json.Marshal(list)
+	if err != nil {
+		logger.LoggerSync.Warnf("Error persisting the %s: %v", endpoint, err)
+		return
+	}
+	persistResource(conf.SyncStatus.DataCache.Directory, endpoint, payload)
+}
+
+// addToCache adds or replaces the entries of the payload in the cache and returns the keys of the entries. It
+// should be called while holding the cache write lock.
+func addToCache(responseType interface{}, payload []byte) ([]string, error) {
+	newResponse := reflect.New(reflect.TypeOf(responseType).Elem()).Interface()
+	if err := json.Unmarshal(payload, newResponse); err != nil {
+		return nil, err
+	}
+	var keys []string
+	switch list := newResponse.(type) {
+	case *types.SubscriptionList:
+		if SubscriptionMap == nil {
+			SubscriptionMap = make(map[int32]Subscription)
+		}
+		for i := range list.List {
+			SubscriptionMap[list.List[i].SubscriptionID] = MarshalSubscription(&list.List[i])
+			keys = append(keys, strconv.Itoa(int(list.List[i].SubscriptionID)))
+		}
+	case *types.ApplicationList:
+		if ApplicationMap == nil {
+			ApplicationMap = make(map[string]Application)
+		}
+		for i := range list.List {
+			ApplicationMap[list.List[i].UUID] = MarshalApplication(&list.List[i])
+			keys = append(keys, list.List[i].UUID)
+		}
+	case *types.ApplicationKeyMappingList:
+		if ApplicationKeyMappingMap == nil {
+			ApplicationKeyMappingMap = make(map[string]ApplicationKeyMapping)
+		}
+		for i := range list.List {
+			reference := GetApplicationKeyMappingReference(&list.List[i])
+			ApplicationKeyMappingMap[reference] = marshalKeyMapping(&list.List[i])
+			keys = append(keys, reference)
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %T", list)
+	}
+	return keys, nil
+}
+
+// removeFromCache removes the cached entries of the resource type whose keys are not in keys. It should be called
+// while holding the cache write lock.
+func removeFromCache(responseType interface{}, keys map[string]bool) {
+	switch responseType.(type) {
+	case *types.SubscriptionList:
+		for id := range SubscriptionMap {
+			if !keys[strconv.Itoa(int(id))] {
+				delete(SubscriptionMap, id)
+			}
+		}
+	case *types.ApplicationList:
+		for uuid := range ApplicationMap {
+			if !keys[uuid] {
+				delete(ApplicationMap, uuid)
+			}
+		}
+	case *types.ApplicationKeyMappingList:
+		for reference := range ApplicationKeyMappingMap {
+			if !keys[reference] {
+				delete(ApplicationKeyMappingMap, reference)
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventhub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+)
+
+// newApplicationsServer serves the applications in pages. The offset and limit are ignored if paginate is false.
+func newApplicationsServer(t *testing.T, total int, paginate bool, failOffset int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+internalWebAppEP+applicationsEndpoint, r.URL.Path)
+		atomic.AddInt32(&requests, 1)
+		offset, limit := 0, total
+		if paginate && r.URL.Query().Get(limitParam) != "" {
+			offset, _ = strconv.Atoi(r.URL.Query().Get(offsetParam))
+			limit, _ = strconv.Atoi(r.URL.Query().Get(limitParam))
+		}
+		if offset == failOffset {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		list := types.ApplicationList{List: []types.Application{}}
+		for i := offset; i < total && i < offset+limit; i++ {
+			list.List = append(list.List, types.Application{UUID: fmt.Sprintf("app-%02d", i),
+				TenantDomain: "carbon.super"})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	return server, &requests
+}
+
+func setPullConfig(t *testing.T, serviceURL string, pageSize int) {
+	previousConf := conf
+	t.Cleanup(func() {
+		conf = previousConf
+		cacheMutex.Lock()
+		ApplicationMap = nil
+		delete(resourceSyncStates, applicationsEndpoint)
+		cacheMutex.Unlock()
+	})
+	conf = &config.Config{}
+	conf.ControlPlane.ServiceURL = serviceURL + "/"
+	conf.ControlPlane.SkipSSLVerification = true
+	conf.ControlPlane.DataPull.PageSize = pageSize
+	conf.ControlPlane.DataPull.MaxConcurrentRequests = 2
+}
+
+func TestPullResourceInPages(t *testing.T) {
+	server, requests := newApplicationsServer(t, 5, true, -1)
+	defer server.Close()
+	setPullConfig(t, server.URL, 2)
+	UpdateApplication(&types.Application{UUID: "removed-app", TenantDomain: "carbon.super"})
+
+	assert.NoError(t, pullResource(resource{endpoint: applicationsEndpoint, responseType: appList}))
+	applications, state := GetApplications()
+	assert.Len(t, applications, 5)
+	assert.Equal(t, "app-00", applications[0].UUID)
+	assert.Equal(t, "app-04", applications[4].UUID, "applications not pulled should be removed")
+	assert.True(t, state.Reachable)
+	// The first page is pulled alone and the rest in batches of 2 until a page is not full
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestPullResourceWithoutPagination(t *testing.T) {
+	server, requests := newApplicationsServer(t, 5, false, -1)
+	defer server.Close()
+	setPullConfig(t, server.URL, 2)
+
+	assert.NoError(t, pullResource(resource{endpoint: applicationsEndpoint, responseType: appList}))
+	applications, _ := GetApplications()
+	assert.Len(t, applications, 5)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests),
+		"resource should be pulled at once when the control plane does not paginate it")
+
+	conf.ControlPlane.DataPull.PageSize = 0
+	assert.NoError(t, pullResource(resource{endpoint: applicationsEndpoint, responseType: appList}))
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestPullResourceIgnoringPageBoundaries(t *testing.T) {
+	// The control plane ignores the offset and limit, and has as many entries as the page size
+	server, requests := newApplicationsServer(t, 2, false, -1)
+	defer server.Close()
+	setPullConfig(t, server.URL, 2)
+	UpdateApplication(&types.Application{UUID: "cached-app", TenantDomain: "carbon.super"})
+
+	assert.NoError(t, pullResource(resource{endpoint: applicationsEndpoint, responseType: appList}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests), "pull should stop when a page repeats the entries")
+	applications, state := GetApplications()
+	assert.Len(t, applications, 3, "cached entries should be kept when the page boundaries cannot be trusted")
+	assert.True(t, state.Reachable)
+}
+
+func TestPullResourcePageFailure(t *testing.T) {
+	server, _ := newApplicationsServer(t, 5, true, 4)
+	defer server.Close()
+	setPullConfig(t, server.URL, 2)
+	UpdateApplication(&types.Application{UUID: "cached-app", TenantDomain: "carbon.super"})
+
+	err := pullResource(resource{endpoint: applicationsEndpoint, responseType: appList})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "page 2 of the applications")
+	}
+	applications, state := GetApplications()
+	assert.Len(t, applications, 5, "cached entries should be kept along with the entries of the pulled pages")
+	assert.True(t, state.LastSync.IsZero(), "sync state should not be updated when a page could not be pulled")
+}
+
+func TestRequestLimiter(t *testing.T) {
+	limiter := newRequestLimiter(2, 0)
+	var active, maxActive int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.acquire()
+			defer limiter.release()
+			current := atomic.AddInt32(&active, 1)
+			for {
+				previous := atomic.LoadInt32(&maxActive)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxActive, previous, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxActive), "requests should not exceed the concurrency limit")
+
+	limiter = newRequestLimiter(4, 50)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.acquire()
+		limiter.release()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond, "requests should not exceed the rate limit")
+}
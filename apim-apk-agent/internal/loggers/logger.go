@@ -47,6 +47,8 @@ const (
 	pkgGA                   = "github.com/wso2/apk/adapter/internal/ga"
 	pkgNotifier             = "github.com/wso2/apk/adapter/internal/notifier"
 	pkgSourceWatcher        = "github.com/wso2/apk/adapter/internal/sourcewatcher"
+	pkgSyncStatus           = "github.com/wso2/apk/adapter/internal/syncstatus"
+	pkgProfiling            = "github.com/wso2/apk/adapter/internal/profiling"
 )
 
 // logger package references
@@ -66,6 +68,8 @@ var (
 	LoggerGA                   logging.Log
 	LoggerNotifier             logging.Log
 	LoggerSourceWatcher        logging.Log
+	LoggerSyncStatus           logging.Log
+	LoggerProfiling            logging.Log
 )
 
 func init() {
@@ -90,5 +94,7 @@ func UpdateLoggers() {
 	LoggerGA = logging.InitPackageLogger(pkgGA)
 	LoggerNotifier = logging.InitPackageLogger(pkgNotifier)
 	LoggerSourceWatcher = logging.InitPackageLogger(pkgSourceWatcher)
+	LoggerSyncStatus = logging.InitPackageLogger(pkgSyncStatus)
+	LoggerProfiling = logging.InitPackageLogger(pkgProfiling)
 	logrus.Info("Updated loggers")
 }
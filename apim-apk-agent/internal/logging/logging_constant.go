@@ -40,6 +40,10 @@ const (
 	Error1103 = 1103
 	Error1104 = 1104
 	Error1105 = 1105
+	Error1107 = 1107
+	Error1108 = 1108
+	Error1109 = 1109
+	Error1110 = 1110
 )
 
 // Error Log Internal discovery(1400-1499) Config Constants
@@ -161,6 +165,22 @@ var Mapper = map[int]logging.ErrorDetails{
 		ErrorCode: Error1105,
 		Message:   "Error serving Rate Limiter xDS gRPC server.",
 	},
+	Error1107: {
+		ErrorCode: Error1107,
+		Message:   "Error serving the API sync status server.",
+	},
+	Error1108: {
+		ErrorCode: Error1108,
+		Message:   "Error updating the sync status of the API custom resource.",
+	},
+	Error1109: {
+		ErrorCode: Error1109,
+		Message:   "Error initiating the event hub connection.",
+	},
+	Error1110: {
+		ErrorCode: Error1110,
+		Message:   "Error serving the profiling server.",
+	},
 	Error1400: {
 		ErrorCode: Error1400,
 		Message:   "Error in Stream request type.",
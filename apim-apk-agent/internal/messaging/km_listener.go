@@ -126,7 +126,7 @@ func handleKMConfiguration() {
 				}
 			}
 		}
-		d.Ack(false)
+		d.Ack()
 	}
 	logger.LoggerInternalMsg.Info("handle: deliveries channel closed")
 }
@@ -19,13 +19,83 @@
 package messaging
 
 import (
+	"strings"
+	"time"
+
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
 	msg "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/messaging"
 )
 
 // ProcessEvents to pass event consumption
-func ProcessEvents(config *config.Config) {
-	msg.InitiateJMSConnection(config.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints)
+func ProcessEvents(conf *config.Config) {
+	brokerConnectionParameters := conf.ControlPlane.BrokerConnectionParameters
+	reconnectParameters := msg.ReconnectParameters{
+		InitialInterval: brokerConnectionParameters.ReconnectInterval * time.Millisecond,
+		MaxInterval:     brokerConnectionParameters.MaxReconnectInterval * time.Millisecond,
+		Jitter:          brokerConnectionParameters.ReconnectJitter,
+		RetryCount:      brokerConnectionParameters.ReconnectRetryCount,
+	}
+	var eventSource msg.EventSource
+	if strings.EqualFold(brokerConnectionParameters.BrokerType, config.KafkaBrokerType) {
+		kafka := brokerConnectionParameters.Kafka
+		eventSource = msg.NewKafkaEventSource(msg.KafkaParameters{
+			Brokers:       kafka.Brokers,
+			ConsumerGroup: kafka.ConsumerGroup,
+			Topics: msg.KafkaTopics{
+				Notification:    kafka.Topics.Notification,
+				KeyManager:      kafka.Topics.KeyManager,
+				TokenRevocation: kafka.Topics.TokenRevocation,
+				ThrottleData:    kafka.Topics.ThrottleData,
+			},
+			TLS: msg.TLSParameters{
+				Enabled:          kafka.TLS.Enabled,
+				CACertFile:       kafka.TLS.CACertFile,
+				CertFile:         kafka.TLS.CertFile,
+				KeyFile:          kafka.TLS.KeyFile,
+				SkipVerification: kafka.TLS.SkipVerification,
+			},
+			SASL: msg.KafkaSASLParameters{
+				Mechanism: kafka.SASL.Mechanism,
+				Username:  kafka.SASL.Username,
+				Password:  kafka.SASL.Password,
+			},
+		}, reconnectParameters)
+	} else if strings.EqualFold(brokerConnectionParameters.BrokerType, config.NATSBrokerType) {
+		nats := brokerConnectionParameters.Nats
+		eventSource = msg.NewNATSEventSource(msg.NATSParameters{
+			Servers: nats.Servers,
+			Stream:  nats.Stream,
+			Durable: nats.Durable,
+			Subjects: msg.NATSSubjects{
+				Notification:    nats.Subjects.Notification,
+				KeyManager:      nats.Subjects.KeyManager,
+				TokenRevocation: nats.Subjects.TokenRevocation,
+				ThrottleData:    nats.Subjects.ThrottleData,
+			},
+			AckWait:    nats.AckWait * time.Second,
+			MaxDeliver: nats.MaxDeliver,
+			Username:   nats.Username,
+			Password:   nats.Password,
+			Token:      nats.Token,
+			TLS: msg.TLSParameters{
+				Enabled:          nats.TLS.Enabled,
+				CACertFile:       nats.TLS.CACertFile,
+				CertFile:         nats.TLS.CertFile,
+				KeyFile:          nats.TLS.KeyFile,
+				SkipVerification: nats.TLS.SkipVerification,
+			},
+		}, reconnectParameters)
+	} else {
+		eventSource = msg.NewJMSEventSource(brokerConnectionParameters.EventListeningEndpoints, reconnectParameters)
+	}
+	err := msg.StartEventSource(eventSource)
+	if err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.PrintError(logging.Error1109, logging.CRITICAL,
+			"Error while initiating the event hub connection, error: %v", err.Error()))
+		return
+	}
 
 	go handleNotification()
 	go handleKMConfiguration()
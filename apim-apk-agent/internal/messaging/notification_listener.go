@@ -26,7 +26,9 @@ import (
 
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
 	eventhubInternal "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/notifier"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/synchronizer"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/syncstatus"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/logging"
@@ -83,7 +85,7 @@ func handleNotification() {
 		if err != nil {
 			continue
 		}
-		d.Ack(false)
+		d.Ack()
 	}
 	logger.LoggerMsg.Infof("handle: deliveries channel closed")
 }
@@ -184,7 +186,16 @@ func handleAPIEvents(data []byte, eventType string) {
 
 	//Per each revision, synchronization should happen.
 	if strings.EqualFold(deployAPIToGateway, apiEvent.Event.Type) {
-		go synchronizer.FetchAPIsFromControlPlane(apiEvent.UUID, apiEvent.GatewayLabels)
+		syncstatus.RecordSyncAttempt(apiEvent.UUID, apiEvent.APIName, apiEvent.APIVersion, apiEvent.TenantDomain)
+		go func() {
+			err := synchronizer.FetchAPIsFromControlPlane(apiEvent.UUID, apiEvent.GatewayLabels)
+			notifier.NotifyAPISync(notifier.APIImportOperation, apiEvent.UUID, apiEvent.APIName, apiEvent.APIVersion,
+				apiEvent.TenantDomain, err)
+			if err == nil {
+				notifier.RegisterAPIWithAnalytics(apiEvent.UUID, apiEvent.APIName, apiEvent.APIVersion,
+					apiEvent.APIContext, apiEvent.APIProvider, apiEvent.TenantDomain, apiEvent.GatewayLabels)
+			}
+		}()
 	}
 
 	for _, env := range apiEvent.GatewayLabels {
@@ -194,6 +205,13 @@ func handleAPIEvents(data []byte, eventType string) {
 		// removeFromGateway event with multiple labels could only appear when the API is subjected
 		// to delete. Hence we could simply delete after checking against just one iteration.
 		if strings.EqualFold(removeAPIFromGateway, apiEvent.Event.Type) {
+			for _, label := range apiEvent.GatewayLabels {
+				syncstatus.RecordUndeploy(apiEvent.UUID, label, "")
+			}
+			// The agent only records the undeploy, as the API is not removed from the gateway by the agent in this
+			// version. Recording cannot fail, hence only the success is notified.
+			notifier.NotifyAPISync(notifier.RevisionUndeployOperation, apiEvent.UUID, apiEvent.APIName,
+				apiEvent.APIVersion, apiEvent.TenantDomain, nil)
 			// xds.DeleteAPIWithAPIMEvent(apiEvent.UUID, apiEvent.TenantDomain, apiEvent.GatewayLabels, "")
 			// for _, env := range apiEvent.GatewayLabels {
 			// 	xdsAPIList := xds.DeleteAPIAndReturnList(apiEvent.UUID, apiEvent.TenantDomain, env)
@@ -205,7 +223,7 @@ func handleAPIEvents(data []byte, eventType string) {
 		}
 		if strings.EqualFold(deployAPIToGateway, apiEvent.Event.Type) {
 			conf, _ := config.ReadConfigs()
-			configuredEnvs := conf.ControlPlane.EnvironmentLabels
+			configuredEnvs := eventhubInternal.GetEnvironmentLabels(conf)
 			if len(configuredEnvs) == 0 {
 				configuredEnvs = append(configuredEnvs, config.DefaultGatewayName)
 			}
@@ -247,7 +265,7 @@ func handleLifeCycleEvents(data []byte) {
 	logger.LoggerMsg.Infof("API event data %v", apiEventObj)
 
 	conf, _ := config.ReadConfigs()
-	configuredEnvs := conf.ControlPlane.EnvironmentLabels
+	configuredEnvs := eventhubInternal.GetEnvironmentLabels(conf)
 	logger.LoggerMsg.Debugf("%s : %s API life cycle state change event triggered", apiEvent.APIName, apiEvent.APIVersion)
 	if len(configuredEnvs) == 0 {
 		configuredEnvs = append(configuredEnvs, config.DefaultGatewayName)
@@ -319,12 +337,15 @@ func handleApplicationEvents(data []byte, eventType string) {
 
 		logger.LoggerMsg.Infof("Application event data %v", app)
 
-		eventhubInternal.ApplicationMap[app.Name] = eventhubInternal.MarshalApplication(&app)
-		logger.LoggerMsg.Infof("JMS Event Application Map: %v", eventhubInternal.ApplicationMap)
-
+		// An event older than the last event of the application is not applied to the cache
 		if isLaterEvent(applicationListTimeStampMap, fmt.Sprint(applicationEvent.ApplicationID), applicationEvent.TimeStamp) {
 			return
 		}
+		if strings.EqualFold(applicationDelete, eventType) {
+			eventhubInternal.RemoveApplication(app.UUID)
+		} else {
+			eventhubInternal.UpdateApplication(&app)
+		}
 
 		// var appList *subscription.ApplicationList
 		// if applicationEvent.Event.Type == applicationCreate {
@@ -363,12 +384,15 @@ func handleSubscriptionEvents(data []byte, eventType string) {
 
 	logger.LoggerMsg.Infof("Subscription event data %v", sub)
 
-	eventhubInternal.SubscriptionMap[sub.SubscriptionID] = eventhubInternal.MarshalSubscription(&sub)
-	logger.LoggerMsg.Infof("JMS Event Subscription Map: %v", eventhubInternal.SubscriptionMap)
-
+	// An event older than the last event of the subscription is not applied to the cache
 	if isLaterEvent(subsriptionsListTimeStampMap, fmt.Sprint(subscriptionEvent.SubscriptionID), subscriptionEvent.TimeStamp) {
 		return
 	}
+	if strings.EqualFold(subscriptionDelete, eventType) {
+		eventhubInternal.RemoveSubscription(sub.SubscriptionID)
+	} else {
+		eventhubInternal.UpdateSubscription(&sub)
+	}
 	// var subList *subscription.SubscriptionList
 	// if subscriptionEvent.Event.Type == subscriptionCreate {
 	// 	subList = xds.MarshalSubscriptionEventAndReturnList(&sub, xds.CreateEvent)
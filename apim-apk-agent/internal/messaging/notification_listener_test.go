@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	eventhubInternal "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+)
+
+func findCachedApplication(uuid string) *eventhubInternal.Application {
+	applications, _ := eventhubInternal.GetApplications()
+	for i := range applications {
+		if applications[i].UUID == uuid {
+			return &applications[i]
+		}
+	}
+	return nil
+}
+
+func findCachedSubscription(subscriptionID int32) *eventhubInternal.Subscription {
+	subscriptions, _ := eventhubInternal.GetSubscriptions()
+	for i := range subscriptions {
+		if subscriptions[i].SubscriptionID == subscriptionID {
+			return &subscriptions[i]
+		}
+	}
+	return nil
+}
+
+func TestHandleApplicationEvents(t *testing.T) {
+	handleApplicationEvents([]byte(`{"uuid": "app-1", "applicationId": 101, "applicationName": "App1",
+		"timeStamp": 2000, "tenantDomain": "carbon.super"}`), applicationCreate)
+	application := findCachedApplication("app-1")
+	if assert.NotNil(t, application) {
+		assert.Equal(t, "App1", application.Name)
+	}
+
+	handleApplicationEvents([]byte(`{"uuid": "app-1", "applicationId": 101, "applicationName": "Outdated",
+		"timeStamp": 1000, "tenantDomain": "carbon.super"}`), applicationUpdate)
+	application = findCachedApplication("app-1")
+	if assert.NotNil(t, application) {
+		assert.Equal(t, "App1", application.Name, "An event older than the last event should not be applied")
+	}
+
+	handleApplicationEvents([]byte(`{"uuid": "app-1", "applicationId": 101, "applicationName": "App1",
+		"timeStamp": 3000, "tenantDomain": "carbon.super"}`), applicationDelete)
+	assert.Nil(t, findCachedApplication("app-1"), "A deleted application should be removed from the cache")
+}
+
+func TestHandleSubscriptionEvents(t *testing.T) {
+	handleSubscriptionEvents([]byte(`{"subscriptionId": 201, "apiUUID": "api-1", "applicationUUID": "app-2",
+		"subscriptionState": "UNBLOCKED", "timeStamp": 2000, "tenantDomain": "carbon.super"}`), subscriptionCreate)
+	subscription := findCachedSubscription(201)
+	if assert.NotNil(t, subscription) {
+		assert.Equal(t, "UNBLOCKED", subscription.SubscriptionState)
+	}
+
+	handleSubscriptionEvents([]byte(`{"subscriptionId": 201, "apiUUID": "api-1", "applicationUUID": "app-2",
+		"subscriptionState": "BLOCKED", "timeStamp": 1000, "tenantDomain": "carbon.super"}`), subscriptionUpdate)
+	subscription = findCachedSubscription(201)
+	if assert.NotNil(t, subscription) {
+		assert.Equal(t, "UNBLOCKED", subscription.SubscriptionState,
+			"An event older than the last event should not be applied")
+	}
+
+	handleSubscriptionEvents([]byte(`{"subscriptionId": 201, "apiUUID": "api-1", "applicationUUID": "app-2",
+		"subscriptionState": "UNBLOCKED", "timeStamp": 3000, "tenantDomain": "carbon.super"}`), subscriptionDelete)
+	assert.Nil(t, findCachedSubscription(201), "A deleted subscription should be removed from the cache")
+}
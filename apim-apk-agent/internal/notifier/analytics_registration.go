@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wso2/apk/adapter/pkg/logging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/tlsutils"
+)
+
+const (
+	analyticsAuthTokenProperty string = "authToken"
+	apkAPIOrigin               string = "APK"
+	originAnalyticsProperty    string = "origin"
+)
+
+// AnalyticsAPIRegistration holds the details of a synced API which are registered with the analytics endpoint
+type AnalyticsAPIRegistration struct {
+	APIUUID      string            `json:"apiUUID"`
+	APIName      string            `json:"apiName"`
+	APIVersion   string            `json:"apiVersion"`
+	APIContext   string            `json:"apiContext"`
+	APIProvider  string            `json:"apiProvider"`
+	Organization string            `json:"organization"`
+	Environments []string          `json:"environments"`
+	Properties   map[string]string `json:"properties"`
+	Timestamp    string            `json:"timestamp"`
+}
+
+// RegisterAPIWithAnalytics registers a successfully synced API with the configured analytics endpoint, so that
+// the metrics of the API are available in the analytics dashboards. Nothing is done unless both analytics and
+// the analytics API registration are enabled.
+func RegisterAPIWithAnalytics(apiUUID, apiName, apiVersion, apiContext, apiProvider, organization string,
+	environments []string) {
+	conf, _ := config.ReadConfigs()
+	if !conf.Analytics.Enabled || !conf.Analytics.APIRegistration.Enabled {
+		return
+	}
+	// The configured properties are copied so that the origin of the API can be added without altering the config
+	properties := map[string]string{originAnalyticsProperty: apkAPIOrigin}
+	for key, value := range conf.Analytics.APIRegistration.Properties {
+		properties[key] = value
+	}
+	registration := AnalyticsAPIRegistration{
+		APIUUID:      apiUUID,
+		APIName:      apiName,
+		APIVersion:   apiVersion,
+		APIContext:   apiContext,
+		APIProvider:  apiProvider,
+		Organization: organization,
+		Environments: environments,
+		Properties:   properties,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	}
+	go sendAnalyticsAPIRegistration(conf.Analytics.APIRegistration.Endpoint,
+		conf.Analytics.Enforcer.ConfigProperties[analyticsAuthTokenProperty],
+		conf.Analytics.APIRegistration.SkipSSLVerification, registration)
+}
+
+func sendAnalyticsAPIRegistration(endpoint, authToken string, skipSSLVerification bool,
+	registration AnalyticsAPIRegistration) {
+	payload, err := json.Marshal(registration)
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error marshalling the analytics registration of API %s : %v", registration.APIUUID,
+				err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2104,
+		})
+		return
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error creating the analytics registration request of API %s : %v",
+				registration.APIUUID, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2104,
+		})
+		return
+	}
+	req.Header.Set(contentTypeHeader, "application/json")
+	if authToken != "" {
+		req.Header.Set(authHeader, authBearer+authToken)
+	}
+
+	resp, err := tlsutils.InvokeControlPlane(req, skipSSLVerification)
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error registering API %s with the analytics endpoint : %v", registration.APIUUID,
+				err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2105,
+		})
+		return
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error response status code %v when registering API %s with the analytics endpoint",
+				resp.StatusCode, registration.APIUUID),
+			Severity:  logging.MINOR,
+			ErrorCode: 2105,
+		})
+		return
+	}
+	logger.LoggerNotifier.Debugf("API %s:%s (%s) registered with the analytics endpoint", registration.APIName,
+		registration.APIVersion, registration.APIUUID)
+}
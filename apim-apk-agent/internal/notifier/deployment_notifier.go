@@ -36,23 +36,32 @@ const (
 	deployedRevisionEP   string = "internal/data/v1/apis/deployed-revisions"
 	unDeployedRevisionEP string = "internal/data/v1/apis/undeployed-revision"
 	authBasic            string = "Basic "
+	authBearer           string = "Bearer "
 	authHeader           string = "Authorization"
 	contentTypeHeader    string = "Content-Type"
 )
 
-// UpdateDeployedRevisions create the DeployedAPIRevision object
-func UpdateDeployedRevisions(apiID string, revisionID int, envs []string, vhost string) *DeployedAPIRevision {
+// UpdateDeployedRevisions create the DeployedAPIRevision object with an entry for each vhost the API is deployed
+// to in each gateway environment. The default vhost of the environment is used if no vhost is given for it.
+func UpdateDeployedRevisions(apiID string, revisionID int, envs []string, vhosts map[string][]string) *DeployedAPIRevision {
 	revisions := &DeployedAPIRevision{
 		APIID:      apiID,
 		RevisionID: revisionID,
 		EnvInfo:    []DeployedEnvInfo{},
 	}
 	for _, env := range envs {
-		info := DeployedEnvInfo{
-			Name:  env,
-			VHost: vhost,
+		envVhosts := vhosts[env]
+		if len(envVhosts) == 0 {
+			defaultVhost, _, _ := config.GetDefaultVhost(env)
+			envVhosts = []string{defaultVhost}
+		}
+		for _, vhost := range envVhosts {
+			info := DeployedEnvInfo{
+				Name:  env,
+				VHost: vhost,
+			}
+			revisions.EnvInfo = append(revisions.EnvInfo, info)
 		}
-		revisions.EnvInfo = append(revisions.EnvInfo, info)
 	}
 	return revisions
 }
@@ -116,8 +125,9 @@ func SendRevisionUpdateAck(deployedRevisionList []*DeployedAPIRevision) {
 	}
 }
 
-// SendRevisionUndeployAck - send the undeployed revision acknowledgement to control plane
-func SendRevisionUndeployAck(apiUUID string, revisionUUID string, environment string) {
+// SendRevisionUndeployAck - send the undeployed revision acknowledgement to control plane. The vhost is given
+// when the revision is undeployed from only one of the vhosts of the environment.
+func SendRevisionUndeployAck(apiUUID string, revisionUUID string, environment string, vhost string) {
 	conf, _ := config.ReadConfigs()
 	cpConfigs := conf.ControlPlane
 	if apiUUID == "" || revisionUUID == "" || environment == "" || !cpConfigs.Enabled || !cpConfigs.SendRevisionUpdate {
@@ -134,6 +144,7 @@ func SendRevisionUndeployAck(apiUUID string, revisionUUID string, environment st
 		APIUUID:      apiUUID,
 		RevisionUUID: revisionUUID,
 		Environment:  environment,
+		Vhost:        vhost,
 	}
 
 	jsonValue, _ := json.Marshal(removedRevision)
@@ -35,4 +35,5 @@ type UnDeployedAPIRevision struct {
 	APIUUID      string `json:"apiUUID"`
 	RevisionUUID string `json:"revisionUUID"`
 	Environment  string `json:"environment"`
+	Vhost        string `json:"vhost,omitempty"`
 }
@@ -0,0 +1,203 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wso2/apk/adapter/pkg/logging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/tlsutils"
+)
+
+const (
+	// APIImportOperation represents an API import (deploy) triggered by the control plane
+	APIImportOperation string = "API_IMPORT"
+	// RevisionUndeployOperation represents an API revision undeploy triggered by the control plane
+	RevisionUndeployOperation string = "REVISION_UNDEPLOY"
+
+	syncSuccessStatus string = "success"
+	syncFailureStatus string = "failure"
+
+	slackWebhookType string = "slack"
+	httpWebhookType  string = "http"
+)
+
+// SyncEvent holds the details of an API sync operation which are available to the webhook payload templates
+type SyncEvent struct {
+	Operation    string `json:"operation"`
+	Status       string `json:"status"`
+	APIUUID      string `json:"apiUUID"`
+	APIName      string `json:"apiName"`
+	APIVersion   string `json:"apiVersion"`
+	Organization string `json:"organization"`
+	Error        string `json:"error"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// slackPayload is the default payload of a slack webhook
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// NotifyAPISync fires the configured webhooks for the given API sync operation. If syncErr is nil the
+// operation is considered to be successful.
+func NotifyAPISync(operation, apiUUID, apiName, apiVersion, organization string, syncErr error) {
+	event := SyncEvent{
+		Operation:    operation,
+		Status:       syncSuccessStatus,
+		APIUUID:      apiUUID,
+		APIName:      apiName,
+		APIVersion:   apiVersion,
+		Organization: organization,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	}
+	if syncErr != nil {
+		event.Status = syncFailureStatus
+		event.Error = syncErr.Error()
+	}
+
+	conf, _ := config.ReadConfigs()
+	timeout := conf.Notifier.RequestTimeout * time.Second
+	for _, webhook := range conf.Notifier.Webhooks {
+		if !isSubscribed(webhook, event.Status) {
+			continue
+		}
+		go sendWebhook(webhook, event, timeout)
+	}
+}
+
+func isSubscribed(webhook config.Webhook, status string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, event := range webhook.Events {
+		if strings.EqualFold(event, status) {
+			return true
+		}
+	}
+	return false
+}
+
+func sendWebhook(webhook config.Webhook, event SyncEvent, timeout time.Duration) {
+	payload, err := renderWebhookPayload(webhook, event)
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error rendering the payload of webhook %s : %v", webhook.Name, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2102,
+		})
+		return
+	}
+
+	req, err := http.NewRequest("POST", webhook.URL, bytes.NewBuffer(payload))
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error creating the request for webhook %s : %v", webhook.Name, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2102,
+		})
+		return
+	}
+	req.Header.Set(contentTypeHeader, "application/json")
+	for header, value := range webhook.Headers {
+		req.Header.Set(header, value)
+	}
+
+	resp, err := tlsutils.InvokeControlPlaneWithTimeout(req, webhook.SkipSSLVerification, timeout)
+	if err != nil {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error response from webhook %s : %v", webhook.Name, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2103,
+		})
+		return
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error response status code %v from webhook %s", resp.StatusCode, webhook.Name),
+			Severity:  logging.MINOR,
+			ErrorCode: 2103,
+		})
+		return
+	}
+	logger.LoggerNotifier.Debugf("%s %s notification sent to webhook %s for API %s", event.Operation, event.Status,
+		webhook.Name, event.APIUUID)
+}
+
+// renderWebhookPayload renders the request body of the webhook. The default payloads are marshalled, while the
+// fields of the event are JSON escaped before a custom template is executed, so that the payload stays valid JSON
+// whatever the API names or the error messages contain.
+func renderWebhookPayload(webhook config.Webhook, event SyncEvent) ([]byte, error) {
+	if webhook.PayloadTemplate == "" {
+		switch strings.ToLower(webhook.Type) {
+		case slackWebhookType:
+			return json.Marshal(slackPayload{Text: slackMessage(event)})
+		case httpWebhookType, "":
+			return json.Marshal(event)
+		default:
+			return nil, fmt.Errorf("unsupported webhook type %q", webhook.Type)
+		}
+	}
+	tmpl, err := template.New(webhook.Name).Parse(webhook.PayloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+	escapedEvent := SyncEvent{
+		Operation:    jsonEscape(event.Operation),
+		Status:       jsonEscape(event.Status),
+		APIUUID:      jsonEscape(event.APIUUID),
+		APIName:      jsonEscape(event.APIName),
+		APIVersion:   jsonEscape(event.APIVersion),
+		Organization: jsonEscape(event.Organization),
+		Error:        jsonEscape(event.Error),
+		Timestamp:    jsonEscape(event.Timestamp),
+	}
+	var payload bytes.Buffer
+	if err := tmpl.Execute(&payload, escapedEvent); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func slackMessage(event SyncEvent) string {
+	message := fmt.Sprintf("[apim-apk-agent] %s %s for API %s:%s (organization: %s)", event.Operation, event.Status,
+		event.APIName, event.APIVersion, event.Organization)
+	if event.Error != "" {
+		message += ". Error: " + event.Error
+	}
+	return message
+}
+
+// jsonEscape escapes a value to be placed inside a JSON string
+func jsonEscape(value string) string {
+	escaped, _ := json.Marshal(value)
+	return string(escaped[1 : len(escaped)-1])
+}
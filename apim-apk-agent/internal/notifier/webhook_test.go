@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+)
+
+func TestRenderWebhookPayload(t *testing.T) {
+	event := SyncEvent{
+		Operation:    APIImportOperation,
+		Status:       syncFailureStatus,
+		APIUUID:      "6579f5d3",
+		APIName:      `Pet "store"\API`,
+		APIVersion:   "1.0.0",
+		Organization: "carbon.super",
+		Error:        "unexpected token \"<\"\nat line 1",
+	}
+	webhooks := []config.Webhook{
+		{Name: "http", Type: httpWebhookType},
+		{Name: "slack", Type: slackWebhookType},
+		{Name: "custom", PayloadTemplate: `{"api": "{{.APIName}}", "error": "{{.Error}}"}`},
+	}
+	for _, webhook := range webhooks {
+		payload, err := renderWebhookPayload(webhook, event)
+		assert.Nil(t, err)
+		var rendered map[string]string
+		assert.Nil(t, json.Unmarshal(payload, &rendered), "Payload of webhook %s should be valid JSON: %s",
+			webhook.Name, string(payload))
+		switch webhook.Name {
+		case "http":
+			assert.Equal(t, event.APIName, rendered["apiName"])
+			assert.Equal(t, event.Error, rendered["error"])
+		case "slack":
+			assert.Contains(t, rendered["text"], event.APIName)
+		case "custom":
+			assert.Equal(t, map[string]string{"api": event.APIName, "error": event.Error}, rendered)
+		}
+	}
+
+	_, err := renderWebhookPayload(config.Webhook{Name: "teams", Type: "teams"}, event)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package profiling
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+)
+
+// memoryWatermark holds the highest memory usage of the agent observed so far
+type memoryWatermark struct {
+	heapAlloc uint64
+	sys       uint64
+}
+
+// record raises the watermark to the memory usage in stats and returns true if the heap usage reached a new high
+func (watermark *memoryWatermark) record(stats *runtime.MemStats) bool {
+	if stats.Sys > watermark.sys {
+		watermark.sys = stats.Sys
+	}
+	if stats.HeapAlloc > watermark.heapAlloc {
+		watermark.heapAlloc = stats.HeapAlloc
+		return true
+	}
+	return false
+}
+
+// LogMemoryUsage logs the memory usage of the agent along with its high watermark at every interval, so that the
+// memory growth during a large API sync can be traced from the logs. This call blocks forever.
+func LogMemoryUsage(interval time.Duration) {
+	logger.LoggerProfiling.Infof("Logging the memory usage every %v", interval)
+	watermark := &memoryWatermark{}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		newHigh := watermark.record(&stats)
+		logger.LoggerProfiling.Info(formatMemoryUsage(&stats, watermark, runtime.NumGoroutine(), newHigh))
+	}
+}
+
+func formatMemoryUsage(stats *runtime.MemStats, watermark *memoryWatermark, goroutines int, newHigh bool) string {
+	message := fmt.Sprintf("Memory usage: heap %s (high watermark %s), heap objects %d, obtained from the OS %s "+
+		"(high watermark %s), GC cycles %d, goroutines %d", formatMiB(stats.HeapAlloc),
+		formatMiB(watermark.heapAlloc), stats.HeapObjects, formatMiB(stats.Sys), formatMiB(watermark.sys),
+		stats.NumGC, goroutines)
+	if newHigh {
+		message += ". The heap usage reached a new high watermark"
+	}
+	return message
+}
+
+func formatMiB(bytes uint64) string {
+	return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+}
@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package profiling
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfilingHandlerRequiresBasicAuth(t *testing.T) {
+	handler := newProfilingHandler("admin", "secret")
+
+	for _, credentials := range [][]string{nil, {"admin", "wrong"}, {"other", "secret"}} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+		if credentials != nil {
+			req.SetBasicAuth(credentials[0], credentials[1])
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/heap?debug=1", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "heap profile")
+}
+
+func TestMemoryWatermark(t *testing.T) {
+	watermark := &memoryWatermark{}
+	assert.True(t, watermark.record(&runtime.MemStats{HeapAlloc: 100 << 20, Sys: 200 << 20}))
+	assert.False(t, watermark.record(&runtime.MemStats{HeapAlloc: 50 << 20, Sys: 300 << 20}))
+	assert.Equal(t, memoryWatermark{heapAlloc: 100 << 20, sys: 300 << 20}, *watermark)
+
+	message := formatMemoryUsage(&runtime.MemStats{HeapAlloc: 50 << 20, Sys: 300 << 20, NumGC: 3}, watermark, 12,
+		false)
+	assert.Contains(t, message, "heap 50.0 MiB (high watermark 100.0 MiB)")
+	assert.Contains(t, message, "GC cycles 3, goroutines 12")
+	assert.NotContains(t, message, "new high watermark")
+}
@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package profiling contains the implementation to diagnose the memory and CPU usage of the agent
+package profiling
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
+)
+
+const (
+	pprofResourcePrefix string = "/debug/pprof/"
+	basicAuthRealm      string = `Basic realm="apim-apk-agent profiling"`
+)
+
+// StartProfilingServer serves the pprof endpoints at /debug/pprof/ behind basic authentication, on a port separate
+// from the sync status server so that it is not exposed along with it. The server is not started when the profiling
+// credentials are not set. This call blocks until the server stops.
+func StartProfilingServer(conf *config.Config) {
+	if conf.Profiling.Username == "" || conf.Profiling.Password == "" {
+		logger.LoggerProfiling.Errorf("The profiling server is not started as profiling.username or " +
+			"profiling.password is not set")
+		return
+	}
+	address := conf.Profiling.Host + ":" + conf.Profiling.Port
+	logger.LoggerProfiling.Infof("Starting the profiling server on %s", address)
+	if err := http.ListenAndServe(address, newProfilingHandler(conf.Profiling.Username,
+		conf.Profiling.Password)); err != nil {
+		logger.LoggerProfiling.ErrorC(logging.PrintError(logging.Error1110, logging.MAJOR,
+			"Error serving the profiling server on %s, error: %v", address, err.Error()))
+	}
+}
+
+// newProfilingHandler registers the pprof handlers in a mux of their own, as importing net/http/pprof registers
+// them in the default mux as well
+func newProfilingHandler(username, password string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pprofResourcePrefix, pprof.Index)
+	mux.HandleFunc(pprofResourcePrefix+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(pprofResourcePrefix+"profile", pprof.Profile)
+	mux.HandleFunc(pprofResourcePrefix+"symbol", pprof.Symbol)
+	mux.HandleFunc(pprofResourcePrefix+"trace", pprof.Trace)
+	return withBasicAuth(mux, username, password)
+}
+
+func withBasicAuth(next http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestUsername, requestPassword, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(requestUsername), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(requestPassword), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", basicAuthRealm)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -24,7 +24,14 @@
 package synchronizer
 
 import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/syncstatus"
 
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
 	sync "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/synchronizer"
@@ -47,7 +54,8 @@ func init() {
 // given API ID and a list of environments that API has been deployed to.
 // updatedAPIID is the corresponding ID of the API in the form of an UUID
 // updatedEnvs contains the list of environments the API deployed to.
-func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
+// An error is returned if the control plane responds with an unrecoverable (4xx) error.
+func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) error {
 	// Read configurations and derive the eventHub details
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
@@ -55,7 +63,7 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 		logger.LoggerSync.Errorf("Error reading configs: %v", errReadConfig)
 	}
 	// Populate data from config.
-	configuredEnvs := conf.ControlPlane.EnvironmentLabels
+	configuredEnvs := eventhub.GetEnvironmentLabels(conf)
 	//finalEnvs contains the actual envrionments that the adapter should update
 	var finalEnvs []string
 	if len(configuredEnvs) > 0 {
@@ -77,7 +85,7 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 	if len(finalEnvs) == 0 {
 		// If the finalEnvs is empty -> it means, the configured envrionments  does not contains the affected/updated
 		// environments. If that's the case, then APIs should not be fetched from the adapter.
-		return
+		return nil
 	}
 
 	c := make(chan sync.SyncAPIResponse)
@@ -95,15 +103,61 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 			// if err != nil {
 			// 	logger.LoggerSync.Errorf("Error occurred while pushing API data for the API %q: %v ", updatedAPIID, err)
 			// }
-			break
+			revisionID, vhosts := getDeployedRevision(data.Resp)
+			syncstatus.RecordSyncSuccess(updatedAPIID, revisionID, vhosts)
+			return nil
 		} else if data.ErrorCode >= 400 && data.ErrorCode < 500 {
 			logger.LoggerSync.Errorf("Error occurred when retrieving API %q from control plane: %v", updatedAPIID, data.Err)
 			//health.SetControlPlaneRestAPIStatus(false)
+			err := fmt.Errorf("error occurred when retrieving API %q from control plane: %v", updatedAPIID, data.Err)
+			syncstatus.RecordSyncFailure(updatedAPIID, err)
+			return err
 		} else {
 			// Keep the iteration still until all the envrionment response properly.
 			logger.LoggerSync.Errorf("Error occurred while fetching data from control plane for the API %q: %v. Hence retrying..", updatedAPIID, data.Err)
 			sync.RetryFetchingAPIs(c, data, sync.RuntimeArtifactEndpoint, true, queryParamMap)
 		}
 	}
+}
 
+// getDeployedRevision returns the revision of the API project received from the control plane, which is
+// the name of the API project file listed in the deployment descriptor, along with the vhosts the API is
+// deployed to in each gateway environment.
+func getDeployedRevision(apiProjects []byte) (string, map[string][]string) {
+	reader, err := zip.NewReader(bytes.NewReader(apiProjects), int64(len(apiProjects)))
+	if err != nil {
+		logger.LoggerSync.Debugf("Error reading the API projects to find the revision: %v", err)
+		return "", nil
+	}
+	deploymentDescriptor, _, err := sync.ReadRootFiles(reader)
+	if err != nil || len(deploymentDescriptor.Data.Deployments) == 0 {
+		return "", nil
+	}
+	return strings.TrimSuffix(deploymentDescriptor.Data.Deployments[0].APIFile, zipExt),
+		getDeployedVhosts(deploymentDescriptor.Data.Deployments)
+}
+
+// getDeployedVhosts groups the vhosts of the gateway environments of the deployments by environment. An API
+// with custom domains is deployed to an environment once per vhost.
+func getDeployedVhosts(deployments []sync.APIDeployment) map[string][]string {
+	vhosts := make(map[string][]string)
+	for _, deployment := range deployments {
+		for _, env := range deployment.Environments {
+			if env.Vhost == "" || containsString(vhosts[env.Name], env.Vhost) {
+				continue
+			}
+			vhosts[env.Name] = append(vhosts[env.Name], env.Vhost)
+		}
+	}
+	return vhosts
+}
+
+// containsString checks whether the given value is in the list
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
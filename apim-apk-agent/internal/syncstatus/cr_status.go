@@ -0,0 +1,235 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
+)
+
+const (
+	serviceAccountTokenPath  string = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCACertPath string = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	kubernetesServiceHostEnv string = "KUBERNETES_SERVICE_HOST"
+	kubernetesServicePortEnv string = "KUBERNETES_SERVICE_PORT"
+	apiCRPlural              string = "apis"
+	mergePatchContentType    string = "application/merge-patch+json"
+	kubernetesRequestTimeout        = 10 * time.Second
+)
+
+// apiCRList is the subset of the API custom resource list used to find the CR of an API
+type apiCRList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	} `json:"items"`
+}
+
+// crStatusUpdateQueue coalesces the custom resource status updates of each API and writes them from a single worker,
+// so that a burst of sync events neither starts a goroutine per event nor calls the Kubernetes API once per event.
+// The worker always writes the latest status of an API, hence the updates queued for the API while it is waiting
+// are merged into one.
+type crStatusUpdateQueue struct {
+	mutex   sync.Mutex
+	started bool
+	pending map[string]struct{}
+	signal  chan struct{}
+	update  func(apiUUID string)
+}
+
+var crStatusUpdates = newCRStatusUpdateQueue(updateAPICRStatus)
+
+func newCRStatusUpdateQueue(update func(apiUUID string)) *crStatusUpdateQueue {
+	return &crStatusUpdateQueue{
+		pending: make(map[string]struct{}),
+		signal:  make(chan struct{}, 1),
+		update:  update,
+	}
+}
+
+// StartCRStatusUpdater starts the worker which writes the sync status of the APIs to the status subresource of
+// their custom resources. The status is not written unless the worker is started.
+func StartCRStatusUpdater() {
+	crStatusUpdates.start()
+}
+
+func (queue *crStatusUpdateQueue) start() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if !queue.started {
+		queue.started = true
+		go queue.run()
+	}
+}
+
+// schedule queues the API for a status update. This is a no-op until the worker is started.
+func (queue *crStatusUpdateQueue) schedule(apiUUID string) {
+	queue.mutex.Lock()
+	if !queue.started {
+		queue.mutex.Unlock()
+		return
+	}
+	queue.pending[apiUUID] = struct{}{}
+	queue.mutex.Unlock()
+	select {
+	case queue.signal <- struct{}{}:
+	default:
+		// The worker is already signalled and picks up the API with the rest of the pending ones
+	}
+}
+
+func (queue *crStatusUpdateQueue) run() {
+	for range queue.signal {
+		queue.mutex.Lock()
+		pending := queue.pending
+		queue.pending = make(map[string]struct{})
+		queue.mutex.Unlock()
+		for apiUUID := range pending {
+			queue.update(apiUUID)
+		}
+	}
+}
+
+// updateAPICRStatus writes the current sync status of the API to the status subresource of the API
+// custom resources labeled with the API UUID
+func updateAPICRStatus(apiUUID string) {
+	conf, _ := config.ReadConfigs()
+	crConf := conf.SyncStatus.CRStatus
+	status, found := GetAPISyncStatus(apiUUID)
+	if !found {
+		return
+	}
+	client, apiServer, token, err := getKubernetesClient()
+	if err != nil {
+		logCRStatusError(status.APIUUID, err)
+		return
+	}
+	resourcesURL := fmt.Sprintf("%s/apis/%s/namespaces/%s/%s", apiServer, crConf.APIVersion, crConf.Namespace,
+		apiCRPlural)
+
+	// Find the API custom resources created for the API
+	listReq, err := http.NewRequest(http.MethodGet, resourcesURL+"?labelSelector="+
+		url.QueryEscape(crConf.UUIDLabel+"="+status.APIUUID), nil)
+	if err != nil {
+		logCRStatusError(status.APIUUID, err)
+		return
+	}
+	listReq.Header.Set("Authorization", "Bearer "+token)
+	var crList apiCRList
+	if err := invokeKubernetesAPI(client, listReq, &crList); err != nil {
+		logCRStatusError(status.APIUUID, err)
+		return
+	}
+	if len(crList.Items) == 0 {
+		logger.LoggerSyncStatus.Debugf("No API custom resource found with label %s=%s", crConf.UUIDLabel,
+			status.APIUUID)
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"controlPlaneSync": status,
+		},
+	})
+	if err != nil {
+		logCRStatusError(status.APIUUID, err)
+		return
+	}
+	for _, item := range crList.Items {
+		patchReq, err := http.NewRequest(http.MethodPatch, resourcesURL+"/"+item.Metadata.Name+"/status",
+			bytes.NewBuffer(patch))
+		if err != nil {
+			logCRStatusError(status.APIUUID, err)
+			continue
+		}
+		patchReq.Header.Set("Authorization", "Bearer "+token)
+		patchReq.Header.Set("Content-Type", mergePatchContentType)
+		if err := invokeKubernetesAPI(client, patchReq, nil); err != nil {
+			logCRStatusError(status.APIUUID, err)
+			continue
+		}
+		logger.LoggerSyncStatus.Debugf("Updated the sync status of the API custom resource %s to %s",
+			item.Metadata.Name, status.State)
+	}
+}
+
+// getKubernetesClient creates a http client to access the Kubernetes API server using the in-cluster
+// service account credentials
+func getKubernetesClient() (*http.Client, string, string, error) {
+	host, port := os.Getenv(kubernetesServiceHostEnv), os.Getenv(kubernetesServicePortEnv)
+	if host == "" || port == "" {
+		return nil, "", "", fmt.Errorf("the agent is not running inside a Kubernetes cluster")
+	}
+	token, err := ioutil.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error reading the service account token: %v", err)
+	}
+	caCert, err := ioutil.ReadFile(serviceAccountCACertPath)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error reading the service account CA certificate: %v", err)
+	}
+	caCertPool := x509.NewCertPool()
+	caCertPool.AppendCertsFromPEM(caCert)
+	client := &http.Client{
+		Timeout: kubernetesRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: caCertPool},
+		},
+	}
+	return client, "https://" + net.JoinHostPort(host, port), string(bytes.TrimSpace(token)), nil
+}
+
+func invokeKubernetesAPI(client *http.Client, req *http.Request, response interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s responded with status code %d: %s", req.Method, req.URL.Path,
+			resp.StatusCode, string(body))
+	}
+	if response != nil {
+		return json.Unmarshal(body, response)
+	}
+	return nil
+}
+
+func logCRStatusError(apiUUID string, err error) {
+	logger.LoggerSyncStatus.ErrorC(logging.PrintError(logging.Error1108, logging.MINOR,
+		"Error updating the sync status of the API custom resource for the API %s, error: %v", apiUUID, err.Error()))
+}
@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRStatusUpdateQueueCoalescesUpdates(t *testing.T) {
+	updates := make(chan string, 20)
+	release := make(chan struct{})
+	queue := newCRStatusUpdateQueue(func(apiUUID string) {
+		updates <- apiUUID
+		<-release
+	})
+
+	queue.schedule("api-0")
+	queue.start()
+	queue.schedule("api-1")
+	assert.Equal(t, "api-1", <-updates, "Updates scheduled before the worker is started should be dropped")
+	// The worker is blocked updating api-1, so these are coalesced while they wait
+	for i := 0; i < 10; i++ {
+		queue.schedule("api-1")
+	}
+	queue.schedule("api-2")
+	close(release)
+
+	updated := map[string]int{}
+	for i := 0; i < 2; i++ {
+		select {
+		case apiUUID := <-updates:
+			updated[apiUUID]++
+		case <-time.After(time.Second):
+			t.Fatal("Pending updates were not written")
+		}
+	}
+	assert.Equal(t, map[string]int{"api-1": 1, "api-2": 1}, updated)
+	select {
+	case apiUUID := <-updates:
+		t.Errorf("Unexpected update of %s", apiUUID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
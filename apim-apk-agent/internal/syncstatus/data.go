@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+)
+
+const (
+	applicationsResource  string = "/applications"
+	subscriptionsResource string = "/subscriptions"
+	metricsResource       string = "/metrics"
+	lastSyncHeader        string = "Last-Sync"
+	warningHeader         string = "Warning"
+	// staleWarning is the HTTP warning for a response served from a cache that could not be revalidated
+	staleWarning string = `110 - "Response is Stale"`
+)
+
+// dataListResponse is the payload of the applications and subscriptions endpoints
+type dataListResponse struct {
+	Count int         `json:"count"`
+	List  interface{} `json:"list"`
+}
+
+// handleApplications serves the applications cached from the control plane
+func handleApplications(w http.ResponseWriter, r *http.Request, staleAfter time.Duration) {
+	applications, state := eventhub.GetApplications()
+	writeCachedData(w, r, dataListResponse{Count: len(applications), List: applications}, state, staleAfter)
+}
+
+// handleSubscriptions serves the subscriptions cached from the control plane
+func handleSubscriptions(w http.ResponseWriter, r *http.Request, staleAfter time.Duration) {
+	subscriptions, state := eventhub.GetSubscriptions()
+	writeCachedData(w, r, dataListResponse{Count: len(subscriptions), List: subscriptions}, state, staleAfter)
+}
+
+// writeCachedData writes the cached data with the time it was last pulled from the control plane in the Last-Sync
+// header. Stale data is still served, but is flagged with a Warning header, so that the clients can keep working
+// while the control plane is unreachable.
+func writeCachedData(w http.ResponseWriter, r *http.Request, data dataListResponse,
+	state eventhub.ResourceSyncState, staleAfter time.Duration) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, r, ErrorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	if state.LastSync.IsZero() {
+		writeJSONError(w, r, ErrorCodeDataNotSynced, "the data has not been synced with the control plane yet")
+		return
+	}
+	w.Header().Set(lastSyncHeader, state.LastSync.Format(http.TimeFormat))
+	if state.IsStale(staleAfter, time.Now()) {
+		w.Header().Set(warningHeader, staleWarning)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logger.LoggerSyncStatus.Errorf("Error writing the cached data: %v", err)
+	}
+}
+
+// handleMetrics exposes the staleness of the data cached from the control plane in the Prometheus text format
+func handleMetrics(w http.ResponseWriter, r *http.Request, staleAfter time.Duration) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, r, ErrorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(formatStalenessMetrics(eventhub.GetResourceSyncStates(), staleAfter, time.Now())))
+}
+
+func formatStalenessMetrics(states map[string]eventhub.ResourceSyncState, staleAfter time.Duration,
+	now time.Time) string {
+	resourceNames := make([]string, 0, len(states))
+	for name := range states {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	var builder strings.Builder
+	writeGauge := func(name, help string, value func(state eventhub.ResourceSyncState) (float64, bool)) {
+		fmt.Fprintf(&builder, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, resourceName := range resourceNames {
+			if v, ok := value(states[resourceName]); ok {
+				fmt.Fprintf(&builder, "%s{resource=%q} %s\n", name, resourceName, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+		}
+	}
+	writeGauge("apim_apk_agent_data_last_sync_timestamp_seconds",
+		"Unix time at which the data was last pulled from the control plane",
+		func(state eventhub.ResourceSyncState) (float64, bool) {
+			return float64(state.LastSync.Unix()), !state.LastSync.IsZero()
+		})
+	writeGauge("apim_apk_agent_data_staleness_seconds",
+		"Seconds since the data was last pulled from the control plane",
+		func(state eventhub.ResourceSyncState) (float64, bool) {
+			return now.Sub(state.LastSync).Seconds(), !state.LastSync.IsZero()
+		})
+	writeGauge("apim_apk_agent_data_stale",
+		"Whether the data is stale (1) or in sync with the control plane (0)",
+		func(state eventhub.ResourceSyncState) (float64, bool) {
+			if state.IsStale(staleAfter, now) {
+				return 1, true
+			}
+			return 0, true
+		})
+	return builder.String()
+}
@@ -0,0 +1,45 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+)
+
+func TestFormatStalenessMetrics(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	states := map[string]eventhub.ResourceSyncState{
+		"subscriptions":            {LastSync: time.Unix(1700000000, 0), Reachable: true},
+		"applications":             {LastSync: time.Unix(1700000000, 0), Reachable: false},
+		"application-key-mappings": {},
+	}
+	metrics := formatStalenessMetrics(states, time.Hour, now)
+
+	assert.Contains(t, metrics, "# TYPE apim_apk_agent_data_staleness_seconds gauge\n")
+	assert.Contains(t, metrics, `apim_apk_agent_data_last_sync_timestamp_seconds{resource="applications"} 1700000000`+"\n")
+	assert.Contains(t, metrics, `apim_apk_agent_data_staleness_seconds{resource="subscriptions"} 100`+"\n")
+	assert.Contains(t, metrics, `apim_apk_agent_data_stale{resource="subscriptions"} 0`+"\n")
+	assert.Contains(t, metrics, `apim_apk_agent_data_stale{resource="applications"} 1`+"\n")
+	assert.Contains(t, metrics, `apim_apk_agent_data_stale{resource="application-key-mappings"} 1`+"\n")
+	assert.NotContains(t, metrics, `apim_apk_agent_data_staleness_seconds{resource="application-key-mappings"}`,
+		"staleness should not be reported for data never synced")
+}
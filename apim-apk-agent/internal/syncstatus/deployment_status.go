@@ -0,0 +1,189 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/auth"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/tlsutils"
+)
+
+const (
+	deploymentStatusResourceSuffix string = "/deployment-status"
+	publisherAPIsEP                string = "api/am/publisher/v4/apis/"
+	deploymentsResource            string = "/deployments"
+)
+
+// errAPINotFoundInControlPlane is returned when the control plane does not know the API
+var errAPINotFoundInControlPlane = errors.New("API not found in the control plane")
+
+// RevisionDeployment is the deployment of a revision of an API in a gateway environment as reported by the
+// Publisher
+type RevisionDeployment struct {
+	RevisionUUID string `json:"revisionUuid"`
+	Name         string `json:"name"`
+	Vhost        string `json:"vhost"`
+	Status       string `json:"status"`
+	DeployedTime string `json:"deployedTime,omitempty"`
+}
+
+// revisionDeploymentList is the payload of the Publisher deployments endpoint of an API
+type revisionDeploymentList struct {
+	List []RevisionDeployment `json:"list"`
+}
+
+// DeploymentStatus compares the revisions of an API deployed in the gateway environments of the agent according
+// to the control plane with the revision deployed in the data plane
+type DeploymentStatus struct {
+	APIUUID string `json:"apiUUID"`
+	// ControlPlane holds the deployments of the API in the gateway environments of the agent
+	ControlPlane []RevisionDeployment `json:"controlPlane"`
+	// DataPlane holds the sync status of the API in the data plane. It is nil if no sync has been attempted.
+	DataPlane *APISyncStatus `json:"dataPlane,omitempty"`
+	// InSync is true if the revision deployed in the data plane is the one deployed in the control plane
+	InSync bool `json:"inSync"`
+}
+
+// handleAPIDeploymentStatus serves the deployment status of an API after retrieving its deployments from the
+// Publisher, so that the Adapter can confirm the control plane reflects the state of the data plane
+func handleAPIDeploymentStatus(w http.ResponseWriter, r *http.Request, apiUUID string) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, r, ErrorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	conf, _ := config.ReadConfigs()
+	cpConfigs := conf.ControlPlane
+	if !cpConfigs.Enabled {
+		writeJSONError(w, r, ErrorCodeControlPlaneDisabled, "the control plane is not enabled")
+		return
+	}
+	deployments, err := getControlPlaneDeployments(cpConfigs.ServiceURL, cpConfigs.Username, cpConfigs.Password,
+		cpConfigs.SkipSSLVerification, apiUUID)
+	if err == errAPINotFoundInControlPlane {
+		writeJSONError(w, r, ErrorCodeAPINotInControlPlane, "the API "+apiUUID+" was not found in the control plane")
+		return
+	}
+	if err != nil {
+		logger.LoggerSyncStatus.Errorf("Error retrieving the deployments of the API %s from the control plane: %v",
+			apiUUID, err)
+		writeJSONError(w, r, ErrorCodeControlPlaneUnavailable,
+			"error retrieving the deployments from the control plane")
+		return
+	}
+	status := newDeploymentStatus(apiUUID, deployments, eventhub.GetEnvironmentLabels(conf))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		logger.LoggerSyncStatus.Errorf("Error writing the deployment status of the API %s: %v", apiUUID, err)
+	}
+}
+
+// getControlPlaneDeployments retrieves the deployments of the revisions of an API from the Publisher
+func getControlPlaneDeployments(serviceURL, username, password string, skipSSL bool,
+	apiUUID string) ([]RevisionDeployment, error) {
+	deploymentsEP := serviceURL
+	if !strings.HasSuffix(deploymentsEP, "/") {
+		deploymentsEP += "/"
+	}
+	deploymentsEP += publisherAPIsEP + apiUUID + deploymentsResource
+
+	req, err := http.NewRequest(http.MethodGet, deploymentsEP, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Basic "+auth.GetBasicAuth(username, password))
+	resp, err := tlsutils.InvokeControlPlane(req, skipSSL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errAPINotFoundInControlPlane
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, deploymentsEP)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var deploymentList revisionDeploymentList
+	if err := json.Unmarshal(body, &deploymentList); err != nil {
+		return nil, err
+	}
+	return deploymentList.List, nil
+}
+
+// newDeploymentStatus compares the deployments of an API in the given gateway environments with its sync status.
+// The API is in sync if the revision synced in the data plane is deployed to each vhost of these environments, or if
+// it is neither deployed in these environments nor in the data plane.
+func newDeploymentStatus(apiUUID string, deployments []RevisionDeployment,
+	environmentLabels []string) DeploymentStatus {
+	status := DeploymentStatus{APIUUID: apiUUID, ControlPlane: []RevisionDeployment{}}
+	for _, deployment := range deployments {
+		for _, label := range environmentLabels {
+			if deployment.Name == label {
+				status.ControlPlane = append(status.ControlPlane, deployment)
+				break
+			}
+		}
+	}
+	syncStatus, found := GetAPISyncStatus(apiUUID)
+	if found {
+		status.DataPlane = &syncStatus
+	}
+	if len(status.ControlPlane) == 0 {
+		status.InSync = !found || syncStatus.State == UndeployedState
+		return status
+	}
+	if !found || syncStatus.State != SyncedState || syncStatus.RevisionID == "" {
+		return status
+	}
+	status.InSync = true
+	for _, deployment := range status.ControlPlane {
+		// The revision of the data plane is the name of the API project, which ends with the revision UUID
+		if !strings.HasSuffix(syncStatus.RevisionID, deployment.RevisionUUID) ||
+			!isDeployedToVhost(syncStatus, deployment) {
+			status.InSync = false
+			break
+		}
+	}
+	return status
+}
+
+// isDeployedToVhost checks whether the data plane serves the API from the vhost of the given deployment. The vhosts
+// are not known if the API was synced without a deployment descriptor, in which case only the revision is compared.
+func isDeployedToVhost(syncStatus APISyncStatus, deployment RevisionDeployment) bool {
+	if len(syncStatus.Vhosts) == 0 || deployment.Vhost == "" {
+		return true
+	}
+	for _, vhost := range syncStatus.Vhosts[deployment.Name] {
+		if vhost == deployment.Vhost {
+			return true
+		}
+	}
+	return false
+}
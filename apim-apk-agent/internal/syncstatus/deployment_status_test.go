@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetControlPlaneDeployments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+		switch r.URL.Path {
+		case "/api/am/publisher/v4/apis/pizza/deployments":
+			_, _ = w.Write([]byte(`{"count": 1, "list": [{"revisionUuid": "r1", "name": "Default",
+				"vhost": "localhost", "status": "APPROVED"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	deployments, err := getControlPlaneDeployments(server.URL, "admin", "secret", true, "pizza")
+	assert.Nil(t, err)
+	assert.Equal(t, []RevisionDeployment{{RevisionUUID: "r1", Name: "Default", Vhost: "localhost",
+		Status: "APPROVED"}}, deployments)
+
+	_, err = getControlPlaneDeployments(server.URL+"/", "admin", "secret", true, "burger")
+	assert.Equal(t, errAPINotFoundInControlPlane, err)
+}
+
+func TestNewDeploymentStatus(t *testing.T) {
+	labels := []string{"Default"}
+	deployments := []RevisionDeployment{
+		{RevisionUUID: "r2", Name: "Default", Vhost: "localhost"},
+		{RevisionUUID: "r1", Name: "us-region", Vhost: "us.wso2.com"},
+	}
+
+	status := newDeploymentStatus("deployment-status-unknown", deployments, labels)
+	assert.Len(t, status.ControlPlane, 1, "Deployments in other gateway environments should be ignored")
+	assert.Nil(t, status.DataPlane)
+	assert.False(t, status.InSync)
+
+	RecordSyncSuccess("deployment-status-synced", "deployment-status-synced-r2", nil)
+	status = newDeploymentStatus("deployment-status-synced", deployments, labels)
+	assert.Equal(t, SyncedState, status.DataPlane.State)
+	assert.True(t, status.InSync)
+
+	RecordSyncSuccess("deployment-status-outdated", "deployment-status-outdated-r1", nil)
+	assert.False(t, newDeploymentStatus("deployment-status-outdated", deployments, labels).InSync)
+
+	RecordSyncFailure("deployment-status-failed", errors.New("connection refused"))
+	assert.False(t, newDeploymentStatus("deployment-status-failed", deployments, labels).InSync)
+
+	RecordUndeploy("deployment-status-undeployed", "", "")
+	assert.True(t, newDeploymentStatus("deployment-status-undeployed", nil, labels).InSync)
+	assert.False(t, newDeploymentStatus("deployment-status-synced", nil, labels).InSync)
+}
+
+func TestNewDeploymentStatusWithVhosts(t *testing.T) {
+	labels := []string{"Default"}
+	deployments := []RevisionDeployment{
+		{RevisionUUID: "r1", Name: "Default", Vhost: "localhost"},
+		{RevisionUUID: "r1", Name: "Default", Vhost: "api.example.com"},
+	}
+
+	RecordSyncSuccess("deployment-status-vhosts", "deployment-status-vhosts-r1",
+		map[string][]string{"Default": {"localhost", "api.example.com"}})
+	assert.True(t, newDeploymentStatus("deployment-status-vhosts", deployments, labels).InSync)
+
+	RecordSyncSuccess("deployment-status-missing-vhost", "deployment-status-missing-vhost-r1",
+		map[string][]string{"Default": {"localhost"}})
+	assert.False(t, newDeploymentStatus("deployment-status-missing-vhost", deployments, labels).InSync,
+		"The API should be out of sync if it is not deployed to a vhost of the control plane")
+}
+
+func TestRecordUndeployPerVhost(t *testing.T) {
+	RecordSyncSuccess("undeploy-per-vhost", "undeploy-per-vhost-r1",
+		map[string][]string{"Default": {"localhost", "api.example.com"}, "us-region": {"us.wso2.com"}})
+
+	RecordUndeploy("undeploy-per-vhost", "Default", "api.example.com")
+	status, _ := GetAPISyncStatus("undeploy-per-vhost")
+	assert.Equal(t, SyncedState, status.State)
+	assert.Equal(t, map[string][]string{"Default": {"localhost"}, "us-region": {"us.wso2.com"}}, status.Vhosts)
+
+	RecordUndeploy("undeploy-per-vhost", "us-region", "")
+	status, _ = GetAPISyncStatus("undeploy-per-vhost")
+	assert.Equal(t, SyncedState, status.State)
+	assert.Equal(t, map[string][]string{"Default": {"localhost"}}, status.Vhosts)
+
+	RecordUndeploy("undeploy-per-vhost", "Default", "localhost")
+	status, _ = GetAPISyncStatus("undeploy-per-vhost")
+	assert.Equal(t, UndeployedState, status.State)
+	assert.Empty(t, status.RevisionID)
+	assert.Nil(t, status.Vhosts)
+}
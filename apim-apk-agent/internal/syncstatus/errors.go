@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+)
+
+// correlationIDHeader carries the correlation id of a request. It is generated when the client does not send one.
+const correlationIDHeader string = "X-Correlation-ID"
+
+// ErrorCode identifies the kind of an error returned by the endpoints of the sync status server
+type ErrorCode string
+
+// Error codes of the sync status server
+const (
+	ErrorCodeUnauthorized            ErrorCode = "UNAUTHORIZED"
+	ErrorCodeResourceNotFound        ErrorCode = "RESOURCE_NOT_FOUND"
+	ErrorCodeMethodNotAllowed        ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrorCodeAPINotSynced            ErrorCode = "API_NOT_SYNCED"
+	ErrorCodeDataNotSynced           ErrorCode = "DATA_NOT_SYNCED"
+	ErrorCodeControlPlaneDisabled    ErrorCode = "CONTROL_PLANE_DISABLED"
+	ErrorCodeAPINotInControlPlane    ErrorCode = "API_NOT_IN_CONTROL_PLANE"
+	ErrorCodeControlPlaneUnavailable ErrorCode = "CONTROL_PLANE_UNAVAILABLE"
+	ErrorCodeInternal                ErrorCode = "INTERNAL_ERROR"
+)
+
+// ErrorResponse is the payload of every error returned by the sync status server. Retryable tells the clients,
+// such as the Adapter, whether the same request may succeed later without any change on their side.
+type ErrorResponse struct {
+	Code          ErrorCode `json:"code"`
+	Message       string    `json:"message"`
+	Retryable     bool      `json:"retryable"`
+	CorrelationID string    `json:"correlationId"`
+}
+
+type errorDefinition struct {
+	statusCode int
+	retryable  bool
+}
+
+// errorDefinitions fixes the status code and the retry semantics of each error code, so that the handlers cannot
+// return the same kind of error with differing status codes
+var errorDefinitions = map[ErrorCode]errorDefinition{
+	ErrorCodeUnauthorized:            {http.StatusUnauthorized, false},
+	ErrorCodeResourceNotFound:        {http.StatusNotFound, false},
+	ErrorCodeMethodNotAllowed:        {http.StatusMethodNotAllowed, false},
+	ErrorCodeAPINotSynced:            {http.StatusNotFound, true},
+	ErrorCodeDataNotSynced:           {http.StatusServiceUnavailable, true},
+	ErrorCodeControlPlaneDisabled:    {http.StatusNotImplemented, false},
+	ErrorCodeAPINotInControlPlane:    {http.StatusNotFound, false},
+	ErrorCodeControlPlaneUnavailable: {http.StatusBadGateway, true},
+	ErrorCodeInternal:                {http.StatusInternalServerError, true},
+}
+
+// writeJSONError writes the error response of the given code with the correlation id of the request, which is
+// echoed in the X-Correlation-ID header as well
+func writeJSONError(w http.ResponseWriter, r *http.Request, code ErrorCode, message string) {
+	definition, found := errorDefinitions[code]
+	if !found {
+		code = ErrorCodeInternal
+		definition = errorDefinitions[code]
+	}
+	correlationID := getCorrelationID(r)
+	logger.LoggerSyncStatus.Debugf("Returning the error %s for %s %s with the correlation id %s: %s", code,
+		r.Method, r.URL.Path, correlationID, message)
+	w.Header().Set(correlationIDHeader, correlationID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(definition.statusCode)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Code: code, Message: message, Retryable: definition.retryable,
+		CorrelationID: correlationID})
+}
+
+func getCorrelationID(r *http.Request) string {
+	if correlationID := r.Header.Get(correlationIDHeader); correlationID != "" {
+		return correlationID
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSONError(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/applications", nil)
+	request.Header.Set(correlationIDHeader, "c0ffee")
+	recorder := httptest.NewRecorder()
+	writeJSONError(recorder, request, ErrorCodeDataNotSynced, "the data has not been synced")
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "c0ffee", recorder.Header().Get(correlationIDHeader))
+	var response ErrorResponse
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, ErrorResponse{Code: ErrorCodeDataNotSynced, Message: "the data has not been synced",
+		Retryable: true, CorrelationID: "c0ffee"}, response)
+}
+
+func TestWriteJSONErrorGeneratesCorrelationID(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writeJSONError(recorder, httptest.NewRequest(http.MethodPost, "/healthz", nil), ErrorCodeMethodNotAllowed,
+		"method not allowed")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	var response ErrorResponse
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.False(t, response.Retryable)
+	assert.Len(t, response.CorrelationID, 32)
+	assert.Equal(t, response.CorrelationID, recorder.Header().Get(correlationIDHeader))
+}
+
+func TestErrorDefinitions(t *testing.T) {
+	for _, code := range []ErrorCode{ErrorCodeResourceNotFound, ErrorCodeMethodNotAllowed, ErrorCodeAPINotSynced,
+		ErrorCodeDataNotSynced, ErrorCodeControlPlaneDisabled, ErrorCodeAPINotInControlPlane,
+		ErrorCodeControlPlaneUnavailable, ErrorCodeInternal} {
+		_, found := errorDefinitions[code]
+		assert.True(t, found, "no definition for the error code %s", code)
+	}
+}
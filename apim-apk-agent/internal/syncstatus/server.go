@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
+)
+
+const (
+	apisResourcePrefix   string = "/apis/"
+	statusResourceSuffix string = "/status"
+	healthResource       string = "/healthz"
+	healthStatusUp       string = "UP"
+	healthStatusDown     string = "DOWN"
+	bearerPrefix         string = "Bearer "
+)
+
+// healthResponse is the payload of the health endpoint
+type healthResponse struct {
+	Status   string                                   `json:"status"`
+	EventHub map[string]health.EventHubConsumerStatus `json:"eventHub,omitempty"`
+}
+
+// StartSyncStatusServer serves the sync status of each API at GET /apis/{uuid}/status, its deployment status
+// compared with the control plane at GET /apis/{uuid}/deployment-status and the connection state of the event hub
+// consumers at GET /healthz. The applications and subscriptions cached from the control plane are served at
+// GET /applications and GET /subscriptions, and their staleness at GET /metrics. Every endpoint returns its
+// errors as an ErrorResponse.
+// Every endpoint except /healthz requires the configured token as a bearer token. The server is not started on a
+// non loopback host unless the token is set, as it serves the data of all the organizations.
+// This call blocks until the server stops.
+func StartSyncStatusServer(conf *config.Config) {
+	if conf.SyncStatus.Token == "" && !isLoopbackHost(conf.SyncStatus.Host) {
+		logger.LoggerSyncStatus.Errorf("The API sync status server is not started on %s as syncStatus.token is "+
+			"not set", conf.SyncStatus.Host)
+		return
+	}
+	address := conf.SyncStatus.Host + ":" + conf.SyncStatus.Port
+	logger.LoggerSyncStatus.Infof("Starting the API sync status server on %s", address)
+	if err := http.ListenAndServe(address, newSyncStatusHandler(conf)); err != nil {
+		logger.LoggerSyncStatus.ErrorC(logging.PrintError(logging.Error1107, logging.MAJOR,
+			"Error serving the API sync status server on %s, error: %v", address, err.Error()))
+	}
+}
+
+func newSyncStatusHandler(conf *config.Config) http.Handler {
+	staleAfter := conf.SyncStatus.DataCache.StaleAfter * time.Second
+	mux := http.NewServeMux()
+	mux.HandleFunc(apisResourcePrefix, handleAPIResource)
+	mux.HandleFunc(healthResource, func(w http.ResponseWriter, r *http.Request) {
+		handleHealth(w, r, conf.ControlPlane.Enabled)
+	})
+	mux.HandleFunc(applicationsResource, func(w http.ResponseWriter, r *http.Request) {
+		handleApplications(w, r, staleAfter)
+	})
+	mux.HandleFunc(subscriptionsResource, func(w http.ResponseWriter, r *http.Request) {
+		handleSubscriptions(w, r, staleAfter)
+	})
+	mux.HandleFunc(metricsResource, func(w http.ResponseWriter, r *http.Request) {
+		handleMetrics(w, r, staleAfter)
+	})
+	return withBearerToken(mux, conf.SyncStatus.Token)
+}
+
+// withBearerToken rejects the requests which do not carry the token, except the ones to the health endpoint so that
+// it can be used by the liveness and readiness probes. No token is required when the token is empty.
+func withBearerToken(next http.Handler, token string) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthResource {
+			authorization := r.Header.Get("Authorization")
+			if !strings.HasPrefix(authorization, bearerPrefix) || subtle.ConstantTimeCompare(
+				[]byte(strings.TrimPrefix(authorization, bearerPrefix)), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeJSONError(w, r, ErrorCodeUnauthorized, "unauthorized")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func handleAPIResource(w http.ResponseWriter, r *http.Request) {
+	var resourceSuffix string
+	switch {
+	case strings.HasSuffix(r.URL.Path, statusResourceSuffix):
+		resourceSuffix = statusResourceSuffix
+	case strings.HasSuffix(r.URL.Path, deploymentStatusResourceSuffix):
+		resourceSuffix = deploymentStatusResourceSuffix
+	default:
+		writeJSONError(w, r, ErrorCodeResourceNotFound, "resource not found")
+		return
+	}
+	apiUUID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, apisResourcePrefix), resourceSuffix)
+	if apiUUID == "" || strings.Contains(apiUUID, "/") {
+		writeJSONError(w, r, ErrorCodeResourceNotFound, "resource not found")
+		return
+	}
+	if resourceSuffix == deploymentStatusResourceSuffix {
+		handleAPIDeploymentStatus(w, r, apiUUID)
+		return
+	}
+	handleAPISyncStatus(w, r, apiUUID)
+}
+
+func handleAPISyncStatus(w http.ResponseWriter, r *http.Request, apiUUID string) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, r, ErrorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	status, found := GetAPISyncStatus(apiUUID)
+	if !found {
+		writeJSONError(w, r, ErrorCodeAPINotSynced, "no sync has been attempted for the API "+apiUUID)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		logger.LoggerSyncStatus.Errorf("Error writing the sync status of the API %s: %v", apiUUID, err)
+	}
+}
+
+// handleHealth reports DOWN with 503 when the event hub is enabled and any of its consumers is not
+// connected, since the agent would silently miss the control plane events in that state
+func handleHealth(w http.ResponseWriter, r *http.Request, eventHubEnabled bool) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, r, ErrorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	response := healthResponse{Status: healthStatusUp}
+	statusCode := http.StatusOK
+	if eventHubEnabled {
+		var connected bool
+		response.EventHub, connected = health.GetEventHubConsumerStatus()
+		if !connected {
+			response.Status = healthStatusDown
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.LoggerSyncStatus.Errorf("Error writing the health status: %v", err)
+	}
+}
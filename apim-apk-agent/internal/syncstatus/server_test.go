@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package syncstatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBearerToken(t *testing.T) {
+	handler := withBearerToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "secret")
+
+	for _, authorization := range []string{"", "Bearer wrong", "secret", "Basic c2VjcmV0"} {
+		req := httptest.NewRequest(http.MethodGet, "/applications", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Authorization: %s", authorization)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/applications", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "The health endpoint should not require the token")
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	assert.True(t, isLoopbackHost("127.0.0.1"))
+	assert.True(t, isLoopbackHost("localhost"))
+	assert.True(t, isLoopbackHost("::1"))
+	assert.False(t, isLoopbackHost("0.0.0.0"))
+	assert.False(t, isLoopbackHost(""))
+}
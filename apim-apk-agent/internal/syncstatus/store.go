@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package syncstatus keeps track of the control plane sync state of each API and exposes it
+// through a REST endpoint and the status subresource of the API custom resources. The applications and
+// subscriptions cached from the control plane and their staleness are served by the same server.
+package syncstatus
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// SyncingState represents an API which is being fetched from the control plane
+	SyncingState string = "Syncing"
+	// SyncedState represents an API which was successfully synced with the control plane
+	SyncedState string = "Synced"
+	// FailedState represents an API which failed to sync with the control plane
+	FailedState string = "Failed"
+	// UndeployedState represents an API whose revision was undeployed from the gateway
+	UndeployedState string = "Undeployed"
+)
+
+// APISyncStatus holds the control plane sync state of an API. Vhosts holds the vhosts the API is deployed to in each
+// gateway environment.
+type APISyncStatus struct {
+	APIUUID       string              `json:"apiUUID"`
+	APIName       string              `json:"apiName,omitempty"`
+	APIVersion    string              `json:"apiVersion,omitempty"`
+	Organization  string              `json:"organization,omitempty"`
+	RevisionID    string              `json:"revisionId,omitempty"`
+	Vhosts        map[string][]string `json:"vhosts,omitempty"`
+	State         string              `json:"state"`
+	LastAttempted time.Time           `json:"lastAttempted"`
+	LastSuccess   *time.Time          `json:"lastSuccess,omitempty"`
+	LastError     string              `json:"lastError,omitempty"`
+}
+
+var (
+	apiSyncStatusMap = make(map[string]*APISyncStatus)
+	mutex            sync.RWMutex
+)
+
+// RecordSyncAttempt marks that the given API is being fetched from the control plane
+func RecordSyncAttempt(apiUUID, apiName, apiVersion, organization string) {
+	mutex.Lock()
+	status, found := apiSyncStatusMap[apiUUID]
+	if !found {
+		status = &APISyncStatus{APIUUID: apiUUID}
+		apiSyncStatusMap[apiUUID] = status
+	}
+	if apiName != "" {
+		status.APIName = apiName
+		status.APIVersion = apiVersion
+		status.Organization = organization
+	}
+	status.State = SyncingState
+	status.LastAttempted = time.Now().UTC()
+	mutex.Unlock()
+	crStatusUpdates.schedule(apiUUID)
+}
+
+// RecordSyncSuccess marks that the given revision of the API was successfully synced to the given vhosts of each
+// gateway environment
+func RecordSyncSuccess(apiUUID, revisionID string, vhosts map[string][]string) {
+	now := time.Now().UTC()
+	mutex.Lock()
+	status := getOrCreateStatus(apiUUID, now)
+	status.State = SyncedState
+	status.RevisionID = revisionID
+	status.Vhosts = copyVhosts(vhosts)
+	status.LastSuccess = &now
+	status.LastError = ""
+	mutex.Unlock()
+	crStatusUpdates.schedule(apiUUID)
+}
+
+// RecordSyncFailure marks that the last sync attempt of the given API failed with syncErr
+func RecordSyncFailure(apiUUID string, syncErr error) {
+	mutex.Lock()
+	status := getOrCreateStatus(apiUUID, time.Now().UTC())
+	status.State = FailedState
+	if syncErr != nil {
+		status.LastError = syncErr.Error()
+	}
+	mutex.Unlock()
+	crStatusUpdates.schedule(apiUUID)
+}
+
+// RecordUndeploy marks that the deployed revision of the given API was removed from a vhost of a gateway
+// environment. An empty vhost removes the API from all the vhosts of the environment and an empty environment
+// removes it from all the environments. The API is marked as undeployed once it is not left in any vhost.
+func RecordUndeploy(apiUUID, environment, vhost string) {
+	mutex.Lock()
+	status := getOrCreateStatus(apiUUID, time.Now().UTC())
+	switch {
+	case environment == "":
+		status.Vhosts = nil
+	case vhost == "":
+		delete(status.Vhosts, environment)
+	default:
+		remaining := []string{}
+		for _, deployedVhost := range status.Vhosts[environment] {
+			if deployedVhost != vhost {
+				remaining = append(remaining, deployedVhost)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(status.Vhosts, environment)
+		} else {
+			status.Vhosts[environment] = remaining
+		}
+	}
+	if len(status.Vhosts) == 0 {
+		status.State = UndeployedState
+		status.RevisionID = ""
+		status.Vhosts = nil
+	}
+	mutex.Unlock()
+	crStatusUpdates.schedule(apiUUID)
+}
+
+// GetAPISyncStatus returns the sync status of the given API. The second return value is false if
+// no sync has been attempted for the API.
+func GetAPISyncStatus(apiUUID string) (APISyncStatus, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	status, found := apiSyncStatusMap[apiUUID]
+	if !found {
+		return APISyncStatus{}, false
+	}
+	syncStatus := *status
+	syncStatus.Vhosts = copyVhosts(status.Vhosts)
+	return syncStatus, true
+}
+
+// getOrCreateStatus should be called while holding the write lock
+func getOrCreateStatus(apiUUID string, attemptedTime time.Time) *APISyncStatus {
+	status, found := apiSyncStatusMap[apiUUID]
+	if !found {
+		status = &APISyncStatus{APIUUID: apiUUID, LastAttempted: attemptedTime}
+		apiSyncStatusMap[apiUUID] = status
+	}
+	return status
+}
+
+// copyVhosts returns a copy of the vhosts of the gateway environments so that the stored status is not shared
+func copyVhosts(vhosts map[string][]string) map[string][]string {
+	if len(vhosts) == 0 {
+		return nil
+	}
+	vhostsCopy := make(map[string][]string, len(vhosts))
+	for environment, envVhosts := range vhosts {
+		vhostsCopy[environment] = append([]string(nil), envVhosts...)
+	}
+	return vhostsCopy
+}
@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// EventHubConnecting represents an event hub consumer which has not subscribed yet
+	EventHubConnecting string = "CONNECTING"
+	// EventHubConnected represents an event hub consumer which is subscribed and consuming events
+	EventHubConnected string = "CONNECTED"
+	// EventHubReconnecting represents an event hub consumer which lost its connection or channel
+	EventHubReconnecting string = "RECONNECTING"
+)
+
+// EventHubConsumerStatus holds the connection state of the event hub consumer of a binding key
+type EventHubConsumerStatus struct {
+	State             string     `json:"state"`
+	ReconnectAttempts int        `json:"reconnectAttempts"`
+	LastConnected     *time.Time `json:"lastConnected,omitempty"`
+	LastError         string     `json:"lastError,omitempty"`
+}
+
+var (
+	eventHubConsumerStatusMap = make(map[string]*EventHubConsumerStatus)
+	eventHubMutex             sync.RWMutex
+)
+
+// SetEventHubConsumerConnecting marks that the consumer of the given binding key is being established
+func SetEventHubConsumerConnecting(key string) {
+	eventHubMutex.Lock()
+	defer eventHubMutex.Unlock()
+	getOrCreateEventHubConsumerStatus(key).State = EventHubConnecting
+}
+
+// SetEventHubConsumerConnected marks that the consumer of the given binding key is subscribed
+func SetEventHubConsumerConnected(key string) {
+	now := time.Now().UTC()
+	eventHubMutex.Lock()
+	defer eventHubMutex.Unlock()
+	status := getOrCreateEventHubConsumerStatus(key)
+	status.State = EventHubConnected
+	status.ReconnectAttempts = 0
+	status.LastConnected = &now
+	status.LastError = ""
+}
+
+// SetEventHubConsumerReconnecting marks that the consumer of the given binding key is reconnecting
+// after the given number of failed attempts. reconnectErr is the reason for the latest failure.
+func SetEventHubConsumerReconnecting(key string, attempts int, reconnectErr error) {
+	eventHubMutex.Lock()
+	defer eventHubMutex.Unlock()
+	status := getOrCreateEventHubConsumerStatus(key)
+	status.State = EventHubReconnecting
+	status.ReconnectAttempts = attempts
+	if reconnectErr != nil {
+		status.LastError = reconnectErr.Error()
+	}
+}
+
+// GetEventHubConsumerStatus returns the connection state of the event hub consumers by binding key.
+// The second return value is true only if every consumer is connected.
+func GetEventHubConsumerStatus() (map[string]EventHubConsumerStatus, bool) {
+	eventHubMutex.RLock()
+	defer eventHubMutex.RUnlock()
+	statuses := make(map[string]EventHubConsumerStatus, len(eventHubConsumerStatusMap))
+	connected := len(eventHubConsumerStatusMap) > 0
+	for key, status := range eventHubConsumerStatusMap {
+		statuses[key] = *status
+		if status.State != EventHubConnected {
+			connected = false
+		}
+	}
+	return statuses, connected
+}
+
+// getOrCreateEventHubConsumerStatus should be called while holding the write lock
+func getOrCreateEventHubConsumerStatus(key string) *EventHubConsumerStatus {
+	status, found := eventHubConsumerStatusMap[key]
+	if !found {
+		status = &EventHubConsumerStatus{State: EventHubConnecting}
+		eventHubConsumerStatusMap[key] = status
+	}
+	return status
+}
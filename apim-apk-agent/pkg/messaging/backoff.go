@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultReconnectInterval    time.Duration = 5 * time.Second
+	defaultMaxReconnectInterval time.Duration = 60 * time.Second
+	defaultReconnectRetryCount  int           = 5
+)
+
+// ReconnectParameters holds the parameters used to reconnect to the event hub when the broker
+// connection is lost
+type ReconnectParameters struct {
+	// InitialInterval is the delay before the first reconnect attempt
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound of the delay between two reconnect attempts
+	MaxInterval time.Duration
+	// Jitter is the fraction (0 to 1) by which each delay is randomly increased or decreased
+	Jitter float64
+	// RetryCount is the number of attempts made on a broker URL before failing over to the next
+	RetryCount int
+}
+
+// withDefaults returns the parameters with the default values set for the unset parameters
+func (parameters ReconnectParameters) withDefaults() ReconnectParameters {
+	if parameters.InitialInterval <= 0 {
+		parameters.InitialInterval = defaultReconnectInterval
+	}
+	if parameters.MaxInterval <= 0 {
+		parameters.MaxInterval = defaultMaxReconnectInterval
+	}
+	if parameters.RetryCount <= 0 {
+		parameters.RetryCount = defaultReconnectRetryCount
+	}
+	return parameters
+}
+
+// backoff computes exponentially growing delays between reconnect attempts
+type backoff struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	jitter          float64
+	attempt         int
+}
+
+func newBackoff(initialInterval, maxInterval time.Duration, jitter float64) *backoff {
+	if initialInterval <= 0 {
+		initialInterval = defaultReconnectInterval
+	}
+	if maxInterval < initialInterval {
+		maxInterval = initialInterval
+	}
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+	return &backoff{initialInterval: initialInterval, maxInterval: maxInterval, jitter: jitter}
+}
+
+// next returns the delay before the next attempt, doubling the interval on each call until it
+// reaches the maximum interval
+func (b *backoff) next() time.Duration {
+	interval := b.initialInterval
+	for i := 0; i < b.attempt && interval < b.maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > b.maxInterval {
+		interval = b.maxInterval
+	}
+	b.attempt++
+	if b.jitter == 0 {
+		return interval
+	}
+	delta := b.jitter * float64(interval)
+	return time.Duration(float64(interval) - delta + rand.Float64()*2*delta)
+}
+
+// reset restarts the delays from the initial interval
+func (b *backoff) reset() {
+	b.attempt = 0
+}
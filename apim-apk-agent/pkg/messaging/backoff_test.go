@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffGrowsExponentiallyUpToMaxInterval(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second, 0)
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		10 * time.Second, 10 * time.Second}
+	for i, interval := range expected {
+		assert.Equal(t, interval, b.next(), "unexpected delay for attempt %d", i+1)
+	}
+
+	b.reset()
+	assert.Equal(t, time.Second, b.next(), "delay should restart from the initial interval after reset")
+}
+
+func TestBackoffJitterStaysWithinBounds(t *testing.T) {
+	b := newBackoff(4*time.Second, 4*time.Second, 0.25)
+	for i := 0; i < 100; i++ {
+		interval := b.next()
+		assert.True(t, interval >= 3*time.Second && interval <= 5*time.Second,
+			"delay %v is outside the jitter bounds", interval)
+	}
+}
+
+func TestNewBackoffDefaults(t *testing.T) {
+	b := newBackoff(0, 0, 2)
+	assert.Equal(t, defaultReconnectInterval, b.initialInterval)
+	assert.Equal(t, defaultReconnectInterval, b.maxInterval)
+	assert.Equal(t, 1.0, b.jitter)
+}
@@ -27,121 +27,124 @@ import (
 	"time"
 
 	"github.com/streadway/amqp"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
 )
 
 var (
 	// NotificationChannel stores the Events for notifications
-	NotificationChannel chan amqp.Delivery
+	NotificationChannel chan Delivery
 	// KeyManagerChannel stores the key manager eventsv
-	KeyManagerChannel chan amqp.Delivery
+	KeyManagerChannel chan Delivery
 	// RevokedTokenChannel stores the revoked token events
-	RevokedTokenChannel chan amqp.Delivery
+	RevokedTokenChannel chan Delivery
 	// ThrottleDataChannel stores the throttling related events
-	ThrottleDataChannel chan amqp.Delivery
+	ThrottleDataChannel chan Delivery
 )
 
 func init() {
-	NotificationChannel = make(chan amqp.Delivery)
-	KeyManagerChannel = make(chan amqp.Delivery)
-	RevokedTokenChannel = make(chan amqp.Delivery)
-	ThrottleDataChannel = make(chan amqp.Delivery)
+	NotificationChannel = make(chan Delivery)
+	KeyManagerChannel = make(chan Delivery)
+	RevokedTokenChannel = make(chan Delivery)
+	ThrottleDataChannel = make(chan Delivery)
 }
 
 // EventListeningEndpoints represents the list of endpoints
 var EventListeningEndpoints []string
 
 // ConnectToRabbitMQ function tries to connect to the RabbitMQ server as long as it takes to establish a connection
-func connectToRabbitMQ() (*amqp.Connection, error) {
-	var err error
-	var conn *amqp.Connection
+func connectToRabbitMQ() *amqp.Connection {
 	amqpURIArray = retrieveAMQPURLList()
 	for index, url := range amqpURIArray {
 		logger.LoggerMsg.Infof("Dialing URI [%d] %q", index, maskURL(url.URL)+"/")
-		conn, err = amqp.Dial(url.URL)
+		conn, err := amqp.Dial(url.URL)
 		if err == nil {
 			logger.LoggerMsg.Infof("Successfully established the AMQP connection on URI [%d], %q", index, maskURL(url.URL)+"/")
-			return conn, nil
+			return conn
 		}
 	}
-	_, conn, err = connectionRetry("")
-	return conn, err
+	return connectionRetry("")
 }
 
-// reconnect reconnects to server if the connection or a channel
-// is closed unexpectedly.
-func (c *Consumer) reconnect(key string) {
-	var err error
-	shouldReconnect := false
-	connClose := <-c.Conn.NotifyClose(make(chan *amqp.Error))
-	connBlocked := c.Conn.NotifyBlocked(make(chan amqp.Blocking))
-	chClose := c.Channel.NotifyClose(make(chan *amqp.Error))
-
-	if connClose != nil {
-		shouldReconnect = true
-		logger.LoggerMsg.Errorf("CRITICAL: Connection dropped for %s, reconnecting...", key)
-	}
-
-	if connBlocked != nil {
-		shouldReconnect = true
-		logger.LoggerMsg.Errorf("CRITICAL: Connection blocked for %s, reconnecting...", key)
-	}
-
-	if chClose != nil {
-		shouldReconnect = true
-		logger.LoggerMsg.Errorf("CRITICAL: Channel closed for %s, reconnecting...", key)
-	}
+// consume subscribes to the events of the given binding key and keeps consuming them. Whenever the
+// connection or the channel is lost, the consumer reconnects with an exponential backoff and
+// resubscribes, so that a broker outage does not leave the agent without events.
+func consume(key string, conn *amqp.Connection) {
+	resubscribeBackoff := newBackoff(reconnectParameters.InitialInterval, reconnectParameters.MaxInterval,
+		reconnectParameters.Jitter)
+	for {
+		c := &Consumer{
+			Conn: conn,
+			Tag:  consumerTag + "-" + key,
+			Done: make(chan error),
+		}
+		deliveries, err := subscribe(c, key)
+		if err == nil {
+			health.SetEventHubConsumerConnected(key)
+			resubscribeBackoff.reset()
+			dispatchEvents(key, deliveries)
+			// the deliveries channel is closed once the channel or the connection is closed
+			select {
+			case closeErr := <-c.closeNotify:
+				if closeErr != nil {
+					err = closeErr
+				}
+			default:
+			}
+			logger.LoggerMsg.Errorf("CRITICAL: Channel closed for %s, reconnecting...", key)
+		} else {
+			logger.LoggerMsg.Errorf("Error while subscribing to %s events. %s", key, err)
+		}
+		health.SetEventHubConsumerReconnecting(key, 0, err)
 
-	if shouldReconnect {
-		c.Conn.Close()
-		c, RabbitConn, err = connectionRetry(key)
-		if err != nil {
-			logger.LoggerMsg.Errorf("Cannot establish connection for topic %s", key)
+		if conn.IsClosed() {
+			logger.LoggerMsg.Errorf("CRITICAL: Connection dropped for %s, reconnecting...", key)
+			conn = connectionRetry(key)
+			logger.LoggerMsg.Infof("Reconnected to topic %s", key)
+		} else {
+			if c.Channel != nil {
+				c.Channel.Close()
+			}
+			retryInterval := resubscribeBackoff.next()
+			logger.LoggerMsg.Infof("Resubscribing to topic %s after %v", key, retryInterval)
+			time.Sleep(retryInterval)
 		}
-	} else {
-		logger.LoggerMsg.Infof("NotifyClose from the connection and channel are %v and %v respectively, NotifyBlocked from the connection is %v",
-			connClose, chClose, connBlocked)
 	}
 }
 
-// connectionRetry
-func connectionRetry(key string) (*Consumer, *amqp.Connection, error) {
-	var err error
-	var i int
-
+// connectionRetry retries to connect with the broker URLs in order, waiting an exponentially
+// growing and randomized interval between the attempts. It blocks until a connection is established.
+func connectionRetry(key string) *amqp.Connection {
+	var attempts int
 	for j := 0; j < len(amqpURIArray); j++ {
 		var maxAttempt = amqpURIArray[j].retryCount
-		var retryInterval = time.Duration(amqpURIArray[j].connectionDelay) * time.Second
+		var initialInterval = time.Duration(amqpURIArray[j].connectionDelay) * time.Second
 
 		if maxAttempt == 0 {
-			maxAttempt = 5
+			maxAttempt = reconnectParameters.RetryCount
 		}
-
-		if retryInterval == 0 {
-			retryInterval = 10 * time.Second
+		if initialInterval == 0 {
+			initialInterval = reconnectParameters.InitialInterval
 		}
-		logger.LoggerMsg.Infof("Retrying to connect with %s (URI %d) in every %d seconds until exceed %d attempts",
-			maskURL(amqpURIArray[j].URL), j, amqpURIArray[j].connectionDelay, maxAttempt)
-
-		for i = 1; i <= maxAttempt; i++ {
+		retryBackoff := newBackoff(initialInterval, reconnectParameters.MaxInterval, reconnectParameters.Jitter)
+		logger.LoggerMsg.Infof("Retrying to connect with %s (URI %d) with an interval starting from %v up to %v until exceed %d attempts",
+			maskURL(amqpURIArray[j].URL), j, retryBackoff.initialInterval, retryBackoff.maxInterval, maxAttempt)
 
-			RabbitConn, err = amqp.Dial(amqpURIArray[j].URL + "/")
+		for i := 1; i <= maxAttempt; i++ {
+			attempts++
+			conn, err := amqp.Dial(amqpURIArray[j].URL + "/")
 			if err == nil {
 				logger.LoggerMsg.Infof("Successfully connected to %s (URI %d) after %d attempts", maskURL(amqpURIArray[j].URL), j, i)
-				if key != "" && len(key) > 0 {
-					logger.LoggerMsg.Infof("Reconnected to topic %s", key)
-					// startup pull
-					c := startConsumer(key)
-					return c, RabbitConn, nil
-				}
-				return nil, RabbitConn, nil
+				return conn
 			}
 
+			retryInterval := retryBackoff.next()
 			if key != "" && len(key) > 0 {
-				logger.LoggerMsg.Infof("Retry attempt %d for the %s (URI %d) to connect with topic %s has failed. Retrying after %d seconds", i,
-					maskURL(amqpURIArray[j].URL), j, key, amqpURIArray[j].connectionDelay)
+				health.SetEventHubConsumerReconnecting(key, attempts, err)
+				logger.LoggerMsg.Infof("Retry attempt %d for the %s (URI %d) to connect with topic %s has failed. Retrying after %v", i,
+					maskURL(amqpURIArray[j].URL), j, key, retryInterval)
 			} else {
-				logger.LoggerMsg.Infof("Retry attempt %d for the %s (URI %d) has failed. Retrying after %d seconds", i, maskURL(amqpURIArray[j].URL), j, amqpURIArray[j].connectionDelay)
+				logger.LoggerMsg.Infof("Retry attempt %d for the %s (URI %d) has failed. Retrying after %v", i, maskURL(amqpURIArray[j].URL), j, retryInterval)
 			}
 			time.Sleep(retryInterval)
 		}
@@ -150,7 +153,7 @@ func connectionRetry(key string) (*Consumer, *amqp.Connection, error) {
 			j = -1
 		}
 	}
-	return nil, RabbitConn, err
+	return nil
 }
 
 // retrieveAMQPURLList function extract AMQPURLList from EventListening connection url
@@ -204,14 +207,16 @@ type amqpFailoverURL struct {
 	connectionDelay int
 }
 
-func handleEvent(c *Consumer, key string) error {
+// subscribe declares a queue bound to the given key on a new channel and starts consuming from it
+func subscribe(c *Consumer, key string) (<-chan amqp.Delivery, error) {
 	var err error
 
 	logger.LoggerMsg.Debugf("Getting Channel for %s events.", key)
 	c.Channel, err = c.Conn.Channel()
 	if err != nil {
-		return fmt.Errorf("Channel: %s", err)
+		return nil, fmt.Errorf("Channel: %s", err)
 	}
+	c.closeNotify = c.Channel.NotifyClose(make(chan *amqp.Error, 1))
 
 	logger.LoggerMsg.Debugf("got Channel, declaring Exchange (%q)", exchange)
 	if err = c.Channel.ExchangeDeclare(
@@ -223,7 +228,7 @@ func handleEvent(c *Consumer, key string) error {
 		false,        // noWait
 		nil,          // arguments
 	); err != nil {
-		return fmt.Errorf("Exchange Declare: %s", err)
+		return nil, fmt.Errorf("Exchange Declare: %s", err)
 	}
 
 	logger.LoggerMsg.Infof("declared Exchange, declaring Queue %q", key+"queue")
@@ -236,7 +241,7 @@ func handleEvent(c *Consumer, key string) error {
 		nil,   // arguments
 	)
 	if err != nil {
-		return fmt.Errorf("Error while declaring queue: %s", err)
+		return nil, fmt.Errorf("Error while declaring queue: %s", err)
 	}
 
 	logger.LoggerMsg.Debugf("Binding to Exchange (key %q) after declaring the Queue (%q %d messages, %d consumers)",
@@ -249,7 +254,7 @@ func handleEvent(c *Consumer, key string) error {
 		false,      // noWait
 		nil,        // arguments
 	); err != nil {
-		return fmt.Errorf("Queue Bind: %s", err)
+		return nil, fmt.Errorf("Queue Bind: %s", err)
 	}
 	logger.LoggerMsg.Infof("Queue bound to Exchange, starting Consume (consumer tag %q) events", c.Tag)
 	deliveries, err := c.Channel.Consume(
@@ -261,41 +266,57 @@ func handleEvent(c *Consumer, key string) error {
 		false,      // noWait
 		nil,        // arguments
 	)
-	if strings.EqualFold(key, notification) {
-		for event := range deliveries {
-			NotificationChannel <- event
-		}
-	} else if strings.EqualFold(key, keymanager) {
-		for event := range deliveries {
-			KeyManagerChannel <- event
-		}
-	} else if strings.EqualFold(key, tokenRevocation) {
-		for event := range deliveries {
-			RevokedTokenChannel <- event
-		}
-	} else if strings.EqualFold(key, throttleData) {
-		for event := range deliveries {
-			ThrottleDataChannel <- event
-		}
+	if err != nil {
+		return nil, fmt.Errorf("Consume: %s", err)
+	}
+	return deliveries, nil
+}
+
+// dispatchEvents passes the consumed events to the channel of the given key until the deliveries
+// channel is closed
+func dispatchEvents(key string, deliveries <-chan amqp.Delivery) {
+	channel := eventChannel(key)
+	if channel == nil {
+		return
+	}
+	for delivery := range deliveries {
+		d := delivery
+		channel <- Delivery{Body: d.Body, ack: func() error { return d.Ack(false) }}
 	}
-	return nil
 }
 
 // InitiateJMSConnection to pass event consumption
-func InitiateJMSConnection(eventListeningEndpoints []string) error {
-	var err error
-	EventListeningEndpoints = eventListeningEndpoints
-	bindingKeys := []string{notification, keymanager, tokenRevocation, throttleData}
-	RabbitConn, err = connectToRabbitMQ()
-
-	if err == nil {
-		for i, key := range bindingKeys {
-			logger.LoggerMsg.Infof("Establishing consumer index %v for key %s ", i, key)
-			go func(key string) {
-				startConsumer(key)
-				select {}
-			}(key)
-		}
+func InitiateJMSConnection(eventListeningEndpoints []string, parameters ReconnectParameters) error {
+	return StartEventSource(NewJMSEventSource(eventListeningEndpoints, parameters))
+}
+
+// jmsEventSource consumes the events from the JMS (AMQP) broker of the traffic manager
+type jmsEventSource struct {
+	eventListeningEndpoints []string
+	parameters              ReconnectParameters
+}
+
+// NewJMSEventSource returns an event source consuming the events from the given JMS (AMQP) broker URLs in the
+// failover order
+func NewJMSEventSource(eventListeningEndpoints []string, parameters ReconnectParameters) EventSource {
+	return &jmsEventSource{eventListeningEndpoints: eventListeningEndpoints, parameters: parameters}
+}
+
+func (s *jmsEventSource) Name() string {
+	return "JMS"
+}
+
+func (s *jmsEventSource) Start(bindingKeys []string) error {
+	EventListeningEndpoints = s.eventListeningEndpoints
+	reconnectParameters = s.parameters.withDefaults()
+	RabbitConn = connectToRabbitMQ()
+	if RabbitConn == nil {
+		return fmt.Errorf("no valid event listening endpoint is configured")
 	}
-	return err
+
+	for i, key := range bindingKeys {
+		logger.LoggerMsg.Infof("Establishing consumer index %v for key %s ", i, key)
+		go consume(key, RabbitConn)
+	}
+	return nil
 }
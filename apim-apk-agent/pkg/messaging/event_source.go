@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+)
+
+// bindingKeys are the keys of the events consumed from the control plane
+var bindingKeys = []string{notification, keymanager, tokenRevocation, throttleData}
+
+// Delivery is an event consumed from the control plane, independent of the broker it was consumed from
+type Delivery struct {
+	Body []byte
+	ack  func() error
+}
+
+// Ack acknowledges that the event was processed, so that the broker does not redeliver it
+func (d Delivery) Ack() error {
+	if d.ack == nil {
+		return nil
+	}
+	return d.ack()
+}
+
+// TLSParameters holds the TLS configuration of the connections to a broker
+type TLSParameters struct {
+	Enabled bool
+	// CACertFile is the PEM file of the CA certificates used to verify the broker. The system CAs are used when
+	// not set.
+	CACertFile string
+	// CertFile and KeyFile are the PEM files of the client certificate used for mutual TLS
+	CertFile         string
+	KeyFile          string
+	SkipVerification bool
+}
+
+// EventSource consumes the events published by the control plane from a broker and passes them to the
+// event channels
+type EventSource interface {
+	// Name returns the name of the broker used in the logs
+	Name() string
+	// Start connects to the broker and keeps consuming the events of the given binding keys in the background
+	Start(bindingKeys []string) error
+}
+
+// StartEventSource starts consuming the events of all the binding keys from the given event source
+func StartEventSource(source EventSource) error {
+	for _, key := range bindingKeys {
+		health.SetEventHubConsumerConnecting(key)
+	}
+	logger.LoggerMsg.Infof("Consuming the control plane events from %s", source.Name())
+	return source.Start(bindingKeys)
+}
+
+// eventChannel returns the channel the events of the given binding key are passed to
+func eventChannel(key string) chan Delivery {
+	switch {
+	case strings.EqualFold(key, notification):
+		return NotificationChannel
+	case strings.EqualFold(key, keymanager):
+		return KeyManagerChannel
+	case strings.EqualFold(key, tokenRevocation):
+		return RevokedTokenChannel
+	case strings.EqualFold(key, throttleData):
+		return ThrottleDataChannel
+	}
+	return nil
+}
+
+func newTLSConfig(parameters TLSParameters) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: parameters.SkipVerification}
+	if parameters.CACertFile != "" {
+		caCerts, err := ioutil.ReadFile(parameters.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the CA certificates of the broker: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCerts) {
+			return nil, fmt.Errorf("no PEM certificate is found in %s", parameters.CACertFile)
+		}
+	}
+	if parameters.CertFile != "" || parameters.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(parameters.CertFile, parameters.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the client certificate of the broker: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,237 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+)
+
+const (
+	kafkaDialTimeout time.Duration = 10 * time.Second
+	// kafkaConsumerGroupPrefix prefixes the host name in the default consumer group. Each agent needs a consumer
+	// group of its own, as the agents sharing a consumer group would split the events among them.
+	kafkaConsumerGroupPrefix string = "apim-apk-agent-"
+)
+
+// Supported SASL mechanisms of Kafka
+const (
+	KafkaSASLPlain       string = "PLAIN"
+	KafkaSASLScramSHA256 string = "SCRAM-SHA-256"
+	KafkaSASLScramSHA512 string = "SCRAM-SHA-512"
+)
+
+// KafkaParameters holds the parameters used to consume the events of the control plane from Kafka
+type KafkaParameters struct {
+	// Brokers are the addresses (host:port) of the Kafka brokers
+	Brokers []string
+	// ConsumerGroup is the consumer group of the agent. Defaults to a group derived from the host name.
+	ConsumerGroup string
+	Topics        KafkaTopics
+	TLS           TLSParameters
+	SASL          KafkaSASLParameters
+}
+
+// KafkaTopics holds the Kafka topics the events of each binding key are published to. The binding key is used as
+// the topic when a topic is not set.
+type KafkaTopics struct {
+	Notification    string
+	KeyManager      string
+	TokenRevocation string
+	ThrottleData    string
+}
+
+// KafkaSASLParameters holds the SASL authentication of the connections to the Kafka brokers
+type KafkaSASLParameters struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512. SASL is not used when not set.
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+// topic returns the Kafka topic of the given binding key
+func (topics KafkaTopics) topic(key string) string {
+	var topic string
+	switch {
+	case strings.EqualFold(key, notification):
+		topic = topics.Notification
+	case strings.EqualFold(key, keymanager):
+		topic = topics.KeyManager
+	case strings.EqualFold(key, tokenRevocation):
+		topic = topics.TokenRevocation
+	case strings.EqualFold(key, throttleData):
+		topic = topics.ThrottleData
+	}
+	if topic == "" {
+		return key
+	}
+	return topic
+}
+
+// kafkaEventSource consumes the events from Kafka, for deployments where the JMS broker of the traffic manager is
+// replaced by Kafka
+type kafkaEventSource struct {
+	parameters          KafkaParameters
+	reconnectParameters ReconnectParameters
+}
+
+// NewKafkaEventSource returns an event source consuming the events from the given Kafka brokers
+func NewKafkaEventSource(parameters KafkaParameters, reconnectParameters ReconnectParameters) EventSource {
+	return &kafkaEventSource{parameters: parameters, reconnectParameters: reconnectParameters.withDefaults()}
+}
+
+func (s *kafkaEventSource) Name() string {
+	return "Kafka"
+}
+
+func (s *kafkaEventSource) Start(bindingKeys []string) error {
+	if len(s.parameters.Brokers) == 0 {
+		return errors.New("no Kafka broker is configured")
+	}
+	dialer, err := newKafkaDialer(s.parameters)
+	if err != nil {
+		return err
+	}
+	consumerGroup := s.parameters.ConsumerGroup
+	if consumerGroup == "" {
+		consumerGroup = defaultKafkaConsumerGroup()
+	}
+	for _, key := range bindingKeys {
+		topic := s.parameters.Topics.topic(key)
+		logger.LoggerMsg.Infof("Establishing the Kafka consumer of topic %s in consumer group %s for key %s",
+			topic, consumerGroup, key)
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers: s.parameters.Brokers,
+			GroupID: consumerGroup,
+			Topic:   topic,
+			Dialer:  dialer,
+			// As with the JMS topics, only the events published after the agent subscribed are consumed
+			StartOffset: kafka.LastOffset,
+			ErrorLogger: kafka.LoggerFunc(logger.LoggerMsg.Debugf),
+		})
+		go s.consume(key, topic, reader, dialer)
+	}
+	return nil
+}
+
+// consume keeps consuming the events of the given key. Whenever an event cannot be fetched, the consumer waits
+// with an exponential backoff until a broker is reachable again.
+func (s *kafkaEventSource) consume(key, topic string, reader *kafka.Reader, dialer *kafka.Dialer) {
+	retryBackoff := newBackoff(s.reconnectParameters.InitialInterval, s.reconnectParameters.MaxInterval,
+		s.reconnectParameters.Jitter)
+	channel := eventChannel(key)
+	ctx := context.Background()
+	connected := false
+	attempts := 0
+	for {
+		if !connected {
+			if err := s.dialAnyBroker(ctx, dialer); err != nil {
+				attempts++
+				retryInterval := retryBackoff.next()
+				health.SetEventHubConsumerReconnecting(key, attempts, err)
+				logger.LoggerMsg.Errorf("Error connecting to the Kafka brokers to consume topic %s. Retrying after %v: %v",
+					topic, retryInterval, err)
+				time.Sleep(retryInterval)
+				continue
+			}
+			connected = true
+			attempts = 0
+			retryBackoff.reset()
+			health.SetEventHubConsumerConnected(key)
+		}
+
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			logger.LoggerMsg.Errorf("CRITICAL: Error consuming the Kafka topic %s for %s, reconnecting... %v",
+				topic, key, err)
+			connected = false
+			health.SetEventHubConsumerReconnecting(key, attempts, err)
+			continue
+		}
+		if channel == nil {
+			continue
+		}
+		m := message
+		channel <- Delivery{Body: m.Value, ack: func() error { return reader.CommitMessages(ctx, m) }}
+	}
+}
+
+// dialAnyBroker checks whether at least one of the brokers is reachable
+func (s *kafkaEventSource) dialAnyBroker(ctx context.Context, dialer *kafka.Dialer) error {
+	var err error
+	for _, broker := range s.parameters.Brokers {
+		var conn *kafka.Conn
+		conn, err = dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+	}
+	return err
+}
+
+func defaultKafkaConsumerGroup() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "default"
+	}
+	return kafkaConsumerGroupPrefix + hostname
+}
+
+// newKafkaDialer returns the dialer used to connect to the brokers with the TLS and SASL configuration
+func newKafkaDialer(parameters KafkaParameters) (*kafka.Dialer, error) {
+	dialer := &kafka.Dialer{Timeout: kafkaDialTimeout, DualStack: true}
+	if parameters.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(parameters.TLS)
+		if err != nil {
+			return nil, err
+		}
+		dialer.TLS = tlsConfig
+	}
+	mechanism, err := newKafkaSASLMechanism(parameters.SASL)
+	if err != nil {
+		return nil, err
+	}
+	dialer.SASLMechanism = mechanism
+	return dialer, nil
+}
+
+func newKafkaSASLMechanism(parameters KafkaSASLParameters) (sasl.Mechanism, error) {
+	switch strings.ToUpper(parameters.Mechanism) {
+	case "":
+		return nil, nil
+	case KafkaSASLPlain:
+		return plain.Mechanism{Username: parameters.Username, Password: parameters.Password}, nil
+	case KafkaSASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, parameters.Username, parameters.Password)
+	case KafkaSASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, parameters.Username, parameters.Password)
+	}
+	return nil, fmt.Errorf("unsupported Kafka SASL mechanism %s. Supported mechanisms: %s, %s, %s",
+		parameters.Mechanism, KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512)
+}
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaTopics(t *testing.T) {
+	topics := KafkaTopics{Notification: "apim.notification"}
+	assert.Equal(t, "apim.notification", topics.topic(notification))
+	assert.Equal(t, keymanager, topics.topic(keymanager), "Binding key should be used when the topic is not set")
+}
+
+func TestNewKafkaSASLMechanism(t *testing.T) {
+	mechanism, err := newKafkaSASLMechanism(KafkaSASLParameters{})
+	assert.Nil(t, err)
+	assert.Nil(t, mechanism, "SASL should not be used when the mechanism is not set")
+
+	mechanism, err = newKafkaSASLMechanism(KafkaSASLParameters{Mechanism: "plain", Username: "agent", Password: "pwd"})
+	assert.Nil(t, err)
+	assert.Equal(t, plain.Mechanism{Username: "agent", Password: "pwd"}, mechanism)
+
+	mechanism, err = newKafkaSASLMechanism(KafkaSASLParameters{Mechanism: KafkaSASLScramSHA512, Username: "agent",
+		Password: "pwd"})
+	assert.Nil(t, err)
+	assert.Equal(t, KafkaSASLScramSHA512, mechanism.Name())
+
+	_, err = newKafkaSASLMechanism(KafkaSASLParameters{Mechanism: "GSSAPI"})
+	assert.NotNil(t, err)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	tlsConfig, err := newTLSConfig(TLSParameters{Enabled: true, SkipVerification: true})
+	assert.Nil(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.RootCAs, "System CAs should be used when the CA certificates are not set")
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caCertFile, []byte("not a certificate"), 0600))
+	_, err = newTLSConfig(TLSParameters{Enabled: true, CACertFile: caCertFile})
+	assert.NotNil(t, err)
+
+	_, err = newTLSConfig(TLSParameters{Enabled: true, CertFile: "missing.pem", KeyFile: "missing.key"})
+	assert.NotNil(t, err)
+}
+
+func TestNewKafkaDialer(t *testing.T) {
+	dialer, err := newKafkaDialer(KafkaParameters{TLS: TLSParameters{Enabled: true},
+		SASL: KafkaSASLParameters{Mechanism: KafkaSASLPlain}})
+	assert.Nil(t, err)
+	assert.NotNil(t, dialer.TLS)
+	assert.NotNil(t, dialer.SASLMechanism)
+
+	dialer, err = newKafkaDialer(KafkaParameters{})
+	assert.Nil(t, err)
+	assert.Nil(t, dialer.TLS, "TLS should not be used when it is not enabled")
+}
+
+func TestKafkaEventSourceWithoutBrokers(t *testing.T) {
+	err := NewKafkaEventSource(KafkaParameters{}, ReconnectParameters{}).Start(bindingKeys)
+	assert.NotNil(t, err)
+}
+
+func TestDeliveryAck(t *testing.T) {
+	assert.Nil(t, Delivery{}.Ack())
+	acked := false
+	assert.Nil(t, Delivery{ack: func() error { acked = true; return nil }}.Ack())
+	assert.True(t, acked)
+}
+
+func TestEventChannel(t *testing.T) {
+	assert.Equal(t, NotificationChannel, eventChannel(notification))
+	assert.Equal(t, ThrottleDataChannel, eventChannel(throttleData))
+	assert.Nil(t, eventChannel("unknown"))
+}
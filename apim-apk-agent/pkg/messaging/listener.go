@@ -32,6 +32,8 @@ var (
 	rabbitCloseError chan *amqp.Error
 	// amqpURIArray represents an array of amqpFailoverURL objects
 	amqpURIArray = make([]amqpFailoverURL, 0)
+	// reconnectParameters holds the backoff used to reconnect and resubscribe the consumers
+	reconnectParameters ReconnectParameters
 )
 
 const (
@@ -50,32 +52,6 @@ const (
 	organizationPurge  string = "organizationPurge"
 )
 
-// StartConsumer for provided key consume data
-func startConsumer(key string) *Consumer {
-	c := &Consumer{
-		Conn:    nil,
-		Channel: nil,
-		Tag:     consumerTag + "-key",
-		Done:    make(chan error),
-	}
-
-	c.Conn = RabbitConn
-	go func() {
-		c.reconnect(key)
-	}()
-	err := handleEvent(c, key)
-	if err != nil {
-		logger.LoggerMsg.Errorf("Error while handling event. %s", err)
-	}
-
-	logger.LoggerMsg.Infof("Shutting down the consumer")
-	if err := c.Shutdown(); err != nil {
-		logger.LoggerMsg.Errorf("Error during shutdown: %s", err)
-	}
-
-	return c
-}
-
 // Shutdown when error happens
 func (c *Consumer) Shutdown() error {
 
@@ -104,4 +80,6 @@ type Consumer struct {
 	Channel *amqp.Channel
 	Tag     string
 	Done    chan error
+	// closeNotify receives the reason when the channel is closed
+	closeNotify chan *amqp.Error
 }
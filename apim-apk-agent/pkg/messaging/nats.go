@@ -0,0 +1,251 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+)
+
+const (
+	natsDialTimeout    time.Duration = 10 * time.Second
+	natsRequestTimeout time.Duration = 10 * time.Second
+	natsDefaultAckWait time.Duration = 30 * time.Second
+	natsPullExpiry     time.Duration = 30 * time.Second
+	natsClientName     string        = "apim-apk-agent"
+	// natsDurablePrefix prefixes the host name in the default durable consumer names. Each agent needs durable
+	// consumers of its own, as the agents sharing a durable consumer would split the events among them.
+	natsDurablePrefix string = "apim-apk-agent-"
+)
+
+// NATSParameters holds the parameters used to consume the events of the control plane from NATS JetStream
+type NATSParameters struct {
+	// Servers are the URLs of the NATS servers (ex: nats://nats:4222)
+	Servers []string
+	// Stream is the JetStream stream the events are published to
+	Stream string
+	// Durable prefixes the names of the durable consumers of the agent. Defaults to a prefix derived from the host
+	// name.
+	Durable  string
+	Subjects NATSSubjects
+	// AckWait is the time after which an event that is not acknowledged is redelivered. Defaults to 30 seconds.
+	AckWait time.Duration
+	// MaxDeliver is the maximum number of times an event is delivered. Events are redelivered until they are
+	// acknowledged when not set.
+	MaxDeliver int
+	Username   string
+	Password   string
+	Token      string
+	TLS        TLSParameters
+}
+
+// NATSSubjects holds the subjects the events of each binding key are published to. The binding key is used as the
+// subject when a subject is not set.
+type NATSSubjects struct {
+	Notification    string
+	KeyManager      string
+	TokenRevocation string
+	ThrottleData    string
+}
+
+// subject returns the NATS subject of the given binding key
+func (subjects NATSSubjects) subject(key string) string {
+	var subject string
+	switch {
+	case strings.EqualFold(key, notification):
+		subject = subjects.Notification
+	case strings.EqualFold(key, keymanager):
+		subject = subjects.KeyManager
+	case strings.EqualFold(key, tokenRevocation):
+		subject = subjects.TokenRevocation
+	case strings.EqualFold(key, throttleData):
+		subject = subjects.ThrottleData
+	}
+	if subject == "" {
+		return key
+	}
+	return subject
+}
+
+// natsEventSource consumes the events from NATS JetStream through a durable pull consumer per binding key. The
+// events are acknowledged once they are processed, so that the events not processed are redelivered by the server,
+// including the events published while the agent was down.
+type natsEventSource struct {
+	parameters          NATSParameters
+	reconnectParameters ReconnectParameters
+	options             []nats.Option
+}
+
+// NewNATSEventSource returns an event source consuming the events from the given NATS JetStream stream
+func NewNATSEventSource(parameters NATSParameters, reconnectParameters ReconnectParameters) EventSource {
+	if parameters.AckWait <= 0 {
+		parameters.AckWait = natsDefaultAckWait
+	}
+	if parameters.Durable == "" {
+		parameters.Durable = defaultNATSDurablePrefix()
+	}
+	return &natsEventSource{parameters: parameters, reconnectParameters: reconnectParameters.withDefaults()}
+}
+
+func (s *natsEventSource) Name() string {
+	return "NATS JetStream"
+}
+
+func (s *natsEventSource) Start(bindingKeys []string) error {
+	if len(s.parameters.Servers) == 0 {
+		return errors.New("no NATS server is configured")
+	}
+	if s.parameters.Stream == "" {
+		return errors.New("no NATS JetStream stream is configured")
+	}
+	options, err := s.connectOptions()
+	if err != nil {
+		return err
+	}
+	s.options = options
+	for _, key := range bindingKeys {
+		subject := s.parameters.Subjects.subject(key)
+		durable := s.durableName(key)
+		logger.LoggerMsg.Infof("Establishing the NATS JetStream consumer %s of subject %s in stream %s for key %s",
+			durable, subject, s.parameters.Stream, key)
+		go s.consume(key, subject, durable)
+	}
+	return nil
+}
+
+// connectOptions returns the options of the connections to the servers. The client does not reconnect by itself,
+// as the consumers reconnect with the backoff of the reconnect parameters.
+func (s *natsEventSource) connectOptions() ([]nats.Option, error) {
+	options := []nats.Option{nats.Name(natsClientName), nats.Timeout(natsDialTimeout), nats.NoReconnect()}
+	if s.parameters.Username != "" {
+		options = append(options, nats.UserInfo(s.parameters.Username, s.parameters.Password))
+	}
+	if s.parameters.Token != "" {
+		options = append(options, nats.Token(s.parameters.Token))
+	}
+	if s.parameters.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(s.parameters.TLS)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, nats.Secure(tlsConfig))
+	}
+	return options, nil
+}
+
+// durableName returns the name of the durable consumer of the given binding key. The characters not allowed in
+// consumer names are replaced.
+func (s *natsEventSource) durableName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t':
+			return '_'
+		}
+		return r
+	}, s.parameters.Durable+"-"+key)
+}
+
+// consumerConfig returns the configuration of the durable consumer of the subject with explicit acknowledgements.
+// As with the JMS topics, only the events published after the consumer was created are consumed.
+func (s *natsEventSource) consumerConfig(subject, durable string) jetstream.ConsumerConfig {
+	return jetstream.ConsumerConfig{
+		Durable:       durable,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       s.parameters.AckWait,
+		MaxDeliver:    s.parameters.MaxDeliver,
+		FilterSubject: subject,
+	}
+}
+
+// consume keeps consuming the events of the given key. Whenever the connection fails, the consumer waits with an
+// exponential backoff until a server is reachable again. The events not acknowledged before the connection failed
+// are redelivered once the consumer is reconnected.
+func (s *natsEventSource) consume(key, subject, durable string) {
+	retryBackoff := newBackoff(s.reconnectParameters.InitialInterval, s.reconnectParameters.MaxInterval,
+		s.reconnectParameters.Jitter)
+	attempts := 0
+	for {
+		err := s.consumeUntilError(key, subject, durable, func() {
+			attempts = 0
+			retryBackoff.reset()
+			health.SetEventHubConsumerConnected(key)
+		})
+		attempts++
+		retryInterval := retryBackoff.next()
+		health.SetEventHubConsumerReconnecting(key, attempts, err)
+		logger.LoggerMsg.Errorf("CRITICAL: Error consuming the NATS subject %s for %s. Reconnecting after %v: %v",
+			subject, key, retryInterval, err)
+		time.Sleep(retryInterval)
+	}
+}
+
+// consumeUntilError connects to a server, binds the durable consumer and pulls the events one at a time until the
+// connection fails. Creating a consumer that already exists binds to it, so that the events published while the
+// agent was down are delivered.
+func (s *natsEventSource) consumeUntilError(key, subject, durable string, onConnected func()) error {
+	conn, err := nats.Connect(strings.Join(s.parameters.Servers, ","), s.options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), natsRequestTimeout)
+	consumer, err := js.CreateOrUpdateConsumer(ctx, s.parameters.Stream, s.consumerConfig(subject, durable))
+	cancel()
+	if err != nil {
+		return fmt.Errorf("error creating the consumer %s in stream %s: %w", durable, s.parameters.Stream, err)
+	}
+	onConnected()
+
+	channel := eventChannel(key)
+	for {
+		message, err := consumer.Next(jetstream.FetchMaxWait(natsPullExpiry))
+		if errors.Is(err, nats.ErrTimeout) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if channel == nil {
+			_ = message.Ack()
+			continue
+		}
+		channel <- Delivery{Body: message.Data(), ack: message.Ack}
+	}
+}
+
+func defaultNATSDurablePrefix() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "default"
+	}
+	return natsDurablePrefix + hostname
+}
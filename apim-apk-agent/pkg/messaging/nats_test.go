@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNATSServer accepts a single client connection, records the subscriptions of the client and returns the
+// messages it publishes
+type fakeNATSServer struct {
+	listener      net.Listener
+	conn          net.Conn
+	reader        *bufio.Reader
+	subscriptions map[string]string
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	return &fakeNATSServer{listener: listener, subscriptions: make(map[string]string)}
+}
+
+// accept accepts the connection of the client and responds to the PING sent with its CONNECT
+func (s *fakeNATSServer) accept(t *testing.T) {
+	conn, err := s.listener.Accept()
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+	s.send(t, `INFO {"server_id":"test","version":"2.10.0","headers":true,"max_payload":1048576,"proto":1}`+"\r\n")
+	assert.True(t, strings.HasPrefix(s.readLine(t), "CONNECT "))
+	assert.Equal(t, "PING", s.readLine(t))
+	s.send(t, "PONG\r\n")
+}
+
+func (s *fakeNATSServer) send(t *testing.T, data string) {
+	_, err := io.WriteString(s.conn, data)
+	assert.Nil(t, err)
+}
+
+// sendMessage sends the payload to the subscription of the client matching the subject
+func (s *fakeNATSServer) sendMessage(t *testing.T, subject, reply, payload string) {
+	sid, found := s.subscriptions[subject]
+	if !found {
+		sid = s.subscriptions[subject[:strings.LastIndex(subject, ".")]+".*"]
+	}
+	if reply != "" {
+		reply += " "
+	}
+	s.send(t, fmt.Sprintf("MSG %s %s %s%d\r\n%s\r\n", subject, sid, reply, len(payload), payload))
+}
+
+// readPublish reads the next PUB of the client and returns its subject, reply subject and payload
+func (s *fakeNATSServer) readPublish(t *testing.T) (string, string, string) {
+	line := s.readLine(t)
+	for strings.HasPrefix(line, "SUB ") || strings.HasPrefix(line, "UNSUB ") || line == "PING" || line == "PONG" {
+		if args := strings.Fields(line); args[0] == "SUB" {
+			s.subscriptions[args[1]] = args[len(args)-1]
+		}
+		line = s.readLine(t)
+	}
+	args := strings.Fields(line)
+	assert.Equal(t, "PUB", args[0], "Unexpected protocol message %s", line)
+	size, _ := strconv.Atoi(args[len(args)-1])
+	payload := make([]byte, size+2)
+	_, err := io.ReadFull(s.reader, payload)
+	assert.Nil(t, err)
+	reply := ""
+	if len(args) == 4 {
+		reply = args[2]
+	}
+	return args[1], reply, string(payload[:size])
+}
+
+func (s *fakeNATSServer) readLine(t *testing.T) string {
+	line, err := s.reader.ReadString('\n')
+	assert.Nil(t, err)
+	return strings.TrimRight(line, "\r\n")
+}
+
+func TestNATSSubjects(t *testing.T) {
+	subjects := NATSSubjects{Notification: "apim.notification"}
+	assert.Equal(t, "apim.notification", subjects.subject(notification))
+	assert.Equal(t, keymanager, subjects.subject(keymanager), "Binding key should be used when the subject is not set")
+}
+
+func TestNATSDurableName(t *testing.T) {
+	source := NewNATSEventSource(NATSParameters{Durable: "agent.eu-1"}, ReconnectParameters{}).(*natsEventSource)
+	assert.Equal(t, "agent_eu-1-notification", source.durableName(notification))
+	assert.Equal(t, natsDefaultAckWait, source.parameters.AckWait)
+
+	source = NewNATSEventSource(NATSParameters{}, ReconnectParameters{}).(*natsEventSource)
+	assert.True(t, strings.HasPrefix(source.parameters.Durable, natsDurablePrefix))
+}
+
+func TestNATSConsumerConfig(t *testing.T) {
+	source := NewNATSEventSource(NATSParameters{AckWait: 10 * time.Second, MaxDeliver: 5},
+		ReconnectParameters{}).(*natsEventSource)
+	assert.Equal(t, jetstream.ConsumerConfig{
+		Durable:       "agent-1-keymanager",
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       10 * time.Second,
+		MaxDeliver:    5,
+		FilterSubject: "apim.keymanager",
+	}, source.consumerConfig("apim.keymanager", "agent-1-keymanager"))
+}
+
+func TestNATSEventSourceWithoutServers(t *testing.T) {
+	err := NewNATSEventSource(NATSParameters{Stream: "APIM_EVENTS"}, ReconnectParameters{}).Start(bindingKeys)
+	assert.NotNil(t, err)
+	err = NewNATSEventSource(NATSParameters{Servers: []string{"nats:4222"}}, ReconnectParameters{}).Start(bindingKeys)
+	assert.NotNil(t, err, "Start should fail when the stream is not set")
+}
+
+func TestNATSEventSourceConsume(t *testing.T) {
+	server := newFakeNATSServer(t)
+	channel := make(chan Delivery, 1)
+	KeyManagerChannel, channel = channel, KeyManagerChannel
+	defer func() { KeyManagerChannel = channel }()
+
+	source := NewNATSEventSource(NATSParameters{Servers: []string{"nats://" + server.listener.Addr().String()},
+		Stream: "APIM_EVENTS", Durable: "agent-1", AckWait: 10 * time.Second},
+		ReconnectParameters{}).(*natsEventSource)
+	assert.Nil(t, source.Start([]string{keymanager}))
+	server.accept(t)
+
+	subject, inbox, payload := server.readPublish(t)
+	assert.Equal(t, "$JS.API.CONSUMER.CREATE.APIM_EVENTS.agent-1-keymanager.keymanager", subject)
+	var request struct {
+		Config jetstream.ConsumerConfig `json:"config"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(payload), &request))
+	assert.Equal(t, jetstream.AckExplicitPolicy, request.Config.AckPolicy)
+	assert.Equal(t, 10*time.Second, request.Config.AckWait)
+	server.sendMessage(t, inbox, "", `{"type":"io.nats.jetstream.api.v1.consumer_create_response",`+
+		`"stream_name":"APIM_EVENTS","name":"agent-1-keymanager","config":{"durable_name":"agent-1-keymanager"}}`)
+
+	subject, inbox, _ = server.readPublish(t)
+	assert.Equal(t, "$JS.API.CONSUMER.MSG.NEXT.APIM_EVENTS.agent-1-keymanager", subject)
+	ackSubject := "$JS.ACK.APIM_EVENTS.agent-1-keymanager.1.1.1.1700000000000000000.0"
+	server.sendMessage(t, inbox, ackSubject, "event")
+
+	var delivery Delivery
+	select {
+	case delivery = <-KeyManagerChannel:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The event was not passed to the key manager channel")
+	}
+	assert.Equal(t, "event", string(delivery.Body))
+	assert.Nil(t, delivery.Ack())
+	subject, _, payload = server.readPublish(t)
+	for strings.HasPrefix(subject, "$JS.API.CONSUMER.MSG.NEXT") {
+		subject, _, payload = server.readPublish(t)
+	}
+	assert.Equal(t, ackSubject, subject)
+	assert.Equal(t, "+ACK", payload)
+}
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
@@ -102,6 +103,12 @@ func IsPublicCertificate(certContent []byte) bool {
 
 // InvokeControlPlane sends request to the control plane and returns the response
 func InvokeControlPlane(req *http.Request, skipSSL bool) (*http.Response, error) {
+	return InvokeControlPlaneWithTimeout(req, skipSSL, 0)
+}
+
+// InvokeControlPlaneWithTimeout sends request to the given endpoint and returns the response. The request is
+// cancelled if it does not complete within the timeout. A zero timeout means no timeout.
+func InvokeControlPlaneWithTimeout(req *http.Request, skipSSL bool, timeout time.Duration) (*http.Response, error) {
 	tr := &http.Transport{}
 	if !skipSSL {
 		_, _, truststoreLocation := GetKeyLocations()
@@ -118,6 +125,7 @@ func InvokeControlPlane(req *http.Request, skipSSL bool) (*http.Response, error)
 	// Configuring the http client
 	client := &http.Client{
 		Transport: tr,
+		Timeout:   timeout,
 	}
 	return client.Do(req)
 }
@@ -21,12 +21,25 @@ function showUsageAndExit() {
 function detectPlatformSpecificBuild() {
     if [ ! -e "$platform" ]; then
       platform=$(uname -s)
+      machine=$(uname -m)
       if [[ "${platform}" == "Linux" ]]; then
-          platforms="linux/386/linux/i586 linux/amd64/linux/x64"
+          if [[ "${machine}" == "aarch64" || "${machine}" == "arm64" ]]; then
+              platforms="linux/arm64/linux/arm64"
+          else
+              platforms="linux/386/linux/i586 linux/amd64/linux/x64"
+          fi
       elif [[ "${platform}" == "Darwin" ]]; then
-          platforms="darwin/amd64/macosx/x64"
+          if [[ "${machine}" == "arm64" ]]; then
+              platforms="darwin/arm64/darwin/arm64"
+          else
+              platforms="darwin/amd64/macosx/x64"
+          fi
       else
-          platforms="windows/386/windows/i586 windows/amd64/windows/x64"
+          if [[ "${machine}" == "aarch64" || "${machine}" == "arm64" ]]; then
+              platforms="windows/arm64/windows/arm64"
+          else
+              platforms="windows/386/windows/i586 windows/amd64/windows/x64"
+          fi
       fi
     fi
 }
@@ -75,6 +88,11 @@ buildPath="$rootPath/${buildDir}"
 echo "Cleaning build path ${buildDir}..."
 rm -rf $buildPath
 
+manPath="$rootPath/build/man"
+echo "Generating man pages..."
+rm -rf $manPath
+go run tools/manGen.go $manPath
+
 filename=$(basename ${target})
 baseDir=$(dirname ${target})
 if [ ".go" == ${filename:(-3)} ]
@@ -89,7 +107,7 @@ if [ "${full_build}" == "true" ]; then
 # the following line give an error in MacOS
 #    echo "Building "$'\e[1m'"${filename^^}:${build_version}"$'\e[0m'" for all platforms..."
     # string format: {GOOS}/{GOARCH}/{ZIP_FILE_OS_NAME}/{ZIP_FILE_ARCH_NAME}
-    platforms="darwin/amd64/darwin/amd64 darwin/arm64/darwin/arm64 linux/386/linux/i586 linux/arm64/linux/arm64 linux/amd64/linux/amd64 windows/386/windows/i586 windows/amd64/windows/x64"
+    platforms="darwin/amd64/darwin/amd64 darwin/arm64/darwin/arm64 linux/386/linux/i586 linux/arm64/linux/arm64 linux/amd64/linux/amd64 windows/386/windows/i586 windows/amd64/windows/x64 windows/arm64/windows/arm64"
 else
     detectPlatformSpecificBuild
     echo "Building "$'\e[1m'"${filename^^}:${build_version}"$'\e[0m'" for detected "$'\e[1m'"${platform}"$'\e[0m'" platform..."
@@ -127,6 +145,7 @@ do
 
     if [[ "windows" != "$goos" ]]; then
       cp -r "${baseDir}/shell-completions/apictl_bash_completions.sh" $zipdir > /dev/null 2>&1
+      cp -r $manPath $zipdir > /dev/null 2>&1
     fi
 
     # set destination path for binary
@@ -24,7 +24,9 @@ import (
 
 const AddCmdLiteral = "add"
 const AddCmdShortDesc = "Add Environment to Config file"
-const AddCmdLongDesc = `Add new environment and its related endpoints to the config file`
+const AddCmdLongDesc = `Add new environment and its related endpoints to the config file
+Create an Application in an environment
+Subscribe an Application to an API or API Product in an environment`
 const addCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` production \
 --apim  https://localhost:9443 
 
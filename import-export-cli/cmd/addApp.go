@@ -0,0 +1,108 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var addAppEnvironment string
+var addAppName string
+var addAppTier string
+var addAppDescription string
+var addAppTokenType string
+var addAppAttributes []string
+var addAppGroups []string
+
+// AddApp command related usage info
+const addAppCmdLiteral = "app"
+const addAppCmdShortDesc = "Create an Application"
+const addAppCmdLongDesc = "Create an Application of the user in the environment specified by the flag --environment, -e " +
+	"with the given throttling tier, attributes and groups"
+
+const addAppCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + addAppCmdLiteral + ` -n MyApp --tier Unlimited -e dev
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + addAppCmdLiteral + ` -n MyApp --tier 10PerMin --attributes team=payments --attributes env=qa --groups g1,g2 -e dev
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + addAppCmdLiteral + ` -n MyApp -d "Payments application" --token-type OAUTH -e prod
+NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.`
+
+// addAppCmd represents the add app command
+var addAppCmd = &cobra.Command{
+	Use:     addAppCmdLiteral,
+	Short:   addAppCmdShortDesc,
+	Long:    addAppCmdLongDesc,
+	Example: addAppCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AddCmdLiteral + " " + addAppCmdLiteral + " called")
+		cred, err := GetCredentials(addAppEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeAddAppCmd(cred)
+	},
+}
+
+func executeAddAppCmd(credential credentials.Credential) {
+	attributes, err := impl.ParseAppAttributes(addAppAttributes)
+	if err != nil {
+		utils.HandleErrorAndExit("Error creating the Application", err)
+	}
+	tokenType := addAppTokenType
+	if tokenType == "" {
+		tokenType = utils.GetMainConfigFromFile(utils.MainConfigFilePath).Config.TokenType
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, addAppEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while creating the Application", err)
+	}
+	app, err := impl.AddApplication(accessToken, addAppEnvironment, &impl.ApplicationRequest{
+		Name:             addAppName,
+		ThrottlingPolicy: addAppTier,
+		Description:      addAppDescription,
+		TokenType:        tokenType,
+		Groups:           addAppGroups,
+		Attributes:       attributes,
+	})
+	if err != nil {
+		utils.HandleErrorAndExit("Error creating the Application "+addAppName, err)
+	}
+	fmt.Println("Application " + app.Name + " created successfully. Application ID: " + app.ApplicationID)
+}
+
+func init() {
+	AddCmd.AddCommand(addAppCmd)
+	addAppCmd.Flags().StringVarP(&addAppName, "name", "n", "", "Name of the Application")
+	addAppCmd.Flags().StringVarP(&addAppTier, "tier", "", "Unlimited",
+		"Throttling tier (business plan) of the Application")
+	addAppCmd.Flags().StringVarP(&addAppDescription, "description", "d", "", "Description of the Application")
+	addAppCmd.Flags().StringVarP(&addAppTokenType, "token-type", "", "",
+		"Token type of the Application (JWT or OAUTH). The token type in the main config is used if not given")
+	addAppCmd.Flags().StringSliceVarP(&addAppAttributes, "attributes", "", []string{},
+		"Attributes of the Application given as key=value")
+	addAppCmd.Flags().StringSliceVarP(&addAppGroups, "groups", "", []string{},
+		"Groups to share the Application with")
+	addAppCmd.Flags().StringVarP(&addAppEnvironment, "environment", "e", "",
+		"Environment to create the Application in")
+	_ = addAppCmd.MarkFlagRequired("name")
+	_ = addAppCmd.MarkFlagRequired("environment")
+}
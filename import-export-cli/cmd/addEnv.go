@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -33,12 +35,18 @@ var flagRegistrationEndpoint string // registration endpoint of the environment
 var flagApiManagerEndpoint string   // api manager endpoint of the environment to be added
 var flagAdminEndpoint string        // admin endpoint of the environment to be added
 var flagMiManagementEndpoint string // mi management endpoint of the environment to be added
+var flagAnalyticsEndpoint string    // analytics endpoint of the environment to be added
+var flagVaultEndpoint string        // vault endpoint of the environment to be added
+var flagAllowedCommands []string    // commands allowed in the environment to be added
+var flagReadOnly bool               // whether only the read-only commands are allowed in the environment to be added
 
 // AddEnv command related Info
 const AddEnvCmdLiteral = "env [environment]"
 const AddEnvCmdLiteralTrimmed = "env"
 const addEnvCmdShortDesc = "Add Environment to Config file"
-const addEnvCmdLongDesc = "Add new environment and its related endpoints to the config file"
+const addEnvCmdLongDesc = `Add new environment and its related endpoints to the config file
+
+An environment can also be defined without writing the config file (eg: when running in a container with a read-only file system) by setting the APICTL_ENV_<ENV>_APIM, _PUBLISHER, _DEVPORTAL, _REGISTRATION, _ADMIN, _TOKEN_ENDPOINT and _MI environment variables, where <ENV> is the upper cased name of the environment. The config files are not created when the config file does not exist and such an environment is defined.`
 const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` production \
 --apim  https://localhost:9443 
 
@@ -65,7 +73,24 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 You can either provide only the flag --apim , or all the other 4 flags (--registration --publisher --devportal --admin) without providing --apim flag.
 If you are omitting any of --registration --publisher --devportal --admin flags, you need to specify --apim flag with the API Manager endpoint. In both of the
 cases --token flag is optional and use it to specify the gateway token endpoint. This will be used for "apictl get-keys" operation.
-To add a micro integrator instance to an environment you can use the --mi flag.`
+To add a micro integrator instance to an environment you can use the --mi flag.
+To retrieve API usage summaries using "apictl get api-usage", specify the analytics REST endpoint using the --analytics flag.
+To resolve the vault:<path>#<key> references in the params files from HashiCorp Vault, specify the Vault endpoint using the --vault flag.
+The Vault token is read from the APICTL_ENV_<ENV>_VAULT_TOKEN or the VAULT_TOKEN environment variable.
+
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` ci-prod \
+--apim https://apim.com:9443 \
+--read-only
+
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` ci-staging \
+--apim https://apim.com:9443 \
+--allowed-commands "get apis","export api","import api"
+
+To reduce the impact of a leaked configuration, such as the configuration of a shared CI pipeline, restrict the commands
+which can be run against an environment using the --allowed-commands flag. A command is allowed if it starts with one of
+the allowed commands, hence "get" allows "get keys" which creates an application and subscribes it to the API.
+The --read-only flag allows only the get and export commands which do not change the environment. The login and logout
+commands are allowed in every environment.`
 
 // addEnvCmd represents the addEnv command
 var addEnvCmd = &cobra.Command{
@@ -92,6 +117,16 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints.AdminEndpoint = flagAdminEndpoint
 	envEndpoints.TokenEndpoint = flagTokenEndpoint
 	envEndpoints.MiManagementEndpoint = flagMiManagementEndpoint
+	envEndpoints.AnalyticsEndpoint = flagAnalyticsEndpoint
+	envEndpoints.VaultEndpoint = flagVaultEndpoint
+	envEndpoints.AllowedCommands = flagAllowedCommands
+	if flagReadOnly {
+		if len(flagAllowedCommands) > 0 {
+			utils.HandleErrorAndExit("Error adding environment",
+				errors.New("--read-only and --allowed-commands cannot be used together"))
+		}
+		envEndpoints.ReadOnly = true
+	}
 	err := impl.AddEnv(envToBeAdded, envEndpoints, mainConfigFilePath, AddEnvCmdLiteral)
 	if err != nil {
 		utils.HandleErrorAndExit("Error adding environment", err)
@@ -110,5 +145,13 @@ func init() {
 		"Registration endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAdminEndpoint, "admin", "", "Admin endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagMiManagementEndpoint, "mi", "", "Micro Integrator Management endpoint for the environment")
+	addEnvCmd.Flags().StringVar(&flagAnalyticsEndpoint, "analytics", "",
+		"Analytics REST endpoint for the environment. This will be used for \"apictl get api-usage\" operation")
+	addEnvCmd.Flags().StringVar(&flagVaultEndpoint, "vault", "",
+		"HashiCorp Vault endpoint used to resolve the vault: references in the params files")
+	addEnvCmd.Flags().StringSliceVar(&flagAllowedCommands, "allowed-commands", []string{},
+		"Commands allowed to be run against the environment (ex: get,export). All the commands are allowed if not given")
+	addEnvCmd.Flags().BoolVar(&flagReadOnly, "read-only", false,
+		"Allow only the get and export commands which do not change the environment")
 	_ = addEnvCmd.MarkFlagRequired("environment")
 }
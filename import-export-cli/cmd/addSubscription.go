@@ -0,0 +1,103 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var addSubscriptionEnvironment string
+var addSubscriptionApp string
+var addSubscriptionAPI string
+var addSubscriptionProvider string
+var addSubscriptionTier string
+
+// AddSubscription command related usage info
+const addSubscriptionCmdLiteral = "subscription"
+const addSubscriptionCmdShortDesc = "Subscribe an Application to an API"
+const addSubscriptionCmdLongDesc = "Subscribe an Application of the user to an API or API Product in the environment " +
+	"specified by the flag --environment, -e with the given subscription tier. If the Application is already " +
+	"subscribed to the API, the existing subscription is kept as it is"
+
+const addSubscriptionCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + addSubscriptionCmdLiteral + ` --app MyApp --api PizzaShackAPI:1.0.0 --tier Gold -e dev
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + addSubscriptionCmdLiteral + ` --app MyApp --api PizzaShackAPI:1.0.0 --provider admin --tier Unlimited -e prod
+NOTE: The flags (--app, --api, --tier and --environment (-e)) are mandatory.`
+
+// addSubscriptionCmd represents the add subscription command
+var addSubscriptionCmd = &cobra.Command{
+	Use:     addSubscriptionCmdLiteral,
+	Short:   addSubscriptionCmdShortDesc,
+	Long:    addSubscriptionCmdLongDesc,
+	Example: addSubscriptionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AddCmdLiteral + " " + addSubscriptionCmdLiteral + " called")
+		cred, err := GetCredentials(addSubscriptionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeAddSubscriptionCmd(cred)
+	},
+}
+
+func executeAddSubscriptionCmd(credential credentials.Credential) {
+	apiName, apiVersion, err := impl.ParseAPIIdentifier(addSubscriptionAPI)
+	if err != nil {
+		utils.HandleErrorAndExit("Error subscribing to the API", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, addSubscriptionEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while subscribing to the API", err)
+	}
+	subscription, created, err := impl.AddSubscription(accessToken, addSubscriptionEnvironment, addSubscriptionApp,
+		apiName, apiVersion, addSubscriptionProvider, addSubscriptionTier)
+	if err != nil {
+		utils.HandleErrorAndExit("Error subscribing the Application "+addSubscriptionApp+" to the API "+
+			addSubscriptionAPI, err)
+	}
+	if created {
+		fmt.Println("Application " + addSubscriptionApp + " subscribed to the API " + addSubscriptionAPI +
+			" successfully. Subscription ID: " + subscription.SubscriptionID)
+	} else {
+		fmt.Println("Application " + addSubscriptionApp + " is already subscribed to the API " + addSubscriptionAPI +
+			" with the tier " + subscription.ThrottlingPolicy + ". Subscription ID: " + subscription.SubscriptionID)
+	}
+}
+
+func init() {
+	AddCmd.AddCommand(addSubscriptionCmd)
+	addSubscriptionCmd.Flags().StringVarP(&addSubscriptionApp, "app", "", "",
+		"Name of the Application to subscribe")
+	addSubscriptionCmd.Flags().StringVarP(&addSubscriptionAPI, "api", "", "",
+		"API or API Product to subscribe to, given as <name>:<version>")
+	addSubscriptionCmd.Flags().StringVarP(&addSubscriptionProvider, "provider", "r", "",
+		"Provider of the API or API Product")
+	addSubscriptionCmd.Flags().StringVarP(&addSubscriptionTier, "tier", "", "",
+		"Subscription tier (business plan) of the subscription")
+	addSubscriptionCmd.Flags().StringVarP(&addSubscriptionEnvironment, "environment", "e", "",
+		"Environment of the Application and the API")
+	_ = addSubscriptionCmd.MarkFlagRequired("app")
+	_ = addSubscriptionCmd.MarkFlagRequired("api")
+	_ = addSubscriptionCmd.MarkFlagRequired("tier")
+	_ = addSubscriptionCmd.MarkFlagRequired("environment")
+}
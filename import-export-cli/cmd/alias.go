@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// alias command related usage Info
+const AliasCmdLiteral = "alias"
+const aliasCmdShortDesc = "Manage the aliases of frequently used commands"
+const aliasCmdLongDesc = `Manage the aliases of frequently used commands. An alias is stored in the main config file and is
+expanded to its command when it is given as the first argument of ` + utils.ProjectName + `. The arguments given after the
+alias are passed through to the command.`
+
+const aliasCmdExamples = utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasSetCmdLiteral + ` dep "import api --update -e prod --params prod.yaml"
+` + utils.ProjectName + ` dep -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasListCmdLiteral + `
+` + utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasDeleteCmdLiteral + ` dep`
+
+// AliasCmd represents the alias command
+var AliasCmd = &cobra.Command{
+	Use:     AliasCmdLiteral,
+	Short:   aliasCmdShortDesc,
+	Long:    aliasCmdLongDesc,
+	Example: aliasCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AliasCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+// ExpandAlias replaces an alias given as the first argument with its command. Commands of apictl take precedence
+// over the aliases with the same name.
+func ExpandAlias() {
+	args := os.Args[1:]
+	if len(args) == 0 || isBuiltInCommand(args[0]) {
+		return
+	}
+	expandedArgs, expanded, err := utils.ExpandAlias(args, utils.GetAliases(utils.MainConfigFilePath))
+	if err != nil {
+		utils.HandleErrorAndExit("Error expanding the alias "+args[0], err)
+	}
+	if expanded {
+		utils.Logln(utils.LogPrefixInfo+"Expanded the alias "+args[0]+" to:", expandedArgs)
+		RootCmd.SetArgs(expandedArgs)
+	}
+}
+
+// isBuiltInCommand returns whether name is the name or an alias of a command of apictl
+func isBuiltInCommand(name string) bool {
+	for _, command := range RootCmd.Commands() {
+		if command.Name() == name || command.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+func init() {
+	RootCmd.AddCommand(AliasCmd)
+}
@@ -0,0 +1,53 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// "alias delete" command related usage Info
+const AliasDeleteCmdLiteral = "delete"
+const aliasDeleteCmdShortDesc = "Delete an alias"
+const aliasDeleteCmdLongDesc = `Delete an alias from the main config file`
+
+const aliasDeleteCmdExamples = utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasDeleteCmdLiteral + ` dep`
+
+// aliasDeleteCmd represents the alias delete command
+var aliasDeleteCmd = &cobra.Command{
+	Use:     AliasDeleteCmdLiteral + " <name>",
+	Short:   aliasDeleteCmdShortDesc,
+	Long:    aliasDeleteCmdLongDesc,
+	Example: aliasDeleteCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AliasCmdLiteral + " " + AliasDeleteCmdLiteral + " called")
+		if err := utils.RemoveAlias(args[0], utils.MainConfigFilePath); err != nil {
+			utils.HandleErrorAndExit("Error deleting the alias "+args[0], err)
+		}
+		fmt.Println("Successfully deleted the alias " + args[0])
+	},
+}
+
+func init() {
+	AliasCmd.AddCommand(aliasDeleteCmd)
+}
@@ -0,0 +1,54 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var aliasListCmdFormat string
+
+// "alias list" command related usage Info
+const AliasListCmdLiteral = "list"
+const aliasListCmdShortDesc = "List the aliases"
+const aliasListCmdLongDesc = `List the aliases and the commands they are expanded to`
+
+const aliasListCmdExamples = utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasListCmdLiteral + `
+` + utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasListCmdLiteral + ` --format "{{.Name}}"`
+
+// aliasListCmd represents the alias list command
+var aliasListCmd = &cobra.Command{
+	Use:     AliasListCmdLiteral,
+	Short:   aliasListCmdShortDesc,
+	Long:    aliasListCmdLongDesc,
+	Example: aliasListCmdExamples,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AliasCmdLiteral + " " + AliasListCmdLiteral + " called")
+		impl.PrintAliases(utils.GetAliases(utils.MainConfigFilePath), aliasListCmdFormat)
+	},
+}
+
+func init() {
+	AliasCmd.AddCommand(aliasListCmd)
+	aliasListCmd.Flags().StringVarP(&aliasListCmdFormat, "format", "", "", "Pretty-print the aliases "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+}
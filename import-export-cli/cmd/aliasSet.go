@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// "alias set" command related usage Info
+const AliasSetCmdLiteral = "set"
+const aliasSetCmdShortDesc = "Set an alias for a command"
+const aliasSetCmdLongDesc = `Set an alias for a command, without the ` + utils.ProjectName + ` prefix. The command of an existing alias is replaced.
+The name of an alias cannot be the name of a command of ` + utils.ProjectName + `.`
+
+const aliasSetCmdExamples = utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasSetCmdLiteral + ` dep "import api --update -e prod --params prod.yaml"
+` + utils.ProjectName + ` ` + AliasCmdLiteral + ` ` + AliasSetCmdLiteral + ` prod-apis "get apis -e prod --format '{{.Name}} {{.Version}}'"`
+
+// aliasSetCmd represents the alias set command
+var aliasSetCmd = &cobra.Command{
+	Use:     AliasSetCmdLiteral + " <name> <command>",
+	Short:   aliasSetCmdShortDesc,
+	Long:    aliasSetCmdLongDesc,
+	Example: aliasSetCmdExamples,
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + AliasCmdLiteral + " " + AliasSetCmdLiteral + " called")
+		if isBuiltInCommand(args[0]) {
+			utils.HandleErrorAndExit("Error setting the alias "+args[0],
+				fmt.Errorf("%s is a command of %s", args[0], utils.ProjectName))
+		}
+		if err := utils.SetAlias(args[0], args[1], utils.MainConfigFilePath); err != nil {
+			utils.HandleErrorAndExit("Error setting the alias "+args[0], err)
+		}
+		fmt.Println("Successfully set the alias " + args[0])
+	},
+}
+
+func init() {
+	AliasCmd.AddCommand(aliasSetCmd)
+}
@@ -109,11 +109,11 @@ var InitCmd = &cobra.Command{
 			fmt.Printf("%s already exists\n", initCmdOutputDir)
 			if !stat.IsDir() {
 				fmt.Printf("%s is not a directory\n", initCmdOutputDir)
-				os.Exit(1)
+				utils.Exit(1)
 			}
 			if !awsInitCmdForced {
 				fmt.Println("Run with -f or --force to overwrite directory and create project")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 			fmt.Println("Running command in forced mode")
 		}
@@ -411,7 +411,8 @@ func initializeProject() error {
 	initCmdInitialState := "CREATED"
 	initCmdApiDefinitionPath := ""
 	advertiseOnly := true
-	err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, path, initCmdApiDefinitionPath, advertiseOnly)
+	err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, path, "", "", initCmdApiDefinitionPath, nil,
+		advertiseOnly, "")
 	if err != nil {
 		utils.HandleErrorAndContinue("Error initializing project", err)
 		// Remove the already created project with its content since it is partially created and wrong
@@ -54,7 +54,7 @@ var BundleCmd = &cobra.Command{
 		if stat, err := os.Stat(bundleSource); !os.IsNotExist(err) {
 			if !stat.IsDir() {
 				fmt.Printf("%s is not a directory\n", bundleSource)
-				os.Exit(1)
+				utils.Exit(1)
 			}
 		}
 
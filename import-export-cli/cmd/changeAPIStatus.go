@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"net/http"
@@ -34,33 +36,87 @@ var apiNameForStateChange string
 var apiVersionForStateChange string
 var apiProviderForStateChange string
 var apiStateChangeAction string
+var apiStateChangeFormat string
+var apiStateChangeFile string
+var apiStateChangeQuery string
+var apiStateChangeContinueOnError bool
 
 // ChangeAPIStatus command related usage info
 const changeAPIStatusCmdLiteral = "api"
 const changeAPIStatusCmdShortDesc = "Change Status of an API"
-const changeAPIStatusCmdLongDesc = "Change the lifecycle status of an API in an environment"
+const changeAPIStatusCmdLongDesc = `Change the lifecycle status of an API in an environment.
+The status of multiple APIs can be changed at once by listing them in a YAML file given by flag (--file, -f) or by
+selecting them with a search query given by flag (--query, -q). The outcome of each API is reported and the remaining
+APIs are skipped after the first failure unless the flag (--continue-on-error) is given.`
 
 const changeAPIStatusCmdExamples = utils.ProjectName + ` ` + changeStatusCmdLiteral + ` ` + changeAPIStatusCmdLiteral + ` -a Publish -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + changeStatusCmdLiteral + ` ` + changeAPIStatusCmdLiteral + ` -a Publish -n FacebookAPI -v 2.1.0 -e production
-NOTE: The 4 flags (--action (-a), --name (-n), --version (-v), and --environment (-e)) are mandatory.`
+` + utils.ProjectName + ` ` + changeStatusCmdLiteral + ` ` + changeAPIStatusCmdLiteral + ` -a Publish -n FacebookAPI -v 2.1.0 -e production --format json
+` + utils.ProjectName + ` ` + changeStatusCmdLiteral + ` ` + changeAPIStatusCmdLiteral + ` -a Publish -f ./apis.yaml -e production
+` + utils.ProjectName + ` ` + changeStatusCmdLiteral + ` ` + changeAPIStatusCmdLiteral + ` -a Publish -q "status:CREATED tag:retail" -e dev --continue-on-error
+NOTE: Both the flags (--action (-a) and --environment (-e)) are mandatory. Either the flags (--name (-n) and --version (-v)),
+the flag (--file (-f)) or the flag (--query (-q)) should be given.
+The file lists the name, version and optionally the provider of each API:
+  - name: PizzaShackAPI
+    version: 1.0.0
+  - name: TwitterAPI
+    version: 2.0.0
+    provider: alice`
 
 // changeAPIStatusCmd represents change-status api command
 var ChangeAPIStatusCmd = &cobra.Command{
 	Use: changeAPIStatusCmdLiteral + " (--action <action-of-the-api-state-change> --name <name-of-the-api> --version <version-of-the-api> --provider " +
-		"<provider-of-the-api> --environment <environment-from-which-the-api-state-should-be-changed>)",
+		"<provider-of-the-api> --environment <environment-from-which-the-api-state-should-be-changed>) | " +
+		"(--action <action-of-the-api-state-change> (--file <file-listing-the-apis> | --query <search-query-of-the-apis>) " +
+		"--environment <environment-from-which-the-api-state-should-be-changed>)",
 	Short:   changeAPIStatusCmdShortDesc,
 	Long:    changeAPIStatusCmdLongDesc,
 	Example: changeAPIStatusCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + changeAPIStatusCmdLiteral + " called")
+		batch := apiStateChangeFile != "" || apiStateChangeQuery != ""
+		if !batch && (apiNameForStateChange == "" || apiVersionForStateChange == "") {
+			utils.HandleErrorAndExit("Invalid flags",
+				errors.New("either --name and --version, --file or --query should be given"))
+		}
 		cred, err := GetCredentials(apiStateChangeEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials ", err)
 		}
+		if batch {
+			executeChangeAPIsStatusCmd(cred)
+			return
+		}
 		executeChangeAPIStatusCmd(cred)
 	},
 }
 
+// executeChangeAPIsStatusCmd changes the status of the APIs listed in a file or matching a search query
+func executeChangeAPIsStatusCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, apiStateChangeEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while changing status of the APIs", err)
+	}
+	var targets []impl.APIStatusChangeTarget
+	if apiStateChangeFile != "" {
+		targets, err = impl.ReadAPIStatusChangeTargets(apiStateChangeFile)
+	} else {
+		targets, err = impl.SearchAPIStatusChangeTargets(accessToken, apiStateChangeEnvironment, apiStateChangeQuery)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error while finding the APIs to change the status of", err)
+	}
+	results := impl.ChangeAPIsStatusInEnv(accessToken, apiStateChangeEnvironment, apiStateChangeAction, targets,
+		apiStateChangeContinueOnError)
+	impl.PrintAPIStatusChangeResults(results, len(targets), apiStateChangeFormat)
+	for _, result := range results {
+		if result.Error != "" {
+			utils.HandleErrorAndExit("Error while changing the status of the APIs",
+				errors.New("the status of one or more APIs was not changed"))
+		}
+	}
+}
+
 // executeChangeAPIStatusCmd executes the change api status command
 func executeChangeAPIStatusCmd(credential credentials.Credential) {
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, apiStateChangeEnvironment)
@@ -72,7 +128,10 @@ func executeChangeAPIStatusCmd(credential credentials.Credential) {
 		}
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		if resp.StatusCode() == http.StatusOK {
+		if resp.StatusCode() == http.StatusOK && apiStateChangeFormat == utils.JsonFormatType {
+			// 200 OK
+			printChangeAPIStatusResult(accessToken, resp.Body())
+		} else if resp.StatusCode() == http.StatusOK {
 			// 200 OK
 			fmt.Println(apiNameForStateChange + " API state changed successfully!")
 		} else if resp.StatusCode() == http.StatusInternalServerError {
@@ -88,6 +147,27 @@ func executeChangeAPIStatusCmd(credential credentials.Credential) {
 	}
 }
 
+// printChangeAPIStatusResult prints the result of the lifecycle change in json format
+func printChangeAPIStatusResult(accessToken string, body []byte) {
+	lifecycleChangeResponse := &utils.LifecycleChangeResponse{}
+	if err := json.Unmarshal(body, lifecycleChangeResponse); err != nil {
+		utils.HandleErrorAndExit("Error while reading the lifecycle change response", err)
+	}
+	apiId, err := impl.GetAPIId(accessToken, apiStateChangeEnvironment, apiNameForStateChange,
+		apiVersionForStateChange, apiProviderForStateChange)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting API Id of the state changed API", err)
+	}
+	utils.PrintJsonOutput(utils.ChangeAPIStatusResult{
+		Id:             apiId,
+		Name:           apiNameForStateChange,
+		Version:        apiVersionForStateChange,
+		Action:         apiStateChangeAction,
+		State:          lifecycleChangeResponse.LifecycleState.State,
+		WorkflowStatus: lifecycleChangeResponse.WorkflowStatus,
+	})
+}
+
 func init() {
 	ChangeStatusCmd.AddCommand(ChangeAPIStatusCmd)
 	ChangeAPIStatusCmd.Flags().StringVarP(&apiStateChangeAction, "action", "a", "",
@@ -100,9 +180,18 @@ func init() {
 		"Provider of the API")
 	ChangeAPIStatusCmd.Flags().StringVarP(&apiStateChangeEnvironment, "environment", "e",
 		"", "Environment of which the API state should be changed")
+	ChangeAPIStatusCmd.Flags().StringVarP(&apiStateChangeFormat, "format", "", "", "Output format of the "+
+		"state change result. Use \"json\" to print the API id and the new lifecycle state in json format")
+	ChangeAPIStatusCmd.Flags().StringVarP(&apiStateChangeFile, "file", "f", "",
+		"YAML file listing the name, version and provider of the APIs to be state changed")
+	ChangeAPIStatusCmd.Flags().StringVarP(&apiStateChangeQuery, "query", "q", "",
+		"Search query selecting the APIs to be state changed")
+	ChangeAPIStatusCmd.Flags().BoolVarP(&apiStateChangeContinueOnError, "continue-on-error", "", false,
+		"Continue changing the status of the remaining APIs when the status of an API cannot be changed")
 	// Mark required flags
 	_ = ChangeAPIStatusCmd.MarkFlagRequired("action")
-	_ = ChangeAPIStatusCmd.MarkFlagRequired("name")
-	_ = ChangeAPIStatusCmd.MarkFlagRequired("version")
 	_ = ChangeAPIStatusCmd.MarkFlagRequired("environment")
+	ChangeAPIStatusCmd.MarkFlagsRequiredTogether("name", "version")
+	ChangeAPIStatusCmd.MarkFlagsMutuallyExclusive("name", "file", "query")
+	ChangeAPIStatusCmd.MarkFlagsMutuallyExclusive("version", "file", "query")
 }
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var compareCmdFormat string
+
+// Compare command related usage Info
+const CompareCmdLiteral = "compare"
+const compareCmdShortDesc = "Compare two exported archives"
+
+const compareCmdLongDesc = `Compare two exported API archives or projects, such as the archives exported from two environments after a promotion.
+The yaml and json files are normalized as done by export api --normalize before comparing, so that only the fields which differ are listed.
+The command exits with status 1 if the archives differ.`
+
+const compareCmdExamples = utils.ProjectName + ` ` + CompareCmdLiteral + ` ./dev/PizzaShackAPI_1.0.0.zip ./prod/PizzaShackAPI_1.0.0.zip
+` + utils.ProjectName + ` ` + CompareCmdLiteral + ` ./PizzaShackAPI ./PizzaShackAPI_1.0.0.zip --format json`
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:     CompareCmdLiteral + " <source-path> <target-path>",
+	Short:   compareCmdShortDesc,
+	Long:    compareCmdLongDesc,
+	Example: compareCmdExamples,
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + CompareCmdLiteral + " called")
+		comparison, err := impl.CompareArchives(args[0], args[1])
+		if err != nil {
+			utils.HandleErrorAndExit("Error while comparing "+args[0]+" and "+args[1], err)
+		}
+		impl.PrintArchiveComparison(comparison, compareCmdFormat)
+		if !comparison.Identical() {
+			utils.ExitWithOutput(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringVarP(&compareCmdFormat, "format", "", "",
+		"Output format of the differences. Use \"json\" to output the differences in JSON")
+}
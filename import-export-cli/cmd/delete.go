@@ -30,11 +30,13 @@ const deleteCmdLiteral = "delete"
 const deleteCmdShortDesc = "Delete an API/APIProduct/Application in an environment"
 const deleteCmdLongDesc = `Delete an API available in the environment specified by flag (--environment, -e)
 Delete an API Product available in the environment specified by flag (--environment, -e)
-Delete an Application of a specific user in the environment specified by flag (--environment, -e)`
+Delete an Application of a specific user in the environment specified by flag (--environment, -e)
+Delete the subscription of an Application to an API in the environment specified by flag (--environment, -e)`
 
 const deleteCmdExamples = utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAPIProductCmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev 
-` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAppCmdLiteral + ` -n TestApplication -o admin -e dev`
+` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAppCmdLiteral + ` -n TestApplication -o admin -e dev
+` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteSubscriptionCmdLiteral + ` --app TestApplication --api TwitterAPI:1.0.0 -e dev`
 
 // DeleteCmd represents the delete command
 var DeleteCmd = &cobra.Command{
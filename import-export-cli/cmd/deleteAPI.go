@@ -32,14 +32,17 @@ var deleteAPIEnvironment string
 var deleteAPIName string
 var deleteAPIVersion string
 var deleteAPIProvider string
+var deleteAPIPreview bool
 
 // DeleteAPI command related usage info
 const deleteAPICmdLiteral = "api"
 const deleteAPICmdShortDesc = "Delete API"
-const deleteAPICmdLongDesc = "Delete an API from an environment"
+const deleteAPICmdLongDesc = "Delete an API from an environment. Use --preview to list the subscriptions, the API " +
+	"Products and the deployed revisions affected by deleting the API without deleting it."
 
 const deleteAPICmdExamplesDefault = utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAPICmdLiteral + ` -n FacebookAPI -v 2.1.0 -e production
+` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteAPICmdLiteral + ` -n FacebookAPI -v 2.1.0 -e production --preview
 NOTE: The 3 flags (--name (-n), --version (-v), and --environment (-e)) are mandatory.`
 
 // DeleteAPICmd represents the delete api command
@@ -71,6 +74,15 @@ var DeleteAPICmd = &cobra.Command{
 func executeDeleteAPICmd(credential credentials.Credential) {
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAPIEnvironment)
 	if preCommandErr == nil {
+		if deleteAPIPreview {
+			preview, err := impl.GetDeleteAPIPreviewFromEnv(accessToken, deleteAPIEnvironment, deleteAPIName,
+				deleteAPIVersion, deleteAPIProvider)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while retrieving the artifacts affected by deleting the API ", err)
+			}
+			impl.PrintDeleteAPIPreview(deleteAPIName, deleteAPIVersion, preview)
+			return
+		}
 		resp, err := impl.DeleteAPI(accessToken, deleteAPIEnvironment, deleteAPIName, deleteAPIVersion, deleteAPIProvider)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting API ", err)
@@ -93,6 +105,8 @@ func init() {
 		"Provider of the API to be deleted")
 	DeleteAPICmd.Flags().StringVarP(&deleteAPIEnvironment, "environment", "e",
 		"", "Environment from which the API should be deleted")
+	DeleteAPICmd.Flags().BoolVarP(&deleteAPIPreview, "preview", "", false,
+		"List the subscriptions, API Products and deployed revisions affected by deleting the API without deleting it")
 
 	// fetches the main-config.yaml file silently; i.e. if it's not created, ignore the error and assume that
 	//	this is the default mode.
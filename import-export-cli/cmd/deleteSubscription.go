@@ -0,0 +1,93 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deleteSubscriptionEnvironment string
+var deleteSubscriptionApp string
+var deleteSubscriptionAPI string
+var deleteSubscriptionProvider string
+
+// DeleteSubscription command related usage info
+const deleteSubscriptionCmdLiteral = "subscription"
+const deleteSubscriptionCmdShortDesc = "Unsubscribe an Application from an API"
+const deleteSubscriptionCmdLongDesc = "Remove the subscription of an Application of the user to an API or API " +
+	"Product in the environment specified by the flag --environment, -e"
+
+const deleteSubscriptionCmdExamples = utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteSubscriptionCmdLiteral + ` --app MyApp --api PizzaShackAPI:1.0.0 -e dev
+` + utils.ProjectName + ` ` + deleteCmdLiteral + ` ` + deleteSubscriptionCmdLiteral + ` --app MyApp --api PizzaShackAPI:1.0.0 --provider admin -e prod
+NOTE: The flags (--app, --api and --environment (-e)) are mandatory.`
+
+// DeleteSubscriptionCmd represents the delete subscription command
+var DeleteSubscriptionCmd = &cobra.Command{
+	Use:     deleteSubscriptionCmdLiteral,
+	Short:   deleteSubscriptionCmdShortDesc,
+	Long:    deleteSubscriptionCmdLongDesc,
+	Example: deleteSubscriptionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + deleteCmdLiteral + " " + deleteSubscriptionCmdLiteral + " called")
+		cred, err := GetCredentials(deleteSubscriptionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeDeleteSubscriptionCmd(cred)
+	},
+}
+
+func executeDeleteSubscriptionCmd(credential credentials.Credential) {
+	apiName, apiVersion, err := impl.ParseAPIIdentifier(deleteSubscriptionAPI)
+	if err != nil {
+		utils.HandleErrorAndExit("Error removing the subscription", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, deleteSubscriptionEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while removing the subscription", err)
+	}
+	subscription, err := impl.DeleteSubscription(accessToken, deleteSubscriptionEnvironment, deleteSubscriptionApp,
+		apiName, apiVersion, deleteSubscriptionProvider)
+	if err != nil {
+		utils.HandleErrorAndExit("Error removing the subscription of the Application "+deleteSubscriptionApp+
+			" to the API "+deleteSubscriptionAPI, err)
+	}
+	fmt.Println("Subscription " + subscription.SubscriptionID + " of the Application " + deleteSubscriptionApp +
+		" to the API " + deleteSubscriptionAPI + " removed successfully!")
+}
+
+func init() {
+	DeleteCmd.AddCommand(DeleteSubscriptionCmd)
+	DeleteSubscriptionCmd.Flags().StringVarP(&deleteSubscriptionApp, "app", "", "",
+		"Name of the subscribed Application")
+	DeleteSubscriptionCmd.Flags().StringVarP(&deleteSubscriptionAPI, "api", "", "",
+		"Subscribed API or API Product, given as <name>:<version>")
+	DeleteSubscriptionCmd.Flags().StringVarP(&deleteSubscriptionProvider, "provider", "r", "",
+		"Provider of the API or API Product")
+	DeleteSubscriptionCmd.Flags().StringVarP(&deleteSubscriptionEnvironment, "environment", "e", "",
+		"Environment of the Application and the API")
+	_ = DeleteSubscriptionCmd.MarkFlagRequired("app")
+	_ = DeleteSubscriptionCmd.MarkFlagRequired("api")
+	_ = DeleteSubscriptionCmd.MarkFlagRequired("environment")
+}
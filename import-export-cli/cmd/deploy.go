@@ -0,0 +1,47 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Deploy command related usage Info
+const GatewayDeployCmdLiteral = "deploy"
+const gatewayDeployCmdShortDesc = "Deploy an API revision to gateway environments"
+const gatewayDeployCmdLongDesc = `Deploy a revision of an API available in the environment specified by flag (--environment, -e) to the gateway
+environments and vhosts specified by flag (--gateway-env, -g)`
+
+const gatewayDeployCmdExamples = utils.ProjectName + ` ` + GatewayDeployCmdLiteral + ` ` + DeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 2 -g Default -e dev`
+
+// GatewayDeployCmd represents the deploy command
+var GatewayDeployCmd = &cobra.Command{
+	Use:     GatewayDeployCmdLiteral,
+	Short:   gatewayDeployCmdShortDesc,
+	Long:    gatewayDeployCmdLongDesc,
+	Example: gatewayDeployCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GatewayDeployCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(GatewayDeployCmd)
+}
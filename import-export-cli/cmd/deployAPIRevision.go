@@ -0,0 +1,105 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployAPIRevisionName string
+var deployAPIRevisionVersion string
+var deployAPIRevisionProvider string
+var deployAPIRevisionNum string
+var deployAPIRevisionGatewayEnvs []string
+var deployAPIRevisionHideOnDevportal bool
+var deployAPIRevisionEnvironment string
+
+// DeployAPIRevisionCmd command related usage info
+const DeployAPIRevisionCmdLiteral = "api-revision"
+const deployAPIRevisionCmdShortDesc = "Deploy an API revision"
+
+const deployAPIRevisionCmdLongDesc = `Deploy a revision of an API to the gateway environments given by flag (--gateway-env, -g).
+The vhost to deploy to can be given after the gateway environment (ex: us-region=us.api.example.com). The revision is deployed to
+the first vhost of the gateway environment otherwise. The revisions of an API can be listed with the get api-revisions command.`
+
+const deployAPIRevisionCmdExamples = utils.ProjectName + ` ` + GatewayDeployCmdLiteral + ` ` + DeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 2 -g Default -e dev
+` + utils.ProjectName + ` ` + GatewayDeployCmdLiteral + ` ` + DeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r alice --rev 3 -g Default -g us-region=us.api.example.com -e production
+` + utils.ProjectName + ` ` + GatewayDeployCmdLiteral + ` ` + DeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 3 -g internal --hide-on-devportal -e production
+NOTE: All the 5 flags (--name (-n), --version (-v), --rev, --gateway-env (-g) and --environment (-e)) are mandatory.`
+
+// DeployAPIRevisionCmd represents the deploy api-revision command
+var DeployAPIRevisionCmd = &cobra.Command{
+	Use: DeployAPIRevisionCmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --provider <provider-of-the-api> " +
+		"--rev <revision-number-of-the-api> --gateway-env <gateway-environment>[=<vhost>] " +
+		"--environment <environment-of-the-api>)",
+	Short:   deployAPIRevisionCmdShortDesc,
+	Long:    deployAPIRevisionCmdLongDesc,
+	Example: deployAPIRevisionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DeployAPIRevisionCmdLiteral + " called")
+		deployments, err := impl.ParseRevisionDeployments(deployAPIRevisionGatewayEnvs, !deployAPIRevisionHideOnDevportal)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid gateway environments", err)
+		}
+		cred, err := GetCredentials(deployAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, deployAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to deploy the API revision", err)
+		}
+		err = impl.DeployAPIRevisionToGateways(accessToken, deployAPIRevisionEnvironment, deployAPIRevisionName,
+			deployAPIRevisionVersion, deployAPIRevisionProvider, deployAPIRevisionNum, deployments)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while deploying the API revision", err)
+		}
+		fmt.Println("Revision " + deployAPIRevisionNum + " of API " + deployAPIRevisionName + "_" +
+			deployAPIRevisionVersion + " successfully deployed to the specified gateway environments")
+	},
+}
+
+// init using Cobra
+func init() {
+	GatewayDeployCmd.AddCommand(DeployAPIRevisionCmd)
+	DeployAPIRevisionCmd.Flags().StringVarP(&deployAPIRevisionName, "name", "n", "",
+		"Name of the API")
+	DeployAPIRevisionCmd.Flags().StringVarP(&deployAPIRevisionVersion, "version", "v", "",
+		"Version of the API")
+	DeployAPIRevisionCmd.Flags().StringVarP(&deployAPIRevisionProvider, "provider", "r", "",
+		"Provider of the API")
+	DeployAPIRevisionCmd.Flags().StringVarP(&deployAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to deploy")
+	DeployAPIRevisionCmd.Flags().StringSliceVarP(&deployAPIRevisionGatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment to deploy the revision to, optionally followed by the vhost (ex: us-region=us.api.example.com)")
+	DeployAPIRevisionCmd.Flags().BoolVarP(&deployAPIRevisionHideOnDevportal, "hide-on-devportal", "", false,
+		"Do not display the gateway environments of the deployments in the Developer Portal")
+	DeployAPIRevisionCmd.Flags().StringVarP(&deployAPIRevisionEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = DeployAPIRevisionCmd.MarkFlagRequired("name")
+	_ = DeployAPIRevisionCmd.MarkFlagRequired("version")
+	_ = DeployAPIRevisionCmd.MarkFlagRequired("rev")
+	_ = DeployAPIRevisionCmd.MarkFlagRequired("gateway-env")
+	_ = DeployAPIRevisionCmd.MarkFlagRequired("environment")
+}
@@ -19,17 +19,11 @@
 package deprecated
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 )
 
 // Executes all deprecated child commands.
 // This is called by main.main(). It only needs to happen once.
 func Execute() {
-	if err := cmd.RootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(-1)
-	}
+	cmd.Execute()
 }
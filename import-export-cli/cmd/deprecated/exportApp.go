@@ -79,7 +79,7 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
+			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp, nil)
 		} else {
 			fmt.Println("Error " + string(resp.Body()))
 		}
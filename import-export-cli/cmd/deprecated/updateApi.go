@@ -31,20 +31,6 @@ import (
 )
 
 const updateCmdLiteral = "update"
-const updateCmdShortDesc = "Update an API to the kubernetes cluster"
-const updateCmdLongDesc = `Update an existing API with Swagger file in the kubernetes cluster. JSON and YAML formats are accepted.`
-const updateCmdExamples = utils.ProjectName + " " + updateCmdLiteral + " " + addApiCmdLiteral + " " + `-n petstore --from-file=./Swagger.json --replicas=1 --namespace=wso2
-
-` + utils.ProjectName + " " + updateCmdLiteral + " " + addApiCmdLiteral + " " + `-n petstore --from-file=./product-apim-tooling/import-export-cli/build/target/apictl/myapi --replicas=1 --namespace=wso2`
-
-// updateCmd represents the update command
-var updateCmdDeprecated = &cobra.Command{
-	Use:        updateCmdLiteral,
-	Short:      updateCmdShortDesc,
-	Long:       updateCmdLongDesc,
-	Example:    updateCmdExamples,
-	Deprecated: "instead use \"" + k8s.K8sCmdLiteral + " " + k8s.K8sUpdateCmdLiteral + "\".",
-}
 
 // updateApiCmd represents the updateApi command
 var updateApiCmdDeprecated = &cobra.Command{
@@ -79,8 +65,7 @@ var updateApiCmdDeprecated = &cobra.Command{
 }
 
 func init() {
-	cmd.RootCmd.AddCommand(updateCmdDeprecated)
-	updateCmdDeprecated.AddCommand(updateApiCmdDeprecated)
+	cmd.UpdateCmd.AddCommand(updateApiCmdDeprecated)
 	updateApiCmdDeprecated.Flags().StringVarP(&flagApiName, "name", "n", "", "Name of the API")
 	updateApiCmdDeprecated.Flags().StringArrayVarP(&flagSwaggerFilePaths, "from-file", "f", []string{}, "Path to swagger file")
 	updateApiCmdDeprecated.Flags().IntVar(&flagReplicas, "replicas", 1, "replica set")
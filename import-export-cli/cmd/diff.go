@@ -0,0 +1,47 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Diff command related usage Info
+const DiffCmdLiteral = "diff"
+const diffCmdShortDesc = "Compare a local project with an API deployed in an environment"
+const diffCmdLongDesc = `Compare a local project with the artifact of an API deployed in an environment, so that the
+changes made to the deployed API outside of the project can be detected`
+
+const diffCmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` ./PizzaShackAPI -e production`
+
+// DiffCmd represents the diff command
+var DiffCmd = &cobra.Command{
+	Use:     DiffCmdLiteral,
+	Short:   diffCmdShortDesc,
+	Long:    diffCmdLongDesc,
+	Example: diffCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(DiffCmd)
+}
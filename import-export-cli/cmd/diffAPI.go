@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	diffAPIEnvironment    string
+	diffAPIProvider       string
+	diffAPIRevisionNum    string
+	diffAPILatestRevision bool
+	diffAPICmdFormat      string
+)
+
+// DiffAPI command related usage Info
+const DiffAPICmdLiteral = "api"
+const diffAPICmdShortDesc = "Compare a local API project with the API deployed in an environment"
+const diffAPICmdLongDesc = `Compare a local API project or archive with the same API exported from the environment specified by --environment(-e).
+The API is looked up by the name and version in the project. The working copy of the API is compared unless a revision is given by --rev or --latest.
+The yaml and json files are normalized as done by export api --normalize before comparing, so that only the fields which differ are listed.
+The files ignored by the ` + utils.ProjectIgnoreFileName + ` file of the project are not compared.
+The command exits with status 1 if the API has drifted from the project.
+NOTE: The flag (--environment (-e)) is mandatory`
+
+const diffAPICmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` ./PizzaShackAPI -e production
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` ./PizzaShackAPI_1.0.0.zip -e production --latest
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` ./PizzaShackAPI -e production -r admin --rev 2 --format json`
+
+// DiffAPICmd represents the diff api command
+var DiffAPICmd = &cobra.Command{
+	Use:     DiffAPICmdLiteral + " <project-path> --environment <environment>",
+	Short:   diffAPICmdShortDesc,
+	Long:    diffAPICmdLongDesc,
+	Example: diffAPICmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " " + DiffAPICmdLiteral + " called")
+		if diffAPILatestRevision && diffAPIRevisionNum != "" {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("--rev cannot be used with --latest"))
+		}
+		cred, err := GetCredentials(diffAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, diffAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for comparing the API", err)
+		}
+		comparison, err := impl.DiffAPI(accessToken, diffAPIEnvironment, args[0], diffAPIProvider,
+			diffAPIRevisionNum, diffAPILatestRevision)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while comparing "+args[0]+" with the API in "+diffAPIEnvironment, err)
+		}
+		impl.PrintArchiveComparison(comparison, diffAPICmdFormat)
+		if !comparison.Identical() {
+			utils.ExitWithOutput(1)
+		}
+	},
+}
+
+func init() {
+	DiffCmd.AddCommand(DiffAPICmd)
+	DiffAPICmd.Flags().StringVarP(&diffAPIEnvironment, "environment", "e", "",
+		"Environment of the API to compare with")
+	DiffAPICmd.Flags().StringVarP(&diffAPIProvider, "provider", "r", "",
+		"Provider of the API. The provider in the project is used if not given")
+	DiffAPICmd.Flags().StringVarP(&diffAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to compare with")
+	DiffAPICmd.Flags().BoolVarP(&diffAPILatestRevision, "latest", "", false,
+		"Compare with the latest revision of the API")
+	DiffAPICmd.Flags().StringVarP(&diffAPICmdFormat, "format", "", "",
+		"Output format of the differences. Use \"json\" to output the differences in JSON")
+	_ = DiffAPICmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,47 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Docs command related usage Info
+const DocsCmdLiteral = "docs"
+const docsCmdShortDesc = "Generate the docs of the commands"
+const docsCmdLongDesc = `Generate the docs of every command of ` + utils.ProjectName + ` as markdown files or man pages,
+so that the complete usage can be browsed offline`
+
+const docsCmdExamples = utils.ProjectName + ` ` + DocsCmdLiteral + ` ` + DocsGenerateCmdLiteral + ` --format man -o ./man`
+
+// DocsCmd represents the docs command
+var DocsCmd = &cobra.Command{
+	Use:     DocsCmdLiteral,
+	Short:   docsCmdShortDesc,
+	Long:    docsCmdLongDesc,
+	Example: docsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DocsCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(DocsCmd)
+}
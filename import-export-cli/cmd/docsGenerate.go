@@ -0,0 +1,98 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Formats of the generated docs
+const (
+	docsFormatMarkdown = "markdown"
+	docsFormatMan      = "man"
+)
+
+var docsGenerateFormat string
+var docsGenerateOutputDir string
+
+// DocsGenerateCmd command related usage info
+const DocsGenerateCmdLiteral = "generate"
+const docsGenerateCmdShortDesc = "Generate the docs of every command"
+const docsGenerateCmdLongDesc = `Generate a markdown file or a man page for every command of ` + utils.ProjectName + ` in the
+directory specified by flag (--output, -o)`
+
+const docsGenerateCmdExamples = utils.ProjectName + ` ` + DocsCmdLiteral + ` ` + DocsGenerateCmdLiteral + ` -o ./docs
+` + utils.ProjectName + ` ` + DocsCmdLiteral + ` ` + DocsGenerateCmdLiteral + ` --format man -o ./man
+NOTE: The generated man pages can be browsed with "man -M ./man apictl"`
+
+// DocsGenerateCmd represents the docs generate command
+var DocsGenerateCmd = &cobra.Command{
+	Use:     DocsGenerateCmdLiteral + " [--format <markdown|man>] [--output <output-directory>]",
+	Short:   docsGenerateCmdShortDesc,
+	Long:    docsGenerateCmdLongDesc,
+	Example: docsGenerateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DocsGenerateCmdLiteral + " called")
+		if err := GenerateDocs(docsGenerateFormat, docsGenerateOutputDir); err != nil {
+			utils.HandleErrorAndExit("Error generating the docs", err)
+		}
+		fmt.Println("Docs generated in " + docsGenerateOutputDir)
+	},
+}
+
+// GenerateDocs generates the docs of every command in the given format to outputDir. The man pages are written to
+// the man1 directory of outputDir, following the layout expected by man.
+func GenerateDocs(format, outputDir string) error {
+	RootCmd.DisableAutoGenTag = true
+	switch format {
+	case docsFormatMarkdown:
+		if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+			return err
+		}
+		return doc.GenMarkdownTree(RootCmd, outputDir)
+	case docsFormatMan:
+		manDir := filepath.Join(outputDir, "man1")
+		if err := os.MkdirAll(manDir, os.ModePerm); err != nil {
+			return err
+		}
+		header := &doc.GenManHeader{
+			Title:   strings.ToUpper(utils.ProjectName),
+			Section: "1",
+			Source:  utils.ProjectName + " " + Version,
+		}
+		return doc.GenManTree(RootCmd, header, manDir)
+	default:
+		return fmt.Errorf("unsupported format %s, use %s or %s", format, docsFormatMarkdown, docsFormatMan)
+	}
+}
+
+func init() {
+	DocsCmd.AddCommand(DocsGenerateCmd)
+	DocsGenerateCmd.Flags().StringVarP(&docsGenerateFormat, "format", "", docsFormatMarkdown,
+		"Format of the docs. Use \"markdown\" or \"man\"")
+	DocsGenerateCmd.Flags().StringVarP(&docsGenerateOutputDir, "output", "o", utils.ProjectName+"-docs",
+		"Directory to write the docs to")
+}
@@ -0,0 +1,67 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var explainCmdTargetVersion string
+var explainCmdRecursive bool
+
+// Explain command related usage Info
+const ExplainCmdLiteral = "explain"
+const explainCmdShortDesc = "Describe the fields of artifact files"
+
+const explainCmdLongDesc = `Describe the fields of the api.yaml, api_params.yaml, application.yaml and deployment_environments.yaml artifact files as supported by the APIM version given by flag (--target-version).
+Nested fields are given in the <artifact>.<field>.<field> form. Use flag (--recursive) to list all the nested fields of a field.`
+
+const explainCmdExamples = utils.ProjectName + ` ` + ExplainCmdLiteral + ` api
+` + utils.ProjectName + ` ` + ExplainCmdLiteral + ` api.data.endpointConfig
+` + utils.ProjectName + ` ` + ExplainCmdLiteral + ` api_params.environments.configs --recursive
+` + utils.ProjectName + ` ` + ExplainCmdLiteral + ` application.yaml.data.applicationInfo --target-version 4.0.0`
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:     ExplainCmdLiteral + " <artifact>[.field...]",
+	Short:   explainCmdShortDesc,
+	Long:    explainCmdLongDesc,
+	Example: explainCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExplainCmdLiteral + " called")
+		explanation, err := impl.ExplainArtifact(args[0], explainCmdTargetVersion, explainCmdRecursive)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while explaining "+args[0], err)
+		}
+		fmt.Print(explanation)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().StringVarP(&explainCmdTargetVersion, "target-version", "", utils.DefaultAPIMVersion,
+		"APIM version the fields are described for")
+	explainCmd.Flags().BoolVarP(&explainCmdRecursive, "recursive", "", false,
+		"List the names and types of all the nested fields")
+}
@@ -35,7 +35,8 @@ Export an Application of a specific user (--owner, -o) in the environment specif
 const exportCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -e dev
-` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev`
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev --as-tenant wso2.com`
 
 // ExportCmd represents the export command
 var ExportCmd = &cobra.Command{
@@ -52,4 +53,5 @@ var ExportCmd = &cobra.Command{
 // init using Cobra
 func init() {
 	RootCmd.AddCommand(ExportCmd)
+	ExportCmd.PersistentFlags().StringVarP(&CmdAsTenant, "as-tenant", "", "", asTenantFlagDesc)
 }
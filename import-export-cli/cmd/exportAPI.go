@@ -40,6 +40,7 @@ var exportAPIPreserveStatus bool
 var exportAPIFormat string
 var runningExportApiCommand bool
 var exportAPILatestRevision bool
+var exportAPINormalize bool
 
 // ExportAPI command related usage info
 const ExportAPICmdLiteral = "api"
@@ -50,6 +51,7 @@ const exportAPICmdLongDesc = "Export an API from an environment"
 const exportAPICmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n FacebookAPI -v 2.1.0 --rev 6 -r admin -e production
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n FacebookAPI -v 2.1.0 --rev 2 -r admin -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev --normalize
 NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory. If --rev is not provided, working copy of the API
 without deployment environments will be exported.`
 
@@ -87,7 +89,8 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteToZip(exportAPIName, exportAPIVersion, "", apiZipLocationPath, runningExportApiCommand, resp)
+			impl.WriteToZip(exportAPIName, exportAPIVersion, "", apiZipLocationPath, runningExportApiCommand,
+				exportAPINormalize, resp)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
 			fmt.Println(string(resp.Body()))
@@ -118,6 +121,9 @@ func init() {
 		"Preserve API status when exporting. Otherwise API will be exported in CREATED status")
 	ExportAPICmd.Flags().BoolVarP(&exportAPILatestRevision, "latest", "", false,
 		"Export the latest revision of the API")
+	ExportAPICmd.Flags().BoolVarP(&exportAPINormalize, "normalize", "", false,
+		"Sort the keys and strip the volatile fields (export_normalize_excluded_fields of the main config) of the "+
+			"exported files, so that the exported API can be version controlled without unrelated diffs")
 	ExportAPICmd.Flags().StringVarP(&exportAPIFormat, "format", "", utils.DefaultExportFormat, "File format of exported archive(json or yaml)")
 	_ = ExportAPICmd.MarkFlagRequired("name")
 	_ = ExportAPICmd.MarkFlagRequired("version")
@@ -35,10 +35,12 @@ const exportAPIsCmdLongDesc = "Export all the APIs of a tenant from one environm
 	"into another environment"
 const exportAPIsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --parallel 5
 NOTE: The flag (--environment (-e)) is mandatory`
 
 var exportAPIsFormat string
 var exportAPIsAllRevisions bool
+var exportAPIsParallel int
 
 //e.g. /home/samithac/.wso2apictl/exported/migration/production-2.5/wso2-dot-org
 var startFromBeginning bool
@@ -52,6 +54,9 @@ var ExportAPIsCmd = &cobra.Command{
 	Example: exportAPIsCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ExportAPIsCmdLiteral + " called")
+		if exportAPIsParallel < 1 {
+			utils.HandleErrorAndExit("Invalid value for --parallel. It should be a positive number", nil)
+		}
 		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
 
 		cred, err := GetCredentials(CmdExportEnvironment)
@@ -87,7 +92,8 @@ func executeExportAPIsCmd(credential credentials.Credential, exportDirectory str
 	}
 
 	impl.ExportAPIs(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAPIsFormat,
-		CmdUsername, apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, exportAPIsAllRevisions)
+		CmdUsername, apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, exportAPIsAllRevisions,
+		exportAPIsParallel)
 }
 
 func init() {
@@ -102,5 +108,7 @@ func init() {
 	ExportAPIsCmd.Flags().BoolVarP(&exportAPIsAllRevisions, "all", "", false,
 		"Export working copy and all revisions for the APIs in the environments ")
 	ExportAPIsCmd.Flags().StringVarP(&exportAPIsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archives(json or yaml)")
+	ExportAPIsCmd.Flags().IntVarP(&exportAPIsParallel, "parallel", "", 1,
+		"Number of APIs to export in parallel")
 	_ = ExportAPIsCmd.MarkFlagRequired("environment")
 }
@@ -40,7 +40,8 @@ var exportAppFormat string
 const ExportAppCmdLiteral = "app"
 const exportAppCmdShortDesc = "Export App"
 
-const exportAppCmdLongDesc = "Export an Application from a specified  environment"
+const exportAppCmdLongDesc = `Export an Application from a specified  environment
+When exported with keys (--with-keys), the grant types, callback URL and key manager specific properties of each key mapping are included in ` + utils.ApplicationKeyMappingsFile + ` and are restored when the Application is imported with keys`
 
 const exportAppCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e prod
@@ -78,7 +79,15 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
+			var keyMappings []impl.ApplicationKeyMapping
+			if exportAppWithKeys {
+				keyMappings, err = impl.GetApplicationKeyMappingsFromEnv(accessToken, CmdExportEnvironment,
+					exportAppName, exportAppOwner)
+				if err != nil {
+					utils.HandleErrorAndExit("Error exporting the key mappings of Application: "+exportAppName, err)
+				}
+			}
+			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp, keyMappings)
 		} else {
 			fmt.Println("Error " + string(resp.Body()))
 		}
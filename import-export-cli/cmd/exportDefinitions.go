@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var exportDefinitionsCmdEnvironment string
+var exportDefinitionsCmdQuery string
+var exportDefinitionsCmdOutput string
+var exportDefinitionsCmdFormat string
+
+const ExportDefinitionsCmdLiteral = "definitions"
+const exportDefinitionsCmdShortDesc = "Export the OpenAPI definitions of APIs"
+
+const exportDefinitionsCmdLongDesc = `Export only the OpenAPI definitions of the APIs matching the query (--query, -q) in the environment specified by flag (--environment, -e),
+to be published in external developer portals. The gateway URLs of the API are injected into the servers of each definition.
+The definitions are written to the directory given by flag (--output) or to the export directory otherwise.`
+
+const exportDefinitionsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportDefinitionsCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportDefinitionsCmdLiteral + ` -e production --query "tag:public" --output ./specs/
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportDefinitionsCmdLiteral + ` -e production -q "name:Pizza" --format json
+NOTE: The flag (--environment (-e)) is mandatory`
+
+var exportDefinitionsCmd = &cobra.Command{
+	Use:     ExportDefinitionsCmdLiteral + " (--environment <environment> --query <query> --output <directory>)",
+	Short:   exportDefinitionsCmdShortDesc,
+	Long:    exportDefinitionsCmdLongDesc,
+	Example: exportDefinitionsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportDefinitionsCmdLiteral + " called")
+		cred, err := GetCredentials(exportDefinitionsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeExportDefinitionsCmd(cred)
+	},
+}
+
+func executeExportDefinitionsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, exportDefinitionsCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+ExportDefinitionsCmdLiteral+"'", err)
+	}
+	outputDir := exportDefinitionsCmdOutput
+	if outputDir == "" {
+		outputDir = filepath.Join(utils.ExportDirectory, utils.ExportedDefinitionsDirName,
+			exportDefinitionsCmdEnvironment)
+	}
+	exported, skipped, err := impl.ExportDefinitionsFromEnv(accessToken, exportDefinitionsCmdEnvironment,
+		exportDefinitionsCmdQuery, outputDir, exportDefinitionsCmdFormat)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while exporting the definitions", err)
+	}
+	impl.PrintExportedDefinitions(exported, skipped)
+}
+
+func init() {
+	ExportCmd.AddCommand(exportDefinitionsCmd)
+	exportDefinitionsCmd.Flags().StringVarP(&exportDefinitionsCmdEnvironment, "environment", "e",
+		"", "Environment from which the definitions should be exported")
+	exportDefinitionsCmd.Flags().StringVarP(&exportDefinitionsCmdQuery, "query", "q",
+		"", "Query pattern of the APIs (ex: tag:public). The definitions of all the APIs are exported when empty")
+	exportDefinitionsCmd.Flags().StringVarP(&exportDefinitionsCmdOutput, "output", "",
+		"", "Directory to write the definitions to")
+	exportDefinitionsCmd.Flags().StringVarP(&exportDefinitionsCmdFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the definitions (json or yaml)")
+	_ = exportDefinitionsCmd.MarkFlagRequired("environment")
+}
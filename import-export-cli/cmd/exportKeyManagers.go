@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var exportKeyManagersCmdEnvironment string
+var exportKeyManagersCmdName string
+var exportKeyManagersCmdOutput string
+var exportKeyManagersCmdFormat string
+
+const ExportKeyManagersCmdLiteral = "keymanagers"
+const exportKeyManagersCmdShortDesc = "Export the configurations of Key Managers"
+
+const exportKeyManagersCmdLongDesc = `Export the configurations (issuer, endpoints, JWKS, claim mappings, grant types, etc.) of the Key Managers in the environment specified by flag (--environment, -e),
+so that they can be recreated in another environment with the import keymanagers command. Only the Key Manager given by flag (--name, -n) is exported if it is set.
+The configurations are written to the directory given by flag (--output) or to the export directory otherwise. They may contain the client secrets of the Key Managers.`
+
+const exportKeyManagersCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportKeyManagersCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportKeyManagersCmdLiteral + ` -e dev -n Okta --output ./key-managers/
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportKeyManagersCmdLiteral + ` -e dev --format json
+NOTE: The flag (--environment (-e)) is mandatory`
+
+var exportKeyManagersCmd = &cobra.Command{
+	Use:     ExportKeyManagersCmdLiteral + " (--environment <environment> --name <key-manager-name> --output <directory>)",
+	Short:   exportKeyManagersCmdShortDesc,
+	Long:    exportKeyManagersCmdLongDesc,
+	Example: exportKeyManagersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportKeyManagersCmdLiteral + " called")
+		cred, err := GetCredentials(exportKeyManagersCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeExportKeyManagersCmd(cred)
+	},
+}
+
+func executeExportKeyManagersCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, exportKeyManagersCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+ExportKeyManagersCmdLiteral+"'", err)
+	}
+	outputDir := exportKeyManagersCmdOutput
+	if outputDir == "" {
+		outputDir = filepath.Join(utils.ExportDirectory, utils.ExportedKeyManagersDirName,
+			exportKeyManagersCmdEnvironment)
+	}
+	exported, err := impl.ExportKeyManagersFromEnv(accessToken, exportKeyManagersCmdEnvironment,
+		exportKeyManagersCmdName, outputDir, exportKeyManagersCmdFormat)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while exporting the Key Managers", err)
+	}
+	impl.PrintExportedKeyManagers(exported)
+}
+
+func init() {
+	ExportCmd.AddCommand(exportKeyManagersCmd)
+	exportKeyManagersCmd.Flags().StringVarP(&exportKeyManagersCmdEnvironment, "environment", "e",
+		"", "Environment from which the Key Managers should be exported")
+	exportKeyManagersCmd.Flags().StringVarP(&exportKeyManagersCmdName, "name", "n",
+		"", "Name of the Key Manager to be exported. All the Key Managers are exported when empty")
+	exportKeyManagersCmd.Flags().StringVarP(&exportKeyManagersCmdOutput, "output", "",
+		"", "Directory to write the Key Managers to")
+	exportKeyManagersCmd.Flags().StringVarP(&exportKeyManagersCmdFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the Key Managers (json or yaml)")
+	_ = exportKeyManagersCmd.MarkFlagRequired("environment")
+}
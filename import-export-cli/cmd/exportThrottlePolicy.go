@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -35,6 +36,7 @@ import (
 var exportThrottlePolicyType string
 var exportThrottlePolicyName string
 var exportThrottlePolicyFormat string
+var exportThrottlePolicyAll bool
 var runningExportThrottlePolicyCommand bool
 
 // ExportThrottlePolicy command related usage info
@@ -46,7 +48,9 @@ const exportThrottlePolicyCmdExamples = utils.ProjectName + ` ` + ExportCmdLiter
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPolicyCmdLiteral + ` ` + ExportThrottlePolicyCmdLiteral + ` -n AppPolicy -e prod --type app --format JSON
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPolicyCmdLiteral + ` ` + ExportThrottlePolicyCmdLiteral + ` -n TestPolicy -e dev --type advanced 
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPolicyCmdLiteral + ` ` + ExportThrottlePolicyCmdLiteral + ` -n CustomPolicy -e prod --type custom 
-NOTE: All the 2 flags (--name (-n) and --environment (-e)) are mandatory.`
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPolicyCmdLiteral + ` ` + ExportThrottlePolicyCmdLiteral + ` --all -e dev --type sub
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPolicyCmdLiteral + ` ` + ExportThrottlePolicyCmdLiteral + ` --all -e dev
+NOTE: All the 2 flags (--name (-n) and --environment (-e)) are mandatory. The flag --name (-n) is not required when exporting all the policies using --all. All the policies of all the types are exported if --type is not given with --all.`
 
 // ExportThrottlePolicyCmd represents the export policy rate-limiting command
 var ExportThrottlePolicyCmd = &cobra.Command{
@@ -59,12 +63,19 @@ var ExportThrottlePolicyCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + ExportThrottlePolicyCmdLiteral + " called")
 		var throttlePoliciesExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedPoliciesDirName, utils.ExportedThrottlePoliciesDirName)
 
+		if !exportThrottlePolicyAll && exportThrottlePolicyName == "" {
+			utils.HandleErrorAndExit("Error exporting Throttling Policy", errors.New("required flag(s) \"name\" not set"))
+		}
 		cred, err := GetCredentials(CmdExportEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
 		}
 
-		executeExportThrottlePolicyCmd(cred, throttlePoliciesExportDirectory)
+		if exportThrottlePolicyAll {
+			executeExportThrottlePoliciesOfTypeCmd(cred, throttlePoliciesExportDirectory)
+		} else {
+			executeExportThrottlePolicyCmd(cred, throttlePoliciesExportDirectory)
+		}
 	},
 }
 
@@ -94,6 +105,27 @@ func executeExportThrottlePolicyCmd(credential credentials.Credential, exportDir
 	}
 }
 
+func executeExportThrottlePoliciesOfTypeCmd(credential credentials.Credential, exportDirectory string) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while exporting Throttling Policies", err)
+	}
+	throttlePolicyLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
+	var count int
+	if exportThrottlePolicyType == "" {
+		count, err = impl.ExportAllThrottlingPoliciesFromEnv(accessToken, CmdExportEnvironment,
+			exportThrottlePolicyFormat, throttlePolicyLocationPath)
+	} else {
+		count, err = impl.ExportThrottlingPoliciesOfTypeFromEnv(accessToken, CmdExportEnvironment,
+			exportThrottlePolicyType, exportThrottlePolicyFormat, throttlePolicyLocationPath)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error while exporting", err)
+	}
+	fmt.Printf("Successfully exported %d Throttling Policies!\n", count)
+	fmt.Println("Find the exported Throttling Policies at " + utils.AppendSlashToString(throttlePolicyLocationPath))
+}
+
 // init using Cobra
 func init() {
 	ExportPolicyCmd.AddCommand(ExportThrottlePolicyCmd)
@@ -104,7 +136,8 @@ func init() {
 	ExportThrottlePolicyCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
 		"", "Environment to which the Throttling Policies should be exported")
 	ExportThrottlePolicyCmd.Flags().StringVarP(&exportThrottlePolicyFormat, "format", "", utils.DefaultExportFormat, "File format of exported archive(JSON or YAML)")
-	_ = ExportThrottlePolicyCmd.MarkFlagRequired("name")
+	ExportThrottlePolicyCmd.Flags().BoolVarP(&exportThrottlePolicyAll, "all", "", false,
+		"Export all the Throttling Policies of the type given by --type, or of all the types")
 	_ = ExportThrottlePolicyCmd.MarkFlagRequired("environment")
 
 }
@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var genBackstageCmdProject string
+var genBackstageCmdEnvironment string
+var genBackstageCmdQuery string
+var genBackstageCmdOutput string
+var genBackstageCmdOwnerMapping map[string]string
+
+const GenBackstageCmdLiteral = "backstage"
+const genBackstageCmdShortDesc = "Generate Backstage catalog entities of APIs"
+
+const genBackstageCmdLongDesc = `Generate a Backstage catalog-info.yaml with the API entity of the API project given by flag (--project),
+or with the API entities of the APIs matching the query (--query, -q) in the environment specified by flag (--environment, -e).
+When generating from an environment, the OpenAPI definitions of the APIs are exported next to the catalog-info.yaml.
+The owner of an entity is the Backstage user of the API provider, unless the provider is mapped to an owner with flag (--owner-mapping).
+The lifecycle of an entity is mapped from the lifecycle state of the API (experimental, production or deprecated).`
+
+const genBackstageCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenBackstageCmdLiteral + ` --project ./PizzaShackAPI
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenBackstageCmdLiteral + ` --project ./PizzaShackAPI --owner-mapping admin=group:platform-team
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenBackstageCmdLiteral + ` -e production --query "tag:public" --output ./catalog
+NOTE: Either the flag (--project) or the flag (--environment (-e)) is mandatory`
+
+var genBackstageCmd = &cobra.Command{
+	Use: GenBackstageCmdLiteral + " (--project <path-to-API-project> | --environment <environment> --query <query>) " +
+		"--output <directory>",
+	Short:   genBackstageCmdShortDesc,
+	Long:    genBackstageCmdLongDesc,
+	Example: genBackstageCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GenBackstageCmdLiteral + " called")
+		if (genBackstageCmdProject == "") == (genBackstageCmdEnvironment == "") {
+			utils.HandleErrorAndExit("Error calling '"+GenBackstageCmdLiteral+"'",
+				errors.New("either the flag --project or the flag --environment should be given"))
+		}
+		if genBackstageCmdProject != "" {
+			path, err := impl.GenerateBackstageCatalogFromProject(genBackstageCmdProject, genBackstageCmdOutput,
+				genBackstageCmdOwnerMapping)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while generating the Backstage catalog", err)
+			}
+			impl.PrintBackstageCatalog(path, nil)
+			return
+		}
+		cred, err := GetCredentials(genBackstageCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGenBackstageCmd(cred)
+	},
+}
+
+func executeGenBackstageCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, genBackstageCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GenBackstageCmdLiteral+"'", err)
+	}
+	outputDir := genBackstageCmdOutput
+	if outputDir == "" {
+		outputDir = filepath.Join(utils.ExportDirectory, utils.ExportedBackstageCatalogsDirName,
+			genBackstageCmdEnvironment)
+	}
+	path, skipped, err := impl.GenerateBackstageCatalogFromEnv(accessToken, genBackstageCmdEnvironment,
+		genBackstageCmdQuery, outputDir, genBackstageCmdOwnerMapping)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while generating the Backstage catalog", err)
+	}
+	impl.PrintBackstageCatalog(path, skipped)
+}
+
+func init() {
+	GenCmd.AddCommand(genBackstageCmd)
+	genBackstageCmd.Flags().StringVarP(&genBackstageCmdProject, "project", "", "",
+		"Path of the API project to generate the entity of")
+	genBackstageCmd.Flags().StringVarP(&genBackstageCmdEnvironment, "environment", "e", "",
+		"Environment to generate the entities of the APIs from")
+	genBackstageCmd.Flags().StringVarP(&genBackstageCmdQuery, "query", "q", "",
+		"Query pattern of the APIs (ex: tag:public). Entities of all the APIs are generated when empty")
+	genBackstageCmd.Flags().StringVarP(&genBackstageCmdOutput, "output", "", "",
+		"Directory to write the catalog-info.yaml to")
+	genBackstageCmd.Flags().StringToStringVarP(&genBackstageCmdOwnerMapping, "owner-mapping", "", nil,
+		"Backstage owners of the API providers (ex: admin=group:platform-team)")
+}
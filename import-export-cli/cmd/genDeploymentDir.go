@@ -268,7 +268,7 @@ var genDeploymentDirCmd = &cobra.Command{
 			if stat, err := os.Stat(genDeploymentDirDestination); !os.IsNotExist(err) {
 				if !stat.IsDir() {
 					fmt.Printf("%s is not a directory\n", genDeploymentDirDestination)
-					os.Exit(1)
+					utils.Exit(1)
 				}
 			}
 		}
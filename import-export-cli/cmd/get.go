@@ -25,6 +25,9 @@ import (
 
 const queryParamSeparator = " "
 
+// Output format of all the get commands (--format)
+var getCmdFormat string
+
 // Get command related usage Info
 const GetCmdLiteral = "get"
 const getCmdShortDesc = "Get APIs/APIProducts/Applications or revisions of a specific API/APIProduct in an environment or Get the Correlation Log Configurations or Get the log level of each API in an environment or Get the environments"
@@ -38,7 +41,8 @@ Get a generated JWT token to invoke an API or API Product by subscribing to a de
 Get the log level of each API in the environment specified by flag (--environment, -e)/
 Get the correlation log configurations in the environment specified by flag (--environment, -e)
 OR
-List all the environments`
+List all the environments
+The output of each get command can be printed in json, yaml or selected with a JSONPath expression using the --format flag`
 
 const getCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetEnvsCmdLiteral + `
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev
@@ -49,7 +53,10 @@ const getCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetEnvsCm
 ` + utils.ProjectName + " " + GetCmdLiteral + " " + GetKeysCmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev
 ` + utils.ProjectName + " " + GetCmdLiteral + " " + GetApiLoggingCmdLiteral + ` -e dev --tenant-domain carbon.super
 ` + utils.ProjectName + " " + GetCmdLiteral + " " + GetApiLoggingCmdLiteral + ` --api-id bf36ca3a-0332-49ba-abce-e9992228ae06 -e dev --tenant-domain carbon.super
-` + utils.ProjectName + " " + GetCmdLiteral + " " + GetCorrelationLoggingCmdLiteral + ` -e dev` 
+` + utils.ProjectName + " " + GetCmdLiteral + " " + GetCorrelationLoggingCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev --as-tenant wso2.com
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev --format yaml
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAPIRevisionsCmdLiteral + ` -n PizzaAPI -v 1.0.0 -e dev --format "jsonpath={[*].id}"`
 
 // ListCmd represents the list command
 var GetCmd = &cobra.Command{
@@ -66,4 +73,7 @@ var GetCmd = &cobra.Command{
 // init using Cobra
 func init() {
 	RootCmd.AddCommand(GetCmd)
+	GetCmd.PersistentFlags().StringVarP(&CmdAsTenant, "as-tenant", "", "", asTenantFlagDesc)
+	GetCmd.PersistentFlags().StringVarP(&getCmdFormat, "format", "", "", "Pretty-print the output using Go "+
+		"Templates. Use \"{{ jsonPretty . }}\" to list all fields. "+utils.FormatFlagDescription)
 }
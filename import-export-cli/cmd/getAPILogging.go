@@ -28,7 +28,6 @@ import (
 var getApiLoggingEnvironment string
 var getApiLoggingAPIId string
 var getApiLoggingTenantDomain string
-var getAPILoggingCmdFormat string
 
 const GetApiLoggingCmdLiteral = "api-logging"
 const getApiLoggingCmdShortDesc = "Display a list of API loggers in an environment"
@@ -56,7 +55,7 @@ func executeGetApiLoggingCmd(credential credentials.Credential) {
 	if getApiLoggingAPIId != "" {
 		api, err := impl.GetPerAPILoggingDetailsFromEnv(credential, getApiLoggingEnvironment, getApiLoggingAPIId, getApiLoggingTenantDomain)
 		if err == nil {
-			impl.PrintAPILoggers(api, getAPILoggingCmdFormat)
+			impl.PrintAPILoggers(api, getCmdFormat)
 		} else {
 			utils.Logln(utils.LogPrefixError+"Getting the log level of the API", err)
 			utils.HandleErrorAndExit("Error while getting the log level of the API", err)
@@ -64,7 +63,7 @@ func executeGetApiLoggingCmd(credential credentials.Credential) {
 	} else {
 		apis, err := impl.GetPerAPILoggingListFromEnv(credential, getApiLoggingEnvironment, getApiLoggingTenantDomain)
 		if err == nil {
-			impl.PrintAPILoggers(apis, getAPILoggingCmdFormat)
+			impl.PrintAPILoggers(apis, getCmdFormat)
 		} else {
 			utils.Logln(utils.LogPrefixError+"Getting list of API log levels for the APIs", err)
 			utils.HandleErrorAndExit("Error while getting list of API log levels for the APIs", err)
@@ -81,7 +80,5 @@ func init() {
 		"", "Tenant Domain")
 	getApiLoggingCmd.Flags().StringVarP(&getApiLoggingEnvironment, "environment", "e",
 		"", "Environment of the APIs which the API loggers should be displayed")
-	getApiLoggingCmd.Flags().StringVarP(&getAPILoggingCmdFormat, "format", "", "", "Pretty-print API loggers "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getApiLoggingCmd.MarkFlagRequired("environment")
 }
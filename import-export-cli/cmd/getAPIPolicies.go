@@ -31,7 +31,6 @@ import (
 )
 
 var getAPIPoliciesCmdEnvironment string
-var getAPIPoliciesCmdFormat string
 var getAPIPolicyListCmdLimit string
 var getAllAPIPoliciesAvailable bool
 
@@ -86,7 +85,7 @@ func executeGetAPIPoliciesCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 
 		if resp.StatusCode() == http.StatusOK {
-			impl.PrintAPIPolicies(resp, getAPIPoliciesCmdFormat)
+			impl.PrintAPIPolicies(resp, getCmdFormat)
 		} else {
 			// neither 200 nor 500
 			fmt.Println("Error getting API Policies:", resp.Status(), "\n", string(resp.Body()))
@@ -100,8 +99,6 @@ func init() {
 	GetPoliciesCmd.AddCommand(getAPIPoliciesCmd)
 	getAPIPoliciesCmd.Flags().StringVarP(&getAPIPoliciesCmdEnvironment, "environment", "e",
 		"", "Environment to be searched")
-	getAPIPoliciesCmd.Flags().StringVarP(&getAPIPoliciesCmdFormat, "format", "", "", "Pretty-print API Policies "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	getAPIPoliciesCmd.Flags().StringVarP(&getAPIPolicyListCmdLimit, "limit", "l",
 		strconv.Itoa(utils.DefaultPoliciesDisplayLimit), "Maximum number of API Policies to return")
 	getAPIPoliciesCmd.Flags().BoolVarP(&getAllAPIPoliciesAvailable, "all", "", false, "Get all API Policies")
@@ -32,7 +32,6 @@ var getRevisionsAPIProductName string
 var getRevisionsAPIProductVersion string
 var getRevisionsAPIProductProvider string
 var getAPIProductRevisionsCmdEnvironment string
-var getAPIProductRevisionsCmdFormat string
 var getAPIProductRevisionsCmdQuery []string
 
 // GetAPIProductRevisionsCmd related info
@@ -72,7 +71,7 @@ func executeGetAPIProductRevisionsCmd(credential credentials.Credential) {
 	_, revisions, err := impl.GetAPIProductRevisionListFromEnv(accessToken, getAPIProductRevisionsCmdEnvironment,
 		getRevisionsAPIProductName, getRevisionsAPIProductVersion, getRevisionsAPIProductProvider, strings.Join(getAPIProductRevisionsCmdQuery, queryParamSeparator))
 	if err == nil {
-		impl.PrintRevisions(revisions, getAPIProductRevisionsCmdFormat)
+		impl.PrintRevisions(revisions, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting List of Revisions", err)
 	}
@@ -90,8 +89,6 @@ func init() {
 		[]string{}, "Query pattern")
 	getAPIProductRevisionsCmd.Flags().StringVarP(&getAPIProductRevisionsCmdEnvironment, "environment", "e",
 		"", "Environment to be searched")
-	getAPIProductRevisionsCmd.Flags().StringVarP(&getAPIProductRevisionsCmdFormat, "format", "", "", "Pretty-print revisions "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("name")
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("version")
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("environment")
@@ -32,7 +32,6 @@ var getAPIRevisionsAPIName string
 var getAPIRevisionsAPIVersion string
 var getAPIRevisionsAPIProvider string
 var getAPIRevisionsCmdEnvironment string
-var getAPIRevisionsCmdFormat string
 var getAPIRevisionsCmdQuery []string
 
 // GetRevisionsCmd related info
@@ -72,7 +71,7 @@ func executeGetAPIRevisionsCmd(credential credentials.Credential) {
 	_, revisions, err := impl.GetRevisionListFromEnv(accessToken, getAPIRevisionsCmdEnvironment, getAPIRevisionsAPIName,
 		getAPIRevisionsAPIVersion, getAPIRevisionsAPIProvider, strings.Join(getAPIRevisionsCmdQuery, queryParamSeparator))
 	if err == nil {
-		impl.PrintRevisions(revisions, getAPIRevisionsCmdFormat)
+		impl.PrintRevisions(revisions, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting List of API Revisions", err)
 	}
@@ -90,8 +89,6 @@ func init() {
 		[]string{}, "Query pattern")
 	getAPIRevisionsCmd.Flags().StringVarP(&getAPIRevisionsCmdEnvironment, "environment", "e",
 		"", "Environment to be searched")
-	getAPIRevisionsCmd.Flags().StringVarP(&getAPIRevisionsCmdFormat, "format", "", "", "Pretty-print revisions "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getAPIRevisionsCmd.MarkFlagRequired("name")
 	_ = getAPIRevisionsCmd.MarkFlagRequired("version")
 	_ = getAPIRevisionsCmd.MarkFlagRequired("environment")
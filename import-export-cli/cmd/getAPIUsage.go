@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getAPIUsageAPIName string
+var getAPIUsageAPIVersion string
+var getAPIUsageAPIProvider string
+var getAPIUsageCmdEnvironment string
+var getAPIUsageCmdPeriod string
+
+// GetAPIUsageCmd related info
+const GetAPIUsageCmdLiteral = "api-usage"
+const GetAPIUsageCmdShortDesc = "Display a usage summary of an API"
+
+const GetAPIUsageCmdLongDesc = `Display a usage summary (hits, errors and latency percentiles) of an API for a given time period.
+The summary is retrieved from the analytics REST endpoint configured for the environment using the --analytics flag of "apictl add env".
+The analytics endpoint should serve the summary of an API at <analytics-endpoint>/apis/{apiId}/usage?from=<RFC3339>&to=<RFC3339>`
+
+var getAPIUsageCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAPIUsageCmdLiteral + ` -n PizzaAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAPIUsageCmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev --last 24h
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAPIUsageCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --last 30d --format json
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// getAPIUsageCmd represents the api-usage command
+var getAPIUsageCmd = &cobra.Command{
+	Use:     GetAPIUsageCmdLiteral,
+	Short:   GetAPIUsageCmdShortDesc,
+	Long:    GetAPIUsageCmdLongDesc,
+	Example: getAPIUsageCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetAPIUsageCmdLiteral + " called")
+		cred, err := GetCredentials(getAPIUsageCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetAPIUsageCmd(cred)
+	},
+}
+
+func executeGetAPIUsageCmd(credential credentials.Credential) {
+	period, err := utils.ParseTimePeriod(getAPIUsageCmdPeriod)
+	if err != nil {
+		utils.HandleErrorAndExit("Invalid value for --last", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getAPIUsageCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get api-usage' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetAPIUsageCmdLiteral+"'", err)
+	}
+
+	usage, err := impl.GetAPIUsageFromEnv(accessToken, getAPIUsageCmdEnvironment, getAPIUsageAPIName,
+		getAPIUsageAPIVersion, getAPIUsageAPIProvider, period)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the usage of the API", err)
+	}
+	impl.PrintAPIUsage(usage, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getAPIUsageCmd)
+	getAPIUsageCmd.Flags().StringVarP(&getAPIUsageAPIName, "name", "n", "",
+		"Name of the API to get the usage")
+	getAPIUsageCmd.Flags().StringVarP(&getAPIUsageAPIVersion, "version", "v", "",
+		"Version of the API to get the usage")
+	getAPIUsageCmd.Flags().StringVarP(&getAPIUsageAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	getAPIUsageCmd.Flags().StringVarP(&getAPIUsageCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	getAPIUsageCmd.Flags().StringVarP(&getAPIUsageCmdPeriod, "last", "", "7d",
+		"Time period to summarize (ex: 30m, 24h, 7d)")
+	_ = getAPIUsageCmd.MarkFlagRequired("name")
+	_ = getAPIUsageCmd.MarkFlagRequired("version")
+	_ = getAPIUsageCmd.MarkFlagRequired("environment")
+}
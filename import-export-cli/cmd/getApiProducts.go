@@ -31,7 +31,6 @@ import (
 )
 
 var getApiProductsCmdEnvironment string
-var getApiProductsCmdFormat string
 var getApiProductsCmdQuery []string
 var getApiProductsCmdLimit string
 
@@ -76,7 +75,7 @@ func executeGetApiProductsCmd(credential credentials.Credential) {
 		strings.Join(getApiProductsCmdQuery, queryParamSeparator),
 		getApiProductsCmdLimit)
 	if err == nil {
-		impl.PrintAPIProducts(apiProducts, getApiProductsCmdFormat)
+		impl.PrintAPIProducts(apiProducts, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting List of API Products", err)
 	}
@@ -91,7 +90,5 @@ func init() {
 		[]string{}, "Query pattern")
 	getApiProductsCmd.Flags().StringVarP(&getApiProductsCmdLimit, "limit", "l",
 		strconv.Itoa(utils.DefaultApiProductsDisplayLimit), "Maximum number of API Products to return")
-	getApiProductsCmd.Flags().StringVarP(&getApiProductsCmdFormat, "format", "", "", "Pretty-print API Products "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getApiProductsCmd.MarkFlagRequired("environment")
 }
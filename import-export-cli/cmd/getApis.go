@@ -30,20 +30,30 @@ import (
 )
 
 var getApisCmdEnvironment string
-var getApisCmdFormat string
 var getApisCmdQuery []string
 var getApisCmdLimit string
+var getApisCmdDeployedIn string
+var getApisCmdOffset string
+var getApisCmdSortBy string
+var getApisCmdSortOrder string
 
 // GetApisCmd related info
 const GetApisCmdLiteral = "apis"
 const getApisCmdShortDesc = "Display a list of APIs in an environment"
 
-const getApisCmdLongDesc = `Display a list of APIs in the environment specified by the flag --environment, -e`
+const getApisCmdLongDesc = `Display a list of APIs in the environment specified by the flag --environment, -e
+The APIs can be searched with the filters of the Publisher search (ex: tag:, status:, provider:, context:, name:, version:)
+given to the flag --query, -q. Multiple filters are combined. The flags --limit, --offset, --sort-by and --sort-order
+can be used to page through and sort the APIs.`
 
 var getApisCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev -q version:1.0.0
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -q provider:admin -q version:1.0.0
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -q tag:payments -q status:PUBLISHED
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -q context:/pizzashack
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -l 100
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -l 100 --offset 100 --sort-by name --sort-order asc
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod --deployed-in us-region
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e staging
 NOTE: The flag (--environment (-e)) is mandatory`
 
@@ -70,10 +80,18 @@ func executeGetApisCmd(credential credentials.Credential) {
 		utils.HandleErrorAndExit("Error calling '"+GetApisCmdLiteral+"'", err)
 	}
 
-	_, apis, err := impl.GetAPIListFromEnv(accessToken, getApisCmdEnvironment,
-		strings.Join(getApisCmdQuery, queryParamSeparator), getApisCmdLimit)
+	_, apis, err := impl.SearchAPIsInEnv(accessToken, getApisCmdEnvironment, impl.APISearchOptions{
+		Query:     strings.Join(getApisCmdQuery, queryParamSeparator),
+		Limit:     getApisCmdLimit,
+		Offset:    getApisCmdOffset,
+		SortBy:    getApisCmdSortBy,
+		SortOrder: getApisCmdSortOrder,
+	})
+	if err == nil && getApisCmdDeployedIn != "" {
+		apis, err = impl.FilterAPIsDeployedInEnv(accessToken, getApisCmdEnvironment, apis, getApisCmdDeployedIn)
+	}
 	if err == nil {
-		impl.PrintAPIs(apis, getApisCmdFormat)
+		impl.PrintAPIs(apis, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting List of APIs", err)
 	}
@@ -85,10 +103,16 @@ func init() {
 	getApisCmd.Flags().StringVarP(&getApisCmdEnvironment, "environment", "e",
 		"", "Environment to be searched")
 	getApisCmd.Flags().StringSliceVarP(&getApisCmdQuery, "query", "q",
-		[]string{}, "Query pattern")
+		[]string{}, "Query pattern (ex: tag:public, status:PUBLISHED, provider:admin, context:/pizzashack)")
 	getApisCmd.Flags().StringVarP(&getApisCmdLimit, "limit", "l",
 		strconv.Itoa(utils.DefaultApisDisplayLimit), "Maximum number of apis to return")
-	getApisCmd.Flags().StringVarP(&getApisCmdFormat, "format", "", "", "Pretty-print apis "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	getApisCmd.Flags().StringVarP(&getApisCmdOffset, "offset", "",
+		"", "Number of apis to skip before the apis to return")
+	getApisCmd.Flags().StringVarP(&getApisCmdSortBy, "sort-by", "",
+		"", "Field to sort the apis by (name, version, createdTime or status)")
+	getApisCmd.Flags().StringVarP(&getApisCmdSortOrder, "sort-order", "",
+		"", "Order to sort the apis in (asc or desc)")
+	getApisCmd.Flags().StringVarP(&getApisCmdDeployedIn, "deployed-in", "", "", "List only the APIs "+
+		"deployed in the given gateway environment. Applied to the APIs returned within the limit")
 	_ = getApisCmd.MarkFlagRequired("environment")
 }
@@ -31,21 +31,26 @@ import (
 
 var getAppsCmdEnvironment string
 var getAppsCmdAppOwner string
-var getAppsCmdFormat string
 var getAppsCmdLimit string
+var getAppsCmdAll bool
 var defaultAppsOwner string
 
 // GetAppsCmd related info
 const GetAppsCmdLiteral = "apps"
 const getAppsCmdShortDesc = "Display a list of Applications in an environment specific to an owner"
 
-const getAppsCmdLongDesc = "Display a list of Applications of the user in the environment specified by the flag --environment, -e"
+const getAppsCmdLongDesc = "Display a list of Applications of the user in the environment specified by the flag --environment, -e. " +
+	"The owner can contain wildcards (*, ? or [...]) to list the Applications of the matching owners. " +
+	"Use --all to list all the Applications of the tenant irrespective of the owner (ex: for usage audits)"
 
 const getAppsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e dev 
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e dev -o sampleUser
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e prod -o sampleUser
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e staging -o sampleUser
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e dev -l 40
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e dev -o "*@example.com"
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e prod --all
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetAppsCmdLiteral + ` -e prod --all -o "dev*" --format json
 NOTE: The flag (--environment (-e)) is mandatory`
 
 // getAppsCmd represents the apps command
@@ -72,11 +77,28 @@ func executeGetAppsCmd(credential credentials.Credential, appOwner string) {
 		utils.HandleErrorAndExit("Error calling '"+GetAppsCmdLiteral+"'", err)
 	}
 
+	if getAppsCmdAll || impl.IsAppOwnerPattern(appOwner) {
+		// List the applications page by page and match the owners
+		maxResults := 0
+		if !getAppsCmdAll {
+			maxResults, err = strconv.Atoi(getAppsCmdLimit)
+			if err != nil {
+				utils.HandleErrorAndExit("Invalid limit "+getAppsCmdLimit, err)
+			}
+		}
+		apps, err := impl.GetAllApplicationListFromEnv(accessToken, getAppsCmdEnvironment, appOwner, maxResults)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting the list of Applications", err)
+		}
+		impl.PrintApps(apps, getCmdFormat)
+		return
+	}
+
 	_, apps, err := impl.GetApplicationListFromEnv(accessToken, getAppsCmdEnvironment, appOwner, getAppsCmdLimit)
 
 	if err == nil {
 		// Printing the list of available Applications
-		impl.PrintApps(apps, getAppsCmdFormat)
+		impl.PrintApps(apps, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting List of Applications", err)
 	}
@@ -86,12 +108,13 @@ func init() {
 	GetCmd.AddCommand(getAppsCmd)
 
 	getAppsCmd.Flags().StringVarP(&getAppsCmdAppOwner, "owner", "o", defaultAppsOwner,
-		"Owner of the Application")
+		"Owner of the Application. Can contain wildcards (*, ? or [...])")
 	getAppsCmd.Flags().StringVarP(&getAppsCmdEnvironment, "environment", "e",
 		"", "Environment to be searched")
 	getAppsCmd.Flags().StringVarP(&getAppsCmdLimit, "limit", "l",
 		strconv.Itoa(utils.DefaultAppsDisplayLimit), "Maximum number of applications to return")
-	getAppsCmd.Flags().StringVarP(&getAppsCmdFormat, "format", "", "", "Pretty-print output"+
-		"using Go templates. Use \"{{jsonPretty .}}\" to list all fields")
+	getAppsCmd.Flags().BoolVarP(&getAppsCmdAll, "all", "", false, "List all the Applications of the tenant "+
+		"irrespective of the owner, page by page")
 	_ = getAppsCmd.MarkFlagRequired("environment")
+	getAppsCmd.MarkFlagsMutuallyExclusive("all", "limit")
 }
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getClaimMappingsKeyManager string
+var getClaimMappingsCmdEnvironment string
+
+// GetClaimMappingsCmd related info
+const GetClaimMappingsCmdLiteral = "claim-mappings"
+const GetClaimMappingsCmdShortDesc = "Display the claim mappings of a key manager"
+
+const GetClaimMappingsCmdLongDesc = `Display the local claims the claims of the tokens issued by a key manager are mapped to, in the environment specified by the flag --environment, -e`
+
+var getClaimMappingsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetClaimMappingsCmdLiteral + ` --key-manager Okta -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetClaimMappingsCmdLiteral + ` --key-manager "Resident Key Manager" -e dev --format json
+NOTE: Both the flags (--key-manager and --environment (-e)) are mandatory.`
+
+// getClaimMappingsCmd represents the claim-mappings command
+var getClaimMappingsCmd = &cobra.Command{
+	Use:     GetClaimMappingsCmdLiteral,
+	Short:   GetClaimMappingsCmdShortDesc,
+	Long:    GetClaimMappingsCmdLongDesc,
+	Example: getClaimMappingsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetClaimMappingsCmdLiteral + " called")
+		cred, err := GetCredentials(getClaimMappingsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetClaimMappingsCmd(cred)
+	},
+}
+
+func executeGetClaimMappingsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getClaimMappingsCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GetClaimMappingsCmdLiteral+"'", err)
+	}
+
+	mappings, err := impl.GetClaimMappingsFromEnv(accessToken, getClaimMappingsCmdEnvironment,
+		getClaimMappingsKeyManager)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the claim mappings of the key manager", err)
+	}
+	impl.PrintClaimMappings(mappings, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getClaimMappingsCmd)
+	getClaimMappingsCmd.Flags().StringVarP(&getClaimMappingsKeyManager, "key-manager", "", "",
+		"Name of the key manager")
+	getClaimMappingsCmd.Flags().StringVarP(&getClaimMappingsCmdEnvironment, "environment", "e",
+		"", "Environment of the key manager")
+	_ = getClaimMappingsCmd.MarkFlagRequired("key-manager")
+	_ = getClaimMappingsCmd.MarkFlagRequired("environment")
+}
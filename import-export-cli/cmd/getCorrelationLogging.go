@@ -26,7 +26,6 @@ import (
 )
 
 var getCorrelationLoggingEnvironment string
-var getCorrelationLoggingCmdFormat string
 
 const GetCorrelationLoggingCmdLiteral = "correlation-logging"
 const getCorrelationLoggingCmdShortDesc = "Display a list of correlation logging components in an environment"
@@ -54,7 +53,7 @@ func executeGetCorrelationLoggingCmd(credential credentials.Credential) {
 	components, err := impl.GetCorrelationLogComponentListFromEnv(credential, getCorrelationLoggingEnvironment)
 
 	if err == nil {
-		impl.PrintCorrelationLoggers(components, getCorrelationLoggingCmdFormat)
+		impl.PrintCorrelationLoggers(components, getCmdFormat)
 	} else {
 		utils.Logln(utils.LogPrefixError+"Getting list of correlation log configurations", err)
 		utils.HandleErrorAndExit("Error while getting list of correlation log configurations", err)
@@ -66,7 +65,5 @@ func init() {
 
 	getCorrelationLoggingCmd.Flags().StringVarP(&getCorrelationLoggingEnvironment, "environment", "e",
 		"", "Environment which the correlation logging components should be displayed")
-	getCorrelationLoggingCmd.Flags().StringVarP(&getCorrelationLoggingCmdFormat, "format", "", "",
-		"Pretty-print correlation logging components using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getCorrelationLoggingCmd.MarkFlagRequired("environment")
 }
@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getDeploymentsAPIName string
+var getDeploymentsAPIVersion string
+var getDeploymentsAPIProvider string
+var getDeploymentsCmdEnvironment string
+
+// GetDeploymentsCmd related info
+const GetDeploymentsCmdLiteral = "deployments"
+const GetDeploymentsCmdShortDesc = "Display the revisions of an API deployed in each gateway environment"
+
+const GetDeploymentsCmdLongDesc = `Display the revisions of an API deployed in each gateway environment and vhost along with the status and the time of each deployment`
+
+var getDeploymentsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetDeploymentsCmdLiteral + ` -n PizzaAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetDeploymentsCmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetDeploymentsCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --format json
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// getDeploymentsCmd represents the deployments command
+var getDeploymentsCmd = &cobra.Command{
+	Use:     GetDeploymentsCmdLiteral,
+	Short:   GetDeploymentsCmdShortDesc,
+	Long:    GetDeploymentsCmdLongDesc,
+	Example: getDeploymentsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetDeploymentsCmdLiteral + " called")
+		cred, err := GetCredentials(getDeploymentsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetDeploymentsCmd(cred)
+	},
+}
+
+func executeGetDeploymentsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getDeploymentsCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get deployments' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetDeploymentsCmdLiteral+"'", err)
+	}
+
+	deployments, err := impl.GetDeploymentsFromEnv(accessToken, getDeploymentsCmdEnvironment, getDeploymentsAPIName,
+		getDeploymentsAPIVersion, getDeploymentsAPIProvider)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the deployments of the API", err)
+	}
+	impl.PrintDeployments(deployments, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getDeploymentsCmd)
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIName, "name", "n", "",
+		"Name of the API to get the deployments")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIVersion, "version", "v", "",
+		"Version of the API to get the deployments")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = getDeploymentsCmd.MarkFlagRequired("name")
+	_ = getDeploymentsCmd.MarkFlagRequired("version")
+	_ = getDeploymentsCmd.MarkFlagRequired("environment")
+}
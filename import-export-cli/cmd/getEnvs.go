@@ -19,22 +19,28 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 const defaulEnvsTableFormat = "table {{.Name}}\t{{.ApiManagerEndpoint}}\t{{.RegistrationEndpoint}}\t{{.TokenEndpoint}}\t{{.PublisherEndpoint}}\t{{.ApplicationEndpoint}}\t{{.AdminEndpoint}}\t{{.MiManagementEndpoint}}"
+const defaultEnvChecksTableFormat = "table {{.Name}}\t{{.Publisher}}\t{{.DevPortal}}\t{{.Admin}}\t{{.Token}}"
 
-var envsCmdFormat string
+var envsCmdCheck bool
 
 // GetEnvsCmd related info
 const GetEnvsCmdLiteral = "envs"
 const getEnvsCmdShortDesc = "Display the list of environments"
 
-const getEnvsCmdLongDesc = `Display a list of environments defined in '` + utils.MainConfigFileName + `' file`
+const getEnvsCmdLongDesc = `Display a list of environments defined in '` + utils.MainConfigFileName + `' file.
+With --check, the publisher, devportal, admin and token endpoints of each environment are probed concurrently and a reachability and latency matrix is displayed instead. An endpoint is considered reachable if it responds with a status code below 500`
 
-const getEnvsCmdExamples = utils.ProjectName + " list envs"
+const getEnvsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetEnvsCmdLiteral + `
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetEnvsCmdLiteral + ` --check
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetEnvsCmdLiteral + ` --check -k`
 
 // getEnvsCmd represents the envs command
 var getEnvsCmd = &cobra.Command{
@@ -44,13 +50,29 @@ var getEnvsCmd = &cobra.Command{
 	Example: getEnvsCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + GetEnvsCmdLiteral + " called")
-		envs := utils.GetMainConfigFromFile(utils.MainConfigFilePath).Environments
-		impl.PrintEnvs(envs, envsCmdFormat, defaulEnvsTableFormat)
+		envs := utils.GetEnvironments(utils.MainConfigFilePath)
+		if envsCmdCheck {
+			executeCheckEnvsCmd(envs)
+			return
+		}
+		impl.PrintEnvs(envs, getCmdFormat, defaulEnvsTableFormat)
 	},
 }
 
+func executeCheckEnvsCmd(envs map[string]utils.EnvEndpoints) {
+	envNames := make([]string, 0, len(envs))
+	for name := range envs {
+		envNames = append(envNames, name)
+	}
+	results := impl.CheckEnvs(envNames, utils.MainConfigFilePath)
+	if unhealthyEnvs := impl.PrintEnvChecks(results, defaultEnvChecksTableFormat); unhealthyEnvs > 0 {
+		utils.HandleErrorAndExit("Environment check failed",
+			fmt.Errorf("%d environment(s) have unreachable endpoints", unhealthyEnvs))
+	}
+}
+
 func init() {
 	GetCmd.AddCommand(getEnvsCmd)
-	getEnvsCmd.Flags().StringVarP(&envsCmdFormat, "format", "", defaulEnvsTableFormat, "Pretty-print "+
-		"environments using go templates")
+	getEnvsCmd.Flags().BoolVarP(&envsCmdCheck, "check", "", false, "Check the reachability and the latency "+
+		"of the publisher, devportal, admin and token endpoints of each environment")
 }
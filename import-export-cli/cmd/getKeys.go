@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -30,13 +32,20 @@ const getKeysCmdShortDesc = "Generate access token to invoke the API or API Prod
 const getKeysCmdLongDesc = `Generate JWT token to invoke the API or API Product by subscribing to a default application for testing purposes`
 const getKeysCmdExamples = utils.ProjectName + " " + GetCmdLiteral + " " + GetKeysCmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev --provider admin
 NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.
-You can override the default token endpoint using --token (-t) optional flag providing a new token endpoint`
+You can override the default token endpoint using --token (-t) optional flag providing a new token endpoint
+Use --format json to get the access token along with its expiry in json format
+` + utils.ProjectName + " " + GetCmdLiteral + " " + GetKeysCmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev --grant-type password --scopes read,write --validity 600 --format json
+Use --grant-type password to generate the token using the credentials of the environment
+Use --scopes to request specific scopes instead of all the scopes of the subscribed APIs`
 
 var keyGenEnv string
 var apiName string
 var apiVersion string
 var apiProvider string
 var keyGenTokenEndpoint string
+var keyGenValidityPeriod int
+var keyGenScopes []string
+var keyGenGrantType string
 
 var getKeysCmd = &cobra.Command{
 	Use:     GetKeysCmdLiteral,
@@ -45,6 +54,14 @@ var getKeysCmd = &cobra.Command{
 	Example: getKeysCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + GetKeysCmdLiteral + " called")
+		if keyGenGrantType != utils.GrantTypeClientCredentials && keyGenGrantType != utils.GrantTypePassword {
+			utils.HandleErrorAndExit("Invalid grant type "+keyGenGrantType, errors.New("supported grant types are "+
+				utils.GrantTypeClientCredentials+" and "+utils.GrantTypePassword))
+		}
+		if keyGenValidityPeriod <= 0 {
+			utils.HandleErrorAndExit("Invalid validity period", errors.New("validity period should be a positive "+
+				"number of seconds"))
+		}
 		cred, err := GetCredentials(keyGenEnv)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -57,7 +74,12 @@ var getKeysCmd = &cobra.Command{
 			utils.HandleErrorAndExit("Internal error occurred", err)
 		}
 		utils.Logln(utils.LogPrefixInfo + "Called DCR endpoint successfully")
-		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint)
+		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint,
+			utils.TokenGenerationOptions{
+				ValidityPeriod: keyGenValidityPeriod,
+				Scopes:         keyGenScopes,
+				GrantType:      keyGenGrantType,
+			}, getCmdFormat)
 	},
 }
 
@@ -69,6 +91,12 @@ func init() {
 	getKeysCmd.Flags().StringVarP(&apiVersion, "version", "v", "", "Version of the API")
 	getKeysCmd.Flags().StringVarP(&apiProvider, "provider", "r", "", "Provider of the API or API Product")
 	getKeysCmd.Flags().StringVarP(&keyGenTokenEndpoint, "token", "t", "", "Token endpoint URL of Environment")
+	getKeysCmd.Flags().IntVarP(&keyGenValidityPeriod, "validity", "", utils.DefaultTokenValidityPeriod,
+		"Validity period of the token in seconds")
+	getKeysCmd.Flags().StringSliceVarP(&keyGenScopes, "scopes", "", []string{}, "Scopes to request for the token. "+
+		"All the scopes of the subscribed APIs are requested by default")
+	getKeysCmd.Flags().StringVarP(&keyGenGrantType, "grant-type", "", utils.GrantTypeClientCredentials,
+		"Grant type used to generate the token (client_credentials or password)")
 	_ = getKeysCmd.MarkFlagRequired("name")
 	_ = getKeysCmd.MarkFlagRequired("environment")
 }
@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getMonetizationAPIName string
+var getMonetizationAPIVersion string
+var getMonetizationAPIProvider string
+var getMonetizationCmdEnvironment string
+
+// GetMonetizationCmd related info
+const GetMonetizationCmdLiteral = "monetization"
+const getMonetizationCmdShortDesc = "Display the monetization settings of an API"
+
+const getMonetizationCmdLongDesc = `Display whether the monetization is enabled for an API and the monetization properties of the API in the environment specified by the flag --environment, -e`
+
+var getMonetizationCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetMonetizationCmdLiteral + ` -n PizzaAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetMonetizationCmdLiteral + ` -n PizzaAPI -v 1.0.0 -r admin -e dev --format json
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// getMonetizationCmd represents the get monetization command
+var getMonetizationCmd = &cobra.Command{
+	Use:     GetMonetizationCmdLiteral,
+	Short:   getMonetizationCmdShortDesc,
+	Long:    getMonetizationCmdLongDesc,
+	Example: getMonetizationCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetMonetizationCmdLiteral + " called")
+		cred, err := GetCredentials(getMonetizationCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetMonetizationCmd(cred)
+	},
+}
+
+func executeGetMonetizationCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getMonetizationCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GetMonetizationCmdLiteral+"'", err)
+	}
+	info, err := impl.GetAPIMonetizationFromEnv(accessToken, getMonetizationCmdEnvironment, getMonetizationAPIName,
+		getMonetizationAPIVersion, getMonetizationAPIProvider)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the monetization of the API", err)
+	}
+	impl.PrintAPIMonetization(getMonetizationAPIName, info, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getMonetizationCmd)
+	getMonetizationCmd.Flags().StringVarP(&getMonetizationAPIName, "name", "n", "",
+		"Name of the API to get the monetization")
+	getMonetizationCmd.Flags().StringVarP(&getMonetizationAPIVersion, "version", "v", "",
+		"Version of the API to get the monetization")
+	getMonetizationCmd.Flags().StringVarP(&getMonetizationAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	getMonetizationCmd.Flags().StringVarP(&getMonetizationCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = getMonetizationCmd.MarkFlagRequired("name")
+	_ = getMonetizationCmd.MarkFlagRequired("version")
+	_ = getMonetizationCmd.MarkFlagRequired("environment")
+}
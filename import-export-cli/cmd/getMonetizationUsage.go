@@ -0,0 +1,73 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getMonetizationUsageCmdEnvironment string
+
+// GetMonetizationUsageCmd related info
+const GetMonetizationUsageCmdLiteral = "monetization-usage"
+const getMonetizationUsageCmdShortDesc = "Display the status of publishing the monetization usage"
+
+const getMonetizationUsageCmdLongDesc = `Display the status of the last job which published the monetization usage of the environment specified by the flag --environment, -e to the billing engine`
+
+var getMonetizationUsageCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetMonetizationUsageCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetMonetizationUsageCmdLiteral + ` -e dev --format json
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// getMonetizationUsageCmd represents the get monetization-usage command
+var getMonetizationUsageCmd = &cobra.Command{
+	Use:     GetMonetizationUsageCmdLiteral,
+	Short:   getMonetizationUsageCmdShortDesc,
+	Long:    getMonetizationUsageCmdLongDesc,
+	Example: getMonetizationUsageCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetMonetizationUsageCmdLiteral + " called")
+		cred, err := GetCredentials(getMonetizationUsageCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetMonetizationUsageCmd(cred)
+	},
+}
+
+func executeGetMonetizationUsageCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getMonetizationUsageCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GetMonetizationUsageCmdLiteral+"'", err)
+	}
+	info, err := impl.GetMonetizationUsagePublishStatusFromEnv(accessToken, getMonetizationUsageCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the monetization usage publish status", err)
+	}
+	impl.PrintMonetizationUsagePublishStatus(info, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getMonetizationUsageCmd)
+	getMonetizationUsageCmd.Flags().StringVarP(&getMonetizationUsageCmdEnvironment, "environment", "e",
+		"", "Environment to get the monetization usage publish status")
+	_ = getMonetizationUsageCmd.MarkFlagRequired("environment")
+}
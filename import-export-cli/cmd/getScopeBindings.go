@@ -0,0 +1,87 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getScopeBindingsAPIName string
+var getScopeBindingsAPIVersion string
+var getScopeBindingsAPIProvider string
+var getScopeBindingsCmdEnvironment string
+
+// GetScopeBindingsCmd related info
+const GetScopeBindingsCmdLiteral = "scope-bindings"
+const GetScopeBindingsCmdShortDesc = "Display the roles bound to each scope of an API"
+
+const GetScopeBindingsCmdLongDesc = `Display the roles bound to each scope of an API in the environment specified by the flag --environment, -e`
+
+var getScopeBindingsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetScopeBindingsCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetScopeBindingsCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -r admin -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetScopeBindingsCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev --format json
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// getScopeBindingsCmd represents the scope-bindings command
+var getScopeBindingsCmd = &cobra.Command{
+	Use:     GetScopeBindingsCmdLiteral,
+	Short:   GetScopeBindingsCmdShortDesc,
+	Long:    GetScopeBindingsCmdLongDesc,
+	Example: getScopeBindingsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetScopeBindingsCmdLiteral + " called")
+		cred, err := GetCredentials(getScopeBindingsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetScopeBindingsCmd(cred)
+	},
+}
+
+func executeGetScopeBindingsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getScopeBindingsCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GetScopeBindingsCmdLiteral+"'", err)
+	}
+
+	bindings, err := impl.GetScopeBindingsFromEnv(accessToken, getScopeBindingsCmdEnvironment,
+		getScopeBindingsAPIName, getScopeBindingsAPIVersion, getScopeBindingsAPIProvider)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the scope bindings of the API", err)
+	}
+	impl.PrintScopeBindings(bindings, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getScopeBindingsCmd)
+	getScopeBindingsCmd.Flags().StringVarP(&getScopeBindingsAPIName, "name", "n", "",
+		"Name of the API to get the scope bindings")
+	getScopeBindingsCmd.Flags().StringVarP(&getScopeBindingsAPIVersion, "version", "v", "",
+		"Version of the API to get the scope bindings")
+	getScopeBindingsCmd.Flags().StringVarP(&getScopeBindingsAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	getScopeBindingsCmd.Flags().StringVarP(&getScopeBindingsCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = getScopeBindingsCmd.MarkFlagRequired("name")
+	_ = getScopeBindingsCmd.MarkFlagRequired("version")
+	_ = getScopeBindingsCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,75 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getSettingsCmdEnvironment string
+
+// GetSettingsCmd related info
+const GetSettingsCmdLiteral = "settings"
+const GetSettingsCmdShortDesc = "Display the settings of an environment"
+
+const GetSettingsCmdLongDesc = `Display the features and the capabilities advertised by the Publisher, DevPortal and Admin settings of the environment specified by the flag --environment, -e, such as the APIM version, the gateway types, the grant types, the key manager types and the scopes`
+
+var getSettingsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSettingsCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSettingsCmdLiteral + ` -e dev --format json
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSettingsCmdLiteral + ` -e dev --format "jsonpath={.grantTypes[*]}"
+NOTE: The flag (--environment (-e)) is mandatory.`
+
+// getSettingsCmd represents the settings command
+var getSettingsCmd = &cobra.Command{
+	Use:     GetSettingsCmdLiteral,
+	Short:   GetSettingsCmdShortDesc,
+	Long:    GetSettingsCmdLongDesc,
+	Example: getSettingsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetSettingsCmdLiteral + " called")
+		cred, err := GetCredentials(getSettingsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetSettingsCmd(cred)
+	},
+}
+
+func executeGetSettingsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getSettingsCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+GetSettingsCmdLiteral+"'", err)
+	}
+
+	settings, err := impl.GetSettingsOfEnv(accessToken, getSettingsCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the settings of "+getSettingsCmdEnvironment, err)
+	}
+	impl.PrintSettings(settings, getCmdFormat)
+}
+
+func init() {
+	GetCmd.AddCommand(getSettingsCmd)
+	getSettingsCmd.Flags().StringVarP(&getSettingsCmdEnvironment, "environment", "e",
+		"", "Environment to get the settings of")
+	_ = getSettingsCmd.MarkFlagRequired("environment")
+}
@@ -30,7 +30,6 @@ import (
 )
 
 var getThrottlePoliciesCmdEnvironment string
-var getThrottlePoliciesCmdFormat string
 var getThrottlePoliciesCmdQuery []string
 
 // GetThrottlePoliciesCmdLiteral related info
@@ -72,7 +71,7 @@ func executeGetThrottlePoliciesCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 
 		if resp.StatusCode() == http.StatusOK {
-			impl.PrintThrottlePolicies(resp, getThrottlePoliciesCmdFormat)
+			impl.PrintThrottlePolicies(resp, getCmdFormat)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
 			fmt.Println(string(resp.Body()))
@@ -91,7 +90,5 @@ func init() {
 		"", "Environment to be searched")
 	getThrottlePoliciesCmd.Flags().StringSliceVarP(&getThrottlePoliciesCmdQuery, "query", "q",
 		[]string{}, "Query pattern")
-	getThrottlePoliciesCmd.Flags().StringVarP(&getThrottlePoliciesCmdFormat, "format", "", "", "Pretty-print throttle policies "+
-		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getThrottlePoliciesCmd.MarkFlagRequired("environment")
 }
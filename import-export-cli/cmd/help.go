@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var helpCmdAll bool
+
+// Help command related usage info
+const helpCmdLiteral = "help"
+const helpCmdShortDesc = "Help about any command"
+const helpCmdLongDesc = `Help provides help for any command in the application.
+Simply type ` + utils.ProjectName + ` help [path to command] for full details.
+Use the flag (--all) to print the usage of every command at once, so that it can be browsed offline.`
+
+const helpCmdExamples = utils.ProjectName + ` ` + helpCmdLiteral + ` import api
+` + utils.ProjectName + ` ` + helpCmdLiteral + ` --all | less`
+
+// helpCmd replaces the default help command of cobra to support printing the usage of every command
+var helpCmd = &cobra.Command{
+	Use:     helpCmdLiteral + " [command]",
+	Short:   helpCmdShortDesc,
+	Long:    helpCmdLongDesc,
+	Example: helpCmdExamples,
+	Run: func(c *cobra.Command, args []string) {
+		if helpCmdAll {
+			printAllCommandsHelp(c.OutOrStdout(), c.Root())
+			return
+		}
+		cmd, _, err := c.Root().Find(args)
+		if cmd == nil || err != nil {
+			c.Printf("Unknown help topic %#q\n", args)
+			_ = c.Root().Usage()
+			return
+		}
+		cmd.InitDefaultHelpFlag()
+		_ = cmd.Help()
+	},
+}
+
+// printAllCommandsHelp prints the description and the usage of a command and all of its available sub commands
+func printAllCommandsHelp(w io.Writer, cmd *cobra.Command) {
+	cmd.InitDefaultHelpFlag()
+	fmt.Fprintln(w, strings.Repeat("=", 80))
+	fmt.Fprintln(w, cmd.CommandPath())
+	fmt.Fprintln(w, strings.Repeat("=", 80))
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	fmt.Fprintln(w, description)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, cmd.UsageString())
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		printAllCommandsHelp(w, child)
+	}
+}
+
+func init() {
+	RootCmd.SetHelpCommand(helpCmd)
+	helpCmd.Flags().BoolVarP(&helpCmdAll, "all", "", false, "Print the usage of every command")
+}
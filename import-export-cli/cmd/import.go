@@ -33,7 +33,8 @@ Import an Application to the environment specified by flag (--environment, -e)`
 
 const importCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f qa/LeasingAPIProduct.zip -e dev
-` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev`
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev --as-tenant wso2.com`
 
 // ImportCmd represents the import command
 var ImportCmd = &cobra.Command{
@@ -50,4 +51,5 @@ var ImportCmd = &cobra.Command{
 // init using Cobra
 func init() {
 	RootCmd.AddCommand(ImportCmd)
+	ImportCmd.PersistentFlags().StringVarP(&CmdAsTenant, "as-tenant", "", "", asTenantFlagDesc)
 }
@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -34,19 +36,42 @@ var (
 	importAPISkipCleanup         bool
 	importAPIRotateRevision      bool
 	importAPISkipDeployments     bool
+	importAPIRedeploy            bool
+	importAPIDeployTo            []string
+	importAPICmdFormat           string
+	importAPITargetVersion       string
+	importAPIContextOverride     string
+	importAPIDryRun              bool
 )
 
 const (
 	// ImportAPI command related usage info
 	ImportAPICmdLiteral   = "api"
 	importAPICmdShortDesc = "Import API"
-	importAPICmdLongDesc  = "Import an API to an environment"
+	importAPICmdLongDesc  = "Import an API to an environment. A new revision of the API is created and deployed to " +
+		"the gateway environments in the deployment_environments.yaml of the project. Use --skip-deployments to update " +
+		"only the working copy, or --deploy-to to deploy the new revision to the given gateway environments instead. " +
+		"Use --redeploy to deploy the latest revision of the API to the gateway environments without importing the " +
+		"project or creating a new revision. With --target-version, the api.yaml of a project generated for another " +
+		"APIM version is converted for the targeted version, with a warning for each field which is mapped or " +
+		"removed. The files of the project matched by the patterns " +
+		"in its " + utils.ProjectIgnoreFileName + " file are not imported. Use --dry-run to print the changes the import " +
+		"would make to the API in the environment without importing it. Environment variables can be referred in the " +
+		"params file as ${VAR} or {{ .Env.VAR }} so that a single params file can be reused across environments, and " +
+		"secrets as vault:<path>#<key> to read them from the HashiCorp Vault configured for the environment"
 )
 
 const importAPICmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f staging/FacebookAPI.zip -e production
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update --rotate-revision
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update --skip-deployments
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --redeploy --deploy-to us-region,eu-region
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update --deploy-to Default,us-region
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update --format json
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --target-version 4.3.0
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --context-override /prod/myapi
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f ~/myapi -e production --update --dry-run
 NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 
 // ImportAPICmd represents the importAPI command
@@ -58,6 +83,18 @@ var ImportAPICmd = &cobra.Command{
 	Example: importAPICmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportAPICmdLiteral + " called")
+		if importAPISkipDeployments && len(importAPIDeployTo) > 0 {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("--deploy-to cannot be used with "+
+				"--skip-deployments"))
+		}
+		if importAPIRedeploy && (importAPISkipDeployments || importAPIRotateRevision || importAPIDryRun) {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("--redeploy cannot be used with "+
+				"--skip-deployments, --rotate-revision or --dry-run as the project is not imported"))
+		}
+		if importAPICmdFormat != "" && importAPICmdFormat != utils.JsonFormatType {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("unsupported format "+importAPICmdFormat+
+				", use "+utils.JsonFormatType))
+		}
 		cred, err := GetCredentials(importEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -66,8 +103,17 @@ var ImportAPICmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
+		if importAPIRedeploy {
+			err = impl.RedeployAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIDeployTo,
+				importAPICmdPreserveProvider, importAPICmdFormat)
+			if err != nil {
+				utils.HandleErrorAndExit("Error deploying API", err)
+			}
+			return
+		}
 		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
+			importAPICmdPreserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments,
+			importAPIDeployTo, importAPICmdFormat, importAPITargetVersion, importAPIContextOverride, importAPIDryRun)
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
@@ -90,10 +136,25 @@ func init() {
 		"revisions with each update")
 	ImportAPICmd.Flags().BoolVar(&importAPISkipDeployments, "skip-deployments", false, "Update only "+
 		"the working copy and skip deployment steps in import")
+	ImportAPICmd.Flags().BoolVar(&importAPIRedeploy, "redeploy", false, "Deploy the latest revision of "+
+		"the API without importing the project or creating a new revision")
+	ImportAPICmd.Flags().StringSliceVar(&importAPIDeployTo, "deploy-to", []string{}, "Gateway environments "+
+		"to deploy the revision to, instead of the ones in the deployment environments file of the project. "+
+		"With --redeploy, a gateway environment can be followed by the vhost (ex: us-region=us.api.example.com)")
 	ImportAPICmd.Flags().StringVarP(&importAPIParamsFile, "params", "", "", "Provide an API Manager params file "+
 		"or a directory generated using \"gen deployment-dir\" command")
 	ImportAPICmd.Flags().BoolVarP(&importAPISkipCleanup, "skip-cleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	ImportAPICmd.Flags().StringVarP(&importAPICmdFormat, "format", "", "", "Output format of the "+
+		"import result. Use \"json\" to print the imported API id and revision id in json format. The revision "+
+		"is empty if no revision is created")
+	ImportAPICmd.Flags().StringVarP(&importAPITargetVersion, "target-version", "", "", "APIM version "+
+		"targeted by the API artifacts. The artifacts are converted for this version before importing. "+
+		"They are imported as they are if not provided")
+	ImportAPICmd.Flags().StringVarP(&importAPIContextOverride, "context-override", "", "", "Context to be "+
+		"set for the API. Overrides the context given in the params file")
+	ImportAPICmd.Flags().BoolVar(&importAPIDryRun, "dry-run", false, "Print the changes the import would "+
+		"make to the API without importing it")
 	// Mark required flags
 	_ = ImportAPICmd.MarkFlagRequired("environment")
 	_ = ImportAPICmd.MarkFlagRequired("file")
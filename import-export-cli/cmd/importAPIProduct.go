@@ -42,7 +42,8 @@ const (
 	// ImportAPIProduct command related usage info
 	importAPIProductCmdLiteral   = "api-product"
 	importAPIProductCmdShortDesc = "Import API Product"
-	importAPIProductCmdLongDesc  = "Import an API Product to an environment"
+	importAPIProductCmdLongDesc  = "Import an API Product to an environment. The files of the project matched by " +
+		"the patterns in its " + utils.ProjectIgnoreFileName + " file are not imported"
 )
 
 const importAPIProductCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f qa/LeasingAPIProduct.zip -e dev
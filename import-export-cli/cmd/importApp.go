@@ -38,7 +38,9 @@ var importAppSkipCleanup bool
 const ImportAppCmdLiteral = "app"
 const importAppCmdShortDesc = "Import App"
 
-const importAppCmdLongDesc = "Import an Application to an environment"
+const importAppCmdLongDesc = `Import an Application to an environment
+Unless the keys are skipped (--skip-keys), the grant types, callback URL and key manager specific properties in ` + utils.ApplicationKeyMappingsFile + ` are restored to the key mappings of the imported Application
+The files of the project matched by the patterns in its ` + utils.ProjectIgnoreFileName + ` file are not imported`
 
 const importAppCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f staging/apps/sampleApp.zip -e prod -o testUser
@@ -0,0 +1,82 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importKeyManagersFile   string
+	importKeyManagersUpdate bool
+)
+
+const (
+	// ImportKeyManagersCmdLiteral command related usage info
+	ImportKeyManagersCmdLiteral   = "keymanagers"
+	importKeyManagersCmdShortDesc = "Import Key Managers"
+	importKeyManagersCmdLongDesc  = "Recreate the Key Managers exported with the export keymanagers command in an " +
+		"environment. The Key Managers are matched with the existing Key Managers of the environment by their names"
+)
+
+const importKeyManagersCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagersCmdLiteral + ` -f ~/.wso2apictl/exported/key-managers/dev/Okta.yaml -e production
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagersCmdLiteral + ` -f ~/.wso2apictl/exported/key-managers/dev -e production --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory
+If a directory is given to --file (-f), all the Key Manager files in the directory are imported`
+
+var ImportKeyManagersCmd = &cobra.Command{
+	Use: ImportKeyManagersCmdLiteral + " --file <path-to-key-managers> --environment " +
+		"<environment>",
+	Short:   importKeyManagersCmdShortDesc,
+	Long:    importKeyManagersCmdLongDesc,
+	Example: importKeyManagersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportKeyManagersCmdLiteral + " called")
+		cred, err := GetCredentials(importEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, importEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for importing Key Managers", err)
+		}
+		err = impl.ImportKeyManagersToEnv(accessOAuthToken, importEnvironment, importKeyManagersFile,
+			importKeyManagersUpdate)
+		if err != nil {
+			utils.HandleErrorAndExit("Error importing Key Managers", err)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportKeyManagersCmd)
+	ImportKeyManagersCmd.Flags().StringVarP(&importKeyManagersFile, "file", "f", "",
+		"File path of the Key Manager, or a directory of Key Managers, to be imported")
+	ImportKeyManagersCmd.Flags().StringVarP(&importEnvironment, "environment", "e",
+		"", "Environment to which the Key Managers should be imported")
+	ImportKeyManagersCmd.Flags().BoolVarP(&importKeyManagersUpdate, "update", "u", false, "Update the "+
+		"Key Managers which already exist in the environment")
+	// Mark required flags
+	_ = ImportKeyManagersCmd.MarkFlagRequired("environment")
+	_ = ImportKeyManagersCmd.MarkFlagRequired("file")
+}
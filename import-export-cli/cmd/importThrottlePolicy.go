@@ -34,14 +34,17 @@ const (
 	// ImportThrottlingPolicyCmdLiteral command related usage info
 	ImportThrottlingPolicyCmdLiteral   = "rate-limiting"
 	importThrottlingPolicyCmdShortDesc = "Import Throttling Policy"
-	importThrottlingPolicyCmdLongDesc  = "Import a Throttling Policy to an environment"
+	importThrottlingPolicyCmdLongDesc  = "Import a Throttling Policy to an environment. The burst control, custom " +
+		"attributes and monetization details of Subscription Policies are validated before importing"
 )
 
 const importThrottlingPolicyCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportThrottlingPolicyCmdLiteral + ` -f qa/customadvanced -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportThrottlingPolicyCmdLiteral + ` -f Env1/Exported/sub1 -e production
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportThrottlingPolicyCmdLiteral + ` -f ~/CustomPolicy -e production -u
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportThrottlingPolicyCmdLiteral + ` -f ~/mythottlepolicy -e production --update
-NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportThrottlingPolicyCmdLiteral + ` -f ~/.wso2apictl/exported/policies/rate-limiting/dev -e production --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory
+If a directory is given to --file (-f), all the Throttling Policy files in the directory are imported`
 
 var ImportThrottlingPolicyCmd = &cobra.Command{
 	Use: ImportThrottlingPolicyCmdLiteral + " --file <path-to-api> --environment " +
@@ -70,7 +73,7 @@ var ImportThrottlingPolicyCmd = &cobra.Command{
 func init() {
 	ImportPolicyCmd.AddCommand(ImportThrottlingPolicyCmd)
 	ImportThrottlingPolicyCmd.Flags().StringVarP(&importThrottlingPolicyFile, "file", "f", "",
-		"File path of the Throttling Policy to be imported")
+		"File path of the Throttling Policy, or a directory of Throttling Policies, to be imported")
 	ImportThrottlingPolicyCmd.Flags().StringVarP(&importEnvironment, "environment", "e",
 		"", "Environment from the which the Throttling Policy should be imported")
 	ImportThrottlingPolicyCmd.Flags().BoolVarP(&importThrottlePolicyUpdate, "update", "u", false, "Update an "+
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
 
 	"github.com/spf13/cobra"
 
@@ -33,20 +34,35 @@ import (
 var (
 	initCmdOutputDir         string
 	initCmdSwaggerPath       string
+	initCmdAsyncAPIPath      string
+	initCmdProtoPath         string
 	initCmdApiDefinitionPath string
 	initCmdInitialState      string
 	initCmdForced            bool
+	initCmdInteractive       bool
+	initCmdTargetVersion     string
 )
 
 const initCmdExample = `apictl init myapi --oas petstore.yaml
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json --initial-state=PUBLISHED
-apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml`
+apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml
+apictl init MyAwesomeAPI --oas ./swagger.yaml --target-version 4.3.0
+apictl init ChatAPI --asyncapi ./asyncapi.yaml
+apictl init OrderService --proto ./protos/order_service.proto
+apictl init MyFirstAPI --interactive`
+
+const initCmdLongDesc = "Initialize a new project in given path. If a OpenAPI specification provided API will be " +
+	"populated with details from it. Swagger 2.0, OpenAPI 3.0 and OpenAPI 3.1 specifications are supported. " +
+	"If an AsyncAPI 2.x specification provided a WebSocket, SSE or WebSub API will be populated based on the " +
+	"protocol of its servers, with the channels mapped to topics. If a proto file provided a gRPC API will be " +
+	"populated with the rpc methods of its services as operations. With --interactive the name, version, " +
+	"context, endpoint, security scheme and gateway type of the API are prompted for"
 
 var InitCommand = &cobra.Command{
 	Use:     "init [project path]",
 	Short:   "Initialize a new project in given path",
-	Long:    "Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it",
+	Long:    initCmdLongDesc,
 	Example: initCmdExample,
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -58,11 +74,11 @@ var InitCommand = &cobra.Command{
 			fmt.Printf("%s already exists\n", initCmdOutputDir)
 			if !stat.IsDir() {
 				fmt.Printf("%s is not a directory\n", initCmdOutputDir)
-				os.Exit(1)
+				utils.Exit(1)
 			}
 			if !initCmdForced {
 				fmt.Println("Run with -f or --force to overwrite directory and create project")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 			fmt.Println("Running command in forced mode")
 		}
@@ -84,7 +100,17 @@ var InitCommand = &cobra.Command{
 			}
 		}
 
-		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath, false)
+		var interactiveDefinition *v2.APIDefinitionFile
+		if initCmdInteractive {
+			definition, err := impl.ReadInitInputsInteractive(initCmdOutputDir)
+			if err != nil {
+				utils.HandleErrorAndExit("Error reading the details of the API", err)
+			}
+			interactiveDefinition = definition
+		}
+
+		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdAsyncAPIPath,
+			initCmdProtoPath, initCmdApiDefinitionPath, interactiveDefinition, false, initCmdTargetVersion)
 		if err != nil {
 			utils.HandleErrorAndContinue("Error initializing project", err)
 			// Remove the already created project with its content since it is partially created and wrong
@@ -107,7 +133,16 @@ func init() {
 		"YAML definition of API")
 	InitCommand.Flags().StringVarP(&initCmdSwaggerPath, "oas", "", "", "Provide an OpenAPI "+
 		"specification file for the API")
+	InitCommand.Flags().StringVarP(&initCmdAsyncAPIPath, "asyncapi", "", "", "Provide an AsyncAPI "+
+		"specification file for a WebSocket, SSE or WebSub API")
+	InitCommand.Flags().StringVarP(&initCmdProtoPath, "proto", "", "", "Provide a proto file for a gRPC "+
+		"API. The files it imports are resolved relative to its directory")
 	InitCommand.Flags().StringVar(&initCmdInitialState, "initial-state", "", fmt.Sprintf("Provide the initial state "+
 		"of the API; Valid states: %v", utils.ValidInitialStates))
 	InitCommand.Flags().BoolVarP(&initCmdForced, "force", "f", false, "Force create project")
+	InitCommand.Flags().BoolVarP(&initCmdInteractive, "interactive", "i", false, "Prompt for the details of the "+
+		"API with sensible defaults")
+	InitCommand.Flags().StringVarP(&initCmdTargetVersion, "target-version", "", "", "APIM version targeted "+
+		fmt.Sprintf("by the project artifacts; Supported versions: %v", utils.SupportedAPIMVersions))
+	InitCommand.MarkFlagsMutuallyExclusive("oas", "asyncapi", "proto")
 }
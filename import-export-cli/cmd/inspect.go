@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var inspectCmdFormat string
+
+// Inspect command related usage Info
+const InspectCmdLiteral = "inspect"
+const inspectCmdShortDesc = "Summarize an exported API archive"
+
+const inspectCmdLongDesc = `Summarize an exported API archive or API project without importing it.
+The name, version, context, endpoints, resources, operation policies, certificates and documents of the API are listed.`
+
+const inspectCmdExamples = utils.ProjectName + ` ` + InspectCmdLiteral + ` ./PizzaShackAPI_1.0.0.zip
+` + utils.ProjectName + ` ` + InspectCmdLiteral + ` ./PizzaShackAPI --format json`
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:     InspectCmdLiteral + " <path>",
+	Short:   inspectCmdShortDesc,
+	Long:    inspectCmdLongDesc,
+	Example: inspectCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + InspectCmdLiteral + " called")
+		summary, err := impl.InspectArchive(args[0])
+		if err != nil {
+			utils.HandleErrorAndExit("Error while inspecting "+args[0], err)
+		}
+		impl.PrintArchiveSummary(summary, inspectCmdFormat)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(inspectCmd)
+	inspectCmd.Flags().StringVarP(&inspectCmdFormat, "format", "", "",
+		"Output format of the summary. Use \"json\" to output the summary in JSON")
+}
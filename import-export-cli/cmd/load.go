@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Load command related usage Info
+const LoadCmdLiteral = "load"
+const loadCmdShortDesc = "Drive load through an API deployed in a gateway environment"
+
+const loadCmdLongDesc = `Send requests at a constant rate to an API available in the environment specified by flag (--environment, -e) through the gateway and report the latencies of the responses`
+
+const loadCmdExamples = utils.ProjectName + ` ` + LoadCmdLiteral + ` ` + LoadAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --rps 50 --duration 2m`
+
+// LoadCmd represents the load command
+var LoadCmd = &cobra.Command{
+	Use:     LoadCmdLiteral,
+	Short:   loadCmdShortDesc,
+	Long:    loadCmdLongDesc,
+	Example: loadCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + LoadCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(LoadCmd)
+}
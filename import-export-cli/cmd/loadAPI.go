@@ -0,0 +1,98 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var loadAPIName string
+var loadAPIVersion string
+var loadAPIProvider string
+var loadAPICmdEnvironment string
+var loadAPICmdGatewayEnv string
+var loadAPICmdResource string
+var loadAPICmdMethod string
+var loadAPICmdRPS int
+var loadAPICmdDuration time.Duration
+
+// LoadAPI command related usage Info
+const LoadAPICmdLiteral = "api"
+const loadAPICmdShortDesc = "Drive load through an API"
+
+const loadAPICmdLongDesc = `Generate a token to invoke an API by subscribing to a default application and send requests to the resource given by flag (--resource) of the API through the gateway.
+Requests are sent at the rate given by flag (--rps) for the period given by flag (--duration) without waiting for previous responses. The number of requests, the status codes, the latency percentiles and a latency histogram are printed at the end`
+
+const loadAPICmdExamples = utils.ProjectName + ` ` + LoadCmdLiteral + ` ` + LoadAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --rps 50 --duration 2m
+` + utils.ProjectName + ` ` + LoadCmdLiteral + ` ` + LoadAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin -e dev -g Default --resource /menu --rps 10 --duration 30s
+` + utils.ProjectName + ` ` + LoadCmdLiteral + ` ` + LoadAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --resource /order --method POST --rps 5 --duration 1m
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// loadAPICmd represents the load api command
+var loadAPICmd = &cobra.Command{
+	Use:     LoadAPICmdLiteral,
+	Short:   loadAPICmdShortDesc,
+	Long:    loadAPICmdLongDesc,
+	Example: loadAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + LoadCmdLiteral + " " + LoadAPICmdLiteral + " called")
+		if loadAPICmdRPS <= 0 {
+			utils.HandleErrorAndExit("Invalid rate", errors.New("rps should be a positive number of requests"))
+		}
+		if loadAPICmdDuration <= 0 {
+			utils.HandleErrorAndExit("Invalid duration", errors.New("duration should be a positive period"))
+		}
+		cred, err := GetCredentials(loadAPICmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		cred.ClientId, cred.ClientSecret, err = impl.CallDCREndpoint(cred, loadAPICmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Internal error occurred", err)
+		}
+		err = impl.LoadTestAPI(cred, loadAPICmdEnvironment, loadAPIName, loadAPIVersion, loadAPIProvider,
+			loadAPICmdGatewayEnv, loadAPICmdResource, loadAPICmdMethod, loadAPICmdRPS, loadAPICmdDuration)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while driving load through the API", err)
+		}
+	},
+}
+
+func init() {
+	LoadCmd.AddCommand(loadAPICmd)
+	loadAPICmd.Flags().StringVarP(&loadAPIName, "name", "n", "", "Name of the API to invoke")
+	loadAPICmd.Flags().StringVarP(&loadAPIVersion, "version", "v", "", "Version of the API to invoke")
+	loadAPICmd.Flags().StringVarP(&loadAPIProvider, "provider", "r", "", "Provider of the API")
+	loadAPICmd.Flags().StringVarP(&loadAPICmdEnvironment, "environment", "e", "", "Environment of the API")
+	loadAPICmd.Flags().StringVarP(&loadAPICmdGatewayEnv, "gateway-env", "g", "", "Gateway environment to send the "+
+		"requests to. The first gateway environment of the API is used by default")
+	loadAPICmd.Flags().StringVarP(&loadAPICmdResource, "resource", "", "/", "Resource path of the API to invoke")
+	loadAPICmd.Flags().StringVarP(&loadAPICmdMethod, "method", "", "GET", "HTTP method of the requests")
+	loadAPICmd.Flags().IntVarP(&loadAPICmdRPS, "rps", "", 10, "Number of requests to send per second")
+	loadAPICmd.Flags().DurationVarP(&loadAPICmdDuration, "duration", "", time.Minute,
+		"Period to send the requests for (e.g. 30s, 2m)")
+	_ = loadAPICmd.MarkFlagRequired("name")
+	_ = loadAPICmd.MarkFlagRequired("version")
+	_ = loadAPICmd.MarkFlagRequired("environment")
+}
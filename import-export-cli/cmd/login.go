@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -38,7 +39,9 @@ var loginPasswordStdin bool
 
 const loginCmdLiteral = "login [environment] [flags]"
 const loginCmdShortDesc = "Login to an API Manager"
-const loginCmdLongDesc = `Login to an API Manager using credentials`
+const loginCmdLongDesc = `Login to an API Manager using credentials
+
+Commands can be run without logging in by setting the credentials of the environment in the APICTL_ENV_<ENV>_USERNAME and APICTL_ENV_<ENV>_PASSWORD (optionally along with APICTL_ENV_<ENV>_CLIENT_ID and APICTL_ENV_<ENV>_CLIENT_SECRET) environment variables, or an access token in APICTL_ENV_<ENV>_TOKEN. These credentials are not stored.`
 const loginCmdExamples = utils.ProjectName + " login dev -u admin -p admin\n" +
 	utils.ProjectName + " login dev -u admin\n" +
 	"cat ~/.mypassword | " + utils.ProjectName + " login dev -u admin"
@@ -57,20 +60,20 @@ var loginCmd = &cobra.Command{
 			fmt.Println("Warning: Using --password in CLI is not secure. Use --password-stdin")
 			if loginPasswordStdin {
 				fmt.Println("--password and --password-stdin are mutual exclusive")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 		}
 
 		if loginPasswordStdin {
 			if loginUsername == "" {
 				fmt.Println("An username is required to use password-stdin")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 
 			data, err := ioutil.ReadAll(os.Stdin)
 			if err != nil {
 				fmt.Println(err)
-				os.Exit(1)
+				utils.Exit(1)
 			}
 
 			loginPassword = strings.TrimRight(strings.TrimSuffix(string(data), "\n"), "\r")
@@ -79,12 +82,12 @@ var loginCmd = &cobra.Command{
 		store, err := credentials.GetDefaultCredentialStore()
 		if err != nil {
 			fmt.Println("Error occurred while loading credential store : ", err)
-			os.Exit(1)
+			utils.Exit(1)
 		}
 		err = runLogin(store, environment, loginUsername, loginPassword)
 		if err != nil {
 			fmt.Println("Error occurred while login : ", err)
-			os.Exit(1)
+			utils.Exit(1)
 		}
 	},
 }
@@ -92,7 +95,7 @@ var loginCmd = &cobra.Command{
 func runLogin(store credentials.Store, environment, username, password string) error {
 	if !utils.APIMExistsInEnv(environment, utils.MainConfigFilePath) {
 		fmt.Println("APIM does not exists in", environment, "Add it using add env")
-		os.Exit(1)
+		utils.Exit(1)
 	}
 
 	if username == "" {
@@ -138,7 +141,23 @@ func GetCredentials(env string) (credentials.Credential, error) {
 
 	if !utils.APIMExistsInEnv(env, utils.MainConfigFilePath) {
 		fmt.Println("APIM does not exists in", env, "Add it using add env")
-		os.Exit(1)
+		utils.Exit(1)
+	}
+
+	// credentials set in the environment variables are used without logging in
+	if envVarCredentials := utils.GetEnvVarCredentials(env); envVarCredentials.HasCredentials() {
+		if envVarCredentials.AccessToken != "" && CmdAsTenant != "" {
+			return credentials.Credential{}, errors.New("cannot run the command as another tenant using the " +
+				"access token set in the environment variables")
+		}
+		cred, err := credentials.GetEnvVarCredential(env, envVarCredentials)
+		if err != nil {
+			return credentials.Credential{}, err
+		}
+		if CmdAsTenant != "" {
+			return credentials.GetTenantCredential(cred, env, CmdAsTenant)
+		}
+		return cred, nil
 	}
 
 	// check for creds
@@ -154,6 +173,9 @@ func GetCredentials(env string) (credentials.Credential, error) {
 	if err != nil {
 		return credentials.Credential{}, err
 	}
+	if CmdAsTenant != "" {
+		return credentials.GetTenantCredential(cred, env, CmdAsTenant)
+	}
 	return cred, nil
 }
 
@@ -20,7 +20,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -43,7 +42,7 @@ var logoutCmd = &cobra.Command{
 		err := runLogout(args[0])
 		if err != nil {
 			fmt.Println(err.Error())
-			os.Exit(1)
+			utils.Exit(1)
 		}
 	},
 }
@@ -0,0 +1,67 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package activate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+)
+
+var activateConnectorCmdEnvironment string
+var activateConnectorCmdPackage string
+
+const artifactConnector = "connector"
+const activateConnectorCmdLiteral = "connector [connector-name]"
+
+var activateConnectorCmd = &cobra.Command{
+	Use:     activateConnectorCmdLiteral,
+	Short:   generateActivateCmdShortDescForArtifact(artifactConnector),
+	Long:    generateActivateCmdLongDescForArtifact(artifactConnector, "connector-name"),
+	Example: generateActivateCmdExamplesForArtifact(artifactConnector, miUtils.GetTrimmedCmdLiteral(activateConnectorCmdLiteral), "salesforce"),
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleActivateConnectorCmdArguments(args)
+	},
+}
+
+func init() {
+	ActivateCmd.AddCommand(activateConnectorCmd)
+	setEnvFlag(activateConnectorCmd, &activateConnectorCmdEnvironment, artifactConnector)
+	activateConnectorCmd.Flags().StringVarP(&activateConnectorCmdPackage, "package", "", "", "Package of the connector. "+
+		"Required only if connectors with the same name exist in multiple packages")
+}
+
+func handleActivateConnectorCmdArguments(args []string) {
+	printActivateCmdVerboseLog(miUtils.GetTrimmedCmdLiteral(activateConnectorCmdLiteral))
+	credentials.HandleMissingCredentials(activateConnectorCmdEnvironment)
+	executeActivateConnector(args[0])
+}
+
+func executeActivateConnector(connectorName string) {
+	resp, err := impl.ActivateConnector(activateConnectorCmdEnvironment, connectorName, activateConnectorCmdPackage)
+	if err != nil {
+		printErrorForArtifact(artifactConnector, connectorName, err)
+	} else {
+		fmt.Println(resp)
+	}
+}
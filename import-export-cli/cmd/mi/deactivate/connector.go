@@ -0,0 +1,67 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package deactivate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+)
+
+var deactivateConnectorCmdEnvironment string
+var deactivateConnectorCmdPackage string
+
+const artifactConnector = "connector"
+const deactivateConnectorCmdLiteral = "connector [connector-name]"
+
+var deactivateConnectorCmd = &cobra.Command{
+	Use:     deactivateConnectorCmdLiteral,
+	Short:   generateDeactivateCmdShortDescForArtifact(artifactConnector),
+	Long:    generateDeactivateCmdLongDescForArtifact(artifactConnector, "connector-name"),
+	Example: generateDeactivateCmdExamplesForArtifact(artifactConnector, miUtils.GetTrimmedCmdLiteral(deactivateConnectorCmdLiteral), "salesforce"),
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleDeactivateConnectorCmdArguments(args)
+	},
+}
+
+func init() {
+	DeactivateCmd.AddCommand(deactivateConnectorCmd)
+	setEnvFlag(deactivateConnectorCmd, &deactivateConnectorCmdEnvironment, artifactConnector)
+	deactivateConnectorCmd.Flags().StringVarP(&deactivateConnectorCmdPackage, "package", "", "", "Package of the connector. "+
+		"Required only if connectors with the same name exist in multiple packages")
+}
+
+func handleDeactivateConnectorCmdArguments(args []string) {
+	printDeactivateCmdVerboseLog(miUtils.GetTrimmedCmdLiteral(deactivateConnectorCmdLiteral))
+	credentials.HandleMissingCredentials(deactivateConnectorCmdEnvironment)
+	executeDeactivateConnector(args[0])
+}
+
+func executeDeactivateConnector(connectorName string) {
+	resp, err := impl.DeactivateConnector(deactivateConnectorCmdEnvironment, connectorName, deactivateConnectorCmdPackage)
+	if err != nil {
+		printErrorForArtifact(artifactConnector, connectorName, err)
+	} else {
+		fmt.Println(resp)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package deactivate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+)
+
+var deactivateDataServiceCmdEnvironment string
+
+const artifactDataService = "data service"
+const deactivateDataServiceCmdLiteral = "data-service [data-service-name]"
+
+var deactivateDataServiceCmd = &cobra.Command{
+	Use:     deactivateDataServiceCmdLiteral,
+	Short:   generateDeactivateCmdShortDescForArtifact(artifactDataService),
+	Long:    generateDeactivateCmdLongDescForArtifact(artifactDataService, "data-service-name"),
+	Example: generateDeactivateCmdExamplesForArtifact(artifactDataService, miUtils.GetTrimmedCmdLiteral(deactivateDataServiceCmdLiteral), "RESTDataService"),
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleDeactivateDataServiceCmdArguments(args)
+	},
+}
+
+func init() {
+	DeactivateCmd.AddCommand(deactivateDataServiceCmd)
+	setEnvFlag(deactivateDataServiceCmd, &deactivateDataServiceCmdEnvironment, artifactDataService)
+}
+
+func handleDeactivateDataServiceCmdArguments(args []string) {
+	printDeactivateCmdVerboseLog(miUtils.GetTrimmedCmdLiteral(deactivateDataServiceCmdLiteral))
+	credentials.HandleMissingCredentials(deactivateDataServiceCmdEnvironment)
+	executeDeactivateDataService(args[0])
+}
+
+func executeDeactivateDataService(dataServiceName string) {
+	resp, err := impl.DeactivateDataService(deactivateDataServiceCmdEnvironment, dataServiceName)
+	if err != nil {
+		printErrorForArtifact(artifactDataService, dataServiceName, err)
+	} else {
+		fmt.Println(resp)
+	}
+}
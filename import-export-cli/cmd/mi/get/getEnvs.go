@@ -44,7 +44,7 @@ var getEnvsCmd = &cobra.Command{
 	Example: getEnvsCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + GetEnvsCmdLiteral + " called")
-		envs := utils.GetMainConfigFromFile(utils.MainConfigFilePath).Environments
+		envs := utils.GetEnvironments(utils.MainConfigFilePath)
 		impl.PrintEnvs(envs, envsCmdFormat, defaulEnvsTableFormat)
 	},
 }
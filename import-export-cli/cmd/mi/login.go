@@ -54,20 +54,20 @@ var loginCmd = &cobra.Command{
 			fmt.Println("Warning: Using --password in CLI is not secure. Use --password-stdin")
 			if loginPasswordStdin {
 				fmt.Println("--password and --password-stdin are mutual exclusive")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 		}
 
 		if loginPasswordStdin {
 			if loginUsername == "" {
 				fmt.Println("An username is required to use password-stdin")
-				os.Exit(1)
+				utils.Exit(1)
 			}
 
 			data, err := ioutil.ReadAll(os.Stdin)
 			if err != nil {
 				fmt.Println(err)
-				os.Exit(1)
+				utils.Exit(1)
 			}
 
 			loginPassword = strings.TrimRight(strings.TrimSuffix(string(data), "\n"), "\r")
@@ -76,12 +76,12 @@ var loginCmd = &cobra.Command{
 		store, err := credentials.GetDefaultCredentialStore()
 		if err != nil {
 			fmt.Println("Error occurred while loading credential store : ", err)
-			os.Exit(1)
+			utils.Exit(1)
 		}
 		err = credentials.RunMILogin(store, environment, loginUsername, loginPassword)
 		if err != nil {
 			fmt.Println("Error occurred while login : ", err)
-			os.Exit(1)
+			utils.Exit(1)
 		}
 	},
 }
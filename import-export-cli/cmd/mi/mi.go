@@ -20,7 +20,6 @@ package mi
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -122,6 +121,6 @@ func initConfig() {
 func Execute() {
 	if err := MICmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(-1)
+		utils.Exit(-1)
 	}
 }
@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package mi
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var statusCmdEnvironment string
+var statusCmdFormat string
+
+const statusCmdLiteral = "status"
+const statusCmdShortDesc = "Display a health summary of a Micro Integrator"
+const statusCmdLongDesc = `Display a health summary of the Micro Integrator in the environment specified by the flag --environment, -e in a single table.
+The summary includes the server and the JVM it runs on, the active and faulty composite apps, the inactive endpoints and the disabled proxy services.`
+
+var statusCmdExamples = utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + statusCmdLiteral + " -e dev\n" +
+	utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + statusCmdLiteral + " -e dev --format json\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:     statusCmdLiteral,
+	Short:   statusCmdShortDesc,
+	Long:    statusCmdLongDesc,
+	Example: statusCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + statusCmdLiteral + " called")
+		credentials.HandleMissingCredentials(statusCmdEnvironment)
+		impl.PrintStatus(impl.GetStatus(statusCmdEnvironment), statusCmdFormat)
+	},
+}
+
+// init using Cobra
+func init() {
+	MICmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVarP(&statusCmdEnvironment, "environment", "e", "", "Environment of the Micro Integrator")
+	statusCmd.Flags().StringVarP(&statusCmdFormat, "format", "", "", "Pretty-print the status using Go Templates. "+
+		"Use \"json\" to print the status in json format")
+	_ = statusCmd.MarkFlagRequired("environment")
+}
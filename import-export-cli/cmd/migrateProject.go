@@ -0,0 +1,82 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var migrateProjectCmdTarget string
+var migrateProjectCmdDestination string
+
+// MigrateProject command related usage Info
+const MigrateProjectCmdLiteral = "project"
+const migrateProjectCmdShortDesc = "Convert APIs exported from APIM 3.x to API projects"
+
+const migrateProjectCmdLongDesc = `Convert APIs exported from API Manager 3.x (archives or extracted directories) to API projects of the API Manager version given by flag (--target).
+The path can be a single exported API or a directory holding several exported APIs. A project is created for each API in the directory given by flag (--destination).
+Custom mediation sequences are converted to operation policies attached to the API, and the "Production and Sandbox" gateway environment is mapped to "Default".
+Constructs which could not be converted are listed for each API so that they can be fixed manually before importing the projects.`
+
+const migrateProjectCmdExamples = utils.ProjectName + ` ` + MigrateCmdLiteral + ` ` + MigrateProjectCmdLiteral + ` ./old-export --target 4.6
+` + utils.ProjectName + ` ` + MigrateCmdLiteral + ` ` + MigrateProjectCmdLiteral + ` ./PizzaShackAPI_1.0.0.zip --target 4.6 -d ./projects
+NOTE: The flag (--target) is mandatory.`
+
+// migrateProjectCmd represents the migrate project command
+var migrateProjectCmd = &cobra.Command{
+	Use:     MigrateProjectCmdLiteral + " <path>",
+	Short:   migrateProjectCmdShortDesc,
+	Long:    migrateProjectCmdLongDesc,
+	Example: migrateProjectCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + MigrateCmdLiteral + " " + MigrateProjectCmdLiteral + " called")
+		migrations, err := impl.MigrateLegacyProjects(args[0], migrateProjectCmdDestination, migrateProjectCmdTarget)
+		printLegacyProjectMigrations(migrations)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while migrating "+args[0], err)
+		}
+	},
+}
+
+func printLegacyProjectMigrations(migrations []impl.LegacyProjectMigration) {
+	for _, migration := range migrations {
+		fmt.Println("Migrated " + migration.Source + " to " + migration.Destination)
+		if len(migration.Unconverted) == 0 {
+			continue
+		}
+		fmt.Println("  Unconverted constructs:")
+		for _, unconverted := range migration.Unconverted {
+			fmt.Println("  - " + unconverted)
+		}
+	}
+}
+
+func init() {
+	MigrateCmd.AddCommand(migrateProjectCmd)
+	migrateProjectCmd.Flags().StringVarP(&migrateProjectCmdTarget, "target", "", "",
+		"APIM version the projects are targeted to (ex: 4.6)")
+	migrateProjectCmd.Flags().StringVarP(&migrateProjectCmdDestination, "destination", "d", ".",
+		"Path of the directory where the projects should be created")
+	_ = migrateProjectCmd.MarkFlagRequired("target")
+}
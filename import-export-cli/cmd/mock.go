@@ -0,0 +1,65 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var mockCmdPort int
+
+// Mock command related usage Info
+const MockCmdLiteral = "mock"
+const mockCmdShortDesc = "Start a mock server for an API project"
+
+const mockCmdLongDesc = `Start a local mock server using the OpenAPI definition of an API project. The mock server responds to each resource of the API with the example of the successful response given in the definition, or a sample generated from the schema of the response when no example is given.
+Resources are served under the base path of the API given in the definition (basePath of OpenAPI 2 or the path of the first server url of OpenAPI 3)`
+
+const mockCmdExamples = utils.ProjectName + ` ` + MockCmdLiteral + ` ./MyAPI
+` + utils.ProjectName + ` ` + MockCmdLiteral + ` ./MyAPI --port 8081`
+
+// MockCmd represents the mock command
+var MockCmd = &cobra.Command{
+	Use:     MockCmdLiteral + " [project path]",
+	Short:   mockCmdShortDesc,
+	Long:    mockCmdLongDesc,
+	Example: mockCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + MockCmdLiteral + " called")
+		if stat, err := os.Stat(args[0]); err != nil || !stat.IsDir() {
+			utils.HandleErrorAndExit("Invalid API project", errors.New(args[0]+" is not a directory"))
+		}
+		err := impl.StartMockServer(args[0], mockCmdPort)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while running the mock server", err)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(MockCmd)
+	MockCmd.Flags().IntVarP(&mockCmdPort, "port", "p", 8081, "Port to start the mock server on")
+}
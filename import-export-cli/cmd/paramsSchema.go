@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsSchemaCmdOutput string
+
+// ParamsSchema command related usage Info
+const ParamsSchemaCmdLiteral = "schema"
+const paramsSchemaCmdShortDesc = "Print the JSON Schema of API params files"
+
+const paramsSchemaCmdLongDesc = `Print the JSON Schema of API params files, or write it to the file given by flag (--output).
+Editors supporting JSON Schema can use it to provide autocompletion and validation for params files. For example, with the YAML language server add the following line to the top of the params file.
+  # yaml-language-server: $schema=./api_params.schema.json
+Params files are validated against the same schema when they are loaded by the import commands`
+
+const paramsSchemaCmdExamples = utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsSchemaCmdLiteral + `
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsSchemaCmdLiteral + ` --output api_params.schema.json`
+
+// paramsSchemaCmd represents the params schema command
+var paramsSchemaCmd = &cobra.Command{
+	Use:     ParamsSchemaCmdLiteral,
+	Short:   paramsSchemaCmdShortDesc,
+	Long:    paramsSchemaCmdLongDesc,
+	Example: paramsSchemaCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ParamsCmdLiteral + " " + ParamsSchemaCmdLiteral + " called")
+		if paramsSchemaCmdOutput == "" {
+			fmt.Print(params.ApiParamsSchema)
+			return
+		}
+		err := ioutil.WriteFile(paramsSchemaCmdOutput, []byte(params.ApiParamsSchema), 0644)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while writing the params schema", err)
+		}
+		fmt.Println("Params schema written to " + paramsSchemaCmdOutput)
+	},
+}
+
+func init() {
+	ParamsCmd.AddCommand(paramsSchemaCmd)
+	paramsSchemaCmd.Flags().StringVarP(&paramsSchemaCmdOutput, "output", "o", "",
+		"File to write the schema to. The schema is printed to the standard output by default")
+}
@@ -0,0 +1,46 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Preview command related usage Info
+const PreviewCmdLiteral = "preview"
+const previewCmdShortDesc = "Preview how an API project would behave in an environment"
+const previewCmdLongDesc = `Preview how an API project would behave once imported to an environment, without importing it`
+
+const previewCmdExamples = utils.ProjectName + ` ` + PreviewCmdLiteral + ` ` + PreviewPoliciesCmdLiteral + ` --project ./PetStoreAPI --resource "GET /pets" -e production`
+
+// PreviewCmd represents the preview command
+var PreviewCmd = &cobra.Command{
+	Use:     PreviewCmdLiteral,
+	Short:   previewCmdShortDesc,
+	Long:    previewCmdLongDesc,
+	Example: previewCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PreviewCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(PreviewCmd)
+}
@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var previewPoliciesProject string
+var previewPoliciesResource string
+var previewPoliciesEnvironment string
+var previewPoliciesFormat string
+
+// PreviewPoliciesCmd command related usage info
+const PreviewPoliciesCmdLiteral = "policies"
+const previewPoliciesCmdShortDesc = "Preview the policy chains of the resources of an API project"
+
+const previewPoliciesCmdLongDesc = `Render the effective request, response and fault policy chains of the resources of an API project
+in the order the gateway applies them. The API level policies are applied before the resource level policies in the request
+flow and after them in the response and fault flows. The policies not shipped in the project are resolved against the common
+policies of the environment specified by flag (--environment, -e). Policies which cannot be resolved, are not applicable to
+the flow, miss required parameters or are applied more than once in a flow are reported as warnings.`
+
+const previewPoliciesCmdExamples = utils.ProjectName + ` ` + PreviewCmdLiteral + ` ` + PreviewPoliciesCmdLiteral + ` --project ./PetStoreAPI --resource "GET /pets" -e production
+` + utils.ProjectName + ` ` + PreviewCmdLiteral + ` ` + PreviewPoliciesCmdLiteral + ` --project ./PetStoreAPI_1.0.0.zip -e dev --format json
+NOTE: Both the flags (--project and --environment (-e)) are mandatory. All the resources are previewed if the flag (--resource) is not given.`
+
+// PreviewPoliciesCmd represents the preview policies command
+var PreviewPoliciesCmd = &cobra.Command{
+	Use: PreviewPoliciesCmdLiteral + " (--project <path-to-api-project> --resource <verb-and-target-of-the-resource> " +
+		"--environment <environment-to-resolve-common-policies>)",
+	Short:   previewPoliciesCmdShortDesc,
+	Long:    previewPoliciesCmdLongDesc,
+	Example: previewPoliciesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PreviewPoliciesCmdLiteral + " called")
+		cred, err := GetCredentials(previewPoliciesEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, previewPoliciesEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to fetch the common policies", err)
+		}
+		chains, err := impl.PreviewPoliciesInEnv(accessToken, previewPoliciesEnvironment, previewPoliciesProject,
+			previewPoliciesResource)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while previewing the policies of "+previewPoliciesProject, err)
+		}
+		impl.PrintPolicyChains(chains, previewPoliciesFormat)
+	},
+}
+
+// init using Cobra
+func init() {
+	PreviewCmd.AddCommand(PreviewPoliciesCmd)
+	PreviewPoliciesCmd.Flags().StringVarP(&previewPoliciesProject, "project", "", "",
+		"Path of the API project or archive")
+	PreviewPoliciesCmd.Flags().StringVarP(&previewPoliciesResource, "resource", "", "",
+		"Verb and target of the resource to preview (ex: \"GET /pets\")")
+	PreviewPoliciesCmd.Flags().StringVarP(&previewPoliciesEnvironment, "environment", "e", "",
+		"Environment to resolve the common policies from")
+	PreviewPoliciesCmd.Flags().StringVarP(&previewPoliciesFormat, "format", "", "",
+		"Output format of the policy chains. Use \"json\" to output the policy chains in JSON")
+	_ = PreviewPoliciesCmd.MarkFlagRequired("project")
+	_ = PreviewPoliciesCmd.MarkFlagRequired("environment")
+}
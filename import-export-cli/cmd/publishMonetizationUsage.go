@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var publishMonetizationUsageCmdEnvironment string
+
+// PublishMonetizationUsageCmd related info
+const PublishMonetizationUsageCmdLiteral = "monetization-usage"
+const publishMonetizationUsageCmdShortDesc = "Publish the monetization usage to the billing engine"
+
+const publishMonetizationUsageCmdLongDesc = `Trigger a job which publishes the usage of the monetized APIs in the environment specified by the flag --environment, -e to the billing engine.
+Use "` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetMonetizationUsageCmdLiteral + `" to check the status of the job`
+
+var publishMonetizationUsageCmdExamples = utils.ProjectName + ` ` + PublishCmdLiteral + ` ` + PublishMonetizationUsageCmdLiteral + ` -e dev
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// publishMonetizationUsageCmd represents the publish monetization-usage command
+var publishMonetizationUsageCmd = &cobra.Command{
+	Use:     PublishMonetizationUsageCmdLiteral,
+	Short:   publishMonetizationUsageCmdShortDesc,
+	Long:    publishMonetizationUsageCmdLongDesc,
+	Example: publishMonetizationUsageCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PublishCmdLiteral + " " + PublishMonetizationUsageCmdLiteral + " called")
+		cred, err := GetCredentials(publishMonetizationUsageCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executePublishMonetizationUsageCmd(cred)
+	},
+}
+
+func executePublishMonetizationUsageCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, publishMonetizationUsageCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+PublishMonetizationUsageCmdLiteral+"'", err)
+	}
+	result, err := impl.PublishMonetizationUsageInEnv(accessToken, publishMonetizationUsageCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while publishing the monetization usage", err)
+	}
+	fmt.Println("Monetization usage publish job triggered. Status: " + result.Status)
+	if result.Message != "" {
+		fmt.Println(result.Message)
+	}
+}
+
+func init() {
+	PublishCmd.AddCommand(publishMonetizationUsageCmd)
+	publishMonetizationUsageCmd.Flags().StringVarP(&publishMonetizationUsageCmdEnvironment, "environment", "e",
+		"", "Environment to publish the monetization usage")
+	_ = publishMonetizationUsageCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var restoreAPIRevisionName string
+var restoreAPIRevisionVersion string
+var restoreAPIRevisionProvider string
+var restoreAPIRevisionNum string
+var restoreAPIRevisionEnvironment string
+
+// RestoreAPIRevisionCmd command related usage info
+const RestoreAPIRevisionCmdLiteral = "api-revision"
+const restoreAPIRevisionCmdShortDesc = "Restore an API to a revision"
+
+const restoreAPIRevisionCmdLongDesc = `Restore the working copy of an API to the revision given by flag (--rev). The changes made to the
+working copy after the revision was created are discarded. The deployments of the API are not changed.`
+
+const restoreAPIRevisionCmdExamples = utils.ProjectName + ` ` + RestoreCmdLiteral + ` ` + RestoreAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 2 -e dev
+` + utils.ProjectName + ` ` + RestoreCmdLiteral + ` ` + RestoreAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r alice --rev 3 -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --rev, --environment (-e)) are mandatory.`
+
+// RestoreAPIRevisionCmd represents the restore api-revision command
+var RestoreAPIRevisionCmd = &cobra.Command{
+	Use: RestoreAPIRevisionCmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --provider <provider-of-the-api> " +
+		"--rev <revision-number-of-the-api> --environment <environment-of-the-api>)",
+	Short:   restoreAPIRevisionCmdShortDesc,
+	Long:    restoreAPIRevisionCmdLongDesc,
+	Example: restoreAPIRevisionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RestoreAPIRevisionCmdLiteral + " called")
+		cred, err := GetCredentials(restoreAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, restoreAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to restore the API revision", err)
+		}
+		err = impl.RestoreAPIRevision(accessToken, restoreAPIRevisionEnvironment, restoreAPIRevisionName,
+			restoreAPIRevisionVersion, restoreAPIRevisionProvider, restoreAPIRevisionNum)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while restoring the API revision", err)
+		}
+		fmt.Println("API " + restoreAPIRevisionName + "_" + restoreAPIRevisionVersion +
+			" successfully restored to revision " + restoreAPIRevisionNum)
+	},
+}
+
+// init using Cobra
+func init() {
+	RestoreCmd.AddCommand(RestoreAPIRevisionCmd)
+	RestoreAPIRevisionCmd.Flags().StringVarP(&restoreAPIRevisionName, "name", "n", "",
+		"Name of the API")
+	RestoreAPIRevisionCmd.Flags().StringVarP(&restoreAPIRevisionVersion, "version", "v", "",
+		"Version of the API")
+	RestoreAPIRevisionCmd.Flags().StringVarP(&restoreAPIRevisionProvider, "provider", "r", "",
+		"Provider of the API")
+	RestoreAPIRevisionCmd.Flags().StringVarP(&restoreAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to restore")
+	RestoreAPIRevisionCmd.Flags().StringVarP(&restoreAPIRevisionEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = RestoreAPIRevisionCmd.MarkFlagRequired("name")
+	_ = RestoreAPIRevisionCmd.MarkFlagRequired("version")
+	_ = RestoreAPIRevisionCmd.MarkFlagRequired("rev")
+	_ = RestoreAPIRevisionCmd.MarkFlagRequired("environment")
+}
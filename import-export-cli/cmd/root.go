@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd/aws"
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd/k8s"
@@ -45,11 +46,17 @@ import (
 var verbose bool
 var cfgFile string
 var insecure bool
+var noCache bool
 var cmdPassword string
 var CmdUsername string
 var CmdExportEnvironment string
 var CmdResourceTenantDomain string
+var CmdAsTenant string
 var CmdForceStartFromBegin bool
+var outputFilePath string
+
+const asTenantFlagDesc = "Tenant domain to run the command against using the tenant qualified username of the " +
+	"logged in super tenant user"
 
 // RootCmd related info
 const rootCmdShortDesc = "CLI for Importing and Exporting APIs and Applications and Managing WSO2 Micro Integrator"
@@ -69,6 +76,14 @@ var RootCmd = &cobra.Command{
 	DisableFlagParsing: isK8sEnabled(),
 	Short:              rootCmdShortDesc,
 	Long:               rootCmdLongDesc,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		checkCommandAllowedInEnv(cmd, args)
+		if outputFilePath != "" {
+			if err := utils.RedirectOutputToFile(outputFilePath); err != nil {
+				utils.HandleErrorAndExit("Error creating the output file "+outputFilePath, err)
+			}
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if isK8sEnabled() {
 			ExecuteKubernetes(args...)
@@ -78,12 +93,51 @@ var RootCmd = &cobra.Command{
 	},
 }
 
+// checkCommandAllowedInEnv stops the command if any of the environments it runs against does not allow it
+func checkCommandAllowedInEnv(cmd *cobra.Command, args []string) {
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), RootCmd.Name()+" ")
+	for _, env := range getCommandEnvironments(cmd, args) {
+		err := utils.CheckCommandAllowedInEnv(env, commandPath, utils.MainConfigFilePath)
+		if err != nil {
+			utils.HandleErrorAndExit("Error running the command", err)
+		}
+	}
+}
+
+// getCommandEnvironments returns the environments a command runs against. The environments are given with the
+// --environment (-e) flag, the deprecated --env flag, or as the [environment] argument of the commands such as
+// "login [environment]" and "remove env [environment]".
+func getCommandEnvironments(cmd *cobra.Command, args []string) []string {
+	var envs []string
+	for _, flagName := range []string{"environment", "env"} {
+		envFlag := cmd.Flags().Lookup(flagName)
+		if envFlag == nil {
+			continue
+		}
+		// Flags such as --env accept several environments
+		if sliceValue, ok := envFlag.Value.(interface{ GetSlice() []string }); ok {
+			envs = append(envs, sliceValue.GetSlice()...)
+		} else if envFlag.Value.String() != "" {
+			envs = append(envs, envFlag.Value.String())
+		}
+	}
+	useArgs := strings.Fields(strings.TrimPrefix(cmd.Use, cmd.Name()))
+	if len(args) > 0 && len(useArgs) > 0 && useArgs[0] == "[environment]" {
+		envs = append(envs, args[0])
+	}
+	return envs
+}
+
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	ExpandAlias()
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(-1)
+		utils.Exit(-1)
+	}
+	if err := utils.CommitOutputFile(); err != nil {
+		utils.HandleErrorAndExit("Error writing the output file "+outputFilePath, err)
 	}
 }
 
@@ -97,6 +151,10 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 	RootCmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 		"Allow connections to SSL endpoints without certs")
+	RootCmd.PersistentFlags().StringVar(&outputFilePath, "output-file", "",
+		"Write the output of the command to the given file. The file is replaced only if the command succeeds")
+	RootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false,
+		"Do not use or update the cache of the responses of the GET requests")
 	//RootCmd.PersistentFlags().StringP("author", "a", "", "WSO2")
 
 	//viper.BindPFlag("author", RootCmd.PersistentFlags().Lookup("author"))
@@ -122,6 +180,12 @@ func init() {
 
 // createConfigFiles() creates the ConfigDir and necessary ConfigFiles inside the user's $HOME directory
 func createConfigFiles() {
+	if !utils.IsFileExist(utils.MainConfigFilePath) && utils.HasEnvironmentsInEnvVars() {
+		// the environments are defined using the APICTL_ENV_* environment variables, so that the config files are
+		// not written (eg: when running in a container with a read-only file system)
+		return
+	}
+
 	err := utils.CreateDirIfNotExist(utils.ConfigDirPath)
 	if err != nil {
 		utils.HandleErrorAndExit("Error creating config directory: "+utils.ConfigDirPath, err)
@@ -182,6 +246,9 @@ func initConfig() {
 	if insecure {
 		utils.Insecure = true
 	}
+	if noCache {
+		utils.HttpCacheDisabled = true
+	}
 
 	/*
 		if cfgFile != "" { // enable ability to specify config file via flag
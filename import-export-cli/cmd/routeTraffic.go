@@ -0,0 +1,106 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var routeTrafficAPIName string
+var routeTrafficFromVersion string
+var routeTrafficToVersion string
+var routeTrafficAPIProvider string
+var routeTrafficPercent int
+var routeTrafficCmdEnvironment string
+
+// RouteTrafficCmd related info
+const RouteTrafficCmdLiteral = "route-traffic"
+const routeTrafficCmdShortDesc = "Route the traffic of an API from one version to another"
+
+const routeTrafficCmdLongDesc = `Route the traffic of an API from one version to another in the environment specified by the flag --environment, -e, to roll out a new version of the API.
+The traffic sent to the default version of the API (the context of the API without the version) is routed by switching the default version of the API. --percent 100 routes the traffic to the version given by --to and --percent 0 routes the traffic back to the version given by --from. Splitting the traffic between the versions by other percentages is not supported by the API Manager gateway`
+
+const routeTrafficCmdExamples = utils.ProjectName + ` ` + RouteTrafficCmdLiteral + ` -n PizzaAPI --from 1.0.0 --to 2.0.0 --percent 100 -e dev
+` + utils.ProjectName + ` ` + RouteTrafficCmdLiteral + ` -n PizzaAPI --from 1.0.0 --to 2.0.0 --percent 0 -r admin -e dev
+NOTE: All the 5 flags (--name (-n), --from, --to, --percent and --environment (-e)) are mandatory.`
+
+// RouteTrafficCmd represents the route-traffic command
+var RouteTrafficCmd = &cobra.Command{
+	Use:     RouteTrafficCmdLiteral,
+	Short:   routeTrafficCmdShortDesc,
+	Long:    routeTrafficCmdLongDesc,
+	Example: routeTrafficCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RouteTrafficCmdLiteral + " called")
+		if routeTrafficPercent < 0 || routeTrafficPercent > 100 {
+			utils.HandleErrorAndExit("Invalid value for --percent",
+				errors.New("the percentage should be between 0 and 100"))
+		}
+		if routeTrafficFromVersion == routeTrafficToVersion {
+			utils.HandleErrorAndExit("Invalid versions", errors.New("--from and --to should be different versions"))
+		}
+		cred, err := GetCredentials(routeTrafficCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeRouteTrafficCmd(cred)
+	},
+}
+
+func executeRouteTrafficCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, routeTrafficCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+RouteTrafficCmdLiteral+"'", err)
+	}
+	defaultVersion, err := impl.RouteTrafficBetweenAPIVersions(accessToken, routeTrafficCmdEnvironment,
+		routeTrafficAPIName, routeTrafficFromVersion, routeTrafficToVersion, routeTrafficAPIProvider,
+		routeTrafficPercent)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while routing the traffic of the API", err)
+	}
+	fmt.Println("Traffic of the API " + routeTrafficAPIName + " is successfully routed to the version " +
+		defaultVersion)
+}
+
+func init() {
+	RootCmd.AddCommand(RouteTrafficCmd)
+	RouteTrafficCmd.Flags().StringVarP(&routeTrafficAPIName, "name", "n", "",
+		"Name of the API to route the traffic")
+	RouteTrafficCmd.Flags().StringVarP(&routeTrafficFromVersion, "from", "", "",
+		"Version of the API the traffic is routed from")
+	RouteTrafficCmd.Flags().StringVarP(&routeTrafficToVersion, "to", "", "",
+		"Version of the API the traffic is routed to")
+	RouteTrafficCmd.Flags().IntVarP(&routeTrafficPercent, "percent", "", 0,
+		"Percentage of the traffic routed to the version given by --to (0 or 100)")
+	RouteTrafficCmd.Flags().StringVarP(&routeTrafficAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	RouteTrafficCmd.Flags().StringVarP(&routeTrafficCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = RouteTrafficCmd.MarkFlagRequired("name")
+	_ = RouteTrafficCmd.MarkFlagRequired("from")
+	_ = RouteTrafficCmd.MarkFlagRequired("to")
+	_ = RouteTrafficCmd.MarkFlagRequired("percent")
+	_ = RouteTrafficCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,136 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var seedCmdEnvironment string
+var seedAPICount int
+var seedAppCount int
+var seedSubscribe bool
+var seedPrefix string
+var seedTemplatePath string
+var seedCleanup bool
+
+// SeedCmd related info
+const SeedCmdLiteral = "seed"
+const seedCmdShortDesc = "Seed an environment with sample APIs, applications and subscriptions"
+
+const seedCmdLongDesc = `Create sample APIs, applications and subscriptions in the environment specified by the flag --environment, -e, for demos and load testing.
+The APIs are created, deployed and published, and every application is subscribed to every API if --subscribe is given. The APIs and the applications can be customized with a template file given by --template.
+The sample resources are named with the prefix given by --prefix, and --cleanup removes the sample resources with that prefix from the environment`
+
+const seedCmdExamples = utils.ProjectName + ` ` + SeedCmdLiteral + ` -e dev --apis 20 --apps 5 --subscribe
+` + utils.ProjectName + ` ` + SeedCmdLiteral + ` -e dev --apis 10 --template ./seed-template.yaml --prefix demo
+` + utils.ProjectName + ` ` + SeedCmdLiteral + ` -e dev --cleanup
+` + utils.ProjectName + ` ` + SeedCmdLiteral + ` -e dev --cleanup --prefix demo
+NOTE: The flag (--environment (-e)) is mandatory.`
+
+// SeedCmd represents the seed command
+var SeedCmd = &cobra.Command{
+	Use:     SeedCmdLiteral,
+	Short:   seedCmdShortDesc,
+	Long:    seedCmdLongDesc,
+	Example: seedCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SeedCmdLiteral + " called")
+		if seedPrefix == "" {
+			utils.HandleErrorAndExit("Invalid value for --prefix", errors.New("the prefix cannot be empty"))
+		}
+		if !seedCleanup {
+			if seedAPICount < 0 || seedAppCount < 0 {
+				utils.HandleErrorAndExit("Invalid number of resources",
+					errors.New("--apis and --apps cannot be negative"))
+			}
+			if seedAPICount == 0 && seedAppCount == 0 {
+				utils.HandleErrorAndExit("Nothing to seed", errors.New("either --apis or --apps should be given"))
+			}
+			if seedSubscribe && (seedAPICount == 0 || seedAppCount == 0) {
+				utils.HandleErrorAndExit("Invalid flags", errors.New("--subscribe requires both --apis and --apps"))
+			}
+		}
+		cred, err := GetCredentials(seedCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSeedCmd(cred)
+	},
+}
+
+func executeSeedCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, seedCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SeedCmdLiteral+"'", err)
+	}
+	if seedCleanup {
+		result, err := impl.CleanupSeededEnv(accessToken, seedCmdEnvironment, seedPrefix)
+		printSeedResult("Removed", result)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while removing the sample resources", err)
+		}
+		return
+	}
+	template, err := impl.LoadSeedTemplate(seedTemplatePath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while loading the seed template", err)
+	}
+	result, err := impl.SeedEnv(accessToken, seedCmdEnvironment, seedPrefix, seedAPICount, seedAppCount,
+		seedSubscribe, template)
+	printSeedResult("Created", result)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while seeding the environment. Run '"+utils.ProjectName+" "+
+			SeedCmdLiteral+" --cleanup' to remove the sample resources created so far", err)
+	}
+}
+
+func printSeedResult(action string, result *impl.SeedResult) {
+	if result == nil {
+		return
+	}
+	fmt.Printf("%s %d API(s), %d application(s)", action, len(result.APIs), len(result.Applications))
+	if result.Subscriptions > 0 {
+		fmt.Printf(" and %d subscription(s)", result.Subscriptions)
+	}
+	fmt.Println(" in the environment " + seedCmdEnvironment)
+}
+
+func init() {
+	RootCmd.AddCommand(SeedCmd)
+	SeedCmd.Flags().StringVarP(&seedCmdEnvironment, "environment", "e",
+		"", "Environment to seed")
+	SeedCmd.Flags().IntVarP(&seedAPICount, "apis", "", 0, "Number of sample APIs to create")
+	SeedCmd.Flags().IntVarP(&seedAppCount, "apps", "", 0, "Number of sample applications to create")
+	SeedCmd.Flags().BoolVarP(&seedSubscribe, "subscribe", "", false,
+		"Subscribe each sample application to each sample API")
+	SeedCmd.Flags().StringVarP(&seedPrefix, "prefix", "", "apictl-seed",
+		"Prefix of the names of the sample resources")
+	SeedCmd.Flags().StringVarP(&seedTemplatePath, "template", "", "",
+		"Path to the template file of the sample APIs and applications")
+	SeedCmd.Flags().BoolVarP(&seedCleanup, "cleanup", "", false,
+		"Remove the sample resources with the prefix from the environment")
+	_ = SeedCmd.MarkFlagRequired("environment")
+}
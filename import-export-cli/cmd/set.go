@@ -78,6 +78,12 @@ var SetCmd = &cobra.Command{
 }
 
 func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
+	unlock, err := utils.LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		utils.HandleErrorAndExit("Unable to lock "+mainConfigFilePath, err)
+	}
+	defer unlock()
+
 	// read the existing config vars
 	configVars := utils.GetMainConfigFromFile(mainConfigFilePath)
 	//Change Http Request timeout
@@ -152,7 +158,7 @@ func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
 		fmt.Println("VCS deployment repo path is set to : " + flagVCSDeploymentRepoPath)
 	}
 
-	utils.WriteConfigFile(configVars, mainConfigFilePath)
+	utils.WriteLockedConfigFile(configVars, mainConfigFilePath)
 }
 
 // init using Cobra
@@ -0,0 +1,111 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setAPIDeprecationAPIName string
+var setAPIDeprecationAPIVersion string
+var setAPIDeprecationAPIProvider string
+var setAPIDeprecationSunsetDate string
+var setAPIDeprecationSuccessorLink string
+var setAPIDeprecationAddHeaderPolicy bool
+var setAPIDeprecationCmdEnvironment string
+
+// SetAPIDeprecationCmd related info
+const SetAPIDeprecationCmdLiteral = "api-deprecation"
+const setAPIDeprecationCmdShortDesc = "Set the deprecation metadata of an API"
+
+const setAPIDeprecationCmdLongDesc = `Set the sunset date and the link to the successor of an API in the environment specified by the flag --environment, -e.
+The metadata is stored in the additional properties deprecationDate, sunsetDate and successorLink of the API. The deprecation date is set to the current time the first time the metadata is set.
+If the flag --add-header-policy is given, policies adding the Deprecation, Sunset and Link headers to the responses are attached to each operation of the API.
+The lifecycle state of the API is not changed. A new revision should be deployed for the attached policies to take effect.`
+
+var setAPIDeprecationCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIDeprecationCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev --sunset-date 2025-12-31
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIDeprecationCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -r admin -e production --sunset-date 2025-12-31T00:00:00Z --successor https://api.example.com/petstore/2.0.0
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIDeprecationCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e production --sunset-date 2025-12-31 --successor https://api.example.com/petstore/2.0.0 --add-header-policy
+NOTE: All the 4 flags (--name (-n), --version (-v), --environment (-e) and --sunset-date) are mandatory.`
+
+// setAPIDeprecationCmd represents the set api-deprecation command
+var setAPIDeprecationCmd = &cobra.Command{
+	Use:     SetAPIDeprecationCmdLiteral,
+	Short:   setAPIDeprecationCmdShortDesc,
+	Long:    setAPIDeprecationCmdLongDesc,
+	Example: setAPIDeprecationCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetAPIDeprecationCmdLiteral + " called")
+		cred, err := GetCredentials(setAPIDeprecationCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetAPIDeprecationCmd(cred)
+	},
+}
+
+func executeSetAPIDeprecationCmd(credential credentials.Credential) {
+	sunsetDate, err := impl.ParseSunsetDate(setAPIDeprecationSunsetDate)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the deprecation metadata of the API", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setAPIDeprecationCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetAPIDeprecationCmdLiteral+"'", err)
+	}
+	deprecation, err := impl.SetAPIDeprecationFromEnv(accessToken, setAPIDeprecationCmdEnvironment,
+		setAPIDeprecationAPIName, setAPIDeprecationAPIVersion, setAPIDeprecationAPIProvider, sunsetDate,
+		setAPIDeprecationSuccessorLink, setAPIDeprecationAddHeaderPolicy)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the deprecation metadata of the API", err)
+	}
+	fmt.Println("Deprecation metadata of the API " + setAPIDeprecationAPIName + " " + setAPIDeprecationAPIVersion +
+		" is set. Sunset date: " + deprecation.SunsetDate.UTC().Format(http.TimeFormat))
+	if setAPIDeprecationAddHeaderPolicy {
+		fmt.Println("Deploy a new revision of the API for the deprecation headers to be added to the responses")
+	}
+}
+
+func init() {
+	SetCmd.AddCommand(setAPIDeprecationCmd)
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationAPIName, "name", "n", "",
+		"Name of the API")
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationAPIVersion, "version", "v", "",
+		"Version of the API")
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationSunsetDate, "sunset-date", "", "",
+		"Date after which the API is expected to be unavailable (YYYY-MM-DD or RFC 3339 time)")
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationSuccessorLink, "successor", "", "",
+		"Link to the API replacing the API")
+	setAPIDeprecationCmd.Flags().BoolVarP(&setAPIDeprecationAddHeaderPolicy, "add-header-policy", "", false,
+		"Attach policies adding the Deprecation, Sunset and Link headers to the responses of the API")
+	setAPIDeprecationCmd.Flags().StringVarP(&setAPIDeprecationCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = setAPIDeprecationCmd.MarkFlagRequired("name")
+	_ = setAPIDeprecationCmd.MarkFlagRequired("version")
+	_ = setAPIDeprecationCmd.MarkFlagRequired("sunset-date")
+	_ = setAPIDeprecationCmd.MarkFlagRequired("environment")
+}
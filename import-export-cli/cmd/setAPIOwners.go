@@ -0,0 +1,106 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setAPIOwnersAPIName string
+var setAPIOwnersAPIVersion string
+var setAPIOwnersAPIProvider string
+var setAPIOwnersBusinessOwner string
+var setAPIOwnersTechnicalOwner string
+var setAPIOwnersCmdEnvironment string
+
+// SetAPIOwnersCmd related info
+const SetAPIOwnersCmdLiteral = "api-owners"
+const setAPIOwnersCmdShortDesc = "Set the business and technical owners of an API"
+
+const setAPIOwnersCmdLongDesc = `Set the business owner and the technical owner in the business information of an API in the environment specified by the flag --environment, -e.
+An owner can be given as an email (eg: jane@example.com), as a name (eg: "Jane Doe") or as a name and an email (eg: "Jane Doe <jane@example.com>").
+Only the details given are changed. The details of an owner that is not given are kept.`
+
+var setAPIOwnersCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIOwnersCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev --business-owner jane@example.com --technical-owner john@example.com
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIOwnersCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -r admin -e production --business-owner "Jane Doe <jane@example.com>"
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIOwnersCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e production --technical-owner "John Doe"
+NOTE: The 3 flags (--name (-n), --version (-v) and --environment (-e)) and at least one of the flags --business-owner and --technical-owner are mandatory.`
+
+// setAPIOwnersCmd represents the set api-owners command
+var setAPIOwnersCmd = &cobra.Command{
+	Use:     SetAPIOwnersCmdLiteral,
+	Short:   setAPIOwnersCmdShortDesc,
+	Long:    setAPIOwnersCmdLongDesc,
+	Example: setAPIOwnersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetAPIOwnersCmdLiteral + " called")
+		cred, err := GetCredentials(setAPIOwnersCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetAPIOwnersCmd(cred)
+	},
+}
+
+func executeSetAPIOwnersCmd(credential credentials.Credential) {
+	businessOwner, err := impl.ParseAPIOwner(setAPIOwnersBusinessOwner)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the owners of the API", err)
+	}
+	technicalOwner, err := impl.ParseAPIOwner(setAPIOwnersTechnicalOwner)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the owners of the API", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setAPIOwnersCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetAPIOwnersCmdLiteral+"'", err)
+	}
+	businessInformation, err := impl.SetAPIOwnersFromEnv(accessToken, setAPIOwnersCmdEnvironment,
+		setAPIOwnersAPIName, setAPIOwnersAPIVersion, setAPIOwnersAPIProvider, businessOwner, technicalOwner)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the owners of the API", err)
+	}
+	fmt.Println("Owners of the API " + setAPIOwnersAPIName + " " + setAPIOwnersAPIVersion + " are set. " +
+		"Business owner: " + businessInformation.BusinessOwner.String() + ", Technical owner: " +
+		businessInformation.TechnicalOwner.String())
+}
+
+func init() {
+	SetCmd.AddCommand(setAPIOwnersCmd)
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersAPIName, "name", "n", "",
+		"Name of the API")
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersAPIVersion, "version", "v", "",
+		"Version of the API")
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersBusinessOwner, "business-owner", "", "",
+		"Business owner of the API (email, name or \"Name <email>\")")
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersTechnicalOwner, "technical-owner", "", "",
+		"Technical owner of the API (email, name or \"Name <email>\")")
+	setAPIOwnersCmd.Flags().StringVarP(&setAPIOwnersCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = setAPIOwnersCmd.MarkFlagRequired("name")
+	_ = setAPIOwnersCmd.MarkFlagRequired("version")
+	_ = setAPIOwnersCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setAPIThumbnailAPIName string
+var setAPIThumbnailAPIVersion string
+var setAPIThumbnailAPIProvider string
+var setAPIThumbnailCmdEnvironment string
+var setAPIThumbnailFile string
+
+// SetAPIThumbnailCmd related info
+const SetAPIThumbnailCmdLiteral = "api-thumbnail"
+const setAPIThumbnailCmdShortDesc = "Set the thumbnail of an API"
+
+const setAPIThumbnailCmdLongDesc = `Set the thumbnail of an API in the environment specified by the flag --environment, -e, without re-importing the API.
+The thumbnail can be a jpg, jpeg, png, gif or svg image. To set the thumbnail when importing an API, add the image to the Image directory of the API project`
+
+var setAPIThumbnailCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIThumbnailCmdLiteral + ` -n PizzaAPI -v 1.0.0 --file logo.png -e dev
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetAPIThumbnailCmdLiteral + ` -n PizzaAPI -v 1.0.0 -r admin --file ./images/pizza.jpeg -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --file and --environment (-e)) are mandatory.`
+
+// setAPIThumbnailCmd represents the set api-thumbnail command
+var setAPIThumbnailCmd = &cobra.Command{
+	Use:     SetAPIThumbnailCmdLiteral,
+	Short:   setAPIThumbnailCmdShortDesc,
+	Long:    setAPIThumbnailCmdLongDesc,
+	Example: setAPIThumbnailCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetAPIThumbnailCmdLiteral + " called")
+		cred, err := GetCredentials(setAPIThumbnailCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetAPIThumbnailCmd(cred)
+	},
+}
+
+func executeSetAPIThumbnailCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setAPIThumbnailCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetAPIThumbnailCmdLiteral+"'", err)
+	}
+	err = impl.SetAPIThumbnailInEnv(accessToken, setAPIThumbnailCmdEnvironment, setAPIThumbnailAPIName,
+		setAPIThumbnailAPIVersion, setAPIThumbnailAPIProvider, setAPIThumbnailFile)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the thumbnail of the API", err)
+	}
+	fmt.Println("Thumbnail is successfully set to the API " + setAPIThumbnailAPIName)
+}
+
+func init() {
+	SetCmd.AddCommand(setAPIThumbnailCmd)
+	setAPIThumbnailCmd.Flags().StringVarP(&setAPIThumbnailAPIName, "name", "n", "",
+		"Name of the API to set the thumbnail")
+	setAPIThumbnailCmd.Flags().StringVarP(&setAPIThumbnailAPIVersion, "version", "v", "",
+		"Version of the API to set the thumbnail")
+	setAPIThumbnailCmd.Flags().StringVarP(&setAPIThumbnailAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setAPIThumbnailCmd.Flags().StringVarP(&setAPIThumbnailFile, "file", "", "",
+		"Path to the image file of the thumbnail")
+	setAPIThumbnailCmd.Flags().StringVarP(&setAPIThumbnailCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = setAPIThumbnailCmd.MarkFlagRequired("name")
+	_ = setAPIThumbnailCmd.MarkFlagRequired("version")
+	_ = setAPIThumbnailCmd.MarkFlagRequired("file")
+	_ = setAPIThumbnailCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setClaimMappingKeyManager string
+var setClaimMappingRemoteClaim string
+var setClaimMappingLocalClaim string
+var setClaimMappingCmdEnvironment string
+
+// SetClaimMappingCmd related info
+const SetClaimMappingCmdLiteral = "claim-mapping"
+const setClaimMappingCmdShortDesc = "Set the local claim a claim of a key manager is mapped to"
+
+const setClaimMappingCmdLongDesc = `Map a claim of the tokens issued by a key manager to a local claim in the environment specified by the flag --environment, -e.
+The mapping of the remote claim is replaced if it is already mapped, and is removed when the flag --local-claim is empty. The other configurations of the key manager are not changed.`
+
+var setClaimMappingCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetClaimMappingCmdLiteral + ` --key-manager Okta -e dev --remote-claim email --local-claim http://wso2.org/claims/emailaddress
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetClaimMappingCmdLiteral + ` --key-manager Okta -e dev --remote-claim groups --local-claim ""
+NOTE: All the 4 flags (--key-manager, --environment (-e), --remote-claim and --local-claim) are mandatory.`
+
+// setClaimMappingCmd represents the set claim-mapping command
+var setClaimMappingCmd = &cobra.Command{
+	Use:     SetClaimMappingCmdLiteral,
+	Short:   setClaimMappingCmdShortDesc,
+	Long:    setClaimMappingCmdLongDesc,
+	Example: setClaimMappingCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetClaimMappingCmdLiteral + " called")
+		cred, err := GetCredentials(setClaimMappingCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetClaimMappingCmd(cred)
+	},
+}
+
+func executeSetClaimMappingCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setClaimMappingCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetClaimMappingCmdLiteral+"'", err)
+	}
+	err = impl.SetClaimMappingFromEnv(accessToken, setClaimMappingCmdEnvironment, setClaimMappingKeyManager,
+		setClaimMappingRemoteClaim, setClaimMappingLocalClaim)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the claim mapping of the key manager", err)
+	}
+	if setClaimMappingLocalClaim == "" {
+		fmt.Println("Mapping of the claim " + setClaimMappingRemoteClaim + " of the key manager " +
+			setClaimMappingKeyManager + " is removed")
+	} else {
+		fmt.Println("Claim " + setClaimMappingRemoteClaim + " of the key manager " + setClaimMappingKeyManager +
+			" is mapped to " + setClaimMappingLocalClaim)
+	}
+}
+
+func init() {
+	SetCmd.AddCommand(setClaimMappingCmd)
+	setClaimMappingCmd.Flags().StringVarP(&setClaimMappingKeyManager, "key-manager", "", "",
+		"Name of the key manager")
+	setClaimMappingCmd.Flags().StringVarP(&setClaimMappingRemoteClaim, "remote-claim", "", "",
+		"Claim of the tokens issued by the key manager")
+	setClaimMappingCmd.Flags().StringVarP(&setClaimMappingLocalClaim, "local-claim", "", "",
+		"Local claim to map the remote claim to. The mapping is removed when empty")
+	setClaimMappingCmd.Flags().StringVarP(&setClaimMappingCmdEnvironment, "environment", "e",
+		"", "Environment of the key manager")
+	_ = setClaimMappingCmd.MarkFlagRequired("key-manager")
+	_ = setClaimMappingCmd.MarkFlagRequired("remote-claim")
+	_ = setClaimMappingCmd.MarkFlagRequired("local-claim")
+	_ = setClaimMappingCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,89 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setDefaultVersionAPIName string
+var setDefaultVersionAPIVersion string
+var setDefaultVersionAPIProvider string
+var setDefaultVersionCmdEnvironment string
+
+// SetDefaultVersionCmd related info
+const SetDefaultVersionCmdLiteral = "default-version"
+const setDefaultVersionCmdShortDesc = "Set the default version of an API"
+
+const setDefaultVersionCmdLongDesc = `Mark a version of an API as the default version in the environment specified by the flag --environment, -e, without re-importing the API.
+The previous default version of the API is unmarked by API Manager`
+
+var setDefaultVersionCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetDefaultVersionCmdLiteral + ` -n PizzaAPI -v 2.0.0 -e dev
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetDefaultVersionCmdLiteral + ` -n PizzaAPI -v 2.0.0 -r admin -e production
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// setDefaultVersionCmd represents the set default-version command
+var setDefaultVersionCmd = &cobra.Command{
+	Use:     SetDefaultVersionCmdLiteral,
+	Short:   setDefaultVersionCmdShortDesc,
+	Long:    setDefaultVersionCmdLongDesc,
+	Example: setDefaultVersionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetDefaultVersionCmdLiteral + " called")
+		cred, err := GetCredentials(setDefaultVersionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetDefaultVersionCmd(cred)
+	},
+}
+
+func executeSetDefaultVersionCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setDefaultVersionCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetDefaultVersionCmdLiteral+"'", err)
+	}
+	err = impl.SetDefaultAPIVersion(accessToken, setDefaultVersionCmdEnvironment, setDefaultVersionAPIName,
+		setDefaultVersionAPIVersion, setDefaultVersionAPIProvider)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the default version of the API", err)
+	}
+	fmt.Println("Version " + setDefaultVersionAPIVersion + " is set as the default version of the API " +
+		setDefaultVersionAPIName)
+}
+
+func init() {
+	SetCmd.AddCommand(setDefaultVersionCmd)
+	setDefaultVersionCmd.Flags().StringVarP(&setDefaultVersionAPIName, "name", "n", "",
+		"Name of the API")
+	setDefaultVersionCmd.Flags().StringVarP(&setDefaultVersionAPIVersion, "version", "v", "",
+		"Version of the API to set as the default version")
+	setDefaultVersionCmd.Flags().StringVarP(&setDefaultVersionAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setDefaultVersionCmd.Flags().StringVarP(&setDefaultVersionCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = setDefaultVersionCmd.MarkFlagRequired("name")
+	_ = setDefaultVersionCmd.MarkFlagRequired("version")
+	_ = setDefaultVersionCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,105 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setMonetizationAPIName string
+var setMonetizationAPIVersion string
+var setMonetizationAPIProvider string
+var setMonetizationCmdEnvironment string
+var setMonetizationEnable bool
+var setMonetizationProperties []string
+
+// SetMonetizationCmd related info
+const SetMonetizationCmdLiteral = "monetization"
+const setMonetizationCmdShortDesc = "Enable or disable the monetization of an API"
+
+const setMonetizationCmdLongDesc = `Enable or disable the monetization of an API and set the monetization properties of the API in the environment specified by the flag --environment, -e.
+The price plans of the API are taken from the monetization details of the Subscription Policies of the API`
+
+var setMonetizationCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetMonetizationCmdLiteral + ` -n PizzaAPI -v 1.0.0 -e dev --enable --property ConnectedAccountKey=acct_1Hx2
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetMonetizationCmdLiteral + ` -n PizzaAPI -v 1.0.0 -r admin -e dev --enable=false
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// setMonetizationCmd represents the set monetization command
+var setMonetizationCmd = &cobra.Command{
+	Use:     SetMonetizationCmdLiteral,
+	Short:   setMonetizationCmdShortDesc,
+	Long:    setMonetizationCmdLongDesc,
+	Example: setMonetizationCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetMonetizationCmdLiteral + " called")
+		properties, err := utils.ParseKeyValuePairs(setMonetizationProperties)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid value for --property", err)
+		}
+		cred, err := GetCredentials(setMonetizationCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetMonetizationCmd(cred, properties)
+	},
+}
+
+func executeSetMonetizationCmd(credential credentials.Credential, properties map[string]string) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setMonetizationCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetMonetizationCmdLiteral+"'", err)
+	}
+	err = impl.SetAPIMonetizationInEnv(accessToken, setMonetizationCmdEnvironment, setMonetizationAPIName,
+		setMonetizationAPIVersion, setMonetizationAPIProvider, &utils.APIMonetizationInfo{
+			Enabled:    setMonetizationEnable,
+			Properties: properties,
+		})
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the monetization of the API", err)
+	}
+	if setMonetizationEnable {
+		fmt.Println("Monetization is successfully enabled for the API " + setMonetizationAPIName)
+	} else {
+		fmt.Println("Monetization is successfully disabled for the API " + setMonetizationAPIName)
+	}
+}
+
+func init() {
+	SetCmd.AddCommand(setMonetizationCmd)
+	setMonetizationCmd.Flags().StringVarP(&setMonetizationAPIName, "name", "n", "",
+		"Name of the API to set the monetization")
+	setMonetizationCmd.Flags().StringVarP(&setMonetizationAPIVersion, "version", "v", "",
+		"Version of the API to set the monetization")
+	setMonetizationCmd.Flags().StringVarP(&setMonetizationAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setMonetizationCmd.Flags().StringVarP(&setMonetizationCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	setMonetizationCmd.Flags().BoolVarP(&setMonetizationEnable, "enable", "", true,
+		"Enable or disable the monetization of the API")
+	setMonetizationCmd.Flags().StringArrayVarP(&setMonetizationProperties, "property", "", []string{},
+		"Monetization property of the API in key=value format. Can be given multiple times")
+	_ = setMonetizationCmd.MarkFlagRequired("name")
+	_ = setMonetizationCmd.MarkFlagRequired("version")
+	_ = setMonetizationCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,99 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var setScopeBindingAPIName string
+var setScopeBindingAPIVersion string
+var setScopeBindingAPIProvider string
+var setScopeBindingScope string
+var setScopeBindingRoles []string
+var setScopeBindingCmdEnvironment string
+
+// SetScopeBindingCmd related info
+const SetScopeBindingCmdLiteral = "scope-binding"
+const setScopeBindingCmdShortDesc = "Set the roles bound to a scope of an API"
+
+const setScopeBindingCmdLongDesc = `Replace the roles bound to a scope of an API in the environment specified by the flag --environment, -e, without re-importing the API.
+The scope is not restricted to any role when the flag --roles is empty. The roles of shared scopes cannot be set through an API.`
+
+var setScopeBindingCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetScopeBindingCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev --scope read:pets --roles admin,dev
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetScopeBindingCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -r admin -e production --scope write:pets --roles admin
+` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetScopeBindingCmdLiteral + ` -n PetstoreAPI -v 1.0.0 -e dev --scope read:pets --roles ""
+NOTE: All the 5 flags (--name (-n), --version (-v), --environment (-e), --scope and --roles) are mandatory.`
+
+// setScopeBindingCmd represents the set scope-binding command
+var setScopeBindingCmd = &cobra.Command{
+	Use:     SetScopeBindingCmdLiteral,
+	Short:   setScopeBindingCmdShortDesc,
+	Long:    setScopeBindingCmdLongDesc,
+	Example: setScopeBindingCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetScopeBindingCmdLiteral + " called")
+		cred, err := GetCredentials(setScopeBindingCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeSetScopeBindingCmd(cred)
+	},
+}
+
+func executeSetScopeBindingCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, setScopeBindingCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error calling '"+SetScopeBindingCmdLiteral+"'", err)
+	}
+	err = impl.SetScopeBindingFromEnv(accessToken, setScopeBindingCmdEnvironment, setScopeBindingAPIName,
+		setScopeBindingAPIVersion, setScopeBindingAPIProvider, setScopeBindingScope, setScopeBindingRoles)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while setting the roles of the scope", err)
+	}
+	fmt.Println("Roles of the scope " + setScopeBindingScope + " of the API " + setScopeBindingAPIName + " " +
+		setScopeBindingAPIVersion + " are set to [" + strings.Join(setScopeBindingRoles, ",") + "]")
+}
+
+func init() {
+	SetCmd.AddCommand(setScopeBindingCmd)
+	setScopeBindingCmd.Flags().StringVarP(&setScopeBindingAPIName, "name", "n", "",
+		"Name of the API")
+	setScopeBindingCmd.Flags().StringVarP(&setScopeBindingAPIVersion, "version", "v", "",
+		"Version of the API")
+	setScopeBindingCmd.Flags().StringVarP(&setScopeBindingAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	setScopeBindingCmd.Flags().StringVarP(&setScopeBindingScope, "scope", "", "",
+		"Name of the scope")
+	setScopeBindingCmd.Flags().StringSliceVarP(&setScopeBindingRoles, "roles", "", []string{},
+		"Comma separated list of the roles to bind to the scope")
+	setScopeBindingCmd.Flags().StringVarP(&setScopeBindingCmdEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = setScopeBindingCmd.MarkFlagRequired("name")
+	_ = setScopeBindingCmd.MarkFlagRequired("version")
+	_ = setScopeBindingCmd.MarkFlagRequired("scope")
+	_ = setScopeBindingCmd.MarkFlagRequired("roles")
+	_ = setScopeBindingCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var testWSAPIName string
+var testWSAPIVersion string
+var testWSAPIProvider string
+var testWSCmdEnvironment string
+var testWSCmdGatewayEnv string
+var testWSCmdMessages []string
+var testWSCmdTimeout int
+
+// TestWS command related usage Info
+const TestWSCmdLiteral = "ws"
+const testWSCmdShortDesc = "Test a WebSocket API"
+
+const testWSCmdLongDesc = `Generate a token to invoke a WebSocket API by subscribing to a default application, open a WebSocket connection to the API through the gateway, send the messages given by flag (--message) and print the frames received from the API.
+Sent messages are prefixed with ">" and received frames are prefixed with "<". The connection is closed when no frame is received within the period given by flag (--timeout)`
+
+const testWSCmdExamples = utils.ProjectName + ` ` + TestCmdLiteral + ` ` + TestWSCmdLiteral + ` -n ChatAPI -v 1.0.0 -e dev --message '{"ping":1}'
+` + utils.ProjectName + ` ` + TestCmdLiteral + ` ` + TestWSCmdLiteral + ` -n ChatAPI -v 1.0.0 -r admin -e dev -g Default --message hello --message bye --timeout 5
+NOTE: All the 3 flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// testWSCmd represents the test ws command
+var testWSCmd = &cobra.Command{
+	Use:     TestWSCmdLiteral,
+	Short:   testWSCmdShortDesc,
+	Long:    testWSCmdLongDesc,
+	Example: testWSCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + TestCmdLiteral + " " + TestWSCmdLiteral + " called")
+		if testWSCmdTimeout <= 0 {
+			utils.HandleErrorAndExit("Invalid timeout", errors.New("timeout should be a positive number of seconds"))
+		}
+		cred, err := GetCredentials(testWSCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		cred.ClientId, cred.ClientSecret, err = impl.CallDCREndpoint(cred, testWSCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Internal error occurred", err)
+		}
+		err = impl.TestWebSocketAPI(cred, testWSCmdEnvironment, testWSAPIName, testWSAPIVersion, testWSAPIProvider,
+			testWSCmdGatewayEnv, testWSCmdMessages, time.Duration(testWSCmdTimeout)*time.Second)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while testing the WebSocket API", err)
+		}
+	},
+}
+
+func init() {
+	TestCmd.AddCommand(testWSCmd)
+	testWSCmd.Flags().StringVarP(&testWSAPIName, "name", "n", "", "Name of the WebSocket API to test")
+	testWSCmd.Flags().StringVarP(&testWSAPIVersion, "version", "v", "", "Version of the WebSocket API to test")
+	testWSCmd.Flags().StringVarP(&testWSAPIProvider, "provider", "r", "", "Provider of the WebSocket API")
+	testWSCmd.Flags().StringVarP(&testWSCmdEnvironment, "environment", "e", "", "Environment of the WebSocket API")
+	testWSCmd.Flags().StringVarP(&testWSCmdGatewayEnv, "gateway-env", "g", "", "Gateway environment to connect to. "+
+		"The first gateway environment of the API is used by default")
+	testWSCmd.Flags().StringArrayVarP(&testWSCmdMessages, "message", "m", []string{}, "Message to send to the API. "+
+		"Can be given multiple times to send multiple messages in order")
+	testWSCmd.Flags().IntVarP(&testWSCmdTimeout, "timeout", "", 10,
+		"Seconds to wait for frames from the API before closing the connection")
+	_ = testWSCmd.MarkFlagRequired("name")
+	_ = testWSCmd.MarkFlagRequired("version")
+	_ = testWSCmd.MarkFlagRequired("environment")
+}
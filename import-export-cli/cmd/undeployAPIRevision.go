@@ -0,0 +1,102 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var undeployAPIRevisionName string
+var undeployAPIRevisionVersion string
+var undeployAPIRevisionProvider string
+var undeployAPIRevisionNum string
+var undeployAPIRevisionGatewayEnvs []string
+var undeployAPIRevisionEnvironment string
+
+// UndeployAPIRevisionCmd command related usage info
+const UndeployAPIRevisionCmdLiteral = "api-revision"
+const undeployAPIRevisionCmdShortDesc = "Undeploy an API revision"
+
+const undeployAPIRevisionCmdLongDesc = `Undeploy a revision of an API from the gateway environments given by flag (--gateway-env, -g).
+The vhost to undeploy from can be given after the gateway environment (ex: us-region=us.api.example.com), so that an API deployed
+to multiple vhosts of a gateway environment is undeployed from only one of them.`
+
+const undeployAPIRevisionCmdExamples = utils.ProjectName + ` ` + UndeployCmdLiteral + ` ` + UndeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 2 -e dev
+` + utils.ProjectName + ` ` + UndeployCmdLiteral + ` ` + UndeployAPIRevisionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r alice --rev 3 -g us-region=us.api.example.com -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --rev, --environment (-e)) are mandatory.
+If the flag (--gateway-env (-g)) is not provided, the revision is undeployed from all the gateway environments it is deployed to.`
+
+// UndeployAPIRevisionCmd represents the undeploy api-revision command
+var UndeployAPIRevisionCmd = &cobra.Command{
+	Use: UndeployAPIRevisionCmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --provider <provider-of-the-api> " +
+		"--rev <revision-number-of-the-api> --gateway-env <gateway-environment>[=<vhost>] " +
+		"--environment <environment-of-the-api>)",
+	Short:   undeployAPIRevisionCmdShortDesc,
+	Long:    undeployAPIRevisionCmdLongDesc,
+	Example: undeployAPIRevisionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UndeployAPIRevisionCmdLiteral + " called")
+		deployments, err := impl.ParseRevisionDeployments(undeployAPIRevisionGatewayEnvs, true)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid gateway environments", err)
+		}
+		cred, err := GetCredentials(undeployAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, undeployAPIRevisionEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to undeploy the API revision", err)
+		}
+		err = impl.UndeployAPIRevisionFromGateways(accessToken, undeployAPIRevisionEnvironment,
+			undeployAPIRevisionName, undeployAPIRevisionVersion, undeployAPIRevisionProvider, undeployAPIRevisionNum,
+			deployments)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while undeploying the API revision", err)
+		}
+		fmt.Println("Revision " + undeployAPIRevisionNum + " of API " + undeployAPIRevisionName + "_" +
+			undeployAPIRevisionVersion + " successfully undeployed from the specified gateway environments")
+	},
+}
+
+// init using Cobra
+func init() {
+	UndeployCmd.AddCommand(UndeployAPIRevisionCmd)
+	UndeployAPIRevisionCmd.Flags().StringVarP(&undeployAPIRevisionName, "name", "n", "",
+		"Name of the API")
+	UndeployAPIRevisionCmd.Flags().StringVarP(&undeployAPIRevisionVersion, "version", "v", "",
+		"Version of the API")
+	UndeployAPIRevisionCmd.Flags().StringVarP(&undeployAPIRevisionProvider, "provider", "r", "",
+		"Provider of the API")
+	UndeployAPIRevisionCmd.Flags().StringVarP(&undeployAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to undeploy")
+	UndeployAPIRevisionCmd.Flags().StringSliceVarP(&undeployAPIRevisionGatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment to undeploy the revision from, optionally followed by the vhost (ex: us-region=us.api.example.com)")
+	UndeployAPIRevisionCmd.Flags().StringVarP(&undeployAPIRevisionEnvironment, "environment", "e",
+		"", "Environment of the API")
+	_ = UndeployAPIRevisionCmd.MarkFlagRequired("name")
+	_ = UndeployAPIRevisionCmd.MarkFlagRequired("version")
+	_ = UndeployAPIRevisionCmd.MarkFlagRequired("rev")
+	_ = UndeployAPIRevisionCmd.MarkFlagRequired("environment")
+}
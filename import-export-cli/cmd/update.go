@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Update command related usage Info
+const UpdateCmdLiteral = "update"
+const updateCmdShortDesc = "Update an Application in an environment"
+
+const updateCmdLongDesc = `Update an Application of the user in the environment specified by flag (--environment, -e)`
+
+const updateCmdExamples = utils.ProjectName + ` ` + UpdateCmdLiteral + ` ` + updateAppCmdLiteral + ` -n MyApp --tier Gold -e dev
+` + utils.ProjectName + ` ` + UpdateCmdLiteral + ` ` + updateAppCmdLiteral + ` -n MyApp --attributes team=payments --groups g1,g2 -e dev`
+
+// UpdateCmd represents the update command
+var UpdateCmd = &cobra.Command{
+	Use:     UpdateCmdLiteral,
+	Short:   updateCmdShortDesc,
+	Long:    updateCmdLongDesc,
+	Example: updateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UpdateCmdLiteral + " called")
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(UpdateCmd)
+}
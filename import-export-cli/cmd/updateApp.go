@@ -0,0 +1,108 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var updateAppEnvironment string
+var updateAppName string
+var updateAppTier string
+var updateAppDescription string
+var updateAppTokenType string
+var updateAppAttributes []string
+var updateAppGroups []string
+
+// UpdateApp command related usage info
+const updateAppCmdLiteral = "app"
+const updateAppCmdShortDesc = "Update an Application"
+const updateAppCmdLongDesc = "Update the throttling tier, description, token type, attributes or groups of an " +
+	"Application of the user in the environment specified by the flag --environment, -e. The fields which are not " +
+	"given are kept as they are, and the given attributes are added to the existing attributes of the Application"
+
+const updateAppCmdExamples = utils.ProjectName + ` ` + UpdateCmdLiteral + ` ` + updateAppCmdLiteral + ` -n MyApp --tier Gold -e dev
+` + utils.ProjectName + ` ` + UpdateCmdLiteral + ` ` + updateAppCmdLiteral + ` -n MyApp --attributes team=payments --groups g1,g2 -e dev
+` + utils.ProjectName + ` ` + UpdateCmdLiteral + ` ` + updateAppCmdLiteral + ` -n MyApp --groups "" -e prod
+NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.`
+
+// updateAppCmd represents the update app command
+var updateAppCmd = &cobra.Command{
+	Use:     updateAppCmdLiteral,
+	Short:   updateAppCmdShortDesc,
+	Long:    updateAppCmdLongDesc,
+	Example: updateAppCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UpdateCmdLiteral + " " + updateAppCmdLiteral + " called")
+		cred, err := GetCredentials(updateAppEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeUpdateAppCmd(cred, cmd.Flags().Changed("groups"))
+	},
+}
+
+func executeUpdateAppCmd(credential credentials.Credential, updateGroups bool) {
+	attributes, err := impl.ParseAppAttributes(updateAppAttributes)
+	if err != nil {
+		utils.HandleErrorAndExit("Error updating the Application", err)
+	}
+	update := &impl.ApplicationUpdate{
+		ThrottlingPolicy: updateAppTier,
+		Description:      updateAppDescription,
+		TokenType:        updateAppTokenType,
+		Attributes:       attributes,
+	}
+	// The groups are replaced only when given, where an empty value removes all the groups
+	if updateGroups {
+		update.Groups = updateAppGroups
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, updateAppEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens while updating the Application", err)
+	}
+	app, err := impl.UpdateApplication(accessToken, updateAppEnvironment, updateAppName, update)
+	if err != nil {
+		utils.HandleErrorAndExit("Error updating the Application "+updateAppName, err)
+	}
+	fmt.Println("Application " + app.Name + " updated successfully. Application ID: " + app.ApplicationID)
+}
+
+func init() {
+	UpdateCmd.AddCommand(updateAppCmd)
+	updateAppCmd.Flags().StringVarP(&updateAppName, "name", "n", "", "Name of the Application")
+	updateAppCmd.Flags().StringVarP(&updateAppTier, "tier", "", "",
+		"Throttling tier (business plan) of the Application")
+	updateAppCmd.Flags().StringVarP(&updateAppDescription, "description", "d", "", "Description of the Application")
+	updateAppCmd.Flags().StringVarP(&updateAppTokenType, "token-type", "", "",
+		"Token type of the Application (JWT or OAUTH)")
+	updateAppCmd.Flags().StringSliceVarP(&updateAppAttributes, "attributes", "", []string{},
+		"Attributes to add to the Application given as key=value")
+	updateAppCmd.Flags().StringSliceVarP(&updateAppGroups, "groups", "", []string{},
+		"Groups to share the Application with, replacing the existing groups")
+	updateAppCmd.Flags().StringVarP(&updateAppEnvironment, "environment", "e", "",
+		"Environment of the Application")
+	_ = updateAppCmd.MarkFlagRequired("name")
+	_ = updateAppCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,62 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ValidateSwagger command related usage Info
+const ValidateSwaggerCmdLiteral = "swagger"
+const validateSwaggerCmdShortDesc = "Validate an OpenAPI definition"
+
+const validateSwaggerCmdLongDesc = `Validate an OpenAPI 2 or OpenAPI 3 definition against the specification and the constraints of WSO2 API Manager, without requiring an API project or a connection to an environment.
+Apart from the structure of the definition, the x-wso2 extensions, the keywords and operations not supported by API Manager, and the context and version of the API are validated. The command fails if any errors are found, while warnings are only reported`
+
+const validateSwaggerCmdExamples = utils.ProjectName + ` ` + ValidateCmdLiteral + ` ` + ValidateSwaggerCmdLiteral + ` ./petstore.yaml
+` + utils.ProjectName + ` ` + ValidateCmdLiteral + ` ` + ValidateSwaggerCmdLiteral + ` ./PetstoreAPI/Definitions/swagger.yaml`
+
+// ValidateSwaggerCmd represents the validate swagger command
+var ValidateSwaggerCmd = &cobra.Command{
+	Use:     ValidateSwaggerCmdLiteral + " [definition path]",
+	Short:   validateSwaggerCmdShortDesc,
+	Long:    validateSwaggerCmdLongDesc,
+	Example: validateSwaggerCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ValidateSwaggerCmdLiteral + " called")
+		issues, err := impl.ValidateSwagger(args[0])
+		if err != nil {
+			utils.HandleErrorAndExit("Error while validating the OpenAPI definition", err)
+		}
+		if errorCount := impl.PrintSwaggerValidationIssues(issues); errorCount > 0 {
+			utils.HandleErrorAndExit("Invalid OpenAPI definition",
+				fmt.Errorf("%d error(s) found in %s", errorCount, args[0]))
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ValidateCmd.AddCommand(ValidateSwaggerCmd)
+}
@@ -20,7 +20,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -28,8 +27,9 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-var flagVCSDeployEnvName string    // name of the environment the project changes need to be deployed
-var flagVCSDeploySkipRollback bool // specifies whether rolling back on error needs to be avoided
+var flagVCSDeployEnvName string     // name of the environment the project changes need to be deployed
+var flagVCSDeploySkipRollback bool  // specifies whether rolling back on error needs to be avoided
+var flagVCSDeploySummaryFile string // path of the file to write the deployment summary
 
 // deploy command related usage Info
 const deployCmdLiteral = "deploy"
@@ -38,10 +38,15 @@ const deployCmdLongDesc = `Deploys projects to the specified environment specifi
 Only the changed projects compared to the revision at the last successful deployment will be deployed. 
 If any project(s) got failed during the deployment, by default, the operation will rollback the environment to the last successful state. 
 If this needs to be avoided, use --skip-rollback=true
+APIs are deployed before API Products, and API Products before Applications. A failed project does not stop the 
+deployment of the others, but the API Products and Applications that depend on a failed API or API Product are skipped. 
+Use --summary-file to write the result of each project as JSON for pipeline reporting.
+Each deployment is recorded in the audit trail, which can be listed using 'vcs history'.
 NOTE: --environment (-e) flag is mandatory`
 
 const deployCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true`
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --summary-file /tmp/deploy-summary.json`
 
 // deployCmd represents the deploy command
 var DeployCmd = &cobra.Command{
@@ -53,12 +58,12 @@ var DeployCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + deployCmdLiteral + " called")
 		if !utils.EnvExistsInMainConfigFile(flagVCSDeployEnvName, utils.MainConfigFilePath) {
 			fmt.Println(flagVCSDeployEnvName, "does not exists. Add it using add env")
-			os.Exit(1)
+			utils.Exit(1)
 		}
 		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 		if mainConfig.Config.VCSSourceRepoPath == "" {
 			fmt.Println("VCS source repo path cannot be empty. Set it using apictl set command.")
-			os.Exit(1)
+			utils.Exit(1)
 		}
 		credential, err := GetCredentials(flagVCSDeployEnvName)
 		if err != nil {
@@ -68,19 +73,35 @@ var DeployCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
 		}
-		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+		failedProjects, summary := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+		summary.Print()
+		if flagVCSDeploySummaryFile != "" {
+			if err := summary.WriteToFile(flagVCSDeploySummaryFile); err != nil {
+				utils.HandleErrorAndExit("Error while writing the deployment summary to "+flagVCSDeploySummaryFile, err)
+			}
+		}
 		if failedProjects != nil && len(failedProjects) > 0 && flagVCSDeploySkipRollback == false {
 			fmt.Println("\nRolling back to the last successful revision as there are failures..")
 			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName)
+			recordVCSDeployment(credential.Username, summary, err == nil)
 			if err != nil {
 				utils.HandleErrorAndExit("There are project deployment failures. Failed to rollback.", err)
 			} else {
 				utils.HandleErrorAndExit("There are project deployment failures. Rolled back to the last successful revision.", err)
 			}
 		}
+		recordVCSDeployment(credential.Username, summary, false)
 	},
 }
 
+// recordVCSDeployment adds the deployment to the audit trail. A failure to record the deployment does not fail the
+// deployment, as the projects are already deployed by then.
+func recordVCSDeployment(user string, summary *git.DeploymentSummary, rolledBack bool) {
+	if err := git.RecordDeployment(flagVCSDeployEnvName, user, summary, rolledBack); err != nil {
+		utils.HandleErrorAndContinue("Error while recording the deployment in the audit trail", err)
+	}
+}
+
 func init() {
 	VCSCmd.AddCommand(DeployCmd)
 
@@ -91,6 +112,8 @@ func init() {
 	DeployCmd.Flags().BoolVarP(&flagVCSDeploySkipRollback, "skipRollback", "", false,
 		"Specifies whether rolling back to the last successful revision during an error situation should be skipped")
 	DeployCmd.Flags().MarkDeprecated("skipRollback", "Use skip-rollback flag")
+	DeployCmd.Flags().StringVarP(&flagVCSDeploySummaryFile, "summary-file", "", "",
+		"Path of the file to write the result of each project as JSON")
 
 	_ = DeployCmd.MarkFlagRequired("environment")
 }
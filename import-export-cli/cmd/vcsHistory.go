@@ -0,0 +1,82 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSHistoryEnvName string // name of the environment to list the deployments
+var flagVCSHistoryFormat string  // format of the output to be printed
+
+// history command related usage Info
+const vcsHistoryCmdLiteral = "history"
+const vcsHistoryCmdShortDesc = "Lists the deployments recorded in the audit trail"
+const vcsHistoryCmdLongDesc = `Lists the deployments of the source repository to the environment specified by --environment(-e), 
+which are recorded in the audit trail by the deploy command. Each deployment shows the deployed commit, whether the 
+commit is signed, the user who deployed it and the result of each project.
+NOTE: --environment (-e) flag is mandatory`
+
+const vcsHistoryCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsHistoryCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsHistoryCmdLiteral + ` -e dev --format json`
+
+// VCSHistoryCmd represents the history command
+var VCSHistoryCmd = &cobra.Command{
+	Use:     vcsHistoryCmdLiteral,
+	Short:   vcsHistoryCmdShortDesc,
+	Long:    vcsHistoryCmdLongDesc,
+	Example: vcsHistoryCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsHistoryCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSHistoryEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSHistoryEnvName, "does not exists. Add it using add env")
+			utils.Exit(1)
+		}
+		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+		if mainConfig.Config.VCSSourceRepoPath == "" {
+			fmt.Println("VCS source repo path cannot be empty. Set it using apictl set command.")
+			utils.Exit(1)
+		}
+		history, intact, err := git.GetDeploymentHistory(flagVCSHistoryEnvName)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while reading the deployment history", err)
+		}
+		if !intact {
+			fmt.Fprintln(os.Stderr, "Warning: the audit trail "+git.VCSAuditFilePath+
+				" has been modified after the deployments were recorded")
+		}
+		git.PrintDeploymentHistory(history, flagVCSHistoryFormat)
+	},
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSHistoryCmd)
+
+	VCSHistoryCmd.Flags().StringVarP(&flagVCSHistoryEnvName, "environment", "e", "", "Name of the "+
+		"environment to list the deployments")
+	VCSHistoryCmd.Flags().StringVarP(&flagVCSHistoryFormat, "format", "", "", "Pretty-print the deployments "+
+		"using Go Templates. "+utils.FormatFlagDescription)
+
+	_ = VCSHistoryCmd.MarkFlagRequired("environment")
+}
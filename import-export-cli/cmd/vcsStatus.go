@@ -53,7 +53,7 @@ var VCSStatusCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + vcsStatusCmdLiteral + " called")
 		if !utils.EnvExistsInMainConfigFile(flagVCSStatusEnvName, utils.MainConfigFilePath) {
 			fmt.Println(flagVCSStatusEnvName, "does not exists. Add it using add env")
-			os.Exit(1)
+			utils.Exit(1)
 		}
 
 		_, totalProjectsToUpdate, updatedProjectsPerType := git.GetStatus(flagVCSStatusEnvName, git.FromRevTypeLastAttempted)
@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -57,6 +59,18 @@ type Credentials struct {
 type Environment struct {
 	APIM Credential   `json:"apim"`
 	MI   MiCredential `json:"mi"`
+	// APIMToken is the cached access token of apim
+	APIMToken *APIMToken `json:"apimToken,omitempty"`
+}
+
+// APIMToken for caching the access token of the cli between commands
+type APIMToken struct {
+	// AccessToken of apim
+	AccessToken string `json:"accessToken"`
+	// RefreshToken issued along with the access token
+	RefreshToken string `json:"refreshToken"`
+	// ExpiresAt is the unix time at which the access token expires
+	ExpiresAt int64 `json:"expiresAt"`
 }
 
 type MgAdapterEnv struct {
@@ -81,19 +95,71 @@ func GetDefaultCredentialStore() (Store, error) {
 	return GetCredentialStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
 }
 
-// GetOAuthAccessToken generates an accesstoken for CLI
+// GetOAuthAccessToken returns an accesstoken for CLI. The token cached in the store is reused until it expires and
+// is renewed using the refresh token or the stored credentials afterwards
 func GetOAuthAccessToken(credential Credential, env string) (string, error) {
+	if accessToken := utils.GetEnvVarCredentials(env).AccessToken; accessToken != "" {
+		utils.Logln(utils.LogPrefixInfo + "Using the access token of " + env + " set in the environment variables")
+		return accessToken, nil
+	}
+
+	tokenCacheMutex.Lock()
+	defer tokenCacheMutex.Unlock()
+
 	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
-	data, err := utils.GetOAuthTokens(credential.Username, credential.Password,
-		Base64Encode(credential.ClientId+":"+credential.ClientSecret),
-		tokenEndpoint)
+	// load the store for each call as another command could have renewed the token meanwhile
+	store, err := GetDefaultCredentialStore()
 	if err != nil {
 		return "", err
 	}
-	if accessToken, ok := data["access_token"]; ok {
-		return accessToken, nil
+	return getOAuthAccessToken(store, credential, env, tokenEndpoint, time.Now())
+}
+
+// GetEnvVarCredential returns the credentials of the environment set in the APICTL_ENV_<ENV>_* environment variables.
+// A client is registered for the username when the client id and secret are not set. The credentials are not
+// stored, so that nothing is written to the file system. An empty credential is returned along with an access
+// token, since the token is used as it is.
+func GetEnvVarCredential(env string, envVarCredentials utils.EnvVarCredentials) (Credential, error) {
+	if envVarCredentials.AccessToken != "" {
+		return Credential{}, nil
+	}
+	credential := Credential{envVarCredentials.Username, envVarCredentials.Password, envVarCredentials.ClientID,
+		envVarCredentials.ClientSecret}
+	if credential.ClientId == "" || credential.ClientSecret == "" {
+		registrationEndpoint := utils.GetRegistrationEndpointOfEnv(env, utils.MainConfigFilePath)
+		clientId, clientSecret, err := utils.GetClientIDSecret(credential.Username, credential.Password,
+			registrationEndpoint)
+		if err != nil {
+			return Credential{}, err
+		}
+		credential.ClientId, credential.ClientSecret = clientId, clientSecret
+	}
+	return credential, nil
+}
+
+// GetTenantCredential returns the credentials to run a command against the given tenant. The tenant qualified
+// username of the logged in super tenant user is used along with a client registered for that username
+func GetTenantCredential(credential Credential, env, tenantDomain string) (Credential, error) {
+	registrationEndpoint := utils.GetRegistrationEndpointOfEnv(env, utils.MainConfigFilePath)
+	return getTenantCredential(credential, env, tenantDomain, registrationEndpoint)
+}
+
+func getTenantCredential(credential Credential, env, tenantDomain, registrationEndpoint string) (Credential, error) {
+	if tenantDomain == "" || tenantDomain == utils.DefaultTenantDomain {
+		return credential, nil
+	}
+	username := strings.TrimSuffix(credential.Username, "@"+utils.DefaultTenantDomain)
+	if strings.Contains(username, "@") {
+		return Credential{}, fmt.Errorf("only super tenant users can run commands against another tenant, "+
+			"logged in to %s as %s", env, credential.Username)
+	}
+	username += "@" + tenantDomain
+	utils.Logln(utils.LogPrefixInfo + "Running the command as " + username)
+	clientId, clientSecret, err := utils.GetClientIDSecret(username, credential.Password, registrationEndpoint)
+	if err != nil {
+		return Credential{}, err
 	}
-	return "", errors.New("access_token not found")
+	return Credential{username, credential.Password, clientId, clientSecret}, nil
 }
 
 // GetBasicAuth returns basic auth username:password encoded in base64
@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTenantCredential(t *testing.T) {
+	var owner string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		registration := make(map[string]interface{})
+		if err := json.Unmarshal(body, &registration); err != nil {
+			t.Errorf("Unexpected registration request: %s\n", string(body))
+		}
+		owner, _ = registration["owner"].(string)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"clientId": "tenant-client-id", "clientSecret": "tenant-client-secret"}`))
+	}))
+	defer server.Close()
+	cred := Credential{Username: "admin", Password: "admin", ClientId: "client-id", ClientSecret: "client-secret"}
+
+	tenantCred, err := getTenantCredential(cred, "dev", "wso2.com", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, Credential{"admin@wso2.com", "admin", "tenant-client-id", "tenant-client-secret"}, tenantCred)
+	assert.Equal(t, "admin@wso2.com", owner)
+
+	cred.Username = "admin@carbon.super"
+	tenantCred, err = getTenantCredential(cred, "dev", "wso2.com", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "admin@wso2.com", tenantCred.Username)
+}
+
+func TestGetTenantCredentialSuperTenant(t *testing.T) {
+	cred := Credential{Username: "admin", Password: "admin", ClientId: "client-id", ClientSecret: "client-secret"}
+	tenantCred, err := getTenantCredential(cred, "dev", "carbon.super", "")
+	assert.Nil(t, err)
+	assert.Equal(t, cred, tenantCred)
+}
+
+func TestGetTenantCredentialTenantUser(t *testing.T) {
+	cred := Credential{Username: "admin@abc.com", Password: "admin", ClientId: "client-id", ClientSecret: "secret"}
+	_, err := getTenantCredential(cred, "dev", "wso2.com", "")
+	assert.Error(t, err, "Tenant users should not be able to run commands against another tenant")
+}
@@ -23,11 +23,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 // PlainTextWarnMessage warning message
 const PlainTextWarnMessage = "WARNING: credentials are stored as a plain text in %s\n"
 
+// storeFilePermission is the permission of the store file, which is readable only by the owner
+const storeFilePermission = 0600
+
 // JsonStore is storing keys in json format
 type JsonStore struct {
 	// Path to file
@@ -69,17 +74,34 @@ func (s *JsonStore) Load() error {
 	return nil
 }
 
-// saves to disk
+// saves to disk. The lock of the store should be held.
 func (s *JsonStore) persist() error {
 	data, err := json.MarshalIndent(s.credentials, "", "  ")
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(s.Path, data, os.ModePerm)
+	// write to a temporary file and rename it, so that a command running in parallel never reads a partially
+	// written store. The store holds tokens, hence only the owner can read it, which also applies to the stores
+	// written with wider permissions before.
+	return utils.WriteFileAtomically(s.Path, data, storeFilePermission)
+}
+
+// update reloads the store and applies the change while holding the lock of the store, so that the changes made by
+// another apictl process in the meantime (ex: a token cached by a parallel command) are not overwritten
+func (s *JsonStore) update(change func() error) error {
+	unlock, err := utils.LockConfigFile(s.Path)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer unlock()
+
+	if err := s.Load(); err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	return s.persist()
 }
 
 // GetAPIMCredentials returns credentials for apim from the store or an error
@@ -111,15 +133,19 @@ func (s *JsonStore) GetAPIMCredentials(env string) (Credential, error) {
 
 // SetAPIMCredentials sets credentials for micro integrator using username, password, clientID and client secret
 func (s *JsonStore) SetAPIMCredentials(env, username, password, clientId, clientSecret string) error {
-	environment := s.credentials.Environments[env]
-	environment.APIM = Credential{
-		Username:     Base64Encode(username),
-		Password:     Base64Encode(password),
-		ClientId:     Base64Encode(clientId),
-		ClientSecret: Base64Encode(clientSecret),
-	}
-	s.credentials.Environments[env] = environment
-	err := s.persist()
+	err := s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.APIM = Credential{
+			Username:     Base64Encode(username),
+			Password:     Base64Encode(password),
+			ClientId:     Base64Encode(clientId),
+			ClientSecret: Base64Encode(clientSecret),
+		}
+		// a token issued to the previous credentials should not be reused
+		environment.APIMToken = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -127,6 +153,42 @@ func (s *JsonStore) SetAPIMCredentials(env, username, password, clientId, client
 	return nil
 }
 
+// GetAPIMToken returns the cached access token of apim from the store or an error
+func (s *JsonStore) GetAPIMToken(env string) (APIMToken, error) {
+	if environment, ok := s.credentials.Environments[env]; ok && environment.APIMToken != nil {
+		accessToken, err := Base64Decode(environment.APIMToken.AccessToken)
+		if err != nil {
+			return APIMToken{}, err
+		}
+		refreshToken, err := Base64Decode(environment.APIMToken.RefreshToken)
+		if err != nil {
+			return APIMToken{}, err
+		}
+		token := APIMToken{
+			accessToken, refreshToken, environment.APIMToken.ExpiresAt,
+		}
+		return token, nil
+	}
+	return APIMToken{}, fmt.Errorf("access token not found for APIM in %s", env)
+}
+
+// SetAPIMToken caches the access token of apim in the store
+func (s *JsonStore) SetAPIMToken(env string, token APIMToken) error {
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		environment.APIMToken = &APIMToken{
+			AccessToken:  Base64Encode(token.AccessToken),
+			RefreshToken: Base64Encode(token.RefreshToken),
+			ExpiresAt:    token.ExpiresAt,
+		}
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
 // GetMICredentials returns credentials for micro integrator from the store or an error
 func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 	if environment, ok := s.credentials.Environments[env]; ok {
@@ -152,14 +214,16 @@ func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 
 // SetMICredentials set credentials for mi using username, password, accessToken
 func (s *JsonStore) SetMICredentials(env, username, password, accessToken string) error {
-	environment := s.credentials.Environments[env]
-	environment.MI = MiCredential{
-		Username:    Base64Encode(username),
-		Password:    Base64Encode(password),
-		AccessToken: Base64Encode(accessToken),
-	}
-	s.credentials.Environments[env] = environment
-	err := s.persist()
+	err := s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.MI = MiCredential{
+			Username:    Base64Encode(username),
+			Password:    Base64Encode(password),
+			AccessToken: Base64Encode(accessToken),
+		}
+		s.credentials.Environments[env] = environment
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -178,59 +242,63 @@ func (s *JsonStore) GetMGToken(env string) (MgAdapterEnv, error) {
 
 // SetMGToken set token for microgateway adapter
 func (s *JsonStore) SetMGToken(env, accessToken string) error {
-	mgwAdapterEnv := s.credentials.MgwAdapterEnvs[env]
-	mgwAdapterEnv.AccessToken = accessToken
-	s.credentials.MgwAdapterEnvs[env] = mgwAdapterEnv
-	if err := s.persist(); err != nil {
-		return err
-	}
-	return nil
+	return s.update(func() error {
+		mgwAdapterEnv := s.credentials.MgwAdapterEnvs[env]
+		mgwAdapterEnv.AccessToken = accessToken
+		s.credentials.MgwAdapterEnvs[env] = mgwAdapterEnv
+		return nil
+	})
 }
 
 // EraseAPIM remove apim credentials from the store
 func (s *JsonStore) EraseAPIM(env string) error {
-	environment, ok := s.credentials.Environments[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	}
-	if !miCredentialsExists(environment.MI) {
-		// delete the environment
-		delete(s.credentials.Environments, env)
-	} else {
-		// remove only apim credentials
-		environment.APIM = Credential{}
-		s.credentials.Environments[env] = environment
-	}
-	return s.persist()
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !miCredentialsExists(environment.MI) {
+			// delete the environment
+			delete(s.credentials.Environments, env)
+		} else {
+			// remove only apim credentials
+			environment.APIM = Credential{}
+			environment.APIMToken = nil
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
 }
 
 // EraseMI remove mi credentials from the store
 func (s *JsonStore) EraseMI(env string) error {
-	environment, ok := s.credentials.Environments[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	}
-	if !apimCredentialsExists(environment.APIM) {
-		// delete the environment
-		delete(s.credentials.Environments, env)
-	} else {
-		// remove only mi credentials
-		environment.MI = MiCredential{}
-		s.credentials.Environments[env] = environment
-	}
-	return s.persist()
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !apimCredentialsExists(environment.APIM) {
+			// delete the environment
+			delete(s.credentials.Environments, env)
+		} else {
+			// remove only mi credentials
+			environment.MI = MiCredential{}
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
 }
 
 // EraseMG remove mg tokens from the store
 func (s *JsonStore) EraseMG(env string) error {
-	_, ok := s.credentials.MgwAdapterEnvs[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	} else {
+	return s.update(func() error {
+		if _, ok := s.credentials.MgwAdapterEnvs[env]; !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
 		// remove only mg tokens
 		delete(s.credentials.MgwAdapterEnvs, env)
-	}
-	return s.persist()
+		return nil
+	})
 }
 
 // IsKeychainEnabled returns if another store is activated
@@ -0,0 +1,77 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonStoreKeepsChangesOfParallelUpdates(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "keys.json")
+
+	// Each store is loaded before the others persist their changes, as parallel apictl processes would do
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		store := NewJsonStore(storePath)
+		assert.Nil(t, store.Load())
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.Nil(t, store.SetMGToken(fmt.Sprintf("env%d", i), "access-token"))
+		}(i)
+	}
+	wg.Wait()
+
+	store := NewJsonStore(storePath)
+	assert.Nil(t, store.Load())
+	assert.Len(t, store.credentials.MgwAdapterEnvs, 10, "no change should be lost")
+
+	assert.Nil(t, store.EraseMG("env0"))
+	assert.NotNil(t, store.EraseMG("env0"), "erased environment should not be found")
+	assert.False(t, store.HasMG("env0"))
+	assert.True(t, store.HasMG("env1"))
+}
+
+func TestJsonStoreIsReadableOnlyByOwner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	storePath := filepath.Join(t.TempDir(), "keys.json")
+	assert.Nil(t, ioutil.WriteFile(storePath, []byte(`{"environments": {}, "mgw-clusters": {}}`), 0777))
+	assert.Nil(t, os.Chmod(storePath, 0777))
+
+	store := NewJsonStore(storePath)
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetMGToken("dev", "access-token"))
+
+	info, err := os.Stat(storePath)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "existing store should be restricted when rewritten")
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(storePath), "*.tmp*"))
+	assert.Nil(t, err)
+	assert.Empty(t, files, "temporary file should not be left behind")
+}
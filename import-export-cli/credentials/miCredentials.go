@@ -51,7 +51,7 @@ func GetMICredentials(env string) (MiCredential, error) {
 
 	if !utils.MIExistsInEnv(env, utils.MainConfigFilePath) {
 		fmt.Println("MI does not exists in", env, "Add it using add env")
-		os.Exit(1)
+		utils.Exit(1)
 	}
 
 	if !store.HasMI(env) {
@@ -143,7 +143,7 @@ func RevokeAccessTokenForMI(env, token string) error {
 func RunMILogin(store Store, environment, username, password string) error {
 	if !utils.MIExistsInEnv(environment, utils.MainConfigFilePath) {
 		fmt.Println("MI does not exists in", environment, "Add it using add env")
-		os.Exit(1)
+		utils.Exit(1)
 	}
 	if username == "" {
 		fmt.Print("Username:")
@@ -29,10 +29,14 @@ type Store interface {
 	GetAPIMCredentials(env string) (Credential, error)
 	// GetMICredentials returns credentials for micro integrator from the store or an error
 	GetMICredentials(env string) (MiCredential, error)
+	// GetAPIMToken returns the cached access token of apim or an error
+	GetAPIMToken(env string) (APIMToken, error)
 	// GetMgwAdapterToken returns the Access Token of the Microgateway Adapter
 	GetMGToken(env string) (MgAdapterEnv, error)
 	// SetAPIMCredentials sets credentials for micro integrator using username, password, clientID and client secret
 	SetAPIMCredentials(env, username, password, clientID, clientSecret string) error
+	// SetAPIMToken caches the access token of apim
+	SetAPIMToken(env string, token APIMToken) error
 	// SetMICredentials sets credentials for micro integrator using username, password and access token
 	SetMICredentials(env, username, password, accessToken string) error
 	// SetMGToken sets the Access Token for a Microgateway Adapter env
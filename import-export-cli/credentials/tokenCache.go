@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// tokenExpiryBuffer is deducted from the validity period of a cached token, so that the token does not expire while
+// a command is using it
+const tokenExpiryBuffer = 60 * time.Second
+
+// tokenCacheMutex serializes the token cache updates of the requests made in parallel within a command
+var tokenCacheMutex sync.Mutex
+
+// getOAuthAccessToken returns the access token cached in the store if it is still valid. Otherwise a new token is
+// obtained using the cached refresh token, or by logging in again with the stored credentials if the refresh token
+// is not usable, and the new token is cached
+// @param store : Credential store to cache the token
+// @param credential : Credentials of the environment
+// @param env : Environment of the credentials
+// @param tokenEndpoint : OAuth token endpoint of the environment
+// @param now : Current time
+// @return access token, error
+func getOAuthAccessToken(store Store, credential Credential, env, tokenEndpoint string, now time.Time) (string,
+	error) {
+	b64EncodedClientIDClientSecret := Base64Encode(credential.ClientId + ":" + credential.ClientSecret)
+	if !isStoredCredential(store, credential, env) {
+		// tokens issued to credentials which are not in the store are not cached
+		tokenResponse, err := utils.GetOAuthTokenResponse(credential.Username, credential.Password,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+		if err != nil {
+			return "", err
+		}
+		return getAccessToken(tokenResponse)
+	}
+
+	token, err := store.GetAPIMToken(env)
+	if err == nil && token.AccessToken != "" && now.Add(tokenExpiryBuffer).Before(time.Unix(token.ExpiresAt, 0)) {
+		utils.Logln(utils.LogPrefixInfo + "Using the cached access token of " + env)
+		return token.AccessToken, nil
+	}
+
+	var tokenResponse *utils.TokenResponse
+	if err == nil && token.RefreshToken != "" {
+		utils.Logln(utils.LogPrefixInfo + "Refreshing the expired access token of " + env)
+		tokenResponse, err = utils.RefreshOAuthTokens(token.RefreshToken, b64EncodedClientIDClientSecret,
+			tokenEndpoint)
+		if err != nil {
+			// the refresh token could have expired or been used by another command
+			utils.Logln(utils.LogPrefixWarning + "Unable to refresh the access token: " + err.Error())
+			tokenResponse = nil
+		}
+	}
+	if tokenResponse == nil {
+		utils.Logln(utils.LogPrefixInfo + "Logging in to " + env + " again using the stored credentials")
+		tokenResponse, err = utils.GetOAuthTokenResponse(credential.Username, credential.Password,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+		if err != nil {
+			return "", err
+		}
+	}
+	accessToken, err := getAccessToken(tokenResponse)
+	if err != nil {
+		return "", err
+	}
+
+	token = APIMToken{
+		AccessToken:  accessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresAt:    now.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second).Unix(),
+	}
+	if err = store.SetAPIMToken(env, token); err != nil {
+		// the token can still be used by the current command
+		utils.Logln(utils.LogPrefixWarning + "Unable to cache the access token: " + err.Error())
+	}
+	return accessToken, nil
+}
+
+// isStoredCredential returns whether the given credentials are the apim credentials stored for the environment
+func isStoredCredential(store Store, credential Credential, env string) bool {
+	if !store.HasAPIM(env) {
+		return false
+	}
+	storedCredential, err := store.GetAPIMCredentials(env)
+	return err == nil && storedCredential.Username == credential.Username &&
+		storedCredential.ClientId == credential.ClientId
+}
+
+func getAccessToken(tokenResponse *utils.TokenResponse) (string, error) {
+	if tokenResponse.AccessToken == "" {
+		return "", errors.New("access_token not found")
+	}
+	return tokenResponse.AccessToken, nil
+}
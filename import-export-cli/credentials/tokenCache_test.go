@@ -0,0 +1,142 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTokenCacheStore(t *testing.T) *JsonStore {
+	store := NewJsonStore(filepath.Join(t.TempDir(), DefaultConfigFile))
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "client-id", "client-secret"))
+	return store
+}
+
+func getTokenEndpointStub(t *testing.T, grants *[]string, refreshStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Unexpected request body: %v\n", err)
+		}
+		grantType := r.PostForm.Get("grant_type")
+		*grants = append(*grants, grantType)
+		if grantType == "refresh_token" && refreshStatus != http.StatusOK {
+			w.WriteHeader(refreshStatus)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"access_token": "` + grantType + `-access-token",
+			"refresh_token": "` + grantType + `-refresh-token", "expires_in": 3600}`))
+	}))
+}
+
+func TestGetOAuthAccessTokenCached(t *testing.T) {
+	var grants []string
+	server := getTokenEndpointStub(t, &grants, http.StatusOK)
+	defer server.Close()
+	store := getTokenCacheStore(t)
+	cred, _ := store.GetAPIMCredentials("dev")
+	now := time.Now()
+
+	accessToken, err := getOAuthAccessToken(store, cred, "dev", server.URL, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "password-access-token", accessToken)
+
+	// the cached token should be read from the file by the next command
+	reloaded := NewJsonStore(store.Path)
+	assert.Nil(t, reloaded.Load())
+	accessToken, err = getOAuthAccessToken(reloaded, cred, "dev", server.URL, now.Add(30*time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "password-access-token", accessToken)
+	assert.Equal(t, []string{"password"}, grants)
+}
+
+func TestGetOAuthAccessTokenRefresh(t *testing.T) {
+	var grants []string
+	server := getTokenEndpointStub(t, &grants, http.StatusOK)
+	defer server.Close()
+	store := getTokenCacheStore(t)
+	cred, _ := store.GetAPIMCredentials("dev")
+	now := time.Now()
+
+	_, err := getOAuthAccessToken(store, cred, "dev", server.URL, now)
+	assert.Nil(t, err)
+	accessToken, err := getOAuthAccessToken(store, cred, "dev", server.URL, now.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh_token-access-token", accessToken)
+	assert.Equal(t, []string{"password", "refresh_token"}, grants)
+
+	token, err := store.GetAPIMToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh_token-refresh-token", token.RefreshToken)
+	assert.Equal(t, now.Add(2*time.Hour).Unix(), token.ExpiresAt)
+}
+
+func TestGetOAuthAccessTokenRefreshFailed(t *testing.T) {
+	var grants []string
+	server := getTokenEndpointStub(t, &grants, http.StatusBadRequest)
+	defer server.Close()
+	store := getTokenCacheStore(t)
+	cred, _ := store.GetAPIMCredentials("dev")
+	now := time.Now()
+
+	_, err := getOAuthAccessToken(store, cred, "dev", server.URL, now)
+	assert.Nil(t, err)
+	accessToken, err := getOAuthAccessToken(store, cred, "dev", server.URL, now.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, "password-access-token", accessToken)
+	assert.Equal(t, []string{"password", "refresh_token", "password"}, grants,
+		"Should login again with the stored credentials when the refresh token is rejected")
+}
+
+func TestGetOAuthAccessTokenNotStoredCredential(t *testing.T) {
+	var grants []string
+	server := getTokenEndpointStub(t, &grants, http.StatusOK)
+	defer server.Close()
+	store := getTokenCacheStore(t)
+	cred := Credential{Username: "admin", Password: "admin", ClientId: "other-client-id", ClientSecret: "secret"}
+
+	accessToken, err := getOAuthAccessToken(store, cred, "dev", server.URL, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, "password-access-token", accessToken)
+	_, err = store.GetAPIMToken("dev")
+	assert.Error(t, err, "Tokens of credentials which are not stored should not be cached")
+}
+
+func TestSetAPIMCredentialsClearsToken(t *testing.T) {
+	store := getTokenCacheStore(t)
+	assert.Nil(t, store.SetAPIMToken("dev", APIMToken{"access-token", "refresh-token", time.Now().Unix()}))
+	token, err := store.GetAPIMToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+
+	data, _ := ioutil.ReadFile(store.Path)
+	assert.NotContains(t, string(data), "access-token", "Tokens should be encoded like the other credentials")
+
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "client-id", "client-secret"))
+	_, err = store.GetAPIMToken("dev")
+	assert.Error(t, err)
+}
@@ -27,10 +27,12 @@ import (
 const Git = "git"
 const VCSConfigFileName = "vcs_config.yaml"
 const VCSRepoInfoFileName = "vcs.yaml"
+const VCSAuditFileName = "vcs_audit.log"
 
 const FromRevTypeLastAttempted = "last_attempted"
 const FromRevTypeLastSuccessful = "last_successful"
 
 const lastSuccessfulCommitsToKeep = 15
 
-var VCSConfigFilePath = filepath.Join(utils.ConfigDirPath, VCSConfigFileName)
\ No newline at end of file
+var VCSConfigFilePath = filepath.Join(utils.ConfigDirPath, VCSConfigFileName)
+var VCSAuditFilePath = filepath.Join(utils.ConfigDirPath, VCSAuditFileName)
\ No newline at end of file
@@ -0,0 +1,269 @@
+/*
+*  Copyright (c) 2024 WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const defaultDeploymentHistoryTableFormat = "table {{.Timestamp}}\t{{.ShortCommit}}\t{{.CommitSignature}}\t" +
+	"{{.User}}\t{{.Result}}\t{{.Succeeded}}\t{{.Failed}}\t{{.Skipped}}"
+
+// commitSignatureStatuses maps the signature status of a commit given by git (%G?) to a readable status
+var commitSignatureStatuses = map[string]string{
+	"G": "good",
+	"B": "bad",
+	"U": "good-unknown-validity",
+	"X": "good-expired",
+	"Y": "expired-key",
+	"R": "revoked-key",
+	"E": "unverifiable",
+	"N": "unsigned",
+}
+
+// DeploymentAuditRecord is a vcs deployment recorded in the audit trail. Each record keeps the hash of the record
+// before it, so that a record removed or modified afterwards can be detected.
+type DeploymentAuditRecord struct {
+	Timestamp          string                    `json:"timestamp"`
+	RepoId             string                    `json:"repoId"`
+	Environment        string                    `json:"environment"`
+	Commit             string                    `json:"commit"`
+	CommitSignature    string                    `json:"commitSignature"`
+	CommitSigner       string                    `json:"commitSigner,omitempty"`
+	User               string                    `json:"user"`
+	Result             string                    `json:"result"`
+	RolledBack         bool                      `json:"rolledBack"`
+	Succeeded          int                       `json:"succeeded"`
+	Failed             int                       `json:"failed"`
+	Skipped            int                       `json:"skipped"`
+	Projects           []ProjectDeploymentResult `json:"projects"`
+	PreviousRecordHash string                    `json:"previousRecordHash"`
+}
+
+// ShortCommit returns the abbreviated commit id of the deployed revision
+func (record DeploymentAuditRecord) ShortCommit() string {
+	if len(record.Commit) > 8 {
+		return record.Commit[0:8]
+	}
+	return record.Commit
+}
+
+// Appends the deployment to the audit trail (vcs_audit.log) along with the commit deployed from the source repository
+// and whether the commit is signed
+// environment is the environment name
+// user is the user who deployed the projects
+// summary is the result of each deployed and deleted project
+// rolledBack is true if the environment was rolled back to the last successful revision due to failures
+func RecordDeployment(environment, user string, summary *DeploymentSummary, rolledBack bool) error {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	changeDirectoryToSourceRepo(mainConfig)
+
+	repoId, err := getRepoId()
+	if err != nil {
+		return err
+	}
+	commit, err := getLatestCommitId()
+	if err != nil {
+		return err
+	}
+	signature, signer := getCommitSignature(commit)
+
+	result := DeploymentStatusSucceeded
+	if summary.Failed > 0 || summary.Skipped > 0 {
+		result = DeploymentStatusFailed
+	}
+	return appendAuditRecord(VCSAuditFilePath, DeploymentAuditRecord{
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		RepoId:          repoId,
+		Environment:     environment,
+		Commit:          commit,
+		CommitSignature: signature,
+		CommitSigner:    signer,
+		User:            user,
+		Result:          result,
+		RolledBack:      rolledBack,
+		Succeeded:       summary.Succeeded,
+		Failed:          summary.Failed,
+		Skipped:         summary.Skipped,
+		Projects:        summary.Projects,
+	})
+}
+
+// Returns the deployments of the source repository to the environment recorded in the audit trail, oldest first.
+// The second return value is false if a record of the audit trail was removed or modified.
+// environment is the environment name
+func GetDeploymentHistory(environment string) ([]DeploymentAuditRecord, bool, error) {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	changeDirectoryToSourceRepo(mainConfig)
+
+	repoId, err := getRepoId()
+	if err != nil {
+		return nil, false, err
+	}
+	records, intact, err := readAuditRecords(VCSAuditFilePath)
+	if err != nil {
+		return nil, false, err
+	}
+	history := []DeploymentAuditRecord{}
+	for _, record := range records {
+		if record.RepoId == repoId && record.Environment == environment {
+			history = append(history, record)
+		}
+	}
+	return history, intact, nil
+}
+
+// Prints the deployment history in the given format
+func PrintDeploymentHistory(history []DeploymentAuditRecord, format string) {
+	if utils.PrintFormattedOutput(history, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultDeploymentHistoryTableFormat)
+
+	historyContext := formatter.NewContext(os.Stdout, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, record := range history {
+			if err := t.Execute(w, record); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	historyTableHeaders := map[string]string{
+		"Timestamp":       "TIMESTAMP",
+		"ShortCommit":     "COMMIT",
+		"CommitSignature": "SIGNATURE",
+		"User":            "USER",
+		"Result":          "RESULT",
+		"Succeeded":       "SUCCEEDED",
+		"Failed":          "FAILED",
+		"Skipped":         "SKIPPED",
+	}
+	if err := historyContext.Write(renderer, historyTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+// Returns the signature status of the commit and the signer if the commit is signed
+func getCommitSignature(commit string) (string, string) {
+	output, err := executeGitCommand("log", "-1", "--format=%G?%n%GS", commit)
+	if err != nil {
+		return commitSignatureStatuses["E"], ""
+	}
+	lines := strings.SplitN(strings.TrimRight(output, "\n"), "\n", 2)
+	status, ok := commitSignatureStatuses[strings.TrimSpace(lines[0])]
+	if !ok {
+		status = commitSignatureStatuses["E"]
+	}
+	var signer string
+	if len(lines) > 1 {
+		signer = strings.TrimSpace(lines[1])
+	}
+	return status, signer
+}
+
+// Appends the record as a line to the audit file with the hash of the last line of the file. The file is only ever
+// opened for appending.
+func appendAuditRecord(filePath string, record DeploymentAuditRecord) error {
+	lastRecord, err := readLastLine(filePath)
+	if err != nil {
+		return err
+	}
+	record.PreviousRecordHash = hashAuditRecord(lastRecord)
+	content, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(content, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Reads the records of the audit file and verifies that each record has the hash of the record before it
+func readAuditRecords(filePath string) ([]DeploymentAuditRecord, bool, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, true, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	var records []DeploymentAuditRecord
+	intact := true
+	var previousLine []byte
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record DeploymentAuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, false, fmt.Errorf("invalid record in %s: %v", filePath, err)
+		}
+		if record.PreviousRecordHash != hashAuditRecord(previousLine) {
+			intact = false
+		}
+		records = append(records, record)
+		previousLine = append([]byte{}, line...)
+	}
+	return records, intact, scanner.Err()
+}
+
+// Returns the last non empty line of the file or nil if the file does not exist
+func readLastLine(filePath string) ([]byte, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	lines := bytes.Split(bytes.TrimRight(content, "\n"), []byte{'\n'})
+	return lines[len(lines)-1], nil
+}
+
+// Returns the hex encoded SHA-256 hash of the record line or an empty string for the first record
+func hashAuditRecord(line []byte) string {
+	if len(line) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256(line)
+	return hex.EncodeToString(hash[:])
+}
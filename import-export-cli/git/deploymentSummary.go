@@ -0,0 +1,171 @@
+/*
+*  Copyright (c) 2024 WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Deployment operations and statuses of the projects reported in the deployment summary
+const (
+	DeploymentOperationDeploy = "deploy"
+	DeploymentOperationDelete = "delete"
+
+	DeploymentStatusSucceeded = "succeeded"
+	DeploymentStatusFailed    = "failed"
+	DeploymentStatusSkipped   = "skipped"
+)
+
+// deploymentOrder is the order in which the project types are deployed, so that the APIs are deployed before the
+// API Products that include them and both are deployed before the Applications that subscribe to them.
+// The projects are deleted in the reverse order.
+var deploymentOrder = []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication}
+
+// projectTypeTitles are the titles under which the projects of each type are listed during the deployment
+var projectTypeTitles = map[string]string{
+	utils.ProjectTypeApi:         "APIs",
+	utils.ProjectTypeApiProduct:  "API Products",
+	utils.ProjectTypeApplication: "Applications",
+}
+
+// ProjectDeploymentResult is the result of deploying or deleting a single project
+type ProjectDeploymentResult struct {
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	RelativePath string `json:"relativePath"`
+	Operation    string `json:"operation"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// DeploymentSummary is the machine readable summary of a vcs deployment
+type DeploymentSummary struct {
+	Environment string                    `json:"environment"`
+	Total       int                       `json:"total"`
+	Succeeded   int                       `json:"succeeded"`
+	Failed      int                       `json:"failed"`
+	Skipped     int                       `json:"skipped"`
+	Projects    []ProjectDeploymentResult `json:"projects"`
+
+	// failedArtifacts keeps the APIs and API Products that could not be deployed, so that the projects depending on
+	// them can be skipped
+	failedArtifacts map[string]bool
+}
+
+// newDeploymentSummary creates an empty deployment summary for the environment
+func newDeploymentSummary(environment string) *DeploymentSummary {
+	return &DeploymentSummary{
+		Environment:     environment,
+		Projects:        []ProjectDeploymentResult{},
+		failedArtifacts: make(map[string]bool),
+	}
+}
+
+// record adds the result of a project to the summary. err is the reason for the failure or skipping of the project.
+func (summary *DeploymentSummary) record(projectParam *params.ProjectParams, operation, status string, err error) {
+	result := ProjectDeploymentResult{
+		Type:         projectParam.Type,
+		Name:         projectParam.NickName,
+		RelativePath: projectParam.RelativePath,
+		Operation:    operation,
+		Status:       status,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	summary.Projects = append(summary.Projects, result)
+	summary.Total++
+	switch status {
+	case DeploymentStatusSucceeded:
+		summary.Succeeded++
+	case DeploymentStatusFailed:
+		summary.Failed++
+	case DeploymentStatusSkipped:
+		summary.Skipped++
+	}
+}
+
+// markArtifactFailed records that the API or API Product with the given name and version could not be deployed
+func (summary *DeploymentSummary) markArtifactFailed(name, version string) {
+	summary.failedArtifacts[artifactKey(name, version)] = true
+}
+
+// failedDependencyOf returns the name and version of an API or API Product the project depends on which could not be
+// deployed. The second return value is false if all the dependencies of the project were deployed.
+// projectPath is the path of the project in the source repository
+func (summary *DeploymentSummary) failedDependencyOf(projectType, projectPath string) (string, bool) {
+	if len(summary.failedArtifacts) == 0 {
+		return "", false
+	}
+	switch projectType {
+	case utils.ProjectTypeApiProduct:
+		apiProduct, _, err := impl.GetAPIProductDefinition(projectPath)
+		if err != nil {
+			return "", false
+		}
+		for _, api := range apiProduct.Data.APIs {
+			if summary.failedArtifacts[artifactKey(api.Name, api.Version)] {
+				return artifactKey(api.Name, api.Version), true
+			}
+		}
+	case utils.ProjectTypeApplication:
+		app, _, err := impl.GetApplicationDefinition(projectPath)
+		if err != nil {
+			return "", false
+		}
+		for _, subscribedAPI := range app.Data.SubscribedAPIs {
+			if summary.failedArtifacts[artifactKey(subscribedAPI.APIId.APIName, subscribedAPI.APIId.Version)] {
+				return artifactKey(subscribedAPI.APIId.APIName, subscribedAPI.APIId.Version), true
+			}
+		}
+	}
+	return "", false
+}
+
+// Print prints the number of succeeded, failed and skipped projects of the deployment
+func (summary *DeploymentSummary) Print() {
+	if summary.Total == 0 {
+		return
+	}
+	fmt.Printf("\nSummary: %d succeeded, %d failed, %d skipped\n", summary.Succeeded, summary.Failed, summary.Skipped)
+	for _, result := range summary.Projects {
+		if result.Status != DeploymentStatusSucceeded {
+			fmt.Println(" " + result.Status + ": " + result.Name + ": (" + result.RelativePath + ") " + result.Error)
+		}
+	}
+}
+
+// WriteToFile writes the summary as JSON to filePath
+func (summary *DeploymentSummary) WriteToFile(filePath string) error {
+	content, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+func artifactKey(name, version string) string {
+	return name + ":" + version
+}
@@ -243,7 +243,8 @@ func Rollback(accessToken, environment string) error {
 
 	// Again change directory to the source repo and deploy the updated projects
 	changeDirectoryToSourceRepo(mainConfig)
-	deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+	deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate,
+		updatedProjectsPerType, newDeploymentSummary(environment))
 
 	// Again change directory to the source repo (because inside deployUpdatedProjects the directory must have changed to the deployment)
 	changeDirectoryToSourceRepo(mainConfig)
@@ -308,9 +309,10 @@ func deleteTmpBranch(tmpBranch string) {
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
 // deletedProjectsPerType A map that has keys as Apps/APIs or API Products and values as deleted projects of each type
+// summary is the deployment summary to which the result of each deletion is added
 // This will return the failed projects with the same structure at the end if such projects exist during deletion.
 func deployProjectDeletions(accessToken, environment string, deletedProjectsPerType map[string][]*params.ProjectParams,
-	failedProjects map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+	failedProjects map[string][]*params.ProjectParams, summary *DeploymentSummary) map[string][]*params.ProjectParams {
 	// Deleting Application projects
 	applicationProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjectsToDelete) != 0 {
@@ -318,15 +320,16 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 		for i, projectParam := range applicationProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
 			appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Data.Applicationinfo.Name,
 				appInfo.Data.Applicationinfo.Owner)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			impl.PrintDeleteAppResponse(resp, err)
+			summary.record(projectParam, DeploymentOperationDelete, DeploymentStatusSucceeded, nil)
 		}
 	}
 
@@ -337,14 +340,15 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 		for i, projectParam := range apiProductProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
 			apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.Data.Name, apiProductInfo.Data.Version, apiProductInfo.Data.Provider)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			impl.PrintDeleteAPIProductResponse(resp, err)
+			summary.record(projectParam, DeploymentOperationDelete, DeploymentStatusSucceeded, nil)
 		}
 	}
 
@@ -355,45 +359,51 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 		for i, projectParam := range apiProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
 			apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.Data.Name, apiInfo.Data.Version, apiInfo.Data.Provider)
-			if handleIfError(err, failedProjects, projectParam) {
+			if handleIfError(err, failedProjects, projectParam, summary) {
 				continue
 			}
 			impl.PrintDeleteAPIResponse(resp, err)
+			summary.record(projectParam, DeploymentOperationDelete, DeploymentStatusSucceeded, nil)
 		}
 	}
 
 	return failedProjects
 }
 
-// Logs the error and appends the failed project given from projectParam into the failedProjects map.
-func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams) bool {
+// Logs the error and appends the failed project given from projectParam into the failedProjects map and the summary.
+func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams,
+	summary *DeploymentSummary) bool {
 	if err != nil {
 		fmt.Println("Error... ", err)
 		failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+		summary.record(projectParam, DeploymentOperationDelete, DeploymentStatusFailed, err)
 	}
 	return err != nil
 }
 
 // Deploys the updated projects. It will only handle new or updated projects and deleted projects will be tracked and
 // skipped. Those deleted projects will be returned from the 2nd return argument.
+// The projects are deployed in the deploymentOrder. A failed project does not stop the deployment of the other
+// projects, but the API Products and Applications that depend on an API or API Product which failed are skipped.
 // accesstoken is the access token to access the APIM product REST APIs
 // sourceRepoId is the id of the source git repository (located in vcs.yaml)
 // deploymentRepoId is the id of the deployment git repository (located in vcs.yaml)
 // environment is the environment name
 // totalProjectsToUpdate is the number of total projects that needs to be deployed.
 // updatedProjectsPerType is a map of string -> ProjectParams which consists of updated projects per each type (API, App..)
+// summary is the deployment summary to which the result of each project is added
 // Returns bool, true if any deleted projects exists so the process should continue with project deletion path
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  deleted projects
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  failed during the deployment
 func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment string, totalProjectsToUpdate int,
-	updatedProjectsPerType map[string][]*params.ProjectParams) (bool, map[string][]*params.ProjectParams,
-	map[string][]*params.ProjectParams) {
+	updatedProjectsPerType map[string][]*params.ProjectParams, summary *DeploymentSummary) (bool,
+	map[string][]*params.ProjectParams, map[string][]*params.ProjectParams) {
 	if totalProjectsToUpdate == 0 {
 		fmt.Println("Everything is up-to-date")
 		return false, nil, nil
@@ -406,94 +416,39 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	var deletedProjectsPerType = make(map[string][]*params.ProjectParams)
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
-	// deploying API projects
-	apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
-	if len(apiProjects) != 0 {
-		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
-		for i, projectParam := range apiProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
-			}
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
-			if !dirExists {
-				projectDeploymentParamsDirLocation = ""
-			} else {
-				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
-					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPI)
-				if err != nil {
-					fmt.Println("Error... ", err)
-					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-				}
-			}
-			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			err := impl.ImportAPIToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
-				projectDeploymentParamsDirLocation, importParams.Update, importParams.PreserveProvider, false, importParams.RotateRevision, false)
-			if err != nil {
-				fmt.Println("Error... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-			}
+	for _, projectType := range deploymentOrder {
+		projects := updatedProjectsPerType[projectType]
+		if len(projects) == 0 {
+			continue
 		}
-	}
-
-	// deploying API product projects
-	apiProductProjects := updatedProjectsPerType[utils.ProjectTypeApiProduct]
-	if len(apiProductProjects) != 0 {
-		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
-		for i, projectParam := range apiProductProjects {
+		fmt.Println("\n" + projectTypeTitles[projectType] + " (" + strconv.Itoa(len(projects)) + ") ...")
+		for i, projectParam := range projects {
 			// if the project is a deleted one, we do it later. So keep it for now.
 			if projectParam.Deleted {
 				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
 				hasDeletedProjects = true
 				continue
 			}
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
-			if !dirExists {
-				projectDeploymentParamsDirLocation = ""
-			} else {
-				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
-					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPIProduct)
-				if err != nil {
-					fmt.Println("Error... ", err)
-					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-				}
-			}
-			importParams := projectParam.MetaData.DeployConfig.Import
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			err := impl.ImportAPIProductToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
-				projectDeploymentParamsDirLocation, importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
-				importParams.PreserveProvider, false, importParams.RotateRevision, false)
-			if err != nil {
-				fmt.Println("\terror... ", err)
+			if dependency, failed := summary.failedDependencyOf(projectType, getSourceProjectPath(mainConfig,
+				projectParam)); failed {
+				err := errors.New("skipped as " + dependency + " which it depends on was not deployed")
+				fmt.Println("Skipped... ", err)
 				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-			}
-		}
-	}
-
-	// deploying Application projects
-	applicationProjects := updatedProjectsPerType[utils.ProjectTypeApplication]
-	if len(applicationProjects) != 0 {
-		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
-		for i, projectParam := range applicationProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
+				summary.record(projectParam, DeploymentOperationDeploy, DeploymentStatusSkipped, err)
 				continue
 			}
-			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			_, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath, projectParam.MetaData.Owner,
-				importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
+			err := deployProject(accessToken, environment, mainConfig, projectParam)
 			if err != nil {
-				fmt.Println("\terror... ", err)
+				fmt.Println("Error... ", err)
 				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				summary.record(projectParam, DeploymentOperationDeploy, DeploymentStatusFailed, err)
+				if projectType != utils.ProjectTypeApplication {
+					summary.markArtifactFailed(projectParam.MetaData.Name, projectParam.MetaData.Version)
+				}
+				continue
 			}
+			summary.record(projectParam, DeploymentOperationDeploy, DeploymentStatusSucceeded, nil)
 		}
 	}
 
@@ -510,6 +465,54 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	return hasDeletedProjects, deletedProjectsPerType, failedProjects
 }
 
+// Deploys a single new or updated project (API, API Product or Application) to the environment
+// accesstoken is the access token to access the APIM product REST APIs
+// environment is the environment name
+// mainConfig is the main configuration which has the paths of the source and the deployment repositories
+// projectParam is the project to be deployed
+func deployProject(accessToken, environment string, mainConfig *utils.MainConfig,
+	projectParam *params.ProjectParams) error {
+	if projectParam.Type == utils.ProjectTypeApplication {
+		importParams := projectParam.MetaData.DeployConfig.Import
+		_, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath, projectParam.MetaData.Owner,
+			importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
+		return err
+	}
+
+	metaFileName := utils.MetaFileAPI
+	if projectParam.Type == utils.ProjectTypeApiProduct {
+		metaFileName = utils.MetaFileAPIProduct
+	}
+	projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
+	dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
+	if !dirExists {
+		projectDeploymentParamsDirLocation = ""
+	} else {
+		err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
+			projectDeploymentParamsDirLocation+string(os.PathSeparator)+metaFileName)
+		if err != nil {
+			return err
+		}
+	}
+	importParams := projectParam.MetaData.DeployConfig.Import
+	if projectParam.Type == utils.ProjectTypeApiProduct {
+		return impl.ImportAPIProductToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+			projectDeploymentParamsDirLocation, importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
+			importParams.PreserveProvider, false, importParams.RotateRevision, false)
+	}
+	return impl.ImportAPIToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+		projectDeploymentParamsDirLocation, importParams.Update, importParams.PreserveProvider, false, importParams.RotateRevision, false, nil, "", "", "", false)
+}
+
+// Returns the path of the project in the source repository. Applications are deployed from the path they were
+// detected, while APIs and API Products are deployed from the path derived from their name and version.
+func getSourceProjectPath(mainConfig *utils.MainConfig, projectParam *params.ProjectParams) string {
+	if projectParam.Type == utils.ProjectTypeApplication {
+		return projectParam.AbsolutePath
+	}
+	return generateSourceProjectPath(mainConfig, projectParam)
+}
+
 // This method is responsible for resolving the correct meta data deplof configurations
 // for API and API Product projects by considering both the Source and Deployment repositories
 // sourceDeploymentMetaData is the values of the meta data file from the Source repository
@@ -572,7 +575,11 @@ func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedPro
 // Deploy all the changes to the specified environment.
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func DeployChangedFiles(accessToken, environment string) map[string][]*params.ProjectParams {
+// Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
+//  failed during the deployment
+// Returns *DeploymentSummary, the result of each deployed and deleted project
+func DeployChangedFiles(accessToken, environment string) (map[string][]*params.ProjectParams, *DeploymentSummary) {
+	summary := newDeploymentSummary(environment)
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
 	changeDirectoryToSourceRepo(mainConfig)
@@ -594,7 +601,8 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 	// Again change directory to the source repo and deploy the updated projects
 	changeDirectoryToSourceRepo(mainConfig)
 	hasDeletedProjects, deletedProjectsPerType, failedProjects :=
-		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate,
+			updatedProjectsPerType, summary)
 
 	// Deletion will only be considered for source repo
 	if hasDeletedProjects {
@@ -610,7 +618,7 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 		if !hasEnv || len(envVCSConfig.LastSuccessfulRev) == 0 {
 			utils.HandleErrorAndExit("Error: there are projects to delete but no last successful "+
 				"revision available in vcs config (vcs_config.yaml)", nil)
-			return nil
+			return nil, summary
 		}
 		currentBranch := getCurrentBranch()
 		lastSuccessfulRev := envVCSConfig.LastSuccessfulRev[0]
@@ -618,14 +626,14 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 
 		fmt.Println("\nDeleting projects ..")
 		checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRev)
-		failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects)
+		failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects, summary)
 		checkoutBranch(currentBranch)
 		deleteTmpBranch(tmpBranchName)
 
 		// Update the VCS config with failed projects, last attempted and last successful revisions
 		updateVCSConfig(sourceRepoId, environment, failedProjects)
 	}
-	return failedProjects
+	return failedProjects, summary
 }
 
 // Create 'vcs.yaml' in the repository root folder with a unique id (uuid) for the repository.
@@ -0,0 +1,84 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ApplicationRequest is an application to be created using the Developer Portal REST API
+type ApplicationRequest struct {
+	Name             string            `json:"name"`
+	ThrottlingPolicy string            `json:"throttlingPolicy"`
+	Description      string            `json:"description"`
+	TokenType        string            `json:"tokenType"`
+	Groups           []string          `json:"groups,omitempty"`
+	Attributes       map[string]string `json:"attributes,omitempty"`
+}
+
+// ParseAppAttributes parses the application attributes given as key=value
+// @param attributes : Attributes given as key=value
+// @return attributes map, error
+func ParseAppAttributes(attributes []string) (map[string]string, error) {
+	attributesMap := make(map[string]string)
+	for _, attribute := range attributes {
+		keyValue := strings.SplitN(attribute, "=", 2)
+		if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+			return nil, fmt.Errorf("invalid attribute %s, the attributes should be given as key=value", attribute)
+		}
+		attributesMap[strings.TrimSpace(keyValue[0])] = keyValue[1]
+	}
+	return attributesMap, nil
+}
+
+// AddApplication creates an application of the user in the environment
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to create the application in
+// @param application : Application to be created
+// @return created application, error
+func AddApplication(accessToken, environment string, application *ApplicationRequest) (*utils.AppDetails, error) {
+	applicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return addApplication(applicationsEndpoint, accessToken, application)
+}
+
+func addApplication(applicationsEndpoint, accessToken string, application *ApplicationRequest) (*utils.AppDetails,
+	error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+
+	utils.Logln(utils.LogPrefixInfo+"Creating the application", application.Name)
+	resp, err := utils.InvokePOSTRequest(applicationsEndpoint, headers, application)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return nil, getApplicationResponseError(resp.StatusCode(), resp.Body())
+	}
+	appDetails := &utils.AppDetails{}
+	if err := json.Unmarshal(resp.Body(), appDetails); err != nil {
+		return nil, err
+	}
+	return appDetails, nil
+}
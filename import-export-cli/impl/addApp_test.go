@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppAttributes(t *testing.T) {
+	attributes, err := ParseAppAttributes([]string{"team=payments", " env =qa", "query=a=b", "empty="})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "payments", "env": "qa", "query": "a=b", "empty": ""}, attributes)
+
+	for _, attribute := range []string{"team", "=payments", " =payments"} {
+		_, err = ParseAppAttributes([]string{attribute})
+		assert.NotNil(t, err, attribute)
+	}
+}
+
+func TestAddApplication(t *testing.T) {
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(body, &request))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"applicationId": "app-1", "name": "MyApp", "throttlingPolicy": "Unlimited"}`))
+	}))
+	defer server.Close()
+
+	app, err := addApplication(server.URL, "token", &ApplicationRequest{
+		Name:             "MyApp",
+		ThrottlingPolicy: "Unlimited",
+		TokenType:        "JWT",
+		Groups:           []string{"g1", "g2"},
+		Attributes:       map[string]string{"team": "payments"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "app-1", app.ApplicationID)
+	assert.Equal(t, "MyApp", request["name"])
+	assert.Equal(t, "JWT", request["tokenType"])
+	assert.Equal(t, []interface{}{"g1", "g2"}, request["groups"])
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, request["attributes"])
+}
+
+func TestAddApplicationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code": 409, "message": "Conflict"}`))
+	}))
+	defer server.Close()
+
+	_, err := addApplication(server.URL, "token", &ApplicationRequest{Name: "MyApp", ThrottlingPolicy: "Unlimited"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "409")
+}
@@ -61,6 +61,12 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		}
 	}
 
+	unlock, err := utils.LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if utils.EnvExistsInMainConfigFile(envName, mainConfigFilePath) {
 		// environment already exists
 		return errors.New("Environment '" + envName + "' already exists in " + mainConfigFilePath)
@@ -96,8 +102,19 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		validatedEnvEndpoints.MiManagementEndpoint = envEndpoints.MiManagementEndpoint
 	}
 
+	if envEndpoints.AnalyticsEndpoint != "" {
+		validatedEnvEndpoints.AnalyticsEndpoint = envEndpoints.AnalyticsEndpoint
+	}
+
+	if envEndpoints.VaultEndpoint != "" {
+		validatedEnvEndpoints.VaultEndpoint = envEndpoints.VaultEndpoint
+	}
+
+	validatedEnvEndpoints.AllowedCommands = envEndpoints.AllowedCommands
+	validatedEnvEndpoints.ReadOnly = envEndpoints.ReadOnly
+
 	mainConfig.Environments[envName] = validatedEnvEndpoints
-	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
+	utils.WriteLockedConfigFile(mainConfig, mainConfigFilePath)
 
 	fmt.Printf("Successfully added environment '%s'\n", envName)
 
@@ -117,6 +134,12 @@ func AddMIEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePa
 			return errors.New("Endpoint(s) cannot be blank")
 		}
 	}
+	unlock, err := utils.LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	mainConfig := utils.GetMainConfigFromFile(mainConfigFilePath)
 
 	var validatedEnvEndpoints = utils.EnvEndpoints{
@@ -127,7 +150,7 @@ func AddMIEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePa
 	}
 
 	mainConfig.Environments[envName] = validatedEnvEndpoints
-	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
+	utils.WriteLockedConfigFile(mainConfig, mainConfigFilePath)
 
 	fmt.Printf("Successfully added environment '%s'\n", envName)
 
@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+)
+
+const (
+	defaultAliasTableFormat = "table {{.Name}}\t{{.Command}}"
+
+	aliasNameHeader    = "NAME"
+	aliasCommandHeader = "COMMAND"
+)
+
+// Alias is an alias along with the command it is expanded to
+type Alias struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// PrintAliases prints the aliases sorted by name
+// @param aliases : Map of alias names to commands
+// @param format : Go template to format the output. A table is printed when empty
+func PrintAliases(aliases map[string]string, format string) {
+	if format == "" {
+		format = defaultAliasTableFormat
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliasContext := formatter.NewContext(os.Stdout, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, name := range names {
+			if err := t.Execute(w, Alias{Name: name, Command: aliases[name]}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	err := aliasContext.Write(renderer, map[string]string{
+		"Name":    aliasNameHeader,
+		"Command": aliasCommandHeader,
+	})
+	if err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// deploymentLookupConcurrency is the maximum number of APIs whose deployments are retrieved in parallel
+const deploymentLookupConcurrency = 10
+
+// apiDeployment holds the deployment of a revision of an API in a gateway environment
+type apiDeployment struct {
+	RevisionID         string `json:"revisionUuid"`
+	Name               string `json:"name"`
+	Vhost              string `json:"vhost"`
+	Status             string `json:"status"`
+	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+	DeployedTime       string `json:"deployedTime"`
+}
+
+type apiDeploymentList struct {
+	List []apiDeployment `json:"list"`
+}
+
+// FilterAPIsDeployedInEnv returns the APIs which have a revision deployed in the given gateway environment
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the APIs
+// @param apis			: APIs to filter
+// @param gatewayEnv	: Gateway environment the APIs should be deployed in
+// @return APIs deployed in the gateway environment in the order they were given
+// @return error
+func FilterAPIsDeployedInEnv(accessToken, environment string, apis []utils.API, gatewayEnv string) ([]utils.API,
+	error) {
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return filterAPIsDeployedIn(accessToken, apiListEndpoint, apis, gatewayEnv)
+}
+
+func filterAPIsDeployedIn(accessToken, apiListEndpoint string, apis []utils.API, gatewayEnv string) ([]utils.API,
+	error) {
+	deployed := make([]bool, len(apis))
+	errs := make([]error, len(apis))
+	limiter := make(chan struct{}, deploymentLookupConcurrency)
+	var wg sync.WaitGroup
+	for i, api := range apis {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func(i int, apiId string) {
+			defer wg.Done()
+			defer func() { <-limiter }()
+			deployments, err := getAPIDeployments(accessToken, apiListEndpoint+"/"+apiId+"/deployments")
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, deployment := range deployments {
+				if deployment.Name == gatewayEnv {
+					deployed[i] = true
+					return
+				}
+			}
+		}(i, api.ID)
+	}
+	wg.Wait()
+
+	filtered := []utils.API{}
+	for i, api := range apis {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("error while retrieving the deployments of %s %s: %v", api.Name, api.Version,
+				errs[i])
+		}
+		if deployed[i] {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered, nil
+}
+
+// getAPIDeployments retrieves the gateway environments the revisions of an API are deployed in
+// @param accessToken			: Access Token for the environment
+// @param deploymentsEndpoint	: Deployments endpoint of the API
+// @return deployments of the API
+// @return error
+func getAPIDeployments(accessToken, deploymentsEndpoint string) ([]apiDeployment, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	utils.Logln(utils.LogPrefixInfo+"URL:", deploymentsEndpoint)
+	resp, err := utils.InvokeGETRequest(deploymentsEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the deployments of the API. Status: " +
+			resp.Status())
+	}
+	deploymentList := &apiDeploymentList{}
+	err = json.Unmarshal(resp.Body(), deploymentList)
+	return deploymentList.List, err
+}
@@ -0,0 +1,77 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestFilterAPIsDeployedIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(utils.HeaderAuthorization) != "Bearer access-token" {
+			t.Errorf("Unexpected authorization header '%s'\n", r.Header.Get(utils.HeaderAuthorization))
+		}
+		w.WriteHeader(http.StatusOK)
+		switch strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/apis/"), "/deployments") {
+		case "pizza":
+			_, _ = w.Write([]byte(`{"list": [{"revisionUuid": "r1", "name": "Default"},
+				{"revisionUuid": "r1", "name": "us-region"}]}`))
+		case "coffee":
+			_, _ = w.Write([]byte(`{"list": [{"revisionUuid": "r2", "name": "Default"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"list": []}`))
+		}
+	}))
+	defer server.Close()
+	apis := []utils.API{
+		{ID: "pizza", Name: "PizzaShackAPI", Version: "1.0.0"},
+		{ID: "tea", Name: "TeaAPI", Version: "1.0.0"},
+		{ID: "coffee", Name: "CoffeeAPI", Version: "1.0.0"},
+	}
+
+	filtered, err := filterAPIsDeployedIn("access-token", server.URL+"/apis", apis, "Default")
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.API{apis[0], apis[2]}, filtered, "Should keep the order of the APIs")
+
+	filtered, err = filterAPIsDeployedIn("access-token", server.URL+"/apis", apis, "us-region")
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.API{apis[0]}, filtered)
+
+	filtered, err = filterAPIsDeployedIn("access-token", server.URL+"/apis", apis, "eu-region")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(filtered))
+}
+
+func TestFilterAPIsDeployedInError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	apis := []utils.API{{ID: "pizza", Name: "PizzaShackAPI", Version: "1.0.0"}}
+
+	_, err := filterAPIsDeployedIn("access-token", server.URL+"/apis", apis, "Default")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "PizzaShackAPI 1.0.0")
+}
@@ -0,0 +1,251 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// Names of the additional properties holding the deprecation metadata of an API
+	apiDeprecationDateProperty    = "deprecationDate"
+	apiSunsetDateProperty         = "sunsetDate"
+	apiSuccessorLinkProperty      = "successorLink"
+	apiAdditionalPropertiesKey    = "additionalProperties"
+	apiAdditionalPropertiesMapKey = "additionalPropertiesMap"
+
+	// Headers injected into the responses of a deprecated API
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+	linkHeader        = "Link"
+
+	addHeaderPolicyName    = "addHeader"
+	addHeaderPolicyVersion = "v1"
+	apiOperationsKey       = "operations"
+	operationPoliciesKey   = "operationPolicies"
+	responsePoliciesKey    = "response"
+)
+
+// APIDeprecation holds the deprecation metadata of an API
+type APIDeprecation struct {
+	// DeprecationDate is the time the API was deprecated
+	DeprecationDate time.Time
+	// SunsetDate is the time after which the API is expected to be unavailable
+	SunsetDate time.Time
+	// SuccessorLink is the link to the API replacing the deprecated API
+	SuccessorLink string
+}
+
+// ParseSunsetDate parses a sunset date given either as a date (eg: 2025-12-31) or as an RFC 3339 time
+func ParseSunsetDate(date string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, errors.New("invalid sunset date " + date + ". The date should be given as " +
+			"YYYY-MM-DD or as an RFC 3339 time")
+	}
+	return t.UTC(), nil
+}
+
+// SetAPIDeprecationFromEnv stores the deprecation metadata of an API as additional properties of the API and, if
+// addHeaderPolicy is true, attaches policies injecting the Deprecation, Sunset and Link headers to the responses
+// of each operation of the API
+// @param accessToken		: Access Token for the environment
+// @param environment		: Environment of the API
+// @param apiName			: Name of the API
+// @param apiVersion		: Version of the API
+// @param provider			: Provider of the API
+// @param sunsetDate		: Time after which the API is expected to be unavailable
+// @param successorLink		: Link to the API replacing the API
+// @param addHeaderPolicy	: Attach the policies injecting the deprecation headers
+// @return deprecation metadata of the API, error
+func SetAPIDeprecationFromEnv(accessToken, environment, apiName, apiVersion, provider string, sunsetDate time.Time,
+	successorLink string, addHeaderPolicy bool) (*APIDeprecation, error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId
+	return setAPIDeprecation(url, accessToken, sunsetDate, successorLink, addHeaderPolicy, time.Now().UTC())
+}
+
+// setAPIDeprecation stores the deprecation metadata of an API. The deprecation date already stored in the API is
+// kept, so that the date the API was deprecated does not move when the metadata is updated. The successor link
+// already stored is kept if no successor link is given.
+// @param url				: URL of the API in the publisher
+// @param accessToken		: Access Token for the environment
+// @param sunsetDate		: Time after which the API is expected to be unavailable
+// @param successorLink		: Link to the API replacing the API
+// @param addHeaderPolicy	: Attach the policies injecting the deprecation headers
+// @param now				: Current time used as the deprecation date if it is not set
+// @return deprecation metadata of the API, error
+func setAPIDeprecation(url, accessToken string, sunsetDate time.Time, successorLink string, addHeaderPolicy bool,
+	now time.Time) (*APIDeprecation, error) {
+	if err := validateSuccessorLink(successorLink); err != nil {
+		return nil, err
+	}
+	api, err := getRESTResource(url, accessToken, "API")
+	if err != nil {
+		return nil, err
+	}
+	deprecation := &APIDeprecation{DeprecationDate: now, SunsetDate: sunsetDate, SuccessorLink: successorLink}
+	if value, ok := getAdditionalProperty(api, apiDeprecationDateProperty); ok {
+		if deprecationDate, err := time.Parse(time.RFC3339, value); err == nil {
+			deprecation.DeprecationDate = deprecationDate
+		}
+	}
+	if successorLink == "" {
+		deprecation.SuccessorLink, _ = getAdditionalProperty(api, apiSuccessorLinkProperty)
+	}
+	setAdditionalProperty(api, apiDeprecationDateProperty, deprecation.DeprecationDate.Format(time.RFC3339))
+	setAdditionalProperty(api, apiSunsetDateProperty, sunsetDate.Format(time.RFC3339))
+	if deprecation.SuccessorLink != "" {
+		setAdditionalProperty(api, apiSuccessorLinkProperty, deprecation.SuccessorLink)
+	}
+	if addHeaderPolicy {
+		setDeprecationHeaderPolicies(api, deprecation)
+	}
+	if err = updateRESTResource(url, accessToken, "API", api); err != nil {
+		return nil, err
+	}
+	return deprecation, nil
+}
+
+// validateSuccessorLink checks whether the successor link is an absolute http(s) URL
+func validateSuccessorLink(link string) error {
+	if link == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid successor link " + link + ". The link should be an absolute http(s) URL")
+	}
+	return nil
+}
+
+// getAdditionalProperty returns the value of an additional property of an API
+func getAdditionalProperty(api map[string]interface{}, name string) (string, bool) {
+	properties, _ := api[apiAdditionalPropertiesKey].([]interface{})
+	for _, item := range properties {
+		property, _ := item.(map[string]interface{})
+		if property != nil && property["name"] == name {
+			value, _ := property["value"].(string)
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// setAdditionalProperty adds or replaces an additional property of an API. The property is not displayed in the
+// Developer Portal. The additional properties map is updated as well when the Publisher returns it, as the
+// Publisher may read the properties from either of them.
+func setAdditionalProperty(api map[string]interface{}, name, value string) {
+	properties, _ := api[apiAdditionalPropertiesKey].([]interface{})
+	found := false
+	for _, item := range properties {
+		property, _ := item.(map[string]interface{})
+		if property != nil && property["name"] == name {
+			property["value"] = value
+			found = true
+		}
+	}
+	if !found {
+		properties = append(properties, map[string]interface{}{"name": name, "value": value, "display": false})
+	}
+	api[apiAdditionalPropertiesKey] = properties
+
+	if propertiesMap, ok := api[apiAdditionalPropertiesMapKey].(map[string]interface{}); ok && len(propertiesMap) > 0 {
+		propertiesMap[name] = map[string]interface{}{"name": name, "value": value, "display": false}
+	}
+}
+
+// setDeprecationHeaderPolicies attaches the policies injecting the deprecation headers to the responses of each
+// operation of an API. The policies previously attached for these headers are replaced.
+func setDeprecationHeaderPolicies(api map[string]interface{}, deprecation *APIDeprecation) {
+	headers := []map[string]interface{}{
+		addHeaderPolicy(deprecationHeader, "@"+strconv.FormatInt(deprecation.DeprecationDate.Unix(), 10)),
+		addHeaderPolicy(sunsetHeader, deprecation.SunsetDate.UTC().Format(http.TimeFormat)),
+	}
+	if deprecation.SuccessorLink != "" {
+		headers = append(headers, addHeaderPolicy(linkHeader,
+			"<"+deprecation.SuccessorLink+">; rel=\"successor-version\""))
+	}
+	operations, _ := api[apiOperationsKey].([]interface{})
+	for _, item := range operations {
+		operation, _ := item.(map[string]interface{})
+		if operation == nil {
+			continue
+		}
+		policies, _ := operation[operationPoliciesKey].(map[string]interface{})
+		if policies == nil {
+			policies = make(map[string]interface{})
+			operation[operationPoliciesKey] = policies
+		}
+		responsePolicies, _ := policies[responsePoliciesKey].([]interface{})
+		retained := make([]interface{}, 0, len(responsePolicies)+len(headers))
+		for _, policy := range responsePolicies {
+			if !isDeprecationHeaderPolicy(policy) {
+				retained = append(retained, policy)
+			}
+		}
+		for _, header := range headers {
+			retained = append(retained, header)
+		}
+		policies[responsePoliciesKey] = retained
+	}
+}
+
+// addHeaderPolicy returns the common policy adding a header to the response
+func addHeaderPolicy(name, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"policyName":    addHeaderPolicyName,
+		"policyVersion": addHeaderPolicyVersion,
+		"parameters": map[string]interface{}{
+			"headerName":  name,
+			"headerValue": value,
+		},
+	}
+}
+
+// isDeprecationHeaderPolicy returns true if the policy adds one of the deprecation headers
+func isDeprecationHeaderPolicy(item interface{}) bool {
+	policy, _ := item.(map[string]interface{})
+	if policy == nil || policy["policyName"] != addHeaderPolicyName {
+		return false
+	}
+	parameters, _ := policy["parameters"].(map[string]interface{})
+	switch parameters["headerName"] {
+	case deprecationHeader, sunsetHeader:
+		return true
+	case linkHeader:
+		// Only the link to the successor is replaced, as the Link header may be used for other relations
+		value, _ := parameters["headerValue"].(string)
+		return strings.Contains(value, `rel="successor-version"`)
+	}
+	return false
+}
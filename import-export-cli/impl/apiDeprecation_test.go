@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const apiDeprecationTestAPI = `{"name": "PetstoreAPI", "version": "1.0.0",
+	"additionalProperties": [
+		{"name": "team", "value": "pets", "display": true},
+		{"name": "deprecationDate", "value": "2024-01-15T00:00:00Z", "display": false}],
+	"operations": [
+		{"target": "/pets", "verb": "GET", "operationPolicies": {"request": [], "response": [
+			{"policyName": "addHeader", "policyVersion": "v1",
+				"parameters": {"headerName": "Sunset", "headerValue": "Sun, 01 Dec 2024 00:00:00 GMT"}},
+			{"policyName": "addHeader", "policyVersion": "v1",
+				"parameters": {"headerName": "Link", "headerValue": "<https://docs.example.com>; rel=\"help\""}}],
+			"fault": []}},
+		{"target": "/pets", "verb": "POST"}]}`
+
+func newAPIDeprecationTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/", http.StatusOK, apiDeprecationTestAPI).
+		handle(http.MethodPut, "/", http.StatusOK, "")
+}
+
+func TestSetAPIDeprecation(t *testing.T) {
+	server := newAPIDeprecationTestServer(t)
+	defer server.Close()
+
+	sunsetDate, err := ParseSunsetDate("2025-06-30")
+	assert.Nil(t, err)
+	deprecation, err := setAPIDeprecation(server.URL, "access-token", sunsetDate, "https://api.example.com/v2",
+		false, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	var updatedAPI map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/", &updatedAPI))
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), deprecation.DeprecationDate,
+		"Deprecation date already stored should be kept")
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "team", "value": "pets", "display": true},
+		map[string]interface{}{"name": "deprecationDate", "value": "2024-01-15T00:00:00Z", "display": false},
+		map[string]interface{}{"name": "sunsetDate", "value": "2025-06-30T00:00:00Z", "display": false},
+		map[string]interface{}{"name": "successorLink", "value": "https://api.example.com/v2", "display": false},
+	}, updatedAPI["additionalProperties"])
+	operation := updatedAPI["operations"].([]interface{})[1].(map[string]interface{})
+	assert.Nil(t, operation["operationPolicies"], "Policies should not be attached unless requested")
+}
+
+func TestSetAPIDeprecationWithHeaderPolicy(t *testing.T) {
+	server := newAPIDeprecationTestServer(t)
+	defer server.Close()
+
+	sunsetDate, _ := ParseSunsetDate("2025-06-30T12:00:00+02:00")
+	_, err := setAPIDeprecation(server.URL, "access-token", sunsetDate, "https://api.example.com/v2", true,
+		time.Now())
+	assert.Nil(t, err)
+	var updatedAPI map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/", &updatedAPI))
+
+	headerPolicies := func(index int) map[string]string {
+		operation := updatedAPI["operations"].([]interface{})[index].(map[string]interface{})
+		policies := operation["operationPolicies"].(map[string]interface{})["response"].([]interface{})
+		headers := make(map[string]string)
+		for _, policy := range policies {
+			parameters := policy.(map[string]interface{})["parameters"].(map[string]interface{})
+			headers[parameters["headerName"].(string)+" "+parameters["headerValue"].(string)] = ""
+		}
+		assert.Len(t, headers, len(policies), "Each header should be added once")
+		return headers
+	}
+	expected := map[string]string{
+		"Deprecation @1705276800":                                    "",
+		"Sunset Mon, 30 Jun 2025 10:00:00 GMT":                       "",
+		`Link <https://api.example.com/v2>; rel="successor-version"`: "",
+	}
+	assert.Equal(t, expected, headerPolicies(1))
+	expected[`Link <https://docs.example.com>; rel="help"`] = ""
+	assert.Equal(t, expected, headerPolicies(0), "Other Link headers should be kept")
+}
+
+func TestSetAPIDeprecationInvalidInput(t *testing.T) {
+	_, err := ParseSunsetDate("30/06/2025")
+	assert.NotNil(t, err)
+
+	_, err = setAPIDeprecation("http://localhost", "access-token", time.Now(), "api/v2", false, time.Now())
+	assert.NotNil(t, err)
+}
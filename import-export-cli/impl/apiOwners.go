@@ -0,0 +1,149 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// Keys of the business information of an API in the Publisher REST API
+	apiBusinessInformationKey = "businessInformation"
+	businessOwnerKey          = "businessOwner"
+	businessOwnerEmailKey     = "businessOwnerEmail"
+	technicalOwnerKey         = "technicalOwner"
+	technicalOwnerEmailKey    = "technicalOwnerEmail"
+)
+
+// APIOwner holds the name and the email of an owner of an API
+type APIOwner struct {
+	Name  string
+	Email string
+}
+
+// String returns the owner in the form "Name <email>"
+func (owner APIOwner) String() string {
+	if owner.Email == "" {
+		return owner.Name
+	}
+	if owner.Name == "" {
+		return owner.Email
+	}
+	return owner.Name + " <" + owner.Email + ">"
+}
+
+// APIBusinessInformation holds the business and technical owners of an API
+type APIBusinessInformation struct {
+	BusinessOwner  APIOwner
+	TechnicalOwner APIOwner
+}
+
+// ParseAPIOwner parses an owner given as an email (eg: jane@example.com), as a name and an email
+// (eg: "Jane Doe <jane@example.com>") or as a name only (eg: "Jane Doe")
+func ParseAPIOwner(owner string) (APIOwner, error) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return APIOwner{}, nil
+	}
+	if address, err := mail.ParseAddress(owner); err == nil {
+		return APIOwner{Name: address.Name, Email: address.Address}, nil
+	}
+	if strings.ContainsAny(owner, "@<>") {
+		return APIOwner{}, errors.New("invalid owner " + owner + ". The owner should be given as an email, " +
+			"as a name or as \"Name <email>\"")
+	}
+	return APIOwner{Name: owner}, nil
+}
+
+// SetAPIOwnersFromEnv updates the business and technical owners in the business information of an API
+// @param accessToken		: Access Token for the environment
+// @param environment		: Environment of the API
+// @param apiName			: Name of the API
+// @param apiVersion		: Version of the API
+// @param provider			: Provider of the API
+// @param businessOwner		: Business owner of the API
+// @param technicalOwner	: Technical owner of the API
+// @return business information of the API, error
+func SetAPIOwnersFromEnv(accessToken, environment, apiName, apiVersion, provider string, businessOwner,
+	technicalOwner APIOwner) (*APIBusinessInformation, error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId
+	return setAPIOwners(url, accessToken, businessOwner, technicalOwner)
+}
+
+// setAPIOwners updates the owners in the business information of an API. Only the names and the emails given are
+// changed, so that an owner can be updated without repeating the details already stored in the API.
+// @param url				: URL of the API in the publisher
+// @param accessToken		: Access Token for the environment
+// @param businessOwner		: Business owner of the API
+// @param technicalOwner	: Technical owner of the API
+// @return business information of the API, error
+func setAPIOwners(url, accessToken string, businessOwner, technicalOwner APIOwner) (*APIBusinessInformation,
+	error) {
+	if businessOwner == (APIOwner{}) && technicalOwner == (APIOwner{}) {
+		return nil, errors.New("either the business owner or the technical owner should be given")
+	}
+	api, err := getRESTResource(url, accessToken, "API")
+	if err != nil {
+		return nil, err
+	}
+	businessInformation, _ := api[apiBusinessInformationKey].(map[string]interface{})
+	if businessInformation == nil {
+		businessInformation = make(map[string]interface{})
+	}
+	setBusinessInformationField(businessInformation, businessOwnerKey, businessOwner.Name)
+	setBusinessInformationField(businessInformation, businessOwnerEmailKey, businessOwner.Email)
+	setBusinessInformationField(businessInformation, technicalOwnerKey, technicalOwner.Name)
+	setBusinessInformationField(businessInformation, technicalOwnerEmailKey, technicalOwner.Email)
+	api[apiBusinessInformationKey] = businessInformation
+	if err = updateRESTResource(url, accessToken, "API", api); err != nil {
+		return nil, err
+	}
+	return &APIBusinessInformation{
+		BusinessOwner: APIOwner{
+			Name:  getBusinessInformationField(businessInformation, businessOwnerKey),
+			Email: getBusinessInformationField(businessInformation, businessOwnerEmailKey),
+		},
+		TechnicalOwner: APIOwner{
+			Name:  getBusinessInformationField(businessInformation, technicalOwnerKey),
+			Email: getBusinessInformationField(businessInformation, technicalOwnerEmailKey),
+		},
+	}, nil
+}
+
+// setBusinessInformationField sets a field of the business information of an API if the value is not empty
+func setBusinessInformationField(businessInformation map[string]interface{}, key, value string) {
+	if value != "" {
+		businessInformation[key] = value
+	}
+}
+
+// getBusinessInformationField returns a field of the business information of an API or an empty string if the
+// field is not set
+func getBusinessInformationField(businessInformation map[string]interface{}, key string) string {
+	value, _ := businessInformation[key].(string)
+	return value
+}
@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const apiOwnersTestAPI = `{"name": "PetstoreAPI", "version": "1.0.0",
+	"businessInformation": {"businessOwner": "Jane Doe", "businessOwnerEmail": "jane@example.com",
+		"technicalOwner": "John Doe", "technicalOwnerEmail": "john@example.com"}}`
+
+func newAPIOwnersTestServer(t *testing.T, api string) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/", http.StatusOK, api).
+		handle(http.MethodPut, "/", http.StatusOK, "")
+}
+
+func TestParseAPIOwner(t *testing.T) {
+	owner, err := ParseAPIOwner("jane@example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, APIOwner{Email: "jane@example.com"}, owner)
+
+	owner, err = ParseAPIOwner("Jane Doe <jane@example.com>")
+	assert.Nil(t, err)
+	assert.Equal(t, APIOwner{Name: "Jane Doe", Email: "jane@example.com"}, owner)
+	assert.Equal(t, "Jane Doe <jane@example.com>", owner.String())
+
+	owner, err = ParseAPIOwner("Jane Doe")
+	assert.Nil(t, err)
+	assert.Equal(t, APIOwner{Name: "Jane Doe"}, owner)
+
+	_, err = ParseAPIOwner("jane@")
+	assert.NotNil(t, err)
+}
+
+func TestSetAPIOwners(t *testing.T) {
+	server := newAPIOwnersTestServer(t, apiOwnersTestAPI)
+	defer server.Close()
+
+	businessInformation, err := setAPIOwners(server.URL, "access-token", APIOwner{Email: "mary@example.com"},
+		APIOwner{})
+	assert.Nil(t, err)
+	var updatedAPI map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/", &updatedAPI))
+	assert.Equal(t, APIOwner{Name: "Jane Doe", Email: "mary@example.com"}, businessInformation.BusinessOwner)
+	assert.Equal(t, APIOwner{Name: "John Doe", Email: "john@example.com"}, businessInformation.TechnicalOwner,
+		"Technical owner should be kept when it is not given")
+	assert.Equal(t, map[string]interface{}{
+		"businessOwner":       "Jane Doe",
+		"businessOwnerEmail":  "mary@example.com",
+		"technicalOwner":      "John Doe",
+		"technicalOwnerEmail": "john@example.com",
+	}, updatedAPI["businessInformation"])
+	assert.Equal(t, "PetstoreAPI", updatedAPI["name"])
+}
+
+func TestSetAPIOwnersWithoutBusinessInformation(t *testing.T) {
+	server := newAPIOwnersTestServer(t, `{"name": "PetstoreAPI", "version": "1.0.0"}`)
+	defer server.Close()
+
+	_, err := setAPIOwners(server.URL, "access-token", APIOwner{},
+		APIOwner{Name: "John Doe", Email: "john@example.com"})
+	assert.Nil(t, err)
+	var updatedAPI map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/", &updatedAPI))
+	assert.Equal(t, map[string]interface{}{
+		"technicalOwner":      "John Doe",
+		"technicalOwnerEmail": "john@example.com",
+	}, updatedAPI["businessInformation"])
+}
+
+func TestSetAPIOwnersWithoutOwners(t *testing.T) {
+	_, err := setAPIOwners("http://localhost", "access-token", APIOwner{}, APIOwner{})
+	assert.NotNil(t, err)
+}
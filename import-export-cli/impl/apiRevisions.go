@@ -0,0 +1,287 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// gatewayEnvVhostSeparator separates the gateway environment and the vhost of a deployment given to the
+// revision commands (ex: us-region=us.api.example.com)
+const gatewayEnvVhostSeparator = "="
+
+// ParseRevisionDeployments creates the deployments of a revision from the gateway environments given to the revision
+// commands. A gateway environment can be followed by the vhost to deploy to (ex: us-region=us.api.example.com).
+// @param gatewayEnvs : Gateway environments, optionally with the vhosts
+// @param displayOnDevportal : Whether the deployments are displayed in the Developer Portal
+// @return deployments, error
+func ParseRevisionDeployments(gatewayEnvs []string, displayOnDevportal bool) ([]utils.Deployment, error) {
+	var deployments []utils.Deployment
+	for _, gatewayEnv := range gatewayEnvs {
+		name, vhost := gatewayEnv, ""
+		if i := strings.Index(gatewayEnv, gatewayEnvVhostSeparator); i >= 0 {
+			name, vhost = gatewayEnv[:i], gatewayEnv[i+1:]
+			if vhost == "" {
+				return nil, errors.New("vhost of the gateway environment " + name + " should not be empty")
+			}
+		}
+		if name == "" {
+			return nil, errors.New("gateway environment of " + gatewayEnv + " should not be empty")
+		}
+		deployments = append(deployments, utils.Deployment{Name: name, Vhost: vhost,
+			DisplayOnDevportal: displayOnDevportal})
+	}
+	return deployments, nil
+}
+
+// DeployAPIRevisionToGateways deploys a revision of an API to gateway environments. The deployments without a vhost
+// are deployed to the first vhost of the gateway environment.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @param revisionNum : Revision number of the API
+// @param deployments : Gateway environments and vhosts to deploy the revision to
+// @return error
+func DeployAPIRevisionToGateways(accessToken, environment, name, version, provider, revisionNum string,
+	deployments []utils.Deployment) error {
+	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
+	if err != nil {
+		return err
+	}
+	publisherEndpoint := utils.AppendSlashToString(utils.GetPublisherEndpointOfEnv(environment,
+		utils.MainConfigFilePath))
+	return deployAPIRevision(accessToken, publisherEndpoint+"apis", publisherEndpoint+"settings", apiId,
+		revisionNum, deployments)
+}
+
+// deployAPIRevision deploys a revision of an API to gateway environments
+// @param accessToken : Access Token for the environment
+// @param apisEndpoint : APIs endpoint of the Publisher
+// @param settingsEndpoint : Settings endpoint of the Publisher
+// @param apiId : ID of the API
+// @param revisionNum : Revision number of the API
+// @param deployments : Gateway environments and vhosts to deploy the revision to
+// @return error
+func deployAPIRevision(accessToken, apisEndpoint, settingsEndpoint, apiId, revisionNum string,
+	deployments []utils.Deployment) error {
+	apiEndpoint := utils.AppendSlashToString(apisEndpoint) + apiId
+	revisionId, err := getAPIRevisionID(accessToken, apiEndpoint+"/revisions", revisionNum)
+	if err != nil {
+		return err
+	}
+	if err = setDefaultVhosts(accessToken, settingsEndpoint, deployments); err != nil {
+		return err
+	}
+	return invokeRevisionOperation(accessToken, apiEndpoint+"/deploy-revision?revisionId="+revisionId,
+		deployments, "deploying")
+}
+
+// deployLatestAPIRevision deploys the latest revision of an API to gateway environments without creating a new
+// revision
+// @param accessToken : Access Token for the environment
+// @param apisEndpoint : APIs endpoint of the Publisher
+// @param settingsEndpoint : Settings endpoint of the Publisher
+// @param apiId : ID of the API
+// @param deployments : Gateway environments and vhosts to deploy the revision to
+// @return deployed revision, error
+func deployLatestAPIRevision(accessToken, apisEndpoint, settingsEndpoint, apiId string,
+	deployments []utils.Deployment) (*utils.Revisions, error) {
+	apiEndpoint := utils.AppendSlashToString(apisEndpoint) + apiId
+	_, revisions, err := GetRevisionsList(accessToken, apiEndpoint+"/revisions")
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, errors.New("the API does not have a revision to deploy")
+	}
+	// Revisions are listed in the order they were created
+	revision := revisions[len(revisions)-1]
+	if err = setDefaultVhosts(accessToken, settingsEndpoint, deployments); err != nil {
+		return nil, err
+	}
+	err = invokeRevisionOperation(accessToken, apiEndpoint+"/deploy-revision?revisionId="+revision.ID,
+		deployments, "deploying")
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// UndeployAPIRevisionFromGateways undeploys a revision of an API from gateway environments, or from all the gateway
+// environments it is deployed to if no gateway environment is given
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @param revisionNum : Revision number of the API
+// @param deployments : Gateway environments and vhosts to undeploy the revision from
+// @return error
+func UndeployAPIRevisionFromGateways(accessToken, environment, name, version, provider, revisionNum string,
+	deployments []utils.Deployment) error {
+	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
+	if err != nil {
+		return err
+	}
+	apisEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return undeployAPIRevision(accessToken, apisEndpoint, apiId, revisionNum, deployments)
+}
+
+// undeployAPIRevision undeploys a revision of an API from gateway environments
+// @param accessToken : Access Token for the environment
+// @param apisEndpoint : APIs endpoint of the Publisher
+// @param apiId : ID of the API
+// @param revisionNum : Revision number of the API
+// @param deployments : Gateway environments and vhosts to undeploy the revision from
+// @return error
+func undeployAPIRevision(accessToken, apisEndpoint, apiId, revisionNum string,
+	deployments []utils.Deployment) error {
+	apiEndpoint := utils.AppendSlashToString(apisEndpoint) + apiId
+	revisionId, err := getAPIRevisionID(accessToken, apiEndpoint+"/revisions", revisionNum)
+	if err != nil {
+		return err
+	}
+	undeployEndpoint := apiEndpoint + "/undeploy-revision?revisionId=" + revisionId
+	if len(deployments) == 0 {
+		undeployEndpoint += "&allEnvironments=true"
+		deployments = []utils.Deployment{}
+	}
+	return invokeRevisionOperation(accessToken, undeployEndpoint, deployments, "undeploying")
+}
+
+// RestoreAPIRevision restores the working copy of an API to a revision
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @param revisionNum : Revision number of the API
+// @return error
+func RestoreAPIRevision(accessToken, environment, name, version, provider, revisionNum string) error {
+	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
+	if err != nil {
+		return err
+	}
+	apisEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return restoreAPIRevision(accessToken, apisEndpoint, apiId, revisionNum)
+}
+
+// restoreAPIRevision restores the working copy of an API to a revision
+// @param accessToken : Access Token for the environment
+// @param apisEndpoint : APIs endpoint of the Publisher
+// @param apiId : ID of the API
+// @param revisionNum : Revision number of the API
+// @return error
+func restoreAPIRevision(accessToken, apisEndpoint, apiId, revisionNum string) error {
+	apiEndpoint := utils.AppendSlashToString(apisEndpoint) + apiId
+	revisionId, err := getAPIRevisionID(accessToken, apiEndpoint+"/revisions", revisionNum)
+	if err != nil {
+		return err
+	}
+	return invokeRevisionOperation(accessToken, apiEndpoint+"/restore-revision?revisionId="+revisionId, nil,
+		"restoring")
+}
+
+// getAPIRevisionID returns the ID of a revision of an API
+// @param accessToken : Access Token for the environment
+// @param revisionsEndpoint : Revisions endpoint of the API
+// @param revisionNum : Revision number of the API
+// @return ID of the revision, error
+func getAPIRevisionID(accessToken, revisionsEndpoint, revisionNum string) (string, error) {
+	_, revisions, err := GetRevisionsList(accessToken, revisionsEndpoint)
+	if err != nil {
+		return "", err
+	}
+	for _, revision := range revisions {
+		if utils.GetRevisionNumFromRevisionName(revision.RevisionNumber) == revisionNum {
+			return revision.ID, nil
+		}
+	}
+	return "", errors.New("revision " + revisionNum + " of the API is not found")
+}
+
+// setDefaultVhosts sets the first vhost of the gateway environment as the vhost of the deployments without a vhost
+// @param accessToken : Access Token for the environment
+// @param settingsEndpoint : Settings endpoint of the Publisher
+// @param deployments : Deployments of a revision
+// @return error
+func setDefaultVhosts(accessToken, settingsEndpoint string, deployments []utils.Deployment) error {
+	var settings *publisherSettings
+	for i := range deployments {
+		if deployments[i].Vhost != "" {
+			continue
+		}
+		if settings == nil {
+			var err error
+			if settings, err = getPublisherSettings(accessToken, settingsEndpoint); err != nil {
+				return err
+			}
+		}
+		found := false
+		for _, gatewayEnv := range settings.Environments {
+			if gatewayEnv.Name == deployments[i].Name && len(gatewayEnv.Vhosts) > 0 {
+				deployments[i].Vhost = gatewayEnv.Vhosts[0].Host
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("gateway environment " + deployments[i].Name + " is not found")
+		}
+	}
+	return nil
+}
+
+// invokeRevisionOperation invokes an operation (deploy, undeploy or restore) on a revision of an API
+// @param accessToken : Access Token for the environment
+// @param url : URL of the operation
+// @param deployments : Deployments sent as the body of the request, if any
+// @param operation : Name of the operation used in the error messages
+// @return error
+func invokeRevisionOperation(accessToken, url string, deployments []utils.Deployment, operation string) error {
+	utils.Logln(utils.LogPrefixInfo+"URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	body := ""
+	if deployments != nil {
+		content, err := json.Marshal(deployments)
+		if err != nil {
+			return err
+		}
+		body = string(content)
+	}
+	resp, err := utils.InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return err
+	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("error while %s the revision: %s %s", operation, resp.Status(), string(resp.Body()))
+	}
+	return nil
+}
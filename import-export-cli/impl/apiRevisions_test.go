@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func newRevisionsTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/apis/api-1/revisions", http.StatusOK, `{"count": 2, "list": [
+			{"id": "rev-1", "displayName": "Revision 1"}, {"id": "rev-2", "displayName": "Revision 2"}]}`).
+		handle(http.MethodGet, "/settings", http.StatusOK, `{"environment": [
+			{"name": "Default", "vhosts": [{"host": "localhost"}]},
+			{"name": "us-region", "vhosts": [{"host": "us.wso2.com"}, {"host": "api.example.com"}]}]}`).
+		handle(http.MethodPost, "/apis/api-1/deploy-revision", http.StatusCreated, "").
+		handle(http.MethodPost, "/apis/api-1/undeploy-revision", http.StatusCreated, "").
+		handle(http.MethodPost, "/apis/api-1/restore-revision", http.StatusCreated, "")
+}
+
+func TestParseRevisionDeployments(t *testing.T) {
+	deployments, err := ParseRevisionDeployments([]string{"Default", "us-region=api.example.com"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.Deployment{
+		{Name: "Default", DisplayOnDevportal: true},
+		{Name: "us-region", Vhost: "api.example.com", DisplayOnDevportal: true},
+	}, deployments)
+
+	_, err = ParseRevisionDeployments([]string{"us-region="}, true)
+	assert.EqualError(t, err, "vhost of the gateway environment us-region should not be empty")
+}
+
+func TestDeployAPIRevision(t *testing.T) {
+	server := newRevisionsTestServer(t)
+	defer server.Close()
+
+	err := deployAPIRevision("access-token", server.URL+"/apis", server.URL+"/settings", "api-1", "2",
+		[]utils.Deployment{{Name: "Default"}, {Name: "us-region", Vhost: "api.example.com"}})
+	assert.Nil(t, err)
+	requests := server.requestsTo(http.MethodPost, "/apis/api-1/deploy-revision")
+	assert.Len(t, requests, 1)
+	assert.Equal(t, url.Values{"revisionId": {"rev-2"}}, requests[0].query)
+	var deployments []utils.Deployment
+	assert.Nil(t, json.Unmarshal(requests[0].body, &deployments))
+	assert.Equal(t, []utils.Deployment{{Name: "Default", Vhost: "localhost"},
+		{Name: "us-region", Vhost: "api.example.com"}}, deployments,
+		"The deployments without a vhost should be deployed to the first vhost of the gateway environment")
+
+	err = deployAPIRevision("access-token", server.URL+"/apis", server.URL+"/settings", "api-1", "2",
+		[]utils.Deployment{{Name: "eu-region"}})
+	assert.EqualError(t, err, "gateway environment eu-region is not found")
+
+	err = deployAPIRevision("access-token", server.URL+"/apis", server.URL+"/settings", "api-1", "3",
+		[]utils.Deployment{{Name: "Default"}})
+	assert.EqualError(t, err, "revision 3 of the API is not found")
+}
+
+func TestDeployLatestAPIRevision(t *testing.T) {
+	server := newRevisionsTestServer(t)
+	defer server.Close()
+
+	revision, err := deployLatestAPIRevision("access-token", server.URL+"/apis", server.URL+"/settings", "api-1",
+		[]utils.Deployment{{Name: "us-region"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "rev-2", revision.ID, "The latest revision should be deployed")
+	requests := server.requestsTo(http.MethodPost, "/apis/api-1/deploy-revision")
+	assert.Len(t, requests, 1)
+	assert.Equal(t, url.Values{"revisionId": {"rev-2"}}, requests[0].query)
+	var deployments []utils.Deployment
+	assert.Nil(t, json.Unmarshal(requests[0].body, &deployments))
+	assert.Equal(t, []utils.Deployment{{Name: "us-region", Vhost: "us.wso2.com"}}, deployments)
+}
+
+func TestUndeployAndRestoreAPIRevision(t *testing.T) {
+	server := newRevisionsTestServer(t)
+	defer server.Close()
+
+	assert.Nil(t, undeployAPIRevision("access-token", server.URL+"/apis", "api-1", "1", nil))
+	assert.Nil(t, undeployAPIRevision("access-token", server.URL+"/apis", "api-1", "1",
+		[]utils.Deployment{{Name: "us-region", Vhost: "api.example.com"}}))
+	assert.Nil(t, restoreAPIRevision("access-token", server.URL+"/apis", "api-1", "1"))
+
+	requests := server.requestsTo(http.MethodPost, "/apis/api-1/undeploy-revision")
+	assert.Len(t, requests, 2)
+	assert.Equal(t, url.Values{"revisionId": {"rev-1"}, "allEnvironments": {"true"}}, requests[0].query)
+	assert.JSONEq(t, "[]", string(requests[0].body))
+	assert.Equal(t, url.Values{"revisionId": {"rev-1"}}, requests[1].query)
+	var deployments []utils.Deployment
+	assert.Nil(t, json.Unmarshal(requests[1].body, &deployments))
+	assert.Equal(t, []utils.Deployment{{Name: "us-region", Vhost: "api.example.com"}}, deployments)
+
+	requests = server.requestsTo(http.MethodPost, "/apis/api-1/restore-revision")
+	assert.Len(t, requests, 1)
+	assert.Equal(t, url.Values{"revisionId": {"rev-1"}}, requests[0].query)
+	assert.Empty(t, requests[0].body)
+}
@@ -0,0 +1,119 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const apiThumbnailResource = "thumbnail"
+
+// SetAPIThumbnailInEnv uploads the given image as the thumbnail of an API, without re-importing the API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @param filePath		: Path to the image file
+// @return error
+func SetAPIThumbnailInEnv(accessToken, environment, apiName, apiVersion, provider, filePath string) error {
+	if err := validateThumbnailFile(filePath); err != nil {
+		return err
+	}
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId + "/" +
+		apiThumbnailResource
+	return setAPIThumbnail(url, accessToken, filePath)
+}
+
+func setAPIThumbnail(url, accessToken, filePath string) error {
+	utils.Logln(utils.LogPrefixInfo+"SetAPIThumbnail: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	resp, err := utils.InvokePUTRequestWithFile(url, headers, "file", filePath)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for setting the thumbnail of the API. Status: " +
+			resp.Status())
+	}
+	return nil
+}
+
+// validateThumbnailFile checks whether the file exists and is of a type supported as an API thumbnail
+func validateThumbnailFile(filePath string) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory, not an image file", filePath)
+	}
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), "."))
+	for _, supportedExtension := range utils.ThumbnailFileExtensions {
+		if extension == supportedExtension {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported thumbnail %s. Supported file types are %s", filepath.Base(filePath),
+		strings.Join(utils.ThumbnailFileExtensions, ", "))
+}
+
+// validateProjectThumbnail checks the Image directory of an API project, so that an invalid thumbnail is
+// reported before the project is imported. Hidden files in the directory are ignored.
+func validateProjectThumbnail(projectPath string) error {
+	imageDirectory := filepath.Join(projectPath, utils.InitProjectImage)
+	files, err := ioutil.ReadDir(imageDirectory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var thumbnails []string
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		if err := validateThumbnailFile(filepath.Join(imageDirectory, file.Name())); err != nil {
+			return err
+		}
+		thumbnails = append(thumbnails, file.Name())
+	}
+	if len(thumbnails) > 1 {
+		return fmt.Errorf("only one thumbnail is allowed in the %s directory, but found %s", utils.InitProjectImage,
+			strings.Join(thumbnails, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestValidateProjectThumbnail(t *testing.T) {
+	projectPath := t.TempDir()
+	assert.Nil(t, validateProjectThumbnail(projectPath), "Projects without an Image directory should be valid")
+
+	imageDirectory := filepath.Join(projectPath, utils.InitProjectImage)
+	assert.Nil(t, os.Mkdir(imageDirectory, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(imageDirectory, "icon.PNG"), []byte("png"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(imageDirectory, ".DS_Store"), []byte{}, 0644))
+	assert.Nil(t, validateProjectThumbnail(projectPath), "Hidden files should be ignored")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(imageDirectory, "icon.jpeg"), []byte("jpeg"), 0644))
+	err := validateProjectThumbnail(projectPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only one thumbnail is allowed")
+
+	assert.Nil(t, os.Remove(filepath.Join(imageDirectory, "icon.jpeg")))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(imageDirectory, "icon.bmp"), []byte("bmp"), 0644))
+	err = validateProjectThumbnail(projectPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported thumbnail icon.bmp")
+}
+
+func TestSetAPIThumbnail(t *testing.T) {
+	thumbnailPath := filepath.Join(t.TempDir(), "logo.png")
+	assert.Nil(t, ioutil.WriteFile(thumbnailPath, []byte("png content"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/apis/123/thumbnail", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get(utils.HeaderAuthorization))
+		file, header, err := r.FormFile("file")
+		assert.Nil(t, err)
+		defer file.Close()
+		content, _ := ioutil.ReadAll(file)
+		assert.Equal(t, "logo.png", header.Filename)
+		assert.Equal(t, "png content", string(content))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	assert.Nil(t, setAPIThumbnail(server.URL+"/apis/123/thumbnail", "token", thumbnailPath))
+
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer failingServer.Close()
+	assert.Error(t, setAPIThumbnail(failingServer.URL+"/apis/123/thumbnail", "token", thumbnailPath))
+}
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// publisherSettings holds the fields of the Publisher settings response used to detect the APIM version, to
+// resolve the gateway URLs and to display the settings of an environment
+type publisherSettings struct {
+	APIMVersion  string               `json:"apimVersion"`
+	Version      string               `json:"version"`
+	Environments []gatewayEnvironment `json:"environment"`
+	GatewayTypes []string             `json:"gatewayTypes"`
+	Scopes       []string             `json:"scopes"`
+}
+
+// gatewayEnvironment is a gateway environment advertised by the Publisher settings
+type gatewayEnvironment struct {
+	Name   string         `json:"name"`
+	Vhosts []gatewayVhost `json:"vhosts"`
+}
+
+// gatewayVhost is a virtual host of a gateway environment
+type gatewayVhost struct {
+	Host        string `json:"host"`
+	HTTPContext string `json:"httpContext"`
+	HTTPSPort   int    `json:"httpsPort"`
+}
+
+// GetAPIMVersionOfEnv probes the Publisher settings of the environment to detect the APIM version
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to be probed
+// @return APIM version in "vX.Y.Z" form or an empty string if the version is not advertised, error
+func GetAPIMVersionOfEnv(accessToken, environment string) (string, error) {
+	settings, err := getPublisherSettings(accessToken,
+		utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath)+"/settings")
+	if err != nil {
+		return "", err
+	}
+	version := settings.APIMVersion
+	if version == "" {
+		version = settings.Version
+	}
+	if version == "" {
+		utils.Logln(utils.LogPrefixInfo + "APIM version is not advertised by the Publisher settings of " + environment)
+		return "", nil
+	}
+	return utils.NormalizeAPIMVersion(version)
+}
+
+// getPublisherSettings retrieves the Publisher settings
+// @param accessToken		: Access Token for the environment
+// @param settingsEndpoint	: Publisher settings endpoint
+// @return Publisher settings, error
+func getPublisherSettings(accessToken, settingsEndpoint string) (*publisherSettings, error) {
+	settings := &publisherSettings{}
+	if err := getSettings(accessToken, settingsEndpoint, "Publisher", settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
@@ -23,8 +23,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -112,25 +115,47 @@ func GetAPIDefinition(filePath string) (*v2.APIDefinitionFile, []byte, error) {
 // @return array of API objects
 // @return error
 func GetAPIList(accessToken, apiListEndpoint, query, limit string) (count int32, apis []utils.API, err error) {
-	queryParamAdded := false
-	getQueryParamConnector := func() (connector string) {
-		if queryParamAdded {
-			return "&"
-		} else {
-			return ""
-		}
-	}
+	return SearchAPIs(accessToken, apiListEndpoint, APISearchOptions{Query: query, Limit: limit})
+}
+
+// APISearchOptions holds the search, paging and sorting options of the API list of the Publisher
+type APISearchOptions struct {
+	// Query of the Publisher search API (ex: "tag:public status:PUBLISHED")
+	Query string
+	// Limit is the maximum number of APIs to return
+	Limit string
+	// Offset is the number of APIs to skip
+	Offset string
+	// SortBy is the field to sort the APIs by (name, version, createdTime or status)
+	SortBy string
+	// SortOrder is the order to sort the APIs in (asc or desc)
+	SortOrder string
+}
+
+// apiSortByFields maps the fields the APIs can be sorted by to the values of the sortBy parameter of the Publisher
+var apiSortByFields = map[string]string{
+	"name":        "apiName",
+	"version":     "version",
+	"createdTime": "createdTime",
+	"status":      "status",
+}
 
+// SearchAPIs Get the list of APIs matching the search options in a particular environment
+// @param accessToken : Access Token for the environment
+// @param apiListEndpoint : API List endpoint
+// @param options : search, paging and sorting options
+// @return count (no. of APIs)
+// @return array of API objects
+// @return error
+func SearchAPIs(accessToken, apiListEndpoint string, options APISearchOptions) (count int32, apis []utils.API,
+	err error) {
+	queryParams, err := getAPISearchQueryParams(options)
+	if err != nil {
+		return 0, nil, err
+	}
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
-	var queryParamSring string
-	if query != "" {
-		queryParamSring = "query=" + query
-		queryParamAdded = true
-	}
-	if limit != "" {
-		queryParamSring += getQueryParamConnector() + "limit=" + limit
-	}
+	queryParamSring := queryParams.Encode()
 	utils.Logln(utils.LogPrefixInfo+"URL:", apiListEndpoint+"?"+queryParamSring)
 	resp, err := utils.InvokeGETRequestWithQueryParamsString(apiListEndpoint, queryParamSring, headers)
 
@@ -154,6 +179,41 @@ func GetAPIList(accessToken, apiListEndpoint, query, limit string) (count int32,
 	}
 }
 
+// getAPISearchQueryParams validates the search options and converts them to the query parameters of the Publisher
+// @param options : search, paging and sorting options
+// @return query parameters, error
+func getAPISearchQueryParams(options APISearchOptions) (url.Values, error) {
+	queryParams := url.Values{}
+	if options.Query != "" {
+		queryParams.Set("query", options.Query)
+	}
+	for name, value := range map[string]string{"limit": options.Limit, "offset": options.Offset} {
+		if value == "" {
+			continue
+		}
+		if number, err := strconv.Atoi(value); err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid %s %s, it should be a non-negative number", name, value)
+		}
+		queryParams.Set(name, value)
+	}
+	if options.SortBy != "" {
+		sortBy, found := apiSortByFields[options.SortBy]
+		if !found {
+			return nil, fmt.Errorf("invalid sort field %s, use one of name, version, createdTime or status",
+				options.SortBy)
+		}
+		queryParams.Set("sortBy", sortBy)
+	}
+	if options.SortOrder != "" {
+		sortOrder := strings.ToLower(options.SortOrder)
+		if sortOrder != "asc" && sortOrder != "desc" {
+			return nil, fmt.Errorf("invalid sort order %s, use asc or desc", options.SortOrder)
+		}
+		queryParams.Set("sortOrder", sortOrder)
+	}
+	return queryParams, nil
+}
+
 // GetRevisionsList Get the list of Revisions available for the given API
 // @param accessToken 			: Access Token for the environment
 // @param revisionListEndpoint 	: Revision List endpoint
@@ -0,0 +1,218 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// ApplicationKeyMapping represents the OAuth settings of the keys generated for an application from a key manager
+type ApplicationKeyMapping struct {
+	KeyMappingID         string                 `json:"keyMappingId,omitempty" yaml:"-"`
+	KeyManager           string                 `json:"keyManager" yaml:"keyManager"`
+	KeyType              string                 `json:"keyType" yaml:"keyType"`
+	ConsumerKey          string                 `json:"consumerKey,omitempty" yaml:"consumerKey,omitempty"`
+	SupportedGrantTypes  []string               `json:"supportedGrantTypes" yaml:"supportedGrantTypes"`
+	CallbackURL          string                 `json:"callbackUrl,omitempty" yaml:"callbackUrl,omitempty"`
+	AdditionalProperties map[string]interface{} `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+}
+
+type applicationKeyMappingList struct {
+	Count int                     `json:"count"`
+	List  []ApplicationKeyMapping `json:"list"`
+}
+
+type applicationKeyMappingsFile struct {
+	KeyMappings []ApplicationKeyMapping `yaml:"keyMappings"`
+}
+
+// GetApplicationKeyMappingsFromEnv returns the key mappings of an application, including the grant types, the
+// callback URL and the key manager specific properties of each key mapping
+// @param accessToken : Access Token for the resource
+// @param environment : Environment of the application
+// @param name : Name of the application
+// @param owner : Owner of the application
+func GetApplicationKeyMappingsFromEnv(accessToken, environment, name, owner string) ([]ApplicationKeyMapping, error) {
+	devportalApplicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	appId, err := getApplicationIdByName(devportalApplicationsEndpoint, name, owner, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return getApplicationKeyMappings(devportalApplicationsEndpoint, appId, accessToken)
+}
+
+// includeApplicationKeyMappingsToZip writes the key mappings to the key mappings file inside the application archive
+func includeApplicationKeyMappingsToZip(zipFile string, keyMappings []ApplicationKeyMapping) error {
+	tmpClonedLoc, err := utils.GetTempCloneFromDirOrZip(zipFile)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpClonedLoc))
+
+	content, err := yaml.Marshal(applicationKeyMappingsFile{KeyMappings: keyMappings})
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(tmpClonedLoc, utils.ApplicationKeyMappingsFile), content, 0644)
+	if err != nil {
+		return err
+	}
+	return utils.Zip(tmpClonedLoc, zipFile)
+}
+
+// restoreApplicationKeyMappings restores the grant types, the callback URL and the key manager specific properties
+// of the key mappings exported with an application, to the key mappings of the imported application
+// @param devportalApplicationsEndpoint : Dev Portal Applications Endpoint for the environment
+// @param applicationFilePath : Path of the imported application archive or directory
+// @param accessToken : Access Token for the resource
+func restoreApplicationKeyMappings(devportalApplicationsEndpoint, applicationFilePath, accessToken string) error {
+	applicationPath, err := utils.GetTempCloneFromDirOrZip(applicationFilePath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(applicationPath))
+
+	keyMappingsFilePath := filepath.Join(applicationPath, utils.ApplicationKeyMappingsFile)
+	if _, err := os.Stat(keyMappingsFilePath); os.IsNotExist(err) {
+		utils.Logln(utils.LogPrefixInfo + "No key mappings to restore in " + applicationFilePath)
+		return nil
+	}
+	content, err := ioutil.ReadFile(keyMappingsFilePath)
+	if err != nil {
+		return err
+	}
+	exported := &applicationKeyMappingsFile{}
+	if err = yaml.Unmarshal(content, exported); err != nil {
+		return err
+	}
+	if len(exported.KeyMappings) == 0 {
+		return nil
+	}
+
+	metaData, err := LoadMetaInfoFromFile(filepath.Join(applicationPath, utils.MetaFileApplication))
+	if err != nil {
+		return err
+	}
+	appId, err := getApplicationIdByName(devportalApplicationsEndpoint, metaData.Name, "", accessToken)
+	if err != nil {
+		return err
+	}
+	imported, err := getApplicationKeyMappings(devportalApplicationsEndpoint, appId, accessToken)
+	if err != nil {
+		return err
+	}
+
+	for _, keyMapping := range exported.KeyMappings {
+		target := findApplicationKeyMapping(imported, keyMapping.KeyManager, keyMapping.KeyType)
+		if target == nil {
+			fmt.Printf("Skipped restoring the %s keys of key manager %s as they were not imported\n",
+				keyMapping.KeyType, keyMapping.KeyManager)
+			continue
+		}
+		keyMapping.KeyMappingID = target.KeyMappingID
+		keyMapping.ConsumerKey = target.ConsumerKey
+		if err = updateApplicationKeyMapping(devportalApplicationsEndpoint, appId, keyMapping, accessToken); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Successfully restored the key mappings of the Application.")
+	return nil
+}
+
+func findApplicationKeyMapping(keyMappings []ApplicationKeyMapping, keyManager, keyType string) *ApplicationKeyMapping {
+	for i := range keyMappings {
+		if keyMappings[i].KeyManager == keyManager && keyMappings[i].KeyType == keyType {
+			return &keyMappings[i]
+		}
+	}
+	return nil
+}
+
+// getApplicationIdByName returns the ID of the application with the given name. The owner is matched as well
+// when it is given
+func getApplicationIdByName(devportalApplicationsEndpoint, name, owner, accessToken string) (string, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequestWithQueryParam("query", name, devportalApplicationsEndpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New("Error while searching the application " + name + ". Status: " + resp.Status())
+	}
+	appList := &utils.AppList{}
+	if err = json.Unmarshal(resp.Body(), appList); err != nil {
+		return "", err
+	}
+	for _, app := range appList.List {
+		if app.Name == name && (owner == "" || app.Owner == owner) {
+			return app.ApplicationID, nil
+		}
+	}
+	return "", errors.New("Application " + name + " not found")
+}
+
+func getApplicationKeyMappings(devportalApplicationsEndpoint, appId, accessToken string) ([]ApplicationKeyMapping,
+	error) {
+	url := utils.AppendSlashToString(devportalApplicationsEndpoint) + appId + "/oauth-keys"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("Error while retrieving the keys of the application. Status: " + resp.Status())
+	}
+	keyMappings := &applicationKeyMappingList{}
+	if err = json.Unmarshal(resp.Body(), keyMappings); err != nil {
+		return nil, err
+	}
+	return keyMappings.List, nil
+}
+
+func updateApplicationKeyMapping(devportalApplicationsEndpoint, appId string, keyMapping ApplicationKeyMapping,
+	accessToken string) error {
+	url := utils.AppendSlashToString(devportalApplicationsEndpoint) + appId + "/oauth-keys/" + keyMapping.KeyMappingID
+	body, err := json.Marshal(keyMapping)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	resp, err := utils.InvokePutRequest(nil, url, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Error while restoring the " + keyMapping.KeyType + " keys of key manager " +
+			keyMapping.KeyManager + ". Status: " + resp.Status())
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+func createApplicationProject(t *testing.T, keyMappings []ApplicationKeyMapping) string {
+	projectPath := filepath.Join(t.TempDir(), "admin_SampleApp")
+	assert.Nil(t, os.Mkdir(projectPath, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.MetaFileApplication),
+		[]byte("name: SampleApp\nowner: admin\n"), 0644))
+	if keyMappings != nil {
+		content, err := yaml.Marshal(applicationKeyMappingsFile{KeyMappings: keyMappings})
+		assert.Nil(t, err)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.ApplicationKeyMappingsFile), content, 0644))
+	}
+	return projectPath
+}
+
+func TestRestoreApplicationKeyMappings(t *testing.T) {
+	projectPath := createApplicationProject(t, []ApplicationKeyMapping{
+		{
+			KeyManager:           "Resident Key Manager",
+			KeyType:              "PRODUCTION",
+			SupportedGrantTypes:  []string{"password", "authorization_code"},
+			CallbackURL:          "https://sample.app/callback",
+			AdditionalProperties: map[string]interface{}{"application_access_token_expiry_time": "3600"},
+		},
+		{
+			KeyManager:          "Okta",
+			KeyType:             "SANDBOX",
+			SupportedGrantTypes: []string{"client_credentials"},
+		},
+	})
+
+	var updated ApplicationKeyMapping
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/applications":
+			assert.Equal(t, "SampleApp", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count": 2, "list": [{"applicationId": "other-app", "name": "SampleApp2"},
+				{"applicationId": "app-id", "name": "SampleApp"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/applications/app-id/oauth-keys":
+			_, _ = w.Write([]byte(`{"count": 1, "list": [{"keyMappingId": "mapping-id",
+				"keyManager": "Resident Key Manager", "keyType": "PRODUCTION", "consumerKey": "new-consumer-key",
+				"supportedGrantTypes": ["client_credentials"]}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/applications/app-id/oauth-keys/mapping-id":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Nil(t, json.Unmarshal(body, &updated))
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request '%s %s'\n", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	err := restoreApplicationKeyMappings(server.URL+"/applications", projectPath, "access-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "mapping-id", updated.KeyMappingID)
+	assert.Equal(t, "new-consumer-key", updated.ConsumerKey, "Consumer key of the imported keys should be kept")
+	assert.Equal(t, []string{"password", "authorization_code"}, updated.SupportedGrantTypes)
+	assert.Equal(t, "https://sample.app/callback", updated.CallbackURL)
+	assert.Equal(t, "3600", updated.AdditionalProperties["application_access_token_expiry_time"])
+}
+
+func TestRestoreApplicationKeyMappingsWithoutKeyMappingsFile(t *testing.T) {
+	projectPath := createApplicationProject(t, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request '%s %s'\n", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	err := restoreApplicationKeyMappings(server.URL+"/applications", projectPath, "access-token")
+	assert.Nil(t, err)
+}
+
+func TestIncludeApplicationKeyMappingsToZip(t *testing.T) {
+	projectPath := createApplicationProject(t, nil)
+	zipFile := filepath.Join(t.TempDir(), "admin_SampleApp.zip")
+	assert.Nil(t, utils.Zip(projectPath, zipFile))
+
+	keyMappings := []ApplicationKeyMapping{{
+		KeyMappingID:        "mapping-id",
+		KeyManager:          "Resident Key Manager",
+		KeyType:             "PRODUCTION",
+		SupportedGrantTypes: []string{"refresh_token"},
+	}}
+	assert.Nil(t, includeApplicationKeyMappingsToZip(zipFile, keyMappings))
+
+	extracted, err := utils.GetTempCloneFromDirOrZip(zipFile)
+	assert.Nil(t, err)
+	defer os.RemoveAll(filepath.Dir(extracted))
+	content, err := ioutil.ReadFile(filepath.Join(extracted, utils.ApplicationKeyMappingsFile))
+	assert.Nil(t, err)
+	exported := &applicationKeyMappingsFile{}
+	assert.Nil(t, yaml.Unmarshal(content, exported))
+	assert.Equal(t, "Resident Key Manager", exported.KeyMappings[0].KeyManager)
+	assert.Equal(t, []string{"refresh_token"}, exported.KeyMappings[0].SupportedGrantTypes)
+	assert.Empty(t, exported.KeyMappings[0].KeyMappingID, "Key mapping IDs are specific to an environment")
+	_, err = os.Stat(filepath.Join(extracted, utils.MetaFileApplication))
+	assert.Nil(t, err, "Existing files of the application should be kept")
+}
@@ -142,3 +142,34 @@ func GetApplicationList(accessToken, applicationListEndpoint, appOwner, limit st
 		return 0, nil, errors.New(resp.Status())
 	}
 }
+
+// getDevPortalAppId returns the ID of the application of the user with the given name using the Developer Portal
+// REST API
+// @param applicationsEndpoint : Developer Portal applications endpoint of the environment
+// @param accessToken : Access Token for the environment
+// @param appName : Name of the application
+// @return appId, error
+func getDevPortalAppId(applicationsEndpoint, accessToken, appName string) (string, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequestWithQueryParam("query", appName, applicationsEndpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Body: %s\n", resp.Body())
+		return "", errors.New("Request didn't respond 200 OK for searching the application " + appName +
+			". Status: " + resp.Status())
+	}
+	apps := &utils.AppList{}
+	if err := json.Unmarshal(resp.Body(), apps); err != nil {
+		return "", err
+	}
+	// The search matches the names partially, hence the exact match is picked
+	for _, app := range apps.List {
+		if app.Name == appName {
+			return app.ApplicationID, nil
+		}
+	}
+	return "", errors.New("cannot find the application " + appName)
+}
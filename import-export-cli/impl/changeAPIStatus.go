@@ -19,10 +19,41 @@
 package impl
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
 )
 
+// apiStatusChangeSearchLimit is the page size used when searching the APIs to change the status of
+const apiStatusChangeSearchLimit = 100
+
+// APIStatusChangeTarget identifies an API whose lifecycle status is to be changed
+type APIStatusChangeTarget struct {
+	ID       string `yaml:"-"`
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+	Provider string `yaml:"provider"`
+}
+
+// APIStatusChangeResult is the outcome of changing the lifecycle status of one of the APIs of a batch
+type APIStatusChangeResult struct {
+	Id             string `json:"id,omitempty"`
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	Provider       string `json:"provider,omitempty"`
+	Action         string `json:"action"`
+	State          string `json:"state,omitempty"`
+	WorkflowStatus string `json:"workflowStatus,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
 // ChangeAPIStatusInEnv function is used with change-status api command
 func ChangeAPIStatusInEnv(accessToken, environment, stateChangeAction, name, version, provider string) (*resty.Response, error) {
 	changeAPIStatusEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
@@ -39,11 +70,21 @@ func ChangeAPIStatusInEnv(accessToken, environment, stateChangeAction, name, ver
 // @param accessToken : Access Token for the resource
 // @return response Response in the form of *resty.Response
 func changeAPIStatus(changeAPIStatusEndpoint, stateChangeAction, name, version, provider, environment, accessToken string) (*resty.Response, error) {
-	changeAPIStatusEndpoint = utils.AppendSlashToString(changeAPIStatusEndpoint)
 	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting API Id for state change ", err)
 	}
+	return changeAPIStatusById(changeAPIStatusEndpoint, stateChangeAction, apiId, accessToken)
+}
+
+// changeAPIStatusById changes the lifecycle status of the API with the given id
+// @param changeAPIStatusEndpoint : API Manager Publisher REST API Endpoint for the environment
+// @param stateChangeAction : Action to be performed to change the state of the API
+// @param apiId : Id of the API
+// @param accessToken : Access Token for the resource
+// @return response Response in the form of *resty.Response
+func changeAPIStatusById(changeAPIStatusEndpoint, stateChangeAction, apiId, accessToken string) (*resty.Response, error) {
+	changeAPIStatusEndpoint = utils.AppendSlashToString(changeAPIStatusEndpoint)
 	url := changeAPIStatusEndpoint + "change-lifecycle"
 	utils.Logln(utils.LogPrefixInfo+"APIStateChange: URL:", url)
 
@@ -61,3 +102,147 @@ func changeAPIStatus(changeAPIStatusEndpoint, stateChangeAction, name, version,
 	}
 	return resp, nil
 }
+
+// ReadAPIStatusChangeTargets reads the APIs to change the status of from a YAML file listing the name, the version
+// and optionally the provider of each API
+// @param filePath : Path of the file
+// @return APIs to change the status of, error
+func ReadAPIStatusChangeTargets(filePath string) ([]APIStatusChangeTarget, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var targets []APIStatusChangeTarget
+	if err = yaml.Unmarshal(content, &targets); err != nil {
+		return nil, fmt.Errorf("invalid API list %s: %w", filePath, err)
+	}
+	for i, target := range targets {
+		if target.Name == "" || target.Version == "" {
+			return nil, fmt.Errorf("invalid API list %s: the name and the version of API %d are required",
+				filePath, i+1)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no APIs are listed in " + filePath)
+	}
+	return targets, nil
+}
+
+// SearchAPIStatusChangeTargets finds the APIs matching a search query, to change their status
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to search the APIs in
+// @param query : Search query of the APIs (ex: "status:CREATED tag:retail")
+// @return APIs to change the status of, error
+func SearchAPIStatusChangeTargets(accessToken, environment, query string) ([]APIStatusChangeTarget, error) {
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return searchAPIStatusChangeTargets(accessToken, apiListEndpoint, query)
+}
+
+func searchAPIStatusChangeTargets(accessToken, apiListEndpoint, query string) ([]APIStatusChangeTarget, error) {
+	targets := []APIStatusChangeTarget{}
+	for offset := 0; ; offset += apiStatusChangeSearchLimit {
+		_, apis, err := SearchAPIs(accessToken, apiListEndpoint, APISearchOptions{Query: query,
+			Limit: strconv.Itoa(apiStatusChangeSearchLimit), Offset: strconv.Itoa(offset)})
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range apis {
+			targets = append(targets, APIStatusChangeTarget{ID: api.ID, Name: api.Name, Version: api.Version,
+				Provider: api.Provider})
+		}
+		if len(apis) < apiStatusChangeSearchLimit {
+			break
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no APIs match the query " + query)
+	}
+	return targets, nil
+}
+
+// ChangeAPIsStatusInEnv changes the lifecycle status of a batch of APIs, reporting the outcome of each API
+// @param accessToken : Access Token for the environment
+// @param environment : Environment where the APIs reside
+// @param stateChangeAction : Action to be performed to change the state of the APIs
+// @param targets : APIs to change the status of
+// @param continueOnError : Whether to go on with the remaining APIs after a failure
+// @return results of the APIs attempted, in the order of the targets
+func ChangeAPIsStatusInEnv(accessToken, environment, stateChangeAction string, targets []APIStatusChangeTarget,
+	continueOnError bool) []APIStatusChangeResult {
+	changeAPIStatusEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return changeAPIsStatus(changeAPIStatusEndpoint, environment, stateChangeAction, targets, continueOnError,
+		accessToken)
+}
+
+func changeAPIsStatus(changeAPIStatusEndpoint, environment, stateChangeAction string,
+	targets []APIStatusChangeTarget, continueOnError bool, accessToken string) []APIStatusChangeResult {
+	results := []APIStatusChangeResult{}
+	for _, target := range targets {
+		result := changeTargetAPIStatus(changeAPIStatusEndpoint, environment, stateChangeAction, target,
+			accessToken)
+		results = append(results, result)
+		if result.Error != "" && !continueOnError {
+			break
+		}
+	}
+	return results
+}
+
+// changeTargetAPIStatus changes the status of an API of a batch. The id of the API is looked up unless the API was
+// found by a search.
+func changeTargetAPIStatus(changeAPIStatusEndpoint, environment, stateChangeAction string,
+	target APIStatusChangeTarget, accessToken string) APIStatusChangeResult {
+	result := APIStatusChangeResult{Id: target.ID, Name: target.Name, Version: target.Version,
+		Provider: target.Provider, Action: stateChangeAction}
+	if result.Id == "" {
+		apiId, err := GetAPIId(accessToken, environment, target.Name, target.Version, target.Provider)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Id = apiId
+	}
+	resp, err := changeAPIStatusById(changeAPIStatusEndpoint, stateChangeAction, result.Id, accessToken)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if resp.StatusCode() != http.StatusOK {
+		result.Error = resp.Status() + " " + string(resp.Body())
+		return result
+	}
+	lifecycleChangeResponse := &utils.LifecycleChangeResponse{}
+	if err = json.Unmarshal(resp.Body(), lifecycleChangeResponse); err != nil {
+		result.Error = "invalid lifecycle change response: " + err.Error()
+		return result
+	}
+	result.State = lifecycleChangeResponse.LifecycleState.State
+	result.WorkflowStatus = lifecycleChangeResponse.WorkflowStatus
+	return result
+}
+
+// PrintAPIStatusChangeResults prints the outcome of changing the status of each API of a batch
+// @param results : Results of the APIs attempted
+// @param total : Number of APIs in the batch
+// @param format : Format type of the output
+func PrintAPIStatusChangeResults(results []APIStatusChangeResult, total int, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(results)
+		return
+	}
+	succeeded := 0
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Println("FAILED  " + result.Name + " " + result.Version + ": " + result.Error)
+			continue
+		}
+		succeeded++
+		state := result.State
+		if result.WorkflowStatus != "" {
+			state += " (workflow " + result.WorkflowStatus + ")"
+		}
+		fmt.Println("CHANGED " + result.Name + " " + result.Version + ": " + state)
+	}
+	fmt.Printf("\n%d of %d APIs changed, %d failed, %d skipped\n", succeeded, total, len(results)-succeeded,
+		total-len(results))
+}
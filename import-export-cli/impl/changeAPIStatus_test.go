@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAPIStatusChangeTargets(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "apis.yaml")
+	writeTestFile(t, filePath, `- name: PizzaShackAPI
+  version: 1.0.0
+- name: TwitterAPI
+  version: 2.0.0
+  provider: alice
+`)
+	targets, err := ReadAPIStatusChangeTargets(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, []APIStatusChangeTarget{{Name: "PizzaShackAPI", Version: "1.0.0"},
+		{Name: "TwitterAPI", Version: "2.0.0", Provider: "alice"}}, targets)
+
+	writeTestFile(t, filePath, "- name: PizzaShackAPI\n")
+	_, err = ReadAPIStatusChangeTargets(filePath)
+	assert.NotNil(t, err)
+}
+
+func TestChangeAPIsStatus(t *testing.T) {
+	changedAPIs := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "status:CREATED", r.URL.Query().Get("query"))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"count": 3, "list": [{"id": "api-1", "name": "Pizza", "version": "1.0.0"},
+				{"id": "api-2", "name": "Twitter", "version": "1.0.0"},
+				{"id": "api-3", "name": "Weather", "version": "1.0.0"}]}`))
+			return
+		}
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/change-lifecycle"))
+		assert.Equal(t, "Publish", r.URL.Query().Get("action"))
+		apiId := r.URL.Query().Get("apiId")
+		changedAPIs = append(changedAPIs, apiId)
+		if apiId == "api-2" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"description": "API has no endpoints"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"workflowStatus": "APPROVED", "lifecycleState": {"state": "PUBLISHED"}}`))
+	}))
+	defer server.Close()
+
+	targets, err := searchAPIStatusChangeTargets("access_token", server.URL, "status:CREATED")
+	assert.Nil(t, err)
+	assert.Len(t, targets, 3)
+
+	results := changeAPIsStatus(server.URL, "dev", "Publish", targets, false, "access_token")
+	assert.Equal(t, []string{"api-1", "api-2"}, changedAPIs)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "PUBLISHED", results[0].State)
+	assert.Empty(t, results[0].Error)
+	assert.Contains(t, results[1].Error, "API has no endpoints")
+
+	changedAPIs = []string{}
+	results = changeAPIsStatus(server.URL, "dev", "Publish", targets, true, "access_token")
+	assert.Equal(t, []string{"api-1", "api-2", "api-3"}, changedAPIs)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "Weather", results[2].Name)
+	assert.Equal(t, "APPROVED", results[2].WorkflowStatus)
+}
@@ -0,0 +1,216 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	envChecksPublisherHeader = "PUBLISHER"
+	envChecksDevPortalHeader = "DEVPORTAL"
+	envChecksAdminHeader     = "ADMIN"
+	envChecksTokenHeader     = "TOKEN"
+
+	envEndpointPublisher = "publisher"
+	envEndpointDevPortal = "devportal"
+	envEndpointAdmin     = "admin"
+	envEndpointToken     = "token"
+
+	envEndpointNotConfigured = "NOT CONFIGURED"
+	envEndpointUnreachable   = "UNREACHABLE"
+)
+
+// EnvEndpointCheck holds the result of probing an endpoint of an environment
+type EnvEndpointCheck struct {
+	URL        string
+	Reachable  bool
+	StatusCode int
+	Latency    time.Duration
+	Error      string
+}
+
+// EnvCheck holds the results of probing the endpoints of an environment
+type EnvCheck struct {
+	Name      string
+	Endpoints map[string]EnvEndpointCheck
+}
+
+// Healthy returns true if all the endpoints of the environment are reachable
+func (e EnvCheck) Healthy() bool {
+	for _, check := range e.Endpoints {
+		if !check.Reachable {
+			return false
+		}
+	}
+	return true
+}
+
+// Publisher returns the reachability of the publisher endpoint
+func (e EnvCheck) Publisher() string {
+	return e.Endpoints[envEndpointPublisher].String()
+}
+
+// DevPortal returns the reachability of the devportal endpoint
+func (e EnvCheck) DevPortal() string {
+	return e.Endpoints[envEndpointDevPortal].String()
+}
+
+// Admin returns the reachability of the admin endpoint
+func (e EnvCheck) Admin() string {
+	return e.Endpoints[envEndpointAdmin].String()
+}
+
+// Token returns the reachability of the token endpoint
+func (e EnvCheck) Token() string {
+	return e.Endpoints[envEndpointToken].String()
+}
+
+// String returns the reachability and the latency of the endpoint as shown in the matrix
+func (c EnvEndpointCheck) String() string {
+	if c.URL == "" {
+		return envEndpointNotConfigured
+	}
+	if c.StatusCode == 0 {
+		return envEndpointUnreachable
+	}
+	latency := c.Latency.Round(time.Millisecond)
+	if !c.Reachable {
+		return fmt.Sprintf("ERROR %d (%v)", c.StatusCode, latency)
+	}
+	return fmt.Sprintf("OK %d (%v)", c.StatusCode, latency)
+}
+
+// CheckEnvs probes the publisher, devportal, admin and token endpoints of the given environments
+// concurrently and returns the results sorted by the environment name
+// @param envNames : Names of the environments to check
+// @param mainConfigFilePath : Path to the main config file where the environments are defined
+// @return results of the environments
+func CheckEnvs(envNames []string, mainConfigFilePath string) []EnvCheck {
+	envEndpoints := make(map[string]map[string]string, len(envNames))
+	for _, env := range envNames {
+		tokenEndpoint := utils.GetTokenEndpointOfEnv(env, mainConfigFilePath)
+		if tokenEndpoint == "" {
+			tokenEndpoint = utils.GetInternalTokenEndpointOfEnv(env, mainConfigFilePath)
+		}
+		envEndpoints[env] = map[string]string{
+			envEndpointPublisher: utils.GetPublisherEndpointOfEnv(env, mainConfigFilePath),
+			envEndpointDevPortal: utils.GetDevPortalApiListEndpointOfEnv(env, mainConfigFilePath),
+			envEndpointAdmin:     utils.GetAdminEndpointOfEnv(env, mainConfigFilePath),
+			envEndpointToken:     tokenEndpoint,
+		}
+	}
+	return checkEnvEndpoints(envEndpoints)
+}
+
+// checkEnvEndpoints probes all the endpoints of all the environments concurrently
+func checkEnvEndpoints(envEndpoints map[string]map[string]string) []EnvCheck {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	checks := make(map[string]map[string]EnvEndpointCheck, len(envEndpoints))
+	for env, endpoints := range envEndpoints {
+		checks[env] = make(map[string]EnvEndpointCheck, len(endpoints))
+		for endpointType, url := range endpoints {
+			wg.Add(1)
+			go func(env, endpointType, url string) {
+				defer wg.Done()
+				check := probeEnvEndpoint(url)
+				mutex.Lock()
+				checks[env][endpointType] = check
+				mutex.Unlock()
+			}(env, endpointType, url)
+		}
+	}
+	wg.Wait()
+
+	results := make([]EnvCheck, 0, len(checks))
+	for env, endpointChecks := range checks {
+		results = append(results, EnvCheck{Name: env, Endpoints: endpointChecks})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// probeEnvEndpoint sends a GET request to the endpoint. The endpoint is considered reachable if it
+// responds with any status below 500, as the probe is not authenticated.
+func probeEnvEndpoint(url string) EnvEndpointCheck {
+	check := EnvEndpointCheck{URL: url}
+	if url == "" {
+		return check
+	}
+	utils.Logln(utils.LogPrefixInfo + "Checking the endpoint " + url)
+	start := time.Now()
+	resp, err := utils.InvokeGETRequest(url, make(map[string]string))
+	check.Latency = time.Since(start)
+	if err != nil {
+		check.Error = err.Error()
+		utils.Logln(utils.LogPrefixWarning + "Endpoint " + url + " is unreachable: " + err.Error())
+		return check
+	}
+	check.StatusCode = resp.StatusCode()
+	check.Reachable = check.StatusCode < http.StatusInternalServerError
+	return check
+}
+
+// PrintEnvChecks prints the reachability matrix of the environments and returns the number of
+// environments with at least one endpoint which is not reachable
+func PrintEnvChecks(results []EnvCheck, format string) int {
+	envChecksContext := formatter.NewContext(os.Stdout, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, result := range results {
+			if err := t.Execute(w, result); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	envChecksTableHeaders := map[string]string{
+		"Name":      envsNameHeader,
+		"Publisher": envChecksPublisherHeader,
+		"DevPortal": envChecksDevPortalHeader,
+		"Admin":     envChecksAdminHeader,
+		"Token":     envChecksTokenHeader,
+	}
+
+	if err := envChecksContext.Write(renderer, envChecksTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+
+	unhealthyEnvs := 0
+	for _, result := range results {
+		if !result.Healthy() {
+			unhealthyEnvs++
+		}
+	}
+	return unhealthyEnvs
+}
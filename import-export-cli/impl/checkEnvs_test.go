@@ -0,0 +1,80 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEnvEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/am/publisher/v4":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/api/am/admin/v4":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	// The listener of the closed server refuses connections
+	closedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedServer.Close()
+
+	results := checkEnvEndpoints(map[string]map[string]string{
+		"prod": {
+			envEndpointPublisher: server.URL + "/api/am/publisher/v4",
+			envEndpointDevPortal: server.URL + "/api/am/devportal/v3/apis",
+			envEndpointAdmin:     server.URL + "/api/am/admin/v4",
+			envEndpointToken:     closedServer.URL + "/oauth2/token",
+		},
+		"dev": {
+			envEndpointPublisher: server.URL + "/api/am/publisher/v4",
+			envEndpointDevPortal: server.URL + "/api/am/devportal/v3/apis",
+			envEndpointAdmin:     "",
+			envEndpointToken:     server.URL + "/oauth2/token",
+		},
+	})
+
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "dev", results[0].Name, "Results should be sorted by the environment name")
+	assert.Equal(t, "prod", results[1].Name, "Results should be sorted by the environment name")
+
+	prod := results[1]
+	assert.True(t, prod.Endpoints[envEndpointPublisher].Reachable, "4xx responses should be considered reachable")
+	assert.Equal(t, http.StatusUnauthorized, prod.Endpoints[envEndpointPublisher].StatusCode)
+	assert.True(t, prod.Endpoints[envEndpointDevPortal].Reachable)
+	assert.False(t, prod.Endpoints[envEndpointAdmin].Reachable, "5xx responses should not be considered reachable")
+	assert.Contains(t, prod.Admin(), "ERROR 503")
+	assert.False(t, prod.Endpoints[envEndpointToken].Reachable)
+	assert.NotEqual(t, "", prod.Endpoints[envEndpointToken].Error)
+	assert.Equal(t, envEndpointUnreachable, prod.Token())
+	assert.False(t, prod.Healthy())
+
+	dev := results[0]
+	assert.Equal(t, envEndpointNotConfigured, dev.Admin())
+	assert.Contains(t, dev.Publisher(), "OK 401")
+	assert.False(t, dev.Healthy(), "Environments with endpoints that are not configured should not be healthy")
+}
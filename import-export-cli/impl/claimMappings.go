@@ -0,0 +1,219 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	claimMappingRemoteClaimHeader = "REMOTE_CLAIM"
+	claimMappingLocalClaimHeader  = "LOCAL_CLAIM"
+
+	defaultClaimMappingTableFormat = "table {{.RemoteClaim}}\t{{.LocalClaim}}"
+
+	keyManagerClaimMappingKey = "claimMapping"
+)
+
+// ClaimMapping maps a claim of the tokens issued by a key manager to a local claim
+type ClaimMapping struct {
+	RemoteClaim string `json:"remoteClaim"`
+	LocalClaim  string `json:"localClaim"`
+}
+
+// claimMapping struct holds a claim mapping for outputting
+type claimMapping struct {
+	mapping ClaimMapping
+}
+
+// RemoteClaim of the key manager
+func (c claimMapping) RemoteClaim() string {
+	return c.mapping.RemoteClaim
+}
+
+// LocalClaim the remote claim is mapped to
+func (c claimMapping) LocalClaim() string {
+	return c.mapping.LocalClaim
+}
+
+// MarshalJSON marshals claimMapping using custom marshaller which uses methods instead of fields
+func (c *claimMapping) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+// GetClaimMappingsFromEnv retrieves the claim mappings of a key manager
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the key manager
+// @param keyManager	: Name of the key manager
+// @return claim mappings of the key manager, error
+func GetClaimMappingsFromEnv(accessToken, environment, keyManager string) ([]ClaimMapping, error) {
+	keyManagersEndpoint := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + keyManagersResource
+	return getClaimMappings(keyManagersEndpoint, accessToken, keyManager)
+}
+
+// getClaimMappings retrieves the claim mappings of a key manager
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param keyManager			: Name of the key manager
+// @return claim mappings of the key manager, error
+func getClaimMappings(keyManagersEndpoint, accessToken, keyManager string) ([]ClaimMapping, error) {
+	url, err := getKeyManagerURL(keyManagersEndpoint, accessToken, keyManager)
+	if err != nil {
+		return nil, err
+	}
+	km, err := getRESTResource(url, accessToken, "key manager")
+	if err != nil {
+		return nil, err
+	}
+	// The claim mappings are re-marshalled into typed structs as the key manager is retrieved as a generic map
+	content, err := json.Marshal(km[keyManagerClaimMappingKey])
+	if err != nil {
+		return nil, err
+	}
+	mappings := []ClaimMapping{}
+	if err = json.Unmarshal(content, &mappings); err != nil {
+		return nil, err
+	}
+	if mappings == nil {
+		mappings = []ClaimMapping{}
+	}
+	return mappings, nil
+}
+
+// SetClaimMappingFromEnv adds or replaces the mapping of a remote claim of a key manager, or removes the mapping
+// of the remote claim if localClaim is empty
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the key manager
+// @param keyManager	: Name of the key manager
+// @param remoteClaim	: Claim of the tokens issued by the key manager
+// @param localClaim	: Local claim to map the remote claim to
+// @return error
+func SetClaimMappingFromEnv(accessToken, environment, keyManager, remoteClaim, localClaim string) error {
+	keyManagersEndpoint := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + keyManagersResource
+	return setClaimMapping(keyManagersEndpoint, accessToken, keyManager, remoteClaim, localClaim)
+}
+
+// setClaimMapping adds or replaces the mapping of a remote claim of a key manager, or removes the mapping of the
+// remote claim if localClaim is empty. The other configurations of the key manager are sent back as they are.
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param keyManager			: Name of the key manager
+// @param remoteClaim			: Claim of the tokens issued by the key manager
+// @param localClaim			: Local claim to map the remote claim to
+// @return error
+func setClaimMapping(keyManagersEndpoint, accessToken, keyManager, remoteClaim, localClaim string) error {
+	remoteClaim = strings.TrimSpace(remoteClaim)
+	if remoteClaim == "" {
+		return errors.New("remote claim should not be empty")
+	}
+	url, err := getKeyManagerURL(keyManagersEndpoint, accessToken, keyManager)
+	if err != nil {
+		return err
+	}
+	km, err := getRESTResource(url, accessToken, "key manager")
+	if err != nil {
+		return err
+	}
+	existing, _ := km[keyManagerClaimMappingKey].([]interface{})
+	mappings := make([]interface{}, 0, len(existing)+1)
+	found := false
+	for _, item := range existing {
+		mapping, _ := item.(map[string]interface{})
+		if mapping == nil || mapping["remoteClaim"] != remoteClaim {
+			mappings = append(mappings, item)
+			continue
+		}
+		found = true
+		if localClaim != "" {
+			mapping["localClaim"] = localClaim
+			mappings = append(mappings, mapping)
+		}
+	}
+	if !found {
+		if localClaim == "" {
+			return errors.New("remote claim " + remoteClaim + " is not mapped in the key manager " + keyManager)
+		}
+		mappings = append(mappings, map[string]interface{}{"remoteClaim": remoteClaim, "localClaim": localClaim})
+	}
+	km[keyManagerClaimMappingKey] = mappings
+	return updateRESTResource(url, accessToken, "key manager", km)
+}
+
+// getKeyManagerURL returns the URL of a key manager in the Admin API
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param keyManager			: Name of the key manager
+// @return URL of the key manager, error
+func getKeyManagerURL(keyManagersEndpoint, accessToken, keyManager string) (string, error) {
+	keyManagers, err := listKeyManagers(keyManagersEndpoint, accessToken)
+	if err != nil {
+		return "", err
+	}
+	for _, km := range keyManagers {
+		if km.Name == keyManager {
+			return keyManagersEndpoint + "/" + km.ID, nil
+		}
+	}
+	return "", errors.New("key manager " + keyManager + " is not found")
+}
+
+// PrintClaimMappings prints the claim mappings of a key manager in the given format
+// @param mappings	Claim mappings of the key manager
+// @param format	Format type of the output
+func PrintClaimMappings(mappings []ClaimMapping, format string) {
+	if utils.PrintFormattedOutput(mappings, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultClaimMappingTableFormat)
+	// create claim mapping context with standard output
+	claimMappingContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, m := range mappings {
+			if err := t.Execute(w, &claimMapping{m}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	claimMappingTableHeaders := map[string]string{
+		"RemoteClaim": claimMappingRemoteClaimHeader,
+		"LocalClaim":  claimMappingLocalClaimHeader,
+	}
+
+	// execute context
+	if err := claimMappingContext.Write(renderer, claimMappingTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
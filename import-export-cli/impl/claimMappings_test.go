@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const claimMappingsTestKeyManager = `{"id": "km-1", "name": "Okta", "type": "Okta", "enabled": true,
+	"claimMapping": [
+		{"remoteClaim": "email", "localClaim": "http://wso2.org/claims/emailaddress"},
+		{"remoteClaim": "groups", "localClaim": "http://wso2.org/claims/role"}]}`
+
+func newClaimMappingsTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/key-managers", http.StatusOK, `{"count": 2, "list": [
+			{"id": "km-0", "name": "Resident Key Manager"}, {"id": "km-1", "name": "Okta"}]}`).
+		handle(http.MethodGet, "/key-managers/km-1", http.StatusOK, claimMappingsTestKeyManager).
+		handle(http.MethodPut, "/key-managers/km-1", http.StatusOK, "")
+}
+
+func TestGetClaimMappings(t *testing.T) {
+	server := newClaimMappingsTestServer(t)
+	defer server.Close()
+
+	mappings, err := getClaimMappings(server.URL+"/key-managers", "access-token", "Okta")
+	assert.Nil(t, err)
+	assert.Equal(t, []ClaimMapping{
+		{RemoteClaim: "email", LocalClaim: "http://wso2.org/claims/emailaddress"},
+		{RemoteClaim: "groups", LocalClaim: "http://wso2.org/claims/role"},
+	}, mappings)
+
+	_, err = getClaimMappings(server.URL+"/key-managers", "access-token", "Auth0")
+	assert.EqualError(t, err, "key manager Auth0 is not found")
+}
+
+func TestSetClaimMapping(t *testing.T) {
+	server := newClaimMappingsTestServer(t)
+	defer server.Close()
+
+	err := setClaimMapping(server.URL+"/key-managers", "access-token", "Okta", "groups", "http://wso2.org/claims/groups")
+	assert.Nil(t, err)
+	var updatedKeyManager map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/key-managers/km-1", &updatedKeyManager))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"remoteClaim": "email", "localClaim": "http://wso2.org/claims/emailaddress"},
+		map[string]interface{}{"remoteClaim": "groups", "localClaim": "http://wso2.org/claims/groups"},
+	}, updatedKeyManager["claimMapping"])
+	assert.Equal(t, "Okta", updatedKeyManager["type"], "Other configurations should be sent back as they are")
+
+	err = setClaimMapping(server.URL+"/key-managers", "access-token", "Okta", "name", "http://wso2.org/claims/fullname")
+	assert.Nil(t, err)
+	updatedKeyManager = nil
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/key-managers/km-1", &updatedKeyManager))
+	assert.Len(t, updatedKeyManager["claimMapping"], 3)
+
+	err = setClaimMapping(server.URL+"/key-managers", "access-token", "Okta", "email", "")
+	assert.Nil(t, err)
+	updatedKeyManager = nil
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/key-managers/km-1", &updatedKeyManager))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"remoteClaim": "groups", "localClaim": "http://wso2.org/claims/role"},
+	}, updatedKeyManager["claimMapping"], "Mapping should be removed when the local claim is empty")
+
+	err = setClaimMapping(server.URL+"/key-managers", "access-token", "Okta", "name", "")
+	assert.NotNil(t, err, "Should not remove a claim which is not mapped")
+}
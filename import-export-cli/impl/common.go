@@ -19,8 +19,11 @@
 package impl
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -51,6 +54,61 @@ func ExecuteNewFileUploadRequest(uri string, params map[string]string, paramName
 	return utils.InvokePOSTRequestWithFileAndQueryParams(params, uri, headers, paramName, path)
 }
 
+// getRESTResource retrieves a resource from a REST API of APIM (ex: an API from the Publisher or a key manager from
+// the Admin API) as a generic map, so that the fields unknown to apictl are sent back as they are when the resource
+// is updated with updateRESTResource
+// @param url			: URL of the resource
+// @param accessToken	: Access Token for the environment
+// @param resourceName	: Name of the resource type used in the error messages
+// @return resource, error
+func getRESTResource(url, accessToken, resourceName string) (map[string]interface{}, error) {
+	utils.Logln(utils.LogPrefixInfo+"URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the " + resourceName + ". Status: " +
+			resp.Status())
+	}
+	var resource map[string]interface{}
+	if err = json.Unmarshal(resp.Body(), &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// updateRESTResource updates a resource in a REST API of APIM
+// @param url			: URL of the resource
+// @param accessToken	: Access Token for the environment
+// @param resourceName	: Name of the resource type used in the error messages
+// @param resource		: Resource to update
+// @return error
+func updateRESTResource(url, accessToken, resourceName string, resource map[string]interface{}) error {
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	resp, err := utils.InvokePUTRequestWithoutQueryParams(url, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for updating the " + resourceName + ". Status: " +
+			resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
+
 // From the template data (tmpl) writes the target file using the provided mainConfig
 func WriteTargetFileFromTemplate(targetFile string, tmpl []byte, envs *utils.MainConfig) error {
 	t, err := template.New("").Parse(string(tmpl))
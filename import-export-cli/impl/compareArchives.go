@@ -0,0 +1,309 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Kinds of the differences between two archives
+const (
+	DifferenceAdded    = "added"
+	DifferenceRemoved  = "removed"
+	DifferenceModified = "modified"
+)
+
+// ArchiveComparison holds the differences between two archives
+type ArchiveComparison struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Files lists the files added, removed or modified in the target, sorted by the path
+	Files []FileDifference `json:"files"`
+}
+
+// Identical returns true if the archives have no differences
+func (c *ArchiveComparison) Identical() bool {
+	return len(c.Files) == 0
+}
+
+// FileDifference is a file added, removed or modified in the target archive
+type FileDifference struct {
+	// Path of the file relative to the root of the project
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	// Fields lists the fields which differ in a modified yaml or json file
+	Fields []FieldDifference `json:"fields,omitempty"`
+}
+
+// FieldDifference is a field added, removed or modified in a yaml or json file of the target archive
+type FieldDifference struct {
+	// Path of the field, which are dot separated keys with the indexes of the list items in brackets
+	Path   string      `json:"path"`
+	Kind   string      `json:"kind"`
+	Source interface{} `json:"source,omitempty"`
+	Target interface{} `json:"target,omitempty"`
+}
+
+// CompareArchives compares two exported archives or projects after normalizing them, so that only the differences
+// in the content of the files are reported
+// @param source : Path of the archive or project to compare with
+// @param target : Path of the archive or project to compare
+// @return differences of the archives, error
+func CompareArchives(source, target string) (*ArchiveComparison, error) {
+	sourcePath, err := utils.GetTempCloneFromDirOrZip(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", source, err)
+	}
+	defer os.RemoveAll(filepath.Dir(sourcePath))
+	targetPath, err := utils.GetTempCloneFromDirOrZip(target)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", target, err)
+	}
+	defer os.RemoveAll(filepath.Dir(targetPath))
+
+	files, err := compareProjects(sourcePath, targetPath, getNormalizeExcludedFields())
+	if err != nil {
+		return nil, err
+	}
+	return &ArchiveComparison{Source: source, Target: target, Files: files}, nil
+}
+
+func compareProjects(sourcePath, targetPath string, excludedFields []string) ([]FileDifference, error) {
+	sourceFiles, err := listProjectFiles(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	targetFiles, err := listProjectFiles(targetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := []FileDifference{}
+	for path := range sourceFiles {
+		if !targetFiles[path] {
+			differences = append(differences, FileDifference{Path: path, Kind: DifferenceRemoved})
+		}
+	}
+	for path := range targetFiles {
+		if !sourceFiles[path] {
+			differences = append(differences, FileDifference{Path: path, Kind: DifferenceAdded})
+			continue
+		}
+		difference, err := compareProjectFile(filepath.Join(sourcePath, path), filepath.Join(targetPath, path),
+			excludedFields)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing %s: %w", path, err)
+		}
+		if difference != nil {
+			difference.Path = path
+			differences = append(differences, *difference)
+		}
+	}
+	sortFileDifferences(differences)
+	return differences, nil
+}
+
+// sortFileDifferences sorts the file differences by the path
+func sortFileDifferences(differences []FileDifference) {
+	sort.SliceStable(differences, func(i, j int) bool { return differences[i].Path < differences[j].Path })
+}
+
+// listProjectFiles returns the slash separated paths of the files of a project relative to its root
+func listProjectFiles(projectPath string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	err := filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(projectPath, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = true
+		return nil
+	})
+	return files, err
+}
+
+// compareProjectFile compares the fields of yaml and json files without the excluded fields, and the content of the
+// other files. The returned difference is nil if the files do not differ.
+func compareProjectFile(sourceFile, targetFile string, excludedFields []string) (*FileDifference, error) {
+	sourceContent, err := ioutil.ReadFile(sourceFile)
+	if err != nil {
+		return nil, err
+	}
+	targetContent, err := ioutil.ReadFile(targetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := strings.ToLower(filepath.Ext(sourceFile))
+	isStructured := extension == ".yaml" || extension == ".yml" || extension == ".json"
+	if !isStructured || len(bytes.TrimSpace(sourceContent)) == 0 || len(bytes.TrimSpace(targetContent)) == 0 {
+		if bytes.Equal(sourceContent, targetContent) {
+			return nil, nil
+		}
+		return &FileDifference{Kind: DifferenceModified}, nil
+	}
+
+	sourceValue, err := decodeNormalized(sourceContent, extension, excludedFields)
+	if err != nil {
+		return nil, err
+	}
+	targetValue, err := decodeNormalized(targetContent, extension, excludedFields)
+	if err != nil {
+		return nil, err
+	}
+	fields := compareFields("", sourceValue, targetValue, nil)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return &FileDifference{Kind: DifferenceModified, Fields: fields}, nil
+}
+
+// decodeNormalized decodes yaml or json content after stripping the excluded fields
+func decodeNormalized(content []byte, extension string, excludedFields []string) (interface{}, error) {
+	var err error
+	if extension != ".json" {
+		if content, err = utils.YamlToJson(content); err != nil {
+			return nil, err
+		}
+	}
+	normalized, err := utils.NormalizeJson(content, excludedFields)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.UseNumber()
+	var value interface{}
+	err = decoder.Decode(&value)
+	return value, err
+}
+
+// compareFields appends the differences between two values decoded from json to differences. Maps are compared by
+// the keys and lists by the indexes of the items.
+func compareFields(path string, source, target interface{}, differences []FieldDifference) []FieldDifference {
+	switch sourceValue := source.(type) {
+	case map[string]interface{}:
+		if targetValue, ok := target.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(sourceValue)+len(targetValue))
+			for key := range sourceValue {
+				keys = append(keys, key)
+			}
+			for key := range targetValue {
+				if _, found := sourceValue[key]; !found {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				sourceChild, inSource := sourceValue[key]
+				targetChild, inTarget := targetValue[key]
+				switch {
+				case !inSource:
+					differences = append(differences, FieldDifference{Path: childPath, Kind: DifferenceAdded,
+						Target: targetChild})
+				case !inTarget:
+					differences = append(differences, FieldDifference{Path: childPath, Kind: DifferenceRemoved,
+						Source: sourceChild})
+				default:
+					differences = compareFields(childPath, sourceChild, targetChild, differences)
+				}
+			}
+			return differences
+		}
+	case []interface{}:
+		if targetValue, ok := target.([]interface{}); ok {
+			for i := 0; i < len(sourceValue) || i < len(targetValue); i++ {
+				itemPath := path + "[" + strconv.Itoa(i) + "]"
+				switch {
+				case i >= len(sourceValue):
+					differences = append(differences, FieldDifference{Path: itemPath, Kind: DifferenceAdded,
+						Target: targetValue[i]})
+				case i >= len(targetValue):
+					differences = append(differences, FieldDifference{Path: itemPath, Kind: DifferenceRemoved,
+						Source: sourceValue[i]})
+				default:
+					differences = compareFields(itemPath, sourceValue[i], targetValue[i], differences)
+				}
+			}
+			return differences
+		}
+	}
+	if source != target {
+		differences = append(differences, FieldDifference{Path: path, Kind: DifferenceModified, Source: source,
+			Target: target})
+	}
+	return differences
+}
+
+// PrintArchiveComparison prints the differences between two archives in the given format
+// @param comparison : Differences of the archives
+// @param format : Format type of the output
+func PrintArchiveComparison(comparison *ArchiveComparison, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(comparison)
+		return
+	}
+	if comparison.Identical() {
+		fmt.Println(comparison.Source + " and " + comparison.Target + " are identical")
+		return
+	}
+	for _, file := range comparison.Files {
+		fmt.Println(file.Kind + ": " + file.Path)
+		for _, field := range file.Fields {
+			printFieldDifference(field)
+		}
+	}
+}
+
+// printFieldDifference prints an added field with "+", a removed field with "-" and a modified field with "~"
+func printFieldDifference(field FieldDifference) {
+	switch field.Kind {
+	case DifferenceAdded:
+		fmt.Println("  + " + field.Path + ": " + formatFieldValue(field.Target))
+	case DifferenceRemoved:
+		fmt.Println("  - " + field.Path + ": " + formatFieldValue(field.Source))
+	default:
+		fmt.Println("  ~ " + field.Path + ": " + formatFieldValue(field.Source) + " -> " +
+			formatFieldValue(field.Target))
+	}
+}
+
+func formatFieldValue(value interface{}) string {
+	formatted, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(formatted)
+}
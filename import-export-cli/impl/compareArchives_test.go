@@ -0,0 +1,77 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestCompareProjects(t *testing.T) {
+	source, target := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(source, "api.yaml"), `type: api
+data:
+  id: 0b8a1f7e
+  name: PizzaShackAPI
+  endpointConfig:
+    production_endpoints:
+      url: https://dev.wso2.com
+  tags: [pizza]
+  policies: [Gold]
+`)
+	writeTestFile(t, filepath.Join(target, "api.yaml"), `type: api
+data:
+  endpointConfig:
+    production_endpoints:
+      url: https://prod.wso2.com
+  name: PizzaShackAPI
+  id: 6d2c94b3
+  tags: [pizza, food]
+`)
+	writeTestFile(t, filepath.Join(source, "Definitions", "swagger.yaml"), "openapi: 3.0.1\n")
+	writeTestFile(t, filepath.Join(target, "Definitions", "swagger.yaml"), "openapi:   3.0.1\n")
+	writeTestFile(t, filepath.Join(source, "Image", "icon.png"), "source")
+	writeTestFile(t, filepath.Join(target, "Image", "icon.png"), "target")
+	writeTestFile(t, filepath.Join(target, "Docs", "HowTo", "document.yaml"), "type: document\n")
+	writeTestFile(t, filepath.Join(source, "deployment_environments.yaml"), "type: deployment_environments\n")
+
+	differences, err := compareProjects(source, target, []string{"data.id"})
+	assert.Nil(t, err)
+	assert.Equal(t, []FileDifference{
+		{Path: "Docs/HowTo/document.yaml", Kind: DifferenceAdded},
+		{Path: "Image/icon.png", Kind: DifferenceModified},
+		{Path: "api.yaml", Kind: DifferenceModified, Fields: []FieldDifference{
+			{Path: "data.endpointConfig.production_endpoints.url", Kind: DifferenceModified,
+				Source: "https://dev.wso2.com", Target: "https://prod.wso2.com"},
+			{Path: "data.policies", Kind: DifferenceRemoved, Source: []interface{}{"Gold"}},
+			{Path: "data.tags[1]", Kind: DifferenceAdded, Target: "food"},
+		}},
+		{Path: "deployment_environments.yaml", Kind: DifferenceRemoved},
+	}, differences, "Excluded fields, key order and formatting should not be reported")
+}
+
+func TestCompareProjectsIdentical(t *testing.T) {
+	project := utils.GetRelativeTestDataPathFromImpl() + "PizzaShackAPI-1.0.0"
+	differences, err := compareProjects(project, project, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, differences)
+}
@@ -0,0 +1,235 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	choreoConnectGatewayType = "wso2/choreo-connect"
+	wso2GatewayVendor        = "wso2"
+)
+
+// apiFieldConversion maps the fields of api.yaml which changed in an APIM version. upgrade converts the data of an
+// API exported from an older version and downgrade converts the data for an older version.
+type apiFieldConversion struct {
+	since     string
+	upgrade   func(data yaml.MapSlice) (yaml.MapSlice, []string)
+	downgrade func(data yaml.MapSlice) (yaml.MapSlice, []string)
+}
+
+// apiFieldConversions are ordered by the version introducing the change. The fields added in a version are not listed
+// here, as they are removed using the explain catalog of api.yaml when converting for an older version.
+var apiFieldConversions = []apiFieldConversion{
+	{
+		// Choreo Connect is given as the gateway type since 4.1.0 instead of the gateway vendor
+		since: "v4.1.0",
+		upgrade: func(data yaml.MapSlice) (yaml.MapSlice, []string) {
+			if getMapSliceValue(data, "gatewayVendor") != choreoConnectGatewayType {
+				return data, nil
+			}
+			data = setMapSliceValue(data, "gatewayVendor", wso2GatewayVendor)
+			data = setMapSliceValue(data, "gatewayType", choreoConnectGatewayType)
+			return data, []string{"data.gatewayVendor " + choreoConnectGatewayType + " was mapped to data.gatewayType"}
+		},
+		downgrade: func(data yaml.MapSlice) (yaml.MapSlice, []string) {
+			if getMapSliceValue(data, "gatewayType") != choreoConnectGatewayType {
+				return data, nil
+			}
+			data = setMapSliceValue(data, "gatewayVendor", choreoConnectGatewayType)
+			return data, []string{"data.gatewayType " + choreoConnectGatewayType + " was mapped to data.gatewayVendor"}
+		},
+	},
+}
+
+// ConvertAPIProjectToVersion converts the API definition of the project, so that it can be imported to the given
+// APIM version. The fields which changed between the version the project was generated for and the target version
+// are mapped, and the fields not supported by the target version are removed.
+// @param projectPath : Path of the API project
+// @param targetVersion : APIM version in "vX.Y.Z" form the project is imported to
+// @return warnings describing each field which was mapped or removed
+// @return error
+func ConvertAPIProjectToVersion(projectPath, targetVersion string) ([]string, error) {
+	for _, fileName := range []string{utils.APIDefinitionFileYaml, utils.APIDefinitionFileJson} {
+		path := filepath.Join(projectPath, fileName)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		converted, warnings, err := convertAPIDefinition(content, targetVersion)
+		if err != nil || len(warnings) == 0 {
+			return nil, err
+		}
+		if fileName == utils.APIDefinitionFileJson {
+			if converted, err = yamlToIndentedJson(converted); err != nil {
+				return nil, err
+			}
+		}
+		utils.Logln(utils.LogPrefixInfo + "Converting " + path + " for APIM version " + targetVersion)
+		return warnings, ioutil.WriteFile(path, converted, info.Mode())
+	}
+	return nil, fmt.Errorf("API definition file was not found in %s", projectPath)
+}
+
+// convertAPIDefinition converts the content of api.yaml (or api.json) from the APIM version given in the artifact to
+// the target version. The content is converted only when both the versions are supported.
+// @return converted content in yaml, and the warnings. No warnings are returned if nothing was converted.
+func convertAPIDefinition(content []byte, targetVersion string) ([]byte, []string, error) {
+	definition := yaml.MapSlice{}
+	if err := yaml.Unmarshal(content, &definition); err != nil {
+		return nil, nil, err
+	}
+	artifactVersion, _ := getMapSliceValue(definition, "version").(string)
+	sourceVersion, err := utils.NormalizeAPIMVersion(artifactVersion)
+	if err != nil || sourceVersion == targetVersion {
+		return nil, nil, nil
+	}
+	sourceIndex, targetIndex := apimVersionIndex(sourceVersion), apimVersionIndex(targetVersion)
+	if sourceIndex < 0 || targetIndex < 0 {
+		utils.Logln(utils.LogPrefixWarning + "Cannot convert the API definition from APIM version " + sourceVersion +
+			" to " + targetVersion)
+		return nil, nil, nil
+	}
+	data, ok := getMapSliceValue(definition, "data").(yaml.MapSlice)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	var warnings []string
+	if sourceIndex < targetIndex {
+		for _, conversion := range apiFieldConversions {
+			if index := apimVersionIndex(conversion.since); index > sourceIndex && index <= targetIndex {
+				var mapped []string
+				data, mapped = conversion.upgrade(data)
+				warnings = append(warnings, mapped...)
+			}
+		}
+	} else {
+		for i := len(apiFieldConversions) - 1; i >= 0; i-- {
+			conversion := apiFieldConversions[i]
+			if index := apimVersionIndex(conversion.since); index > targetIndex && index <= sourceIndex {
+				var mapped []string
+				data, mapped = conversion.downgrade(data)
+				warnings = append(warnings, mapped...)
+			}
+		}
+		var dataFields []ArtifactField
+		if dataField := findArtifactField(apiDocument.Fields, "data", sourceVersion); dataField != nil {
+			dataFields = dataField.Fields
+		}
+		var removed []string
+		data, removed = removeUnsupportedFields(data, dataFields, "data", targetVersion)
+		warnings = append(warnings, removed...)
+	}
+	if len(warnings) == 0 {
+		return nil, nil, nil
+	}
+	converted, err := yaml.Marshal(setMapSliceValue(definition, "data", data))
+	return converted, warnings, err
+}
+
+// removeUnsupportedFields removes the fields not supported by the given APIM version. Only the fields described in
+// the explain catalog are removed, as the versions supporting the other fields are not known.
+func removeUnsupportedFields(values yaml.MapSlice, fields []ArtifactField, path,
+	version string) (yaml.MapSlice, []string) {
+	var warnings []string
+	supported := yaml.MapSlice{}
+	for _, item := range values {
+		name, _ := item.Key.(string)
+		var f *ArtifactField
+		for i := range fields {
+			if fields[i].Name == name {
+				f = &fields[i]
+				break
+			}
+		}
+		if f == nil {
+			supported = append(supported, item)
+			continue
+		}
+		fieldPath := path + "." + name
+		if !isFieldSupported(*f, version) {
+			warnings = append(warnings, fmt.Sprintf("%s is not supported by APIM %s and was removed", fieldPath,
+				version))
+			continue
+		}
+		switch value := item.Value.(type) {
+		case yaml.MapSlice:
+			var removed []string
+			item.Value, removed = removeUnsupportedFields(value, f.Fields, fieldPath, version)
+			warnings = append(warnings, removed...)
+		case []interface{}:
+			for i, element := range value {
+				if elementValues, ok := element.(yaml.MapSlice); ok {
+					var removed []string
+					value[i], removed = removeUnsupportedFields(elementValues, f.Fields,
+						fmt.Sprintf("%s[%d]", fieldPath, i), version)
+					warnings = append(warnings, removed...)
+				}
+			}
+		}
+		supported = append(supported, item)
+	}
+	return supported, warnings
+}
+
+func getMapSliceValue(values yaml.MapSlice, key string) interface{} {
+	for _, item := range values {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// setMapSliceValue replaces the value of the key keeping its position, or appends the key if it does not exist
+func setMapSliceValue(values yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range values {
+		if item.Key == key {
+			values[i].Value = value
+			return values
+		}
+	}
+	return append(values, yaml.MapItem{Key: key, Value: value})
+}
+
+func yamlToIndentedJson(content []byte) ([]byte, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	indented := &bytes.Buffer{}
+	err = json.Indent(indented, jsonContent, "", "  ")
+	return indented.Bytes(), err
+}
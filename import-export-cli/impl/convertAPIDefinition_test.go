@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/renstrom/dedent"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+var apiDefinitionV46 = dedent.Dedent(`
+	type: api
+	version: v4.6.0
+	data:
+	  name: PizzaShackAPI
+	  gatewayVendor: wso2
+	  gatewayType: wso2/choreo-connect
+	  subtypeConfiguration:
+	    subtype: DEFAULT
+	  operations:
+	    - target: /order
+	      verb: POST
+	      operationPolicies:
+	        request: []
+	  customField: value
+`)
+
+func TestConvertAPIDefinitionToOlderVersion(t *testing.T) {
+	converted, warnings, err := convertAPIDefinition([]byte(apiDefinitionV46), "v4.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"data.gatewayType wso2/choreo-connect was mapped to data.gatewayVendor",
+		"data.gatewayType is not supported by APIM v4.0.0 and was removed",
+		"data.subtypeConfiguration is not supported by APIM v4.0.0 and was removed",
+		"data.operations[0].operationPolicies is not supported by APIM v4.0.0 and was removed",
+	}, warnings)
+
+	definition := yaml.MapSlice{}
+	assert.Nil(t, yaml.Unmarshal(converted, &definition))
+	data := getMapSliceValue(definition, "data").(yaml.MapSlice)
+	assert.Equal(t, choreoConnectGatewayType, getMapSliceValue(data, "gatewayVendor"))
+	assert.Nil(t, getMapSliceValue(data, "gatewayType"))
+	assert.Equal(t, "value", getMapSliceValue(data, "customField"), "Unknown fields should be kept")
+	operation := getMapSliceValue(data, "operations").([]interface{})[0].(yaml.MapSlice)
+	assert.Equal(t, "/order", getMapSliceValue(operation, "target"))
+	assert.Nil(t, getMapSliceValue(operation, "operationPolicies"))
+}
+
+func TestConvertAPIDefinitionToSupportingVersion(t *testing.T) {
+	_, warnings, err := convertAPIDefinition([]byte(apiDefinitionV46), "v4.3.0")
+	assert.Nil(t, err)
+	assert.Empty(t, warnings, "All the fields are supported by APIM v4.3.0")
+
+	_, warnings, err = convertAPIDefinition([]byte(apiDefinitionV46), "v4.6.0")
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestConvertAPIDefinitionToNewerVersion(t *testing.T) {
+	definition := dedent.Dedent(`
+		type: api
+		version: v4.0.0
+		data:
+		  name: PizzaShackAPI
+		  gatewayVendor: wso2/choreo-connect
+	`)
+	converted, warnings, err := convertAPIDefinition([]byte(definition), "v4.2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"data.gatewayVendor wso2/choreo-connect was mapped to data.gatewayType"}, warnings)
+	assert.Contains(t, string(converted), "gatewayVendor: wso2\n")
+	assert.Contains(t, string(converted), "gatewayType: wso2/choreo-connect\n")
+}
+
+func TestConvertAPIProjectToVersion(t *testing.T) {
+	projectPath := t.TempDir()
+	apiFilePath := filepath.Join(projectPath, utils.APIDefinitionFileJson)
+	assert.Nil(t, ioutil.WriteFile(apiFilePath, []byte(`{"type": "api", "version": "v4.3.0",
+		"data": {"name": "PizzaShackAPI", "subtypeConfiguration": {"subtype": "DEFAULT"}}}`), 0644))
+
+	warnings, err := ConvertAPIProjectToVersion(projectPath, "v4.2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"data.subtypeConfiguration is not supported by APIM v4.2.0 and was removed"}, warnings)
+	content, err := ioutil.ReadFile(apiFilePath)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type": "api", "version": "v4.3.0", "data": {"name": "PizzaShackAPI"}}`, string(content))
+
+	_, err = ConvertAPIProjectToVersion(t.TempDir(), "v4.2.0")
+	assert.Error(t, err)
+}
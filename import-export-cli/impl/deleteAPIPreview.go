@@ -0,0 +1,288 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	previewApplicationHeader = "APPLICATION"
+	previewSubscriberHeader  = "SUBSCRIBER"
+	previewPolicyHeader      = "POLICY"
+	previewStatusHeader      = "STATUS"
+	previewNameHeader        = "NAME"
+	previewVersionHeader     = "VERSION"
+	previewProviderHeader    = "PROVIDER"
+
+	defaultPreviewSubscriptionTableFormat = "table {{.Application}}\t{{.Subscriber}}\t{{.Policy}}\t{{.Status}}"
+	defaultPreviewApiProductTableFormat   = "table {{.Name}}\t{{.Version}}\t{{.Provider}}"
+
+	// previewPageLimit is the number of entries retrieved per request when listing subscriptions and API Products
+	previewPageLimit = 100
+)
+
+// DeleteAPIPreview holds the artifacts affected by deleting an API
+type DeleteAPIPreview struct {
+	Subscriptions []utils.PublisherSubscription
+	APIProducts   []utils.APIProduct
+	Deployments   []utils.APIRevisionDeployment
+}
+
+// previewSubscription struct holds a subscription affected by deleting an API for outputting
+type previewSubscription struct {
+	subscription utils.PublisherSubscription
+}
+
+// Application of the subscription
+func (s previewSubscription) Application() string {
+	return s.subscription.ApplicationInfo.Name
+}
+
+// Subscriber of the application
+func (s previewSubscription) Subscriber() string {
+	return s.subscription.ApplicationInfo.Subscriber
+}
+
+// Policy of the subscription
+func (s previewSubscription) Policy() string {
+	return s.subscription.ThrottlingPolicy
+}
+
+// Status of the subscription
+func (s previewSubscription) Status() string {
+	return s.subscription.SubscriptionStatus
+}
+
+// MarshalJSON marshals previewSubscription using custom marshaller which uses methods instead of fields
+func (s *previewSubscription) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(s)
+}
+
+// apiProductAPIs holds the APIs an API Product is made of
+type apiProductAPIs struct {
+	APIs []struct {
+		APIID string `json:"apiId"`
+	} `json:"apis"`
+}
+
+// GetDeleteAPIPreviewFromEnv retrieves the subscriptions, the API Products and the deployed revisions that would be
+// affected by deleting an API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment from which the API would be deleted
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @return artifacts affected by deleting the API
+// @return error
+func GetDeleteAPIPreviewFromEnv(accessToken, environment, apiName, apiVersion, provider string) (
+	*DeleteAPIPreview, error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	return getDeleteAPIPreview(accessToken, utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath),
+		apiId)
+}
+
+// getDeleteAPIPreview retrieves the artifacts affected by deleting an API
+// @param accessToken		: Access Token for the environment
+// @param publisherEndpoint	: Publisher REST API endpoint of the environment
+// @param apiId				: ID of the API
+// @return artifacts affected by deleting the API
+// @return error
+func getDeleteAPIPreview(accessToken, publisherEndpoint, apiId string) (*DeleteAPIPreview, error) {
+	publisherEndpoint = utils.AppendSlashToString(publisherEndpoint)
+	subscriptions, err := getAPISubscriptions(accessToken, publisherEndpoint+"subscriptions", apiId)
+	if err != nil {
+		return nil, err
+	}
+	apiProducts, err := getAPIProductsReferringAPI(accessToken, publisherEndpoint+"api-products", apiId)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := getDeployments(accessToken, publisherEndpoint+"apis/"+apiId)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteAPIPreview{Subscriptions: subscriptions, APIProducts: apiProducts, Deployments: deployments}, nil
+}
+
+// getAPISubscriptions retrieves all the subscriptions of an API
+// @param accessToken			: Access Token for the environment
+// @param subscriptionsEndpoint	: Publisher subscriptions endpoint
+// @param apiId					: ID of the API
+// @return subscriptions of the API
+// @return error
+func getAPISubscriptions(accessToken, subscriptionsEndpoint, apiId string) ([]utils.PublisherSubscription, error) {
+	subscriptions := []utils.PublisherSubscription{}
+	for {
+		subscriptionList := &utils.PublisherSubscriptionList{}
+		err := getPreviewPage(accessToken, subscriptionsEndpoint, map[string]string{"apiId": apiId},
+			len(subscriptions), "subscriptions of the API", subscriptionList)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscriptionList.List...)
+		if len(subscriptionList.List) < previewPageLimit {
+			return subscriptions, nil
+		}
+	}
+}
+
+// getAPIProductsReferringAPI retrieves the API Products which include resources of an API
+// @param accessToken			: Access Token for the environment
+// @param apiProductsEndpoint	: Publisher API Products endpoint
+// @param apiId					: ID of the API
+// @return API Products which include the API
+// @return error
+func getAPIProductsReferringAPI(accessToken, apiProductsEndpoint, apiId string) ([]utils.APIProduct, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	apiProducts := []utils.APIProduct{}
+	offset := 0
+	for {
+		apiProductList := &utils.APIProductListResponse{}
+		err := getPreviewPage(accessToken, apiProductsEndpoint, map[string]string{}, offset, "API Products",
+			apiProductList)
+		if err != nil {
+			return nil, err
+		}
+		// The API Product list does not include the APIs, hence each API Product is retrieved to find them
+		for _, apiProduct := range apiProductList.List {
+			url := utils.AppendSlashToString(apiProductsEndpoint) + apiProduct.ID
+			utils.Logln(utils.LogPrefixInfo+"URL:", url)
+			resp, err := utils.InvokeGETRequest(url, headers)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode() != http.StatusOK {
+				utils.Logf("Error: %s\n", resp.Error())
+				utils.Logf("Body: %s\n", resp.Body())
+				return nil, errors.New("Request didn't respond 200 OK for retrieving the API Product " +
+					apiProduct.Name + ". Status: " + resp.Status())
+			}
+			productAPIs := &apiProductAPIs{}
+			if err = json.Unmarshal(resp.Body(), productAPIs); err != nil {
+				return nil, err
+			}
+			for _, api := range productAPIs.APIs {
+				if api.APIID == apiId {
+					apiProducts = append(apiProducts, apiProduct)
+					break
+				}
+			}
+		}
+		offset += len(apiProductList.List)
+		if len(apiProductList.List) < previewPageLimit {
+			return apiProducts, nil
+		}
+	}
+}
+
+// getPreviewPage retrieves a page of a Publisher list resource into list
+func getPreviewPage(accessToken, endpoint string, queryParams map[string]string, offset int, resource string,
+	list interface{}) error {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams["limit"] = strconv.Itoa(previewPageLimit)
+	queryParams["offset"] = strconv.Itoa(offset)
+
+	utils.Logln(utils.LogPrefixInfo+"URL:", endpoint)
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, endpoint, headers)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for retrieving the " + resource + ". Status: " +
+			resp.Status())
+	}
+	return json.Unmarshal(resp.Body(), list)
+}
+
+// PrintDeleteAPIPreview prints the artifacts affected by deleting an API
+// @param apiName	: Name of the API
+// @param apiVersion	: Version of the API
+// @param preview	: Artifacts affected by deleting the API
+func PrintDeleteAPIPreview(apiName, apiVersion string, preview *DeleteAPIPreview) {
+	fmt.Println("Deleting the API " + apiName + " " + apiVersion + " will affect the following. " +
+		"The API has not been deleted.")
+
+	fmt.Println("\nSubscriptions: " + strconv.Itoa(len(preview.Subscriptions)))
+	if len(preview.Subscriptions) > 0 {
+		printPreviewTable(defaultPreviewSubscriptionTableFormat, func(w io.Writer, t *template.Template) error {
+			for _, s := range preview.Subscriptions {
+				if err := t.Execute(w, &previewSubscription{s}); err != nil {
+					return err
+				}
+				_, _ = w.Write([]byte{'\n'})
+			}
+			return nil
+		}, map[string]string{
+			"Application": previewApplicationHeader,
+			"Subscriber":  previewSubscriberHeader,
+			"Policy":      previewPolicyHeader,
+			"Status":      previewStatusHeader,
+		})
+	}
+
+	fmt.Println("\nAPI Products: " + strconv.Itoa(len(preview.APIProducts)))
+	if len(preview.APIProducts) > 0 {
+		printPreviewTable(defaultPreviewApiProductTableFormat, func(w io.Writer, t *template.Template) error {
+			for _, p := range preview.APIProducts {
+				if err := t.Execute(w, p); err != nil {
+					return err
+				}
+				_, _ = w.Write([]byte{'\n'})
+			}
+			return nil
+		}, map[string]string{
+			"Name":     previewNameHeader,
+			"Version":  previewVersionHeader,
+			"Provider": previewProviderHeader,
+		})
+	}
+
+	fmt.Println("\nDeployed revisions: " + strconv.Itoa(len(preview.Deployments)))
+	if len(preview.Deployments) > 0 {
+		PrintDeployments(preview.Deployments, "")
+	}
+}
+
+// printPreviewTable writes a table of the preview to the standard output
+func printPreviewTable(format string, renderer func(io.Writer, *template.Template) error,
+	headers map[string]string) {
+	previewContext := formatter.NewContext(os.Stdout, format)
+	if err := previewContext.Write(renderer, headers); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,75 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeleteAPIPreview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/subscriptions":
+			assert.Equal(t, "pizza", r.URL.Query().Get("apiId"))
+			_, _ = w.Write([]byte(`{"count": 1, "list": [{"subscriptionId": "s1",
+				"applicationInfo": {"applicationId": "a1", "name": "PizzaApp", "subscriber": "alice"},
+				"throttlingPolicy": "Gold", "subscriptionStatus": "UNBLOCKED"}]}`))
+		case "/api-products":
+			_, _ = w.Write([]byte(`{"count": 2, "list": [{"id": "p1", "name": "FoodProduct", "version": "1.0.0"},
+				{"id": "p2", "name": "DrinksProduct", "version": "1.0.0"}]}`))
+		case "/api-products/p1":
+			_, _ = w.Write([]byte(`{"id": "p1", "apis": [{"apiId": "burger"}, {"apiId": "pizza"}]}`))
+		case "/api-products/p2":
+			_, _ = w.Write([]byte(`{"id": "p2", "apis": [{"apiId": "coffee"}]}`))
+		case "/apis/pizza/deployments":
+			_, _ = w.Write([]byte(`{"list": [{"revisionUuid": "r1", "name": "Default", "vhost": "localhost",
+				"status": "APPROVED"}]}`))
+		case "/apis/pizza/revisions":
+			_, _ = w.Write([]byte(`{"count": 1, "list": [{"id": "r1", "displayName": "Revision 1"}]}`))
+		default:
+			t.Errorf("Unexpected request to %s\n", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	preview, err := getDeleteAPIPreview("access-token", server.URL, "pizza")
+	assert.Nil(t, err)
+	assert.Len(t, preview.Subscriptions, 1)
+	assert.Equal(t, "PizzaApp", previewSubscription{preview.Subscriptions[0]}.Application())
+	assert.Equal(t, "UNBLOCKED", previewSubscription{preview.Subscriptions[0]}.Status())
+	assert.Len(t, preview.APIProducts, 1)
+	assert.Equal(t, "FoodProduct", preview.APIProducts[0].Name, "Should only include the API Products of the API")
+	assert.Len(t, preview.Deployments, 1)
+	assert.Equal(t, "Revision 1", preview.Deployments[0].Revision)
+}
+
+func TestGetDeleteAPIPreviewError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := getDeleteAPIPreview("access-token", server.URL, "pizza")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,135 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// DiffAPI compares an API deployed in an environment with a local API project, so that the changes made to the API
+// in the environment outside of the project can be detected. The files ignored by the project are not compared.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the API
+// @param projectPath : Path of the API project or archive
+// @param provider : Provider of the API. The provider in the project is used if empty
+// @param revisionNum : Revision number of the API to compare with. The working copy is compared if empty
+// @param latestRevision : Whether to compare with the latest revision of the API
+// @return differences from the API in the environment to the project, error
+func DiffAPI(accessToken, environment, projectPath, provider, revisionNum string, latestRevision bool) (
+	*ArchiveComparison, error) {
+	localPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", projectPath, err)
+	}
+	defer os.RemoveAll(filepath.Dir(localPath))
+	apiDefinition, _, err := GetAPIDefinition(localPath)
+	if err != nil {
+		return nil, err
+	}
+	if provider == "" {
+		provider = apiDefinition.Data.Provider
+	}
+
+	deployedPath, err := exportAPIToTempProject(accessToken, environment, apiDefinition.Data.Name,
+		apiDefinition.Data.Version, revisionNum, provider, latestRevision)
+	if err != nil {
+		return nil, err
+	}
+	if deployedPath == "" {
+		return nil, fmt.Errorf("API %s %s of %s is not found in %s", apiDefinition.Data.Name,
+			apiDefinition.Data.Version, provider, environment)
+	}
+	defer os.RemoveAll(filepath.Dir(deployedPath))
+
+	files, err := compareProjects(deployedPath, localPath, getNormalizeExcludedFields())
+	if err != nil {
+		return nil, err
+	}
+	files, err = removeIgnoredFiles(localPath, files)
+	if err != nil {
+		return nil, err
+	}
+	return &ArchiveComparison{
+		Source: apiDefinition.Data.Name + " " + apiDefinition.Data.Version + " in " + environment,
+		Target: projectPath,
+		Files:  files,
+	}, nil
+}
+
+// exportAPIToTempProject exports an API from an environment and extracts it to a temporary directory. The returned
+// path is empty if the API is not found. The parent directory of the returned path should be removed once the project
+// is no longer needed.
+func exportAPIToTempProject(accessToken, environment, name, version, revisionNum, provider string,
+	latestRevision bool) (string, error) {
+	resp, err := ExportAPIFromEnv(accessToken, name, version, revisionNum, provider, utils.DefaultExportFormat,
+		environment, true, latestRevision)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New("error retrieving the API from " + environment + ": " + resp.Status() + " " +
+			string(resp.Body()))
+	}
+	tempZipFile, err := utils.WriteResponseToTempZip(name+"_"+version+".zip", resp)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(filepath.Dir(tempZipFile))
+	return utils.GetTempCloneFromDirOrZip(tempZipFile)
+}
+
+// removeIgnoredFiles removes the differences of the files ignored by the project, as they are never imported
+func removeIgnoredFiles(projectPath string, files []FileDifference) ([]FileDifference, error) {
+	ignore, err := utils.LoadProjectIgnore(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", utils.ProjectIgnoreFileName, err)
+	}
+	if ignore == nil {
+		return files, nil
+	}
+	included := []FileDifference{}
+	for _, file := range files {
+		if !isIgnoredFile(ignore, file.Path) {
+			included = append(included, file)
+		}
+	}
+	return included, nil
+}
+
+// isIgnoredFile returns true if the file or a directory containing it is ignored, as the ignored directories are not
+// packaged along with their files
+func isIgnoredFile(ignore *utils.ProjectIgnore, filePath string) bool {
+	parts := strings.Split(filePath, "/")
+	for i := 1; i < len(parts); i++ {
+		if ignore.Ignores(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return ignore.Ignores(filePath, false)
+}
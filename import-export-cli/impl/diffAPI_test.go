@@ -0,0 +1,53 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestRemoveIgnoredFiles(t *testing.T) {
+	project := t.TempDir()
+	writeTestFile(t, filepath.Join(project, utils.ProjectIgnoreFileName), "*.bak\nDocs/drafts/\n")
+	files := []FileDifference{
+		{Path: ".apictlignore", Kind: DifferenceAdded},
+		{Path: "Definitions/swagger.yaml", Kind: DifferenceModified},
+		{Path: "Definitions/swagger.yaml.bak", Kind: DifferenceAdded},
+		{Path: "Docs/drafts/guide.md", Kind: DifferenceAdded},
+		{Path: "Docs/FileContents/guide.md", Kind: DifferenceRemoved},
+	}
+
+	included, err := removeIgnoredFiles(project, files)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, []FileDifference{
+		{Path: "Definitions/swagger.yaml", Kind: DifferenceModified},
+		{Path: "Docs/FileContents/guide.md", Kind: DifferenceRemoved},
+	}, included, "Files ignored by the project or in an ignored directory should be removed")
+}
+
+func TestRemoveIgnoredFilesWithoutIgnoreFile(t *testing.T) {
+	files := []FileDifference{{Path: "Definitions/swagger.yaml", Kind: DifferenceModified}}
+	included, err := removeIgnoredFiles(t.TempDir(), files)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, files, included)
+}
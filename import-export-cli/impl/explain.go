@@ -0,0 +1,401 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ArtifactField describes a field of an artifact file
+type ArtifactField struct {
+	Name        string
+	Type        string
+	Description string
+	// Since is the first APIM version supporting the field. The field is supported by all versions when empty.
+	Since  string
+	Fields []ArtifactField
+}
+
+// artifactDocument describes an artifact file
+type artifactDocument struct {
+	FileName    string
+	Description string
+	Fields      []ArtifactField
+}
+
+func field(name, fieldType, description string, fields ...ArtifactField) ArtifactField {
+	return ArtifactField{Name: name, Type: fieldType, Description: description, Fields: fields}
+}
+
+func (f ArtifactField) since(version string) ArtifactField {
+	f.Since = version
+	return f
+}
+
+func artifactHeaderFields(artifactType string, data ArtifactField) []ArtifactField {
+	return []ArtifactField{
+		field("type", "string", "Type of the artifact. Should be \""+artifactType+"\""),
+		field("version", "string", "APIM version the artifact was exported from or is targeted to, "+
+			"in the vX.Y.Z form (ex: "+utils.DefaultAPIMVersion+")"),
+		data,
+	}
+}
+
+var endpointFields = []ArtifactField{
+	field("url", "string", "URL of the endpoint"),
+	field("config", "object", "Timeout, retry and suspension settings of the endpoint",
+		field("retryTimeOut", "integer", "Number of times to retry before suspending the endpoint"),
+		field("retryDelay", "integer", "Delay between retries in milliseconds"),
+		field("factor", "integer", "Factor by which the suspension duration grows on each failure"),
+		field("suspendDuration", "integer", "Initial duration to suspend the endpoint in milliseconds"),
+		field("suspendMaxDuration", "integer", "Maximum duration to suspend the endpoint in milliseconds"),
+		field("actionDuration", "integer", "Timeout of a request to the endpoint in milliseconds"),
+		field("actionSelect", "string", "Action on timeout (discard or fault)"),
+	),
+}
+
+var apiDocument = artifactDocument{
+	FileName:    utils.APIDefinitionFileYaml,
+	Description: "Definition of an API in an API project",
+	Fields: artifactHeaderFields("api", field("data", "object", "The API",
+		field("name", "string", "Name of the API"),
+		field("description", "string", "Description of the API"),
+		field("context", "string", "Context the API is exposed at in the gateway"),
+		field("version", "string", "Version of the API"),
+		field("provider", "string", "Username of the provider of the API"),
+		field("lifeCycleStatus", "string", "Lifecycle state of the API (CREATED, PUBLISHED, DEPRECATED, ...)"),
+		field("responseCachingEnabled", "boolean", "Whether the responses of the API are cached in the gateway"),
+		field("cacheTimeout", "integer", "Time in seconds responses are cached for"),
+		field("hasThumbnail", "boolean", "Whether the API has a thumbnail image"),
+		field("isDefaultVersion", "boolean", "Whether the API is also exposed at the context without the version"),
+		field("isRevision", "boolean", "Whether the artifact is a revision of the API"),
+		field("revisionId", "integer", "ID of the revision, if the artifact is a revision"),
+		field("enableSchemaValidation", "boolean", "Whether requests and responses are validated against the "+
+			"API definition"),
+		field("type", "string", "Type of the API (HTTP, SOAP, SOAPTOREST, GRAPHQL, WS, WEBSUB, SSE, ...)"),
+		field("transport", "[]string", "Transports the API is exposed over (http, https)"),
+		field("tags", "[]string", "Tags of the API"),
+		field("policies", "[]string", "Subscription throttling policies available for the API"),
+		field("apiThrottlingPolicy", "string", "API level throttling policy"),
+		field("authorizationHeader", "string", "Header to read the access token from"),
+		field("securityScheme", "[]string", "Security schemes of the API (oauth2, api_key, basic_auth, "+
+			"mutualssl, oauth_basic_auth_api_key_mandatory, ...)"),
+		field("maxTps", "object", "Maximum transactions per second allowed to the backends",
+			field("production", "integer", "Maximum TPS of the production backend"),
+			field("sandbox", "integer", "Maximum TPS of the sandbox backend"),
+		),
+		field("visibility", "string", "Visibility of the API in the Dev Portal (PUBLIC, PRIVATE, RESTRICTED)"),
+		field("visibleRoles", "[]string", "Roles the API is visible to when the visibility is RESTRICTED"),
+		field("visibleTenants", "[]string", "Tenants the API is visible to"),
+		field("subscriptionAvailability", "string", "Tenants allowed to subscribe to the API "+
+			"(CURRENT_TENANT, ALL_TENANTS, SPECIFIC_TENANTS)"),
+		field("subscriptionAvailableTenants", "[]string", "Tenants allowed to subscribe when the availability "+
+			"is SPECIFIC_TENANTS"),
+		field("additionalProperties", "[]object", "Custom properties of the API",
+			field("name", "string", "Name of the property"),
+			field("value", "string", "Value of the property"),
+			field("display", "boolean", "Whether the property is shown in the Dev Portal"),
+		),
+		field("monetization", "object", "Monetization settings of the API"),
+		field("accessControl", "string", "Publisher access control of the API (NONE, RESTRICTED)"),
+		field("accessControlRoles", "[]string", "Roles allowed to view and edit the API in the Publisher"),
+		field("businessInformation", "object", "Business information of the API",
+			field("businessOwner", "string", "Name of the business owner"),
+			field("businessOwnerEmail", "string", "Email of the business owner"),
+			field("technicalOwner", "string", "Name of the technical owner"),
+			field("technicalOwnerEmail", "string", "Email of the technical owner"),
+		),
+		field("corsConfiguration", "object", "CORS settings of the API",
+			field("corsConfigurationEnabled", "boolean", "Whether CORS is enabled for the API"),
+			field("accessControlAllowOrigins", "[]string", "Allowed origins"),
+			field("accessControlAllowCredentials", "boolean", "Whether credentials are allowed"),
+			field("accessControlAllowHeaders", "[]string", "Allowed headers"),
+			field("accessControlAllowMethods", "[]string", "Allowed methods"),
+		),
+		field("websubSubscriptionConfiguration", "object", "Subscription settings of a WebSub API",
+			field("enable", "boolean", "Whether subscriptions are verified"),
+			field("secret", "string", "Secret used to sign the subscriptions"),
+			field("signingAlgorithm", "string", "Algorithm used to sign the subscriptions"),
+			field("signatureHeader", "string", "Header which holds the signature"),
+		),
+		field("workflowStatus", "string", "Status of the API creation workflow"),
+		field("endpointConfig", "object", "Backend endpoints of the API. Can be overridden per environment "+
+			"with the params file",
+			field("endpoint_type", "string", "Type of the endpoints (http, address, load_balance, failover, "+
+				"awslambda, default, ...)"),
+			field("production_endpoints", "object", "Production endpoint", endpointFields...),
+			field("sandbox_endpoints", "object", "Sandbox endpoint", endpointFields...),
+			field("endpoint_security", "object", "Credentials used to invoke the endpoints",
+				field("production", "object", "Credentials of the production endpoint"),
+				field("sandbox", "object", "Credentials of the sandbox endpoint"),
+			),
+		),
+		field("endpointImplementationType", "string", "Whether the API proxies the endpoints (ENDPOINT) or is "+
+			"mocked in the gateway (INLINE)"),
+		field("scopes", "[]object", "OAuth scopes of the API",
+			field("scope", "object", "The scope",
+				field("name", "string", "Name of the scope"),
+				field("displayName", "string", "Display name of the scope"),
+				field("description", "string", "Description of the scope"),
+				field("bindings", "[]string", "Roles bound to the scope"),
+			),
+			field("shared", "boolean", "Whether the scope is a shared scope"),
+		),
+		field("operations", "[]object", "Resources of the API",
+			field("target", "string", "Path of the resource"),
+			field("verb", "string", "HTTP method of the resource"),
+			field("authType", "string", "Authentication of the resource (Any, None, ...)"),
+			field("throttlingPolicy", "string", "Throttling policy of the resource"),
+			field("scopes", "[]string", "Scopes required to invoke the resource"),
+			field("operationPolicies", "object", "Policies attached to the resource",
+				field("request", "[]object", "Policies applied to the request flow"),
+				field("response", "[]object", "Policies applied to the response flow"),
+				field("fault", "[]object", "Policies applied to the fault flow"),
+			).since(operationPoliciesSupportedVersion),
+		),
+		field("apiPolicies", "object", "Policies attached to all the resources of the API",
+			field("request", "[]object", "Policies applied to the request flow"),
+			field("response", "[]object", "Policies applied to the response flow"),
+			field("fault", "[]object", "Policies applied to the fault flow"),
+		).since(operationPoliciesSupportedVersion),
+		field("categories", "[]string", "API categories the API belongs to"),
+		field("keyManagers", "[]string", "Key managers allowed to issue tokens for the API"),
+		field("advertiseInfo", "object", "Settings of an API advertised in the Dev Portal but hosted elsewhere",
+			field("advertised", "boolean", "Whether the API is advertise only"),
+			field("apiExternalProductionEndpoint", "string", "Production endpoint of the advertised API"),
+			field("apiExternalSandboxEndpoint", "string", "Sandbox endpoint of the advertised API"),
+			field("originalDevPortalUrl", "string", "Dev Portal URL of the advertised API"),
+			field("apiOwner", "string", "Owner of the advertised API"),
+			field("vendor", "string", "Vendor of the advertised API (WSO2, AWS, ...)"),
+		),
+		field("gatewayVendor", "string", "Vendor of the gateway the API is deployed to (wso2, external)"),
+		field("gatewayType", "string", "Type of the gateway the API is deployed to").since("v4.1.0"),
+		field("asyncTransportProtocols", "[]string", "Transports of a streaming API"),
+		field("enableSubscriberVerification", "boolean", "Whether WebSub subscribers are verified"),
+		field("subtypeConfiguration", "object", "Settings of an API subtype such as an AI API",
+			field("subtype", "string", "Subtype of the API (DEFAULT, AIAPI)"),
+			field("configuration", "object", "Settings of the subtype"),
+		).since("v4.3.0"),
+	)),
+}
+
+var applicationDocument = artifactDocument{
+	FileName:    utils.ApplicationDefinitionFileYaml,
+	Description: "Definition of an Application in an exported Application project",
+	Fields: artifactHeaderFields("application", field("data", "object", "The Application",
+		field("applicationInfo", "object", "Details of the Application",
+			field("name", "string", "Name of the Application"),
+			field("throttlingPolicy", "string", "Application throttling policy"),
+			field("description", "string", "Description of the Application"),
+			field("tokenType", "string", "Type of the tokens issued for the Application (JWT, OAUTH)"),
+			field("status", "string", "Approval status of the Application"),
+			field("groups", "[]string", "Groups the Application is shared with"),
+			field("subscriptionCount", "integer", "Number of subscriptions of the Application"),
+			field("keys", "[]object", "Keys of the Application, present only when exported with keys",
+				field("keyManager", "string", "Key manager the keys were generated from"),
+				field("consumerKey", "string", "Consumer key"),
+				field("consumerSecret", "string", "Consumer secret"),
+				field("supportedGrantTypes", "[]string", "Grant types allowed for the keys"),
+				field("callbackUrl", "string", "Callback URL of the keys"),
+				field("keyType", "string", "Type of the keys (PRODUCTION, SANDBOX)"),
+			),
+			field("subscriptionScopes", "[]object", "Scopes of the subscribed APIs"),
+			field("owner", "string", "Owner of the Application"),
+			field("hashEnabled", "boolean", "Whether the consumer secrets are hashed"),
+			field("attributes", "object", "Custom attributes of the Application"),
+		),
+		field("subscribedAPIs", "[]object", "Subscriptions of the Application",
+			field("apiId", "object", "Identifier of the subscribed API",
+				field("providerName", "string", "Provider of the API"),
+				field("apiName", "string", "Name of the API"),
+				field("version", "string", "Version of the API"),
+			),
+			field("throttlingPolicy", "string", "Subscription throttling policy"),
+		),
+	)),
+}
+
+var deploymentEnvironmentsDocument = artifactDocument{
+	FileName:    utils.DeploymentEnvFile,
+	Description: "Gateway environments an API project is deployed to when it is imported",
+	Fields: artifactHeaderFields("deployment_environments", field("data", "[]object",
+		"Gateway environments to deploy the API to",
+		field("deploymentEnvironment", "string", "Name of the gateway environment"),
+		field("deploymentVhost", "string", "Virtual host to deploy the API to in the gateway environment"),
+		field("displayOnDevportal", "boolean", "Whether the gateway URLs are shown in the Dev Portal"),
+	)),
+}
+
+// explainableArtifacts are the artifacts which can be explained, keyed by the artifact name
+var explainableArtifacts = map[string]func() (artifactDocument, error){
+	"api":                     func() (artifactDocument, error) { return apiDocument, nil },
+	"api_params":              apiParamsDocument,
+	"application":             func() (artifactDocument, error) { return applicationDocument, nil },
+	"deployment_environments": func() (artifactDocument, error) { return deploymentEnvironmentsDocument, nil },
+}
+
+// apiParamsDocument builds the fields of the API params file from params.ApiParamsSchema, which is also used to
+// validate params files
+func apiParamsDocument() (artifactDocument, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(params.ApiParamsSchema), &schema); err != nil {
+		return artifactDocument{}, err
+	}
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	root := fieldFromSchema("", schema, definitions)
+	return artifactDocument{FileName: "api_params.yaml", Description: root.Description, Fields: root.Fields}, nil
+}
+
+func fieldFromSchema(name string, schema, definitions map[string]interface{}) ArtifactField {
+	if ref, ok := schema["$ref"].(string); ok {
+		if definition, found := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{}); found {
+			schema = definition
+		}
+	}
+	f := ArtifactField{Name: name}
+	f.Type, _ = schema["type"].(string)
+	f.Description, _ = schema["description"].(string)
+	if items, ok := schema["items"].(map[string]interface{}); ok && f.Type == "array" {
+		item := fieldFromSchema(name, items, definitions)
+		f.Type = "[]" + item.Type
+		f.Fields = item.Fields
+		if f.Description == "" {
+			f.Description = item.Description
+		}
+		return f
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for propertyName, property := range properties {
+		if propertySchema, ok := property.(map[string]interface{}); ok {
+			f.Fields = append(f.Fields, fieldFromSchema(propertyName, propertySchema, definitions))
+		}
+	}
+	return f
+}
+
+// ExplainArtifact describes the field given by fieldPath (ex: api.data.endpointConfig) of an artifact as supported
+// by the given APIM version. The nested fields are listed recursively when recursive is set.
+func ExplainArtifact(fieldPath, apimVersion string, recursive bool) (string, error) {
+	version, err := utils.NormalizeAPIMVersion(apimVersion)
+	if err != nil {
+		return "", err
+	}
+	segments := strings.Split(fieldPath, ".")
+	// Allow the file name of the artifact as well (ex: api.yaml.data)
+	if len(segments) > 1 && (segments[1] == "yaml" || segments[1] == "yml") {
+		segments = append(segments[:1], segments[2:]...)
+	}
+	getDocument, found := explainableArtifacts[segments[0]]
+	if !found {
+		return "", fmt.Errorf("unknown artifact %q. Supported artifacts: %s", segments[0],
+			strings.Join(getExplainableArtifactNames(), ", "))
+	}
+	document, err := getDocument()
+	if err != nil {
+		return "", err
+	}
+
+	current := ArtifactField{Description: document.Description, Type: "object", Fields: document.Fields}
+	for i, name := range segments[1:] {
+		next := findArtifactField(current.Fields, name, version)
+		if next == nil {
+			return "", fmt.Errorf("field %q does not exist in %s for APIM %s", strings.Join(segments[1:i+2], "."),
+				document.FileName, version)
+		}
+		current = *next
+	}
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "ARTIFACT: %s\nVERSION:  %s\n\n", document.FileName, version)
+	if len(segments) > 1 {
+		fmt.Fprintf(builder, "FIELD:    %s <%s>\n\n", strings.Join(segments[1:], "."), current.Type)
+	}
+	fmt.Fprintf(builder, "DESCRIPTION:\n     %s\n", current.Description)
+	if fields := supportedFields(current.Fields, version); len(fields) > 0 {
+		builder.WriteString("\nFIELDS:\n")
+		writeArtifactFields(builder, fields, version, recursive, 1)
+	}
+	return builder.String(), nil
+}
+
+func writeArtifactFields(builder *strings.Builder, fields []ArtifactField, version string, recursive bool,
+	depth int) {
+	indent := strings.Repeat("   ", depth)
+	for _, f := range fields {
+		fmt.Fprintf(builder, "%s%s\t<%s>\n", indent, f.Name, f.Type)
+		if recursive {
+			writeArtifactFields(builder, supportedFields(f.Fields, version), version, recursive, depth+1)
+			continue
+		}
+		fmt.Fprintf(builder, "%s  %s\n\n", indent, f.Description)
+	}
+}
+
+// supportedFields returns the fields supported by the given APIM version sorted by name
+func supportedFields(fields []ArtifactField, version string) []ArtifactField {
+	supported := make([]ArtifactField, 0, len(fields))
+	for _, f := range fields {
+		if isFieldSupported(f, version) {
+			supported = append(supported, f)
+		}
+	}
+	sort.Slice(supported, func(i, j int) bool { return supported[i].Name < supported[j].Name })
+	return supported
+}
+
+func findArtifactField(fields []ArtifactField, name, version string) *ArtifactField {
+	for i := range fields {
+		if fields[i].Name == name && isFieldSupported(fields[i], version) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// isFieldSupported compares the versions by their order in utils.SupportedAPIMVersions
+func isFieldSupported(f ArtifactField, version string) bool {
+	if f.Since == "" {
+		return true
+	}
+	return apimVersionIndex(version) >= apimVersionIndex(f.Since)
+}
+
+func apimVersionIndex(version string) int {
+	for i, supportedVersion := range utils.SupportedAPIMVersions {
+		if supportedVersion == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func getExplainableArtifactNames() []string {
+	names := make([]string, 0, len(explainableArtifacts))
+	for name := range explainableArtifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
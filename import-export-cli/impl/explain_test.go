@@ -0,0 +1,82 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainArtifactTopLevel(t *testing.T) {
+	explanation, err := ExplainArtifact("api", "4.2.0", false)
+	assert.Nil(t, err)
+	assert.Contains(t, explanation, "ARTIFACT: api.yaml")
+	assert.Contains(t, explanation, "VERSION:  v4.2.0")
+	assert.Contains(t, explanation, "data\t<object>")
+	assert.Contains(t, explanation, "version\t<string>")
+}
+
+func TestExplainArtifactNestedField(t *testing.T) {
+	explanation, err := ExplainArtifact("api.yaml.data.endpointConfig", "v4.2.0", false)
+	assert.Nil(t, err)
+	assert.Contains(t, explanation, "FIELD:    data.endpointConfig <object>")
+	assert.Contains(t, explanation, "production_endpoints\t<object>")
+	// Fields are listed in the alphabetical order
+	assert.True(t, strings.Index(explanation, "endpoint_security") < strings.Index(explanation, "sandbox_endpoints"))
+}
+
+func TestExplainArtifactFieldsOfAPIMVersion(t *testing.T) {
+	explanation, err := ExplainArtifact("api.data", "v4.0.0", false)
+	assert.Nil(t, err)
+	assert.NotContains(t, explanation, "gatewayType")
+	assert.NotContains(t, explanation, "subtypeConfiguration")
+
+	explanation, err = ExplainArtifact("api.data", "v4.3.0", false)
+	assert.Nil(t, err)
+	assert.Contains(t, explanation, "gatewayType\t<string>")
+	assert.Contains(t, explanation, "subtypeConfiguration\t<object>")
+
+	_, err = ExplainArtifact("api.data.gatewayType", "v4.0.0", false)
+	assert.NotNil(t, err)
+}
+
+func TestExplainArtifactParamsFromSchema(t *testing.T) {
+	explanation, err := ExplainArtifact("api_params.environments.configs", "v4.2.0", true)
+	assert.Nil(t, err)
+	assert.Contains(t, explanation, "FIELD:    environments.configs <object>")
+	assert.Contains(t, explanation, "deploymentEnvironments\t<[]object>")
+	// Nested fields are listed without descriptions in the recursive mode
+	assert.Contains(t, explanation, "      deploymentVhost\t<string>")
+	assert.Contains(t, explanation, "   policies\t<[]string>")
+}
+
+func TestExplainArtifactErrors(t *testing.T) {
+	_, err := ExplainArtifact("swagger", "v4.2.0", false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "api, api_params, application, deployment_environments")
+
+	_, err = ExplainArtifact("application.data.unknown", "v4.2.0", false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "data.unknown")
+
+	_, err = ExplainArtifact("api", "3.2.0", false)
+	assert.NotNil(t, err)
+}
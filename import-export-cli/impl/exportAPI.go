@@ -79,10 +79,11 @@ func exportAPI(name, version, revisionNum, provider, format, publisherEndpoint,
 // @param exportAPIRevisionNumber: Revision number of the api
 // @param zipLocationPath: Path to the export directory
 // @param runningExportApiCommand: Whether the export API command is running
+// @param normalize: Whether to normalize the exported files for version control
 // @param resp : Response returned from making the HTTP request (only pass a 200 OK)
 // Exported API will be written to a zip file
 func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath string,
-	runningExportApiCommand bool, resp *resty.Response) {
+	runningExportApiCommand, normalize bool, resp *resty.Response) {
 	zipFilename := exportAPIName + "_" + exportAPIVersion
 	if exportAPIRevisionNumber != "" {
 		zipFilename += "_" + utils.GetRevisionNamFromRevisionNum(exportAPIRevisionNumber)
@@ -117,9 +118,43 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 		utils.HandleErrorAndExit("Error creating the final zip archive with api_meta.yaml file", err)
 	}
 
+	if normalize {
+		err = NormalizeExportedArchive(exportedFinalZip)
+		if err != nil {
+			utils.HandleErrorAndExit("Error normalizing the exported API", err)
+		}
+	}
+
 	// Output the final zip file location.
 	if runningExportApiCommand {
 		fmt.Println("Successfully exported API!")
 		fmt.Println("Find the exported API at " + exportedFinalZip)
 	}
 }
+
+// NormalizeExportedArchive rewrites an exported archive with the keys of the yaml and json files sorted and the
+// volatile fields listed in export_normalize_excluded_fields of the main config stripped, so that exporting an
+// unchanged API yields the same files
+// @param archivePath : Path to the exported archive
+// @return error
+func NormalizeExportedArchive(archivePath string) error {
+	tmpClonedLoc, err := utils.GetTempCloneFromDirOrZip(archivePath)
+	if err != nil {
+		return err
+	}
+	err = utils.NormalizeProjectFiles(tmpClonedLoc, getNormalizeExcludedFields())
+	if err != nil {
+		return err
+	}
+	return utils.Zip(tmpClonedLoc, archivePath)
+}
+
+// getNormalizeExcludedFields returns the fields stripped when normalizing, which are given by
+// export_normalize_excluded_fields of the main config
+func getNormalizeExcludedFields() []string {
+	excludedFields := utils.GetMainConfigFromFile(utils.MainConfigFilePath).Config.ExportNormalizeExcludedFields
+	if len(excludedFields) == 0 {
+		excludedFields = utils.DefaultExportNormalizeExcludedFields
+	}
+	return excludedFields
+}
@@ -19,6 +19,7 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -142,40 +143,39 @@ func getRevisionsListForAPI(accessToken, cmdExportEnvironment string, api utils.
 	return GetRevisionListFromEnv(accessToken, cmdExportEnvironment, api.Name, api.Version, api.Provider, query)
 }
 
-// Do the API exportation
+// Do the API exportation. The APIs of each iteration are exported by parallel number of workers.
 func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
-	exportAPIsFormat, cmdUsername, apiExportDir string, exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions bool) {
+	exportAPIsFormat, cmdUsername, apiExportDir string, exportAPIPreserveStatus, runningExportApiCommand,
+	exportAllRevisions bool, parallel int) {
 	if count == 0 {
 		fmt.Println("No APIs available to be exported..!")
 	} else {
-		var counterSuceededAPIs = 0
+		summary := &utils.MigrationApisExportSummary{
+			Environment:     cmdExportEnvironment,
+			OnTenant:        cmdResourceTenantDomain,
+			ExportDirectory: apiExportDir,
+		}
 		for count > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", count, "of APIs to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(apiListOffset)+". Maximum limit of APIs exported in single iteration is "+
 				strconv.Itoa(utils.MaxAPIsToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
 			if preCommandErr == nil {
-				for i := startingApiIndexFromList; i < len(apis); i++ {
-					if exportAllRevisions {
-						//Export the working copy of the api
-						exportAPIandWriteToZip(apis[i], "", accessToken, cmdExportEnvironment, apiExportDir,
-							exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand)
-						counterSuceededAPIs++
-					}
-					revisionCount, revisions, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, apis[i],
-						exportAllRevisions)
-					if err != nil {
-						fmt.Println("An error occurred while getting the revisions list for API "+apis[i].Version+
-							"_"+apis[i].Version, err)
-					} else if revisionCount > 0 {
-						for j := 0; j < len(revisions); j++ {
-							exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
-							exportAPIandWriteToZip(apis[i], exportApiRevision, accessToken, cmdExportEnvironment,
-								apiExportDir, exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus,
-								runningExportApiCommand)
-							counterSuceededAPIs++
-						}
-					}
+				exportAPI := func(api utils.API) (int, error) {
+					return exportAPIWithRevisions(api, accessToken, cmdExportEnvironment, apiExportDir,
+						exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions)
+				}
+				onLastSucceeded := func(api utils.API) {
+					//write on last-succeeded-api.log
+					utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, api)
+				}
+				results := exportAPIsInParallel(apis[startingApiIndexFromList:], parallel, exportAPI, onLastSucceeded)
+				addToExportSummary(summary, results)
+				if summary.FailedApis > 0 {
+					utils.WriteMigrationApisExportSummaryFile(summary, exportRelatedFilesPath)
+					utils.HandleErrorAndExit("Error exporting "+strconv.Itoa(summary.FailedApis)+" API(s). Find the "+
+						"summary at "+filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportSummaryFileName)+
+						". Run the command again to resume the export from the first failed API", nil)
 				}
 			} else {
 				// error getting OAuth tokens
@@ -191,15 +191,123 @@ func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdEx
 					exportRelatedFilesPath, apiListOffset)
 			}
 		}
-		fmt.Println("\nTotal number of APIs exported: " + cast.ToString(counterSuceededAPIs))
+		utils.WriteMigrationApisExportSummaryFile(summary, exportRelatedFilesPath)
+		fmt.Println("\nTotal number of APIs exported: " + cast.ToString(summary.ExportedArchives))
 		fmt.Println("API export path: " + apiExportDir)
+		fmt.Println("Export summary: " + filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportSummaryFileName))
 		fmt.Println("\nCommand: export-apis execution completed !")
 	}
 }
 
+// Export the APIs using a pool of parallel workers and return the result of each API in the order of apisToExport.
+// exportAPI exports a single API and returns the number of archives written for it. onLastSucceeded is only called
+// for an API once all the APIs before it are exported, so that a halted export can be resumed from the API after it
+// without missing any API.
+func exportAPIsInParallel(apisToExport []utils.API, parallel int, exportAPI func(api utils.API) (int, error),
+	onLastSucceeded func(api utils.API)) []utils.MigrationApiExportResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	type exportResult struct {
+		index    int
+		archives int
+		err      error
+	}
+	jobs := make(chan int, len(apisToExport))
+	exportResults := make(chan exportResult, len(apisToExport))
+	for worker := 0; worker < parallel; worker++ {
+		go func() {
+			for i := range jobs {
+				archives, err := exportAPI(apisToExport[i])
+				exportResults <- exportResult{index: i, archives: archives, err: err}
+			}
+		}()
+	}
+	for i := range apisToExport {
+		jobs <- i
+	}
+	close(jobs)
+
+	results := make([]utils.MigrationApiExportResult, len(apisToExport))
+	completed := make([]bool, len(apisToExport))
+	nextToSucceed := 0
+	for done := 1; done <= len(apisToExport); done++ {
+		exported := <-exportResults
+		api := apisToExport[exported.index]
+		results[exported.index] = utils.MigrationApiExportResult{
+			Name:     api.Name,
+			Version:  api.Version,
+			Provider: api.Provider,
+			Archives: exported.archives,
+		}
+		if exported.err != nil {
+			results[exported.index].Error = exported.err.Error()
+			fmt.Printf("[%d/%d] Error exporting API %s %s of provider %s: %s\n", done, len(apisToExport), api.Name,
+				api.Version, api.Provider, exported.err.Error())
+			continue
+		}
+		fmt.Printf("[%d/%d] Exported API %s %s of provider %s\n", done, len(apisToExport), api.Name, api.Version,
+			api.Provider)
+		completed[exported.index] = true
+		lastSucceeded := nextToSucceed
+		for nextToSucceed < len(apisToExport) && completed[nextToSucceed] {
+			nextToSucceed++
+		}
+		if nextToSucceed > lastSucceeded {
+			onLastSucceeded(apisToExport[nextToSucceed-1])
+		}
+	}
+	return results
+}
+
+// Add the results of an iteration to the export summary
+func addToExportSummary(summary *utils.MigrationApisExportSummary, results []utils.MigrationApiExportResult) {
+	for _, result := range results {
+		summary.TotalApis++
+		summary.ExportedArchives += result.Archives
+		if result.Error != "" {
+			summary.FailedApis++
+		} else {
+			summary.SucceededApis++
+		}
+		summary.Apis = append(summary.Apis, result)
+	}
+}
+
+// Export the working copy (if exportAllRevisions is true) and the revisions of the API and return the number of
+// archives written
+func exportAPIWithRevisions(api utils.API, accessToken, cmdExportEnvironment, apiExportDir, exportAPIsFormat string,
+	exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions bool) (int, error) {
+	var archives int
+	if exportAllRevisions {
+		//Export the working copy of the api
+		if err := exportAPIandWriteToZip(api, "", accessToken, cmdExportEnvironment, apiExportDir,
+			exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand); err != nil {
+			return archives, err
+		}
+		archives++
+	}
+	revisionCount, revisions, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, api,
+		exportAllRevisions)
+	if err != nil {
+		fmt.Println("An error occurred while getting the revisions list for API "+api.Name+
+			"_"+api.Version, err)
+	} else if revisionCount > 0 {
+		for j := 0; j < len(revisions); j++ {
+			exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
+			if err := exportAPIandWriteToZip(api, exportApiRevision, accessToken, cmdExportEnvironment,
+				apiExportDir, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand); err != nil {
+				return archives, err
+			}
+			archives++
+		}
+	}
+	return archives, nil
+}
+
 //Export the API and archive to zip format
 func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
-	exportRelatedFilesPath, exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand bool) {
+	exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand bool) error {
 
 	exportAPIName := api.Name
 	exportAPIVersion := api.Version
@@ -211,18 +319,16 @@ func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExpor
 	resp, err := ExportAPIFromEnv(accessToken, exportAPIName, exportAPIVersion, exportApiRevision,
 		exportApiProvider, exportAPIsFormat, cmdExportEnvironment, exportAPIPreserveStatus, false)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return err
 	}
 
 	if resp.StatusCode() == http.StatusOK {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, resp)
-		//write on last-succeeded-api.log
-		utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, api)
-	} else {
-		fmt.Println("Error exporting API:", exportAPIName, "-", exportAPIVersion, " of Provider:", exportApiProvider)
-		utils.PrintErrorResponseAndExit(resp)
+		WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, false,
+			resp)
+		return nil
 	}
+	return errors.New(resp.Status() + " " + string(resp.Body()))
 }
 
 // Create the required directory structure to save the exported APIs
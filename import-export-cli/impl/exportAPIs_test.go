@@ -0,0 +1,93 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestExportAPIsInParallel(t *testing.T) {
+	apisToExport := []utils.API{
+		{Name: "PizzaAPI", Version: "1.0.0", Provider: "admin"},
+		{Name: "PetAPI", Version: "1.0.0", Provider: "admin"},
+		{Name: "BookAPI", Version: "2.0.0", Provider: "admin"},
+		{Name: "CarAPI", Version: "1.0.0", Provider: "admin"},
+	}
+
+	var mutex sync.Mutex
+	exported := map[string]bool{}
+	var lastSucceeded []string
+	results := exportAPIsInParallel(apisToExport, 3, func(api utils.API) (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		exported[api.Name] = true
+		if api.Name == "BookAPI" {
+			return 1, errors.New("500 Internal Server Error")
+		}
+		return 2, nil
+	}, func(api utils.API) {
+		lastSucceeded = append(lastSucceeded, api.Name)
+	})
+
+	assert.Len(t, exported, 4, "All the APIs should be exported")
+	assert.Len(t, results, 4)
+	for i, result := range results {
+		assert.Equal(t, apisToExport[i].Name, result.Name, "Results should be in the order of the APIs")
+	}
+	assert.Equal(t, "500 Internal Server Error", results[2].Error)
+	assert.Equal(t, 1, results[2].Archives)
+	assert.Empty(t, results[3].Error)
+	assert.Equal(t, 2, results[3].Archives)
+
+	// The last succeeded API should never pass the failed API
+	assert.NotEmpty(t, lastSucceeded)
+	assert.Equal(t, "PetAPI", lastSucceeded[len(lastSucceeded)-1])
+
+	summary := &utils.MigrationApisExportSummary{}
+	addToExportSummary(summary, results)
+	assert.Equal(t, 4, summary.TotalApis)
+	assert.Equal(t, 3, summary.SucceededApis)
+	assert.Equal(t, 1, summary.FailedApis)
+	assert.Equal(t, 7, summary.ExportedArchives)
+}
+
+func TestExportAPIsInParallelWithSingleWorker(t *testing.T) {
+	apisToExport := []utils.API{
+		{Name: "PizzaAPI", Version: "1.0.0", Provider: "admin"},
+		{Name: "PetAPI", Version: "1.0.0", Provider: "admin"},
+	}
+
+	var order []string
+	var lastSucceeded []string
+	results := exportAPIsInParallel(apisToExport, 0, func(api utils.API) (int, error) {
+		order = append(order, api.Name)
+		return 1, nil
+	}, func(api utils.API) {
+		lastSucceeded = append(lastSucceeded, api.Name)
+	})
+
+	assert.Equal(t, []string{"PizzaAPI", "PetAPI"}, order, "APIs should be exported in order by a single worker")
+	assert.Equal(t, []string{"PizzaAPI", "PetAPI"}, lastSucceeded)
+	assert.Len(t, results, 2)
+}
@@ -73,9 +73,10 @@ func ExportApp(name, owner, format, devportalApplicationsEndpoint, accessToken s
 // @param exportAppName : Name of the Application to be exported
 // @param exportAppOwner : Owner of the Application to be exported
 // @param resp : Response returned from making the HTTP request (only pass a 200 OK)
+// @param keyMappings : Key mappings of the Application to include in the zip file, if any
 // Exported Application will be written to a zip file
 func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string,
-	resp *resty.Response) {
+	resp *resty.Response, keyMappings []ApplicationKeyMapping) {
 	zipFilename := replaceUserStoreDomainDelimiter(exportAppOwner) + "_" + exportAppName + ".zip" // admin_testApp.zip
 	// Writes the REST API response to a temporary zip file
 	tempZipFile, err := utils.WriteResponseToTempZip(zipFilename, resp)
@@ -83,6 +84,13 @@ func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string
 		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported application", err)
 	}
 
+	if len(keyMappings) > 0 {
+		err = includeApplicationKeyMappingsToZip(tempZipFile, keyMappings)
+		if err != nil {
+			utils.HandleErrorAndExit("Error including the key mappings in the exported application", err)
+		}
+	}
+
 	err = utils.CreateDirIfNotExist(zipLocationPath)
 	if err != nil {
 		utils.HandleErrorAndExit("Error creating dir to store zip archive: "+zipLocationPath, err)
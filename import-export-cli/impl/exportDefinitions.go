@@ -0,0 +1,254 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// ExportedDefinition is the OpenAPI definition of an API written by export definitions
+type ExportedDefinition struct {
+	Name            string
+	Version         string
+	Provider        string
+	LifeCycleStatus string
+	// Path of the written definition
+	Path string
+	// ServerURLs are the gateway URLs injected into the definition
+	ServerURLs []string
+}
+
+// SkippedDefinition is an API whose OpenAPI definition could not be exported
+type SkippedDefinition struct {
+	Name    string
+	Version string
+	Reason  string
+}
+
+// ExportDefinitionsFromEnv writes the OpenAPI definitions of the APIs matching the query to the output directory,
+// with the gateway URLs the APIs are deployed in as the servers of the definitions
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to export the definitions from
+// @param query			: Search query of the APIs (ex: tag:public). All the APIs are exported when empty
+// @param outputDir		: Directory to write the definitions to
+// @param format		: File format of the definitions (json or yaml)
+// @return exported definitions, skipped APIs, error
+func ExportDefinitionsFromEnv(accessToken, environment, query, outputDir, format string) ([]ExportedDefinition,
+	[]SkippedDefinition, error) {
+	return exportDefinitions(accessToken, utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath),
+		query, outputDir, format)
+}
+
+func exportDefinitions(accessToken, publisherEndpoint, query, outputDir, format string) ([]ExportedDefinition,
+	[]SkippedDefinition, error) {
+	format = strings.ToLower(format)
+	if format != "json" && format != "yaml" {
+		return nil, nil, errors.New("unsupported format " + format + ", use json or yaml")
+	}
+	publisherEndpoint = utils.AppendSlashToString(publisherEndpoint)
+	settings, err := getPublisherSettings(accessToken, publisherEndpoint+"settings")
+	if err != nil {
+		return nil, nil, err
+	}
+	apis, err := getAPIsMatchingQuery(accessToken, publisherEndpoint+"apis", query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return nil, nil, err
+	}
+
+	exported := []ExportedDefinition{}
+	skipped := []SkippedDefinition{}
+	for _, api := range apis {
+		apiEndpoint := publisherEndpoint + "apis/" + api.ID
+		definition, err := getAPIDefinition(accessToken, apiEndpoint+"/swagger")
+		if err != nil {
+			// APIs without an OpenAPI definition (ex: GraphQL and streaming APIs) are skipped
+			skipped = append(skipped, SkippedDefinition{Name: api.Name, Version: api.Version, Reason: err.Error()})
+			continue
+		}
+		deployments, err := getAPIDeployments(accessToken, apiEndpoint+"/deployments")
+		if err != nil {
+			return nil, nil, fmt.Errorf("error while retrieving the deployments of %s %s: %v", api.Name,
+				api.Version, err)
+		}
+		serverURLs := getGatewayURLs(settings.Environments, deployments, getGatewayContext(api.Context, api.Version))
+		content, err := marshalDefinition(setDefinitionServers(definition, serverURLs), format)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error while writing the definition of %s %s: %v", api.Name, api.Version,
+				err)
+		}
+		path := filepath.Join(outputDir, api.Name+"_"+api.Version+"."+format)
+		if err = ioutil.WriteFile(path, content, 0644); err != nil {
+			return nil, nil, err
+		}
+		exported = append(exported, ExportedDefinition{Name: api.Name, Version: api.Version, Provider: api.Provider,
+			LifeCycleStatus: api.LifeCycleStatus, Path: path, ServerURLs: serverURLs})
+	}
+	return exported, skipped, nil
+}
+
+// getAPIsMatchingQuery retrieves all the APIs matching the query
+func getAPIsMatchingQuery(accessToken, apiListEndpoint, query string) ([]utils.API, error) {
+	queryParams := map[string]string{}
+	if query != "" {
+		queryParams["query"] = query
+	}
+	apis := []utils.API{}
+	for {
+		apiList := &utils.APIListResponse{}
+		err := getPreviewPage(accessToken, apiListEndpoint, queryParams, len(apis), "APIs", apiList)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, apiList.List...)
+		if len(apiList.List) < previewPageLimit {
+			return apis, nil
+		}
+	}
+}
+
+// getAPIDefinition retrieves the OpenAPI definition of an API
+func getAPIDefinition(accessToken, definitionEndpoint string) (yaml.MapSlice, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	utils.Logln(utils.LogPrefixInfo+"URL:", definitionEndpoint)
+	resp, err := utils.InvokeGETRequest(definitionEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the OpenAPI definition. Status: " +
+			resp.Status())
+	}
+	definition := yaml.MapSlice{}
+	if err = yaml.Unmarshal(resp.Body(), &definition); err != nil {
+		return nil, err
+	}
+	if getMapSliceValue(definition, "openapi") == nil && getMapSliceValue(definition, "swagger") == nil {
+		return nil, errors.New("the definition of the API is not an OpenAPI definition")
+	}
+	return definition, nil
+}
+
+// getGatewayContext returns the context the API is exposed in the gateway, which includes the version of the API
+func getGatewayContext(context, version string) string {
+	if strings.Contains(context, "{version}") {
+		return strings.ReplaceAll(context, "{version}", version)
+	}
+	if strings.HasSuffix(context, "/"+version) {
+		return context
+	}
+	return strings.TrimSuffix(context, "/") + "/" + version
+}
+
+// getGatewayURLs returns the HTTPS URLs of the API in the gateway environments and vhosts it is deployed in
+func getGatewayURLs(environments []gatewayEnvironment, deployments []apiDeployment, gatewayContext string) []string {
+	urls := []string{}
+	added := map[string]bool{}
+	for _, deployment := range deployments {
+		vhost := gatewayVhost{Host: deployment.Vhost}
+		for _, environment := range environments {
+			if environment.Name != deployment.Name {
+				continue
+			}
+			for _, environmentVhost := range environment.Vhosts {
+				if environmentVhost.Host == deployment.Vhost {
+					vhost = environmentVhost
+				}
+			}
+		}
+		if vhost.Host == "" {
+			continue
+		}
+		url := "https://" + vhost.Host
+		if vhost.HTTPSPort != 0 && vhost.HTTPSPort != 443 {
+			url += ":" + strconv.Itoa(vhost.HTTPSPort)
+		}
+		if httpContext := strings.Trim(vhost.HTTPContext, "/"); httpContext != "" {
+			url += "/" + httpContext
+		}
+		url += gatewayContext
+		if !added[url] {
+			added[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// setDefinitionServers replaces the servers of an OpenAPI 3 definition with the gateway URLs. The host, base path
+// and schemes of a Swagger 2 definition are set from the first gateway URL. The definition is not changed if the
+// API is not deployed in any gateway.
+func setDefinitionServers(definition yaml.MapSlice, serverURLs []string) yaml.MapSlice {
+	if len(serverURLs) == 0 {
+		return definition
+	}
+	if getMapSliceValue(definition, "openapi") != nil {
+		servers := make([]yaml.MapSlice, 0, len(serverURLs))
+		for _, serverURL := range serverURLs {
+			servers = append(servers, yaml.MapSlice{{Key: "url", Value: serverURL}})
+		}
+		return setMapSliceValue(definition, "servers", servers)
+	}
+	hostAndPath := strings.TrimPrefix(serverURLs[0], "https://")
+	host, basePath := hostAndPath, "/"
+	if i := strings.Index(hostAndPath, "/"); i >= 0 {
+		host, basePath = hostAndPath[:i], hostAndPath[i:]
+	}
+	definition = setMapSliceValue(definition, "host", host)
+	definition = setMapSliceValue(definition, "basePath", basePath)
+	return setMapSliceValue(definition, "schemes", []string{"https"})
+}
+
+func marshalDefinition(definition yaml.MapSlice, format string) ([]byte, error) {
+	content, err := yaml.Marshal(definition)
+	if err != nil || format == "yaml" {
+		return content, err
+	}
+	return yamlToIndentedJson(content)
+}
+
+// PrintExportedDefinitions prints the exported definitions along with the APIs which were skipped
+// @param exported	: Exported definitions
+// @param skipped	: APIs whose definitions were not exported
+func PrintExportedDefinitions(exported []ExportedDefinition, skipped []SkippedDefinition) {
+	for _, definition := range exported {
+		fmt.Println("Exported the definition of " + definition.Name + " " + definition.Version + " to " +
+			definition.Path)
+		if len(definition.ServerURLs) == 0 {
+			fmt.Println("  The API is not deployed in any gateway, the servers of the definition are not changed")
+		}
+	}
+	for _, api := range skipped {
+		fmt.Println("Skipped " + api.Name + " " + api.Version + ": " + api.Reason)
+	}
+	fmt.Printf("\n%d definition(s) exported, %d API(s) skipped\n", len(exported), len(skipped))
+}
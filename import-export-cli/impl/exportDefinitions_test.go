@@ -0,0 +1,131 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestExportDefinitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/settings":
+			_, _ = w.Write([]byte(`{"environment": [{"name": "Default", "vhosts": [{"host": "gw.example.com",
+				"httpContext": "", "httpsPort": 8243}, {"host": "api.example.com", "httpsPort": 443}]}]}`))
+		case "/apis":
+			assert.Equal(t, "tag:public", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count": 3, "list": [
+				{"id": "pizza", "name": "PizzaShackAPI", "context": "/pizzashack", "version": "1.0.0"},
+				{"id": "legacy", "name": "LegacyAPI", "context": "/legacy/{version}", "version": "v1"},
+				{"id": "graphql", "name": "StarWarsAPI", "context": "/swapi", "version": "1.0.0"}]}`))
+		case "/apis/pizza/swagger":
+			_, _ = w.Write([]byte(`{"openapi": "3.0.1", "info": {"title": "PizzaShackAPI", "version": "1.0.0"},
+				"servers": [{"url": "/"}], "paths": {"/menu": {"get": {"responses": {"200": {"description": "OK"}}}}}}`))
+		case "/apis/pizza/deployments":
+			_, _ = w.Write([]byte(`{"list": [{"name": "Default", "vhost": "gw.example.com"},
+				{"name": "Default", "vhost": "api.example.com"}]}`))
+		case "/apis/legacy/swagger":
+			_, _ = w.Write([]byte("swagger: '2.0'\ninfo:\n  title: LegacyAPI\n  version: v1\npaths: {}\n"))
+		case "/apis/legacy/deployments":
+			_, _ = w.Write([]byte(`{"list": [{"name": "Default", "vhost": "gw.example.com"}]}`))
+		case "/apis/graphql/swagger":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("Unexpected request to %s\n", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	outputDir := t.TempDir()
+	exported, skipped, err := exportDefinitions("access-token", server.URL, "tag:public", outputDir, "JSON")
+	assert.Nil(t, err)
+	assert.Len(t, exported, 2)
+	assert.Len(t, skipped, 1)
+	assert.Equal(t, "StarWarsAPI", skipped[0].Name)
+
+	assert.Equal(t, filepath.Join(outputDir, "PizzaShackAPI_1.0.0.json"), exported[0].Path)
+	assert.Equal(t, []string{"https://gw.example.com:8243/pizzashack/1.0.0", "https://api.example.com/pizzashack/1.0.0"},
+		exported[0].ServerURLs)
+	content, err := ioutil.ReadFile(exported[0].Path)
+	assert.Nil(t, err)
+	definition := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(content, &definition))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"url": "https://gw.example.com:8243/pizzashack/1.0.0"},
+		map[string]interface{}{"url": "https://api.example.com/pizzashack/1.0.0"},
+	}, definition["servers"])
+	assert.Contains(t, definition["paths"], "/menu")
+
+	content, err = ioutil.ReadFile(exported[1].Path)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(content, &definition))
+	assert.Equal(t, "gw.example.com:8243", definition["host"])
+	assert.Equal(t, "/legacy/v1", definition["basePath"])
+	assert.Equal(t, []interface{}{"https"}, definition["schemes"])
+}
+
+func TestExportDefinitionsYAML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/settings":
+			_, _ = w.Write([]byte(`{"environment": []}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"count": 1, "list": [{"id": "pizza", "name": "PizzaShackAPI",
+				"context": "/pizzashack", "version": "1.0.0"}]}`))
+		case "/apis/pizza/swagger":
+			_, _ = w.Write([]byte(`{"openapi": "3.0.1", "info": {"title": "PizzaShackAPI", "version": "1.0.0"},
+				"servers": [{"url": "https://backend.example.com"}], "paths": {}}`))
+		case "/apis/pizza/deployments":
+			_, _ = w.Write([]byte(`{"list": []}`))
+		default:
+			t.Errorf("Unexpected request to %s\n", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	exported, _, err := exportDefinitions("access-token", server.URL, "", t.TempDir(), "yaml")
+	assert.Nil(t, err)
+	assert.Len(t, exported, 1)
+	assert.Empty(t, exported[0].ServerURLs)
+	content, err := ioutil.ReadFile(exported[0].Path)
+	assert.Nil(t, err)
+	definition := yaml.MapSlice{}
+	assert.Nil(t, yaml.Unmarshal(content, &definition))
+	assert.Equal(t, "openapi", definition[0].Key, "Should keep the order of the fields")
+	assert.Contains(t, string(content), "url: https://backend.example.com",
+		"Should not change the servers of an API which is not deployed")
+
+	_, _, err = exportDefinitions("access-token", server.URL, "", t.TempDir(), "xml")
+	assert.NotNil(t, err)
+}
+
+func TestGetGatewayContext(t *testing.T) {
+	assert.Equal(t, "/pizzashack/1.0.0", getGatewayContext("/pizzashack", "1.0.0"))
+	assert.Equal(t, "/pizzashack/1.0.0", getGatewayContext("/pizzashack/1.0.0", "1.0.0"))
+	assert.Equal(t, "/v1/orders", getGatewayContext("/{version}/orders", "v1"))
+	assert.Equal(t, "/t/wso2.com/pizzashack/1.0.0", getGatewayContext("/t/wso2.com/pizzashack/", "1.0.0"))
+}
@@ -20,12 +20,14 @@ package impl
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/aybabtme/orderedjson"
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 )
@@ -61,16 +63,7 @@ func ExportThrottlingPolicyFromEnv(accessToken string, exportEnvironment string,
 	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
 	throttlePolicyResource := "throttling/policies/export?"
 	if throttlePolicyType != "" {
-		switch throttlePolicyType {
-		case CmdPolicyTypeSubscription:
-			policyType = QueryPolicyTypeSubscription
-		case CmdPolicyTypeApplication:
-			policyType = QueryPolicyTypeApplication
-		case CmdPolicyTypeAdvanced:
-			policyType = QueryPolicyTypeAdvanced
-		case CmdPolicyTypeCustom:
-			policyType = QueryCmdPolicyTypeCustom
-		}
+		policyType = getThrottlePolicyQueryType(throttlePolicyType)
 		query = `name=` + throttlePolicyName + `&type=` + policyType + `&format=` + exportFormat
 	} else {
 		query = `name=` + throttlePolicyName + `&format=` + exportFormat
@@ -87,6 +80,83 @@ func ExportThrottlingPolicyFromEnv(accessToken string, exportEnvironment string,
 	return resp, nil
 }
 
+// ExportThrottlingPoliciesOfTypeFromEnv exports all the Throttling Policies of a given type with the export policy
+// rate-limiting command
+// @param accessToken : Access token to call the admin REST API
+// @param exportEnvironment : Environment from which the policies are exported
+// @param throttlePolicyType : Type of the policies to be exported (sub, app, advanced, custom)
+// @param exportFormat : File format of the exported policies
+// @param exportLocationPath : Directory to write the exported policies
+// @return number of exported policies, error
+func ExportThrottlingPoliciesOfTypeFromEnv(accessToken, exportEnvironment, throttlePolicyType, exportFormat,
+	exportLocationPath string) (int, error) {
+	resp, err := GetThrottlePolicyListFromEnv(accessToken, exportEnvironment,
+		"type:"+getThrottlePolicyQueryType(throttlePolicyType))
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return 0, errors.New("Error getting Throttling Policies: " + resp.Status() + " " + string(resp.Body()))
+	}
+	var policyList utils.ThrottlingPoliciesDetailsList
+	if err = json.Unmarshal(resp.Body(), &policyList); err != nil {
+		return 0, err
+	}
+	for i, policy := range policyList.List {
+		fmt.Println("Exporting Throttling Policy " + policy.PolicyName)
+		resp, err = ExportThrottlingPolicyFromEnv(accessToken, exportEnvironment, policy.PolicyName,
+			throttlePolicyType, exportFormat)
+		if err != nil {
+			return i, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return i, errors.New("Error exporting Throttling Policy " + policy.PolicyName + ": " + resp.Status() +
+				" " + string(resp.Body()))
+		}
+		WriteThrottlePolicyToFile(exportLocationPath, resp, exportFormat, false)
+	}
+	return len(policyList.List), nil
+}
+
+// ExportAllThrottlingPoliciesFromEnv exports the Throttling Policies of all the types (sub, app, advanced, custom) with
+// the export policy rate-limiting command, so that all the policies of an environment can be version controlled
+// @param accessToken : Access token to call the admin REST API
+// @param exportEnvironment : Environment from which the policies are exported
+// @param exportFormat : File format of the exported policies
+// @param exportLocationPath : Directory to write the exported policies
+// @return number of exported policies, error
+func ExportAllThrottlingPoliciesFromEnv(accessToken, exportEnvironment, exportFormat,
+	exportLocationPath string) (int, error) {
+	total := 0
+	for _, throttlePolicyType := range []string{CmdPolicyTypeSubscription, CmdPolicyTypeApplication,
+		CmdPolicyTypeAdvanced, CmdPolicyTypeCustom} {
+		count, err := ExportThrottlingPoliciesOfTypeFromEnv(accessToken, exportEnvironment, throttlePolicyType,
+			exportFormat, exportLocationPath)
+		total += count
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// getThrottlePolicyQueryType resolves the type of a Throttling Policy used by the admin REST API
+// @param throttlePolicyType : Type of the policy given to the command (sub, app, advanced, custom)
+// @return type of the policy used by the admin REST API
+func getThrottlePolicyQueryType(throttlePolicyType string) string {
+	switch throttlePolicyType {
+	case CmdPolicyTypeSubscription:
+		return QueryPolicyTypeSubscription
+	case CmdPolicyTypeApplication:
+		return QueryPolicyTypeApplication
+	case CmdPolicyTypeAdvanced:
+		return QueryPolicyTypeAdvanced
+	case CmdPolicyTypeCustom:
+		return QueryCmdPolicyTypeCustom
+	}
+	return ""
+}
+
 // WriteThrottlePolicyToFile writes the policy to a specified location
 func WriteThrottlePolicyToFile(ExportLocationPath string, resp *resty.Response, ExportFormat string,
 	runningExportThrottlePolicyCommand bool) {
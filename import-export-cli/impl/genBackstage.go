@@ -0,0 +1,319 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// BackstageCatalogFileName is the name of the file the Backstage entities are written to
+	BackstageCatalogFileName = "catalog-info.yaml"
+
+	backstageAPIVersion    = "backstage.io/v1alpha1"
+	backstageAPIKind       = "API"
+	backstageMaxNameLength = 63
+	backstageSuperTenant   = "@carbon.super"
+	backstageContextKey    = "wso2.com/api-context"
+	backstageProviderKey   = "wso2.com/api-provider"
+	backstageOpenAPIType   = "openapi"
+	backstageAsyncAPIType  = "asyncapi"
+	backstageGraphQLType   = "graphql"
+	backstageExperimental  = "experimental"
+	backstageProduction    = "production"
+	backstageDeprecated    = "deprecated"
+	graphQLAPIType         = "GRAPHQL"
+)
+
+// backstageLifecycles maps the lifecycle states of APIM to the lifecycles of Backstage
+var backstageLifecycles = map[string]string{
+	"CREATED":      backstageExperimental,
+	"PROTOTYPED":   backstageExperimental,
+	"PRE-RELEASED": backstageExperimental,
+	"PUBLISHED":    backstageProduction,
+	"BLOCKED":      backstageProduction,
+	"DEPRECATED":   backstageDeprecated,
+	"RETIRED":      backstageDeprecated,
+}
+
+// asyncAPITypes are the API types whose definition is an AsyncAPI definition
+var asyncAPITypes = map[string]bool{"WS": true, "WEBSUB": true, "SSE": true, "WEBHOOK": true, "ASYNC": true}
+
+var (
+	backstageNameInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+	backstageTagInvalidChars  = regexp.MustCompile(`[^a-z0-9:+#]+`)
+)
+
+// BackstageEntity is a Backstage API entity of a catalog-info.yaml
+type BackstageEntity struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   BackstageMetadata `yaml:"metadata"`
+	Spec       BackstageAPISpec  `yaml:"spec"`
+}
+
+// BackstageMetadata is the metadata of a Backstage entity
+type BackstageMetadata struct {
+	Name        string            `yaml:"name"`
+	Title       string            `yaml:"title,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// BackstageAPISpec is the spec of a Backstage API entity
+type BackstageAPISpec struct {
+	Type       string                 `yaml:"type"`
+	Lifecycle  string                 `yaml:"lifecycle"`
+	Owner      string                 `yaml:"owner"`
+	Definition BackstageDefinitionRef `yaml:"definition"`
+}
+
+// BackstageDefinitionRef refers to the definition file of an API relative to the catalog-info.yaml
+type BackstageDefinitionRef struct {
+	Text string `yaml:"$text"`
+}
+
+// backstageAPI holds the details of an API which are mapped to a Backstage entity
+type backstageAPI struct {
+	Name            string
+	Version         string
+	Description     string
+	Context         string
+	Provider        string
+	LifeCycleStatus string
+	Tags            []string
+	DefinitionType  string
+	DefinitionPath  string
+}
+
+// GenerateBackstageCatalogFromProject writes a catalog-info.yaml with the Backstage entity of an API project
+// @param projectPath	: Path of the API project
+// @param outputDir		: Directory to write the catalog-info.yaml to. The project directory is used when empty
+// @param ownerMapping	: Backstage owners of the API providers (ex: admin -> group:platform-team)
+// @return path of the catalog-info.yaml, error
+func GenerateBackstageCatalogFromProject(projectPath, outputDir string, ownerMapping map[string]string) (string,
+	error) {
+	apiFile, _, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an API project: %w", projectPath, err)
+	}
+	api := apiFile.Data
+	definitionType, definitionFile := getBackstageDefinition(api.Type)
+	definitionPath := filepath.Join(projectPath, definitionFile)
+	if _, err = os.Stat(definitionPath); err != nil {
+		return "", fmt.Errorf("the definition of the API is not found in the project: %w", err)
+	}
+	if outputDir == "" {
+		outputDir = projectPath
+	}
+
+	entity, err := newBackstageEntity(backstageAPI{Name: api.Name, Version: api.Version,
+		Description: api.Description, Context: api.Context, Provider: api.Provider,
+		LifeCycleStatus: api.LifeCycleStatus, Tags: api.Tags, DefinitionType: definitionType,
+		DefinitionPath: definitionPath}, outputDir, ownerMapping)
+	if err != nil {
+		return "", err
+	}
+	return writeBackstageCatalog(outputDir, []BackstageEntity{*entity})
+}
+
+// GenerateBackstageCatalogFromEnv exports the OpenAPI definitions of the APIs matching the query and writes a
+// catalog-info.yaml with a Backstage entity for each of the APIs next to the definitions
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to generate the entities from
+// @param query			: Search query of the APIs (ex: tag:public). Entities of all the APIs are generated when empty
+// @param outputDir		: Directory to write the catalog-info.yaml and the definitions to
+// @param ownerMapping	: Backstage owners of the API providers (ex: admin -> group:platform-team)
+// @return path of the catalog-info.yaml, skipped APIs, error
+func GenerateBackstageCatalogFromEnv(accessToken, environment, query, outputDir string,
+	ownerMapping map[string]string) (string, []SkippedDefinition, error) {
+	return generateBackstageCatalog(accessToken, utils.GetPublisherEndpointOfEnv(environment,
+		utils.MainConfigFilePath), query, outputDir, ownerMapping)
+}
+
+func generateBackstageCatalog(accessToken, publisherEndpoint, query, outputDir string,
+	ownerMapping map[string]string) (string, []SkippedDefinition, error) {
+	exported, skipped, err := exportDefinitions(accessToken, publisherEndpoint, query, outputDir, "yaml")
+	if err != nil {
+		return "", nil, err
+	}
+	if len(exported) == 0 {
+		return "", skipped, errors.New("no API definitions were exported to generate the Backstage entities")
+	}
+	entities := []BackstageEntity{}
+	for _, definition := range exported {
+		entity, err := newBackstageEntity(backstageAPI{Name: definition.Name, Version: definition.Version,
+			Provider: definition.Provider, LifeCycleStatus: definition.LifeCycleStatus,
+			DefinitionType: backstageOpenAPIType, DefinitionPath: definition.Path}, outputDir, ownerMapping)
+		if err != nil {
+			return "", nil, err
+		}
+		entities = append(entities, *entity)
+	}
+	path, err := writeBackstageCatalog(outputDir, entities)
+	return path, skipped, err
+}
+
+// getBackstageDefinition returns the Backstage API type and the path of the definition inside the project for the
+// API type
+func getBackstageDefinition(apiType string) (string, string) {
+	apiType = strings.ToUpper(apiType)
+	if apiType == graphQLAPIType {
+		return backstageGraphQLType, utils.InitProjectDefinitionsGraphQLSchema
+	}
+	if asyncAPITypes[apiType] {
+		return backstageAsyncAPIType, utils.InitProjectDefinitionsAsyncAPI
+	}
+	return backstageOpenAPIType, utils.InitProjectDefinitionsSwagger
+}
+
+func newBackstageEntity(api backstageAPI, outputDir string, ownerMapping map[string]string) (*BackstageEntity,
+	error) {
+	definitionRef, err := getBackstageDefinitionRef(outputDir, api.DefinitionPath)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{backstageProviderKey: api.Provider}
+	if api.Context != "" {
+		annotations[backstageContextKey] = api.Context
+	}
+	return &BackstageEntity{
+		APIVersion: backstageAPIVersion,
+		Kind:       backstageAPIKind,
+		Metadata: BackstageMetadata{
+			Name:        getBackstageName(api.Name + "-" + api.Version),
+			Title:       api.Name + " " + api.Version,
+			Description: api.Description,
+			Tags:        getBackstageTags(api.Tags),
+			Annotations: annotations,
+		},
+		Spec: BackstageAPISpec{
+			Type:       api.DefinitionType,
+			Lifecycle:  getBackstageLifecycle(api.LifeCycleStatus),
+			Owner:      getBackstageOwner(api.Provider, ownerMapping),
+			Definition: BackstageDefinitionRef{Text: definitionRef},
+		},
+	}, nil
+}
+
+// getBackstageDefinitionRef returns the path of the definition relative to the directory of the catalog-info.yaml,
+// as Backstage resolves the $text references relative to the location of the catalog file
+func getBackstageDefinitionRef(outputDir, definitionPath string) (string, error) {
+	absOutputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return "", err
+	}
+	absDefinitionPath, err := filepath.Abs(definitionPath)
+	if err != nil {
+		return "", err
+	}
+	ref, err := filepath.Rel(absOutputDir, absDefinitionPath)
+	if err != nil {
+		return "", err
+	}
+	ref = filepath.ToSlash(ref)
+	if !strings.HasPrefix(ref, "../") {
+		ref = "./" + ref
+	}
+	return ref, nil
+}
+
+// getBackstageLifecycle maps the APIM lifecycle state to a Backstage lifecycle. APIs in an unknown state are
+// considered experimental.
+func getBackstageLifecycle(lifeCycleStatus string) string {
+	if lifecycle, ok := backstageLifecycles[strings.ToUpper(lifeCycleStatus)]; ok {
+		return lifecycle
+	}
+	return backstageExperimental
+}
+
+// getBackstageOwner returns the owner mapped to the provider, or the Backstage user of the same name otherwise
+func getBackstageOwner(provider string, ownerMapping map[string]string) string {
+	if owner, ok := ownerMapping[provider]; ok {
+		return owner
+	}
+	return "user:" + getBackstageName(strings.TrimSuffix(provider, backstageSuperTenant))
+}
+
+// getBackstageName converts a value to a valid Backstage entity name, which consists of at most 63 alphanumerics
+// separated by "-", "_" or "."
+func getBackstageName(value string) string {
+	name := backstageNameInvalidChars.ReplaceAllString(value, "-")
+	if len(name) > backstageMaxNameLength {
+		name = name[:backstageMaxNameLength]
+	}
+	return strings.Trim(name, "-_.")
+}
+
+// getBackstageTags converts the API tags to valid Backstage tags, which are lowercase words separated by "-".
+// Tags which become empty are dropped.
+func getBackstageTags(tags []string) []string {
+	backstageTags := []string{}
+	for _, tag := range tags {
+		tag = strings.Trim(backstageTagInvalidChars.ReplaceAllString(strings.ToLower(tag), "-"), "-")
+		if len(tag) > backstageMaxNameLength {
+			tag = strings.TrimRight(tag[:backstageMaxNameLength], "-")
+		}
+		if tag != "" {
+			backstageTags = append(backstageTags, tag)
+		}
+	}
+	return backstageTags
+}
+
+// writeBackstageCatalog writes the entities as the documents of the catalog-info.yaml in the output directory
+func writeBackstageCatalog(outputDir string, entities []BackstageEntity) (string, error) {
+	var catalog bytes.Buffer
+	for i, entity := range entities {
+		if i > 0 {
+			catalog.WriteString("---\n")
+		}
+		content, err := yaml.Marshal(entity)
+		if err != nil {
+			return "", err
+		}
+		catalog.Write(content)
+	}
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(outputDir, BackstageCatalogFileName)
+	return path, ioutil.WriteFile(path, catalog.Bytes(), 0644)
+}
+
+// PrintBackstageCatalog prints the location of the generated catalog-info.yaml along with the skipped APIs
+// @param path		: Path of the catalog-info.yaml
+// @param skipped	: APIs whose entities were not generated
+func PrintBackstageCatalog(path string, skipped []SkippedDefinition) {
+	for _, api := range skipped {
+		fmt.Println("Skipped " + api.Name + " " + api.Version + ": " + api.Reason)
+	}
+	fmt.Println("Backstage catalog is generated at " + path)
+}
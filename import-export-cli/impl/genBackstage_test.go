@@ -0,0 +1,146 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func readBackstageCatalog(t *testing.T, path string) []BackstageEntity {
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	entities := []BackstageEntity{}
+	for _, document := range strings.Split(string(content), "---\n") {
+		entity := BackstageEntity{}
+		assert.Nil(t, yaml.Unmarshal([]byte(document), &entity))
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+func TestGenerateBackstageCatalogFromProject(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "PizzaShackAPI")
+	assert.Nil(t, os.MkdirAll(filepath.Join(projectPath, "Definitions"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(`type: api
+version: v4.2.0
+data:
+  name: PizzaShackAPI
+  description: Pizza ordering API
+  context: /pizzashack
+  version: 1.0.0
+  provider: admin@carbon.super
+  lifeCycleStatus: PUBLISHED
+  type: HTTP
+  tags:
+    - Food & Drinks
+    - public
+`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "Definitions", "swagger.yaml"),
+		[]byte("openapi: 3.0.1\n"), 0644))
+
+	path, err := GenerateBackstageCatalogFromProject(projectPath, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(projectPath, BackstageCatalogFileName), path)
+	entities := readBackstageCatalog(t, path)
+	assert.Len(t, entities, 1)
+	entity := entities[0]
+	assert.Equal(t, "backstage.io/v1alpha1", entity.APIVersion)
+	assert.Equal(t, "API", entity.Kind)
+	assert.Equal(t, "PizzaShackAPI-1.0.0", entity.Metadata.Name)
+	assert.Equal(t, "Pizza ordering API", entity.Metadata.Description)
+	assert.Equal(t, []string{"food-drinks", "public"}, entity.Metadata.Tags)
+	assert.Equal(t, "/pizzashack", entity.Metadata.Annotations["wso2.com/api-context"])
+	assert.Equal(t, "openapi", entity.Spec.Type)
+	assert.Equal(t, "production", entity.Spec.Lifecycle)
+	assert.Equal(t, "user:admin", entity.Spec.Owner)
+	assert.Equal(t, "./Definitions/swagger.yaml", entity.Spec.Definition.Text)
+
+	outputDir := filepath.Join(filepath.Dir(projectPath), "catalog")
+	path, err = GenerateBackstageCatalogFromProject(projectPath, outputDir,
+		map[string]string{"admin@carbon.super": "group:platform-team"})
+	assert.Nil(t, err)
+	entity = readBackstageCatalog(t, path)[0]
+	assert.Equal(t, "group:platform-team", entity.Spec.Owner)
+	assert.Equal(t, "../PizzaShackAPI/Definitions/swagger.yaml", entity.Spec.Definition.Text)
+}
+
+func TestGenerateBackstageCatalogFromProjectWithoutDefinition(t *testing.T) {
+	projectPath := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"),
+		[]byte("type: api\ndata:\n  name: ChatAPI\n  version: 1.0.0\n  type: WS\n"), 0644))
+
+	_, err := GenerateBackstageCatalogFromProject(projectPath, "", nil)
+	assert.NotNil(t, err, "Should fail when the AsyncAPI definition is not in the project")
+
+	_, err = GenerateBackstageCatalogFromProject(t.TempDir(), "", nil)
+	assert.NotNil(t, err, "Should fail when the directory is not an API project")
+}
+
+func TestGenerateBackstageCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/settings":
+			_, _ = w.Write([]byte(`{"environment": []}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"count": 2, "list": [
+				{"id": "pizza", "name": "PizzaShackAPI", "context": "/pizzashack", "version": "1.0.0",
+					"provider": "admin", "lifeCycleStatus": "PUBLISHED"},
+				{"id": "legacy", "name": "LegacyAPI", "context": "/legacy", "version": "v1",
+					"provider": "alice@wso2.com", "lifeCycleStatus": "DEPRECATED"}]}`))
+		case "/apis/pizza/swagger", "/apis/legacy/swagger":
+			_, _ = w.Write([]byte(`{"openapi": "3.0.1", "paths": {}}`))
+		case "/apis/pizza/deployments", "/apis/legacy/deployments":
+			_, _ = w.Write([]byte(`{"list": []}`))
+		default:
+			t.Errorf("Unexpected request to %s\n", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	outputDir := t.TempDir()
+	path, skipped, err := generateBackstageCatalog("access-token", server.URL, "", outputDir, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, skipped)
+	entities := readBackstageCatalog(t, path)
+	assert.Len(t, entities, 2)
+	assert.Equal(t, "./PizzaShackAPI_1.0.0.yaml", entities[0].Spec.Definition.Text)
+	assert.Equal(t, "user:admin", entities[0].Spec.Owner)
+	assert.Equal(t, "LegacyAPI-v1", entities[1].Metadata.Name)
+	assert.Equal(t, "deprecated", entities[1].Spec.Lifecycle)
+	assert.Equal(t, "user:alice-wso2.com", entities[1].Spec.Owner)
+	assert.FileExists(t, filepath.Join(outputDir, "LegacyAPI_v1.yaml"))
+}
+
+func TestGetBackstageNameAndLifecycle(t *testing.T) {
+	assert.Equal(t, "Pizza-Shack-API-1.0.0", getBackstageName("Pizza Shack API-1.0.0"))
+	assert.Equal(t, "api", getBackstageName("_api_"))
+	assert.Len(t, getBackstageName(strings.Repeat("a", 100)), 63)
+	assert.Equal(t, "experimental", getBackstageLifecycle("CREATED"))
+	assert.Equal(t, "experimental", getBackstageLifecycle("UNKNOWN"))
+	assert.Equal(t, "deprecated", getBackstageLifecycle("retired"))
+}
@@ -124,37 +124,40 @@ func PrintAPIPolicies(resp *resty.Response, format string) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error unmarshalling response data", err)
 	}
-	if format == "" {
-		format = defaultAPIPolicyTableFormat
-		// create policy context with standard output
-		policyContext := formatter.NewContext(os.Stdout, format)
-		// create a new renderer function which iterate collection
-		renderer := func(w io.Writer, t *template.Template) error {
-			for _, policy := range policies {
-				if err := t.Execute(w, newAPIPolicyDefinition(policy)); err != nil {
-					return err
-				}
-				_, _ = w.Write([]byte{'\n'})
+	if format == utils.JsonArrayFormatType {
+		utils.ListArtifactsInJsonArrayFormat(policies, utils.ProjectTypeAPIPolicy)
+		return
+	}
+	if utils.PrintFormattedOutput(policies, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultAPIPolicyTableFormat)
+	// create policy context with standard output
+	policyContext := formatter.NewContext(os.Stdout, format)
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, policy := range policies {
+			if err := t.Execute(w, newAPIPolicyDefinition(policy)); err != nil {
+				return err
 			}
-			return nil
+			_, _ = w.Write([]byte{'\n'})
 		}
+		return nil
+	}
 
-		// headers for table
-		apiPolicyTableHeaders := map[string]string{
-			"ID":                apiPolicyUUIDHeader,
-			"Name":              apiPolicyNameHeader,
-			"DisplayName":       apiPolicyDisplayNameHeader,
-			"Version":           apiPolicyVersionHeader,
-			"Category":          apiPolicyCategoryHeader,
-			"ApplicableFlows":   apiPolicyApplicableFlowsHeaders,
-			"SupportedGateways": apiPolicySupportedGatewaysHeaders,
-			"SupportedApiTypes": apiPolicySupportedApiTypesHeader,
-		}
-		// execute context
-		if err := policyContext.Write(renderer, apiPolicyTableHeaders); err != nil {
-			fmt.Println("Error executing template:", err.Error())
-		}
-	} else if format == utils.JsonArrayFormatType {
-		utils.ListArtifactsInJsonArrayFormat(policies, utils.ProjectTypeAPIPolicy)
+	// headers for table
+	apiPolicyTableHeaders := map[string]string{
+		"ID":                apiPolicyUUIDHeader,
+		"Name":              apiPolicyNameHeader,
+		"DisplayName":       apiPolicyDisplayNameHeader,
+		"Version":           apiPolicyVersionHeader,
+		"Category":          apiPolicyCategoryHeader,
+		"ApplicableFlows":   apiPolicyApplicableFlowsHeaders,
+		"SupportedGateways": apiPolicySupportedGatewaysHeaders,
+		"SupportedApiTypes": apiPolicySupportedApiTypesHeader,
+	}
+	// execute context
+	if err := policyContext.Write(renderer, apiPolicyTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
 	}
 }
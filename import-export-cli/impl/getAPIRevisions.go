@@ -103,23 +103,27 @@ func GetRevisionListFromEnv(accessToken, environment, apiName, apiVersion, provi
 // @param revisions	Available revisions list for the API
 // @param format	Format type of the output
 func PrintRevisions(revisions []utils.Revisions, format string) {
-	if format == "" {
-		format = defaultRevisionTableFormat
-	} else if format == utils.JsonArrayFormatType {
+	if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(revisions, utils.ProjectTypeRevision)
 		return
 	}
+	for i, r := range revisions {
+		var gatewayEnvs []string
+		for _, d := range r.Deployments {
+			gatewayEnvs = append(gatewayEnvs, d.Name)
+		}
+		revisions[i].GatewayEnvs = gatewayEnvs
+	}
+	if utils.PrintFormattedOutput(revisions, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultRevisionTableFormat)
 	// create revision Context with standard output
 	revisionContext := formatter.NewContext(os.Stdout, format)
 
 	// create a new renderer function which iterate collection
 	renderer := func(w io.Writer, t *template.Template) error {
 		for _, r := range revisions {
-			var gatewayEnvs []string
-			for _, d := range r.Deployments {
-				gatewayEnvs = append(gatewayEnvs, d.Name)
-			}
-			r.GatewayEnvs = gatewayEnvs
 			if err := t.Execute(w, newRevisionDefinitionFromRevisions(r)); err != nil {
 				return err
 			}
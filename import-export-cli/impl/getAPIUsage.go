@@ -0,0 +1,189 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	usageTotalHitsHeader   = "HITS"
+	usageSuccessHitsHeader = "SUCCESS"
+	usageErrorHitsHeader   = "ERRORS"
+	usageErrorRateHeader   = "ERROR_RATE"
+	usageP50LatencyHeader  = "P50_LATENCY(ms)"
+	usageP90LatencyHeader  = "P90_LATENCY(ms)"
+	usageP99LatencyHeader  = "P99_LATENCY(ms)"
+
+	defaultAPIUsageTableFormat = "table {{.TotalHits}}\t{{.SuccessHits}}\t{{.ErrorHits}}\t{{.ErrorRate}}\t" +
+		"{{.P50Latency}}\t{{.P90Latency}}\t{{.P99Latency}}"
+)
+
+// apiUsage struct holds the usage summary of an API for outputting
+type apiUsage struct {
+	summary utils.APIUsageSummary
+}
+
+// TotalHits of the API
+func (u apiUsage) TotalHits() int64 {
+	return u.summary.TotalHits
+}
+
+// SuccessHits of the API
+func (u apiUsage) SuccessHits() int64 {
+	return u.summary.SuccessHits
+}
+
+// ErrorHits of the API
+func (u apiUsage) ErrorHits() int64 {
+	return u.summary.ErrorHits
+}
+
+// ErrorRate of the API as a percentage
+func (u apiUsage) ErrorRate() string {
+	if u.summary.TotalHits == 0 {
+		return "0.00%"
+	}
+	return fmt.Sprintf("%.2f%%", float64(u.summary.ErrorHits)*100/float64(u.summary.TotalHits))
+}
+
+// P50Latency of the API
+func (u apiUsage) P50Latency() string {
+	return strconv.FormatFloat(u.summary.Latency.P50, 'f', 2, 64)
+}
+
+// P90Latency of the API
+func (u apiUsage) P90Latency() string {
+	return strconv.FormatFloat(u.summary.Latency.P90, 'f', 2, 64)
+}
+
+// P99Latency of the API
+func (u apiUsage) P99Latency() string {
+	return strconv.FormatFloat(u.summary.Latency.P99, 'f', 2, 64)
+}
+
+// MarshalJSON marshals api usage using custom marshaller which uses methods instead of fields
+func (u *apiUsage) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(u)
+}
+
+// GetAPIUsageFromEnv retrieves the usage summary of an API for the given time period from the analytics endpoint
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment name to use when getting the API usage
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @param period		: Time period to summarize, counted backwards from now
+// @return usage summary of the API
+// @return error
+func GetAPIUsageFromEnv(accessToken, environment, apiName, apiVersion, provider string,
+	period time.Duration) (*utils.APIUsageSummary, error) {
+	analyticsEndpoint, err := utils.GetAnalyticsEndpointOfEnv(environment, utils.MainConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-period)
+	url := analyticsEndpoint + "apis/" + apiId + "/usage"
+	utils.Logln(utils.LogPrefixInfo+"GetAPIUsage: URL:", url)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	queryParams := map[string]string{
+		"from": from.Format(time.RFC3339),
+		"to":   to.Format(time.RFC3339),
+	}
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, url, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusOK {
+		usage := &utils.APIUsageSummary{}
+		err = json.Unmarshal(resp.Body(), usage)
+		if err != nil {
+			return nil, err
+		}
+		usage.APIId = apiId
+		usage.From = queryParams["from"]
+		usage.To = queryParams["to"]
+		return usage, nil
+	}
+	utils.Logf("Error: %s\n", resp.Error())
+	utils.Logf("Body: %s\n", resp.Body())
+	if resp.StatusCode() == http.StatusUnauthorized {
+		// 401 Unauthorized
+		return nil, fmt.Errorf("Authorization failed while retrieving the usage of API: " + apiName)
+	}
+	return nil, errors.New("Request didn't respond 200 OK for retrieving the API usage. Status: " + resp.Status())
+}
+
+// PrintAPIUsage prints the usage summary of an API in the given format
+// @param usage		Usage summary of the API
+// @param format	Format type of the output
+func PrintAPIUsage(usage *utils.APIUsageSummary, format string) {
+	if utils.PrintFormattedOutput(usage, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultAPIUsageTableFormat)
+	// create api usage context with standard output
+	usageContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which renders the usage summary
+	renderer := func(w io.Writer, t *template.Template) error {
+		if err := t.Execute(w, &apiUsage{*usage}); err != nil {
+			return err
+		}
+		_, _ = w.Write([]byte{'\n'})
+		return nil
+	}
+
+	// headers for table
+	usageTableHeaders := map[string]string{
+		"TotalHits":   usageTotalHitsHeader,
+		"SuccessHits": usageSuccessHitsHeader,
+		"ErrorHits":   usageErrorHitsHeader,
+		"ErrorRate":   usageErrorRateHeader,
+		"P50Latency":  usageP50LatencyHeader,
+		"P90Latency":  usageP90LatencyHeader,
+		"P99Latency":  usageP99LatencyHeader,
+	}
+
+	// execute context
+	if err := usageContext.Write(renderer, usageTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -99,12 +99,14 @@ func GetAPIProductListFromEnv(accessToken, environment, query, limit string) (co
 
 // PrintAPIProducts
 func PrintAPIProducts(apiProducts []utils.APIProduct, format string) {
-	if format == "" {
-		format = defaultApiProductTableFormat
-	} else if format == utils.JsonArrayFormatType {
+	if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(apiProducts, utils.ProjectTypeApiProduct)
 		return
 	}
+	if utils.PrintFormattedOutput(apiProducts, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultApiProductTableFormat)
 
 	// create API Product context with standard output
 	apiProductContext := formatter.NewContext(os.Stdout, format)
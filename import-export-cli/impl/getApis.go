@@ -105,14 +105,29 @@ func GetAPIListFromEnv(accessToken, environment, query, limit string) (count int
 	return GetAPIList(accessToken, apiListEndpoint, query, limit)
 }
 
+// SearchAPIsInEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when searching the APIs
+// @param options : search, paging and sorting options
+// @return count (no. of APIs)
+// @return array of API objects
+// @return error
+func SearchAPIsInEnv(accessToken, environment string, options APISearchOptions) (count int32, apis []utils.API,
+	err error) {
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return SearchAPIs(accessToken, apiListEndpoint, options)
+}
+
 // PrintAPIs
 func PrintAPIs(apis []utils.API, format string) {
-	if format == "" {
-		format = defaultApiTableFormat
-	} else if format == utils.JsonArrayFormatType {
+	if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(apis, utils.ProjectTypeApi)
 		return
 	}
+	if utils.PrintFormattedOutput(apis, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultApiTableFormat)
 
 	// create api context with standard output
 	apiContext := formatter.NewContext(os.Stdout, format)
@@ -20,9 +20,14 @@ package impl
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"path"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -38,6 +43,9 @@ const (
 	appGroupIdHeader = "GROUP ID"
 
 	defaultAppTableFormat = "table {{.Id}}\t{{.Name}}\t{{.Owner}}\t{{.Status}}\t{{.GroupId}}"
+
+	// Number of applications retrieved in a request when listing the applications page by page
+	appListPageSize = 100
 )
 
 // app contains information about util.Application
@@ -97,6 +105,77 @@ func GetApplicationListFromEnv(accessToken, environment, appOwner, limit string)
 	return GetApplicationList(accessToken, applicationListEndpoint, appOwner, limit)
 }
 
+// IsAppOwnerPattern returns true if the owner contains wildcards (*, ? or [...]) to match multiple owners
+func IsAppOwnerPattern(appOwner string) bool {
+	return strings.ContainsAny(appOwner, "*?[")
+}
+
+// GetAllApplicationListFromEnv lists the applications of all the owners in the environment page by page
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to get the list of applications
+// @param ownerPattern : Owner of the applications, which can contain wildcards. Applications of all the owners are
+// listed if empty
+// @param maxResults : Max number of results to return. All the matching applications are returned if 0
+// @return array of Application objects
+// @return error
+func GetAllApplicationListFromEnv(accessToken, environment, ownerPattern string, maxResults int) ([]utils.Application,
+	error) {
+	applicationListEndpoint := utils.GetAdminApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return getAllApplicationList(accessToken, applicationListEndpoint, ownerPattern, maxResults)
+}
+
+func getAllApplicationList(accessToken, applicationListEndpoint, ownerPattern string, maxResults int) (
+	[]utils.Application, error) {
+	// Validate the pattern before listing the applications
+	if _, err := path.Match(ownerPattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid owner pattern %s: %w", ownerPattern, err)
+	}
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams := map[string]string{"limit": strconv.Itoa(appListPageSize)}
+	// An owner without wildcards is filtered by the server
+	if ownerPattern != "" && !IsAppOwnerPattern(ownerPattern) {
+		queryParams["user"] = ownerPattern
+	}
+
+	apps := []utils.Application{}
+	for offset := 0; ; offset += appListPageSize {
+		queryParams["offset"] = strconv.Itoa(offset)
+		utils.Logln(utils.LogPrefixInfo+"URL:", applicationListEndpoint, queryParams)
+		resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, applicationListEndpoint, headers)
+		if err != nil {
+			return nil, err
+		}
+		utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(resp.Status())
+		}
+		appListResponse := &utils.ApplicationListResponse{}
+		if err := json.Unmarshal(resp.Body(), appListResponse); err != nil {
+			return nil, err
+		}
+		for _, app := range appListResponse.List {
+			if ownerPattern != "" && !matchAppOwner(ownerPattern, app.Owner) {
+				continue
+			}
+			apps = append(apps, app)
+			if maxResults > 0 && len(apps) == maxResults {
+				return apps, nil
+			}
+		}
+		if len(appListResponse.List) < appListPageSize {
+			return apps, nil
+		}
+	}
+}
+
+// matchAppOwner matches the owner of an application against the pattern ignoring the case, as the user names are
+// case insensitive by default
+func matchAppOwner(ownerPattern, owner string) bool {
+	matched, _ := path.Match(strings.ToLower(ownerPattern), strings.ToLower(owner))
+	return matched
+}
+
 // extractAppDefinition extracts ApplicationDefinition from jsonContent
 func extractAppDefinition(jsonContent []byte) (*v2.ApplicationDefinition, error) {
 	application := &v2.ApplicationDefinition{}
@@ -110,12 +189,14 @@ func extractAppDefinition(jsonContent []byte) (*v2.ApplicationDefinition, error)
 
 // PrintApps
 func PrintApps(apps []utils.Application, format string) {
-	if format == "" {
-		format = defaultAppTableFormat
-	} else if format == utils.JsonArrayFormatType {
+	if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(apps, utils.ProjectTypeApplication)
 		return
 	}
+	if utils.PrintFormattedOutput(apps, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultAppTableFormat)
 
 	// create new app context with standard output
 	appContext := formatter.NewContext(os.Stdout, format)
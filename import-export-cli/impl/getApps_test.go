@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// newAppListTestServer serves the given number of applications page by page, owned by
+// alternating owners
+func newAppListTestServer(t *testing.T, count int) *restTestServer {
+	owners := []string{"dev1@example.com", "admin", "Dev2@Example.com"}
+	return newRESTTestServer(t).handleFunc(http.MethodGet, "/", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		appList := utils.ApplicationListResponse{List: []utils.Application{}}
+		for i := offset; i < count && i < offset+limit; i++ {
+			app := utils.Application{ID: fmt.Sprintf("app-%d", i), Name: fmt.Sprintf("App%d", i),
+				Owner: owners[i%len(owners)]}
+			if query.Get("user") != "" && query.Get("user") != app.Owner {
+				continue
+			}
+			appList.List = append(appList.List, app)
+		}
+		appList.Count = int32(len(appList.List))
+		body, _ := json.Marshal(appList)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+}
+
+func TestGetAllApplicationListPaginates(t *testing.T) {
+	server := newAppListTestServer(t, 250)
+	defer server.Close()
+
+	apps, err := getAllApplicationList("token", server.URL, "", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 250, len(apps))
+	requests := server.requestsTo(http.MethodGet, "/")
+	assert.Equal(t, 3, len(requests))
+	assert.Equal(t, "200", requests[2].query.Get("offset"))
+	assert.Equal(t, strconv.Itoa(appListPageSize), requests[2].query.Get("limit"))
+}
+
+func TestGetAllApplicationListOwnerWildcard(t *testing.T) {
+	server := newAppListTestServer(t, 150)
+	defer server.Close()
+
+	apps, err := getAllApplicationList("token", server.URL, "dev*@example.com", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, len(apps))
+	for _, app := range apps {
+		assert.NotEqual(t, "admin", app.Owner)
+	}
+	// Wildcards are matched by the client
+	assert.NotContains(t, server.requestsTo(http.MethodGet, "/")[0].query, "user")
+}
+
+func TestGetAllApplicationListMaxResults(t *testing.T) {
+	server := newAppListTestServer(t, 250)
+	defer server.Close()
+
+	apps, err := getAllApplicationList("token", server.URL, "*", 120)
+	assert.Nil(t, err)
+	assert.Equal(t, 120, len(apps))
+	assert.Equal(t, 2, server.requestCount())
+}
+
+func TestGetAllApplicationListExactOwner(t *testing.T) {
+	server := newAppListTestServer(t, 30)
+	defer server.Close()
+
+	apps, err := getAllApplicationList("token", server.URL, "admin", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(apps))
+	assert.Equal(t, "admin", server.requestsTo(http.MethodGet, "/")[0].query.Get("user"))
+}
+
+func TestGetAllApplicationListInvalidPattern(t *testing.T) {
+	_, err := getAllApplicationList("token", "http://localhost", "dev[", 0)
+	assert.NotNil(t, err)
+}
+
+func TestGetAllApplicationListError(t *testing.T) {
+	server := newRESTTestServer(t).handle(http.MethodGet, "/", http.StatusForbidden, "")
+	defer server.Close()
+
+	_, err := getAllApplicationList("token", server.URL, "", 0)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,220 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	deploymentRevisionHeader     = "REVISION"
+	deploymentRevisionIdHeader   = "REVISION_ID"
+	deploymentGatewayEnvHeader   = "GATEWAY_ENV"
+	deploymentVhostHeader        = "VHOST"
+	deploymentStatusHeader       = "STATUS"
+	deploymentDeployedTimeHeader = "DEPLOYED_TIME"
+
+	defaultDeploymentTableFormat = "table {{.Revision}}\t{{.RevisionId}}\t{{.GatewayEnv}}\t{{.Vhost}}\t{{.Status}}\t" +
+		"{{.DeployedTime}}"
+)
+
+// deployment struct holds the deployment of a revision in a gateway environment for outputting
+type deployment struct {
+	deployment utils.APIRevisionDeployment
+}
+
+// Revision number of the deployed revision
+func (d deployment) Revision() string {
+	return utils.GetRevisionNumFromRevisionName(d.deployment.Revision)
+}
+
+// RevisionId of the deployed revision
+func (d deployment) RevisionId() string {
+	return d.deployment.RevisionID
+}
+
+// GatewayEnv the revision is deployed in
+func (d deployment) GatewayEnv() string {
+	return d.deployment.GatewayEnv
+}
+
+// Vhost the revision is deployed in
+func (d deployment) Vhost() string {
+	return d.deployment.Vhost
+}
+
+// Status of the deployment
+func (d deployment) Status() string {
+	return d.deployment.Status
+}
+
+// DeployedTime of the revision
+func (d deployment) DeployedTime() string {
+	return d.deployment.DeployedTime
+}
+
+// MarshalJSON marshals deployment using custom marshaller which uses methods instead of fields
+func (d *deployment) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(d)
+}
+
+// GetDeploymentsFromEnv retrieves the revisions of an API deployed in each gateway environment
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment name to use when getting the deployments
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @return deployments of the API sorted by the gateway environment and the vhost
+// @return error
+func GetDeploymentsFromEnv(accessToken, environment, apiName, apiVersion, provider string) (
+	[]utils.APIRevisionDeployment, error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	apiEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)) +
+		apiId
+	return getDeployments(accessToken, apiEndpoint)
+}
+
+// getDeployments consolidates the deployments of an API with the names of the deployed revisions
+// @param accessToken	: Access Token for the environment
+// @param apiEndpoint	: Publisher endpoint of the API
+// @return deployments of the API sorted by the gateway environment and the vhost
+// @return error
+func getDeployments(accessToken, apiEndpoint string) ([]utils.APIRevisionDeployment, error) {
+	apiDeployments, err := getAPIDeployments(accessToken, apiEndpoint+"/deployments")
+	if err != nil {
+		return nil, err
+	}
+	revisionNames, err := getRevisionNames(accessToken, apiEndpoint+"/revisions")
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]utils.APIRevisionDeployment, 0, len(apiDeployments))
+	for _, d := range apiDeployments {
+		deployments = append(deployments, utils.APIRevisionDeployment{
+			RevisionID:         d.RevisionID,
+			Revision:           revisionNames[d.RevisionID],
+			GatewayEnv:         d.Name,
+			Vhost:              d.Vhost,
+			Status:             d.Status,
+			DisplayOnDevportal: d.DisplayOnDevportal,
+			DeployedTime:       formatDeployedTime(d.DeployedTime),
+		})
+	}
+	sort.SliceStable(deployments, func(i, j int) bool {
+		if deployments[i].GatewayEnv != deployments[j].GatewayEnv {
+			return deployments[i].GatewayEnv < deployments[j].GatewayEnv
+		}
+		return deployments[i].Vhost < deployments[j].Vhost
+	})
+	return deployments, nil
+}
+
+// getRevisionNames retrieves the display names (ex: Revision 1) of the revisions of an API
+// @param accessToken		: Access Token for the environment
+// @param revisionsEndpoint	: Revisions endpoint of the API
+// @return display names of the revisions keyed by the revision ID
+// @return error
+func getRevisionNames(accessToken, revisionsEndpoint string) (map[string]string, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	utils.Logln(utils.LogPrefixInfo+"URL:", revisionsEndpoint)
+	resp, err := utils.InvokeGETRequest(revisionsEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the revisions of the API. Status: " +
+			resp.Status())
+	}
+	revisionList := &utils.RevisionListResponse{}
+	if err = json.Unmarshal(resp.Body(), revisionList); err != nil {
+		return nil, err
+	}
+	revisionNames := make(map[string]string, len(revisionList.List))
+	for _, revision := range revisionList.List {
+		revisionNames[revision.ID] = revision.RevisionNumber
+	}
+	return revisionNames, nil
+}
+
+// formatDeployedTime formats the deployed time given in epoch milliseconds by some versions of the Publisher in
+// RFC3339. Other formats are kept as they are.
+func formatDeployedTime(deployedTime string) string {
+	millis, err := strconv.ParseInt(deployedTime, 10, 64)
+	if err != nil {
+		return deployedTime
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+// PrintDeployments prints the deployments of an API in the given format
+// @param deployments	Deployments of the API
+// @param format		Format type of the output
+func PrintDeployments(deployments []utils.APIRevisionDeployment, format string) {
+	if utils.PrintFormattedOutput(deployments, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultDeploymentTableFormat)
+	// create deployment context with standard output
+	deploymentContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, d := range deployments {
+			if err := t.Execute(w, &deployment{d}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	deploymentTableHeaders := map[string]string{
+		"Revision":     deploymentRevisionHeader,
+		"RevisionId":   deploymentRevisionIdHeader,
+		"GatewayEnv":   deploymentGatewayEnvHeader,
+		"Vhost":        deploymentVhostHeader,
+		"Status":       deploymentStatusHeader,
+		"DeployedTime": deploymentDeployedTimeHeader,
+	}
+
+	// execute context
+	if err := deploymentContext.Write(renderer, deploymentTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetDeployments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/apis/pizza/deployments":
+			_, _ = w.Write([]byte(`{"list": [
+				{"revisionUuid": "r2", "name": "us-region", "vhost": "us.wso2.com", "status": "APPROVED",
+					"displayOnDevportal": true, "deployedTime": "1700000000000"},
+				{"revisionUuid": "r1", "name": "Default", "vhost": "localhost", "status": "CREATED",
+					"deployedTime": "2023-11-14T22:13:20Z"}]}`))
+		case "/apis/pizza/revisions":
+			_, _ = w.Write([]byte(`{"count": 2, "list": [{"id": "r1", "displayName": "Revision 1"},
+				{"id": "r2", "displayName": "Revision 2"}]}`))
+		default:
+			t.Errorf("Unexpected request to %s\n", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	deployments, err := getDeployments("access-token", server.URL+"/apis/pizza")
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.APIRevisionDeployment{
+		{RevisionID: "r1", Revision: "Revision 1", GatewayEnv: "Default", Vhost: "localhost", Status: "CREATED",
+			DeployedTime: "2023-11-14T22:13:20Z"},
+		{RevisionID: "r2", Revision: "Revision 2", GatewayEnv: "us-region", Vhost: "us.wso2.com", Status: "APPROVED",
+			DisplayOnDevportal: true, DeployedTime: "2023-11-14T22:13:20Z"},
+	}, deployments, "Should be sorted by the gateway environment")
+	assert.Equal(t, "1", deployment{deployments[0]}.Revision())
+}
+
+func TestGetDeploymentsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/apis/pizza/revisions" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"list": []}`))
+	}))
+	defer server.Close()
+
+	_, err := getDeployments("access-token", server.URL+"/apis/pizza")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "revisions")
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -111,9 +112,21 @@ func (e *endpoints) MarshalJSON() ([]byte, error) {
 
 // PrintEnvs
 func PrintEnvs(envData map[string]utils.EnvEndpoints, format, defaulEnvsTableFormat string) {
-	if format == "" {
-		format = defaulEnvsTableFormat
+	if utils.IsMachineReadableFormat(format) {
+		// The environments are printed in the order of their names, so that the output is stable
+		names := make([]string, 0, len(envData))
+		for name := range envData {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		envs := make([]*endpoints, 0, len(envData))
+		for _, name := range names {
+			envs = append(envs, newEndpointFromEnvEndpoints(name, envData[name]))
+		}
+		utils.PrintFormattedOutput(envs, format)
+		return
 	}
+	format = utils.ResolveTableFormat(format, defaulEnvsTableFormat)
 
 	// create api context with standard output
 	envsContext := formatter.NewContext(os.Stdout, format)
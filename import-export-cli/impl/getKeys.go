@@ -23,7 +23,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/renstrom/dedent"
@@ -39,14 +42,32 @@ var apiVersion string
 var apiProvider string
 var keyGenEnv string
 var keyGenTokenEndpoint string
+var keyGenCredential credentials.Credential
+var tokenGenOptions utils.TokenGenerationOptions
+
+//Subscribe the given API or API Product to the default application and print an access token
+func GetKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint string,
+	options utils.TokenGenerationOptions, outputFormat string) {
+	token := GenerateKeys(cred, envName, name, version, provider, tokenEndpoint, options)
+	return token
+}
 
 //Subscribe the given API or API Product to the default application and generate an access token
-func GetKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint string) {
+func GenerateKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint string,
+	options utils.TokenGenerationOptions) *utils.TokenResponse {
 	keyGenEnv = envName
 	apiName = name
 	apiVersion = version
 	apiProvider = provider
 	keyGenTokenEndpoint = tokenEndpoint
+	keyGenCredential = cred
+	tokenGenOptions = options
+	if tokenGenOptions.GrantType == "" {
+		tokenGenOptions.GrantType = utils.GrantTypeClientCredentials
+	}
+	if tokenGenOptions.ValidityPeriod <= 0 {
+		tokenGenOptions.ValidityPeriod = utils.DefaultTokenValidityPeriod
+	}
 
 	//generating access token for the env based on the credentials
 	accessToken, err := credentials.GetOAuthAccessToken(cred, keyGenEnv)
@@ -112,7 +133,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 
 				if accessToken != "" {
 					// Access Token generated successfully.
-					fmt.Println(token)
+					return token
 				} else {
 					utils.HandleErrorAndExit("Error while generating token: ", err)
 				}
@@ -122,8 +143,15 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 				if keygenResponse == nil && err != nil {
 					utils.HandleErrorAndExit("Error occurred while generating CLI application keys.", err)
 				}
+				appKey := &utils.ApplicationKey{}
+				appKey.ConsumerKey = keygenResponse.ConsumerKey
+				appKey.ConsumerSecret = keygenResponse.ConsumerSecret
+				token, err := getNewToken(appKey, scopes)
+				if err != nil {
+					utils.HandleErrorAndExit("Error while generating token. ", err)
+				}
 				// Access Token generated successfully.
-				fmt.Println(keygenResponse.Token.AccessToken)
+				return token
 			}
 		} else {
 			utils.HandleErrorAndExit("Error while retrieving the CLI application:", err)
@@ -159,13 +187,33 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 		appKey.ConsumerKey = keygenResponse.ConsumerKey
 		appKey.ConsumerSecret = keygenResponse.ConsumerSecret
 		token, err := getNewToken(appKey, scopes)
-		if token != "" {
+		if token != nil && token.AccessToken != "" {
 			// Access Token generated successfully.
-			fmt.Println(token)
+			return token
 		} else {
 			utils.HandleErrorAndExit("Error while generating token: ", err)
 		}
 	}
+	return nil
+}
+
+// Print the generated access token either as plain text or along with its expiry in a machine readable format
+// @param token : Token response of the token endpoint
+// @param outputFormat : Output format requested by the user
+func printKeys(token *utils.TokenResponse, outputFormat string) {
+	result := utils.GetKeysResult{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		GrantType:    tokenGenOptions.GrantType,
+		Scopes:       strings.Fields(token.Scope),
+		ExpiresIn:    token.ExpiresIn,
+		ExpiresAt:    time.Now().Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339),
+	}
+	if utils.PrintFormattedOutput(result, outputFormat) {
+		return
+	}
+	fmt.Println(token.AccessToken)
 }
 
 // Retrieve an available throttling tiers of the API or API Product
@@ -647,16 +695,16 @@ func createApplication(accessToken string, throttlingPolicy string) (string, str
 
 // Calling token endpoint to get access token for the already created application
 // @param key : Details of the particular key
-// @param scopes[] : Scopes to generate the token
-// @return accessToken, error
-func getNewToken(key *utils.ApplicationKey, scopes []string) (string, error) {
+// @param scopes[] : Scopes of the subscribed APIs, used when no scopes are requested explicitly
+// @return token response, error
+func getNewToken(key *utils.ApplicationKey, scopes []string) (*utils.TokenResponse, error) {
 	var tokenEndpoint string
 	if keyGenTokenEndpoint == "" {
 		tokenEndpoint = utils.GetTokenEndpointOfEnv(keyGenEnv, utils.MainConfigFilePath)
 	} else {
 		tokenEndpoint = keyGenTokenEndpoint
 	}
-	body := "grant_type=client_credentials&scope=" + strings.Join(scopes, " ")
+	body := getTokenRequestBody(scopes)
 
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBasicPrefix + " " +
@@ -668,7 +716,7 @@ func getNewToken(key *utils.ApplicationKey, scopes []string) (string, error) {
 	resp, err := utils.InvokePOSTRequest(tokenEndpoint, headers, body)
 
 	if err != nil {
-		return "", errors.New("Token Endpoint is not valid. " + err.Error())
+		return nil, errors.New("Token Endpoint is not valid. " + err.Error())
 	}
 
 	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
@@ -676,22 +724,38 @@ func getNewToken(key *utils.ApplicationKey, scopes []string) (string, error) {
 		keygenResponse := &utils.TokenResponse{}
 		data := []byte(resp.Body())
 		err = json.Unmarshal(data, &keygenResponse)
-
-		accessToken := keygenResponse.AccessToken
-		return accessToken, err
+		return keygenResponse, err
 
 	} else {
 		utils.Logf("Error: %s\n", resp.Error())
 		utils.Logf("Body: %s\n", resp.Body())
 		if resp.StatusCode() == http.StatusUnauthorized {
 			// 401 Unauthorized
-			return "", fmt.Errorf("authorization failed while generating a token for the CLI application")
+			return nil, fmt.Errorf("authorization failed while generating a token for the CLI application")
 		}
-		return "", errors.New("Request didn't respond 200 OK for generating a new token. Status: " + resp.Status())
+		return nil, errors.New("Request didn't respond 200 OK for generating a new token. Status: " + resp.Status())
 	}
 
 }
 
+// Prepare the form encoded body of the token request based on the requested grant type
+// @param scopes[] : Scopes of the subscribed APIs, used when no scopes are requested explicitly
+// @return form encoded request body
+func getTokenRequestBody(scopes []string) string {
+	if len(tokenGenOptions.Scopes) > 0 {
+		scopes = tokenGenOptions.Scopes
+	}
+	params := url.Values{}
+	params.Set("grant_type", tokenGenOptions.GrantType)
+	if tokenGenOptions.GrantType == utils.GrantTypePassword {
+		params.Set("username", keyGenCredential.Username)
+		params.Set("password", keyGenCredential.Password)
+	}
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("validity_period", strconv.Itoa(tokenGenOptions.ValidityPeriod))
+	return params.Encode()
+}
+
 // Get all the scopes of the APIs and API Products subscribed to a particular application
 // @param appId : Application ID to get the scopes of subscribed APIs and API Products
 // @param accessToken : Access token to call the devportal REST API
@@ -728,7 +792,7 @@ func generateApplicationKeys(appId string, token string) (*utils.KeygenResponse,
 	generateKeyReq := utils.KeygenRequest{
 		KeyType:                 utils.ProductionKeyType,
 		GrantTypesToBeSupported: utils.GrantTypesToBeSupported,
-		ValidityTime:            utils.DefaultTokenValidityPeriod,
+		ValidityTime:            tokenGenOptions.ValidityPeriod,
 	}
 	body, err := json.Marshal(generateKeyReq)
 	if body == nil && err != nil {
@@ -93,12 +93,14 @@ func PrintThrottlePolicies(resp *resty.Response, format string) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error unmarshalling response data", err)
 	}
-	if format == "" {
-		format = defaultThrottlePolicyTableFormat
-	} else if format == utils.JsonArrayFormatType {
+	if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(policies, utils.ProjectTypePolicy)
 		return
 	}
+	if utils.PrintFormattedOutput(policies, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultThrottlePolicyTableFormat)
 	// create policy context with standard output
 	policyContext := formatter.NewContext(os.Stdout, format)
 	// create a new renderer function which iterate collection
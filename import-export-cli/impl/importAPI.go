@@ -38,12 +38,38 @@ import (
 
 	"github.com/Jeffail/gabs"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
 )
 
 var (
 	reAPIName = regexp.MustCompile(`[~!@#;:%^*()+={}|\\<>"',&/$]`)
+	// Match for the tenant prefix of an API context (ex: /t/wso2.com)
+	reTenantContextPrefix = regexp.MustCompile(`^/t/[^/]+`)
 )
 
+// Keys of the environment params which are applied to the API project before importing
+const (
+	contextParamsKey                = "context"
+	endpointRewritesParamsKey       = "endpointRewrites"
+	deploymentEnvironmentsParamsKey = "deploymentEnvironments"
+	additionalPropertiesParamsKey   = "additionalProperties"
+)
+
+const deploymentEnvironmentsArtifactType = "deployment_environments"
+
+// deploymentEnvironmentsFile represents the deployment environments file of an API project
+type deploymentEnvironmentsFile struct {
+	Type    string                  `yaml:"type"`
+	Version string                  `yaml:"version"`
+	Data    []deploymentEnvironment `yaml:"data"`
+}
+
+type deploymentEnvironment struct {
+	DisplayOnDevportal    bool   `yaml:"displayOnDevportal"`
+	DeploymentEnvironment string `yaml:"deploymentEnvironment"`
+	DeploymentVhost       string `yaml:"deploymentVhost,omitempty"`
+}
+
 // extractAPIDefinition extracts API information from jsonContent
 func extractAPIDefinition(jsonContent []byte) (*v2.APIDefinitionFile, error) {
 	api := &v2.APIDefinitionFile{}
@@ -58,6 +84,7 @@ func extractAPIDefinition(jsonContent []byte) (*v2.APIDefinitionFile, error) {
 // resolveImportFilePath resolves the archive/directory for import
 // First will resolve in given path, if not found will try to load from exported directory
 func resolveImportFilePath(file, defaultExportDirectory string) (string, error) {
+	file = utils.ToNativePath(file)
 	// check current path
 	utils.Logln(utils.LogPrefixInfo + "Resolving for API path...")
 	if _, err := os.Stat(file); os.IsNotExist(err) {
@@ -145,7 +172,8 @@ func replaceEnvVariables(apiFilePath string) error {
 }
 
 // importAPI imports an API to the API manager
-func importAPI(endpoint, filePath, accessToken string, extraParams map[string]string, isOauth bool) error {
+func importAPI(endpoint, filePath, accessToken string, extraParams map[string]string, isOauth bool,
+	outputFormat string) error {
 	resp, err := ExecuteNewFileUploadRequest(endpoint, extraParams, "file",
 		filePath, accessToken, isOauth)
 	utils.Logf("Response : %v", resp)
@@ -155,7 +183,9 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API.")
+		if outputFormat != utils.JsonFormatType {
+			fmt.Println("Successfully imported API.")
+		}
 		return nil
 	} else {
 		// We have an HTTP error
@@ -168,15 +198,24 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 
 // ImportAPIToEnv function is used with import-api command
 func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool, deployTo []string,
+	outputFormat, targetAPIMVersion, contextOverride string, dryRun bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
 	return ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath, importAPIUpdate,
-		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
+		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments, deployTo,
+		outputFormat, targetAPIMVersion, contextOverride, dryRun)
 }
 
 // ImportAPI function is used with import-api command
+// The API is deployed to the gateway environments in the deployment environments file of the project, unless
+// the deployments are skipped or the gateway environments are given by deployTo. If dryRun is true, the changes the
+// import would make to the API are printed instead of importing it.
 func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool, deployTo []string,
+	outputFormat, targetAPIMVersion, contextOverride string, dryRun bool) error {
+	if importAPISkipDeployments && len(deployTo) > 0 {
+		return errors.New("deployments cannot be skipped when the gateway environments to deploy to are given")
+	}
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
 	resolvedAPIFilePath, err := resolveImportFilePath(importPath, exportDirectory)
 	if err != nil {
@@ -208,6 +247,61 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 		return err
 	}
 
+	// Validate the thumbnail, which is imported from the Image directory along with the API
+	err = validateProjectThumbnail(apiFilePath)
+	if err != nil {
+		return err
+	}
+
+	// Package the proto files of a gRPC API into the proto definition archive read by the server
+	err = packageProtoDefinition(apiFilePath)
+	if err != nil {
+		return err
+	}
+
+	// Align the version of the artifacts with the targeted APIM version. The artifacts are imported as they are
+	// unless the version is given, as the conversion changes the project.
+	if targetAPIMVersion != "" {
+		err = convertAPIProjectForImport(apiFilePath, targetAPIMVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	var envParams *params.Environment
+	if apiParamsPath != "" {
+		envParams, err = loadImportEnvParams(apiParamsPath, importEnvironment)
+		if err != nil {
+			return err
+		}
+	}
+	// Rewrite the endpoint urls of the API using the rewrite rules given in the params of the environment
+	if envParams != nil && envParams.Config[endpointRewritesParamsKey] != nil {
+		err = rewriteAPIEndpoints(apiFilePath, envParams.Config[endpointRewritesParamsKey])
+		if err != nil {
+			return err
+		}
+	}
+	// Add or override the additional properties of the API with the ones given in the params of the environment
+	if envParams != nil && envParams.Config[additionalPropertiesParamsKey] != nil {
+		err = setAPIAdditionalProperties(apiFilePath, envParams.Config[additionalPropertiesParamsKey])
+		if err != nil {
+			return err
+		}
+	}
+	// The context given with the flag takes precedence over the context given in the params of the environment
+	if contextOverride == "" && envParams != nil {
+		if context, ok := envParams.Config[contextParamsKey].(string); ok {
+			contextOverride = context
+		}
+	}
+	if contextOverride != "" {
+		err = overrideAPIContext(accessOAuthToken, importEnvironment, apiFilePath, contextOverride)
+		if err != nil {
+			return err
+		}
+	}
+
 	if importAPISkipDeployments {
 		//If skip deployments flag used, deployment_environments files will be removed from import artifacts
 		loc := filepath.Join(apiFilePath, utils.DeploymentEnvFile)
@@ -217,10 +311,39 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 			return err
 		}
 	}
+	if len(deployTo) > 0 {
+		// The server deploys to the environments in the params over the deployment environments file
+		if envParams != nil && envParams.Config[deploymentEnvironmentsParamsKey] != nil {
+			return errors.New("deployment environments are given in the params of " + importEnvironment +
+				", remove them to deploy to the gateway environments given for the import")
+		}
+		err = setDeploymentEnvironments(apiFilePath, deployTo)
+		if err != nil {
+			return err
+		}
+	}
 
 	if apiParamsPath != "" {
 		//Reading params file of the API and add configurations into temp artifact
-		err := handleCustomizedParameters(apiFilePath, apiParamsPath, importEnvironment)
+		err := handleCustomizedParameters(apiFilePath, apiParamsPath, importEnvironment, envParams)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		preview, err := previewAPIImport(accessOAuthToken, importEnvironment, apiFilePath, preserveProvider)
+		if err != nil {
+			return err
+		}
+		PrintAPIImportPreview(preview, outputFormat)
+		return nil
+	}
+
+	// Read the API definition before zipping, so that the imported API can be looked up for the json output
+	var apiDefinition *v2.APIDefinitionFile
+	if outputFormat == utils.JsonFormatType {
+		apiDefinition, _, err = GetAPIDefinition(apiFilePath)
 		if err != nil {
 			return err
 		}
@@ -248,109 +371,409 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 	}
 	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
 
-	err = importAPI(publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true)
-	return err
+	err = importAPI(publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true, outputFormat)
+	if err != nil {
+		return err
+	}
+	if outputFormat == utils.JsonFormatType {
+		result, err := getImportAPIResult(accessOAuthToken, importEnvironment, apiDefinition, preserveProvider,
+			!importAPISkipDeployments)
+		if err != nil {
+			return err
+		}
+		utils.PrintJsonOutput(result)
+	}
+	return nil
 }
 
-// envParamsFileProcess function is used to process the environment parameters when they are provided as a file
-func envParamsFileProcess(importPath, paramsPath, importEnvironment string) error {
-	apiParams, err := params.LoadApiParamsFromFile(paramsPath)
+// convertAPIProjectForImport converts the artifacts of an API project for the targeted APIM version. The project is
+// left as it is if it was generated for the targeted version.
+// @param projectPath : Path to the API project
+// @param targetAPIMVersion : Targeted APIM version
+// @return error
+func convertAPIProjectForImport(projectPath, targetAPIMVersion string) error {
+	targetAPIMVersion, err := utils.NormalizeAPIMVersion(targetAPIMVersion)
 	if err != nil {
 		return err
 	}
-	// check whether import environment is included in params configuration
-	envParams := apiParams.GetEnv(importEnvironment)
-	if envParams == nil {
-		return errors.New("Environment '" + importEnvironment + "' does not exist in " + paramsPath)
+	apiDefinition, _, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return err
+	}
+	if projectVersion, err := utils.NormalizeAPIMVersion(apiDefinition.ApimVersion); err == nil &&
+		projectVersion == targetAPIMVersion {
+		utils.Logln(utils.LogPrefixInfo + "The project already targets APIM version " + targetAPIMVersion)
+		return nil
+	}
+	utils.Logln(utils.LogPrefixInfo + "Targeting APIM version " + targetAPIMVersion)
+	warnings, err := ConvertAPIProjectToVersion(projectPath, targetAPIMVersion)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Println("WARNING: " + warning)
+	}
+	return utils.SetProjectAPIMVersion(projectPath, targetAPIMVersion)
+}
+
+// setDeploymentEnvironments replaces the deployment environments file of an API project, so that a new revision
+// of the API is deployed to the given gateway environments
+// @param projectPath : Path to the API project
+// @param gatewayEnvs : Gateway environments to deploy the API to
+// @return error
+func setDeploymentEnvironments(projectPath string, gatewayEnvs []string) error {
+	apiDefinition, _, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return err
+	}
+	deploymentEnvironments := deploymentEnvironmentsFile{
+		Type:    deploymentEnvironmentsArtifactType,
+		Version: apiDefinition.ApimVersion,
+	}
+	for _, gatewayEnv := range gatewayEnvs {
+		deploymentEnvironments.Data = append(deploymentEnvironments.Data, deploymentEnvironment{
+			DisplayOnDevportal:    true,
+			DeploymentEnvironment: gatewayEnv,
+		})
+	}
+	content, err := yaml.Marshal(deploymentEnvironments)
+	if err != nil {
+		return err
+	}
+	loc := filepath.Join(projectPath, utils.DeploymentEnvFile)
+	utils.Logln(utils.LogPrefixInfo + "Deploying to " + strings.Join(gatewayEnvs, ", ") + " using " + loc)
+	return ioutil.WriteFile(loc, content, os.ModePerm)
+}
+
+// RedeployAPIToEnv deploys the latest revision of the API of a project to gateway environments without importing the
+// project, hence no new revision is created. The revision is deployed to the gateway environments given by deployTo,
+// or to the ones in the deployment environments file of the project if none is given.
+// @param accessToken : Access Token for the environment
+// @param importEnvironment : Environment of the API
+// @param importPath : Path to the API project or its archive
+// @param deployTo : Gateway environments to deploy to, optionally with the vhosts (ex: us-region=us.api.example.com)
+// @param preserveProvider : Whether the provider of the API was preserved when importing it
+// @param outputFormat : Output format of the result
+// @return error
+func RedeployAPIToEnv(accessToken, importEnvironment, importPath string, deployTo []string, preserveProvider bool,
+	outputFormat string) error {
+	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
+	resolvedAPIFilePath, err := resolveImportFilePath(importPath, exportDirectory)
+	if err != nil {
+		return err
+	}
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(resolvedAPIFilePath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	apiDefinition, _, err := GetAPIDefinition(tmpPath)
+	if err != nil {
+		return err
+	}
+	var deployments []utils.Deployment
+	if len(deployTo) > 0 {
+		deployments, err = ParseRevisionDeployments(deployTo, true)
 	} else {
+		deployments, err = readProjectDeployments(tmpPath)
+	}
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		return errors.New("no gateway environment is given to deploy the API to, use --deploy-to or the " +
+			utils.DeploymentEnvFile + " of the project")
+	}
 
-		// Create a source directory and add source content to it and then zip it
-		sourceFilePath := filepath.Join(importPath, "SourceArchive")
-		err = utils.MoveDirectoryContentsToNewDirectory(importPath, sourceFilePath)
-		if err != nil {
-			return err
-		}
+	provider := ""
+	if preserveProvider {
+		provider = apiDefinition.Data.Provider
+	}
+	apiId, err := GetAPIId(accessToken, importEnvironment, apiDefinition.Data.Name, apiDefinition.Data.Version,
+		provider)
+	if err != nil {
+		return err
+	}
+	publisherEndpoint := utils.AppendSlashToString(utils.GetPublisherEndpointOfEnv(importEnvironment,
+		utils.MainConfigFilePath))
+	revision, err := deployLatestAPIRevision(accessToken, publisherEndpoint+"apis", publisherEndpoint+"settings",
+		apiId, deployments)
+	if err != nil {
+		return err
+	}
 
-		err, cleanupFunc := utils.CreateZipFile(sourceFilePath, false)
-		if err != nil {
-			return err
-		}
-		//cleanup the temporary artifacts once consuming the zip file
-		if cleanupFunc != nil {
-			defer cleanupFunc()
-		}
-		//If environment parameters are present in parameter file
-		err = handleEnvParams(importPath, importPath, envParams)
-		if err != nil {
-			return err
-		}
+	if outputFormat == utils.JsonFormatType {
+		utils.PrintJsonOutput(&utils.ImportAPIResult{
+			Id:             apiId,
+			Name:           apiDefinition.Data.Name,
+			Version:        apiDefinition.Data.Version,
+			Provider:       apiDefinition.Data.Provider,
+			RevisionId:     revision.ID,
+			RevisionNumber: revision.RevisionNumber,
+		})
+		return nil
+	}
+	var gatewayEnvs []string
+	for _, deployment := range deployments {
+		gatewayEnvs = append(gatewayEnvs, deployment.Name)
 	}
+	fmt.Println("Successfully deployed " + revision.RevisionNumber + " of API " + apiDefinition.Data.Name + " " +
+		apiDefinition.Data.Version + " to " + strings.Join(gatewayEnvs, ", "))
 	return nil
 }
 
-// envParamsDirectoryProcess function is used to process the environment parameters when they are provided as a
-//directory
-func envParamsDirectoryProcess(importPath, paramsPath, importEnvironment string) error {
-	apiParams, err := params.LoadApiParamsFromDirectory(paramsPath)
+// readProjectDeployments reads the gateway environments in the deployment environments file of an API project
+// @param projectPath : Path to the API project
+// @return deployments, which are empty if the project has no deployment environments file, error
+func readProjectDeployments(projectPath string) ([]utils.Deployment, error) {
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, utils.DeploymentEnvFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// check whether import environment is included in api params configuration
-	envParams := apiParams.GetEnv(importEnvironment)
-	if envParams == nil {
-		return errors.New("Environment '" + importEnvironment + "' does not exist in " + paramsPath)
+	deploymentEnvironments := deploymentEnvironmentsFile{}
+	if err = yaml.Unmarshal(content, &deploymentEnvironments); err != nil {
+		return nil, err
+	}
+	var deployments []utils.Deployment
+	for _, environment := range deploymentEnvironments.Data {
+		deployments = append(deployments, utils.Deployment{
+			Name:               environment.DeploymentEnvironment,
+			Vhost:              environment.DeploymentVhost,
+			DisplayOnDevportal: environment.DisplayOnDevportal,
+		})
+	}
+	return deployments, nil
+}
+
+// getImportAPIResult looks up the imported API and its latest revision to build the json output of the import
+// @param accessToken : Access Token for the environment
+// @param importEnvironment : Environment to which the API was imported
+// @param apiDefinition : Definition of the imported API
+// @param preserveProvider : Whether the provider of the API was preserved during the import
+// @param revisionCreated : Whether the import created a revision, otherwise the revision of the result is empty
+// @return result of the import, error
+func getImportAPIResult(accessToken, importEnvironment string, apiDefinition *v2.APIDefinitionFile,
+	preserveProvider, revisionCreated bool) (*utils.ImportAPIResult, error) {
+	provider := ""
+	if preserveProvider {
+		provider = apiDefinition.Data.Provider
+	}
+	apiId, err := GetAPIId(accessToken, importEnvironment, apiDefinition.Data.Name, apiDefinition.Data.Version, provider)
+	if err != nil {
+		return nil, err
+	}
+	result := &utils.ImportAPIResult{
+		Id:       apiId,
+		Name:     apiDefinition.Data.Name,
+		Version:  apiDefinition.Data.Version,
+		Provider: apiDefinition.Data.Provider,
+	}
+	if !revisionCreated {
+		return result, nil
+	}
+
+	revisionListEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(importEnvironment,
+		utils.MainConfigFilePath)) + apiId + "/revisions"
+	_, revisions, err := GetRevisionsList(accessToken, revisionListEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	// Revisions are listed in the order they were created, hence the last one is the revision created by the import
+	if len(revisions) > 0 {
+		result.RevisionId = revisions[len(revisions)-1].ID
+		result.RevisionNumber = revisions[len(revisions)-1].RevisionNumber
+	}
+	return result, nil
+}
+
+// loadImportEnvParams loads the params of the import environment, if any, with the secrets referred from HashiCorp
+// Vault resolved. The params are loaded once per import and passed down, so that the secrets are read only once.
+// @param paramsPath : Path to the params file or the deployment directory
+// @param importEnvironment : Environment to which the API is imported
+// @return params of the environment, error
+func loadImportEnvParams(paramsPath, importEnvironment string) (*params.Environment, error) {
+	var apiParams *params.ApiParams
+	var err error
+	if strings.Contains(paramsPath, ".yaml") {
+		apiParams, err = params.LoadApiParamsFromFile(paramsPath)
 	} else {
+		apiParams, err = params.LoadApiParamsFromDirectory(paramsPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	envParams := apiParams.GetEnv(importEnvironment)
+	if err = resolveVaultSecrets(envParams, importEnvironment); err != nil {
+		return nil, err
+	}
+	return envParams, nil
+}
 
-		// Create a source directory and add source content to it and then zip it
-		sourceFilePath := filepath.Join(importPath, "SourceArchive")
-		err = utils.MoveDirectoryContentsToNewDirectory(importPath, sourceFilePath)
-		if err != nil {
-			return err
-		}
+// rewriteAPIEndpoints rewrites the endpoint urls of the API to be imported using the given rewrite rules
+// @param apiFilePath : Path to the API project being imported
+// @param rewriteRulesParams : Endpoint rewrite rules given in the params of the environment
+// @return error
+func rewriteAPIEndpoints(apiFilePath string, rewriteRulesParams interface{}) error {
+	rulesJson, err := jsoniter.Marshal(rewriteRulesParams)
+	if err != nil {
+		return err
+	}
+	var rules []utils.EndpointRewriteRule
+	if err = json.Unmarshal(rulesJson, &rules); err != nil {
+		return fmt.Errorf("invalid %s in params: %v", endpointRewritesParamsKey, err)
+	}
+	return utils.RewriteProjectEndpoints(apiFilePath, rules)
+}
 
-		err, cleanupFunc := utils.CreateZipFile(sourceFilePath, false)
-		if err != nil {
-			return err
-		}
-		//cleanup the temporary artifacts once consuming the zip file
-		if cleanupFunc != nil {
-			defer cleanupFunc()
-		}
+// setAPIAdditionalProperties adds or overrides the additional properties of the API to be imported
+// @param apiFilePath : Path to the API project being imported
+// @param propertiesParams : Additional properties given in the params of the environment
+// @return error
+func setAPIAdditionalProperties(apiFilePath string, propertiesParams interface{}) error {
+	propertiesJson, err := jsoniter.Marshal(propertiesParams)
+	if err != nil {
+		return err
+	}
+	var properties []utils.AdditionalProperty
+	if err = json.Unmarshal(propertiesJson, &properties); err != nil {
+		return fmt.Errorf("invalid %s in params: %v", additionalPropertiesParamsKey, err)
+	}
+	return utils.SetProjectAdditionalProperties(apiFilePath, properties)
+}
 
-		//create new directory for deployment configurations
-		deploymentDirectoryPath := filepath.Join(importPath, "Deployment")
-		err = utils.CreateDirIfNotExist(deploymentDirectoryPath)
-		if err != nil {
-			return err
-		}
+// overrideAPIContext sets the given context as the context of the API to be imported after making sure that no
+// other API in the environment uses the same context
+// @param accessToken : Access Token for the environment
+// @param importEnvironment : Environment to which the API is imported
+// @param apiFilePath : Path to the API project being imported
+// @param context : New context of the API
+// @return error
+func overrideAPIContext(accessToken, importEnvironment, apiFilePath, context string) error {
+	context, err := utils.NormalizeAPIContext(context)
+	if err != nil {
+		return err
+	}
+	apiDefinition, _, err := GetAPIDefinition(apiFilePath)
+	if err != nil {
+		return err
+	}
+	err = checkAPIContextCollision(accessToken, importEnvironment, apiDefinition.Data.Name, context)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Overriding the context of the API with " + context)
+	return utils.SetProjectAPIContext(apiFilePath, context)
+}
 
-		//copy all the content in the params directory into the artifact to be imported
-		err = utils.CopyDirectoryContents(paramsPath, deploymentDirectoryPath)
-		if err != nil {
-			return err
+// checkAPIContextCollision returns an error if an API other than the versions of the API being imported uses the
+// given context in the environment
+// @param accessToken : Access Token for the environment
+// @param importEnvironment : Environment to which the API is imported
+// @param apiName : Name of the API being imported
+// @param context : Context to be checked
+// @return error
+func checkAPIContextCollision(accessToken, importEnvironment, apiName, context string) error {
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
+	_, apis, err := GetAPIList(accessToken, apiListEndpoint, contextParamsKey+":"+context, "")
+	if err != nil {
+		return err
+	}
+	for _, api := range apis {
+		// Versions of the same API share the context
+		if api.Name == apiName {
+			continue
 		}
-		//If environment parameters are present in parameter file inside the deployment params directory
-		err = handleEnvParams(importPath, deploymentDirectoryPath, envParams)
-		if err != nil {
-			return err
+		existingContext := reTenantContextPrefix.ReplaceAllString(api.Context, "")
+		if existingContext == context || existingContext == context+"/"+api.Version {
+			return fmt.Errorf("context %s is already used by the API %s %s in the environment %s", context,
+				api.Name, api.Version, importEnvironment)
 		}
 	}
 	return nil
 }
 
+// envParamsFileProcess function is used to process the environment parameters when they are provided as a file
+func envParamsFileProcess(importPath string, envParams *params.Environment) error {
+	// Create a source directory and add source content to it and then zip it
+	sourceFilePath := filepath.Join(importPath, "SourceArchive")
+	err := utils.MoveDirectoryContentsToNewDirectory(importPath, sourceFilePath)
+	if err != nil {
+		return err
+	}
+
+	err, cleanupFunc := utils.CreateZipFile(sourceFilePath, false)
+	if err != nil {
+		return err
+	}
+	//cleanup the temporary artifacts once consuming the zip file
+	if cleanupFunc != nil {
+		defer cleanupFunc()
+	}
+	//If environment parameters are present in parameter file
+	return handleEnvParams(importPath, importPath, envParams)
+}
+
+// envParamsDirectoryProcess function is used to process the environment parameters when they are provided as a
+//directory
+func envParamsDirectoryProcess(importPath, paramsPath string, envParams *params.Environment) error {
+	// Create a source directory and add source content to it and then zip it
+	sourceFilePath := filepath.Join(importPath, "SourceArchive")
+	err := utils.MoveDirectoryContentsToNewDirectory(importPath, sourceFilePath)
+	if err != nil {
+		return err
+	}
+
+	err, cleanupFunc := utils.CreateZipFile(sourceFilePath, false)
+	if err != nil {
+		return err
+	}
+	//cleanup the temporary artifacts once consuming the zip file
+	if cleanupFunc != nil {
+		defer cleanupFunc()
+	}
+
+	//create new directory for deployment configurations
+	deploymentDirectoryPath := filepath.Join(importPath, "Deployment")
+	err = utils.CreateDirIfNotExist(deploymentDirectoryPath)
+	if err != nil {
+		return err
+	}
+
+	//copy all the content in the params directory into the artifact to be imported
+	err = utils.CopyDirectoryContents(paramsPath, deploymentDirectoryPath)
+	if err != nil {
+		return err
+	}
+	//If environment parameters are present in parameter file inside the deployment params directory
+	return handleEnvParams(importPath, deploymentDirectoryPath, envParams)
+}
+
 // handleCustomizedParameters handles the configurations provided with params file of the API and the resources that needs to
 // transfer to server side will bundle with the artifact to be imported.
-func handleCustomizedParameters(importPath, paramsPath, importEnvironment string) error {
+// @param importPath : Path to the artifact to be imported
+// @param paramsPath : Path to the params file or the deployment directory
+// @param importEnvironment : Environment to which the artifact is imported
+// @param envParams : Params of the environment loaded with loadImportEnvParams
+// @return error
+func handleCustomizedParameters(importPath, paramsPath, importEnvironment string, envParams *params.Environment) error {
 	utils.Logln(utils.LogPrefixInfo+"Loading parameters from", paramsPath)
+	// check whether import environment is included in params configuration
+	if envParams == nil {
+		return errors.New("Environment '" + importEnvironment + "' does not exist in " + paramsPath)
+	}
 	if strings.Contains(paramsPath, ".yaml") {
 		utils.Logln(utils.LogPrefixInfo+"Processing Params file", paramsPath)
-		err := envParamsFileProcess(importPath, paramsPath, importEnvironment)
+		err := envParamsFileProcess(importPath, envParams)
 		if err != nil {
 			return err
 		}
 	} else {
 		utils.Logln(utils.LogPrefixInfo+"Processing Params in the deployment directory", paramsPath)
-		err := envParamsDirectoryProcess(importPath, paramsPath, importEnvironment)
+		err := envParamsDirectoryProcess(importPath, paramsPath, envParams)
 		if err != nil {
 			return err
 		}
@@ -361,6 +784,11 @@ func handleCustomizedParameters(importPath, paramsPath, importEnvironment string
 
 // Process env params and create the intermediate_params.yaml file to pass to the server
 func handleEnvParams(tempDirectory string, destDirectory string, environmentParams *params.Environment) error {
+	// The context, the endpoint rewrites and the additional properties are already applied to the API definition,
+	// hence they should not be passed to the server
+	delete(environmentParams.Config, contextParamsKey)
+	delete(environmentParams.Config, endpointRewritesParamsKey)
+	delete(environmentParams.Config, additionalPropertiesParamsKey)
 	// read api params from external parameters file
 	envParamsJson, err := jsoniter.Marshal(environmentParams.Config)
 	if err != nil {
@@ -170,6 +170,7 @@ func executeAPIPolicyImportRequest(uri string, importPath string, accessToken st
 // resolveImportFilePath resolves the archive/directory for importing API policy
 // First will resolve in given path, if not found will try to load from exported directory
 func resolvePolicyImportFilePath(file, defaultExportDirectory string) (string, error) {
+	file = utils.ToNativePath(file)
 	// check current path
 	utils.Logln(utils.LogPrefixInfo + "Resolving for Policy path...")
 	if _, err := os.Stat(file); os.IsNotExist(err) {
@@ -38,6 +38,7 @@ var (
 // resolveImportAPIProductFilePath resolves the archive/directory for import
 // First will resolve in given path, if not found will try to load from exported directory
 func resolveImportAPIProductFilePath(file, defaultExportDirectory string) (string, error) {
+	file = utils.ToNativePath(file)
 	// Check current path
 	utils.Logln(utils.LogPrefixInfo + "Resolving for API Product path...")
 	if _, err := os.Stat(file); os.IsNotExist(err) {
@@ -131,7 +132,11 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 
 	if apiProductParamsPath != "" {
 		// Reading params file of the API Product and add configurations into temp artifact
-		err := handleCustomizedParameters(apiProductFilePath, apiProductParamsPath, importEnvironment)
+		envParams, err := loadImportEnvParams(apiProductParamsPath, importEnvironment)
+		if err != nil {
+			return err
+		}
+		err = handleCustomizedParameters(apiProductFilePath, apiProductParamsPath, importEnvironment, envParams)
 		if err != nil {
 			return err
 		}
@@ -19,13 +19,17 @@
 package impl
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func TestExtractAPIInfoWithCorrectJSON(t *testing.T) {
@@ -93,3 +97,96 @@ func TestGetAPIInfoMalformedDirectory(t *testing.T) {
 	assert.Nil(t, api,
 		"Should return nil for malformed directories")
 }
+
+func TestSetDeploymentEnvironments(t *testing.T) {
+	projectPath := t.TempDir()
+	apiYaml := "type: api\nversion: v4.2.0\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n"
+	err := ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(apiYaml), os.ModePerm)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(projectPath, utils.DeploymentEnvFile), []byte("type: deployment_environments\n"+
+		"version: v4.2.0\ndata:\n - displayOnDevportal: true\n   deploymentEnvironment: Default\n"), os.ModePerm)
+	assert.Nil(t, err)
+
+	err = setDeploymentEnvironments(projectPath, []string{"us-region", "eu-region"})
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, utils.DeploymentEnvFile))
+	assert.Nil(t, err)
+	deploymentEnvironments := deploymentEnvironmentsFile{}
+	assert.Nil(t, yaml.Unmarshal(content, &deploymentEnvironments))
+	assert.Equal(t, deploymentEnvironmentsFile{
+		Type:    "deployment_environments",
+		Version: "v4.2.0",
+		Data: []deploymentEnvironment{
+			{DisplayOnDevportal: true, DeploymentEnvironment: "us-region"},
+			{DisplayOnDevportal: true, DeploymentEnvironment: "eu-region"},
+		},
+	}, deploymentEnvironments, "Should replace the deployment environments of the project")
+}
+
+func TestReadProjectDeployments(t *testing.T) {
+	projectPath := t.TempDir()
+	deployments, err := readProjectDeployments(projectPath)
+	assert.Nil(t, err)
+	assert.Empty(t, deployments, "Should not deploy a project without deployment environments")
+
+	err = ioutil.WriteFile(filepath.Join(projectPath, utils.DeploymentEnvFile), []byte("type: deployment_environments\n"+
+		"version: v4.2.0\ndata:\n - displayOnDevportal: true\n   deploymentEnvironment: Default\n"+
+		" - displayOnDevportal: false\n   deploymentEnvironment: us-region\n   deploymentVhost: us.wso2.com\n"),
+		os.ModePerm)
+	assert.Nil(t, err)
+	deployments, err = readProjectDeployments(projectPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.Deployment{
+		{Name: "Default", DisplayOnDevportal: true},
+		{Name: "us-region", Vhost: "us.wso2.com"},
+	}, deployments)
+}
+
+func TestConvertAPIProjectForImport(t *testing.T) {
+	projectPath := t.TempDir()
+	apiYaml := "type: api\nversion: v4.2.0\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(apiYaml), os.ModePerm))
+	deploymentEnvironments := "type: deployment_environments\nversion: v4.1.0\ndata: []\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.DeploymentEnvFile), []byte(deploymentEnvironments),
+		os.ModePerm))
+
+	assert.Nil(t, convertAPIProjectForImport(projectPath, "4.2"))
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, utils.DeploymentEnvFile))
+	assert.Nil(t, err)
+	assert.Equal(t, deploymentEnvironments, string(content), "Should not change a project of the targeted version")
+
+	assert.Nil(t, convertAPIProjectForImport(projectPath, "4.3.0"))
+	definition, _, err := GetAPIDefinition(projectPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "v4.3.0", definition.ApimVersion)
+
+	assert.Error(t, convertAPIProjectForImport(projectPath, "3.2.0"), "Should not target an unsupported version")
+}
+
+func TestHandleCustomizedParametersUsesLoadedParams(t *testing.T) {
+	projectPath := t.TempDir()
+	apiYaml := "type: api\nversion: v4.2.0\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(apiYaml), os.ModePerm))
+	paramsPath := filepath.Join(t.TempDir(), utils.ParamFile)
+	paramsYaml := "environments:\n - name: dev\n   configs:\n     security:\n       production:\n" +
+		"         password: vault:secret/data/apim#password\n"
+	assert.Nil(t, ioutil.WriteFile(paramsPath, []byte(paramsYaml), os.ModePerm))
+
+	// The secrets are already resolved, hence Vault, which is not configured for dev, should not be queried again
+	envParams := &params.Environment{Name: "dev", Config: map[string]interface{}{
+		"security": map[string]interface{}{"production": map[string]interface{}{"password": "s3cret"}},
+	}}
+	assert.Nil(t, handleCustomizedParameters(projectPath, paramsPath, "dev", envParams))
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, utils.ParamsIntermediateFile))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "password: s3cret")
+
+	err = handleCustomizedParameters(t.TempDir(), paramsPath, "prod", nil)
+	assert.EqualError(t, err, "Environment 'prod' does not exist in "+paramsPath)
+}
+
+func TestImportAPISkipDeploymentsWithDeployTo(t *testing.T) {
+	err := ImportAPI("access-token", "", "dev", "PizzaShackAPI", "", true, true, false, false, true,
+		[]string{"Default"}, "", "", "", false)
+	assert.Error(t, err, "Should not skip the deployments when the gateway environments are given")
+}
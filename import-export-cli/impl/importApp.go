@@ -79,6 +79,7 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 		utils.HandleErrorAndExit("Error creating request.", err)
 	}
 
+	projectPath := applicationFilePath
 	// If applicationFilePath contains a directory, zip it. Otherwise, leave it as it is.
 	applicationFilePath, err, cleanupFunc := utils.CreateZipFileFromProject(applicationFilePath, skipCleanup)
 	if err != nil {
@@ -100,6 +101,13 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
 		fmt.Println("Successfully imported Application.")
+		if !skipKeys {
+			err = restoreApplicationKeyMappings(devportalApplicationsEndpoint, projectPath, accessToken)
+			if err != nil {
+				return nil, errors.New("Error restoring the key mappings of the imported Application. " +
+					err.Error())
+			}
+		}
 		return nil, nil
 	} else {
 		// We have an HTTP error
@@ -113,6 +121,7 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 // resolveApplicationImportFilePath resolves the archive/directory for import
 // First will resolve in given path, if not found will try to load from exported directory
 func resolveApplicationImportFilePath(file, defaultExportDirectory string) (string, error) {
+	file = utils.ToNativePath(file)
 	// check current path
 	utils.Logln(utils.LogPrefixInfo + "Resolving for Application path...")
 	if _, err := os.Stat(file); os.IsNotExist(err) {
@@ -21,10 +21,12 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -51,7 +53,66 @@ func importThrottlingPolicy(endpoint string, importPath string, accessToken stri
 
 	utils.Logln(utils.LogPrefixInfo + "Policy Location: ", resolvedPolicyFilePath)
 
-	resp, err := executeThrottlingPolicyUploadRequest(endpoint, resolvedPolicyFilePath, ThrottlePolicyUpdate, accessToken, isOauth)
+	if info, err := os.Stat(resolvedPolicyFilePath); err == nil && info.IsDir() {
+		return importThrottlingPoliciesInDir(endpoint, resolvedPolicyFilePath, accessToken, isOauth, ThrottlePolicyUpdate)
+	}
+	return importThrottlingPolicyFile(endpoint, resolvedPolicyFilePath, accessToken, isOauth, ThrottlePolicyUpdate)
+}
+
+// importThrottlingPoliciesInDir imports all the Throttling Policy files in a directory, such as a directory of
+// policies exported from another environment
+// @param endpoint : Import endpoint of the admin REST API
+// @param policiesDir : Directory containing the policy files
+// @param accessToken : Access token to call the admin REST API
+// @param isOauth : Whether the access token is an OAuth token
+// @param ThrottlePolicyUpdate : Whether to update the existing policies
+// @return error
+func importThrottlingPoliciesInDir(endpoint string, policiesDir string, accessToken string, isOauth bool,
+	ThrottlePolicyUpdate bool) error {
+	files, err := ioutil.ReadDir(policiesDir)
+	if err != nil {
+		return err
+	}
+	var failedPolicies []string
+	for _, file := range files {
+		extension := strings.ToLower(filepath.Ext(file.Name()))
+		if file.IsDir() || (extension != ".yaml" && extension != ".yml" && extension != ".json") {
+			continue
+		}
+		fmt.Println("Importing Throttling Policy " + file.Name())
+		err = importThrottlingPolicyFile(endpoint, filepath.Join(policiesDir, file.Name()), accessToken, isOauth,
+			ThrottlePolicyUpdate)
+		if err != nil {
+			utils.HandleErrorAndContinue("Error importing Throttling Policy "+file.Name(), err)
+			failedPolicies = append(failedPolicies, file.Name())
+		}
+	}
+	if len(failedPolicies) > 0 {
+		return errors.New("failed to import the Throttling Policies " + strings.Join(failedPolicies, ", "))
+	}
+	return nil
+}
+
+// importThrottlingPolicyFile validates and imports a Throttling Policy file
+// @param endpoint : Import endpoint of the admin REST API
+// @param policyFilePath : Path to the policy file
+// @param accessToken : Access token to call the admin REST API
+// @param isOauth : Whether the access token is an OAuth token
+// @param ThrottlePolicyUpdate : Whether to update the existing policy
+// @return error
+func importThrottlingPolicyFile(endpoint string, policyFilePath string, accessToken string, isOauth bool,
+	ThrottlePolicyUpdate bool) error {
+	content, err := ioutil.ReadFile(policyFilePath)
+	if err != nil {
+		return err
+	}
+	// Validate the burst control, custom attributes and monetization fields of subscription policies before
+	// uploading, so that the invalid fields are reported together
+	if err = utils.ValidateSubscriptionThrottlePolicy(content); err != nil {
+		return err
+	}
+
+	resp, err := executeThrottlingPolicyUploadRequest(endpoint, policyFilePath, ThrottlePolicyUpdate, accessToken, isOauth)
 	utils.Logf("Response : %v", resp)
 	if err != nil {
 		utils.Logln(utils.LogPrefixError, err)
@@ -29,6 +29,7 @@ import (
 
 	"github.com/Jeffail/gabs"
 	"github.com/go-openapi/loads"
+	"github.com/go-openapi/swag"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/wso2/product-apim-tooling/import-export-cli/box"
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
@@ -50,7 +51,10 @@ var dirs = []string{
 }
 
 // InitAPIProject function is used to initlialize an API Project
-func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
+// The values of interactiveDefinition, if given, take precedence over the ones of the API definition file
+func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdAsyncAPIPath, initCmdProtoPath,
+	initCmdApiDefinitionPath string, interactiveDefinition *v2.APIDefinitionFile, isAdvertiseOnly bool,
+	initCmdTargetVersion string) error {
 	var dir string
 	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
 
@@ -81,6 +85,15 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
+	if initCmdTargetVersion != "" {
+		targetVersion, err := utils.NormalizeAPIMVersion(initCmdTargetVersion)
+		if err != nil {
+			return err
+		}
+		initCmdTargetVersion = targetVersion
+		definitionFile.ApimVersion = targetVersion
+	}
+
 	// initCmdInitialState has already validated before creating the 'dir'
 	if initCmdInitialState != "" {
 		def.LifeCycleStatus = initCmdInitialState
@@ -91,23 +104,77 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	// Use the swagger definition to populate the API definition and save the swagger file separately inside the project
-	if initCmdSwaggerPath != "" {
-		// Load the swagger file from the provided path
-		doc, err := loadSwagger(initCmdSwaggerPath)
+	// Use the AsyncAPI definition to populate the API definition and save the AsyncAPI file separately inside the
+	// project. The swagger definition is not used by WebSocket, SSE and WebSub APIs.
+	if initCmdAsyncAPIPath != "" {
+		asyncAPIDoc, err := loadAsyncAPI(initCmdAsyncAPIPath)
+		if err != nil {
+			return err
+		}
+		err = v2.AsyncAPIPopulate(def, asyncAPIDoc)
+		if err != nil {
+			return err
+		}
+		yamlAsyncAPI, err := utils.JsonToYaml(asyncAPIDoc)
+		if err != nil {
+			return err
+		}
+		asyncAPISavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsAsyncAPI))
+		utils.Logln(utils.LogPrefixInfo + "Writing " + asyncAPISavePath)
+		err = ioutil.WriteFile(asyncAPISavePath, yamlAsyncAPI, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	} else if initCmdProtoPath != "" {
+		// Use the proto file to populate the gRPC API definition and save it along with the files it imports
+		// inside the project. They are packaged into the proto definition archive when the API is imported.
+		utils.Logln(utils.LogPrefixInfo + "Loading proto definition from " + initCmdProtoPath)
+		protoFiles, mainProtoFile, err := collectProtoFiles(initCmdProtoPath)
+		if err != nil {
+			return err
+		}
+		err = v2.ProtoPopulate(def, protoFiles[mainProtoFile])
+		if err != nil {
+			return err
+		}
+		err = writeProtoFiles(initCmdOutputDir, protoFiles)
 		if err != nil {
 			return err
 		}
-		// We use swagger2 loader. It works fine for now
-		// Since we don't use 3.0 specific details its ok
-		// otherwise please use v2.openAPI3 loaders
-		err = v2.Swagger2Populate(def, doc)
+	} else if initCmdSwaggerPath != "" {
+		// Use the swagger definition to populate the API definition and save the swagger file separately inside
+		// the project
+		// OpenAPI 3.1 documents are populated separately since the swagger2 loader rejects their
+		// JSON Schema 2020-12 keywords
+		oai31Doc, isOAI31, err := loadOAI31(initCmdSwaggerPath)
 		if err != nil {
 			return err
 		}
+		var rawSwagger []byte
+		if isOAI31 {
+			err = v2.OAI31Populate(def, oai31Doc)
+			if err != nil {
+				return err
+			}
+			rawSwagger = oai31Doc
+		} else {
+			// Load the swagger file from the provided path
+			doc, err := loadSwagger(initCmdSwaggerPath)
+			if err != nil {
+				return err
+			}
+			// We use swagger2 loader. It works fine for now
+			// Since we don't use 3.0 specific details its ok
+			// otherwise please use v2.openAPI3 loaders
+			err = v2.Swagger2Populate(def, doc)
+			if err != nil {
+				return err
+			}
+			rawSwagger = doc.Raw()
+		}
 
 		// Convert and write the swagger definition as yaml
-		yamlSwagger, err := utils.JsonToYaml(doc.Raw())
+		yamlSwagger, err := utils.JsonToYaml(rawSwagger)
 		if err != nil {
 			return err
 		}
@@ -142,28 +209,18 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 			return err
 		}
 
-		// Marshal original definition
-		originalDefBytes, err := jsoniter.Marshal(definitionFile)
-		if err != nil {
-			return err
-		}
-		// Marshal new definition
-		newDefBytes, err := jsoniter.Marshal(apiDef)
+		err = mergeAPIDefinition(definitionFile, apiDef)
 		if err != nil {
 			return err
 		}
+	}
 
-		// Merge two definitions
-		finalDefBytes, err := utils.MergeJSON(originalDefBytes, newDefBytes)
-		if err != nil {
-			return err
-		}
-		tmpDef := &v2.APIDefinitionFile{}
-		err = json.Unmarshal(finalDefBytes, &tmpDef)
+	// Use the values given interactively
+	if interactiveDefinition != nil {
+		err = mergeAPIDefinition(definitionFile, interactiveDefinition)
 		if err != nil {
 			return err
 		}
-		definitionFile.Data = tmpDef.Data
 	}
 
 	// If the name of the API is still empty, set the project name as the API name
@@ -195,6 +252,9 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		apimProjDeploymentEnvironmentsFilePath := filepath.Join(initCmdOutputDir, utils.DeploymentEnvFile)
 		utils.Logln(utils.LogPrefixInfo + "Writing " + apimProjDeploymentEnvironmentsFilePath)
 		deploymentEnvironments, _ := box.Get("/init/default_deployment_environments.yaml")
+		if initCmdTargetVersion != "" {
+			deploymentEnvironments = utils.ReplaceArtifactAPIMVersion(deploymentEnvironments, initCmdTargetVersion)
+		}
 		err = ioutil.WriteFile(apimProjDeploymentEnvironmentsFilePath, deploymentEnvironments, os.ModePerm)
 		if err != nil {
 			return err
@@ -244,6 +304,33 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 	return nil
 }
 
+// mergeAPIDefinition merges the non empty values of apiDef into the data of definitionFile
+func mergeAPIDefinition(definitionFile, apiDef *v2.APIDefinitionFile) error {
+	// Marshal original definition
+	originalDefBytes, err := jsoniter.Marshal(definitionFile)
+	if err != nil {
+		return err
+	}
+	// Marshal new definition
+	newDefBytes, err := jsoniter.Marshal(apiDef)
+	if err != nil {
+		return err
+	}
+
+	// Merge two definitions
+	finalDefBytes, err := utils.MergeJSON(originalDefBytes, newDefBytes)
+	if err != nil {
+		return err
+	}
+	tmpDef := &v2.APIDefinitionFile{}
+	err = json.Unmarshal(finalDefBytes, &tmpDef)
+	if err != nil {
+		return err
+	}
+	definitionFile.Data = tmpDef.Data
+	return nil
+}
+
 // loadDefaultSpec loads the API definition
 func loadDefaultSpec() (*v2.APIDefinitionFile, error) {
 	defaultData, ok := box.Get("/init/default_api.yaml")
@@ -272,8 +359,37 @@ func createDirectories(name string) error {
 }
 
 // loadSwagger will Load the swagger definition from swaggerDoc
-// Swagger2.0/OpenAPI3.0 specs are supported
+// Swagger2.0/OpenAPI3.0 specs are supported. OpenAPI3.1 specs are loaded by loadOAI31
 func loadSwagger(swaggerDoc string) (*loads.Document, error) {
 	utils.Logln(utils.LogPrefixInfo + "Loading swagger from " + swaggerDoc)
 	return loads.Spec(swaggerDoc)
 }
+
+// loadOAI31 reads the definition from swaggerDoc, which is either a file or a URL, and converts it to JSON if it is
+// an OpenAPI 3.1 document
+func loadOAI31(swaggerDoc string) ([]byte, bool, error) {
+	content, err := swag.LoadFromFileOrHTTP(swaggerDoc)
+	if err != nil {
+		return nil, false, err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		// Leave the error to be reported by the swagger2 loader
+		return nil, false, nil
+	}
+	if !v2.IsOAI31(jsonContent) {
+		return nil, false, nil
+	}
+	utils.Logln(utils.LogPrefixInfo + "Loading OpenAPI 3.1 definition from " + swaggerDoc)
+	return jsonContent, true, nil
+}
+
+// loadAsyncAPI reads the AsyncAPI definition from asyncAPIDoc, which is either a file or a URL, and converts it to JSON
+func loadAsyncAPI(asyncAPIDoc string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading AsyncAPI definition from " + asyncAPIDoc)
+	content, err := swag.LoadFromFileOrHTTP(asyncAPIDoc)
+	if err != nil {
+		return nil, err
+	}
+	return utils.YamlToJson(content)
+}
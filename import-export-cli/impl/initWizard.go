@@ -0,0 +1,134 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// initWizardOption is an option of a choice made in the interactive init wizard
+type initWizardOption struct {
+	Caption string
+	Values  []string
+}
+
+// Security schemes offered by the interactive init wizard
+var initWizardSecuritySchemes = []initWizardOption{
+	{Caption: "OAuth2", Values: []string{"oauth2", "oauth_basic_auth_api_key_mandatory"}},
+	{Caption: "API Key", Values: []string{"api_key", "oauth_basic_auth_api_key_mandatory"}},
+	{Caption: "Basic Auth", Values: []string{"basic_auth", "oauth_basic_auth_api_key_mandatory"}},
+	{Caption: "OAuth2 and API Key", Values: []string{"oauth2", "api_key", "oauth_basic_auth_api_key_mandatory"}},
+}
+
+// Gateway types offered by the interactive init wizard
+var initWizardGatewayTypes = []initWizardOption{
+	{Caption: "Regular", Values: []string{"wso2/synapse"}},
+	{Caption: "APK", Values: []string{"wso2/apk"}},
+	{Caption: "Choreo Connect", Values: []string{"wso2/choreo-connect"}},
+}
+
+// Functions used to read the inputs of the wizard, which are replaced when testing
+var (
+	readInitWizardInput  = utils.ReadInputString
+	readInitWizardOption = utils.ReadOption
+)
+
+// ReadInitInputsInteractive walks through the name, version, context, endpoint, security scheme and gateway type of
+// the API to initialize, suggesting defaults based on the project path
+// @param projectPath : Path of the project to initialize
+// @return API definition with the values given by the user
+// @return error
+func ReadInitInputsInteractive(projectPath string) (*v2.APIDefinitionFile, error) {
+	var def v2.APIDTODefinition
+	for {
+		var err error
+		name := strings.ReplaceAll(filepath.Base(projectPath), " ", "")
+		def.Name, err = readInitWizardInput("API name", utils.Default{Value: name, IsDefault: name != ""},
+			`^[^\s~!@#;:%^*()+={}|\\<>"',&$\[\]/]+$`, true)
+		if err != nil {
+			return nil, err
+		}
+		def.Version, err = readInitWizardInput("API version", utils.Default{Value: "1.0.0", IsDefault: true},
+			`^[^\s~!@#;:%^*()+={}|\\<>"',&/$\[\]]+$`, true)
+		if err != nil {
+			return nil, err
+		}
+		def.Context, err = readInitWizardInput("API context", utils.Default{Value: "/" + strings.ToLower(def.Name),
+			IsDefault: true}, `^/[^\s~!@#;:%^*()+=|\\<>"',&$\[\]]*$`, true)
+		if err != nil {
+			return nil, err
+		}
+		endpoint, err := readInitWizardInput("Backend endpoint URL", utils.Default{Value: "http://localhost:8080",
+			IsDefault: true}, `^(https?|wss?|grpcs?)://\S+$`, true)
+		if err != nil {
+			return nil, err
+		}
+		def.EndpointConfig = map[string]interface{}{
+			"endpoint_type":        v2.EpHttp,
+			"production_endpoints": map[string]interface{}{"url": endpoint},
+			"sandbox_endpoints":    map[string]interface{}{"url": endpoint},
+		}
+		securityScheme, err := readInitWizardChoice("Choose the security scheme of the API:",
+			initWizardSecuritySchemes)
+		if err != nil {
+			return nil, err
+		}
+		def.SecurityScheme = securityScheme.Values
+		gatewayType, err := readInitWizardChoice("Choose the gateway type of the API:", initWizardGatewayTypes)
+		if err != nil {
+			return nil, err
+		}
+		def.GatewayType = gatewayType.Values[0]
+
+		fmt.Println("\nName           : " + def.Name)
+		fmt.Println("Version        : " + def.Version)
+		fmt.Println("Context        : " + def.Context)
+		fmt.Println("Endpoint       : " + endpoint)
+		fmt.Println("Security scheme: " + securityScheme.Caption)
+		fmt.Println("Gateway type   : " + gatewayType.Caption)
+
+		isConfirmStr, err := readInitWizardInput("Confirm configurations",
+			utils.Default{Value: "Y", IsDefault: true}, "", false)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(isConfirmStr, "y") || strings.EqualFold(isConfirmStr, "yes") {
+			break
+		}
+	}
+	return &v2.APIDefinitionFile{Data: def}, nil
+}
+
+// readInitWizardChoice prints the options and reads the option chosen by the user, the first option by default
+func readInitWizardChoice(printText string, options []initWizardOption) (initWizardOption, error) {
+	fmt.Println(printText)
+	for i, option := range options {
+		fmt.Printf("%d: %s\n", i+1, option.Caption)
+	}
+	chosen, err := readInitWizardOption("Choose a number", 1, len(options), true)
+	if err != nil {
+		return initWizardOption{}, err
+	}
+	return options[chosen-1], nil
+}
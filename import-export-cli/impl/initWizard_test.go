@@ -0,0 +1,90 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// stubInitWizardInputs answers the prompts of the wizard with the given inputs in order. An empty input takes the
+// default value of the prompt.
+func stubInitWizardInputs(t *testing.T, inputs []string, options []int) {
+	previousInput, previousOption := readInitWizardInput, readInitWizardOption
+	t.Cleanup(func() {
+		readInitWizardInput, readInitWizardOption = previousInput, previousOption
+	})
+	readInitWizardInput = func(printText string, defaultVal utils.Default, validRegex string,
+		retryOnInvalid bool) (string, error) {
+		if !assert.NotEmpty(t, inputs, "unexpected prompt: "+printText) {
+			return "", nil
+		}
+		input := inputs[0]
+		inputs = inputs[1:]
+		if input == "" {
+			return defaultVal.Value, nil
+		}
+		if validRegex != "" {
+			assert.True(t, utils.ValidateValue(input, validRegex), "invalid input for "+printText+": "+input)
+		}
+		return input, nil
+	}
+	readInitWizardOption = func(printText string, defaultVal int, maxValue int, retryOnInvalid bool) (int, error) {
+		if !assert.NotEmpty(t, options, "unexpected prompt: "+printText) {
+			return defaultVal, nil
+		}
+		option := options[0]
+		options = options[1:]
+		return option, nil
+	}
+}
+
+func TestReadInitInputsInteractiveDefaults(t *testing.T) {
+	stubInitWizardInputs(t, []string{"", "", "", "", ""}, []int{1, 1})
+
+	definition, err := ReadInitInputsInteractive("projects/PizzaShack")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PizzaShack", definition.Data.Name)
+	assert.Equal(t, "1.0.0", definition.Data.Version)
+	assert.Equal(t, "/pizzashack", definition.Data.Context)
+	assert.Equal(t, []string{"oauth2", "oauth_basic_auth_api_key_mandatory"}, definition.Data.SecurityScheme)
+	assert.Equal(t, "wso2/synapse", definition.Data.GatewayType)
+	assert.Equal(t, map[string]interface{}{"url": "http://localhost:8080"},
+		definition.Data.EndpointConfig.(map[string]interface{})["production_endpoints"])
+}
+
+func TestReadInitInputsInteractiveReenter(t *testing.T) {
+	stubInitWizardInputs(t,
+		[]string{"Orders", "v1", "", "https://orders.example.com", "n",
+			"OrderAPI", "2.0.0", "/orders", "https://orders.example.com/api", "yes"},
+		[]int{2, 2, 4, 2})
+
+	definition, err := ReadInitInputsInteractive("orders")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "OrderAPI", definition.Data.Name, "the inputs given after rejecting the first ones should be used")
+	assert.Equal(t, "2.0.0", definition.Data.Version)
+	assert.Equal(t, "/orders", definition.Data.Context)
+	assert.Equal(t, []string{"oauth2", "api_key", "oauth_basic_auth_api_key_mandatory"},
+		definition.Data.SecurityScheme)
+	assert.Equal(t, "wso2/apk", definition.Data.GatewayType)
+	assert.Equal(t, map[string]interface{}{"url": "https://orders.example.com/api"},
+		definition.Data.EndpointConfig.(map[string]interface{})["sandbox_endpoints"])
+}
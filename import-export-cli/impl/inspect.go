@@ -0,0 +1,315 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Files of an API project summarized when inspecting, given without the extension as they are either in YAML or JSON
+const (
+	endpointCertificatesFile = "endpoint_certificates"
+	clientCertificatesFile   = "client_certificates"
+	documentFile             = "document"
+)
+
+// endpointConfigKeys maps the keys of the endpoint config holding endpoints to the names they are listed with
+var endpointConfigKeys = []struct{ key, name string }{
+	{"production_endpoints", "production"},
+	{"sandbox_endpoints", "sandbox"},
+	{"production_failovers", "production failover"},
+	{"sandbox_failovers", "sandbox failover"},
+}
+
+// ArchiveSummary is the summary of an exported API archive or API project
+type ArchiveSummary struct {
+	Name            string                 `json:"name"`
+	Version         string                 `json:"version"`
+	Context         string                 `json:"context"`
+	Provider        string                 `json:"provider,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	LifeCycleStatus string                 `json:"lifeCycleStatus,omitempty"`
+	Endpoints       []InspectedEndpoint    `json:"endpoints"`
+	Resources       []InspectedResource    `json:"resources"`
+	Policies        []InspectedPolicy      `json:"policies"`
+	Certificates    []InspectedCertificate `json:"certificates"`
+	Docs            []InspectedDocument    `json:"docs"`
+}
+
+// InspectedEndpoint is an endpoint of the API
+type InspectedEndpoint struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// InspectedResource is a resource of the API
+type InspectedResource struct {
+	Verb   string `json:"verb"`
+	Target string `json:"target"`
+}
+
+// InspectedPolicy is an operation policy attached to the API or to one of its resources
+type InspectedPolicy struct {
+	// AttachedTo is API for the API level policies and the verb and target for the resource level policies
+	AttachedTo string `json:"attachedTo"`
+	Flow       string `json:"flow"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+}
+
+// InspectedCertificate is an endpoint or client certificate of the API
+type InspectedCertificate struct {
+	Type  string `json:"type"`
+	Alias string `json:"alias"`
+	// Endpoint of the endpoint certificates
+	Endpoint string `json:"endpoint,omitempty"`
+	// TierName of the client certificates
+	TierName string `json:"tierName,omitempty"`
+}
+
+// InspectedDocument is a document of the API
+type InspectedDocument struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	SourceType string `json:"sourceType"`
+}
+
+// inspectedPolicies holds the operation policies of each flow
+type inspectedPolicies struct {
+	Request  []inspectedPolicyReference `json:"request"`
+	Response []inspectedPolicyReference `json:"response"`
+	Fault    []inspectedPolicyReference `json:"fault"`
+}
+
+type inspectedPolicyReference struct {
+	PolicyName    string `json:"policyName"`
+	PolicyVersion string `json:"policyVersion"`
+}
+
+// inspectedAPIFile holds the fields of the api.yaml summarized when inspecting
+type inspectedAPIFile struct {
+	Type string `json:"type"`
+	Data struct {
+		Name            string                 `json:"name"`
+		Version         string                 `json:"version"`
+		Context         string                 `json:"context"`
+		Provider        string                 `json:"provider"`
+		Type            string                 `json:"type"`
+		LifeCycleStatus string                 `json:"lifeCycleStatus"`
+		EndpointConfig  map[string]interface{} `json:"endpointConfig"`
+		Operations      []struct {
+			Target            string            `json:"target"`
+			Verb              string            `json:"verb"`
+			OperationPolicies inspectedPolicies `json:"operationPolicies"`
+		} `json:"operations"`
+		APIPolicies inspectedPolicies `json:"apiPolicies"`
+	} `json:"data"`
+}
+
+// inspectedCertificatesFile holds the endpoint or client certificates of an API project
+type inspectedCertificatesFile struct {
+	Data []struct {
+		Alias    string `json:"alias"`
+		Endpoint string `json:"endpoint"`
+		TierName string `json:"tierName"`
+	} `json:"data"`
+}
+
+// InspectArchive summarizes an exported API archive or API project without importing it
+// @param path : Path of the API archive or project
+// @return summary of the API, error
+func InspectArchive(path string) (*ArchiveSummary, error) {
+	projectPath, err := utils.GetTempCloneFromDirOrZip(path)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(projectPath))
+	return inspectAPIProject(projectPath)
+}
+
+func inspectAPIProject(projectPath string) (*ArchiveSummary, error) {
+	_, jsonContent, err := resolveYamlOrJSON(filepath.Join(projectPath, "api"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an API project: %w", filepath.Base(projectPath), err)
+	}
+	apiFile := &inspectedAPIFile{}
+	if err = json.Unmarshal(jsonContent, apiFile); err != nil {
+		return nil, err
+	}
+	api := apiFile.Data
+	summary := &ArchiveSummary{
+		Name:            api.Name,
+		Version:         api.Version,
+		Context:         api.Context,
+		Provider:        api.Provider,
+		Type:            api.Type,
+		LifeCycleStatus: api.LifeCycleStatus,
+		Endpoints:       inspectEndpoints(api.EndpointConfig),
+		Resources:       []InspectedResource{},
+		Policies:        inspectPolicies("API", api.APIPolicies),
+	}
+	for _, operation := range api.Operations {
+		summary.Resources = append(summary.Resources, InspectedResource{Verb: operation.Verb, Target: operation.Target})
+		summary.Policies = append(summary.Policies,
+			inspectPolicies(operation.Verb+" "+operation.Target, operation.OperationPolicies)...)
+	}
+
+	if summary.Certificates, err = inspectCertificates(projectPath); err != nil {
+		return nil, err
+	}
+	if summary.Docs, err = inspectDocs(projectPath); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// inspectEndpoints lists the URLs of the endpoint config, which are given either as an object or as a list of
+// objects for load balanced and failover endpoints
+func inspectEndpoints(endpointConfig map[string]interface{}) []InspectedEndpoint {
+	endpoints := []InspectedEndpoint{}
+	for _, endpointKey := range endpointConfigKeys {
+		var configs []interface{}
+		switch value := endpointConfig[endpointKey.key].(type) {
+		case []interface{}:
+			configs = value
+		case map[string]interface{}:
+			configs = []interface{}{value}
+		}
+		for _, config := range configs {
+			if endpoint, ok := config.(map[string]interface{}); ok {
+				if url, ok := endpoint["url"].(string); ok && url != "" {
+					endpoints = append(endpoints, InspectedEndpoint{Type: endpointKey.name, URL: url})
+				}
+			}
+		}
+	}
+	return endpoints
+}
+
+func inspectPolicies(attachedTo string, policies inspectedPolicies) []InspectedPolicy {
+	inspected := []InspectedPolicy{}
+	for _, flow := range []struct {
+		name     string
+		policies []inspectedPolicyReference
+	}{{"request", policies.Request}, {"response", policies.Response}, {"fault", policies.Fault}} {
+		for _, policy := range flow.policies {
+			inspected = append(inspected, InspectedPolicy{AttachedTo: attachedTo, Flow: flow.name,
+				Name: policy.PolicyName, Version: policy.PolicyVersion})
+		}
+	}
+	return inspected
+}
+
+func inspectCertificates(projectPath string) ([]InspectedCertificate, error) {
+	certificates := []InspectedCertificate{}
+	for _, certs := range []struct{ dir, file, certType string }{
+		{utils.InitProjectEndpointCertificates, endpointCertificatesFile, "endpoint"},
+		{utils.InitProjectClientCertificates, clientCertificatesFile, "client"},
+	} {
+		_, jsonContent, err := resolveYamlOrJSON(filepath.Join(projectPath, certs.dir, certs.file))
+		if err != nil {
+			// The project has no certificates of this type
+			continue
+		}
+		certificatesFile := &inspectedCertificatesFile{}
+		if err = json.Unmarshal(jsonContent, certificatesFile); err != nil {
+			return nil, err
+		}
+		for _, cert := range certificatesFile.Data {
+			certificates = append(certificates, InspectedCertificate{Type: certs.certType, Alias: cert.Alias,
+				Endpoint: cert.Endpoint, TierName: cert.TierName})
+		}
+	}
+	return certificates, nil
+}
+
+// inspectDocs lists the documents of the API, each of which is in its own directory inside the Docs directory
+func inspectDocs(projectPath string) ([]InspectedDocument, error) {
+	docs := []InspectedDocument{}
+	entries, err := ioutil.ReadDir(filepath.Join(projectPath, utils.InitProjectDocs))
+	if os.IsNotExist(err) {
+		return docs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		_, jsonContent, err := resolveYamlOrJSON(filepath.Join(projectPath, utils.InitProjectDocs, entry.Name(),
+			documentFile))
+		if err != nil {
+			continue
+		}
+		doc := &v2.Document{}
+		if err = json.Unmarshal(jsonContent, doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, InspectedDocument{Name: doc.Data.Name, Type: doc.Data.Type,
+			SourceType: doc.Data.SourceType})
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	return docs, nil
+}
+
+// PrintArchiveSummary prints the summary of an API archive in the given format
+// @param summary : Summary of the API
+// @param format : Format type of the output
+func PrintArchiveSummary(summary *ArchiveSummary, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(summary)
+		return
+	}
+	fmt.Println("Name:      " + summary.Name)
+	fmt.Println("Version:   " + summary.Version)
+	fmt.Println("Context:   " + summary.Context)
+	fmt.Println("Provider:  " + summary.Provider)
+	fmt.Println("Type:      " + summary.Type)
+	fmt.Println("Status:    " + summary.LifeCycleStatus)
+
+	fmt.Printf("\nEndpoints: %d\n", len(summary.Endpoints))
+	for _, endpoint := range summary.Endpoints {
+		fmt.Println("  " + endpoint.Type + ": " + endpoint.URL)
+	}
+	fmt.Printf("\nResources: %d\n", len(summary.Resources))
+	for _, resource := range summary.Resources {
+		fmt.Println("  " + resource.Verb + " " + resource.Target)
+	}
+	fmt.Printf("\nPolicies: %d\n", len(summary.Policies))
+	for _, policy := range summary.Policies {
+		fmt.Println("  " + policy.AttachedTo + " (" + policy.Flow + "): " + policy.Name + " " + policy.Version)
+	}
+	fmt.Printf("\nCertificates: %d\n", len(summary.Certificates))
+	for _, cert := range summary.Certificates {
+		fmt.Println("  " + cert.Type + ": " + cert.Alias + " (" + cert.Endpoint + cert.TierName + ")")
+	}
+	fmt.Printf("\nDocs: %d\n", len(summary.Docs))
+	for _, doc := range summary.Docs {
+		fmt.Println("  " + doc.Name + " (" + doc.Type + ", " + doc.SourceType + ")")
+	}
+}
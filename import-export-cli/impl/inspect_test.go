@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestInspectAPIProject(t *testing.T) {
+	summary, err := inspectAPIProject(utils.GetRelativeTestDataPathFromImpl() + "PizzaShackAPI-1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "PizzaShackAPI", summary.Name)
+	assert.Equal(t, "1.0.0", summary.Version)
+	assert.Equal(t, []InspectedEndpoint{
+		{Type: "production", URL: "https://localhost:9443/am/sample/pizzashack/v1/api/"},
+		{Type: "sandbox", URL: "https://localhost:9443/am/sample/pizzashack/v1/api/"},
+	}, summary.Endpoints)
+	assert.Len(t, summary.Resources, 5)
+	assert.Equal(t, InspectedResource{Verb: "POST", Target: "/order"}, summary.Resources[0])
+	assert.Empty(t, summary.Policies)
+	assert.Empty(t, summary.Certificates)
+	assert.Empty(t, summary.Docs)
+}
+
+func TestInspectAPIProjectWithArtifacts(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTestFile(t, filepath.Join(projectPath, "api.yaml"), `type: api
+version: v4.2.0
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  context: /pizzashack
+  endpointConfig:
+    endpoint_type: load_balance
+    production_endpoints:
+      - url: https://pizza-1.wso2.com
+      - url: https://pizza-2.wso2.com
+  apiPolicies:
+    request:
+      - policyName: addHeader
+        policyVersion: v1
+  operations:
+    - target: /menu
+      verb: GET
+      operationPolicies:
+        response:
+          - policyName: removeHeader
+            policyVersion: v1
+`)
+	writeTestFile(t, filepath.Join(projectPath, utils.InitProjectEndpointCertificates, "endpoint_certificates.yaml"),
+		`type: endpoint_certificates
+data:
+  - alias: pizza
+    endpoint: https://pizza-1.wso2.com
+    certificate: pizza.crt
+`)
+	writeTestFile(t, filepath.Join(projectPath, utils.InitProjectClientCertificates, "client_certificates.yaml"),
+		`type: client_certificates
+data:
+  - alias: partner
+    tierName: Gold
+    certificate: partner.crt
+`)
+	writeTestFile(t, filepath.Join(projectPath, utils.InitProjectDocs, "HowTo", "document.yaml"), `type: document
+data:
+  name: HowTo
+  type: HOWTO
+  sourceType: INLINE
+`)
+
+	summary, err := inspectAPIProject(projectPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []InspectedEndpoint{
+		{Type: "production", URL: "https://pizza-1.wso2.com"},
+		{Type: "production", URL: "https://pizza-2.wso2.com"},
+	}, summary.Endpoints)
+	assert.Equal(t, []InspectedPolicy{
+		{AttachedTo: "API", Flow: "request", Name: "addHeader", Version: "v1"},
+		{AttachedTo: "GET /menu", Flow: "response", Name: "removeHeader", Version: "v1"},
+	}, summary.Policies)
+	assert.Equal(t, []InspectedCertificate{
+		{Type: "endpoint", Alias: "pizza", Endpoint: "https://pizza-1.wso2.com"},
+		{Type: "client", Alias: "partner", TierName: "Gold"},
+	}, summary.Certificates)
+	assert.Equal(t, []InspectedDocument{{Name: "HowTo", Type: "HOWTO", SourceType: "INLINE"}}, summary.Docs)
+}
+
+func TestInspectAPIProjectNotAPI(t *testing.T) {
+	_, err := inspectAPIProject(t.TempDir())
+	assert.NotNil(t, err)
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
@@ -0,0 +1,322 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// KeyManagerArtifactType is the type of the artifacts of exported key manager configurations
+	KeyManagerArtifactType = "key_manager"
+
+	keyManagersResource = "key-managers"
+	keyManagerIDKey     = "id"
+	keyManagerNameKey   = "name"
+)
+
+// KeyManagerArtifact is a key manager configuration exported from an environment. The configuration (issuer,
+// endpoints, JWKS, claim mappings, grant types, etc.) is kept as returned by the Admin API, so that the fields
+// unknown to apictl are recreated as they are.
+type KeyManagerArtifact struct {
+	Type    string                 `json:"type"`
+	Version string                 `json:"version"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+// ExportedKeyManager is a key manager configuration written by export keymanagers
+type ExportedKeyManager struct {
+	Name string
+	Path string
+}
+
+// keyManagerInfo is a key manager as listed by the Admin API
+type keyManagerInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ExportKeyManagersFromEnv writes the configurations of the key managers of an environment to the output directory
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to export the key managers from
+// @param keyManager	: Name of the key manager to export. All the key managers are exported when empty
+// @param outputDir		: Directory to write the key managers to
+// @param format		: File format of the key managers (json or yaml)
+// @return exported key managers, error
+func ExportKeyManagersFromEnv(accessToken, environment, keyManager, outputDir, format string) ([]ExportedKeyManager,
+	error) {
+	version, err := GetAPIMVersionOfEnv(accessToken, environment)
+	if err != nil || version == "" {
+		utils.Logln(utils.LogPrefixWarning+"Unable to detect the APIM version of "+environment+": ", err)
+		version = utils.DefaultAPIMVersion
+	}
+	keyManagersEndpoint := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + keyManagersResource
+	return exportKeyManagers(keyManagersEndpoint, accessToken, keyManager, outputDir, format, version)
+}
+
+// exportKeyManagers writes the configurations of the key managers to the output directory
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param keyManager			: Name of the key manager to export. All the key managers are exported when empty
+// @param outputDir				: Directory to write the key managers to
+// @param format				: File format of the key managers (json or yaml)
+// @param version				: APIM version of the environment
+// @return exported key managers, error
+func exportKeyManagers(keyManagersEndpoint, accessToken, keyManager, outputDir, format,
+	version string) ([]ExportedKeyManager, error) {
+	format = strings.ToLower(format)
+	if format != "json" && format != "yaml" {
+		return nil, errors.New("unsupported format " + format + ", use json or yaml")
+	}
+	keyManagers, err := listKeyManagers(keyManagersEndpoint, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if keyManager != "" {
+		keyManagers = filterKeyManagers(keyManagers, keyManager)
+		if len(keyManagers) == 0 {
+			return nil, errors.New("key manager " + keyManager + " is not found")
+		}
+	}
+	if err = os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	exported := []ExportedKeyManager{}
+	for _, km := range keyManagers {
+		data, err := getRESTResource(keyManagersEndpoint+"/"+km.ID, accessToken, "key manager")
+		if err != nil {
+			return exported, fmt.Errorf("error while retrieving the key manager %s: %v", km.Name, err)
+		}
+		// The ID is generated by each environment, the key managers are matched by their names when imported
+		delete(data, keyManagerIDKey)
+		content, err := json.MarshalIndent(KeyManagerArtifact{Type: KeyManagerArtifactType, Version: version,
+			Data: data}, "", "  ")
+		if err == nil && format == "yaml" {
+			content, err = yaml.JSONToYAML(content)
+		}
+		if err != nil {
+			return exported, fmt.Errorf("error while writing the key manager %s: %v", km.Name, err)
+		}
+		// The configurations may contain the client secrets of the key managers
+		path := filepath.Join(outputDir, km.Name+"."+format)
+		if err = ioutil.WriteFile(path, content, 0600); err != nil {
+			return exported, err
+		}
+		exported = append(exported, ExportedKeyManager{Name: km.Name, Path: path})
+	}
+	return exported, nil
+}
+
+// filterKeyManagers returns the key managers with the given name
+func filterKeyManagers(keyManagers []keyManagerInfo, name string) []keyManagerInfo {
+	filtered := []keyManagerInfo{}
+	for _, km := range keyManagers {
+		if km.Name == name {
+			filtered = append(filtered, km)
+		}
+	}
+	return filtered
+}
+
+// PrintExportedKeyManagers prints the exported key managers
+// @param exported	: Exported key managers
+func PrintExportedKeyManagers(exported []ExportedKeyManager) {
+	for _, km := range exported {
+		fmt.Println("Exported the key manager " + km.Name + " to " + km.Path)
+	}
+	fmt.Printf("\n%d key manager(s) exported\n", len(exported))
+}
+
+// ImportKeyManagersToEnv recreates the key managers of an artifact file, or of all the artifact files in a
+// directory, in an environment
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to import the key managers to
+// @param importPath	: Path to a key manager artifact or a directory of key manager artifacts
+// @param update		: Whether to update the key managers which already exist in the environment
+// @return error
+func ImportKeyManagersToEnv(accessToken, environment, importPath string, update bool) error {
+	keyManagersEndpoint := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + keyManagersResource
+	return importKeyManagers(keyManagersEndpoint, accessToken, importPath, update)
+}
+
+// importKeyManagers recreates the key managers of an artifact file or a directory of artifact files
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param importPath			: Path to a key manager artifact or a directory of key manager artifacts
+// @param update				: Whether to update the key managers which already exist in the environment
+// @return error
+func importKeyManagers(keyManagersEndpoint, accessToken, importPath string, update bool) error {
+	info, err := os.Stat(importPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return importKeyManagerFile(keyManagersEndpoint, accessToken, importPath, update)
+	}
+	files, err := ioutil.ReadDir(importPath)
+	if err != nil {
+		return err
+	}
+	var failedKeyManagers []string
+	for _, file := range files {
+		extension := strings.ToLower(filepath.Ext(file.Name()))
+		if file.IsDir() || (extension != ".yaml" && extension != ".yml" && extension != ".json") {
+			continue
+		}
+		err = importKeyManagerFile(keyManagersEndpoint, accessToken, filepath.Join(importPath, file.Name()), update)
+		if err != nil {
+			utils.HandleErrorAndContinue("Error importing the key manager "+file.Name(), err)
+			failedKeyManagers = append(failedKeyManagers, file.Name())
+		}
+	}
+	if len(failedKeyManagers) > 0 {
+		return errors.New("failed to import the key managers " + strings.Join(failedKeyManagers, ", "))
+	}
+	return nil
+}
+
+// importKeyManagerFile creates the key manager of an artifact file, or updates the key manager with the same name
+// if it already exists in the environment and update is set
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param filePath				: Path to the key manager artifact
+// @param update				: Whether to update the key manager if it already exists in the environment
+// @return error
+func importKeyManagerFile(keyManagersEndpoint, accessToken, filePath string, update bool) error {
+	artifact, err := readKeyManagerArtifact(filePath)
+	if err != nil {
+		return err
+	}
+	name, _ := artifact.Data[keyManagerNameKey].(string)
+	keyManagers, err := listKeyManagers(keyManagersEndpoint, accessToken)
+	if err != nil {
+		return err
+	}
+	existing := filterKeyManagers(keyManagers, name)
+	if len(existing) == 0 {
+		delete(artifact.Data, keyManagerIDKey)
+		if err = createKeyManager(keyManagersEndpoint, accessToken, artifact.Data); err != nil {
+			return err
+		}
+		fmt.Println("Created the key manager " + name)
+		return nil
+	}
+	if !update {
+		return errors.New("key manager " + name + " already exists. Use --update to update it")
+	}
+	artifact.Data[keyManagerIDKey] = existing[0].ID
+	if err = updateRESTResource(keyManagersEndpoint+"/"+existing[0].ID, accessToken, "key manager",
+		artifact.Data); err != nil {
+		return err
+	}
+	fmt.Println("Updated the key manager " + name)
+	return nil
+}
+
+// readKeyManagerArtifact reads and validates a key manager artifact in json or yaml format
+// @param filePath	: Path to the key manager artifact
+// @return key manager artifact, error
+func readKeyManagerArtifact(filePath string) (*KeyManagerArtifact, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	// The yaml artifacts are converted to json so that the nested objects are decoded into maps with string keys
+	content, err = yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	artifact := &KeyManagerArtifact{}
+	if err = json.Unmarshal(content, artifact); err != nil {
+		return nil, err
+	}
+	if artifact.Type != KeyManagerArtifactType {
+		return nil, errors.New(filePath + " is not a key manager artifact. Type: " + artifact.Type)
+	}
+	if name, _ := artifact.Data[keyManagerNameKey].(string); name == "" {
+		return nil, errors.New("name of the key manager is not found in " + filePath)
+	}
+	return artifact, nil
+}
+
+// createKeyManager creates a key manager in the Admin API
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @param keyManager			: Configuration of the key manager
+// @return error
+func createKeyManager(keyManagersEndpoint, accessToken string, keyManager map[string]interface{}) error {
+	body, err := json.Marshal(keyManager)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	resp, err := utils.InvokePOSTRequest(keyManagersEndpoint, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 201 Created for creating the key manager. Status: " +
+			resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
+
+// listKeyManagers retrieves the key managers of an environment from the Admin API
+// @param keyManagersEndpoint	: Key managers endpoint of the Admin API
+// @param accessToken			: Access Token for the environment
+// @return key managers, error
+func listKeyManagers(keyManagersEndpoint, accessToken string) ([]keyManagerInfo, error) {
+	utils.Logln(utils.LogPrefixInfo+"URL:", keyManagersEndpoint)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(keyManagersEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the key managers. Status: " +
+			resp.Status())
+	}
+	var keyManagers struct {
+		List []keyManagerInfo `json:"list"`
+	}
+	if err = json.Unmarshal(resp.Body(), &keyManagers); err != nil {
+		return nil, err
+	}
+	return keyManagers.List, nil
+}
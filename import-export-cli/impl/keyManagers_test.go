@@ -0,0 +1,101 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const keyManagersTestKeyManager = `{"id": "km-1", "name": "Okta", "type": "Okta", "enabled": true,
+	"issuer": "https://dev.okta.com/oauth2/default",
+	"certificates": {"type": "JWKS", "value": "https://dev.okta.com/oauth2/default/v1/keys"},
+	"availableGrantTypes": ["client_credentials", "authorization_code"],
+	"claimMapping": [{"remoteClaim": "email", "localClaim": "http://wso2.org/claims/emailaddress"}]}`
+
+func newKeyManagersTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/key-managers", http.StatusOK, `{"count": 1, "list": [{"id": "km-1", "name": "Okta"}]}`).
+		handle(http.MethodGet, "/key-managers/km-1", http.StatusOK, keyManagersTestKeyManager).
+		handle(http.MethodPost, "/key-managers", http.StatusCreated, "").
+		handle(http.MethodPut, "/key-managers/km-1", http.StatusOK, "")
+}
+
+func TestExportKeyManagers(t *testing.T) {
+	server := newKeyManagersTestServer(t)
+	defer server.Close()
+	outputDir := t.TempDir()
+
+	exported, err := exportKeyManagers(server.URL+"/key-managers", "access-token", "", outputDir, "YAML", "v4.5.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []ExportedKeyManager{{Name: "Okta", Path: filepath.Join(outputDir, "Okta.yaml")}}, exported)
+
+	artifact, err := readKeyManagerArtifact(exported[0].Path)
+	assert.Nil(t, err)
+	assert.Equal(t, KeyManagerArtifactType, artifact.Type)
+	assert.Equal(t, "v4.5.0", artifact.Version)
+	assert.NotContains(t, artifact.Data, "id", "The ID of the key manager should not be exported")
+	assert.Equal(t, "https://dev.okta.com/oauth2/default", artifact.Data["issuer"])
+	assert.Equal(t, []interface{}{"client_credentials", "authorization_code"}, artifact.Data["availableGrantTypes"])
+
+	_, err = exportKeyManagers(server.URL+"/key-managers", "access-token", "Auth0", outputDir, "yaml", "v4.5.0")
+	assert.EqualError(t, err, "key manager Auth0 is not found")
+}
+
+func TestImportKeyManagers(t *testing.T) {
+	server := newKeyManagersTestServer(t)
+	defer server.Close()
+	importDir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(importDir, "Okta.yaml"), []byte(`type: key_manager
+version: v4.5.0
+data:
+  name: Okta
+  issuer: https://okta.example.com/oauth2/default
+`), 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(importDir, "Auth0.json"), []byte(`{"type": "key_manager",
+		"version": "v4.5.0", "data": {"name": "Auth0", "type": "Auth0", "issuer": "https://example.auth0.com/"}}`),
+		0600))
+
+	err := importKeyManagers(server.URL+"/key-managers", "access-token", importDir, false)
+	assert.EqualError(t, err, "failed to import the key managers Okta.yaml")
+	var created map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPost, "/key-managers", &created))
+	assert.Equal(t, "Auth0", created["name"])
+	assert.Empty(t, server.requestsTo(http.MethodPut, "/key-managers/km-1"),
+		"Existing key managers should not be updated without --update")
+
+	err = importKeyManagers(server.URL+"/key-managers", "access-token", filepath.Join(importDir, "Okta.yaml"), true)
+	assert.Nil(t, err)
+	var updated map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/key-managers/km-1", &updated))
+	assert.Equal(t, "km-1", updated["id"])
+	assert.Equal(t, "https://okta.example.com/oauth2/default", updated["issuer"])
+}
+
+func TestReadKeyManagerArtifactOfOtherType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("type: throttling policy\ndata:\n  name: Gold\n"), 0600))
+
+	_, err := readKeyManagerArtifact(path)
+	assert.EqualError(t, err, path+" is not a key manager artifact. Type: throttling policy")
+}
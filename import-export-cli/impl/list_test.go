@@ -113,6 +113,48 @@ func TestGetAPIListUnreachable(t *testing.T) {
 	}
 }
 
+func TestSearchAPIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("query") != "tag:payments status:PUBLISHED" {
+			t.Errorf("Incorrect query. Got '%s'\n", query.Get("query"))
+		}
+		if query.Get("limit") != "10" || query.Get("offset") != "20" {
+			t.Errorf("Incorrect paging. Got limit '%s' and offset '%s'\n", query.Get("limit"), query.Get("offset"))
+		}
+		if query.Get("sortBy") != "apiName" || query.Get("sortOrder") != "desc" {
+			t.Errorf("Incorrect sorting. Got sortBy '%s' and sortOrder '%s'\n", query.Get("sortBy"),
+				query.Get("sortOrder"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "list": [{"id": "api-1", "name": "Payments", "version": "1.0.0"}]}`))
+	}))
+	defer server.Close()
+
+	count, apiList, err := SearchAPIs("access_token", server.URL, APISearchOptions{
+		Query: "tag:payments status:PUBLISHED", Limit: "10", Offset: "20", SortBy: "name", SortOrder: "DESC"})
+	if err != nil {
+		t.Error("Error" + err.Error())
+	}
+	if count != 1 || len(apiList) != 1 || apiList[0].Name != "Payments" {
+		t.Errorf("Incorrect APIs. Got %v\n", apiList)
+	}
+}
+
+func TestGetAPISearchQueryParamsInvalid(t *testing.T) {
+	invalidOptions := map[string]APISearchOptions{
+		"invalid offset -1, it should be a non-negative number":                     {Offset: "-1"},
+		"invalid limit ten, it should be a non-negative number":                     {Limit: "ten"},
+		"invalid sort field owner, use one of name, version, createdTime or status": {SortBy: "owner"},
+		"invalid sort order up, use asc or desc":                                    {SortOrder: "up"},
+	}
+	for expectedError, options := range invalidOptions {
+		if _, err := getAPISearchQueryParams(options); err == nil || err.Error() != expectedError {
+			t.Errorf("Expected error '%s', got '%v'\n", expectedError, err)
+		}
+	}
+}
+
 func TestGetApplicationListOK(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,307 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const loadHistogramBarWidth = 40
+
+// loadHistogramBuckets are the upper bounds of the buckets of the latency histogram. Latencies above the last
+// bound are counted in an overflow bucket.
+var loadHistogramBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// loadResult holds the outcome of the requests sent during a load run
+type loadResult struct {
+	mutex       sync.Mutex
+	latencies   []time.Duration
+	statusCodes map[int]int
+	errors      map[string]int
+	successful  int
+	elapsed     time.Duration
+}
+
+func newLoadResult() *loadResult {
+	return &loadResult{statusCodes: make(map[int]int), errors: make(map[string]int)}
+}
+
+func (r *loadResult) record(statusCode int, latency time.Duration, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err != nil {
+		r.errors[err.Error()]++
+		return
+	}
+	r.latencies = append(r.latencies, latency)
+	r.statusCodes[statusCode]++
+	if statusCode < http.StatusBadRequest {
+		r.successful++
+	}
+}
+
+// total returns the number of requests sent, including the requests which did not receive a response
+func (r *loadResult) total() int {
+	total := len(r.latencies)
+	for _, count := range r.errors {
+		total += count
+	}
+	return total
+}
+
+// LoadTestAPI generates a token to invoke an API and sends requests to the API through the gateway at the given rate
+// for the given duration, then prints the status codes and the latency histogram of the responses
+// @param cred : Credentials of the environment
+// @param envName : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @param gatewayEnv : Gateway environment to send the requests to, the first gateway environment is used if empty
+// @param resource : Resource path of the API to invoke
+// @param method : HTTP method of the requests
+// @param rps : Number of requests to send per second
+// @param duration : Duration to send the requests
+// @return error
+func LoadTestAPI(cred credentials.Credential, envName, name, version, provider, gatewayEnv, resource, method string,
+	rps int, duration time.Duration) error {
+	token := GenerateKeys(cred, envName, name, version, provider, "", utils.TokenGenerationOptions{})
+	utils.Logln(utils.LogPrefixInfo + "Generated a token to invoke the API.")
+
+	accessToken, err := credentials.GetOAuthAccessToken(cred, envName)
+	if err != nil {
+		return err
+	}
+	apiId, err := searchApiOrProduct(accessToken)
+	if err != nil {
+		return err
+	}
+	api, err := getDevPortalAPI(apiId, accessToken)
+	if err != nil {
+		return err
+	}
+	if api.Type == webSocketAPIType {
+		return errors.New("API " + name + " " + version + " is a WebSocket API. Use '" + utils.ProjectName +
+			" test ws' to invoke it")
+	}
+	apiURL, err := getHTTPURL(api, gatewayEnv)
+	if err != nil {
+		return err
+	}
+	targetURL := strings.TrimSuffix(apiURL, "/") + "/" + strings.TrimPrefix(resource, "/")
+
+	fmt.Printf("Sending %d requests/s to %s %s for %v\n", rps, strings.ToUpper(method), targetURL, duration)
+	result := runLoad(newLoadClient(rps), targetURL, strings.ToUpper(method), token.AccessToken, rps, duration)
+	printLoadResult(result, os.Stdout)
+	return nil
+}
+
+// Get the HTTP URL of an API, preferring the secured URL over the unsecured one
+// @param api : API retrieved from the devportal
+// @param gatewayEnv : Gateway environment to send the requests to, the first gateway environment is used if empty
+// @return HTTP URL, error
+func getHTTPURL(api *utils.DevPortalAPI, gatewayEnv string) (string, error) {
+	for _, endpointURL := range api.EndpointURLs {
+		if gatewayEnv != "" && endpointURL.EnvironmentName != gatewayEnv {
+			continue
+		}
+		if endpointURL.URLs.HTTPS != "" {
+			return endpointURL.URLs.HTTPS, nil
+		}
+		if endpointURL.URLs.HTTP != "" {
+			return endpointURL.URLs.HTTP, nil
+		}
+	}
+	if gatewayEnv != "" {
+		return "", errors.New("API " + api.Name + " " + api.Version + " is not deployed in the gateway environment " +
+			gatewayEnv)
+	}
+	return "", errors.New("API " + api.Name + " " + api.Version + " is not deployed in any gateway environment")
+}
+
+// newLoadClient creates an HTTP client which keeps enough idle connections to the gateway for the given rate
+func newLoadClient(rps int) *http.Client {
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: rps,
+	}
+	if utils.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	} else {
+		transport.TLSClientConfig = utils.GetTlsConfigWithCertificate()
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(utils.HttpRequestTimeout) * time.Millisecond,
+	}
+}
+
+// runLoad sends requests at a constant rate without waiting for the previous responses, so that slow responses
+// do not reduce the rate, and waits for all the responses before returning
+func runLoad(client *http.Client, targetURL, method, accessToken string, rps int,
+	duration time.Duration) *loadResult {
+	result := newLoadResult()
+	ticker := time.NewTicker(time.Second / time.Duration(rps))
+	defer ticker.Stop()
+	deadline := time.After(duration)
+	var wg sync.WaitGroup
+	start := time.Now()
+
+LOOP:
+	for {
+		select {
+		case <-deadline:
+			break LOOP
+		case <-ticker.C:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result.record(sendLoadRequest(client, targetURL, method, accessToken))
+			}()
+		}
+	}
+	wg.Wait()
+	result.elapsed = time.Since(start)
+	return result
+}
+
+func sendLoadRequest(client *http.Client, targetURL, method, accessToken string) (int, time.Duration, error) {
+	req, err := http.NewRequest(method, targetURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set(utils.HeaderAuthorization, utils.HeaderValueAuthBearerPrefix+" "+accessToken)
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Drain the body so that the connection is reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, time.Since(start), nil
+}
+
+// percentile returns the latency below which the given percentage of the sorted latencies fall
+func percentile(sortedLatencies []time.Duration, percentage float64) time.Duration {
+	if len(sortedLatencies) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(percentage/100*float64(len(sortedLatencies)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sortedLatencies[index]
+}
+
+// printLoadResult prints the summary of the requests, the status codes, the errors and the latency histogram
+func printLoadResult(result *loadResult, out io.Writer) {
+	total := result.total()
+	fmt.Fprintf(out, "\nRequests      : %d sent, %d successful, %d failed\n", total, result.successful,
+		total-result.successful)
+	fmt.Fprintf(out, "Duration      : %v\n", result.elapsed.Round(time.Millisecond))
+	if result.elapsed > 0 {
+		fmt.Fprintf(out, "Throughput    : %.1f requests/s\n", float64(total)/result.elapsed.Seconds())
+	}
+
+	statusCodes := make([]int, 0, len(result.statusCodes))
+	for statusCode := range result.statusCodes {
+		statusCodes = append(statusCodes, statusCode)
+	}
+	sort.Ints(statusCodes)
+	var codeCounts []string
+	for _, statusCode := range statusCodes {
+		codeCounts = append(codeCounts, strconv.Itoa(statusCode)+": "+strconv.Itoa(result.statusCodes[statusCode]))
+	}
+	if len(codeCounts) > 0 {
+		fmt.Fprintf(out, "Status codes  : %s\n", strings.Join(codeCounts, ", "))
+	}
+	for message, count := range result.errors {
+		fmt.Fprintf(out, "Error         : %s (%d)\n", message, count)
+	}
+
+	if len(result.latencies) == 0 {
+		return
+	}
+	latencies := make([]time.Duration, len(result.latencies))
+	copy(latencies, result.latencies)
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	var sum time.Duration
+	for _, latency := range latencies {
+		sum += latency
+	}
+	mean := sum / time.Duration(len(latencies))
+	fmt.Fprintf(out, "Latency       : min %v, mean %v, p50 %v, p90 %v, p95 %v, p99 %v, max %v\n",
+		roundLatency(latencies[0]), roundLatency(mean), roundLatency(percentile(latencies, 50)),
+		roundLatency(percentile(latencies, 90)), roundLatency(percentile(latencies, 95)),
+		roundLatency(percentile(latencies, 99)), roundLatency(latencies[len(latencies)-1]))
+
+	counts := make([]int, len(loadHistogramBuckets)+1)
+	for _, latency := range latencies {
+		bucket := sort.Search(len(loadHistogramBuckets), func(i int) bool {
+			return latency <= loadHistogramBuckets[i]
+		})
+		counts[bucket]++
+	}
+	maxCount := 0
+	for _, count := range counts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	fmt.Fprintln(out, "\nLatency histogram:")
+	for i, count := range counts {
+		label := "> " + loadHistogramBuckets[len(loadHistogramBuckets)-1].String()
+		if i < len(loadHistogramBuckets) {
+			label = "<= " + loadHistogramBuckets[i].String()
+		}
+		bar := strings.Repeat("#", count*loadHistogramBarWidth/maxCount)
+		fmt.Fprintf(out, "  %9s | %-*s | %d\n", label, loadHistogramBarWidth, bar, count)
+	}
+}
+
+func roundLatency(latency time.Duration) time.Duration {
+	return latency.Round(100 * time.Microsecond)
+}
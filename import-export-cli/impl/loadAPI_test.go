@@ -0,0 +1,94 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestRunLoad(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(utils.HeaderAuthorization) != "Bearer api-token" {
+			t.Errorf("Unexpected authorization header '%s'\n", r.Header.Get(utils.HeaderAuthorization))
+		}
+		if atomic.AddInt32(&requests, 1)%2 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	result := runLoad(newLoadClient(20), server.URL+"/menu", http.MethodGet, "api-token", 20, time.Second)
+	assert.Equal(t, int(atomic.LoadInt32(&requests)), result.total())
+	assert.True(t, result.total() >= 15 && result.total() <= 21, "Unexpected number of requests: %d", result.total())
+	assert.Equal(t, result.statusCodes[http.StatusOK], result.successful)
+	assert.Equal(t, result.total()-result.successful, result.statusCodes[http.StatusServiceUnavailable])
+}
+
+func TestRunLoadUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	result := runLoad(newLoadClient(10), server.URL, http.MethodGet, "api-token", 10, 500*time.Millisecond)
+	assert.Equal(t, 0, result.successful)
+	assert.Equal(t, 0, len(result.latencies))
+	assert.Equal(t, 1, len(result.errors))
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, time.Millisecond, percentile(latencies, 0))
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+func TestPrintLoadResult(t *testing.T) {
+	result := newLoadResult()
+	result.record(http.StatusOK, 3*time.Millisecond, nil)
+	result.record(http.StatusOK, 40*time.Millisecond, nil)
+	result.record(http.StatusOK, 45*time.Millisecond, nil)
+	result.record(http.StatusTooManyRequests, 6*time.Second, nil)
+	result.elapsed = 2 * time.Second
+
+	var out bytes.Buffer
+	printLoadResult(result, &out)
+	assert.Contains(t, out.String(), "4 sent, 3 successful, 1 failed")
+	assert.Contains(t, out.String(), "2.0 requests/s")
+	assert.Contains(t, out.String(), "200: 3, 429: 1")
+	assert.Contains(t, out.String(), "min 3ms")
+	assert.Contains(t, out.String(), "max 6s")
+	assert.Contains(t, out.String(), "<= 50ms | "+"########################################"+" | 2")
+	assert.Contains(t, out.String(), "<= 5ms | "+"####################"+"                     | 1")
+	assert.Contains(t, out.String(), "> 5s | ")
+}
@@ -138,9 +138,10 @@ func GetPerAPILoggingListFromEnv(credential credentials.Credential, environment,
 
 // PrintAPILoggers
 func PrintAPILoggers(apis []utils.APILogger, format string) {
-	if format == "" {
-		format = defaultLoggingApiTableFormat
+	if utils.PrintFormattedOutput(apis, format) {
+		return
 	}
+	format = utils.ResolveTableFormat(format, defaultLoggingApiTableFormat)
 	// Create API context with standard output
 	apiContext := formatter.NewContext(os.Stdout, format)
 
@@ -264,9 +265,10 @@ func GetCorrelationLogComponentListFromEnv(credential credentials.Credential, en
 }
 
 func PrintCorrelationLoggers(components []utils.CorrelationComponent, format string) {
-	if format == "" {
-		format = defaultLoggingCorrelationTableFormat
+	if utils.PrintFormattedOutput(components, format) {
+		return
 	}
+	format = utils.ResolveTableFormat(format, defaultLoggingCorrelationTableFormat)
 
 	formatContext := formatter.NewContext(os.Stdout, format)
 
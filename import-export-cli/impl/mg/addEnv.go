@@ -33,6 +33,12 @@ func AddEnv(envName string, mgwEndpoints *utils.MgwEndpoints) error {
 	}
 
 	mainConfigFilePath := utils.MainConfigFilePath
+	unlock, err := utils.LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if utils.MgwAdapterEnvExistsInMainConfigFile(envName, mainConfigFilePath) {
 		// environment already exists
 		return errors.New("MgwAdapter Environment '" + envName + "' already exists in " + mainConfigFilePath)
@@ -48,7 +54,7 @@ func AddEnv(envName string, mgwEndpoints *utils.MgwEndpoints) error {
 	}
 
 	mainConfig.MgwAdapterEnvs[envName] = validatedMgwEndpoints
-	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
+	utils.WriteLockedConfigFile(mainConfig, mainConfigFilePath)
 
 	fmt.Printf("Successfully added environment '%s'\n", envName)
 
@@ -0,0 +1,777 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Layout of the API archives exported from APIM 3.x
+const (
+	legacyMetaInfoDir          = "Meta-information"
+	legacySequencesDir         = "Sequences"
+	legacyCustomSequencesDir   = "Custom"
+	legacyDocsFile             = "docs.json"
+	legacyDocsInlineContentDir = "InlineContents"
+	legacyDocsFileContentDir   = "FileContents"
+	// legacyDefaultGatewayEnv is the gateway environment of APIM 3.x which was renamed to Default in APIM 4.x
+	legacyDefaultGatewayEnv = "Production and Sandbox"
+	defaultGatewayEnv       = "Default"
+	// operationPoliciesSupportedVersion is the first APIM version which supports the operation policies the
+	// mediation sequences are converted to
+	operationPoliciesSupportedVersion = "v4.1.0"
+	migratedPolicyVersion             = "v1"
+)
+
+// legacySequenceFlows maps the flows of APIM 3.x mediation sequences to the flows of APIM 4.x operation policies
+var legacySequenceFlows = []struct{ sequence, policy string }{
+	{"in", "request"},
+	{"out", "response"},
+	{"fault", "fault"},
+}
+
+// legacyIgnoredFields are the fields of an APIM 3.x API which are generated by APIM or have no meaning in an API
+// project, and hence are dropped without a warning
+var legacyIgnoredFields = []string{"uuid", "lastUpdated", "createdTime", "isLatest", "rating",
+	"isPublishedDefaultVersion", "apiHeaderChanged", "apiResourcePatternsChanged", "documents", "swaggerDefinition",
+	"graphQLSchema", "thumbnailUrl", "enableStore", "contextTemplate", "environmentList"}
+
+var reLegacySequence = regexp.MustCompile(`(?s)^\s*(?:<\?xml[^>]*\?>\s*)?<sequence\b[^>]*>(.*)</sequence>\s*$`)
+
+// LegacyProjectMigration is the outcome of migrating an API exported from APIM 3.x to an API project
+type LegacyProjectMigration struct {
+	Source      string
+	Destination string
+	// Unconverted lists the constructs of the exported API which could not be converted and need manual attention
+	Unconverted []string
+}
+
+func (m *LegacyProjectMigration) addUnconverted(format string, args ...interface{}) {
+	m.Unconverted = append(m.Unconverted, fmt.Sprintf(format, args...))
+}
+
+type operationPolicySpecification struct {
+	Type    string                      `yaml:"type"`
+	Version string                      `yaml:"version"`
+	Data    operationPolicySpecDataFile `yaml:"data"`
+}
+
+type operationPolicySpecDataFile struct {
+	Category          string   `yaml:"category"`
+	Name              string   `yaml:"name"`
+	Version           string   `yaml:"version"`
+	DisplayName       string   `yaml:"displayName"`
+	Description       string   `yaml:"description"`
+	ApplicableFlows   []string `yaml:"applicableFlows"`
+	SupportedGateways []string `yaml:"supportedGateways"`
+	SupportedApiTypes []string `yaml:"supportedApiTypes"`
+}
+
+type apiPolicyReference struct {
+	PolicyName    string                 `yaml:"policyName"`
+	PolicyVersion string                 `yaml:"policyVersion"`
+	Parameters    map[string]interface{} `yaml:"parameters"`
+}
+
+type apiPolicies struct {
+	Request  []apiPolicyReference `yaml:"request"`
+	Response []apiPolicyReference `yaml:"response"`
+	Fault    []apiPolicyReference `yaml:"fault"`
+}
+
+func (p *apiPolicies) add(flow string, policy apiPolicyReference) {
+	switch flow {
+	case "request":
+		p.Request = append(p.Request, policy)
+	case "response":
+		p.Response = append(p.Response, policy)
+	default:
+		p.Fault = append(p.Fault, policy)
+	}
+}
+
+// MigrateLegacyProjects converts APIs exported from APIM 3.x to API projects of the given APIM version
+// @param source : Exported API archive or directory, or a directory holding several of them
+// @param destination : Directory to write the API projects to
+// @param targetVersion : APIM version the projects are targeted to
+// @return outcome of each migrated API, error
+func MigrateLegacyProjects(source, destination, targetVersion string) ([]LegacyProjectMigration, error) {
+	version, err := utils.NormalizeAPIMVersion(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := getLegacyProjectSources(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []LegacyProjectMigration
+	for _, projectSource := range sources {
+		projectPath, err := utils.GetTempCloneFromDirOrZip(projectSource)
+		if err != nil {
+			return migrations, fmt.Errorf("error reading %s: %w", projectSource, err)
+		}
+		if !isLegacyAPIProject(projectPath) {
+			_ = os.RemoveAll(filepath.Dir(projectPath))
+			if len(sources) == 1 {
+				return nil, fmt.Errorf("%s is not an API exported from APIM 3.x", projectSource)
+			}
+			utils.Logln(utils.LogPrefixInfo + "Skipping " + projectSource + " as it is not an API exported from APIM 3.x")
+			continue
+		}
+		migration, err := migrateLegacyAPIProject(projectPath, destination, version)
+		_ = os.RemoveAll(filepath.Dir(projectPath))
+		if err != nil {
+			return migrations, fmt.Errorf("error migrating %s: %w", projectSource, err)
+		}
+		migration.Source = projectSource
+		migrations = append(migrations, *migration)
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no APIs exported from APIM 3.x were found in %s", source)
+	}
+	return migrations, nil
+}
+
+// getLegacyProjectSources returns the source itself if it is an exported API archive or directory, or else the
+// archives and directories inside the source
+func getLegacyProjectSources(source string) ([]string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() || isLegacyAPIProject(source) {
+		return []string{source}, nil
+	}
+	entries, err := ioutil.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.EqualFold(filepath.Ext(entry.Name()), utils.ZipFileSuffix) {
+			sources = append(sources, filepath.Join(source, entry.Name()))
+		}
+	}
+	return sources, nil
+}
+
+func isLegacyAPIProject(path string) bool {
+	_, err := getLegacyMetaFile(path, "api")
+	return err == nil
+}
+
+// getLegacyMetaFile returns the path of the given file in the meta information directory, which was either in
+// YAML or JSON in APIM 3.x
+func getLegacyMetaFile(projectPath, name string) (string, error) {
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(projectPath, legacyMetaInfoDir, name+ext)
+		if utils.IsFileExist(path) {
+			return path, nil
+		}
+	}
+	return "", os.ErrNotExist
+}
+
+func migrateLegacyAPIProject(projectPath, destination, version string) (*LegacyProjectMigration, error) {
+	apiMetaFile, err := getLegacyMetaFile(projectPath, "api")
+	if err != nil {
+		return nil, err
+	}
+	content, err := utils.LoadYamlAsJson(apiMetaFile)
+	if err != nil {
+		return nil, err
+	}
+	legacyAPI := &legacyFields{values: map[string]interface{}{}, consumed: map[string]bool{}}
+	if err = json.Unmarshal(content, &legacyAPI.values); err != nil {
+		return nil, err
+	}
+
+	migration := &LegacyProjectMigration{}
+	api := convertLegacyAPI(legacyAPI, migration)
+	if api.Name == "" || api.Version == "" {
+		return nil, errors.New("name or version of the API is not found in " + apiMetaFile)
+	}
+
+	migration.Destination = filepath.Join(destination, api.Name+"-"+api.Version)
+	if exists, _ := utils.IsDirExists(migration.Destination); exists {
+		return nil, errors.New(migration.Destination + " already exists")
+	}
+	if err = utils.CreateDirIfNotExist(migration.Destination); err != nil {
+		return nil, err
+	}
+
+	if err = migrateLegacyDefinition(projectPath, migration, api.Type); err != nil {
+		return nil, err
+	}
+	if err = migrateLegacySequences(projectPath, legacyAPI, migration, version, api); err != nil {
+		return nil, err
+	}
+	if err = migrateLegacyDocs(projectPath, migration, version); err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{utils.InitProjectImage, utils.InitProjectWSDL} {
+		if exists, _ := utils.IsDirExists(filepath.Join(projectPath, dir)); exists {
+			if err = utils.CopyDir(filepath.Join(projectPath, dir), filepath.Join(migration.Destination, dir)); err != nil {
+				return nil, err
+			}
+			api.HasThumbnail = api.HasThumbnail || dir == utils.InitProjectImage
+		}
+	}
+	for _, certs := range []string{"endpoint_certificates", "client_certificates"} {
+		if _, err := getLegacyMetaFile(projectPath, certs); err == nil {
+			migration.addUnconverted("%s are not converted. Add the certificates to the params file of the "+
+				"project", strings.Replace(certs, "_", " ", 1))
+		}
+	}
+
+	if err = writeLegacyDeploymentEnvironments(legacyAPI.list("environments"), migration.Destination, version); err != nil {
+		return nil, err
+	}
+	for _, field := range legacyAPI.unconsumed() {
+		migration.addUnconverted("field %q of the API is not converted", field)
+	}
+
+	apiData, err := yaml.Marshal(&v2.APIDefinitionFile{Type: "api", ApimVersion: version, Data: *api})
+	if err != nil {
+		return nil, err
+	}
+	return migration, ioutil.WriteFile(filepath.Join(migration.Destination, utils.APIDefinitionFileYaml), apiData,
+		os.ModePerm)
+}
+
+// convertLegacyAPI converts the API model exported from APIM 3.x to the APIDTO used by APIM 4.x
+func convertLegacyAPI(legacy *legacyFields, migration *LegacyProjectMigration) *v2.APIDTODefinition {
+	legacy.ignore(legacyIgnoredFields...)
+	id := legacy.object("id")
+	api := &v2.APIDTODefinition{
+		Name:                         stringOf(id["apiName"]),
+		Version:                      stringOf(id["version"]),
+		Provider:                     stringOf(id["providerName"]),
+		Description:                  legacy.str("description"),
+		Type:                         legacy.str("type"),
+		LifeCycleStatus:              legacy.str("status"),
+		Transport:                    legacy.list("transports"),
+		Tags:                         legacy.list("tags"),
+		APIThrottlingPolicy:          legacy.str("apiLevelPolicy"),
+		AuthorizationHeader:          legacy.str("authorizationHeader"),
+		SecurityScheme:               legacy.list("apiSecurity"),
+		Visibility:                   strings.ToUpper(legacy.str("visibility")),
+		VisibleRoles:                 legacy.list("visibleRoles"),
+		VisibleTenants:               legacy.list("visibleTenants"),
+		SubscriptionAvailability:     strings.ToUpper(legacy.str("subscriptionAvailability")),
+		SubscriptionAvailableTenants: legacy.list("subscriptionAvailableTenants"),
+		AccessControlRoles:           legacy.list("accessControlRoles"),
+		CorsConfiguration:            legacy.value("corsConfiguration"),
+		EndpointImplementationType:   legacy.str("implementation"),
+		IsDefaultVersion:             legacy.boolean("isDefaultVersion"),
+		EnableSchemaValidation:       legacy.boolean("enableSchemaValidation"),
+		CacheTimeout:                 int(legacy.number("cacheTimeout")),
+		ResponseCachingEnabledKey:    strings.EqualFold(legacy.str("responseCache"), "Enabled"),
+		KeyManagers:                  legacy.list("keyManagers"),
+		WsdlURL:                      legacy.str("wsdlUrl"),
+	}
+	api.Context = convertLegacyContext(legacy.str("contextTemplate"), legacy.str("context"), api.Version)
+
+	if strings.EqualFold(legacy.str("accessControl"), "restricted") {
+		api.AccessControl = "RESTRICTED"
+	} else if legacy.str("accessControl") != "" {
+		api.AccessControl = "NONE"
+	}
+	for _, tier := range legacy.objects("availableTiers") {
+		api.Policies = append(api.Policies, stringOf(tier["name"]))
+	}
+	for _, category := range legacy.objects("apiCategories") {
+		api.Categories = append(api.Categories, stringOf(category["name"]))
+	}
+
+	businessInformation := map[string]string{}
+	for _, field := range []string{"businessOwner", "businessOwnerEmail", "technicalOwner", "technicalOwnerEmail"} {
+		if value := legacy.str(field); value != "" {
+			businessInformation[field] = value
+		}
+	}
+	if len(businessInformation) > 0 {
+		api.BusinessInformation = businessInformation
+	}
+
+	maxTps := map[string]int64{}
+	for field, environment := range map[string]string{"productionMaxTps": "production", "sandboxMaxTps": "sandbox"} {
+		if value, err := strconv.ParseInt(legacy.str(field), 10, 64); err == nil {
+			maxTps[environment] = value
+		}
+	}
+	if len(maxTps) > 0 {
+		api.MaxTPS = maxTps
+	}
+
+	if legacy.boolean("isMonetizationEnabled") || legacy.value("monetizationProperties") != nil {
+		api.Monetization = map[string]interface{}{
+			"enabled":    legacy.boolean("isMonetizationEnabled"),
+			"properties": legacy.value("monetizationProperties"),
+		}
+	}
+
+	additionalPropertyNames := make([]string, 0)
+	additionalProperties := legacy.object("additionalProperties")
+	for name := range additionalProperties {
+		additionalPropertyNames = append(additionalPropertyNames, name)
+	}
+	sort.Strings(additionalPropertyNames)
+	for _, name := range additionalPropertyNames {
+		api.AdditionalProperties = append(api.AdditionalProperties, map[string]interface{}{
+			"name": name, "value": stringOf(additionalProperties[name]), "display": false,
+		})
+	}
+
+	api.AdvertiseInformation = v2.AdvertiseInfo{
+		Advertised:           legacy.boolean("advertiseOnly"),
+		OriginalDevPortalUrl: legacy.str("redirectURL"),
+		ApiOwner:             legacy.str("apiOwner"),
+		Vendor:               legacy.str("vendor"),
+	}
+
+	if endpointConfig := legacy.str("endpointConfig"); endpointConfig != "" {
+		var config interface{}
+		if err := json.Unmarshal([]byte(endpointConfig), &config); err != nil {
+			migration.addUnconverted("endpoint configuration is not converted as it is malformed: %v", err)
+		} else {
+			api.EndpointConfig = config
+		}
+	}
+	if legacy.boolean("endpointSecured") {
+		migration.addUnconverted("endpoint security is not converted. Add the endpoint credentials to the params " +
+			"file of the project")
+	}
+	legacy.ignore("endpointAuthDigest", "endpointUTUsername", "endpointUTPassword")
+
+	for _, scope := range legacy.objects("scopes") {
+		api.Scopes = append(api.Scopes, map[string]interface{}{
+			"scope": map[string]interface{}{
+				"name":        stringOf(scope["key"]),
+				"displayName": stringOf(scope["name"]),
+				"description": stringOf(scope["description"]),
+				"bindings":    splitLegacyList(scope["roles"]),
+			},
+			"shared": false,
+		})
+	}
+	api.Operations = convertLegacyURITemplates(legacy.objects("uriTemplates"), migration)
+	return api
+}
+
+// convertLegacyContext returns the context without the version, as APIM 4.x appends the version to the context
+func convertLegacyContext(contextTemplate, context, version string) string {
+	if contextTemplate != "" {
+		return strings.TrimSuffix(contextTemplate, "/{version}")
+	}
+	return strings.TrimSuffix(context, "/"+version)
+}
+
+func convertLegacyURITemplates(uriTemplates []map[string]interface{},
+	migration *LegacyProjectMigration) []interface{} {
+	var operations []interface{}
+	for _, uriTemplate := range uriTemplates {
+		verb := stringOf(uriTemplate["httpVerb"])
+		if verb == "" {
+			verb = stringOf(uriTemplate["HTTPVerb"])
+		}
+		authType := stringOf(uriTemplate["authType"])
+		if authType == "Any" {
+			authType = "Application & Application User"
+		}
+		var scopes []string
+		if scope, ok := uriTemplate["scope"].(map[string]interface{}); ok && stringOf(scope["key"]) != "" {
+			scopes = append(scopes, stringOf(scope["key"]))
+		}
+		operation := map[string]interface{}{
+			"target":           stringOf(uriTemplate["uriTemplate"]),
+			"verb":             strings.ToUpper(verb),
+			"authType":         authType,
+			"throttlingPolicy": stringOf(uriTemplate["throttlingTier"]),
+		}
+		if len(scopes) > 0 {
+			operation["scopes"] = scopes
+		}
+		if script := stringOf(uriTemplate["mediationScript"]); strings.TrimSpace(script) != "" {
+			migration.addUnconverted("mediation script of %s %s is not converted", operation["verb"],
+				operation["target"])
+		}
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+// migrateLegacyDefinition moves the API definition from the meta information directory to the definitions directory
+func migrateLegacyDefinition(projectPath string, migration *LegacyProjectMigration, apiType string) error {
+	definitionsDir := filepath.Join(migration.Destination, utils.InitProjectDefinitions)
+	if schemaPath := filepath.Join(projectPath, legacyMetaInfoDir, "schema.graphql"); utils.IsFileExist(schemaPath) {
+		if err := utils.CreateDirIfNotExist(definitionsDir); err != nil {
+			return err
+		}
+		if err := utils.CopyFile(schemaPath, filepath.Join(definitionsDir, "schema.graphql")); err != nil {
+			return err
+		}
+	}
+	swaggerPath, err := getLegacyMetaFile(projectPath, "swagger")
+	if err != nil {
+		if strings.EqualFold(apiType, "HTTP") || strings.EqualFold(apiType, "SOAPTOREST") {
+			migration.addUnconverted("API definition is not found in %s", legacyMetaInfoDir)
+		}
+		return nil
+	}
+	swagger, err := utils.LoadYamlAsJson(swaggerPath)
+	if err != nil {
+		return err
+	}
+	swagger, err = utils.JsonToYaml(swagger)
+	if err != nil {
+		return err
+	}
+	if err = utils.CreateDirIfNotExist(definitionsDir); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(migration.Destination, utils.InitProjectDefinitionsSwagger), swagger,
+		os.ModePerm)
+}
+
+// migrateLegacySequences converts the custom mediation sequences of the API to operation policies attached to the API
+func migrateLegacySequences(projectPath string, legacy *legacyFields, migration *LegacyProjectMigration,
+	version string, api *v2.APIDTODefinition) error {
+	policies := &apiPolicies{}
+	specs := map[string]*operationPolicySpecification{}
+	var specNames []string
+	for _, flow := range legacySequenceFlows {
+		name := legacy.str(flow.sequence + "Sequence")
+		if name == "" {
+			continue
+		}
+		if apimVersionIndex(version) < apimVersionIndex(operationPoliciesSupportedVersion) {
+			migration.addUnconverted("%s sequence %q is not converted as operation policies are supported from "+
+				"APIM %s", flow.sequence, name, operationPoliciesSupportedVersion)
+			continue
+		}
+		if spec, found := specs[name]; found {
+			spec.Data.ApplicableFlows = append(spec.Data.ApplicableFlows, flow.policy)
+			policies.add(flow.policy, apiPolicyReference{PolicyName: name, PolicyVersion: migratedPolicyVersion,
+				Parameters: map[string]interface{}{}})
+			continue
+		}
+
+		sequence, err := readLegacySequence(projectPath, flow.sequence, name)
+		if err != nil {
+			migration.addUnconverted("%s sequence %q is not converted: %v", flow.sequence, name, err)
+			continue
+		}
+		if strings.Contains(sequence, "{{") || strings.Contains(sequence, "{%") {
+			migration.addUnconverted("%s sequence %q contains template expressions which need to be escaped in "+
+				"the converted policy", flow.sequence, name)
+		}
+		policiesDir := filepath.Join(migration.Destination, utils.InitProjectSequences)
+		if err = utils.CreateDirIfNotExist(policiesDir); err != nil {
+			return err
+		}
+		policyFileName := name + "_" + migratedPolicyVersion
+		if err = ioutil.WriteFile(filepath.Join(policiesDir, policyFileName+".j2"), []byte(sequence),
+			os.ModePerm); err != nil {
+			return err
+		}
+		specs[name] = &operationPolicySpecification{
+			Type:    "operation_policy_specification",
+			Version: version,
+			Data: operationPolicySpecDataFile{
+				Category:          "Mediation",
+				Name:              name,
+				Version:           migratedPolicyVersion,
+				DisplayName:       name,
+				Description:       "Migrated from the " + name + " mediation sequence",
+				ApplicableFlows:   []string{flow.policy},
+				SupportedGateways: []string{"Synapse"},
+				SupportedApiTypes: []string{"HTTP"},
+			},
+		}
+		specNames = append(specNames, name)
+		policies.add(flow.policy, apiPolicyReference{PolicyName: name, PolicyVersion: migratedPolicyVersion,
+			Parameters: map[string]interface{}{}})
+	}
+
+	for _, name := range specNames {
+		content, err := yaml.Marshal(specs[name])
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(filepath.Join(migration.Destination, utils.InitProjectSequences,
+			name+"_"+migratedPolicyVersion+".yaml"), content, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+	if len(specNames) > 0 {
+		api.APIPolicies = policies
+	}
+	return nil
+}
+
+// readLegacySequence returns the mediators of a sequence, looking up the API specific sequences first and then
+// the shared sequences
+func readLegacySequence(projectPath, flow, name string) (string, error) {
+	flowDir := filepath.Join(projectPath, legacySequencesDir, flow+"-sequence")
+	for _, path := range []string{filepath.Join(flowDir, legacyCustomSequencesDir, name+".xml"),
+		filepath.Join(flowDir, name+".xml")} {
+		if !utils.IsFileExist(path) {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		match := reLegacySequence.FindSubmatch(content)
+		if match == nil {
+			return "", errors.New("the sequence file is not a Synapse sequence")
+		}
+		return strings.TrimSpace(string(match[1])) + "\n", nil
+	}
+	return "", errors.New("the sequence file is not found in the archive")
+}
+
+type legacyDocument struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Summary       string `json:"summary"`
+	SourceType    string `json:"sourceType"`
+	SourceURL     string `json:"sourceUrl"`
+	FilePath      string `json:"filePath"`
+	Visibility    string `json:"visibility"`
+	OtherTypeName string `json:"otherTypeName"`
+}
+
+// migrateLegacyDocs converts the documents listed in the docs file to a directory per document
+func migrateLegacyDocs(projectPath string, migration *LegacyProjectMigration, version string) error {
+	docsDir := filepath.Join(projectPath, utils.InitProjectDocs)
+	docsFile := filepath.Join(docsDir, legacyDocsFile)
+	if !utils.IsFileExist(docsFile) {
+		return nil
+	}
+	content, err := ioutil.ReadFile(docsFile)
+	if err != nil {
+		return err
+	}
+	var docs []legacyDocument
+	if err = json.Unmarshal(content, &docs); err != nil {
+		migration.addUnconverted("documents are not converted as %s is malformed: %v", legacyDocsFile, err)
+		return nil
+	}
+	for _, doc := range docs {
+		var contentPath, contentFileName string
+		switch strings.ToUpper(doc.SourceType) {
+		case "INLINE", "MARKDOWN":
+			contentPath = filepath.Join(docsDir, legacyDocsInlineContentDir, doc.Name)
+			contentFileName = doc.Name
+		case "FILE":
+			contentFileName = filepath.Base(filepath.ToSlash(doc.FilePath))
+			contentPath = filepath.Join(docsDir, legacyDocsFileContentDir, contentFileName)
+		}
+		if contentPath != "" && !utils.IsFileExist(contentPath) {
+			migration.addUnconverted("document %q is not converted as its content is not found in the archive",
+				doc.Name)
+			continue
+		}
+
+		docDir := filepath.Join(migration.Destination, utils.InitProjectDocs, doc.Name)
+		if err = utils.CreateDirIfNotExist(docDir); err != nil {
+			return err
+		}
+		document := v2.Document{Type: "document", Version: version, Data: v2.Data{
+			Name:          doc.Name,
+			Type:          doc.Type,
+			Summary:       doc.Summary,
+			SourceType:    doc.SourceType,
+			SourceURL:     doc.SourceURL,
+			OtherTypeName: doc.OtherTypeName,
+			Visibility:    doc.Visibility,
+		}}
+		if strings.EqualFold(doc.SourceType, "FILE") {
+			document.Data.FileName = contentFileName
+		}
+		if contentPath != "" {
+			if err = utils.CopyFile(contentPath, filepath.Join(docDir, contentFileName)); err != nil {
+				return err
+			}
+		}
+		documentContent, err := yaml.Marshal(document)
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(filepath.Join(docDir, "document.yaml"), documentContent, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeLegacyDeploymentEnvironments(environments []string, projectPath, version string) error {
+	if len(environments) == 0 {
+		return nil
+	}
+	deploymentEnvironments := deploymentEnvironmentsFile{Type: deploymentEnvironmentsArtifactType, Version: version}
+	for _, environment := range environments {
+		if environment == legacyDefaultGatewayEnv {
+			environment = defaultGatewayEnv
+		}
+		deploymentEnvironments.Data = append(deploymentEnvironments.Data, deploymentEnvironment{
+			DisplayOnDevportal:    true,
+			DeploymentEnvironment: environment,
+		})
+	}
+	content, err := yaml.Marshal(deploymentEnvironments)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(projectPath, utils.DeploymentEnvFile), content, os.ModePerm)
+}
+
+// legacyFields reads the fields of an APIM 3.x API while keeping track of the fields which were converted
+type legacyFields struct {
+	values   map[string]interface{}
+	consumed map[string]bool
+}
+
+func (l *legacyFields) value(key string) interface{} {
+	l.consumed[key] = true
+	return l.values[key]
+}
+
+func (l *legacyFields) ignore(keys ...string) {
+	for _, key := range keys {
+		l.consumed[key] = true
+	}
+}
+
+func (l *legacyFields) str(key string) string {
+	return stringOf(l.value(key))
+}
+
+func (l *legacyFields) boolean(key string) bool {
+	value, _ := l.value(key).(bool)
+	return value
+}
+
+func (l *legacyFields) number(key string) float64 {
+	switch value := l.value(key).(type) {
+	case float64:
+		return value
+	case string:
+		number, _ := strconv.ParseFloat(value, 64)
+		return number
+	}
+	return 0
+}
+
+// list reads a list which was either a comma separated string or a list in APIM 3.x
+func (l *legacyFields) list(key string) []string {
+	return splitLegacyList(l.value(key))
+}
+
+func (l *legacyFields) object(key string) map[string]interface{} {
+	value, _ := l.value(key).(map[string]interface{})
+	return value
+}
+
+func (l *legacyFields) objects(key string) []map[string]interface{} {
+	values, _ := l.value(key).([]interface{})
+	objects := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if object, ok := value.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// unconsumed returns the fields with values which were not converted
+func (l *legacyFields) unconsumed() []string {
+	var fields []string
+	for key, value := range l.values {
+		if !l.consumed[key] && !isEmptyLegacyValue(value) {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isEmptyLegacyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func splitLegacyList(value interface{}) []string {
+	var list []string
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			list = append(list, stringOf(item))
+		}
+	}
+	return list
+}
+
+func stringOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,106 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const legacyTestProject = "LegacyPizzaShackAPI-1.0.0"
+
+func TestMigrateLegacyProjects(t *testing.T) {
+	destination := t.TempDir()
+	migrations, err := MigrateLegacyProjects(utils.GetRelativeTestDataPathFromImpl()+legacyTestProject, destination,
+		"4.6")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(migrations))
+	projectPath := filepath.Join(destination, "PizzaShackAPI-1.0.0")
+	assert.Equal(t, projectPath, migrations[0].Destination)
+	assert.ElementsMatch(t, []string{
+		`out sequence "missingSequence" is not converted: the sequence file is not found in the archive`,
+		`field "gatewayLabels" of the API is not converted`,
+	}, migrations[0].Unconverted)
+
+	api, _, err := GetAPIDefinition(projectPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "v4.6.0", api.ApimVersion)
+	assert.Equal(t, "PizzaShackAPI", api.Data.Name)
+	assert.Equal(t, "admin", api.Data.Provider)
+	assert.Equal(t, "/pizzashack", api.Data.Context)
+	assert.Equal(t, "PUBLIC", api.Data.Visibility)
+	assert.Equal(t, "NONE", api.Data.AccessControl)
+	assert.Equal(t, []string{"http", "https"}, api.Data.Transport)
+	assert.Equal(t, []string{"oauth2", "oauth_basic_auth_api_key_mandatory"}, api.Data.SecurityScheme)
+	assert.Equal(t, []string{"Unlimited", "Gold"}, api.Data.Policies)
+	assert.Equal(t, 2, len(api.Data.Operations))
+	assert.NotNil(t, api.Data.EndpointConfig)
+	assert.NotNil(t, api.Data.APIPolicies)
+
+	assert.True(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger)))
+	policy, err := ioutil.ReadFile(filepath.Join(projectPath, utils.InitProjectSequences, "addHeader_v1.j2"))
+	assert.Nil(t, err)
+	assert.Equal(t, "<header name=\"X-Shop\" scope=\"transport\" value=\"PizzaShack\"/>\n", string(policy))
+	assert.True(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectSequences, "addHeader_v1.yaml")))
+
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, utils.DeploymentEnvFile))
+	assert.Nil(t, err)
+	deploymentEnvironments := deploymentEnvironmentsFile{}
+	assert.Nil(t, yaml.Unmarshal(content, &deploymentEnvironments))
+	assert.Equal(t, "Default", deploymentEnvironments.Data[0].DeploymentEnvironment)
+
+	content, err = ioutil.ReadFile(filepath.Join(projectPath, utils.InitProjectDocs, "Overview", "document.yaml"))
+	assert.Nil(t, err)
+	document := v2.Document{}
+	assert.Nil(t, yaml.Unmarshal(content, &document))
+	assert.Equal(t, "INLINE", document.Data.SourceType)
+	assert.True(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectDocs, "Overview", "Overview")))
+	assert.True(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectDocs, "Guide", "document.yaml")))
+
+	// The projects are not overwritten
+	_, err = MigrateLegacyProjects(utils.GetRelativeTestDataPathFromImpl()+legacyTestProject, destination, "4.6")
+	assert.NotNil(t, err)
+}
+
+func TestMigrateLegacyProjectsSequencesBeforeOperationPolicies(t *testing.T) {
+	destination := t.TempDir()
+	migrations, err := MigrateLegacyProjects(utils.GetRelativeTestDataPathFromImpl()+legacyTestProject, destination,
+		"v4.0.0")
+	assert.Nil(t, err)
+	assert.Contains(t, migrations[0].Unconverted,
+		`in sequence "addHeader" is not converted as operation policies are supported from APIM v4.1.0`)
+	assert.False(t, utils.IsFileExist(filepath.Join(migrations[0].Destination, utils.InitProjectSequences)))
+}
+
+func TestMigrateLegacyProjectsNotLegacy(t *testing.T) {
+	_, err := MigrateLegacyProjects(utils.GetRelativeTestDataPathFromImpl()+"PizzaShackAPI-1.0.0", t.TempDir(), "4.6")
+	assert.NotNil(t, err)
+}
+
+func TestConvertLegacyContext(t *testing.T) {
+	assert.Equal(t, "/pizzashack", convertLegacyContext("/pizzashack/{version}", "/pizzashack/1.0.0", "1.0.0"))
+	assert.Equal(t, "/{version}/pizzashack", convertLegacyContext("/{version}/pizzashack", "", "1.0.0"))
+	assert.Equal(t, "/pizzashack", convertLegacyContext("", "/pizzashack/1.0.0", "1.0.0"))
+}
@@ -0,0 +1,397 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// Maximum depth of nested schemas to generate samples, to break recursive schemas
+	mockSchemaMaxDepth      = 10
+	mockDefaultContentType  = "application/json"
+	mockDefinitionRefPrefix = "#/"
+)
+
+var (
+	mockHTTPMethods   = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	rePathParamInPath = regexp.MustCompile(`\{[^/}]+\}`)
+)
+
+// mockRoute holds the mocked response of a resource in the OpenAPI definition
+type mockRoute struct {
+	method      string
+	path        string
+	pathPattern *regexp.Regexp
+	status      int
+	contentType string
+	body        interface{}
+}
+
+// mockServer serves the mocked responses of the resources in an OpenAPI definition
+type mockServer struct {
+	routes []*mockRoute
+}
+
+// StartMockServer starts a local mock server which serves the examples, or samples generated from the schemas, of
+// the responses in the OpenAPI definition of an API project
+// @param projectPath : Path to the API project
+// @param port : Port to start the mock server on
+// @return error
+func StartMockServer(projectPath string, port int) error {
+	definitionPath := filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger)
+	definition, err := ioutil.ReadFile(definitionPath)
+	if err != nil {
+		return errors.New("error while reading the OpenAPI definition of the project: " + err.Error())
+	}
+	server, err := newMockServer(definition)
+	if err != nil {
+		return err
+	}
+	for _, route := range server.routes {
+		fmt.Printf("%-8s %s -> %d\n", strings.ToUpper(route.method), route.path, route.status)
+	}
+	fmt.Println("Mock server started on port " + strconv.Itoa(port))
+	return http.ListenAndServe(":"+strconv.Itoa(port), server)
+}
+
+// newMockServer builds the mocked routes of the resources in an OpenAPI definition
+// @param definition : OpenAPI 2 or OpenAPI 3 definition in yaml or json format
+// @return mock server, error
+func newMockServer(definition []byte) (*mockServer, error) {
+	definitionJson, err := utils.YamlToJson(definition)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err = json.Unmarshal(definitionJson, &doc); err != nil {
+		return nil, err
+	}
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("no paths found in the OpenAPI definition")
+	}
+	basePath := getMockBasePath(doc)
+
+	// Sort the paths so that the routes are matched in the same order on every run, which also matches the static
+	// paths before the templated paths sharing the same prefix
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	server := &mockServer{}
+	for _, path := range pathNames {
+		pathItem, ok := paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fullPath := strings.TrimSuffix(basePath, "/") + path
+		pathPattern, err := getMockPathPattern(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range mockHTTPMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			route := &mockRoute{method: method, path: fullPath, pathPattern: pathPattern}
+			setMockResponse(route, operation, doc)
+			server.routes = append(server.routes, route)
+		}
+	}
+	return server, nil
+}
+
+// getMockPathPattern builds the pattern to match the request paths of a resource, where a path param matches any
+// single path segment
+// @param path : Path of the resource
+// @return pattern, error
+func getMockPathPattern(path string) (*regexp.Regexp, error) {
+	segments := rePathParamInPath.Split(path, -1)
+	for i, segment := range segments {
+		segments[i] = regexp.QuoteMeta(segment)
+	}
+	return regexp.Compile("^" + strings.Join(segments, "[^/]+") + "/?$")
+}
+
+// ServeHTTP serves the mocked response of the first route matching the request
+func (s *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	utils.Logln(utils.LogPrefixInfo + r.Method + " " + r.URL.Path)
+	for _, route := range s.routes {
+		if !strings.EqualFold(route.method, r.Method) || !route.pathPattern.MatchString(r.URL.Path) {
+			continue
+		}
+		if route.body == nil {
+			w.WriteHeader(route.status)
+			return
+		}
+		w.Header().Set(utils.HeaderContentType, route.contentType)
+		w.WriteHeader(route.status)
+		if text, ok := route.body.(string); ok && !strings.Contains(route.contentType, "json") {
+			_, _ = w.Write([]byte(text))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(route.body)
+		return
+	}
+	w.Header().Set(utils.HeaderContentType, mockDefaultContentType)
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    http.StatusNotFound,
+		"message": "No matching resource found in the API definition for " + r.Method + " " + r.URL.Path,
+	})
+}
+
+// getMockBasePath returns the base path of the API given in the OpenAPI definition
+// @param doc : OpenAPI definition
+// @return base path
+func getMockBasePath(doc map[string]interface{}) string {
+	// OpenAPI 2
+	if basePath, ok := doc["basePath"].(string); ok {
+		return basePath
+	}
+	// OpenAPI 3, the path of the first server url is used as the base path
+	if servers, ok := doc["servers"].([]interface{}); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]interface{}); ok {
+			serverURL, _ := server["url"].(string)
+			if i := strings.Index(serverURL, "://"); i >= 0 {
+				serverURL = serverURL[i+3:]
+				if j := strings.Index(serverURL, "/"); j >= 0 {
+					return serverURL[j:]
+				}
+				return ""
+			}
+			return serverURL
+		}
+	}
+	return ""
+}
+
+// setMockResponse sets the status, content type and body of the mocked route using the first successful response
+// of the operation
+// @param route : Route to set the response
+// @param operation : Operation in the OpenAPI definition
+// @param doc : OpenAPI definition
+func setMockResponse(route *mockRoute, operation, doc map[string]interface{}) {
+	route.status = http.StatusOK
+	responses, ok := operation["responses"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	var response map[string]interface{}
+	for _, code := range codes {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			route.status = status
+			response, _ = responses[code].(map[string]interface{})
+			break
+		}
+	}
+	if response == nil {
+		response, _ = responses["default"].(map[string]interface{})
+	}
+	if response == nil {
+		return
+	}
+	response = resolveMockRef(response, doc)
+
+	// OpenAPI 3 responses have the examples and the schema per media type
+	if content, ok := response["content"].(map[string]interface{}); ok {
+		route.contentType = selectMockContentType(content)
+		mediaType, _ := content[route.contentType].(map[string]interface{})
+		if example, ok := mediaType["example"]; ok {
+			route.body = example
+		} else if examples, ok := mediaType["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+			names := make([]string, 0, len(examples))
+			for name := range examples {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			example, _ := examples[names[0]].(map[string]interface{})
+			route.body = resolveMockRef(example, doc)["value"]
+		} else if schema, ok := mediaType["schema"].(map[string]interface{}); ok {
+			route.body = generateMockSample(schema, doc, 0)
+		}
+		return
+	}
+
+	// OpenAPI 2 responses have the examples per media type and a single schema
+	if examples, ok := response["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		route.contentType = selectMockContentType(examples)
+		route.body = examples[route.contentType]
+		return
+	}
+	if schema, ok := response["schema"].(map[string]interface{}); ok {
+		route.contentType = mockDefaultContentType
+		if produces, ok := operation["produces"].([]interface{}); ok && len(produces) > 0 {
+			route.contentType, _ = produces[0].(string)
+		} else if produces, ok := doc["produces"].([]interface{}); ok && len(produces) > 0 {
+			route.contentType, _ = produces[0].(string)
+		}
+		route.body = generateMockSample(schema, doc, 0)
+	}
+}
+
+// selectMockContentType selects json as the content type of the mocked response if available, or else the first
+// content type in alphabetical order
+// @param content : Responses keyed by the content type
+// @return content type
+func selectMockContentType(content map[string]interface{}) string {
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		if strings.Contains(contentType, "json") {
+			return contentType
+		}
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	return contentTypes[0]
+}
+
+// resolveMockRef resolves a local $ref of an object in the OpenAPI definition
+// @param object : Object which may be a reference
+// @param doc : OpenAPI definition
+// @return resolved object, or the object itself if it is not a local reference
+func resolveMockRef(object, doc map[string]interface{}) map[string]interface{} {
+	ref, ok := object["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, mockDefinitionRefPrefix) {
+		return object
+	}
+	var current interface{} = doc
+	for _, key := range strings.Split(strings.TrimPrefix(ref, mockDefinitionRefPrefix), "/") {
+		key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+		parent, ok := current.(map[string]interface{})
+		if !ok {
+			return object
+		}
+		current = parent[key]
+	}
+	if resolved, ok := current.(map[string]interface{}); ok {
+		return resolved
+	}
+	return object
+}
+
+// generateMockSample generates a sample value for a schema, honoring the examples, defaults and enums given in
+// the schema
+// @param schema : Schema to generate the sample
+// @param doc : OpenAPI definition
+// @param depth : Depth of the schema from the response schema
+// @return sample value
+func generateMockSample(schema, doc map[string]interface{}, depth int) interface{} {
+	if depth > mockSchemaMaxDepth {
+		return nil
+	}
+	schema = resolveMockRef(schema, doc)
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if defaultValue, ok := schema["default"]; ok {
+		return defaultValue
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		sample := map[string]interface{}{}
+		for _, subSchema := range allOf {
+			if subSchema, ok := subSchema.(map[string]interface{}); ok {
+				if subSample, ok := generateMockSample(subSchema, doc, depth+1).(map[string]interface{}); ok {
+					for key, value := range subSample {
+						sample[key] = value
+					}
+				}
+			}
+		}
+		return sample
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if subSchemas, ok := schema[key].([]interface{}); ok && len(subSchemas) > 0 {
+			if subSchema, ok := subSchemas[0].(map[string]interface{}); ok {
+				return generateMockSample(subSchema, doc, depth+1)
+			}
+		}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	properties, hasProperties := schema["properties"].(map[string]interface{})
+	switch {
+	case schemaType == "object" || hasProperties:
+		sample := map[string]interface{}{}
+		for name, property := range properties {
+			if property, ok := property.(map[string]interface{}); ok {
+				sample[name] = generateMockSample(property, doc, depth+1)
+			}
+		}
+		return sample
+	case schemaType == "array":
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return []interface{}{}
+		}
+		return []interface{}{generateMockSample(items, doc, depth+1)}
+	case schemaType == "integer":
+		return 0
+	case schemaType == "number":
+		return 0.0
+	case schemaType == "boolean":
+		return true
+	case schemaType == "string":
+		return getMockStringSample(schema)
+	}
+	return nil
+}
+
+// getMockStringSample generates a sample value for a string schema based on its format
+// @param schema : String schema
+// @return sample value
+func getMockStringSample(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	switch format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	}
+	return "string"
+}
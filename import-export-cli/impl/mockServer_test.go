@@ -0,0 +1,180 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renstrom/dedent"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockOAS3Definition = dedent.Dedent(`
+	openapi: 3.0.1
+	info:
+	  title: PetStore
+	  version: 1.0.0
+	servers:
+	  - url: http://localhost:8080/petstore/1.0.0
+	paths:
+	  /pets:
+	    get:
+	      responses:
+	        "200":
+	          description: OK
+	          content:
+	            application/json:
+	              schema:
+	                type: array
+	                items:
+	                  $ref: '#/components/schemas/Pet'
+	    post:
+	      responses:
+	        "201":
+	          description: Created
+	          content:
+	            application/json:
+	              examples:
+	                dog:
+	                  value:
+	                    id: 2
+	                    name: Rex
+	  /pets/{petId}:
+	    get:
+	      responses:
+	        "200":
+	          description: OK
+	          content:
+	            application/json:
+	              example:
+	                id: 1
+	                name: Tom
+	        "404":
+	          description: Not Found
+	    delete:
+	      responses:
+	        "204":
+	          description: Deleted
+	components:
+	  schemas:
+	    Pet:
+	      type: object
+	      properties:
+	        id:
+	          type: integer
+	        name:
+	          type: string
+	          example: Tom
+	        status:
+	          type: string
+	          enum: [available, sold]
+	        born:
+	          type: string
+	          format: date
+`)
+
+var mockOAS2Definition = dedent.Dedent(`
+	swagger: "2.0"
+	info:
+	  title: PizzaShack
+	  version: 1.0.0
+	basePath: /pizzashack/1.0.0
+	produces:
+	  - application/json
+	paths:
+	  /menu:
+	    get:
+	      responses:
+	        "200":
+	          description: OK
+	          schema:
+	            type: array
+	            items:
+	              $ref: '#/definitions/MenuItem'
+	  /order:
+	    post:
+	      responses:
+	        "201":
+	          description: Created
+	          examples:
+	            application/json:
+	              orderId: ORD-1
+	definitions:
+	  MenuItem:
+	    type: object
+	    properties:
+	      name:
+	        type: string
+	        default: Chicken Parmesan
+	      price:
+	        type: number
+`)
+
+func invokeMockServer(t *testing.T, definition, method, path string) *httptest.ResponseRecorder {
+	server, err := newMockServer([]byte(definition))
+	assert.Nil(t, err)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder
+}
+
+func TestMockServerOAS3SchemaSample(t *testing.T) {
+	recorder := invokeMockServer(t, mockOAS3Definition, http.MethodGet, "/petstore/1.0.0/pets")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var pets []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &pets))
+	assert.Equal(t, 1, len(pets))
+	assert.Equal(t, "Tom", pets[0]["name"])
+	assert.Equal(t, "available", pets[0]["status"])
+	assert.Equal(t, "2024-01-01", pets[0]["born"])
+}
+
+func TestMockServerOAS3Example(t *testing.T) {
+	recorder := invokeMockServer(t, mockOAS3Definition, http.MethodGet, "/petstore/1.0.0/pets/1")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"id":1,"name":"Tom"}`, recorder.Body.String())
+
+	recorder = invokeMockServer(t, mockOAS3Definition, http.MethodPost, "/petstore/1.0.0/pets")
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.JSONEq(t, `{"id":2,"name":"Rex"}`, recorder.Body.String())
+}
+
+func TestMockServerOAS3NoContent(t *testing.T) {
+	recorder := invokeMockServer(t, mockOAS3Definition, http.MethodDelete, "/petstore/1.0.0/pets/1")
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, 0, recorder.Body.Len())
+}
+
+func TestMockServerOAS2(t *testing.T) {
+	recorder := invokeMockServer(t, mockOAS2Definition, http.MethodGet, "/pizzashack/1.0.0/menu")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[{"name":"Chicken Parmesan","price":0}]`, recorder.Body.String())
+
+	recorder = invokeMockServer(t, mockOAS2Definition, http.MethodPost, "/pizzashack/1.0.0/order")
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.JSONEq(t, `{"orderId":"ORD-1"}`, recorder.Body.String())
+}
+
+func TestMockServerUnknownResource(t *testing.T) {
+	recorder := invokeMockServer(t, mockOAS3Definition, http.MethodGet, "/petstore/1.0.0/owners")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
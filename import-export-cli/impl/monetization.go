@@ -0,0 +1,319 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	monetizationPropertyHeader = "PROPERTY"
+	monetizationValueHeader    = "VALUE"
+
+	usagePublishStateHeader           = "STATE"
+	usagePublishStatusHeader          = "STATUS"
+	usagePublishStartedTimeHeader     = "STARTED_TIME"
+	usagePublishLastPublishTimeHeader = "LAST_PUBLISH_TIME"
+
+	defaultMonetizationTableFormat = "table {{.Property}}\t{{.Value}}"
+	defaultUsagePublishTableFormat = "table {{.State}}\t{{.Status}}\t{{.StartedTime}}\t{{.LastPublishTime}}"
+
+	monetizationResource          = "monetization"
+	monetizeResource              = "monetize"
+	monetizationUsagePublishPath  = "monetization/publish-usage"
+	monetizationUsageStatusSuffix = "/status"
+)
+
+// monetizationProperty holds a monetization property of an API for outputting
+type monetizationProperty struct {
+	property string
+	value    string
+}
+
+// Property name of the monetization property
+func (p monetizationProperty) Property() string {
+	return p.property
+}
+
+// Value of the monetization property
+func (p monetizationProperty) Value() string {
+	return p.value
+}
+
+// MarshalJSON marshals monetization property using custom marshaller which uses methods instead of fields
+func (p *monetizationProperty) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(p)
+}
+
+// usagePublishInfo holds the status of the monetization usage publish job for outputting
+type usagePublishInfo struct {
+	info utils.MonetizationUsagePublishInfo
+}
+
+// State of the usage publish job
+func (u usagePublishInfo) State() string {
+	return u.info.State
+}
+
+// Status of the usage publish job
+func (u usagePublishInfo) Status() string {
+	return u.info.Status
+}
+
+// StartedTime of the usage publish job
+func (u usagePublishInfo) StartedTime() string {
+	return u.info.StartedTime
+}
+
+// LastPublishTime of the usage publish job
+func (u usagePublishInfo) LastPublishTime() string {
+	return u.info.LastPublishTime
+}
+
+// MarshalJSON marshals usage publish info using custom marshaller which uses methods instead of fields
+func (u *usagePublishInfo) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(u)
+}
+
+// GetAPIMonetizationFromEnv retrieves the monetization status and properties of an API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @return monetization info of the API
+// @return error
+func GetAPIMonetizationFromEnv(accessToken, environment, apiName, apiVersion,
+	provider string) (*utils.APIMonetizationInfo, error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId + "/" +
+		monetizationResource
+	return getAPIMonetization(url, accessToken)
+}
+
+func getAPIMonetization(url, accessToken string) (*utils.APIMonetizationInfo, error) {
+	utils.Logln(utils.LogPrefixInfo+"GetAPIMonetization: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the monetization of the API. Status: " +
+			resp.Status())
+	}
+	info := &utils.APIMonetizationInfo{}
+	err = json.Unmarshal(resp.Body(), info)
+	return info, err
+}
+
+// SetAPIMonetizationInEnv enables or disables the monetization of an API and updates its monetization properties
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @param info			: Monetization status and properties to be set
+// @return error
+func SetAPIMonetizationInEnv(accessToken, environment, apiName, apiVersion, provider string,
+	info *utils.APIMonetizationInfo) error {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId + "/" +
+		monetizeResource
+	return setAPIMonetization(url, accessToken, info)
+}
+
+func setAPIMonetization(url, accessToken string, info *utils.APIMonetizationInfo) error {
+	utils.Logln(utils.LogPrefixInfo+"SetAPIMonetization: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	body, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePOSTRequest(url, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for setting the monetization of the API. Status: " +
+			resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
+
+// PublishMonetizationUsageInEnv triggers publishing the monetization usage records of an environment to the
+// billing engine
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to publish the usage of
+// @return result of triggering the publish job
+// @return error
+func PublishMonetizationUsageInEnv(accessToken, environment string) (*utils.MonetizationUsagePublishResult, error) {
+	url := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)) +
+		monetizationUsagePublishPath
+	utils.Logln(utils.LogPrefixInfo+"PublishMonetizationUsage: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokePOSTRequestWithoutBody(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusAccepted {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for publishing the monetization usage. Status: " +
+			resp.Status())
+	}
+	result := &utils.MonetizationUsagePublishResult{}
+	err = json.Unmarshal(resp.Body(), result)
+	return result, err
+}
+
+// GetMonetizationUsagePublishStatusFromEnv retrieves the status of the last monetization usage publish job of an
+// environment
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment to get the usage publish status of
+// @return status of the usage publish job
+// @return error
+func GetMonetizationUsagePublishStatusFromEnv(accessToken,
+	environment string) (*utils.MonetizationUsagePublishInfo, error) {
+	url := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)) +
+		monetizationUsagePublishPath + monetizationUsageStatusSuffix
+	utils.Logln(utils.LogPrefixInfo+"GetMonetizationUsagePublishStatus: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the monetization usage publish " +
+			"status. Status: " + resp.Status())
+	}
+	info := &utils.MonetizationUsagePublishInfo{}
+	err = json.Unmarshal(resp.Body(), info)
+	return info, err
+}
+
+// PrintAPIMonetization prints the monetization status and properties of an API in the given format
+// @param apiName	Name of the API
+// @param info		Monetization status and properties of the API
+// @param format	Format type of the output
+func PrintAPIMonetization(apiName string, info *utils.APIMonetizationInfo, format string) {
+	if utils.PrintFormattedOutput(info, format) {
+		return
+	}
+	if info.Enabled {
+		fmt.Println("Monetization is enabled for the API " + apiName)
+	} else {
+		fmt.Println("Monetization is disabled for the API " + apiName)
+	}
+	if len(info.Properties) == 0 {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultMonetizationTableFormat)
+	propertyNames := make([]string, 0, len(info.Properties))
+	for name := range info.Properties {
+		propertyNames = append(propertyNames, name)
+	}
+	sort.Strings(propertyNames)
+
+	// create monetization context with standard output
+	monetizationContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, name := range propertyNames {
+			if err := t.Execute(w, &monetizationProperty{name, info.Properties[name]}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	monetizationTableHeaders := map[string]string{
+		"Property": monetizationPropertyHeader,
+		"Value":    monetizationValueHeader,
+	}
+
+	// execute context
+	if err := monetizationContext.Write(renderer, monetizationTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+// PrintMonetizationUsagePublishStatus prints the status of the monetization usage publish job in the given format
+// @param info		Status of the usage publish job
+// @param format	Format type of the output
+func PrintMonetizationUsagePublishStatus(info *utils.MonetizationUsagePublishInfo, format string) {
+	if utils.PrintFormattedOutput(info, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultUsagePublishTableFormat)
+	// create usage publish context with standard output
+	usagePublishContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which renders the usage publish status
+	renderer := func(w io.Writer, t *template.Template) error {
+		if err := t.Execute(w, &usagePublishInfo{*info}); err != nil {
+			return err
+		}
+		_, _ = w.Write([]byte{'\n'})
+		return nil
+	}
+
+	// headers for table
+	usagePublishTableHeaders := map[string]string{
+		"State":           usagePublishStateHeader,
+		"Status":          usagePublishStatusHeader,
+		"StartedTime":     usagePublishStartedTimeHeader,
+		"LastPublishTime": usagePublishLastPublishTimeHeader,
+	}
+
+	// execute context
+	if err := usagePublishContext.Write(renderer, usagePublishTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
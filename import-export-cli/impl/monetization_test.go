@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetAPIMonetization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected method '%s', got '%s'\n", http.MethodGet, r.Method)
+		}
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"enabled": true, "properties": {"ConnectedAccountKey": "acct_1"}}`))
+	}))
+	defer server.Close()
+
+	info, err := getAPIMonetization(server.URL, "access-token")
+	assert.Nil(t, err)
+	assert.True(t, info.Enabled)
+	assert.Equal(t, "acct_1", info.Properties["ConnectedAccountKey"])
+}
+
+func TestSetAPIMonetization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method '%s', got '%s'\n", http.MethodPost, r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		info := &utils.APIMonetizationInfo{}
+		if err := json.Unmarshal(body, info); err != nil || !info.Enabled {
+			t.Errorf("Unexpected monetization info in the request body: %s\n", string(body))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := setAPIMonetization(server.URL, "access-token", &utils.APIMonetizationInfo{
+		Enabled:    true,
+		Properties: map[string]string{"ConnectedAccountKey": "acct_1"},
+	})
+	assert.Nil(t, err)
+}
+
+func TestSetAPIMonetizationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := setAPIMonetization(server.URL, "access-token", &utils.APIMonetizationInfo{Enabled: true})
+	assert.Error(t, err)
+}
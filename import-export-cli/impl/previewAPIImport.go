@@ -0,0 +1,241 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Fields of an api.yaml compared as the endpoints and the policies of an API. The other fields, except the
+// operations, are compared as the properties of the API.
+var (
+	apiEndpointFields = []string{"endpointConfig", "endpointImplementationType"}
+	apiPolicyFields   = []string{"apiPolicies", "policies", "apiThrottlingPolicy", "mediationPolicies"}
+)
+
+// APIImportPreview holds the changes importing an API project would make to the working copy of the API in an
+// environment
+type APIImportPreview struct {
+	Environment string `json:"environment"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Provider    string `json:"provider"`
+	// New is true if the API does not exist in the environment and would be created by the import
+	New bool `json:"new"`
+	// Operations lists the operations added, removed or modified, identified by the verb and the target
+	Operations []FieldDifference `json:"operations"`
+	Endpoints  []FieldDifference `json:"endpoints"`
+	Policies   []FieldDifference `json:"policies"`
+	Properties []FieldDifference `json:"properties"`
+	// Files lists the other files of the project added, removed or modified, such as the definitions and documents
+	Files []FileDifference `json:"files"`
+}
+
+// HasChanges returns true if the import would change the API
+func (p *APIImportPreview) HasChanges() bool {
+	return p.New || len(p.Operations) > 0 || len(p.Endpoints) > 0 || len(p.Policies) > 0 ||
+		len(p.Properties) > 0 || len(p.Files) > 0
+}
+
+// previewAPIImport compares a prepared API project with the working copy of the API exported from the environment
+// @param accessToken : Access Token for the environment
+// @param importEnvironment : Environment to which the API would be imported
+// @param projectPath : Path to the API project prepared for the import
+// @param preserveProvider : Whether the provider of the API would be preserved during the import
+// @return changes the import would make, error
+func previewAPIImport(accessToken, importEnvironment, projectPath string, preserveProvider bool) (*APIImportPreview,
+	error) {
+	apiDefinition, _, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	provider := ""
+	if preserveProvider {
+		provider = apiDefinition.Data.Provider
+	}
+	preview := &APIImportPreview{
+		Environment: importEnvironment,
+		Name:        apiDefinition.Data.Name,
+		Version:     apiDefinition.Data.Version,
+		Provider:    apiDefinition.Data.Provider,
+	}
+
+	currentPath, err := exportAPIToTempProject(accessToken, importEnvironment, apiDefinition.Data.Name,
+		apiDefinition.Data.Version, "", provider, false)
+	if err != nil {
+		return nil, err
+	}
+	if currentPath == "" {
+		preview.New = true
+	} else {
+		defer os.RemoveAll(filepath.Dir(currentPath))
+	}
+
+	err = compareAPIProjects(preview, currentPath, projectPath, getNormalizeExcludedFields())
+	return preview, err
+}
+
+// compareAPIProjects adds the differences between the current API project and the project to be imported to the
+// preview. currentPath is empty if the API does not exist.
+func compareAPIProjects(preview *APIImportPreview, currentPath, projectPath string, excludedFields []string) error {
+	current := map[string]interface{}{}
+	if currentPath != "" {
+		var err error
+		if current, err = loadAPIData(currentPath, excludedFields); err != nil {
+			return err
+		}
+	}
+	target, err := loadAPIData(projectPath, excludedFields)
+	if err != nil {
+		return err
+	}
+
+	preview.Operations = compareAPIOperations(current["operations"], target["operations"])
+	preview.Endpoints = compareFields("", pickFields(current, apiEndpointFields, true),
+		pickFields(target, apiEndpointFields, true), []FieldDifference{})
+	preview.Policies = compareFields("", pickFields(current, apiPolicyFields, true),
+		pickFields(target, apiPolicyFields, true), []FieldDifference{})
+	otherFields := append(append([]string{"operations"}, apiEndpointFields...), apiPolicyFields...)
+	preview.Properties = compareFields("", pickFields(current, otherFields, false),
+		pickFields(target, otherFields, false), []FieldDifference{})
+
+	preview.Files = []FileDifference{}
+	var files []FileDifference
+	if currentPath != "" {
+		if files, err = compareProjects(currentPath, projectPath, excludedFields); err != nil {
+			return err
+		}
+	} else {
+		projectFiles, err := listProjectFiles(projectPath)
+		if err != nil {
+			return err
+		}
+		for file := range projectFiles {
+			files = append(files, FileDifference{Path: file, Kind: DifferenceAdded})
+		}
+		sortFileDifferences(files)
+	}
+	for _, file := range files {
+		// The api.yaml is compared field by field and the meta file is only used by apictl
+		switch path.Base(file.Path) {
+		case utils.APIDefinitionFileYaml, utils.APIDefinitionFileJson, utils.MetaFileAPI:
+			continue
+		}
+		preview.Files = append(preview.Files, FileDifference{Path: file.Path, Kind: file.Kind})
+	}
+	return nil
+}
+
+// loadAPIData returns the data of the api.yaml or api.json of a project without the excluded fields
+func loadAPIData(projectPath string, excludedFields []string) (map[string]interface{}, error) {
+	_, content, err := resolveYamlOrJSON(filepath.Join(projectPath, "api"))
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeNormalized(content, ".json", excludedFields)
+	if err != nil {
+		return nil, err
+	}
+	apiFile, _ := value.(map[string]interface{})
+	data, _ := apiFile["data"].(map[string]interface{})
+	if data == nil {
+		return nil, fmt.Errorf("invalid API definition in %s", projectPath)
+	}
+	return data, nil
+}
+
+// compareAPIOperations compares the operations by the verb and the target, so that reordering the operations is not
+// reported as a change
+func compareAPIOperations(current, target interface{}) []FieldDifference {
+	return compareFields("", operationsByKey(current), operationsByKey(target), []FieldDifference{})
+}
+
+func operationsByKey(operations interface{}) map[string]interface{} {
+	keyed := map[string]interface{}{}
+	list, _ := operations.([]interface{})
+	for _, operation := range list {
+		if fields, ok := operation.(map[string]interface{}); ok {
+			keyed[fmt.Sprint(fields["verb"])+" "+fmt.Sprint(fields["target"])] = operation
+		}
+	}
+	return keyed
+}
+
+// pickFields returns the given fields of data if include is true, or the other fields otherwise
+func pickFields(data map[string]interface{}, fields []string, include bool) map[string]interface{} {
+	selected := map[string]bool{}
+	for _, field := range fields {
+		selected[field] = true
+	}
+	picked := map[string]interface{}{}
+	for key, value := range data {
+		if selected[key] == include {
+			picked[key] = value
+		}
+	}
+	return picked
+}
+
+// PrintAPIImportPreview prints the changes an import would make to the API in the given format
+// @param preview : Changes the import would make
+// @param format : Format type of the output
+func PrintAPIImportPreview(preview *APIImportPreview, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(preview)
+		return
+	}
+	fmt.Println("Dry run: the API is not imported to " + preview.Environment)
+	if preview.New {
+		fmt.Println("API " + preview.Name + " " + preview.Version + " does not exist and would be created")
+	} else if !preview.HasChanges() {
+		fmt.Println("API " + preview.Name + " " + preview.Version + " would not be changed")
+		return
+	} else {
+		fmt.Println("API " + preview.Name + " " + preview.Version + " would be changed")
+	}
+	sections := []struct {
+		title       string
+		differences []FieldDifference
+	}{
+		{"Operations", preview.Operations},
+		{"Endpoints", preview.Endpoints},
+		{"Policies", preview.Policies},
+		{"Properties", preview.Properties},
+	}
+	for _, section := range sections {
+		if len(section.differences) == 0 {
+			continue
+		}
+		fmt.Println("\n" + section.title + ":")
+		for _, field := range section.differences {
+			printFieldDifference(field)
+		}
+	}
+	if len(preview.Files) > 0 {
+		fmt.Println("\nFiles:")
+		for _, file := range preview.Files {
+			fmt.Println("  " + file.Kind + ": " + file.Path)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAPIProjects(t *testing.T) {
+	current, project := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(current, "api.yaml"), `type: api
+data:
+  id: 0b8a1f7e
+  name: PizzaShackAPI
+  description: Pizza ordering API
+  endpointConfig:
+    production_endpoints:
+      url: https://dev.wso2.com
+  policies: [Gold]
+  operations:
+    - target: /order
+      verb: POST
+      authType: Application & Application User
+    - target: /menu
+      verb: GET
+      authType: Any
+`)
+	writeTestFile(t, filepath.Join(current, "Definitions", "swagger.yaml"), "openapi: 3.0.1\n")
+	writeTestFile(t, filepath.Join(project, "api.yaml"), `type: api
+data:
+  name: PizzaShackAPI
+  description: Pizza ordering API
+  endpointConfig:
+    production_endpoints:
+      url: https://prod.wso2.com
+  policies: [Gold, Unlimited]
+  operations:
+    - target: /menu
+      verb: GET
+      authType: None
+    - target: /order/{orderId}
+      verb: DELETE
+      authType: Any
+`)
+	writeTestFile(t, filepath.Join(project, "Definitions", "swagger.yaml"), "openapi: 3.0.2\n")
+	writeTestFile(t, filepath.Join(project, "Docs", "FileContents", "guide.md"), "# Guide\n")
+
+	preview := &APIImportPreview{}
+	err := compareAPIProjects(preview, current, project, []string{"data.id"})
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, preview.HasChanges())
+	assert.False(t, preview.New)
+
+	assert.Equal(t, []FieldDifference{
+		{Path: "DELETE /order/{orderId}", Kind: DifferenceAdded, Target: map[string]interface{}{
+			"target": "/order/{orderId}", "verb": "DELETE", "authType": "Any"}},
+		{Path: "GET /menu.authType", Kind: DifferenceModified, Source: "Any", Target: "None"},
+		{Path: "POST /order", Kind: DifferenceRemoved, Source: map[string]interface{}{
+			"target": "/order", "verb": "POST", "authType": "Application & Application User"}},
+	}, preview.Operations, "Operations should be compared by the verb and the target")
+	assert.Equal(t, []FieldDifference{{Path: "endpointConfig.production_endpoints.url", Kind: DifferenceModified,
+		Source: "https://dev.wso2.com", Target: "https://prod.wso2.com"}}, preview.Endpoints)
+	assert.Equal(t, []FieldDifference{{Path: "policies[1]", Kind: DifferenceAdded, Target: "Unlimited"}},
+		preview.Policies)
+	assert.Empty(t, preview.Properties, "Excluded fields should not be reported")
+	assert.Equal(t, []FileDifference{
+		{Path: "Definitions/swagger.yaml", Kind: DifferenceModified},
+		{Path: "Docs/FileContents/guide.md", Kind: DifferenceAdded},
+	}, preview.Files, "The api.yaml should not be reported as a file")
+}
+
+func TestCompareAPIProjectsOfNewAPI(t *testing.T) {
+	project := t.TempDir()
+	writeTestFile(t, filepath.Join(project, "api.yaml"), `type: api
+data:
+  name: PizzaShackAPI
+  context: /pizzashack
+`)
+	writeTestFile(t, filepath.Join(project, "Definitions", "swagger.yaml"), "openapi: 3.0.1\n")
+
+	preview := &APIImportPreview{New: true}
+	err := compareAPIProjects(preview, "", project, nil)
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, preview.HasChanges())
+	assert.Empty(t, preview.Operations)
+	assert.Equal(t, []FieldDifference{
+		{Path: "context", Kind: DifferenceAdded, Target: "/pizzashack"},
+		{Path: "name", Kind: DifferenceAdded, Target: "PizzaShackAPI"},
+	}, preview.Properties, "All the fields of a new API should be reported as added")
+	assert.Equal(t, []FileDifference{{Path: "Definitions/swagger.yaml", Kind: DifferenceAdded}}, preview.Files)
+}
@@ -0,0 +1,302 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Sources of the policies in a policy chain
+const (
+	policySourceAPI    = "api"
+	policySourceCommon = "common"
+)
+
+// previewCommonPoliciesLimit is the maximum number of common policies fetched from the environment
+const previewCommonPoliciesLimit = "1000"
+
+// PolicyChain is the effective chain of operation policies applied to a resource of an API, in the order the
+// gateway applies them
+type PolicyChain struct {
+	Resource string             `json:"resource"`
+	Request  []PolicyChainEntry `json:"request"`
+	Response []PolicyChainEntry `json:"response"`
+	Fault    []PolicyChainEntry `json:"fault"`
+}
+
+// PolicyChainEntry is a policy of a policy chain
+type PolicyChainEntry struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	DisplayName string `json:"displayName,omitempty"`
+	// AttachedTo is API for the API level policies and the verb and target for the resource level policies
+	AttachedTo string `json:"attachedTo"`
+	// Source is api for the policies shipped in the project and common for the common policies of the environment.
+	// It is empty if the policy is found in neither.
+	Source     string                 `json:"source"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Warnings   []string               `json:"warnings,omitempty"`
+}
+
+type previewedPolicyReference struct {
+	PolicyName    string                 `json:"policyName"`
+	PolicyVersion string                 `json:"policyVersion"`
+	Parameters    map[string]interface{} `json:"parameters"`
+}
+
+type previewedPolicies struct {
+	Request  []previewedPolicyReference `json:"request"`
+	Response []previewedPolicyReference `json:"response"`
+	Fault    []previewedPolicyReference `json:"fault"`
+}
+
+// previewedAPIFile holds the fields of the api.yaml needed to build the policy chains
+type previewedAPIFile struct {
+	Data struct {
+		Operations []struct {
+			Target            string            `json:"target"`
+			Verb              string            `json:"verb"`
+			OperationPolicies previewedPolicies `json:"operationPolicies"`
+		} `json:"operations"`
+		APIPolicies previewedPolicies `json:"apiPolicies"`
+	} `json:"data"`
+}
+
+// PreviewPoliciesInEnv builds the policy chains of the resources of an API project, resolving the policies which
+// are not shipped in the project against the common policies of the environment
+// @param accessToken : Access Token for the environment
+// @param environment : Environment the API would be imported to
+// @param projectPath : Path of the API project or archive
+// @param resource : Verb and target of the resource to preview (ex: "GET /pets"). All the resources if empty
+// @return policy chains of the resources, error
+func PreviewPoliciesInEnv(accessToken, environment, projectPath, resource string) ([]PolicyChain, error) {
+	publisherEndpoint := utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath)
+	resp, err := getAPIPolicyList(accessToken, publisherEndpoint, previewCommonPoliciesLimit)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("Unable to get the common policies of %s: %s %s", environment, resp.Status(),
+			string(resp.Body()))
+	}
+	var commonPolicies utils.APIPoliciesList
+	if err = json.Unmarshal(resp.Body(), &commonPolicies); err != nil {
+		return nil, err
+	}
+
+	clonePath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(clonePath))
+	return previewPolicies(clonePath, resource, commonPolicies.List)
+}
+
+func previewPolicies(projectPath, resource string, commonPolicies []utils.APIPolicy) ([]PolicyChain, error) {
+	_, jsonContent, err := resolveYamlOrJSON(filepath.Join(projectPath, "api"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an API project: %w", filepath.Base(projectPath), err)
+	}
+	apiFile := &previewedAPIFile{}
+	if err = json.Unmarshal(jsonContent, apiFile); err != nil {
+		return nil, err
+	}
+	projectPolicies, err := readProjectPolicySpecs(filepath.Join(projectPath, utils.InitProjectSequences))
+	if err != nil {
+		return nil, err
+	}
+	resolver := policyResolver{project: projectPolicies, common: commonPolicies}
+
+	chains := []PolicyChain{}
+	for _, operation := range apiFile.Data.Operations {
+		name := strings.ToUpper(operation.Verb) + " " + operation.Target
+		if resource != "" && !isSameResource(resource, name) {
+			continue
+		}
+		api := apiFile.Data.APIPolicies
+		ops := operation.OperationPolicies
+		// The API level policies wrap the resource level policies: they are applied first in the request flow and
+		// last in the response and fault flows
+		chains = append(chains, PolicyChain{
+			Resource: name,
+			Request: append(resolver.resolve("API", "request", api.Request),
+				resolver.resolve(name, "request", ops.Request)...),
+			Response: append(resolver.resolve(name, "response", ops.Response),
+				resolver.resolve("API", "response", api.Response)...),
+			Fault: append(resolver.resolve(name, "fault", ops.Fault),
+				resolver.resolve("API", "fault", api.Fault)...),
+		})
+	}
+	if resource != "" && len(chains) == 0 {
+		return nil, fmt.Errorf("resource %q is not found in the API project", resource)
+	}
+	for i := range chains {
+		warnDuplicatePolicies(chains[i].Request)
+		warnDuplicatePolicies(chains[i].Response)
+		warnDuplicatePolicies(chains[i].Fault)
+	}
+	return chains, nil
+}
+
+// isSameResource compares a resource given as the verb and the target, ignoring the case of the verb and the
+// extra spaces
+func isSameResource(resource, name string) bool {
+	fields := strings.Fields(resource)
+	if len(fields) != 2 {
+		return false
+	}
+	return strings.ToUpper(fields[0])+" "+fields[1] == name
+}
+
+// readProjectPolicySpecs reads the specifications of the policies shipped in the Policies directory of a project
+func readProjectPolicySpecs(policiesDir string) ([]utils.APIPolicy, error) {
+	files, err := ioutil.ReadDir(policiesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	specs := []utils.APIPolicy{}
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if file.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(policiesDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if ext != ".json" {
+			if content, err = utils.YamlToJson(content); err != nil {
+				return nil, fmt.Errorf("invalid policy specification %s: %w", file.Name(), err)
+			}
+		}
+		spec := struct {
+			Type string          `json:"type"`
+			Data utils.APIPolicy `json:"data"`
+		}{}
+		if err = json.Unmarshal(content, &spec); err != nil {
+			return nil, fmt.Errorf("invalid policy specification %s: %w", file.Name(), err)
+		}
+		if spec.Type == "operation_policy_specification" {
+			specs = append(specs, spec.Data)
+		}
+	}
+	return specs, nil
+}
+
+// policyResolver looks up the policies referred by an API in the project first and then in the common policies
+type policyResolver struct {
+	project []utils.APIPolicy
+	common  []utils.APIPolicy
+}
+
+func (r policyResolver) find(name, version string) (*utils.APIPolicy, string) {
+	for _, source := range []struct {
+		name     string
+		policies []utils.APIPolicy
+	}{{policySourceAPI, r.project}, {policySourceCommon, r.common}} {
+		for i, policy := range source.policies {
+			if policy.Name == name && policy.Version == version {
+				return &source.policies[i], source.name
+			}
+		}
+	}
+	return nil, ""
+}
+
+func (r policyResolver) resolve(attachedTo, flow string, references []previewedPolicyReference) []PolicyChainEntry {
+	entries := []PolicyChainEntry{}
+	for _, reference := range references {
+		entry := PolicyChainEntry{Name: reference.PolicyName, Version: reference.PolicyVersion,
+			AttachedTo: attachedTo, Parameters: reference.Parameters}
+		policy, source := r.find(reference.PolicyName, reference.PolicyVersion)
+		if policy == nil {
+			entry.Warnings = append(entry.Warnings, "policy is not found in the project or in the common policies "+
+				"of the environment")
+			entries = append(entries, entry)
+			continue
+		}
+		entry.DisplayName = policy.DisplayName
+		entry.Source = source
+		if len(policy.ApplicableFlows) > 0 && !containsIgnoreCase(policy.ApplicableFlows, flow) {
+			entry.Warnings = append(entry.Warnings, "policy is not applicable to the "+flow+" flow")
+		}
+		for _, attribute := range policy.PolicyAttributes {
+			if _, ok := reference.Parameters[attribute.Name]; attribute.Required && !ok {
+				entry.Warnings = append(entry.Warnings, "required parameter "+attribute.Name+" is not given")
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// warnDuplicatePolicies warns about the policies applied more than once in a flow, such as a policy attached to both
+// the API and the resource
+func warnDuplicatePolicies(entries []PolicyChainEntry) {
+	seen := make(map[string]bool)
+	for i := range entries {
+		key := entries[i].Name + ":" + entries[i].Version
+		if seen[key] {
+			entries[i].Warnings = append(entries[i].Warnings, "policy is already applied earlier in the flow")
+		}
+		seen[key] = true
+	}
+}
+
+// PrintPolicyChains prints the policy chains of the resources of an API in the given format
+// @param chains : Policy chains of the resources
+// @param format : Format type of the output
+func PrintPolicyChains(chains []PolicyChain, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(chains)
+		return
+	}
+	for i, chain := range chains {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(chain.Resource)
+		for _, flow := range []struct {
+			name    string
+			entries []PolicyChainEntry
+		}{{"Request", chain.Request}, {"Response", chain.Response}, {"Fault", chain.Fault}} {
+			fmt.Printf("  %s: %d\n", flow.name, len(flow.entries))
+			for j, entry := range flow.entries {
+				source := entry.Source
+				if source == "" {
+					source = "unresolved"
+				}
+				fmt.Printf("    %d. %s %s (%s, %s)\n", j+1, entry.Name, entry.Version, entry.AttachedTo, source)
+				for _, warning := range entry.Warnings {
+					fmt.Println("       WARNING: " + warning)
+				}
+			}
+		}
+	}
+}
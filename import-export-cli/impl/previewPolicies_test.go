@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPreviewPolicies(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTestFile(t, filepath.Join(projectPath, "api.yaml"), `type: api
+data:
+  name: PetStoreAPI
+  version: 1.0.0
+  apiPolicies:
+    request:
+      - policyName: addHeader
+        policyVersion: v1
+        parameters:
+          headerName: X-Source
+    response:
+      - policyName: removeHeader
+        policyVersion: v1
+  operations:
+    - target: /pets
+      verb: GET
+      operationPolicies:
+        request:
+          - policyName: rewritePath
+            policyVersion: v1
+          - policyName: addHeader
+            policyVersion: v1
+        response:
+          - policyName: logPayload
+            policyVersion: v2
+    - target: /pets
+      verb: POST
+`)
+	writeTestFile(t, filepath.Join(projectPath, utils.InitProjectSequences, "rewritePath_v1.yaml"),
+		`type: operation_policy_specification
+data:
+  name: rewritePath
+  version: v1
+  displayName: Rewrite Path
+  applicableFlows:
+    - request
+`)
+	commonPolicies := []utils.APIPolicy{
+		{Name: "addHeader", Version: "v1", DisplayName: "Add Header", ApplicableFlows: []string{"request", "response"},
+			PolicyAttributes: []utils.PolicyAttribute{{Name: "headerName", Required: true}}},
+		{Name: "removeHeader", Version: "v1", ApplicableFlows: []string{"request"}},
+	}
+
+	chains, err := previewPolicies(projectPath, "get  /pets", commonPolicies)
+	assert.Nil(t, err)
+	assert.Len(t, chains, 1)
+	chain := chains[0]
+	assert.Equal(t, "GET /pets", chain.Resource)
+
+	assert.Len(t, chain.Request, 3)
+	assert.Equal(t, "addHeader", chain.Request[0].Name)
+	assert.Equal(t, "API", chain.Request[0].AttachedTo)
+	assert.Equal(t, policySourceCommon, chain.Request[0].Source)
+	assert.Empty(t, chain.Request[0].Warnings)
+	assert.Equal(t, "rewritePath", chain.Request[1].Name)
+	assert.Equal(t, "GET /pets", chain.Request[1].AttachedTo)
+	assert.Equal(t, policySourceAPI, chain.Request[1].Source)
+	assert.Equal(t, "Rewrite Path", chain.Request[1].DisplayName)
+	assert.Equal(t, []string{"required parameter headerName is not given",
+		"policy is already applied earlier in the flow"}, chain.Request[2].Warnings)
+
+	assert.Len(t, chain.Response, 2)
+	assert.Equal(t, "logPayload", chain.Response[0].Name)
+	assert.Empty(t, chain.Response[0].Source)
+	assert.Len(t, chain.Response[0].Warnings, 1)
+	assert.Equal(t, "removeHeader", chain.Response[1].Name)
+	assert.Equal(t, []string{"policy is not applicable to the response flow"}, chain.Response[1].Warnings)
+	assert.Empty(t, chain.Fault)
+}
+
+func TestPreviewPoliciesAllResources(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTestFile(t, filepath.Join(projectPath, "api.yaml"), `type: api
+data:
+  operations:
+    - target: /pets
+      verb: GET
+    - target: /pets
+      verb: POST
+`)
+	chains, err := previewPolicies(projectPath, "", nil)
+	assert.Nil(t, err)
+	assert.Len(t, chains, 2)
+	assert.Equal(t, "POST /pets", chains[1].Resource)
+
+	_, err = previewPolicies(projectPath, "DELETE /pets", nil)
+	assert.NotNil(t, err)
+}
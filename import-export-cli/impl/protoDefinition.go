@@ -0,0 +1,159 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Prefix of the imports provided by the protocol buffers runtime, which are not packaged with the API
+const protoRuntimeImportPrefix = "google/"
+
+// collectProtoFiles reads the proto file and the files it imports, directly or transitively, relative to the
+// directory of the proto file
+// @param protoPath : Path of the proto file
+// @return map of the contents of the files keyed by their import paths, and the import path of the proto file
+// @return error
+func collectProtoFiles(protoPath string) (map[string][]byte, string, error) {
+	baseDir := filepath.Dir(protoPath)
+	mainFile := filepath.Base(protoPath)
+	files := make(map[string][]byte)
+	pending := []string{mainFile}
+	for len(pending) > 0 {
+		importPath := pending[0]
+		pending = pending[1:]
+		if _, ok := files[importPath]; ok {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(baseDir, filepath.FromSlash(importPath)))
+		if err != nil {
+			if importPath == mainFile {
+				return nil, "", err
+			}
+			return nil, "", fmt.Errorf("imported proto file %s is not found in %s: %w", importPath, baseDir, err)
+		}
+		files[importPath] = content
+		for _, imported := range v2.ProtoImports(content) {
+			if !strings.HasPrefix(imported, protoRuntimeImportPrefix) {
+				pending = append(pending, imported)
+			}
+		}
+	}
+	return files, mainFile, nil
+}
+
+// writeProtoFiles writes the proto files to the proto directory of the project, keeping their import paths
+// @param projectPath : Path to the API project
+// @param files : Contents of the proto files keyed by their import paths
+// @return error
+func writeProtoFiles(projectPath string, files map[string][]byte) error {
+	protoDir := filepath.Join(projectPath, utils.InitProjectDefinitionsProto)
+	for importPath, content := range files {
+		protoPath := filepath.Join(protoDir, filepath.FromSlash(importPath))
+		if err := os.MkdirAll(filepath.Dir(protoPath), os.ModePerm); err != nil {
+			return err
+		}
+		utils.Logln(utils.LogPrefixInfo + "Writing " + protoPath)
+		if err := ioutil.WriteFile(protoPath, content, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packageProtoDefinition packages the proto files in the proto directory of the project into the proto
+// definition archive uploaded with a gRPC API, and removes the directory. The project is left as it is if it
+// does not have a proto directory.
+// @param projectPath : Path to the API project
+// @return error
+func packageProtoDefinition(projectPath string) error {
+	protoDir := filepath.Join(projectPath, utils.InitProjectDefinitionsProto)
+	exists, err := utils.IsDirExists(protoDir)
+	if err != nil || !exists {
+		return err
+	}
+	var protoFiles []string
+	err = filepath.Walk(protoDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".proto") {
+			protoFiles = append(protoFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(protoFiles) == 0 {
+		return fmt.Errorf("no proto files found in %s", protoDir)
+	}
+	sort.Strings(protoFiles)
+
+	archivePath := filepath.Join(projectPath, utils.InitProjectDefinitionsProtoArchive)
+	utils.Logln(utils.LogPrefixInfo + "Packaging the proto files into " + archivePath)
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	archive := zip.NewWriter(archiveFile)
+	for _, protoFile := range protoFiles {
+		err = addFileToArchive(archive, protoDir, protoFile)
+		if err != nil {
+			break
+		}
+	}
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := archiveFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(protoDir)
+}
+
+// addFileToArchive adds the file to the archive with its path relative to baseDir
+func addFileToArchive(archive *zip.Writer, baseDir, path string) error {
+	relativePath, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	writer, err := archive.Create(filepath.ToSlash(relativePath))
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func writeTestProtoFile(t *testing.T, path, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+}
+
+func TestCollectProtoFiles(t *testing.T) {
+	protoDir := t.TempDir()
+	writeTestProtoFile(t, filepath.Join(protoDir, "order_service.proto"), `syntax = "proto3";
+import "google/protobuf/empty.proto";
+import "shop/common/money.proto";
+service OrderService { rpc GetOrder (Money) returns (Money); }`)
+	writeTestProtoFile(t, filepath.Join(protoDir, "shop", "common", "money.proto"), `syntax = "proto3";
+import "shop/common/currency.proto";
+message Money { Currency currency = 1; }`)
+	writeTestProtoFile(t, filepath.Join(protoDir, "shop", "common", "currency.proto"), `syntax = "proto3";
+enum Currency { USD = 0; }`)
+
+	files, mainFile, err := collectProtoFiles(filepath.Join(protoDir, "order_service.proto"))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "order_service.proto", mainFile)
+	assert.Len(t, files, 3, "the imported files should be collected except the runtime imports")
+	assert.Contains(t, files, "shop/common/money.proto")
+	assert.Contains(t, files, "shop/common/currency.proto")
+
+	assert.Nil(t, os.Remove(filepath.Join(protoDir, "shop", "common", "currency.proto")))
+	_, _, err = collectProtoFiles(filepath.Join(protoDir, "order_service.proto"))
+	if assert.NotNil(t, err, "err should not be nil") {
+		assert.Contains(t, err.Error(), "imported proto file shop/common/currency.proto is not found")
+	}
+}
+
+func TestPackageProtoDefinition(t *testing.T) {
+	projectPath := t.TempDir()
+	assert.Nil(t, writeProtoFiles(projectPath, map[string][]byte{
+		"order_service.proto":     []byte(`syntax = "proto3";`),
+		"shop/common/money.proto": []byte(`syntax = "proto3";`),
+	}))
+
+	assert.Nil(t, packageProtoDefinition(projectPath))
+	assert.False(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectDefinitionsProto)),
+		"the proto directory should be removed once packaged")
+	archive, err := zip.OpenReader(filepath.Join(projectPath, utils.InitProjectDefinitionsProtoArchive))
+	if assert.Nil(t, err, "err should be nil") {
+		defer archive.Close()
+		var names []string
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{"order_service.proto", "shop/common/money.proto"}, names)
+	}
+
+	// Projects without a proto directory (ex: exported gRPC APIs) are left as they are
+	assert.Nil(t, packageProtoDefinition(projectPath))
+	assert.True(t, utils.IsFileExist(filepath.Join(projectPath, utils.InitProjectDefinitionsProtoArchive)))
+}
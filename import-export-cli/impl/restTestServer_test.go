@@ -0,0 +1,137 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// testRequest is a request received by a restTestServer
+type testRequest struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   []byte
+}
+
+// testRoute serves the requests with the method and the path of the route
+type testRoute struct {
+	method  string
+	path    string
+	handler http.HandlerFunc
+}
+
+// restTestServer is a fake REST API of APIM used to test the functions which invoke it. It serves the registered
+// routes, records each request and fails the test on a request to an unknown route.
+type restTestServer struct {
+	*httptest.Server
+	t        *testing.T
+	mutex    sync.Mutex
+	routes   []testRoute
+	requests []testRequest
+}
+
+// newRESTTestServer starts a fake REST API without any routes. The server should be closed by the test.
+func newRESTTestServer(t *testing.T) *restTestServer {
+	s := &restTestServer{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// handle responds to the requests with the method and the path with the status and the body
+func (s *restTestServer) handle(method, path string, status int, body string) *restTestServer {
+	return s.handleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// handleFunc serves the requests with the method and the path with the handler
+func (s *restTestServer) handleFunc(method, path string, handler http.HandlerFunc) *restTestServer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.routes = append(s.routes, testRoute{method: method, path: path, handler: handler})
+	return s
+}
+
+func (s *restTestServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	s.mutex.Lock()
+	s.requests = append(s.requests, testRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query(),
+		header: r.Header.Clone(), body: body})
+	var handler http.HandlerFunc
+	for _, route := range s.routes {
+		if route.method == r.Method && route.path == r.URL.Path {
+			handler = route.handler
+			break
+		}
+	}
+	s.mutex.Unlock()
+
+	if handler == nil {
+		s.t.Errorf("Unexpected request '%s %s'\n", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	handler(w, r)
+}
+
+// requestsTo returns the requests received with the method and the path in the order they were received
+func (s *restTestServer) requestsTo(method, path string) []testRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var requests []testRequest
+	for _, request := range s.requests {
+		if request.method == method && request.path == path {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// requestCount returns the number of requests received
+func (s *restTestServer) requestCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.requests)
+}
+
+// lastRequestJSON unmarshals the body of the last request received with the method and the path into v. It
+// returns false if no such request was received.
+func (s *restTestServer) lastRequestJSON(method, path string, v interface{}) bool {
+	requests := s.requestsTo(method, path)
+	if len(requests) == 0 {
+		return false
+	}
+	body := requests[len(requests)-1].body
+	if err := json.Unmarshal(body, v); err != nil {
+		s.t.Errorf("Unexpected body of '%s %s': %s\n", method, path, string(body))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const apiIsDefaultVersionKey = "isDefaultVersion"
+
+// RouteTrafficBetweenAPIVersions routes the traffic of the default version of an API from one version to another.
+// The API Manager gateway does not support splitting the traffic between the versions of an API by weight, hence
+// only routing all the traffic to the new version (100 percent) or back to the old version (0 percent) is supported,
+// which is done by switching the default version of the API.
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param fromVersion	: Version of the API the traffic is routed from
+// @param toVersion		: Version of the API the traffic is routed to
+// @param provider		: Provider of the API
+// @param percent		: Percentage of the traffic routed to toVersion
+// @return version which receives the traffic of the default version
+// @return error
+func RouteTrafficBetweenAPIVersions(accessToken, environment, apiName, fromVersion, toVersion, provider string,
+	percent int) (string, error) {
+	if percent != 0 && percent != 100 {
+		return "", fmt.Errorf("weighted routing between the versions of an API is not supported by the gateway. "+
+			"Use --percent 100 to route the traffic to %s or --percent 0 to route the traffic back to %s",
+			toVersion, fromVersion)
+	}
+	fromAPIId, err := GetAPIId(accessToken, environment, apiName, fromVersion, provider)
+	if err != nil {
+		return "", err
+	}
+	toAPIId, err := GetAPIId(accessToken, environment, apiName, toVersion, provider)
+	if err != nil {
+		return "", err
+	}
+	defaultVersion, defaultAPIId := toVersion, toAPIId
+	if percent == 0 {
+		defaultVersion, defaultAPIId = fromVersion, fromAPIId
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + defaultAPIId
+	return defaultVersion, setAPIAsDefaultVersion(url, accessToken)
+}
+
+// SetDefaultAPIVersion marks a version of an API as the default version, which makes API Manager unmark the previous
+// default version of the API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param version		: Version of the API to mark as the default version
+// @param provider		: Provider of the API
+// @return error
+func SetDefaultAPIVersion(accessToken, environment, apiName, version, provider string) error {
+	apiId, err := GetAPIId(accessToken, environment, apiName, version, provider)
+	if err != nil {
+		return err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId
+	return setAPIAsDefaultVersion(url, accessToken)
+}
+
+// setAPIAsDefaultVersion marks an API as the default version, which makes API Manager unmark the previous default
+// version of the API
+// @param url			: URL of the API in the publisher
+// @param accessToken	: Access Token for the environment
+// @return error
+func setAPIAsDefaultVersion(url, accessToken string) error {
+	utils.Logln(utils.LogPrefixInfo+"SetAPIAsDefaultVersion: URL:", url)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for retrieving the API. Status: " + resp.Status())
+	}
+
+	// The API is updated as a generic map, so that the fields unknown to apictl are sent back as they are
+	var api map[string]interface{}
+	if err = json.Unmarshal(resp.Body(), &api); err != nil {
+		return err
+	}
+	if isDefault, _ := api[apiIsDefaultVersionKey].(bool); isDefault {
+		utils.Logln(utils.LogPrefixInfo + "The API is already the default version")
+		return nil
+	}
+	api[apiIsDefaultVersionKey] = true
+	body, err := json.Marshal(api)
+	if err != nil {
+		return err
+	}
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	resp, err = utils.InvokePUTRequestWithoutQueryParams(url, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for updating the default version of the API. Status: " +
+			resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
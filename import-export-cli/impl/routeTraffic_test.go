@@ -0,0 +1,77 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestSetAPIAsDefaultVersion(t *testing.T) {
+	var updatedAPI map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name": "PizzaShackAPI", "version": "2.0.0", "isDefaultVersion": false,
+				"businessInformation": {"businessOwner": "admin"}}`))
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &updatedAPI); err != nil {
+				t.Errorf("Unexpected API in the request body: %s\n", string(body))
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method '%s'\n", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	err := setAPIAsDefaultVersion(server.URL, "access-token")
+	assert.Nil(t, err)
+	assert.Equal(t, true, updatedAPI["isDefaultVersion"])
+	assert.Equal(t, map[string]interface{}{"businessOwner": "admin"}, updatedAPI["businessInformation"],
+		"Fields of the API should be sent back as they are")
+}
+
+func TestSetAPIAsDefaultVersionAlreadyDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected method '%s', got '%s'\n", http.MethodGet, r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name": "PizzaShackAPI", "version": "2.0.0", "isDefaultVersion": true}`))
+	}))
+	defer server.Close()
+
+	err := setAPIAsDefaultVersion(server.URL, "access-token")
+	assert.Nil(t, err)
+}
+
+func TestRouteTrafficBetweenAPIVersionsWeighted(t *testing.T) {
+	_, err := RouteTrafficBetweenAPIVersions("access-token", "dev", "PizzaShackAPI", "1.0.0", "2.0.0", "", 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,245 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	scopeBindingScopeHeader       = "SCOPE"
+	scopeBindingDisplayNameHeader = "DISPLAY_NAME"
+	scopeBindingSharedHeader      = "SHARED"
+	scopeBindingRolesHeader       = "ROLES"
+
+	defaultScopeBindingTableFormat = "table {{.Scope}}\t{{.DisplayName}}\t{{.Shared}}\t{{.Roles}}"
+
+	apiScopesKey        = "scopes"
+	apiScopeKey         = "scope"
+	apiScopeNameKey     = "name"
+	apiScopeBindingsKey = "bindings"
+	apiScopeSharedKey   = "shared"
+)
+
+// ScopeBinding holds the roles bound to a scope of an API
+type ScopeBinding struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName"`
+	Shared      bool     `json:"shared"`
+	Roles       []string `json:"roles"`
+}
+
+// scopeBinding struct holds the roles bound to a scope for outputting
+type scopeBinding struct {
+	binding ScopeBinding
+}
+
+// Scope name
+func (s scopeBinding) Scope() string {
+	return s.binding.Name
+}
+
+// DisplayName of the scope
+func (s scopeBinding) DisplayName() string {
+	return s.binding.DisplayName
+}
+
+// Shared is true if the scope is a shared scope
+func (s scopeBinding) Shared() string {
+	return strconv.FormatBool(s.binding.Shared)
+}
+
+// Roles bound to the scope
+func (s scopeBinding) Roles() string {
+	return strings.Join(s.binding.Roles, ",")
+}
+
+// MarshalJSON marshals scopeBinding using custom marshaller which uses methods instead of fields
+func (s *scopeBinding) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(s)
+}
+
+// apiScope is a scope of the API as given in the scopes of the API in the Publisher
+type apiScope struct {
+	Scope struct {
+		Name        string   `json:"name"`
+		DisplayName string   `json:"displayName"`
+		Bindings    []string `json:"bindings"`
+	} `json:"scope"`
+	Shared bool `json:"shared"`
+}
+
+// GetScopeBindingsFromEnv retrieves the roles bound to each scope of an API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @return scope bindings of the API, error
+func GetScopeBindingsFromEnv(accessToken, environment, apiName, apiVersion, provider string) ([]ScopeBinding,
+	error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return nil, err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId
+	return getScopeBindings(url, accessToken)
+}
+
+// getScopeBindings retrieves the roles bound to each scope of an API
+// @param url			: URL of the API in the publisher
+// @param accessToken	: Access Token for the environment
+// @return scope bindings of the API, error
+func getScopeBindings(url, accessToken string) ([]ScopeBinding, error) {
+	api, err := getRESTResource(url, accessToken, "API")
+	if err != nil {
+		return nil, err
+	}
+	// The scopes are re-marshalled into typed structs as the API is retrieved as a generic map
+	content, err := json.Marshal(api[apiScopesKey])
+	if err != nil {
+		return nil, err
+	}
+	var scopes []apiScope
+	if err = json.Unmarshal(content, &scopes); err != nil {
+		return nil, err
+	}
+	bindings := make([]ScopeBinding, 0, len(scopes))
+	for _, scope := range scopes {
+		roles := scope.Scope.Bindings
+		if roles == nil {
+			roles = []string{}
+		}
+		bindings = append(bindings, ScopeBinding{Name: scope.Scope.Name, DisplayName: scope.Scope.DisplayName,
+			Shared: scope.Shared, Roles: roles})
+	}
+	return bindings, nil
+}
+
+// SetScopeBindingFromEnv replaces the roles bound to a scope of an API
+// @param accessToken	: Access Token for the environment
+// @param environment	: Environment of the API
+// @param apiName		: Name of the API
+// @param apiVersion	: Version of the API
+// @param provider		: Provider of the API
+// @param scopeName		: Name of the scope
+// @param roles			: Roles to bind to the scope. The scope is not restricted to any role when empty
+// @return error
+func SetScopeBindingFromEnv(accessToken, environment, apiName, apiVersion, provider, scopeName string,
+	roles []string) error {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return err
+	}
+	url := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId
+	return setScopeBinding(url, accessToken, scopeName, roles)
+}
+
+// setScopeBinding replaces the roles bound to a scope of an API. The roles of a shared scope are not updated, as
+// they are managed in the shared scope itself and not in the APIs using it.
+// @param url			: URL of the API in the publisher
+// @param accessToken	: Access Token for the environment
+// @param scopeName		: Name of the scope
+// @param roles			: Roles to bind to the scope
+// @return error
+func setScopeBinding(url, accessToken, scopeName string, roles []string) error {
+	api, err := getRESTResource(url, accessToken, "API")
+	if err != nil {
+		return err
+	}
+	scopes, _ := api[apiScopesKey].([]interface{})
+	found := false
+	for _, item := range scopes {
+		scope, _ := item.(map[string]interface{})
+		scopeInfo, _ := scope[apiScopeKey].(map[string]interface{})
+		if scopeInfo == nil || scopeInfo[apiScopeNameKey] != scopeName {
+			continue
+		}
+		if shared, _ := scope[apiScopeSharedKey].(bool); shared {
+			return fmt.Errorf("%s is a shared scope. The roles of a shared scope should be updated in the "+
+				"shared scopes of the Publisher", scopeName)
+		}
+		scopeInfo[apiScopeBindingsKey] = normalizeRoles(roles)
+		found = true
+	}
+	if !found {
+		return errors.New("scope " + scopeName + " is not defined in the API")
+	}
+	return updateRESTResource(url, accessToken, "API", api)
+}
+
+// normalizeRoles trims the roles and removes the empty and the duplicate roles
+func normalizeRoles(roles []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role != "" && !seen[role] {
+			seen[role] = true
+			normalized = append(normalized, role)
+		}
+	}
+	return normalized
+}
+
+// PrintScopeBindings prints the roles bound to each scope of an API in the given format
+// @param bindings	Scope bindings of the API
+// @param format	Format type of the output
+func PrintScopeBindings(bindings []ScopeBinding, format string) {
+	if utils.PrintFormattedOutput(bindings, format) {
+		return
+	}
+	format = utils.ResolveTableFormat(format, defaultScopeBindingTableFormat)
+	// create scope binding context with standard output
+	scopeBindingContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, b := range bindings {
+			if err := t.Execute(w, &scopeBinding{b}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	scopeBindingTableHeaders := map[string]string{
+		"Scope":       scopeBindingScopeHeader,
+		"DisplayName": scopeBindingDisplayNameHeader,
+		"Shared":      scopeBindingSharedHeader,
+		"Roles":       scopeBindingRolesHeader,
+	}
+
+	// execute context
+	if err := scopeBindingContext.Write(renderer, scopeBindingTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const scopeBindingsTestAPI = `{"name": "PetstoreAPI", "version": "1.0.0", "scopes": [
+	{"scope": {"name": "read:pets", "displayName": "Read pets", "bindings": ["admin"]}, "shared": false},
+	{"scope": {"name": "write:pets", "displayName": "Write pets", "bindings": null}, "shared": false},
+	{"scope": {"name": "org:admin", "displayName": "Org admin", "bindings": ["admin"]}, "shared": true}]}`
+
+func newScopeBindingsTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/", http.StatusOK, scopeBindingsTestAPI).
+		handle(http.MethodPut, "/", http.StatusOK, "")
+}
+
+func TestGetScopeBindings(t *testing.T) {
+	server := newScopeBindingsTestServer(t)
+	defer server.Close()
+
+	bindings, err := getScopeBindings(server.URL, "access-token")
+	assert.Nil(t, err)
+	assert.Equal(t, []ScopeBinding{
+		{Name: "read:pets", DisplayName: "Read pets", Roles: []string{"admin"}},
+		{Name: "write:pets", DisplayName: "Write pets", Roles: []string{}},
+		{Name: "org:admin", DisplayName: "Org admin", Shared: true, Roles: []string{"admin"}},
+	}, bindings)
+	assert.Equal(t, "admin", scopeBinding{bindings[0]}.Roles())
+}
+
+func TestSetScopeBinding(t *testing.T) {
+	server := newScopeBindingsTestServer(t)
+	defer server.Close()
+
+	err := setScopeBinding(server.URL, "access-token", "write:pets", []string{"admin", " dev", "admin", ""})
+	assert.Nil(t, err)
+	var updatedAPI map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/", &updatedAPI))
+	scopes := updatedAPI["scopes"].([]interface{})
+	assert.Equal(t, []interface{}{"admin", "dev"},
+		scopes[1].(map[string]interface{})["scope"].(map[string]interface{})["bindings"])
+	assert.Equal(t, []interface{}{"admin"},
+		scopes[0].(map[string]interface{})["scope"].(map[string]interface{})["bindings"],
+		"Roles of the other scopes should not be changed")
+	assert.Equal(t, "PetstoreAPI", updatedAPI["name"], "Fields of the API should be sent back as they are")
+}
+
+func TestSetScopeBindingErrors(t *testing.T) {
+	server := newScopeBindingsTestServer(t)
+	defer server.Close()
+
+	err := setScopeBinding(server.URL, "access-token", "delete:pets", []string{"admin"})
+	assert.Error(t, err, "Should fail for a scope not defined in the API")
+	err = setScopeBinding(server.URL, "access-token", "org:admin", []string{"dev"})
+	assert.Error(t, err, "Should fail for a shared scope")
+	assert.Empty(t, server.requestsTo(http.MethodPut, "/"), "API should not be updated")
+}
@@ -0,0 +1,403 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	seedAPINameInfix         = "-api-"
+	seedApplicationNameInfix = "-app-"
+	seedResourcesLimit       = "1000"
+)
+
+// SeedTemplate holds the templates of the sample resources created by the seed command
+type SeedTemplate struct {
+	API         SeedAPITemplate         `yaml:"api"`
+	Application SeedApplicationTemplate `yaml:"application"`
+}
+
+// SeedAPITemplate holds the template of the sample APIs
+type SeedAPITemplate struct {
+	Version     string             `yaml:"version"`
+	EndpointURL string             `yaml:"endpointUrl"`
+	Policies    []string           `yaml:"policies"`
+	Operations  []SeedAPIOperation `yaml:"operations"`
+	// GatewayEnvironment to deploy the sample APIs. The APIs are not deployed if it is empty
+	GatewayEnvironment string `yaml:"gatewayEnvironment"`
+	Vhost              string `yaml:"vhost"`
+}
+
+// SeedAPIOperation holds an operation of the sample APIs
+type SeedAPIOperation struct {
+	Target string `yaml:"target"`
+	Verb   string `yaml:"verb"`
+}
+
+// SeedApplicationTemplate holds the template of the sample applications
+type SeedApplicationTemplate struct {
+	ThrottlingPolicy string `yaml:"throttlingPolicy"`
+	TokenType        string `yaml:"tokenType"`
+}
+
+// SeedResult holds the names of the sample resources created or removed by the seed command
+type SeedResult struct {
+	APIs          []string
+	Applications  []string
+	Subscriptions int
+}
+
+// seedEndpoints holds the REST API endpoints used to seed an environment
+type seedEndpoints struct {
+	apis          string
+	applications  string
+	subscriptions string
+}
+
+// DefaultSeedTemplate returns the template used when no template file is given
+func DefaultSeedTemplate() *SeedTemplate {
+	return &SeedTemplate{
+		API: SeedAPITemplate{
+			Version:     "1.0.0",
+			EndpointURL: "https://httpbin.org/anything",
+			Policies:    []string{"Unlimited"},
+			Operations: []SeedAPIOperation{
+				{Target: "/*", Verb: http.MethodGet},
+				{Target: "/*", Verb: http.MethodPost},
+			},
+			GatewayEnvironment: "Default",
+			Vhost:              "localhost",
+		},
+		Application: SeedApplicationTemplate{
+			ThrottlingPolicy: "Unlimited",
+			TokenType:        "JWT",
+		},
+	}
+}
+
+// LoadSeedTemplate reads the seed template from the given file. The values missing in the file are taken from
+// the default template.
+// @param filePath : Path to the template file
+// @return template, error
+func LoadSeedTemplate(filePath string) (*SeedTemplate, error) {
+	template := DefaultSeedTemplate()
+	if filePath == "" {
+		return template, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, template); err != nil {
+		return nil, fmt.Errorf("error parsing the seed template %s: %v", filePath, err)
+	}
+	if len(template.API.Policies) == 0 {
+		return nil, errors.New("at least one subscription policy should be given for the APIs in the seed template")
+	}
+	return template, nil
+}
+
+// SeedEnv creates sample APIs and applications in the environment and optionally subscribes each application to
+// each API. The resources are named with the given prefix so that they can be removed with CleanupSeededEnv.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to seed
+// @param prefix : Prefix of the names of the sample resources
+// @param apiCount : Number of APIs to create
+// @param appCount : Number of applications to create
+// @param subscribe : Whether to subscribe the applications to the APIs
+// @param template : Template of the sample resources
+// @return result, error
+func SeedEnv(accessToken, environment, prefix string, apiCount, appCount int, subscribe bool,
+	template *SeedTemplate) (*SeedResult, error) {
+	return seed(getSeedEndpoints(environment), accessToken, prefix, apiCount, appCount, subscribe, template)
+}
+
+// CleanupSeededEnv removes the sample APIs and applications created with the given prefix from the environment.
+// The subscriptions are removed along with the applications.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to clean up
+// @param prefix : Prefix of the names of the sample resources
+// @return result, error
+func CleanupSeededEnv(accessToken, environment, prefix string) (*SeedResult, error) {
+	return cleanupSeed(getSeedEndpoints(environment), accessToken, prefix)
+}
+
+func getSeedEndpoints(environment string) seedEndpoints {
+	return seedEndpoints{
+		apis:          utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		applications:  utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		subscriptions: utils.GetDevPortalSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath),
+	}
+}
+
+func seed(endpoints seedEndpoints, accessToken, prefix string, apiCount, appCount int, subscribe bool,
+	template *SeedTemplate) (*SeedResult, error) {
+	result := &SeedResult{}
+	var apiIds, appIds []string
+	for i := 1; i <= apiCount; i++ {
+		name := prefix + seedAPINameInfix + strconv.Itoa(i)
+		apiId, err := createSeedAPI(endpoints.apis, accessToken, name, template.API)
+		if err != nil {
+			return result, fmt.Errorf("error creating the API %s: %v", name, err)
+		}
+		apiIds = append(apiIds, apiId)
+		result.APIs = append(result.APIs, name)
+	}
+	for i := 1; i <= appCount; i++ {
+		name := prefix + seedApplicationNameInfix + strconv.Itoa(i)
+		appId, err := createSeedApplication(endpoints.applications, accessToken, name, template.Application)
+		if err != nil {
+			return result, fmt.Errorf("error creating the application %s: %v", name, err)
+		}
+		appIds = append(appIds, appId)
+		result.Applications = append(result.Applications, name)
+	}
+	if !subscribe {
+		return result, nil
+	}
+	for i, appId := range appIds {
+		for j, apiId := range apiIds {
+			err := createSeedSubscription(endpoints.subscriptions, accessToken, appId, apiId, template.API.Policies[0])
+			if err != nil {
+				return result, fmt.Errorf("error subscribing the application %s to the API %s: %v",
+					result.Applications[i], result.APIs[j], err)
+			}
+			result.Subscriptions++
+		}
+	}
+	return result, nil
+}
+
+// createSeedAPI creates an API, deploys it if a gateway environment is given in the template and publishes it
+func createSeedAPI(apisEndpoint, accessToken, name string, template SeedAPITemplate) (string, error) {
+	operations := make([]map[string]interface{}, 0, len(template.Operations))
+	for _, operation := range template.Operations {
+		operations = append(operations, map[string]interface{}{
+			"target":   operation.Target,
+			"verb":     strings.ToUpper(operation.Verb),
+			"authType": "Application & Application User",
+		})
+	}
+	endpoint := map[string]string{"url": template.EndpointURL}
+	body := map[string]interface{}{
+		"name":     name,
+		"context":  "/" + name,
+		"version":  template.Version,
+		"policies": template.Policies,
+		"tags":     []string{"apictl-seed"},
+		"endpointConfig": map[string]interface{}{
+			"endpoint_type":        "http",
+			"production_endpoints": endpoint,
+			"sandbox_endpoints":    endpoint,
+		},
+		"operations": operations,
+	}
+	utils.Logln(utils.LogPrefixInfo+"Creating the API", name)
+	resp, err := utils.InvokePOSTRequest(apisEndpoint, getSeedHeaders(accessToken), body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return "", getSeedResponseError(resp)
+	}
+	api := &utils.API{}
+	if err := json.Unmarshal(resp.Body(), api); err != nil {
+		return "", err
+	}
+
+	if template.GatewayEnvironment != "" {
+		if err := deploySeedAPI(apisEndpoint, accessToken, api.ID, template); err != nil {
+			return api.ID, err
+		}
+	}
+
+	lifecycleURL := utils.AppendSlashToString(apisEndpoint) + "change-lifecycle"
+	queryParams := map[string]string{
+		utils.LifeCycleAction: "Publish",
+		utils.ApiId:           api.ID,
+	}
+	resp, err = utils.InvokePOSTRequestWithQueryParam(queryParams, lifecycleURL, getSeedHeaders(accessToken), "")
+	if err != nil {
+		return api.ID, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return api.ID, getSeedResponseError(resp)
+	}
+	return api.ID, nil
+}
+
+// deploySeedAPI creates a revision of the API and deploys it to the gateway environment given in the template
+func deploySeedAPI(apisEndpoint, accessToken, apiId string, template SeedAPITemplate) error {
+	revisionsURL := utils.AppendSlashToString(apisEndpoint) + apiId + "/revisions"
+	resp, err := utils.InvokePOSTRequest(revisionsURL, getSeedHeaders(accessToken),
+		map[string]string{"description": "Created by " + utils.ProjectName + " seed"})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return getSeedResponseError(resp)
+	}
+	revision := &utils.Revisions{}
+	if err := json.Unmarshal(resp.Body(), revision); err != nil {
+		return err
+	}
+
+	deployURL := utils.AppendSlashToString(apisEndpoint) + apiId + "/deploy-revision?revisionId=" +
+		url.QueryEscape(revision.ID)
+	deployments := []map[string]interface{}{{
+		"name":               template.GatewayEnvironment,
+		"vhost":              template.Vhost,
+		"displayOnDevportal": true,
+	}}
+	resp, err = utils.InvokePOSTRequest(deployURL, getSeedHeaders(accessToken), deployments)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return getSeedResponseError(resp)
+	}
+	return nil
+}
+
+func createSeedApplication(applicationsEndpoint, accessToken, name string,
+	template SeedApplicationTemplate) (string, error) {
+	body := map[string]string{
+		"name":             name,
+		"throttlingPolicy": template.ThrottlingPolicy,
+		"tokenType":        template.TokenType,
+		"description":      "Created by " + utils.ProjectName + " seed",
+	}
+	utils.Logln(utils.LogPrefixInfo+"Creating the application", name)
+	resp, err := utils.InvokePOSTRequest(applicationsEndpoint, getSeedHeaders(accessToken), body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return "", getSeedResponseError(resp)
+	}
+	application := &utils.Application{}
+	if err := json.Unmarshal(resp.Body(), application); err != nil {
+		return "", err
+	}
+	return application.ID, nil
+}
+
+func createSeedSubscription(subscriptionsEndpoint, accessToken, appId, apiId, policy string) error {
+	body := map[string]string{
+		"applicationId":    appId,
+		"apiId":            apiId,
+		"throttlingPolicy": policy,
+	}
+	resp, err := utils.InvokePOSTRequest(subscriptionsEndpoint, getSeedHeaders(accessToken), body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return getSeedResponseError(resp)
+	}
+	return nil
+}
+
+func cleanupSeed(endpoints seedEndpoints, accessToken, prefix string) (*SeedResult, error) {
+	result := &SeedResult{}
+	// The applications are removed first, so that the subscriptions to the APIs are removed along with them
+	resp, err := utils.InvokeGETRequest(endpoints.applications+"?query="+url.QueryEscape(prefix)+
+		"&limit="+seedResourcesLimit, getSeedHeaders(accessToken))
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return result, getSeedResponseError(resp)
+	}
+	applications := &utils.ApplicationListResponse{}
+	if err := json.Unmarshal(resp.Body(), applications); err != nil {
+		return result, err
+	}
+	for _, application := range applications.List {
+		if !strings.HasPrefix(application.Name, prefix+seedApplicationNameInfix) {
+			continue
+		}
+		err := deleteSeedResource(utils.AppendSlashToString(endpoints.applications)+application.ID, accessToken)
+		if err != nil {
+			return result, fmt.Errorf("error removing the application %s: %v", application.Name, err)
+		}
+		result.Applications = append(result.Applications, application.Name)
+	}
+
+	resp, err = utils.InvokeGETRequest(endpoints.apis+"?query="+url.QueryEscape("name:"+prefix+seedAPINameInfix)+
+		"&limit="+seedResourcesLimit, getSeedHeaders(accessToken))
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return result, getSeedResponseError(resp)
+	}
+	apis := &utils.APIListResponse{}
+	if err := json.Unmarshal(resp.Body(), apis); err != nil {
+		return result, err
+	}
+	for _, api := range apis.List {
+		if !strings.HasPrefix(api.Name, prefix+seedAPINameInfix) {
+			continue
+		}
+		err := deleteSeedResource(utils.AppendSlashToString(endpoints.apis)+api.ID, accessToken)
+		if err != nil {
+			return result, fmt.Errorf("error removing the API %s: %v", api.Name, err)
+		}
+		result.APIs = append(result.APIs, api.Name)
+	}
+	return result, nil
+}
+
+func deleteSeedResource(resourceURL, accessToken string) error {
+	utils.Logln(utils.LogPrefixInfo+"Removing", resourceURL)
+	resp, err := utils.InvokeDELETERequest(resourceURL, getSeedHeaders(accessToken))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return getSeedResponseError(resp)
+	}
+	return nil
+}
+
+func getSeedHeaders(accessToken string) map[string]string {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	return headers
+}
+
+func getSeedResponseError(resp *resty.Response) error {
+	utils.Logf("Body: %s\n", resp.Body())
+	return errors.New(strconv.Itoa(resp.StatusCode()) + ":<" + string(resp.Body()) + ">")
+}
@@ -0,0 +1,173 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSeedTemplate(t *testing.T) {
+	template, err := LoadSeedTemplate("")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultSeedTemplate(), template)
+
+	templatePath := filepath.Join(t.TempDir(), "seed-template.yaml")
+	assert.Nil(t, ioutil.WriteFile(templatePath, []byte(`api:
+  version: 2.0.0
+  policies:
+    - Gold
+  gatewayEnvironment: ""
+`), 0644))
+	template, err = LoadSeedTemplate(templatePath)
+	assert.Nil(t, err)
+	assert.Equal(t, "2.0.0", template.API.Version)
+	assert.Equal(t, []string{"Gold"}, template.API.Policies)
+	assert.Equal(t, "", template.API.GatewayEnvironment)
+	assert.Equal(t, DefaultSeedTemplate().API.EndpointURL, template.API.EndpointURL,
+		"Values missing in the template file should be taken from the default template")
+	assert.Equal(t, DefaultSeedTemplate().Application, template.Application)
+}
+
+func TestSeed(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []string
+	var subscriptions []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.URL.Path == "/apis":
+			api := map[string]interface{}{}
+			assert.Nil(t, json.Unmarshal(body, &api))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "id-` + api["name"].(string) + `"}`))
+		case r.URL.Path == "/apis/change-lifecycle":
+			assert.Equal(t, "Publish", r.URL.Query().Get("action"))
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/applications":
+			application := map[string]string{}
+			assert.Nil(t, json.Unmarshal(body, &application))
+			assert.Equal(t, "Unlimited", application["throttlingPolicy"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"applicationId": "id-` + application["name"] + `"}`))
+		case r.URL.Path == "/subscriptions":
+			subscription := map[string]string{}
+			assert.Nil(t, json.Unmarshal(body, &subscription))
+			subscriptions = append(subscriptions, subscription)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	template := DefaultSeedTemplate()
+	template.API.GatewayEnvironment = ""
+	endpoints := seedEndpoints{
+		apis:          server.URL + "/apis",
+		applications:  server.URL + "/applications",
+		subscriptions: server.URL + "/subscriptions",
+	}
+	result, err := seed(endpoints, "token", "demo", 2, 3, true, template)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"demo-api-1", "demo-api-2"}, result.APIs)
+	assert.Equal(t, []string{"demo-app-1", "demo-app-2", "demo-app-3"}, result.Applications)
+	assert.Equal(t, 6, result.Subscriptions)
+	assert.Equal(t, 6, len(subscriptions))
+	assert.Equal(t, map[string]string{"applicationId": "id-demo-app-1", "apiId": "id-demo-api-1",
+		"throttlingPolicy": "Unlimited"}, subscriptions[0])
+	for _, request := range requests {
+		assert.False(t, strings.Contains(request, "revision"), "APIs should not be deployed without a gateway environment")
+	}
+}
+
+func TestSeedDeploysAPIs(t *testing.T) {
+	var deployments []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/apis":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "123"}`))
+		case "/apis/123/revisions":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "rev-1"}`))
+		case "/apis/123/deploy-revision":
+			assert.Equal(t, "rev-1", r.URL.Query().Get("revisionId"))
+			assert.Nil(t, json.Unmarshal(body, &deployments))
+			w.WriteHeader(http.StatusCreated)
+		case "/apis/change-lifecycle":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := seed(seedEndpoints{apis: server.URL + "/apis"}, "token", "demo", 1, 0, false,
+		DefaultSeedTemplate())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"demo-api-1"}, result.APIs)
+	assert.Equal(t, 1, len(deployments))
+	assert.Equal(t, "Default", deployments[0]["name"])
+	assert.Equal(t, "localhost", deployments[0]["vhost"])
+}
+
+func TestCleanupSeed(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		switch r.URL.Path {
+		case "/applications":
+			assert.Equal(t, "demo", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count": 3, "list": [{"applicationId": "a1", "name": "demo-app-1"},
+				{"applicationId": "a2", "name": "demo-application"}, {"applicationId": "a3", "name": "demo-app-2"}]}`))
+		case "/apis":
+			assert.Equal(t, "name:demo-api-", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count": 2, "list": [{"id": "p1", "name": "demo-api-1"},
+				{"id": "p2", "name": "PizzaAPI"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := cleanupSeed(seedEndpoints{apis: server.URL + "/apis", applications: server.URL + "/applications"},
+		"token", "demo")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"demo-app-1", "demo-app-2"}, result.Applications)
+	assert.Equal(t, []string{"demo-api-1"}, result.APIs)
+	assert.Equal(t, []string{"/applications/a1", "/applications/a3", "/apis/p1"}, deleted,
+		"Applications should be removed before the APIs")
+}
@@ -0,0 +1,203 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// EnvironmentSettings holds the features and the capabilities of an environment advertised by the Publisher,
+// DevPortal and Admin settings, so that the commands can adapt to the environment
+type EnvironmentSettings struct {
+	Environment         string   `json:"environment"`
+	APIMVersion         string   `json:"apimVersion,omitempty"`
+	GatewayTypes        []string `json:"gatewayTypes"`
+	GatewayEnvironments []string `json:"gatewayEnvironments"`
+	GrantTypes          []string `json:"grantTypes"`
+	KeyManagerTypes     []string `json:"keyManagerTypes"`
+	AnalyticsEnabled    bool     `json:"analyticsEnabled"`
+	MonetizationEnabled bool     `json:"monetizationEnabled"`
+	ApplicationSharing  bool     `json:"applicationSharingEnabled"`
+	AnonymousDevPortal  bool     `json:"anonymousDevPortalEnabled"`
+	PublisherScopes     []string `json:"publisherScopes"`
+	DevPortalScopes     []string `json:"devPortalScopes"`
+	AdminScopes         []string `json:"adminScopes"`
+}
+
+// SupportsGatewayType returns true if APIs can be deployed to gateways of the given type (ie: Regular, APK)
+func (s *EnvironmentSettings) SupportsGatewayType(gatewayType string) bool {
+	return containsIgnoreCase(s.GatewayTypes, gatewayType)
+}
+
+// SupportsGrantType returns true if applications can generate keys with the given grant type
+func (s *EnvironmentSettings) SupportsGrantType(grantType string) bool {
+	return containsIgnoreCase(s.GrantTypes, grantType)
+}
+
+// devPortalSettings holds the fields of the DevPortal settings response
+type devPortalSettings struct {
+	GrantTypes                []string `json:"grantTypes"`
+	Scopes                    []string `json:"scopes"`
+	ApplicationSharingEnabled bool     `json:"applicationSharingEnabled"`
+	MonetizationEnabled       bool     `json:"monetizationEnabled"`
+	IsAnonymousModeEnabled    bool     `json:"IsAnonymousModeEnabled"`
+}
+
+// adminSettings holds the fields of the Admin settings response
+type adminSettings struct {
+	Scopes                  []string `json:"scopes"`
+	AnalyticsEnabled        bool     `json:"analyticsEnabled"`
+	KeyManagerConfiguration []struct {
+		Type string `json:"type"`
+	} `json:"keyManagerConfiguration"`
+}
+
+// GetSettingsOfEnv retrieves the Publisher, DevPortal and Admin settings of an environment
+// @param accessToken : Access Token for the environment
+// @param environment : Environment to retrieve the settings of
+// @return settings of the environment, error
+func GetSettingsOfEnv(accessToken, environment string) (*EnvironmentSettings, error) {
+	return getEnvironmentSettings(accessToken, environment,
+		utils.AppendSlashToString(utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath))+"settings",
+		utils.GetDevPortalSettingsEndpointOfEnv(environment, utils.MainConfigFilePath),
+		utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath))+"settings")
+}
+
+func getEnvironmentSettings(accessToken, environment, publisherSettingsEndpoint, devPortalSettingsEndpoint,
+	adminSettingsEndpoint string) (*EnvironmentSettings, error) {
+	publisher, err := getPublisherSettings(accessToken, publisherSettingsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	devPortal := &devPortalSettings{}
+	if err = getSettings(accessToken, devPortalSettingsEndpoint, "DevPortal", devPortal); err != nil {
+		return nil, err
+	}
+	admin := &adminSettings{}
+	if err = getSettings(accessToken, adminSettingsEndpoint, "Admin", admin); err != nil {
+		return nil, err
+	}
+
+	settings := &EnvironmentSettings{
+		Environment:         environment,
+		APIMVersion:         publisher.APIMVersion,
+		GatewayTypes:        nonNilStrings(publisher.GatewayTypes),
+		GatewayEnvironments: []string{},
+		GrantTypes:          nonNilStrings(devPortal.GrantTypes),
+		KeyManagerTypes:     []string{},
+		AnalyticsEnabled:    admin.AnalyticsEnabled,
+		MonetizationEnabled: devPortal.MonetizationEnabled,
+		ApplicationSharing:  devPortal.ApplicationSharingEnabled,
+		AnonymousDevPortal:  devPortal.IsAnonymousModeEnabled,
+		PublisherScopes:     nonNilStrings(publisher.Scopes),
+		DevPortalScopes:     nonNilStrings(devPortal.Scopes),
+		AdminScopes:         nonNilStrings(admin.Scopes),
+	}
+	if settings.APIMVersion == "" {
+		settings.APIMVersion = publisher.Version
+	}
+	for _, gatewayEnvironment := range publisher.Environments {
+		settings.GatewayEnvironments = append(settings.GatewayEnvironments, gatewayEnvironment.Name)
+	}
+	for _, keyManager := range admin.KeyManagerConfiguration {
+		settings.KeyManagerTypes = append(settings.KeyManagerTypes, keyManager.Type)
+	}
+	return settings, nil
+}
+
+// getSettings retrieves a settings resource of the Publisher, DevPortal or Admin REST API
+// @param accessToken		: Access Token for the environment
+// @param settingsEndpoint	: Settings endpoint
+// @param portal			: Name of the portal used in the error message
+// @param settings			: Settings to unmarshal the response to
+// @return error
+func getSettings(accessToken, settingsEndpoint, portal string, settings interface{}) error {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	utils.Logln(utils.LogPrefixInfo+"URL:", settingsEndpoint)
+	resp, err := utils.InvokeGETRequest(settingsEndpoint, headers)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Request didn't respond 200 OK for retrieving the " + portal + " settings. Status: " +
+			resp.Status())
+	}
+	return json.Unmarshal(resp.Body(), settings)
+}
+
+// PrintSettings prints the settings of an environment in the given format
+// @param settings : Settings of the environment
+// @param format : Format type of the output
+func PrintSettings(settings *EnvironmentSettings, format string) {
+	if utils.PrintFormattedOutput(settings, format) {
+		return
+	}
+	printSetting("Environment", settings.Environment)
+	printSetting("APIM version", settings.APIMVersion)
+	printSetting("Gateway types", strings.Join(settings.GatewayTypes, ", "))
+	printSetting("Gateway environments", strings.Join(settings.GatewayEnvironments, ", "))
+	printSetting("Grant types", strings.Join(settings.GrantTypes, ", "))
+	printSetting("Key manager types", strings.Join(settings.KeyManagerTypes, ", "))
+	printSetting("Analytics enabled", strconv.FormatBool(settings.AnalyticsEnabled))
+	printSetting("Monetization enabled", strconv.FormatBool(settings.MonetizationEnabled))
+	printSetting("Application sharing enabled", strconv.FormatBool(settings.ApplicationSharing))
+	printSetting("Anonymous DevPortal enabled", strconv.FormatBool(settings.AnonymousDevPortal))
+	printScopes("Publisher scopes", settings.PublisherScopes)
+	printScopes("DevPortal scopes", settings.DevPortalScopes)
+	printScopes("Admin scopes", settings.AdminScopes)
+}
+
+func printSetting(name, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Printf("%-28s %s\n", name+":", value)
+}
+
+func printScopes(title string, scopes []string) {
+	fmt.Println("\n" + title + ":")
+	for _, scope := range scopes {
+		fmt.Println("  " + scope)
+	}
+}
+
+func containsIgnoreCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnvironmentSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/publisher/settings":
+			_, _ = w.Write([]byte(`{"apimVersion": "4.3.0", "gatewayTypes": ["Regular", "APK"],
+				"environment": [{"name": "Default"}, {"name": "us-region"}], "scopes": ["apim:api_create"]}`))
+		case "/devportal/settings":
+			_, _ = w.Write([]byte(`{"grantTypes": ["client_credentials", "password"], "scopes": ["apim:subscribe"],
+				"applicationSharingEnabled": true, "IsAnonymousModeEnabled": true}`))
+		case "/admin/settings":
+			_, _ = w.Write([]byte(`{"analyticsEnabled": true, "scopes": ["apim:admin"],
+				"keyManagerConfiguration": [{"type": "default"}, {"type": "Okta"}]}`))
+		case "/forbidden/settings":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("Unexpected request '%s %s'\n", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	settings, err := getEnvironmentSettings("access-token", "dev", server.URL+"/publisher/settings",
+		server.URL+"/devportal/settings", server.URL+"/admin/settings")
+	assert.Nil(t, err)
+	assert.Equal(t, &EnvironmentSettings{
+		Environment:         "dev",
+		APIMVersion:         "4.3.0",
+		GatewayTypes:        []string{"Regular", "APK"},
+		GatewayEnvironments: []string{"Default", "us-region"},
+		GrantTypes:          []string{"client_credentials", "password"},
+		KeyManagerTypes:     []string{"default", "Okta"},
+		AnalyticsEnabled:    true,
+		ApplicationSharing:  true,
+		AnonymousDevPortal:  true,
+		PublisherScopes:     []string{"apim:api_create"},
+		DevPortalScopes:     []string{"apim:subscribe"},
+		AdminScopes:         []string{"apim:admin"},
+	}, settings)
+	assert.True(t, settings.SupportsGatewayType("apk"))
+	assert.False(t, settings.SupportsGatewayType("Envoy"))
+	assert.True(t, settings.SupportsGrantType("password"))
+	assert.False(t, settings.SupportsGrantType("refresh_token"))
+
+	_, err = getEnvironmentSettings("access-token", "dev", server.URL+"/publisher/settings",
+		server.URL+"/devportal/settings", server.URL+"/forbidden/settings")
+	assert.EqualError(t, err, "Request didn't respond 200 OK for retrieving the Admin settings. Status: 403 Forbidden")
+}
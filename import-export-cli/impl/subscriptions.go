@@ -0,0 +1,216 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// subscriptionEndpoints are the Developer Portal REST API endpoints used to manage subscriptions
+type subscriptionEndpoints struct {
+	apis          string
+	applications  string
+	subscriptions string
+}
+
+func getSubscriptionEndpoints(environment string) subscriptionEndpoints {
+	return subscriptionEndpoints{
+		apis:          utils.GetDevPortalApiListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		applications:  utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		subscriptions: utils.GetDevPortalSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath),
+	}
+}
+
+// ParseAPIIdentifier parses an API given as <name>:<version> into its name and version
+func ParseAPIIdentifier(api string) (name, version string, err error) {
+	parts := strings.Split(api, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid API %s, the API should be given as <name>:<version>", api)
+	}
+	return parts[0], parts[1], nil
+}
+
+// AddSubscription subscribes an application of the user to an API or API Product in the environment with the
+// given subscription tier. If the application is already subscribed to the API, the existing subscription is
+// returned without creating a new one.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the application and the API
+// @param appName : Name of the application
+// @param apiName : Name of the API or API Product
+// @param apiVersion : Version of the API or API Product
+// @param apiProvider : Provider of the API or API Product, optional
+// @param tier : Subscription tier
+// @return subscription, whether the subscription was created, error
+func AddSubscription(accessToken, environment, appName, apiName, apiVersion, apiProvider, tier string) (
+	*utils.Subscription, bool, error) {
+	return addSubscription(getSubscriptionEndpoints(environment), accessToken, appName, apiName, apiVersion,
+		apiProvider, tier)
+}
+
+// DeleteSubscription removes the subscription of an application of the user to an API or API Product in the
+// environment.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the application and the API
+// @param appName : Name of the application
+// @param apiName : Name of the API or API Product
+// @param apiVersion : Version of the API or API Product
+// @param apiProvider : Provider of the API or API Product, optional
+// @return removed subscription, error
+func DeleteSubscription(accessToken, environment, appName, apiName, apiVersion, apiProvider string) (
+	*utils.Subscription, error) {
+	return deleteSubscription(getSubscriptionEndpoints(environment), accessToken, appName, apiName, apiVersion,
+		apiProvider)
+}
+
+func addSubscription(endpoints subscriptionEndpoints, accessToken, appName, apiName, apiVersion, apiProvider,
+	tier string) (*utils.Subscription, bool, error) {
+	appId, apiId, subscription, err := findSubscription(endpoints, accessToken, appName, apiName, apiVersion,
+		apiProvider)
+	if err != nil {
+		return nil, false, err
+	}
+	if subscription != nil {
+		utils.Logln(utils.LogPrefixInfo+"Application", appName, "is already subscribed to the API", apiName)
+		return subscription, false, nil
+	}
+
+	body := &utils.SubscriptionCreateRequest{
+		ApplicationID:    appId,
+		APIID:            apiId,
+		ThrottlingPolicy: tier,
+	}
+	utils.Logln(utils.LogPrefixInfo+"Subscribing the application", appName, "to the API", apiName)
+	resp, err := utils.InvokePOSTRequest(endpoints.subscriptions, getSubscriptionHeaders(accessToken), body)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return nil, false, getSubscriptionResponseError(resp)
+	}
+	subscription = &utils.Subscription{}
+	if err := json.Unmarshal(resp.Body(), subscription); err != nil {
+		return nil, false, err
+	}
+	return subscription, true, nil
+}
+
+func deleteSubscription(endpoints subscriptionEndpoints, accessToken, appName, apiName, apiVersion,
+	apiProvider string) (*utils.Subscription, error) {
+	_, _, subscription, err := findSubscription(endpoints, accessToken, appName, apiName, apiVersion, apiProvider)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, fmt.Errorf("application %s is not subscribed to the API %s:%s", appName, apiName, apiVersion)
+	}
+
+	url := utils.AppendSlashToString(endpoints.subscriptions) + subscription.SubscriptionID
+	utils.Logln(utils.LogPrefixInfo+"DeleteSubscription: URL:", url)
+	resp, err := utils.InvokeDELETERequest(url, getSubscriptionHeaders(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return nil, getSubscriptionResponseError(resp)
+	}
+	return subscription, nil
+}
+
+// findSubscription resolves the IDs of the application and the API and returns the subscription of the
+// application to the API, or nil if the application is not subscribed
+func findSubscription(endpoints subscriptionEndpoints, accessToken, appName, apiName, apiVersion,
+	apiProvider string) (appId, apiId string, subscription *utils.Subscription, err error) {
+	appId, err = getDevPortalAppId(endpoints.applications, accessToken, appName)
+	if err != nil {
+		return "", "", nil, err
+	}
+	apiId, err = findSubscriptionAPIId(endpoints.apis, accessToken, apiName, apiVersion, apiProvider)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	queryParams := map[string]string{"applicationId": appId, utils.ApiId: apiId}
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, endpoints.subscriptions,
+		getSubscriptionHeaders(accessToken))
+	if err != nil {
+		return "", "", nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", "", nil, getSubscriptionResponseError(resp)
+	}
+	subscriptions := &utils.SubscriptionList{}
+	if err := json.Unmarshal(resp.Body(), subscriptions); err != nil {
+		return "", "", nil, err
+	}
+	for i, sub := range subscriptions.List {
+		if sub.ApplicationID == appId && sub.APIID == apiId {
+			return appId, apiId, &subscriptions.List[i], nil
+		}
+	}
+	return appId, apiId, nil, nil
+}
+
+// findSubscriptionAPIId returns the ID of the API or API Product with the given name and version in the
+// Developer Portal
+func findSubscriptionAPIId(apisEndpoint, accessToken, apiName, apiVersion, apiProvider string) (string, error) {
+	query := "name:\"" + apiName + "\" version:\"" + apiVersion + "\""
+	if apiProvider != "" {
+		query += " provider:\"" + apiProvider + "\""
+	}
+	resp, err := utils.InvokeGETRequestWithQueryParam("query", query, apisEndpoint,
+		getSubscriptionHeaders(accessToken))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", getSubscriptionResponseError(resp)
+	}
+	apis := &utils.ApiSearch{}
+	if err := json.Unmarshal(resp.Body(), apis); err != nil {
+		return "", err
+	}
+	// The search matches the names partially, hence the exact match is picked
+	for _, api := range apis.List {
+		if api.Name == apiName && api.Version == apiVersion && (apiProvider == "" || api.Provider == apiProvider) {
+			return api.ID, nil
+		}
+	}
+	return "", errors.New("cannot find the API " + apiName + ":" + apiVersion + " in the Developer Portal")
+}
+
+func getSubscriptionHeaders(accessToken string) map[string]string {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	return headers
+}
+
+func getSubscriptionResponseError(resp *resty.Response) error {
+	utils.Logf("Body: %s\n", resp.Body())
+	return errors.New(strconv.Itoa(resp.StatusCode()) + ":<" + string(resp.Body()) + ">")
+}
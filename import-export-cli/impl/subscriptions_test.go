@@ -0,0 +1,138 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// newSubscriptionsTestServer serves the applications, APIs and subscriptions of a Developer Portal, where the
+// application app-1 is subscribed to the API api-1
+func newSubscriptionsTestServer(t *testing.T, subscriptions *[]utils.Subscription) (*restTestServer,
+	subscriptionEndpoints) {
+	server := newRESTTestServer(t).
+		handle(http.MethodGet, "/applications", http.StatusOK, `{"count": 2, "list": [
+			{"applicationId": "app-2", "name": "MyAppV2"}, {"applicationId": "app-1", "name": "MyApp"}]}`).
+		handle(http.MethodGet, "/apis", http.StatusOK, `{"count": 2, "list": [
+			{"id": "api-2", "name": "PizzaShackAPI", "version": "2.0.0", "provider": "admin"},
+			{"id": "api-1", "name": "PizzaShackAPI", "version": "1.0.0", "provider": "admin"}]}`).
+		handleFunc(http.MethodGet, "/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+			list := utils.SubscriptionList{}
+			for _, sub := range *subscriptions {
+				if sub.ApplicationID == r.URL.Query().Get("applicationId") {
+					list.List = append(list.List, sub)
+				}
+			}
+			list.Count = len(list.List)
+			body, _ := json.Marshal(list)
+			_, _ = w.Write(body)
+		}).
+		handleFunc(http.MethodPost, "/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+			request := &utils.SubscriptionCreateRequest{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(request))
+			sub := utils.Subscription{SubscriptionID: "sub-" + request.APIID, ApplicationID: request.ApplicationID,
+				APIID: request.APIID, ThrottlingPolicy: request.ThrottlingPolicy}
+			*subscriptions = append(*subscriptions, sub)
+			w.WriteHeader(http.StatusCreated)
+			body, _ := json.Marshal(sub)
+			_, _ = w.Write(body)
+		}).
+		handleFunc(http.MethodDelete, "/subscriptions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+			*subscriptions = (*subscriptions)[1:]
+			w.WriteHeader(http.StatusOK)
+		})
+	return server, subscriptionEndpoints{
+		apis:          server.URL + "/apis",
+		applications:  server.URL + "/applications",
+		subscriptions: server.URL + "/subscriptions",
+	}
+}
+
+func TestParseAPIIdentifier(t *testing.T) {
+	name, version, err := ParseAPIIdentifier("PizzaShackAPI:1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "PizzaShackAPI", name)
+	assert.Equal(t, "1.0.0", version)
+
+	for _, api := range []string{"PizzaShackAPI", "PizzaShackAPI:", ":1.0.0", "a:b:c"} {
+		_, _, err = ParseAPIIdentifier(api)
+		assert.NotNil(t, err, api)
+	}
+}
+
+func TestAddSubscription(t *testing.T) {
+	subscriptions := []utils.Subscription{{SubscriptionID: "sub-1", ApplicationID: "app-1", APIID: "api-1"}}
+	server, endpoints := newSubscriptionsTestServer(t, &subscriptions)
+	defer server.Close()
+
+	subscription, created, err := addSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "2.0.0", "",
+		"Gold")
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "sub-api-2", subscription.SubscriptionID)
+	assert.Equal(t, 2, len(subscriptions))
+	assert.Equal(t, "app-1", subscriptions[1].ApplicationID)
+	assert.Equal(t, "Gold", subscriptions[1].ThrottlingPolicy)
+}
+
+func TestAddSubscriptionExisting(t *testing.T) {
+	subscriptions := []utils.Subscription{{SubscriptionID: "sub-1", ApplicationID: "app-1", APIID: "api-1"}}
+	server, endpoints := newSubscriptionsTestServer(t, &subscriptions)
+	defer server.Close()
+
+	subscription, created, err := addSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "1.0.0", "admin",
+		"Gold")
+	assert.Nil(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "sub-1", subscription.SubscriptionID)
+	assert.Equal(t, 1, len(subscriptions))
+}
+
+func TestAddSubscriptionUnknownAPI(t *testing.T) {
+	var subscriptions []utils.Subscription
+	server, endpoints := newSubscriptionsTestServer(t, &subscriptions)
+	defer server.Close()
+
+	_, _, err := addSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "3.0.0", "", "Gold")
+	assert.NotNil(t, err)
+	_, _, err = addSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "1.0.0", "alice", "Gold")
+	assert.NotNil(t, err)
+	_, _, err = addSubscription(endpoints, "token", "OtherApp", "PizzaShackAPI", "1.0.0", "", "Gold")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(subscriptions))
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	subscriptions := []utils.Subscription{{SubscriptionID: "sub-1", ApplicationID: "app-1", APIID: "api-1"}}
+	server, endpoints := newSubscriptionsTestServer(t, &subscriptions)
+	defer server.Close()
+
+	subscription, err := deleteSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "1.0.0", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "sub-1", subscription.SubscriptionID)
+	assert.Equal(t, 0, len(subscriptions))
+
+	_, err = deleteSubscription(endpoints, "token", "MyApp", "PizzaShackAPI", "1.0.0", "")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,179 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	webSocketAPIType     = "WS"
+	webSocketOrigin      = "http://localhost/"
+	webSocketSentMark    = "> "
+	webSocketReceiveMark = "< "
+)
+
+// TestWebSocketAPI generates a token to invoke a WebSocket API, connects to the API through the gateway, sends the
+// given messages and prints the frames received from the API
+// @param cred : Credentials of the environment
+// @param envName : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @param gatewayEnv : Gateway environment to connect to, the first gateway environment is used if empty
+// @param messages : Messages to send to the API
+// @param timeout : Time to wait for frames after sending the messages
+// @return error
+func TestWebSocketAPI(cred credentials.Credential, envName, name, version, provider, gatewayEnv string,
+	messages []string, timeout time.Duration) error {
+	token := GenerateKeys(cred, envName, name, version, provider, "", utils.TokenGenerationOptions{})
+	utils.Logln(utils.LogPrefixInfo + "Generated a token to invoke the WebSocket API.")
+
+	accessToken, err := credentials.GetOAuthAccessToken(cred, envName)
+	if err != nil {
+		return err
+	}
+	apiId, err := searchApiOrProduct(accessToken)
+	if err != nil {
+		return err
+	}
+	api, err := getDevPortalAPI(apiId, accessToken)
+	if err != nil {
+		return err
+	}
+	if api.Type != webSocketAPIType {
+		return errors.New("API " + name + " " + version + " is not a WebSocket API. Type: " + api.Type)
+	}
+	wsURL, err := getWebSocketURL(api, gatewayEnv)
+	if err != nil {
+		return err
+	}
+	return invokeWebSocketAPI(wsURL, token.AccessToken, messages, timeout, os.Stdout)
+}
+
+// Get the details of an API from the devportal REST API
+// @param apiId : ID of the API
+// @param accessToken : Access token to call the devportal REST API
+// @return API, error
+func getDevPortalAPI(apiId, accessToken string) (*utils.DevPortalAPI, error) {
+	apiEndpoint := utils.GetDevPortalApiListEndpointOfEnv(keyGenEnv, utils.MainConfigFilePath) + "/" + apiId
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(apiEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving API details. Status: " + resp.Status())
+	}
+	api := &utils.DevPortalAPI{}
+	err = json.Unmarshal(resp.Body(), api)
+	return api, err
+}
+
+// Get the WebSocket URL of an API, preferring the secured URL over the unsecured one
+// @param api : API retrieved from the devportal
+// @param gatewayEnv : Gateway environment to connect to, the first gateway environment is used if empty
+// @return WebSocket URL, error
+func getWebSocketURL(api *utils.DevPortalAPI, gatewayEnv string) (string, error) {
+	for _, endpointURL := range api.EndpointURLs {
+		if gatewayEnv != "" && endpointURL.EnvironmentName != gatewayEnv {
+			continue
+		}
+		if endpointURL.URLs.WSS != "" {
+			return endpointURL.URLs.WSS, nil
+		}
+		if endpointURL.URLs.WS != "" {
+			return endpointURL.URLs.WS, nil
+		}
+	}
+	if gatewayEnv != "" {
+		return "", errors.New("API " + api.Name + " " + api.Version + " is not deployed in the gateway environment " +
+			gatewayEnv)
+	}
+	return "", errors.New("API " + api.Name + " " + api.Version + " is not deployed in any gateway environment")
+}
+
+// Connect to a WebSocket API, send the given messages and print the frames received until the timeout elapses
+// or the connection is closed by the gateway
+// @param wsURL : WebSocket URL of the API
+// @param accessToken : Access token to invoke the API
+// @param messages : Messages to send to the API
+// @param timeout : Time to wait for frames after sending the messages
+// @param out : Writer to print the sent messages and received frames
+// @return error
+func invokeWebSocketAPI(wsURL, accessToken string, messages []string, timeout time.Duration, out io.Writer) error {
+	config, err := websocket.NewConfig(wsURL, webSocketOrigin)
+	if err != nil {
+		return err
+	}
+	config.Header.Set(utils.HeaderAuthorization, utils.HeaderValueAuthBearerPrefix+" "+accessToken)
+	if strings.EqualFold(config.Location.Scheme, "wss") {
+		if utils.Insecure {
+			config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+		} else {
+			config.TlsConfig = utils.GetTlsConfigWithCertificate()
+		}
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return fmt.Errorf("error while connecting to %s: %v", wsURL, err)
+	}
+	defer conn.Close()
+	fmt.Fprintln(out, "Connected to "+wsURL)
+
+	for _, message := range messages {
+		if err = websocket.Message.Send(conn, message); err != nil {
+			return fmt.Errorf("error while sending the message %s: %v", message, err)
+		}
+		fmt.Fprintln(out, webSocketSentMark+message)
+	}
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+		var frame string
+		err = websocket.Message.Receive(conn, &frame)
+		if err != nil {
+			var netErr net.Error
+			if err == io.EOF || (errors.As(err, &netErr) && netErr.Timeout()) {
+				return nil
+			}
+			return fmt.Errorf("error while receiving frames: %v", err)
+		}
+		fmt.Fprintln(out, webSocketReceiveMark+frame)
+	}
+}
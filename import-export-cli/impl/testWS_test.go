@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/net/websocket"
+)
+
+func getTestDevPortalAPI() *utils.DevPortalAPI {
+	api := &utils.DevPortalAPI{Name: "ChatAPI", Version: "1.0.0", Type: webSocketAPIType}
+	defaultEnv := utils.DevPortalEndpointURL{EnvironmentName: "Default"}
+	defaultEnv.URLs.WS = "ws://localhost:9099/chat/1.0.0"
+	defaultEnv.URLs.WSS = "wss://localhost:8099/chat/1.0.0"
+	internalEnv := utils.DevPortalEndpointURL{EnvironmentName: "Internal"}
+	internalEnv.URLs.WS = "ws://internal:9099/chat/1.0.0"
+	api.EndpointURLs = []utils.DevPortalEndpointURL{defaultEnv, internalEnv}
+	return api
+}
+
+func TestGetWebSocketURLPrefersSecuredURL(t *testing.T) {
+	wsURL, err := getWebSocketURL(getTestDevPortalAPI(), "")
+	assert.Nil(t, err)
+	assert.Equal(t, "wss://localhost:8099/chat/1.0.0", wsURL)
+}
+
+func TestGetWebSocketURLOfGatewayEnv(t *testing.T) {
+	wsURL, err := getWebSocketURL(getTestDevPortalAPI(), "Internal")
+	assert.Nil(t, err)
+	assert.Equal(t, "ws://internal:9099/chat/1.0.0", wsURL)
+}
+
+func TestGetWebSocketURLOfUnknownGatewayEnv(t *testing.T) {
+	_, err := getWebSocketURL(getTestDevPortalAPI(), "External")
+	assert.Error(t, err)
+}
+
+func TestInvokeWebSocketAPI(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		if !strings.HasPrefix(conn.Request().Header.Get(utils.HeaderAuthorization),
+			utils.HeaderValueAuthBearerPrefix) {
+			t.Errorf("Error in Authorization Header. Got '%s'\n",
+				conn.Request().Header.Get(utils.HeaderAuthorization))
+		}
+		var message string
+		for websocket.Message.Receive(conn, &message) == nil {
+			_ = websocket.Message.Send(conn, "echo "+message)
+		}
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	err := invokeWebSocketAPI(wsURL, "token", []string{`{"ping":1}`}, 500*time.Millisecond, out)
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), webSocketSentMark+`{"ping":1}`)
+	assert.Contains(t, out.String(), webSocketReceiveMark+`echo {"ping":1}`)
+}
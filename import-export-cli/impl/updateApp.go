@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ApplicationUpdate holds the changes to an application, where the empty fields are kept as they are
+type ApplicationUpdate struct {
+	ThrottlingPolicy string
+	Description      string
+	TokenType        string
+	// Groups replace the groups of the application when not nil
+	Groups []string
+	// Attributes are added to the attributes of the application, overriding the existing ones with the same keys
+	Attributes map[string]string
+}
+
+// UpdateApplication updates an application of the user in the environment
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the application
+// @param appName : Name of the application
+// @param update : Changes to the application
+// @return updated application, error
+func UpdateApplication(accessToken, environment, appName string, update *ApplicationUpdate) (*utils.AppDetails,
+	error) {
+	applicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return updateApplication(applicationsEndpoint, accessToken, appName, update)
+}
+
+func updateApplication(applicationsEndpoint, accessToken, appName string, update *ApplicationUpdate) (
+	*utils.AppDetails, error) {
+	appId, err := getDevPortalAppId(applicationsEndpoint, accessToken, appName)
+	if err != nil {
+		return nil, err
+	}
+	applicationEndpoint := utils.AppendSlashToString(applicationsEndpoint) + appId
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+
+	// The application is read as a map so that the fields not known by apictl are sent back as they are
+	resp, err := utils.InvokeGETRequest(applicationEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, getApplicationResponseError(resp.StatusCode(), resp.Body())
+	}
+	application := make(map[string]interface{})
+	if err := json.Unmarshal(resp.Body(), &application); err != nil {
+		return nil, err
+	}
+	applyApplicationUpdate(application, update)
+
+	utils.Logln(utils.LogPrefixInfo+"Updating the application", appName)
+	resp, err = utils.InvokePUTRequestWithoutQueryParams(applicationEndpoint, headers, application)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, getApplicationResponseError(resp.StatusCode(), resp.Body())
+	}
+	appDetails := &utils.AppDetails{}
+	if err := json.Unmarshal(resp.Body(), appDetails); err != nil {
+		return nil, err
+	}
+	return appDetails, nil
+}
+
+func applyApplicationUpdate(application map[string]interface{}, update *ApplicationUpdate) {
+	if update.ThrottlingPolicy != "" {
+		application["throttlingPolicy"] = update.ThrottlingPolicy
+	}
+	if update.Description != "" {
+		application["description"] = update.Description
+	}
+	if update.TokenType != "" {
+		application["tokenType"] = update.TokenType
+	}
+	if update.Groups != nil {
+		application["groups"] = update.Groups
+	}
+	if len(update.Attributes) > 0 {
+		attributes, ok := application["attributes"].(map[string]interface{})
+		if !ok {
+			attributes = make(map[string]interface{})
+		}
+		for key, value := range update.Attributes {
+			attributes[key] = value
+		}
+		application["attributes"] = attributes
+	}
+}
+
+func getApplicationResponseError(statusCode int, body []byte) error {
+	utils.Logf("Body: %s\n", body)
+	return errors.New(strconv.Itoa(statusCode) + ":<" + string(body) + ">")
+}
@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newUpdateAppTestServer serves the application MyApp with the ID app-1 and echoes the body of the update request
+func newUpdateAppTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handle(http.MethodGet, "/applications", http.StatusOK, `{"count": 2, "list": [
+			{"applicationId": "app-2", "name": "MyAppV2"}, {"applicationId": "app-1", "name": "MyApp"}]}`).
+		handle(http.MethodGet, "/applications/app-1", http.StatusOK, `{"applicationId": "app-1", "name": "MyApp",
+			"throttlingPolicy": "Unlimited", "description": "Payments", "tokenType": "JWT", "groups": ["g1"],
+			"attributes": {"team": "payments"}, "hashEnabled": false}`).
+		handleFunc(http.MethodPut, "/applications/app-1", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		})
+}
+
+func TestUpdateApplication(t *testing.T) {
+	server := newUpdateAppTestServer(t)
+	defer server.Close()
+
+	app, err := updateApplication(server.URL+"/applications", "token", "MyApp", &ApplicationUpdate{
+		ThrottlingPolicy: "Gold",
+		Attributes:       map[string]string{"env": "qa"},
+	})
+	assert.Nil(t, err)
+	var updated map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/applications/app-1", &updated))
+	assert.Equal(t, "app-1", app.ApplicationID)
+	assert.Equal(t, "Gold", updated["throttlingPolicy"])
+	// The fields which are not given are kept as they are
+	assert.Equal(t, "Payments", updated["description"])
+	assert.Equal(t, "JWT", updated["tokenType"])
+	assert.Equal(t, []interface{}{"g1"}, updated["groups"])
+	assert.Equal(t, false, updated["hashEnabled"])
+	assert.Equal(t, map[string]interface{}{"team": "payments", "env": "qa"}, updated["attributes"])
+}
+
+func TestUpdateApplicationGroups(t *testing.T) {
+	server := newUpdateAppTestServer(t)
+	defer server.Close()
+
+	_, err := updateApplication(server.URL+"/applications", "token", "MyApp", &ApplicationUpdate{
+		Groups: []string{},
+	})
+	assert.Nil(t, err)
+	var updated map[string]interface{}
+	assert.True(t, server.lastRequestJSON(http.MethodPut, "/applications/app-1", &updated))
+	assert.Equal(t, []interface{}{}, updated["groups"])
+	assert.Equal(t, "Unlimited", updated["throttlingPolicy"])
+}
+
+func TestUpdateApplicationNotFound(t *testing.T) {
+	server := newUpdateAppTestServer(t)
+	defer server.Close()
+
+	_, err := updateApplication(server.URL+"/applications", "token", "OtherApp", &ApplicationUpdate{})
+	assert.NotNil(t, err)
+	assert.Empty(t, server.requestsTo(http.MethodPut, "/applications/app-1"))
+}
@@ -0,0 +1,493 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// SwaggerValidationError is the severity of an issue which fails the import of the definition to API Manager
+	SwaggerValidationError = "ERROR"
+	// SwaggerValidationWarning is the severity of an issue which is ignored or altered by API Manager
+	SwaggerValidationWarning = "WARNING"
+
+	swaggerVersionPlaceholder  = "{version}"
+	swaggerTenantContextPrefix = "/t/"
+)
+
+var (
+	reSwaggerOpenAPI3Version     = regexp.MustCompile(`^3\.0\.\d+$`)
+	reSwaggerOpenAPI31Version    = regexp.MustCompile(`^3\.1\.\d+$`)
+	reSwaggerContext             = regexp.MustCompile(`^[a-zA-Z0-9_\-/.{}]+$`)
+	reSwaggerInvalidVersionChars = regexp.MustCompile(`[~!@#;:%^*()+={}|\\<>"',&/$\[\]\s]`)
+
+	// Fields allowed in a path item apart from the operations and the extensions
+	swaggerPathItemFields = []string{"parameters", "$ref", "summary", "description", "servers"}
+	// HTTP verbs of the operations supported by API Manager
+	swaggerSupportedVerbs   = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	swaggerUnsupportedVerbs = []string{"trace"}
+
+	swaggerAuthTypes  = []string{"Any", "None", "Application", "Application User", "Application & Application User"}
+	swaggerTransports = []string{"http", "https"}
+	swaggerEpTypes    = []string{"", v2.EpHttp, v2.EpLoadbalance, v2.EpFailover}
+
+	// x-wso2 extensions understood by API Manager at the root of the definition
+	swaggerRootExtensions = []string{"x-wso2-basePath", "x-wso2-cors", "x-wso2-production-endpoints",
+		"x-wso2-sandbox-endpoints", "x-wso2-transports", "x-wso2-auth-header", "x-wso2-api-key-header",
+		"x-wso2-throttling-tier", "x-wso2-mutual-ssl", "x-wso2-application-security", "x-wso2-response-cache",
+		"x-wso2-disable-security", "x-wso2-security", "x-wso2-request-interceptor", "x-wso2-response-interceptor"}
+	// x-wso2 extensions understood by API Manager in an operation
+	swaggerOperationExtensions = []string{"x-wso2-production-endpoints", "x-wso2-sandbox-endpoints",
+		"x-wso2-throttling-tier", "x-wso2-application-security", "x-wso2-disable-security",
+		"x-wso2-request-interceptor", "x-wso2-response-interceptor"}
+)
+
+// SwaggerValidationIssue is an issue found while validating an OpenAPI definition
+type SwaggerValidationIssue struct {
+	Severity string
+	// Location of the issue in the definition, as the keys separated by dots
+	Location string
+	Message  string
+}
+
+// swaggerValidator collects the issues found while validating an OpenAPI definition
+type swaggerValidator struct {
+	doc    map[string]interface{}
+	isOAS3 bool
+	issues []SwaggerValidationIssue
+}
+
+// ValidateSwagger validates an OpenAPI definition against the specification and the constraints of API Manager
+// @param swaggerPath : Path to the OpenAPI 2 or OpenAPI 3 definition in yaml or json format
+// @return issues found in the definition, error
+func ValidateSwagger(swaggerPath string) ([]SwaggerValidationIssue, error) {
+	definition, err := ioutil.ReadFile(swaggerPath)
+	if err != nil {
+		return nil, errors.New("error while reading the OpenAPI definition: " + err.Error())
+	}
+	return validateSwaggerDefinition(definition)
+}
+
+// PrintSwaggerValidationIssues prints the issues found in an OpenAPI definition
+// @param issues : Issues found in the definition
+// @return number of errors among the issues
+func PrintSwaggerValidationIssues(issues []SwaggerValidationIssue) int {
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == SwaggerValidationError {
+			errorCount++
+		}
+		fmt.Printf("%-8s %s: %s\n", issue.Severity, issue.Location, issue.Message)
+	}
+	fmt.Printf("%d error(s), %d warning(s) found\n", errorCount, len(issues)-errorCount)
+	return errorCount
+}
+
+// validateSwaggerDefinition validates an OpenAPI definition against the specification and the constraints of
+// API Manager
+// @param definition : OpenAPI 2 or OpenAPI 3 definition in yaml or json format
+// @return issues found in the definition sorted by the location, error
+func validateSwaggerDefinition(definition []byte) ([]SwaggerValidationIssue, error) {
+	definitionJson, err := utils.YamlToJson(definition)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err = json.Unmarshal(definitionJson, &doc); err != nil {
+		return nil, errors.New("the definition is not a valid json or yaml object: " + err.Error())
+	}
+	v := &swaggerValidator{doc: doc}
+	if v.validateSpecVersion() {
+		v.validateInfo()
+		v.validateContext()
+		v.validateRootExtensions()
+		v.validatePaths()
+	}
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Location < v.issues[j].Location
+	})
+	return v.issues, nil
+}
+
+func (v *swaggerValidator) addError(location, format string, args ...interface{}) {
+	v.issues = append(v.issues, SwaggerValidationIssue{Severity: SwaggerValidationError, Location: location,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *swaggerValidator) addWarning(location, format string, args ...interface{}) {
+	v.issues = append(v.issues, SwaggerValidationIssue{Severity: SwaggerValidationWarning, Location: location,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+// validateSpecVersion validates the swagger or openapi version of the definition
+// @return whether the rest of the definition can be validated
+func (v *swaggerValidator) validateSpecVersion() bool {
+	if version, ok := v.doc["swagger"]; ok {
+		if version != "2.0" {
+			v.addError("swagger", "unsupported swagger version %v, expected 2.0", version)
+			return false
+		}
+		return true
+	}
+	if version, ok := v.doc["openapi"]; ok {
+		versionStr, _ := version.(string)
+		if reSwaggerOpenAPI31Version.MatchString(versionStr) {
+			v.addError("openapi", "OpenAPI %s is not supported by API Manager, use OpenAPI 3.0.x", versionStr)
+			return false
+		}
+		if !reSwaggerOpenAPI3Version.MatchString(versionStr) {
+			v.addError("openapi", "unsupported openapi version %v, expected 3.0.x", version)
+			return false
+		}
+		v.isOAS3 = true
+		return true
+	}
+	v.addError("(root)", "either the swagger or the openapi field is required")
+	return false
+}
+
+// validateInfo validates the title and the version of the API, which are used as the name and the version of the
+// API in API Manager
+func (v *swaggerValidator) validateInfo() {
+	info, ok := v.doc["info"].(map[string]interface{})
+	if !ok {
+		v.addError("info", "the info object is required")
+		return
+	}
+	if title, _ := info["title"].(string); strings.TrimSpace(title) == "" {
+		v.addError("info.title", "the title is required")
+	}
+	version, _ := info["version"].(string)
+	if strings.TrimSpace(version) == "" {
+		v.addError("info.version", "the version is required")
+	} else if reSwaggerInvalidVersionChars.MatchString(version) {
+		v.addError("info.version", "the version %q contains characters which are not allowed in an API version",
+			version)
+	}
+}
+
+// validateContext validates the context of the API, which is taken from x-wso2-basePath if given, or else from the
+// basePath of OpenAPI 2 or the path of the first server url of OpenAPI 3
+func (v *swaggerValidator) validateContext() {
+	location := "x-wso2-basePath"
+	context, ok := v.doc["x-wso2-basePath"].(string)
+	if _, exists := v.doc["x-wso2-basePath"]; exists && !ok {
+		v.addError(location, "x-wso2-basePath should be a string")
+		return
+	}
+	if !ok {
+		location = "basePath"
+		if v.isOAS3 {
+			location = "servers"
+		}
+		context = getMockBasePath(v.doc)
+	}
+	if context == "" || context == "/" {
+		v.addWarning(location, "no context is given, the context needs to be provided when importing the API")
+		return
+	}
+	if !strings.HasPrefix(context, "/") {
+		v.addError(location, "the context %q should start with /", context)
+	}
+	if strings.HasPrefix(context, swaggerTenantContextPrefix) {
+		v.addError(location, "the context %q should not start with %s, which is reserved for tenants", context,
+			swaggerTenantContextPrefix)
+	}
+	if !reSwaggerContext.MatchString(context) {
+		v.addError(location, "the context %q contains characters which are not allowed in an API context", context)
+	}
+	if strings.Count(context, swaggerVersionPlaceholder) > 1 {
+		v.addError(location, "the context %q should contain %s at most once", context, swaggerVersionPlaceholder)
+	}
+	if strings.Count(context, "{") != strings.Count(context, swaggerVersionPlaceholder) {
+		v.addError(location, "%s is the only template allowed in the context %q", swaggerVersionPlaceholder,
+			context)
+	}
+}
+
+// validateRootExtensions validates the x-wso2 extensions at the root of the definition
+func (v *swaggerValidator) validateRootExtensions() {
+	for key, value := range v.doc {
+		if !strings.HasPrefix(key, "x-wso2-") {
+			continue
+		}
+		if !containsSwaggerValue(swaggerRootExtensions, key) {
+			v.addWarning(key, "unknown extension %s is ignored by API Manager", key)
+			continue
+		}
+		v.validateExtension(key, key, value)
+	}
+	if webhooks, ok := v.doc["webhooks"]; ok && webhooks != nil {
+		v.addError("webhooks", "webhooks are not supported by API Manager")
+	}
+}
+
+// validateExtension validates the value of an x-wso2 extension understood by API Manager
+// @param location : Location of the extension
+// @param key : Name of the extension
+// @param value : Value of the extension
+func (v *swaggerValidator) validateExtension(location, key string, value interface{}) {
+	switch key {
+	case "x-wso2-production-endpoints", "x-wso2-sandbox-endpoints":
+		v.validateEndpointsExtension(location, value)
+	case "x-wso2-cors":
+		cors, ok := value.(map[string]interface{})
+		if !ok {
+			v.addError(location, "%s should be an object", key)
+			return
+		}
+		for _, field := range []string{"accessControlAllowOrigins", "accessControlAllowHeaders",
+			"accessControlAllowMethods"} {
+			if fieldValue, exists := cors[field]; exists && !isSwaggerStringList(fieldValue) {
+				v.addError(location+"."+field, "%s should be a list of strings", field)
+			}
+		}
+		if credentials, exists := cors["accessControlAllowCredentials"]; exists {
+			if _, ok := credentials.(bool); !ok {
+				v.addError(location+".accessControlAllowCredentials",
+					"accessControlAllowCredentials should be a boolean")
+			}
+		}
+	case "x-wso2-transports":
+		if !isSwaggerStringList(value) {
+			v.addError(location, "%s should be a list of strings", key)
+			return
+		}
+		for _, transport := range value.([]interface{}) {
+			if !containsSwaggerValue(swaggerTransports, transport.(string)) {
+				v.addError(location, "unsupported transport %q, expected one of %s", transport,
+					strings.Join(swaggerTransports, ", "))
+			}
+		}
+	case "x-wso2-disable-security":
+		if _, ok := value.(bool); !ok {
+			v.addError(location, "%s should be a boolean", key)
+		}
+	case "x-wso2-basePath", "x-wso2-auth-header", "x-wso2-api-key-header", "x-wso2-throttling-tier":
+		if _, ok := value.(string); !ok {
+			v.addError(location, "%s should be a string", key)
+		}
+	case "x-wso2-response-cache":
+		cache, ok := value.(map[string]interface{})
+		if !ok {
+			v.addError(location, "%s should be an object", key)
+			return
+		}
+		if enabled, exists := cache["enabled"]; exists {
+			if _, ok := enabled.(bool); !ok {
+				v.addError(location+".enabled", "enabled should be a boolean")
+			}
+		}
+		if timeout, exists := cache["cacheTimeoutInSeconds"]; exists {
+			if seconds, ok := timeout.(float64); !ok || seconds < 0 || seconds != float64(int64(seconds)) {
+				v.addError(location+".cacheTimeoutInSeconds", "cacheTimeoutInSeconds should be a positive integer")
+			}
+		}
+	}
+}
+
+// validateEndpointsExtension validates the production or sandbox endpoints given as an x-wso2 extension
+// @param location : Location of the extension
+// @param value : Value of the extension
+func (v *swaggerValidator) validateEndpointsExtension(location string, value interface{}) {
+	endpoints, ok := value.(map[string]interface{})
+	if !ok {
+		v.addError(location, "the endpoints should be an object with the urls")
+		return
+	}
+	if !isSwaggerStringList(endpoints["urls"]) || len(endpoints["urls"].([]interface{})) == 0 {
+		v.addError(location+".urls", "at least one endpoint url is required")
+	} else {
+		for _, endpoint := range endpoints["urls"].([]interface{}) {
+			endpointURL, err := url.Parse(endpoint.(string))
+			if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+				v.addError(location+".urls", "the endpoint url %q is not a valid url", endpoint)
+			}
+		}
+	}
+	if epType, exists := endpoints["type"]; exists {
+		epTypeStr, ok := epType.(string)
+		if !ok || !containsSwaggerValue(swaggerEpTypes, epTypeStr) {
+			v.addError(location+".type", "unsupported endpoint type %v, expected one of %s", epType,
+				strings.Join(swaggerEpTypes[1:], ", "))
+		}
+	}
+}
+
+// validatePaths validates the paths and the operations of the definition
+func (v *swaggerValidator) validatePaths() {
+	paths, ok := v.doc["paths"].(map[string]interface{})
+	if !ok {
+		v.addError("paths", "the paths object is required")
+		return
+	}
+	if len(paths) == 0 {
+		v.addWarning("paths", "no resources are defined")
+	}
+	operationIds := make(map[string]string)
+	for path, pathItemValue := range paths {
+		location := "paths." + path
+		if strings.HasPrefix(path, "x-") {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			v.addError(location, "the path %q should start with /", path)
+		}
+		pathItem, ok := pathItemValue.(map[string]interface{})
+		if !ok {
+			v.addError(location, "the path item should be an object")
+			continue
+		}
+		for key, value := range pathItem {
+			switch {
+			case strings.HasPrefix(key, "x-"), containsSwaggerValue(swaggerPathItemFields, key):
+			case containsSwaggerValue(swaggerUnsupportedVerbs, key):
+				v.addError(location+"."+key, "the %s operation is not supported by API Manager",
+					strings.ToUpper(key))
+			case containsSwaggerValue(swaggerSupportedVerbs, key):
+				operation, ok := value.(map[string]interface{})
+				if !ok {
+					v.addError(location+"."+key, "the operation should be an object")
+					continue
+				}
+				v.validateOperation(location+"."+key, path, pathItem, operation, operationIds)
+			default:
+				v.addError(location+"."+key, "unknown field %s in the path item", key)
+			}
+		}
+	}
+}
+
+// validateOperation validates an operation of the definition
+// @param location : Location of the operation
+// @param path : Path of the operation
+// @param pathItem : Path item the operation belongs to
+// @param operation : Operation to validate
+// @param operationIds : Locations of the operations keyed by the operationIds seen so far
+func (v *swaggerValidator) validateOperation(location, path string, pathItem, operation map[string]interface{},
+	operationIds map[string]string) {
+	if responses, ok := operation["responses"].(map[string]interface{}); !ok || len(responses) == 0 {
+		v.addError(location+".responses", "at least one response is required")
+	} else if v.isOAS3 {
+		for code, response := range responses {
+			if responseObj, ok := response.(map[string]interface{}); ok && responseObj["links"] != nil {
+				v.addWarning(location+".responses."+code+".links", "links are ignored by API Manager")
+			}
+		}
+	}
+	if operationId, ok := operation["operationId"].(string); ok && operationId != "" {
+		// Compare with the location of the first occurrence, as the order of the paths is not deterministic
+		if other, exists := operationIds[operationId]; exists {
+			first, second := other, location
+			if second < first {
+				first, second = second, first
+			}
+			v.addError(second+".operationId", "the operationId %q is already used by %s", operationId, first)
+		} else {
+			operationIds[operationId] = location
+		}
+	}
+	if v.isOAS3 && operation["callbacks"] != nil {
+		v.addWarning(location+".callbacks", "callbacks are ignored by API Manager")
+	}
+	v.validatePathParameters(location, path, pathItem, operation)
+
+	for key, value := range operation {
+		if key == "x-auth-type" {
+			if authType, ok := value.(string); !ok || !containsSwaggerValue(swaggerAuthTypes, authType) {
+				v.addError(location+"."+key, "unsupported auth type %v, expected one of %s", value,
+					strings.Join(swaggerAuthTypes, ", "))
+			}
+			continue
+		}
+		if !strings.HasPrefix(key, "x-wso2-") {
+			continue
+		}
+		if !containsSwaggerValue(swaggerOperationExtensions, key) {
+			v.addWarning(location+"."+key, "unknown extension %s is ignored by API Manager", key)
+			continue
+		}
+		v.validateExtension(location+"."+key, key, value)
+	}
+}
+
+// validatePathParameters validates that each template of the path is declared as a path parameter
+// @param location : Location of the operation
+// @param path : Path of the operation
+// @param pathItem : Path item the operation belongs to
+// @param operation : Operation to validate
+func (v *swaggerValidator) validatePathParameters(location, path string, pathItem,
+	operation map[string]interface{}) {
+	declared := make(map[string]bool)
+	for _, parameters := range []interface{}{pathItem["parameters"], operation["parameters"]} {
+		parameterList, _ := parameters.([]interface{})
+		for _, parameterValue := range parameterList {
+			parameter, _ := parameterValue.(map[string]interface{})
+			if _, isRef := parameter["$ref"]; isRef {
+				// Referenced parameters are not resolved, hence the path parameters cannot be validated
+				return
+			}
+			if parameter["in"] == "path" {
+				name, _ := parameter["name"].(string)
+				declared[name] = true
+			}
+		}
+	}
+	for _, template := range rePathParamInPath.FindAllString(path, -1) {
+		name := strings.Trim(template, "{}")
+		if !declared[name] {
+			v.addError(location+".parameters", "the path parameter %s is not declared", name)
+		}
+	}
+}
+
+// isSwaggerStringList returns whether the value is a list of strings
+func isSwaggerStringList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// containsSwaggerValue returns whether the value is in the list of values
+func containsSwaggerValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/renstrom/dedent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSwaggerDefinitionValid(t *testing.T) {
+	definition := dedent.Dedent(`
+	openapi: 3.0.1
+	info:
+	  title: PetStore
+	  version: 1.0.0
+	x-wso2-basePath: /petstore/{version}
+	x-wso2-production-endpoints:
+	  urls:
+	    - https://petstore.swagger.io/v2
+	  type: load_balance
+	x-wso2-transports:
+	  - https
+	paths:
+	  /pets/{petId}:
+	    parameters:
+	      - name: petId
+	        in: path
+	        required: true
+	        schema:
+	          type: string
+	    get:
+	      operationId: getPet
+	      x-auth-type: Application & Application User
+	      responses:
+	        "200":
+	          description: OK
+	`)
+	issues, err := validateSwaggerDefinition([]byte(definition))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(issues))
+}
+
+func TestValidateSwaggerDefinitionUnsupportedVersion(t *testing.T) {
+	definition := dedent.Dedent(`
+	openapi: 3.1.0
+	info:
+	  title: PetStore
+	  version: 1.0.0
+	paths: {}
+	`)
+	issues, err := validateSwaggerDefinition([]byte(definition))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(issues))
+	assert.Equal(t, "openapi", issues[0].Location)
+	assert.Equal(t, SwaggerValidationError, issues[0].Severity)
+}
+
+func TestValidateSwaggerDefinitionAPIMRules(t *testing.T) {
+	definition := dedent.Dedent(`
+	swagger: "2.0"
+	info:
+	  title: PetStore
+	  version: v1/beta
+	basePath: /t/petstore/{version}/{version}
+	x-wso2-production-endpoints:
+	  urls: []
+	x-wso2-custom: value
+	paths:
+	  /pets/{petId}:
+	    get:
+	      operationId: getPet
+	      x-auth-type: Everyone
+	      responses:
+	        "200":
+	          description: OK
+	    trace:
+	      responses:
+	        "200":
+	          description: OK
+	  /pets:
+	    get:
+	      operationId: getPet
+	      responses: {}
+	`)
+	issues, err := validateSwaggerDefinition([]byte(definition))
+	assert.Nil(t, err)
+
+	expected := []SwaggerValidationIssue{
+		{SwaggerValidationError, "basePath", `the context "/t/petstore/{version}/{version}" should not start with /t/, which is reserved for tenants`},
+		{SwaggerValidationError, "basePath", `the context "/t/petstore/{version}/{version}" should contain {version} at most once`},
+		{SwaggerValidationError, "info.version", `the version "v1/beta" contains characters which are not allowed in an API version`},
+		{SwaggerValidationError, "paths./pets.get.responses", "at least one response is required"},
+		{SwaggerValidationError, "paths./pets/{petId}.get.operationId", `the operationId "getPet" is already used by paths./pets.get`},
+		{SwaggerValidationError, "paths./pets/{petId}.get.parameters", "the path parameter petId is not declared"},
+		{SwaggerValidationError, "paths./pets/{petId}.get.x-auth-type", "unsupported auth type Everyone, expected one of Any, None, Application, Application User, Application & Application User"},
+		{SwaggerValidationError, "paths./pets/{petId}.trace", "the TRACE operation is not supported by API Manager"},
+		{SwaggerValidationWarning, "x-wso2-custom", "unknown extension x-wso2-custom is ignored by API Manager"},
+		{SwaggerValidationError, "x-wso2-production-endpoints.urls", "at least one endpoint url is required"},
+	}
+	assert.Equal(t, expected, issues)
+}
@@ -0,0 +1,154 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// vaultReferencePrefix is the prefix of the values in the params files which refer to a secret in HashiCorp Vault
+// as vault:<path>#<key> (ex: vault:secret/data/apim#password)
+const vaultReferencePrefix = "vault:"
+
+const vaultTokenHeader = "X-Vault-Token"
+
+// vaultSecretResolver resolves the vault: references in the params of an environment using the HashiCorp Vault
+// server configured for the environment. The secrets of a path are read only once.
+type vaultSecretResolver struct {
+	environment string
+	endpoint    string
+	token       string
+	secrets     map[string]map[string]interface{}
+}
+
+// resolveVaultSecrets replaces the vault: references in the params of the environment with the secrets read from
+// the HashiCorp Vault server configured for the environment, so that the secrets need not be kept in the params files
+// @param envParams : Params of the environment
+// @param environment : Environment to which the API is imported
+// @return error
+func resolveVaultSecrets(envParams *params.Environment, environment string) error {
+	if envParams == nil {
+		return nil
+	}
+	resolver := &vaultSecretResolver{environment: environment, secrets: make(map[string]map[string]interface{})}
+	_, err := resolver.resolve(envParams.Config)
+	return err
+}
+
+// resolve replaces the vault: references in the given value, where the maps and the slices are updated in place
+func (resolver *vaultSecretResolver) resolve(value interface{}) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, vaultReferencePrefix) {
+			return resolver.readSecret(strings.TrimPrefix(v, vaultReferencePrefix))
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if v[key], err = resolver.resolve(item); err != nil {
+				return nil, err
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			if v[key], err = resolver.resolve(item); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if v[i], err = resolver.resolve(item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+// readSecret returns the value of the key of the secret referred as <path>#<key>
+func (resolver *vaultSecretResolver) readSecret(reference string) (interface{}, error) {
+	separatorIndex := strings.LastIndex(reference, "#")
+	if separatorIndex <= 0 || separatorIndex == len(reference)-1 {
+		return nil, fmt.Errorf("invalid vault reference %s%s, the reference should be given as %s<path>#<key>",
+			vaultReferencePrefix, reference, vaultReferencePrefix)
+	}
+	secretPath := strings.Trim(reference[:separatorIndex], "/")
+	key := reference[separatorIndex+1:]
+
+	secret, ok := resolver.secrets[secretPath]
+	if !ok {
+		var err error
+		if secret, err = resolver.fetchSecret(secretPath); err != nil {
+			return nil, err
+		}
+		resolver.secrets[secretPath] = secret
+	}
+	value, ok := secret[key]
+	if !ok {
+		return nil, fmt.Errorf("cannot find the key %s in the vault secret %s", key, secretPath)
+	}
+	return value, nil
+}
+
+// fetchSecret reads the secret in the path from the Vault server. The data of the secrets in KV version 2 secret
+// engines, which are nested under data along with the metadata, are returned as the secret.
+func (resolver *vaultSecretResolver) fetchSecret(secretPath string) (map[string]interface{}, error) {
+	if resolver.endpoint == "" {
+		endpoint, err := utils.GetVaultEndpointOfEnv(resolver.environment, utils.MainConfigFilePath)
+		if err != nil {
+			return nil, err
+		}
+		resolver.token = utils.GetVaultTokenOfEnv(resolver.environment)
+		if resolver.token == "" {
+			return nil, errors.New("vault token is not set for the environment '" + resolver.environment +
+				"', please set the " + utils.EnvVarConfigPrefix + strings.ToUpper(resolver.environment) +
+				"_VAULT_TOKEN or the " + utils.VaultTokenEnvVar + " environment variable")
+		}
+		resolver.endpoint = endpoint
+	}
+
+	url := resolver.endpoint + "v1/" + secretPath
+	utils.Logln(utils.LogPrefixInfo+"Reading the vault secret:", url)
+	headers := make(map[string]string)
+	headers[vaultTokenHeader] = resolver.token
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the vault secret %s: %v", secretPath, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("error reading the vault secret %s: %s", secretPath, resp.Status())
+	}
+	secretResponse := &struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), secretResponse); err != nil {
+		return nil, fmt.Errorf("error reading the vault secret %s: %v", secretPath, err)
+	}
+	if data, ok := secretResponse.Data["data"].(map[string]interface{}); ok && secretResponse.Data["metadata"] != nil {
+		return data, nil
+	}
+	return secretResponse.Data, nil
+}
@@ -0,0 +1,111 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+)
+
+// newVaultTestServer serves a KV version 2 secret in secret/data/apim and a KV version 1 secret in kv/apim
+func newVaultTestServer(t *testing.T) *restTestServer {
+	return newRESTTestServer(t).
+		handleFunc(http.MethodGet, "/v1/secret/data/apim", vaultTestSecret(`{"data": {
+			"data": {"username": "admin", "password": "s3cret"}, "metadata": {"version": 2}}}`)).
+		handleFunc(http.MethodGet, "/v1/kv/apim", vaultTestSecret(`{"data": {"apiKey": "abc123"}}`)).
+		handle(http.MethodGet, "/v1/secret/data/other", http.StatusNotFound, "")
+}
+
+// vaultTestSecret responds with the secret to the requests which have the vault token
+func vaultTestSecret(secret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(vaultTokenHeader) != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(secret))
+	}
+}
+
+func newTestVaultSecretResolver(endpoint string) *vaultSecretResolver {
+	return &vaultSecretResolver{environment: "dev", endpoint: endpoint + "/", token: "vault-token",
+		secrets: make(map[string]map[string]interface{})}
+}
+
+func TestVaultSecretResolverResolve(t *testing.T) {
+	server := newVaultTestServer(t)
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"endpoints": map[interface{}]interface{}{
+			"production": map[interface{}]interface{}{
+				"url": "https://backend.example.com",
+			},
+		},
+		"security": map[interface{}]interface{}{
+			"production": map[interface{}]interface{}{
+				"username": "vault:secret/data/apim#username",
+				"password": "vault:secret/data/apim#password",
+			},
+		},
+		"additionalProperties": []interface{}{
+			map[interface{}]interface{}{"name": "apiKey", "value": "vault:kv/apim#apiKey"},
+		},
+	}
+	_, err := newTestVaultSecretResolver(server.URL).resolve(config)
+	assert.Nil(t, err)
+
+	security := config["security"].(map[interface{}]interface{})["production"].(map[interface{}]interface{})
+	assert.Equal(t, "admin", security["username"])
+	assert.Equal(t, "s3cret", security["password"])
+	properties := config["additionalProperties"].([]interface{})
+	assert.Equal(t, "abc123", properties[0].(map[interface{}]interface{})["value"])
+	endpoints := config["endpoints"].(map[interface{}]interface{})["production"].(map[interface{}]interface{})
+	assert.Equal(t, "https://backend.example.com", endpoints["url"])
+	// The secret in secret/data/apim is read only once
+	assert.Equal(t, 2, server.requestCount())
+}
+
+func TestVaultSecretResolverErrors(t *testing.T) {
+	server := newVaultTestServer(t)
+	defer server.Close()
+
+	for _, reference := range []string{"vault:secret/data/apim", "vault:#password", "vault:secret/data/apim#",
+		"vault:secret/data/apim#token", "vault:secret/data/other#password"} {
+		_, err := newTestVaultSecretResolver(server.URL).resolve(reference)
+		assert.NotNil(t, err, reference)
+	}
+
+	resolver := newTestVaultSecretResolver(server.URL)
+	resolver.token = "invalid-token"
+	_, err := resolver.resolve("vault:secret/data/apim#password")
+	assert.NotNil(t, err)
+}
+
+func TestResolveVaultSecretsWithoutReferences(t *testing.T) {
+	envParams := &params.Environment{Name: "dev", Config: map[string]interface{}{
+		"endpoints": map[interface{}]interface{}{"production": map[interface{}]interface{}{"url": "vault.example.com"}},
+	}}
+	// The vault endpoint of the environment is not needed when there are no references
+	assert.Nil(t, resolveVaultSecrets(envParams, "dev"))
+	assert.Nil(t, resolveVaultSecrets(nil, "dev"))
+}
@@ -375,15 +375,9 @@ func CreateTempDir(t *testing.T, path string) {
 	}
 }
 
+// GetExportedPathFromOutput returns the path of the exported artifact printed in the output of a command
 func GetExportedPathFromOutput(output string) string {
-	//Check directory path to omit changes due to OS differences
-	if strings.Contains(output, ":\\") {
-		arrayOutput := []rune(output)
-		extractedPath := string(arrayOutput[strings.Index(output, ":\\")-1:])
-		return strings.ReplaceAll(strings.ReplaceAll(extractedPath, "\n", ""), " ", "")
-	} else {
-		return strings.ReplaceAll(strings.ReplaceAll(output[strings.Index(output, string(os.PathSeparator)):], "\n", ""), " ", "")
-	}
+	return utils.ExtractPathFromOutput(output)
 }
 
 //Count number of files in a directory
@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+type updateConnectorStateRequestBody struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	Status  string `json:"status"`
+}
+
+// ActivateConnector enables a connector deployed in the micro integrator in a given environment.
+// If packageName is empty, the package of the connector is looked up by its name.
+func ActivateConnector(env, connectorName, packageName string) (interface{}, error) {
+	return updateConnectorState(env, connectorName, packageName, "enabled")
+}
+
+// DeactivateConnector disables a connector deployed in the micro integrator in a given environment.
+// If packageName is empty, the package of the connector is looked up by its name.
+func DeactivateConnector(env, connectorName, packageName string) (interface{}, error) {
+	return updateConnectorState(env, connectorName, packageName, "disabled")
+}
+
+func updateConnectorState(env, connectorName, packageName, state string) (interface{}, error) {
+	if packageName == "" {
+		var err error
+		packageName, err = getConnectorPackage(env, connectorName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementConnectorResource, env, utils.MainConfigFilePath)
+	body := updateConnectorStateRequestBody{
+		Name:    connectorName,
+		Package: packageName,
+		Status:  state,
+	}
+	resp, err := invokePOSTRequestWithRetry(env, url, body)
+	return handleResponse(resp, err, url, "Message", "Error")
+}
+
+// getConnectorPackage returns the package of the connector with the given name, as the management API requires
+// both the name and the package to identify a connector
+func getConnectorPackage(env, connectorName string) (string, error) {
+	connectorList, err := GetConnectorList(env)
+	if err != nil {
+		return "", err
+	}
+	var packages []string
+	for _, connector := range connectorList.Connectors {
+		if connector.Name == connectorName {
+			packages = append(packages, connector.Package)
+		}
+	}
+	switch len(packages) {
+	case 0:
+		return "", errors.New("Connector does not exist")
+	case 1:
+		return packages[0], nil
+	default:
+		return "", errors.New("Connectors with the same name exist in the packages " + strings.Join(packages, ", ") +
+			". Specify the package using the flag --package")
+	}
+}
@@ -42,3 +42,4 @@ const yearHeader = "YEAR"
 const transactionCountHeader = "TRANSACTION COUNT"
 const userIDHeader = "USER ID"
 const roleHeader = "ROLE"
+const detailsHeader = "DETAILS"
@@ -0,0 +1,190 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	defaultStatusTableFormat = "table {{.Component}}\t{{.Status}}\t{{.Details}}"
+
+	statusOK      = "OK"
+	statusWarning = "WARNING"
+	statusError   = "ERROR"
+
+	serverComponent       = "Server"
+	jvmComponent          = "JVM"
+	compositeAppComponent = "Composite Apps"
+	endpointComponent     = "Endpoints"
+	proxyServiceComponent = "Proxy Services"
+)
+
+// StatusCheck is the status of a component of a Micro Integrator
+type StatusCheck struct {
+	Component string `json:"component"`
+	Status    string `json:"status"`
+	Details   string `json:"details"`
+}
+
+// GetStatus summarizes the server, the composite apps, the endpoints and the proxy services of the micro integrator
+// in a given environment. A component which could not be retrieved is reported with the ERROR status, so that the
+// rest of the components are still summarized.
+func GetStatus(env string) []StatusCheck {
+	var checks []StatusCheck
+	resp, err := callMIManagementEndpointOfResource(utils.MiManagementServerResource, nil, env,
+		&artifactutils.ServerSummary{})
+	if err != nil {
+		checks = append(checks, newErrorStatusCheck(serverComponent, err), newErrorStatusCheck(jvmComponent, err))
+	} else {
+		checks = append(checks, summarizeServer(resp.(*artifactutils.ServerSummary))...)
+	}
+
+	if appList, err := GetCompositeAppList(env); err != nil {
+		checks = append(checks, newErrorStatusCheck(compositeAppComponent, err))
+	} else {
+		checks = append(checks, summarizeCompositeApps(appList))
+	}
+
+	if endpointList, err := GetEndpointList(env); err != nil {
+		checks = append(checks, newErrorStatusCheck(endpointComponent, err))
+	} else {
+		checks = append(checks, summarizeEndpoints(endpointList))
+	}
+
+	if proxyList, err := GetProxyServiceList(env); err != nil {
+		checks = append(checks, newErrorStatusCheck(proxyServiceComponent, err))
+	} else {
+		checks = append(checks, summarizeProxyServices(proxyList))
+	}
+	return checks
+}
+
+func newErrorStatusCheck(component string, err error) StatusCheck {
+	return StatusCheck{Component: component, Status: statusError, Details: err.Error()}
+}
+
+// summarizeServer summarizes the product and the JVM of the server. The management API does not expose the
+// runtime metrics of the JVM, hence only the Java runtime the server runs on is given.
+func summarizeServer(server *artifactutils.ServerSummary) []StatusCheck {
+	jvm := joinNonEmpty("Java", server.JavaVersion, getParenthesized(server.JavaVendor))
+	if server.OsName != "" {
+		jvm = joinNonEmpty(jvm, "on", server.OsName, server.OsVersion)
+	}
+	return []StatusCheck{
+		{Component: serverComponent, Status: statusOK, Details: joinNonEmpty(server.ProductName, server.ProductVersion)},
+		{Component: jvmComponent, Status: statusOK, Details: jvm},
+	}
+}
+
+func summarizeCompositeApps(appList *artifactutils.CompositeAppList) StatusCheck {
+	check := StatusCheck{Component: compositeAppComponent, Status: statusOK,
+		Details: fmt.Sprintf("%d active, %d faulty", appList.ActiveCount, appList.FaultyCount)}
+	if appList.FaultyCount > 0 {
+		var faulty []string
+		for _, app := range appList.FaultyCompositeApps {
+			faulty = append(faulty, app.Name+"_"+app.Version)
+		}
+		check.Status = statusWarning
+		check.Details += ": " + strings.Join(faulty, ", ")
+	}
+	return check
+}
+
+func summarizeEndpoints(endpointList *artifactutils.EndpointList) StatusCheck {
+	var inactive []string
+	for _, endpoint := range endpointList.Endpoints {
+		if !endpoint.Active {
+			inactive = append(inactive, endpoint.Name)
+		}
+	}
+	return summarizeArtifacts(endpointComponent, int(endpointList.Count), "inactive", inactive)
+}
+
+// summarizeProxyServices reports the proxy services which are not running. Older micro integrator versions do not
+// give the state of the proxy services in the list, in which case none of the proxy services is reported.
+func summarizeProxyServices(proxyList *artifactutils.ProxyServiceList) StatusCheck {
+	var disabled []string
+	for _, proxy := range proxyList.Proxies {
+		if proxy.IsRunning != nil && !*proxy.IsRunning {
+			disabled = append(disabled, proxy.Name)
+		}
+	}
+	return summarizeArtifacts(proxyServiceComponent, int(proxyList.Count), "disabled", disabled)
+}
+
+func summarizeArtifacts(component string, count int, state string, unavailable []string) StatusCheck {
+	check := StatusCheck{Component: component, Status: statusOK,
+		Details: fmt.Sprintf("%d total, %d %s", count, len(unavailable), state)}
+	if len(unavailable) > 0 {
+		check.Status = statusWarning
+		check.Details += ": " + strings.Join(unavailable, ", ")
+	}
+	return check
+}
+
+// joinNonEmpty joins the non empty values with spaces
+func joinNonEmpty(values ...string) string {
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+func getParenthesized(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "(" + value + ")"
+}
+
+// PrintStatus prints the status of the components of a micro integrator according to the given format
+func PrintStatus(checks []StatusCheck, format string) {
+	if format == utils.JsonFormatType {
+		utils.PrintJsonOutput(checks)
+		return
+	}
+	statusContext := getContextWithFormat(format, defaultStatusTableFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, check := range checks {
+			if err := t.Execute(w, check); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	statusTableHeaders := map[string]string{
+		"Component": componentHeader,
+		"Status":    statusHeader,
+		"Details":   detailsHeader,
+	}
+	if err := statusContext.Write(renderer, statusTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
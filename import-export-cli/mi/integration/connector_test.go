@@ -45,3 +45,56 @@ func TestGetConnectorsWithoutEnvFlag(t *testing.T) {
 func TestGetConnectorsWithInvalidArgs(t *testing.T) {
 	testutils.ExecGetCommandWithInvalidArgCount(t, config, 0, 2, true, connectorCmd, "abc", "123")
 }
+
+const connectorActivateCmd = "connector"
+const connectorName = "salesforce"
+const connectorPackage = "org.wso2.carbon.connector"
+
+// No connectors are deployed in the micro integrator used by the tests
+func TestActivateNonExistingConnector(t *testing.T) {
+	expected := "[ERROR]: Activating connector [ " + connectorName + " ] Connector does not exist"
+	testutils.ExecActivateCommand(t, config, connectorActivateCmd, connectorName, expected)
+}
+
+func TestActivateConnectorWithoutEnvFlag(t *testing.T) {
+	testutils.ExecActivateCommandWithoutEnvFlag(t, config, connectorActivateCmd, connectorName)
+}
+
+func TestActivateConnectorWithInvalidArgs(t *testing.T) {
+	testutils.ExecActivateCommandWithInvalidArgCount(t, config, 1, 0, connectorActivateCmd)
+	testutils.ExecActivateCommandWithInvalidArgCount(t, config, 1, 2, connectorActivateCmd, connectorName, "abc")
+}
+
+func TestActivateConnectorWithoutSettingUpEnv(t *testing.T) {
+	testutils.ExecActivateCommandWithoutSettingEnv(t, connectorActivateCmd, connectorName)
+}
+
+func TestActivateConnectorWithoutLogin(t *testing.T) {
+	testutils.ExecActivateCommandWithoutLogin(t, config, connectorActivateCmd, connectorName)
+	testutils.ExecActivateCommandWithoutLogin(t, config, connectorActivateCmd, connectorName, "--package",
+		connectorPackage)
+}
+
+func TestDeactivateNonExistingConnector(t *testing.T) {
+	expected := "[ERROR]: Deactivating connector [ " + connectorName + " ] Connector does not exist"
+	testutils.ExecDeactivateCommand(t, config, connectorActivateCmd, connectorName, expected)
+}
+
+func TestDeactivateConnectorWithoutEnvFlag(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutEnvFlag(t, config, connectorActivateCmd, connectorName)
+}
+
+func TestDeactivateConnectorWithInvalidArgs(t *testing.T) {
+	testutils.ExecDeactivateCommandWithInvalidArgCount(t, config, 1, 0, connectorActivateCmd)
+	testutils.ExecDeactivateCommandWithInvalidArgCount(t, config, 1, 2, connectorActivateCmd, connectorName, "abc")
+}
+
+func TestDeactivateConnectorWithoutSettingUpEnv(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutSettingEnv(t, connectorActivateCmd, connectorName)
+}
+
+func TestDeactivateConnectorWithoutLogin(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutLogin(t, config, connectorActivateCmd, connectorName)
+	testutils.ExecDeactivateCommandWithoutLogin(t, config, connectorActivateCmd, connectorName, "--package",
+		connectorPackage)
+}
@@ -59,3 +59,61 @@ func TestGetDataServicesWithoutEnvFlag(t *testing.T) {
 func TestGetDataServicesWithInvalidArgs(t *testing.T) {
 	testutils.ExecGetCommandWithInvalidArgCount(t, config, 1, 2, false, dataServiceCmd, validDataServiceName, invalidDataServiceName)
 }
+
+const dataServiceActivateCmd = "data-service"
+
+func TestActivateDataService(t *testing.T) {
+	testutils.ExecActivateCommandWithoutError(t, config, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestActivateNonExistingDataService(t *testing.T) {
+	expected := "[ERROR]: Activating data service [ " + invalidDataServiceName + " ]"
+	testutils.ExecActivateCommand(t, config, dataServiceActivateCmd, invalidDataServiceName, expected)
+}
+
+func TestActivateDataServiceWithoutEnvFlag(t *testing.T) {
+	testutils.ExecActivateCommandWithoutEnvFlag(t, config, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestActivateDataServiceWithInvalidArgs(t *testing.T) {
+	testutils.ExecActivateCommandWithInvalidArgCount(t, config, 1, 0, dataServiceActivateCmd)
+	testutils.ExecActivateCommandWithInvalidArgCount(t, config, 1, 2, dataServiceActivateCmd, validDataServiceName,
+		invalidDataServiceName)
+}
+
+func TestActivateDataServiceWithoutSettingUpEnv(t *testing.T) {
+	testutils.ExecActivateCommandWithoutSettingEnv(t, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestActivateDataServiceWithoutLogin(t *testing.T) {
+	testutils.ExecActivateCommandWithoutLogin(t, config, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestDeactivateDataService(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutError(t, config, dataServiceActivateCmd, validDataServiceName)
+	// Activate the data service again, so that it is served as before the test
+	testutils.ExecActivateCommandWithoutError(t, config, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestDeactivateNonExistingDataService(t *testing.T) {
+	expected := "[ERROR]: Deactivating data service [ " + invalidDataServiceName + " ]"
+	testutils.ExecDeactivateCommand(t, config, dataServiceActivateCmd, invalidDataServiceName, expected)
+}
+
+func TestDeactivateDataServiceWithoutEnvFlag(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutEnvFlag(t, config, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestDeactivateDataServiceWithInvalidArgs(t *testing.T) {
+	testutils.ExecDeactivateCommandWithInvalidArgCount(t, config, 1, 0, dataServiceActivateCmd)
+	testutils.ExecDeactivateCommandWithInvalidArgCount(t, config, 1, 2, dataServiceActivateCmd, validDataServiceName,
+		invalidDataServiceName)
+}
+
+func TestDeactivateDataServiceWithoutSettingUpEnv(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutSettingEnv(t, dataServiceActivateCmd, validDataServiceName)
+}
+
+func TestDeactivateDataServiceWithoutLogin(t *testing.T) {
+	testutils.ExecDeactivateCommandWithoutLogin(t, config, dataServiceActivateCmd, validDataServiceName)
+}
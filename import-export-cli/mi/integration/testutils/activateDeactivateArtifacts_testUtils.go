@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-apim-tooling/import-export-cli/integration/base"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 // ExecActivateCommand run activate artifactType artifactName
@@ -45,6 +46,28 @@ func execActivateDeactivateCommand(t *testing.T, config *MiConfig, mode, artifac
 	assert.Contains(t, response, expected)
 }
 
+// ExecActivateCommandWithoutError run activate artifactType artifactName and check that no error is printed
+func ExecActivateCommandWithoutError(t *testing.T, config *MiConfig, artifactType, artifactName string, args ...string) {
+	t.Helper()
+	execActivateDeactivateCommandWithoutError(t, config, "activate", artifactType, artifactName, args)
+}
+
+// ExecDeactivateCommandWithoutError run deactivate artifactType artifactName and check that no error is printed
+func ExecDeactivateCommandWithoutError(t *testing.T, config *MiConfig, artifactType, artifactName string, args ...string) {
+	t.Helper()
+	execActivateDeactivateCommandWithoutError(t, config, "deactivate", artifactType, artifactName, args)
+}
+
+func execActivateDeactivateCommandWithoutError(t *testing.T, config *MiConfig, mode, artifactType, artifactName string, args []string) {
+	SetupAndLoginToMI(t, config)
+	getCmdArgs := []string{"mi", mode, artifactType, artifactName, "-e", config.MIClient.GetEnvName(), "-k"}
+	getCmdArgs = append(getCmdArgs, args...)
+	response, err := base.Execute(t, getCmdArgs...)
+	base.Log(response)
+	assert.Nil(t, err)
+	assert.NotContains(t, response, utils.LogPrefixError)
+}
+
 // ExecActivateCommandWithoutSettingEnv run activate without setting up an environment
 func ExecActivateCommandWithoutSettingEnv(t *testing.T, args ...string) {
 	t.Helper()
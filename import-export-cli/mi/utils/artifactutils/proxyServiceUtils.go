@@ -35,4 +35,6 @@ type ProxySummary struct {
 	Name   string `json:"name"`
 	Wsdl11 string `json:"wsdl1_1"`
 	Wsdl20 string `json:"wsdl2_0"`
+	// IsRunning is not given by older Micro Integrator versions
+	IsRunning *bool `json:"isRunning,omitempty"`
 }
@@ -138,7 +138,8 @@ type APIEndpointConfig struct {
 	EPConfig string `json:"endpointConfig"`
 }
 
-// loads the given file in path and substitutes environment variables that are defined as ${var} or $var in the file.
+// loads the given file in path and substitutes environment variables that are defined as {{ .Env.VAR }}, ${var} or
+// $var in the file.
 //
 //	returns the file as string.
 func GetEnvSubstitutedFileContent(path string) (string, error) {
@@ -155,7 +156,11 @@ func GetEnvSubstitutedFileContent(path string) (string, error) {
 		return "", err
 	}
 
-	str, err := utils.EnvSubstituteForCurlyBraces(string(data))
+	str, err := utils.EnvSubstituteTemplate(string(data))
+	if err != nil {
+		return "", err
+	}
+	str, err = utils.EnvSubstituteForCurlyBraces(str)
 	if err != nil {
 		return "", err
 	}
@@ -174,6 +179,11 @@ func LoadApiParamsFromDirectory(path string) (*ApiParams, error) {
 		return nil, err
 	}
 
+	err = ValidateApiParams([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+
 	apiParams := &ApiParams{}
 	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
 	if err != nil {
@@ -192,6 +202,11 @@ func LoadApiParamsFromFile(path string) (*ApiParams, error) {
 		return nil, err
 	}
 
+	err = ValidateApiParams([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+
 	apiParams := &ApiParams{}
 	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
 	if err != nil {
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -37,6 +38,21 @@ func TestLoadApiParamsFromFileWithoutEnv(t *testing.T) {
 	assert.Nil(t, conf, "Conf should be nil")
 }
 
+func TestLoadApiParamsFromFileWithTemplate(t *testing.T) {
+	_ = os.Setenv("FOO_TEMPLATE_HOST", "dev.foo.com")
+	_ = os.Setenv("FOO_TEMPLATE_RETRY", "30")
+	defer os.Unsetenv("FOO_TEMPLATE_HOST")
+	defer os.Unsetenv("FOO_TEMPLATE_RETRY")
+	conf, err := LoadApiParamsFromFile("testdata/api_params-template.yml")
+	assert.Nil(t, err, "Error should be nil when environment variables are present")
+	assert.NotNil(t, conf.GetEnv("dev"), "Should load the environment")
+
+	content, err := GetEnvSubstitutedFileContent("testdata/api_params-template.yml")
+	assert.Nil(t, err, "Error should be nil when environment variables are present")
+	assert.Contains(t, content, "url: 'https://dev.foo.com/v1'")
+	assert.Contains(t, content, "retryTimeOut: 30")
+}
+
 func TestLoadAPIFromFile(t *testing.T) {
 	apiData, err := loadAPIFromFile("testdata/api.json")
 	assert.Nil(t, err, "Error should be nil when correct json loaded")
@@ -59,3 +75,74 @@ func TestAPIConfig_ContainsEnv(t *testing.T) {
 	assert.NotNil(t, configData.GetEnv("dev"), "Should contain correct environment")
 	assert.Nil(t, configData.GetEnv("prod"), "Should not contain undefined environment")
 }
+
+func TestLoadApiParamsFromFileMisindented(t *testing.T) {
+	conf, err := LoadApiParamsFromFile("testdata/api_params-misindented.yml")
+	assert.Error(t, err, "Should return an error when configs are not nested under configs")
+	assert.Contains(t, err.Error(), `environments[0]: unknown property "endpoints"`)
+	assert.Nil(t, conf, "Conf should be nil")
+}
+
+func TestValidateApiParams(t *testing.T) {
+	content := []byte(`
+environments:
+  - name: production
+    configs:
+      endpoints:
+        production:
+          url: https://prod.wso2.com
+          config:
+            retryTimeOut: 13000
+            suspendErrorCode:
+              - "101504"
+      security:
+        production:
+          enabled: true
+          type: basic
+      deploymentEnvironments:
+        - displayOnDevportal: true
+          deploymentEnvironment: Default
+      policies:
+        - Gold
+      additionalProperties:
+        - name: owner-team
+          value: payments
+        - name: runbook
+          value: https://wiki.example.com/payments
+          display: true
+deploy:
+  import:
+    update: true
+`)
+	assert.Nil(t, ValidateApiParams(content), "Valid params should not return an error")
+}
+
+func TestValidateApiParamsViolations(t *testing.T) {
+	content := []byte(`
+environments:
+  - configs:
+      endpoints:
+        production:
+          url:
+      deploymentEnvironments:
+        - deploymentEnvironment: Default
+          displayOnDevportal: "yes"
+      policies: Gold
+      additionalProperties:
+        - name: cost-center
+          value: 1024
+`)
+	err := ValidateApiParams(content)
+	assert.Error(t, err, "Invalid params should return an error")
+	assert.Contains(t, err.Error(), `environments[0]: missing required property "name"`)
+	assert.Contains(t, err.Error(), "environments[0].configs.endpoints.production.url: expected string, found an empty value")
+	assert.Contains(t, err.Error(),
+		"environments[0].configs.deploymentEnvironments[0].displayOnDevportal: expected boolean, found a string")
+	assert.Contains(t, err.Error(), "environments[0].configs.policies: expected array, found a string")
+	assert.Contains(t, err.Error(),
+		"environments[0].configs.additionalProperties[0].value: expected string, found an integer")
+}
+
+func TestApiParamsSchemaIsValidJSON(t *testing.T) {
+	assert.True(t, json.Valid([]byte(ApiParamsSchema)), "Schema should be valid JSON")
+}
@@ -0,0 +1,337 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ApiParamsSchema is the JSON Schema of an API params file. Editors supporting JSON Schema can use it to provide
+// autocompletion and validation while editing params files. The same schema is used to validate params files
+// when they are loaded.
+const ApiParamsSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "apictl API params",
+  "description": "Environment specific parameters used when importing an API with apictl",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "environments": {
+      "description": "Parameters of each environment the API is imported to",
+      "type": "array",
+      "items": { "$ref": "#/definitions/environment" }
+    },
+    "deploy": {
+      "description": "Parameters used when the API is deployed with apictl vcs deploy",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "import": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "update": { "type": "boolean" },
+            "preserveProvider": { "type": "boolean" },
+            "rotateRevision": { "type": "boolean" }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "environment": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["name"],
+      "properties": {
+        "name": {
+          "description": "Name of the apictl environment",
+          "type": "string"
+        },
+        "configs": { "$ref": "#/definitions/configs" }
+      }
+    },
+    "configs": {
+      "description": "Parameters applied to the API when it is imported to the environment",
+      "type": "object",
+      "properties": {
+        "context": { "type": "string" },
+        "endpointType": { "type": "string" },
+        "endpointRoutingPolicy": { "type": "string" },
+        "endpoints": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "production": { "$ref": "#/definitions/endpoint" },
+            "sandbox": { "$ref": "#/definitions/endpoint" }
+          }
+        },
+        "loadBalanceEndpoints": { "type": "object" },
+        "failoverEndpoints": { "type": "object" },
+        "awsLambdaEndpoints": { "type": "object" },
+        "endpointRewrites": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["match", "replace"],
+            "properties": {
+              "match": { "type": "string" },
+              "replace": { "type": "string" }
+            }
+          }
+        },
+        "additionalProperties": {
+          "description": "Additional properties added to the API or overriding the properties with the same names",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["name", "value"],
+            "properties": {
+              "name": { "type": "string" },
+              "value": { "type": "string" },
+              "display": {
+                "description": "Whether the property is displayed in the Developer Portal. Defaults to false",
+                "type": "boolean"
+              }
+            }
+          }
+        },
+        "security": { "type": "object" },
+        "deploymentEnvironments": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["deploymentEnvironment"],
+            "properties": {
+              "displayOnDevportal": { "type": "boolean" },
+              "deploymentEnvironment": { "type": "string" },
+              "deploymentVhost": { "type": "string" }
+            }
+          }
+        },
+        "certs": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": false,
+            "properties": {
+              "hostName": { "type": "string" },
+              "alias": { "type": "string" },
+              "path": { "type": "string" }
+            }
+          }
+        },
+        "mutualSslCerts": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": false,
+            "properties": {
+              "tierName": { "type": "string" },
+              "alias": { "type": "string" },
+              "path": { "type": "string" }
+            }
+          }
+        },
+        "policies": {
+          "type": "array",
+          "items": { "type": "string" }
+        }
+      }
+    },
+    "endpoint": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "url": { "type": "string" },
+        "config": {
+          "type": "object",
+          "properties": {
+            "retryTimeOut": { "type": "integer" },
+            "retryDelay": { "type": "integer" },
+            "factor": { "type": "integer" },
+            "suspendDuration": { "type": "integer" },
+            "suspendMaxDuration": { "type": "integer" },
+            "actionDuration": { "type": "integer" },
+            "actionSelect": { "type": "string" }
+          }
+        },
+        "advanceEndpointConfig": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "timeoutInMillis": { "type": "integer" }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const schemaDefinitionsRefPrefix = "#/definitions/"
+
+// ValidateApiParams validates the content of an API params file against ApiParamsSchema.
+//
+//	It returns an error listing every violation found in the content
+func ValidateApiParams(content []byte) error {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(ApiParamsSchema), &schema); err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	violations := validateAgainstSchema(normalizeYAML(doc), schema, definitions, "")
+	if len(violations) > 0 {
+		return errors.New("params file does not match the schema:\n  " + strings.Join(violations, "\n  "))
+	}
+	return nil
+}
+
+// normalizeYAML converts the maps decoded by the yaml package to maps with string keys
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeYAML(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// validateAgainstSchema validates value against the subset of JSON Schema used by ApiParamsSchema
+// (type, properties, additionalProperties, required, items and local $refs) and returns the violations found
+func validateAgainstSchema(value interface{}, schema, definitions map[string]interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		definition, found := definitions[strings.TrimPrefix(ref, schemaDefinitionsRefPrefix)].(map[string]interface{})
+		if !found {
+			return []string{fmt.Sprintf("%s: unresolvable schema reference %s", displayPath(path), ref)}
+		}
+		schema = definition
+	}
+
+	if schemaType, ok := schema["type"].(string); ok && !isOfSchemaType(value, schemaType) {
+		return []string{fmt.Sprintf("%s: expected %s, found %s", displayPath(path), schemaType, describeType(value))}
+	}
+
+	var violations []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, found := v[name.(string)]; !found {
+					violations = append(violations, fmt.Sprintf("%s: missing required property %q",
+						displayPath(path), name))
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				violations = append(violations, validateAgainstSchema(v[key], propertySchema, definitions,
+					joinPath(path, key))...)
+			} else if allowed, ok := schema["additionalProperties"].(bool); ok && !allowed {
+				violations = append(violations, fmt.Sprintf("%s: unknown property %q", displayPath(path), key))
+			}
+		}
+	case []interface{}:
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				violations = append(violations, validateAgainstSchema(item, itemSchema, definitions,
+					fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return violations
+}
+
+func isOfSchemaType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		switch value.(type) {
+		case int, int64, uint64:
+			return true
+		}
+		return false
+	case "number":
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+func describeType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "an empty value"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "a list"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	default:
+		return "an integer"
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
@@ -27,7 +27,14 @@ type APIProductDefinitionFile struct {
 
 // APIProductDTODefinition represents an API Product artifact in APIM
 type APIProductDTODefinition struct {
-	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
-	Version  string `json:"version,omitempty" yaml:"version,omitempty"`
-	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Name     string                    `json:"name,omitempty" yaml:"name,omitempty"`
+	Version  string                    `json:"version,omitempty" yaml:"version,omitempty"`
+	Provider string                    `json:"provider,omitempty" yaml:"provider,omitempty"`
+	APIs     []APIProductAPIDefinition `json:"apis,omitempty" yaml:"apis,omitempty"`
+}
+
+// APIProductAPIDefinition represents an API included in an API Product
+type APIProductAPIDefinition struct {
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 }
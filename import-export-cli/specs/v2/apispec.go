@@ -77,6 +77,7 @@ type APIDTODefinition struct {
 	EndpointImplementationType      string        `json:"endpointImplementationType,omitempty" yaml:"endpointImplementationType,omitempty"`
 	Scopes                          []interface{} `json:"scopes,omitempty" yaml:"scopes,omitempty"`
 	Operations                      []interface{} `json:"operations,omitempty" yaml:"operations,omitempty"`
+	APIPolicies                     interface{}   `json:"apiPolicies,omitempty" yaml:"apiPolicies,omitempty"`
 	ThreatProtectionPolicies        interface{}   `json:"threatProtectionPolicies,omitempty" yaml:"threatProtectionPolicies,omitempty"`
 	Categories                      []string      `json:"categories,omitempty" yaml:"categories,omitempty"`
 	KeyManagers                     []string      `json:"keyManagers,omitempty" yaml:"keyManagers,omitempty"`
@@ -106,6 +107,8 @@ type Data struct {
 	Summary       string `json:"summary,omitempty" yaml:"summary,omitempty"`
 	SourceType    string `json:"sourceType,omitempty" yaml:"sourceType,omitempty"`
 	OtherTypeName string `json:"otherTypeName,omitempty" yaml:"otherTypeName,omitempty"`
+	SourceURL     string `json:"sourceUrl,omitempty" yaml:"sourceUrl,omitempty"`
+	FileName      string `json:"fileName,omitempty" yaml:"fileName,omitempty"`
 	Visibility    string `json:"visibility,omitempty" yaml:"visibility,omitempty"`
 }
 
@@ -27,7 +27,20 @@ type ApplicationDefinition struct {
 
 // ApplicationDTODefinition represents an Application artifact in APIM
 type ApplicationDTODefinition struct {
-	Applicationinfo ApplicationInfo `json:"applicationInfo,omitempty" yaml:"applicationInfo,omitempty"`
+	Applicationinfo ApplicationInfo           `json:"applicationInfo,omitempty" yaml:"applicationInfo,omitempty"`
+	SubscribedAPIs  []SubscribedAPIDefinition `json:"subscribedAPIs,omitempty" yaml:"subscribedAPIs,omitempty"`
+}
+
+// SubscribedAPIDefinition represents an API or an API Product subscribed by an Application
+type SubscribedAPIDefinition struct {
+	APIId SubscribedAPIIdentifier `json:"apiId,omitempty" yaml:"apiId,omitempty"`
+}
+
+// SubscribedAPIIdentifier represents the identifier of an API or an API Product subscribed by an Application
+type SubscribedAPIIdentifier struct {
+	ProviderName string `json:"providerName,omitempty" yaml:"providerName,omitempty"`
+	APIName      string `json:"apiName,omitempty" yaml:"apiName,omitempty"`
+	Version      string `json:"version,omitempty" yaml:"version,omitempty"`
 }
 
 // ApplicationInfo represents an Application information
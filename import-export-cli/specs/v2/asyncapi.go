@@ -0,0 +1,257 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// API types of the AsyncAPI based APIs supported by the Publisher
+const (
+	APITypeWS     = "WS"
+	APITypeSSE    = "SSE"
+	APITypeWebSub = "WEBSUB"
+)
+
+// asyncAPIVersionPattern matches the versions of the AsyncAPI 2.x specification
+var asyncAPIVersionPattern = regexp.MustCompile(`^2\.\d+\.\d+$`)
+
+// asyncAPIProtocolTypes maps the server protocols of an AsyncAPI document to the API type
+var asyncAPIProtocolTypes = map[string]string{
+	"ws":     APITypeWS,
+	"wss":    APITypeWS,
+	"sse":    APITypeSSE,
+	"websub": APITypeWebSub,
+	"http":   APITypeWebSub,
+	"https":  APITypeWebSub,
+}
+
+// asyncAPIDocument holds the fields of an AsyncAPI 2.x document used to populate the API definition
+type asyncAPIDocument struct {
+	AsyncAPI string `json:"asyncapi"`
+	Info     *struct {
+		Title       string `json:"title"`
+		Version     string `json:"version"`
+		Description string `json:"description"`
+	} `json:"info"`
+	Servers map[string]struct {
+		URL      string `json:"url"`
+		Protocol string `json:"protocol"`
+	} `json:"servers"`
+	Channels map[string]struct {
+		Subscribe json.RawMessage `json:"subscribe"`
+		Publish   json.RawMessage `json:"publish"`
+	} `json:"channels"`
+	Tags                []Tag              `json:"tags"`
+	BasePath            *string            `json:"x-wso2-basePath"`
+	Cors                *CorsConfiguration `json:"x-wso2-cors"`
+	ProductionEndpoints *Endpoints         `json:"x-wso2-production-endpoints"`
+	SandboxEndpoints    *Endpoints         `json:"x-wso2-sandbox-endpoints"`
+}
+
+// parseAsyncAPI parses and validates the structure of an AsyncAPI 2.x document given in JSON
+func parseAsyncAPI(document []byte) (*asyncAPIDocument, error) {
+	doc := &asyncAPIDocument{}
+	if err := json.Unmarshal(document, doc); err != nil {
+		return nil, fmt.Errorf("invalid AsyncAPI document: %w", err)
+	}
+	if !asyncAPIVersionPattern.MatchString(doc.AsyncAPI) {
+		return nil, fmt.Errorf("invalid AsyncAPI document: unsupported asyncapi version %q", doc.AsyncAPI)
+	}
+	if doc.Info == nil || doc.Info.Title == "" || doc.Info.Version == "" {
+		return nil, errors.New("invalid AsyncAPI document: info.title and info.version are required")
+	}
+	if len(doc.Channels) == 0 {
+		return nil, errors.New("invalid AsyncAPI document: at least one channel is required")
+	}
+	return doc, nil
+}
+
+// serverNames returns the names of the servers of the document in order
+func (doc *asyncAPIDocument) serverNames() []string {
+	names := make([]string, 0, len(doc.Servers))
+	for name := range doc.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apiType resolves the API type from the protocols of the servers. All the servers should resolve to the same type.
+func (doc *asyncAPIDocument) apiType() (string, error) {
+	apiType := ""
+	for _, name := range doc.serverNames() {
+		protocol := strings.ToLower(doc.Servers[name].Protocol)
+		serverType, ok := asyncAPIProtocolTypes[protocol]
+		if !ok {
+			return "", fmt.Errorf("unsupported protocol %q of server %q; supported protocols are ws, wss, sse, "+
+				"websub, http and https", doc.Servers[name].Protocol, name)
+		}
+		if apiType != "" && apiType != serverType {
+			return "", fmt.Errorf("servers of the AsyncAPI document use protocols of different API types: %s, %s",
+				apiType, serverType)
+		}
+		apiType = serverType
+	}
+	if apiType == "" {
+		return "", errors.New("unable to determine the API type; provide a server with the protocol of the API")
+	}
+	return apiType, nil
+}
+
+// serverURLs returns the URLs of the servers with the protocol prepended when the URL does not have a scheme
+func (doc *asyncAPIDocument) serverURLs() []string {
+	var urls []string
+	for _, name := range doc.serverNames() {
+		server := doc.Servers[name]
+		if server.URL == "" {
+			continue
+		}
+		if strings.Contains(server.URL, "://") {
+			urls = append(urls, server.URL)
+		} else {
+			urls = append(urls, strings.ToLower(server.Protocol)+"://"+server.URL)
+		}
+	}
+	return urls
+}
+
+// asyncAPIOperations maps the channels of the document to the topics of the API. The publish operations are only
+// mapped for WebSocket APIs, as SSE and WebSub APIs only allow subscribing to the topics.
+func asyncAPIOperations(doc *asyncAPIDocument, apiType string) []interface{} {
+	channels := make([]string, 0, len(doc.Channels))
+	for channel := range doc.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	operations := []interface{}{}
+	for _, channel := range channels {
+		verbs := []string{"SUBSCRIBE"}
+		if apiType == APITypeWS && doc.Channels[channel].Publish != nil {
+			verbs = append(verbs, "PUBLISH")
+		}
+		for _, verb := range verbs {
+			operations = append(operations, map[string]interface{}{
+				"target":           channel,
+				"verb":             verb,
+				"authType":         "Application & Application User",
+				"throttlingPolicy": "Unlimited",
+			})
+		}
+	}
+	return operations
+}
+
+// AsyncAPIPopulate populates the API definition from an AsyncAPI 2.x document given in JSON. The API type is
+// resolved from the server protocols and the channels are mapped to the topics of the API.
+func AsyncAPIPopulate(def *APIDTODefinition, document []byte) error {
+	doc, err := parseAsyncAPI(document)
+	if err != nil {
+		return err
+	}
+	apiType, err := doc.apiType()
+	if err != nil {
+		return err
+	}
+	def.Name = doc.Info.Title
+	def.Version = doc.Info.Version
+	def.Provider = "admin"
+	def.Description = doc.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	def.Type = apiType
+	def.Tags = make([]string, len(doc.Tags))
+	for i, tag := range doc.Tags {
+		def.Tags[i] = tag.Name
+	}
+	def.Operations = asyncAPIOperations(doc, apiType)
+
+	// override basepath if wso2 extension provided
+	if doc.BasePath != nil {
+		populateWSO2BasePath(def, *doc.BasePath)
+	}
+	trimDefinitionSpaces(def)
+
+	if doc.Cors != nil {
+		doc.Cors.CorsConfigurationEnabled = true
+		def.CorsConfiguration = doc.Cors
+	}
+
+	switch apiType {
+	case APITypeWebSub:
+		// WebSub APIs do not have a backend, the gateway acts as the hub
+		def.EndpointConfig = nil
+		def.WebsubSubscriptionConfiguration = map[string]interface{}{
+			"enable":           false,
+			"secret":           "",
+			"signingAlgorithm": "SHA1",
+			"signatureHeader":  "x-hub-signature",
+		}
+		return nil
+	case APITypeWS:
+		def.Transport = []string{"ws", "wss"}
+	}
+
+	if doc.ProductionEndpoints == nil && doc.SandboxEndpoints == nil {
+		// Use the servers of the document as the backend when the wso2 extensions are not provided
+		urls := doc.serverURLs()
+		switch {
+		case len(urls) > 0:
+			doc.ProductionEndpoints = &Endpoints{Urls: urls[:1]}
+			doc.SandboxEndpoints = &Endpoints{Urls: urls[:1]}
+		case apiType == APITypeWS:
+			doc.ProductionEndpoints = &Endpoints{Urls: []string{"ws://localhost:8080"}}
+			doc.SandboxEndpoints = &Endpoints{Urls: []string{"ws://localhost:8081"}}
+		default:
+			// Keep the default endpoints of the project
+			return nil
+		}
+	}
+	if doc.ProductionEndpoints == nil {
+		doc.ProductionEndpoints = &Endpoints{}
+	}
+	if doc.SandboxEndpoints == nil {
+		doc.SandboxEndpoints = &Endpoints{}
+	}
+	if err := populateWSO2Endpoints(def, doc.ProductionEndpoints, doc.SandboxEndpoints); err != nil {
+		return err
+	}
+	return setAsyncAPIEndpointType(def, apiType)
+}
+
+// setAsyncAPIEndpointType sets the endpoint type of WebSocket APIs, which use ws endpoints instead of http
+// endpoints
+func setAsyncAPIEndpointType(def *APIDTODefinition, apiType string) error {
+	if apiType != APITypeWS {
+		return nil
+	}
+	endpointConfig, ok := def.EndpointConfig.(*map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected endpoint config of the WebSocket API: %v", def.EndpointConfig)
+	}
+	if (*endpointConfig)["endpoint_type"] == EpHttp {
+		(*endpointConfig)["endpoint_type"] = "ws"
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncAPIPopulateWebSocket(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/chat_asyncapi.yaml")
+	assert.Nil(t, err, "err should be nil")
+	document, err := yaml.YAMLToJSON(content)
+	assert.Nil(t, err, "err should be nil")
+
+	var def APIDTODefinition
+	err = AsyncAPIPopulate(&def, document)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "ChatAPI", def.Name, "Should return correct api name")
+	assert.Equal(t, "1.0.0", def.Version)
+	assert.Equal(t, "/chat/1.0.0", def.Context)
+	assert.Equal(t, APITypeWS, def.Type)
+	assert.Equal(t, []string{"ws", "wss"}, def.Transport)
+	assert.Equal(t, []string{"chat"}, def.Tags)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"target": "/notifications", "verb": "SUBSCRIBE",
+			"authType": "Application & Application User", "throttlingPolicy": "Unlimited"},
+		map[string]interface{}{"target": "/rooms/{roomId}", "verb": "SUBSCRIBE",
+			"authType": "Application & Application User", "throttlingPolicy": "Unlimited"},
+		map[string]interface{}{"target": "/rooms/{roomId}", "verb": "PUBLISH",
+			"authType": "Application & Application User", "throttlingPolicy": "Unlimited"},
+	}, def.Operations)
+
+	endpointConfig, ok := def.EndpointConfig.(*map[string]interface{})
+	if assert.True(t, ok, "should have endpoint config") {
+		assert.Equal(t, "ws", (*endpointConfig)["endpoint_type"])
+		assert.Equal(t, map[string]interface{}{"url": "wss://ws.example.com/chat"},
+			(*endpointConfig)["production_endpoints"])
+	}
+}
+
+func TestAsyncAPIPopulateSSE(t *testing.T) {
+	var def APIDTODefinition
+	err := AsyncAPIPopulate(&def, []byte(`{"asyncapi": "2.6.0", "info": {"title": "Stocks", "version": "v1"},
+		"servers": {"prod": {"url": "http://stocks.example.com", "protocol": "sse"}},
+		"channels": {"/prices": {"subscribe": {}, "publish": {}}}}`))
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, APITypeSSE, def.Type)
+	assert.Equal(t, "/Stocks", def.Context)
+	assert.Len(t, def.Operations, 1, "SSE APIs should only have subscribe operations")
+	endpointConfig, ok := def.EndpointConfig.(*map[string]interface{})
+	if assert.True(t, ok, "should have endpoint config") {
+		assert.Equal(t, EpHttp, (*endpointConfig)["endpoint_type"])
+	}
+}
+
+func TestAsyncAPIPopulateWebSub(t *testing.T) {
+	def := APIDTODefinition{EndpointConfig: map[string]interface{}{"endpoint_type": "http"}}
+	err := AsyncAPIPopulate(&def, []byte(`{"asyncapi": "2.0.0", "info": {"title": "Repos", "version": "1.0.0"},
+		"servers": {"hub": {"url": "https://hub.example.com", "protocol": "websub"}},
+		"channels": {"push": {"subscribe": {}}, "issues": {"subscribe": {}}}}`))
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, APITypeWebSub, def.Type)
+	assert.Nil(t, def.EndpointConfig, "WebSub APIs should not have endpoints")
+	assert.NotNil(t, def.WebsubSubscriptionConfiguration)
+	assert.Len(t, def.Operations, 2)
+}
+
+func TestAsyncAPIPopulateInvalid(t *testing.T) {
+	invalidDocuments := map[string]string{
+		"unsupported asyncapi version \"3.0.0\"": `{"asyncapi": "3.0.0", "info": {"title": "Chat",
+			"version": "1.0.0"}, "channels": {"chat": {}}}`,
+		"info.title and info.version are required": `{"asyncapi": "2.0.0", "info": {"title": "Chat"},
+			"channels": {"chat": {}}}`,
+		"at least one channel is required": `{"asyncapi": "2.0.0", "info": {"title": "Chat",
+			"version": "1.0.0"}, "servers": {"prod": {"url": "ws://localhost", "protocol": "ws"}}}`,
+		"unable to determine the API type": `{"asyncapi": "2.0.0", "info": {"title": "Chat",
+			"version": "1.0.0"}, "channels": {"chat": {}}}`,
+		"unsupported protocol \"kafka\"": `{"asyncapi": "2.0.0", "info": {"title": "Chat", "version": "1.0.0"},
+			"servers": {"prod": {"url": "localhost:9092", "protocol": "kafka"}}, "channels": {"chat": {}}}`,
+		"different API types": `{"asyncapi": "2.0.0", "info": {"title": "Chat", "version": "1.0.0"},
+			"servers": {"a": {"url": "ws://localhost", "protocol": "ws"},
+			"b": {"url": "http://localhost", "protocol": "sse"}}, "channels": {"chat": {}}}`,
+	}
+	for expectedError, document := range invalidDocuments {
+		var def APIDTODefinition
+		err := AsyncAPIPopulate(&def, []byte(document))
+		if assert.NotNil(t, err, "err should not be nil") {
+			assert.Contains(t, err.Error(), expectedError)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// oai31VersionPattern matches the versions of the OpenAPI 3.1 specification
+var oai31VersionPattern = regexp.MustCompile(`^3\.1\.\d+$`)
+
+// oai31Document holds the fields of an OpenAPI 3.1 document used to validate it and to populate the API
+// definition. The schemas are kept raw, as they follow JSON Schema 2020-12 which the Swagger 2.0 and OpenAPI 3.0
+// models cannot represent (ex: type arrays, numeric exclusiveMinimum, const).
+type oai31Document struct {
+	OpenAPI string `json:"openapi"`
+	Info    *struct {
+		Title       string `json:"title"`
+		Version     string `json:"version"`
+		Description string `json:"description"`
+	} `json:"info"`
+	JSONSchemaDialect   string                                `json:"jsonSchemaDialect"`
+	Tags                []Tag                                 `json:"tags"`
+	Paths               map[string]map[string]json.RawMessage `json:"paths"`
+	Webhooks            map[string]map[string]json.RawMessage `json:"webhooks"`
+	Components          map[string]json.RawMessage            `json:"components"`
+	BasePath            *string                               `json:"x-wso2-basePath"`
+	Cors                *CorsConfiguration                    `json:"x-wso2-cors"`
+	ProductionEndpoints *Endpoints                            `json:"x-wso2-production-endpoints"`
+	SandboxEndpoints    *Endpoints                            `json:"x-wso2-sandbox-endpoints"`
+}
+
+// IsOAI31 returns true if the given JSON document declares a version of the OpenAPI 3.1 specification
+func IsOAI31(document []byte) bool {
+	var header struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(document, &header); err != nil {
+		return false
+	}
+	return oai31VersionPattern.MatchString(header.OpenAPI)
+}
+
+// parseOAI31 parses and validates the structure of an OpenAPI 3.1 document given in JSON
+func parseOAI31(document []byte) (*oai31Document, error) {
+	doc := &oai31Document{}
+	if err := json.Unmarshal(document, doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI 3.1 document: %w", err)
+	}
+	if !oai31VersionPattern.MatchString(doc.OpenAPI) {
+		return nil, fmt.Errorf("invalid OpenAPI 3.1 document: unsupported openapi version %q", doc.OpenAPI)
+	}
+	if doc.Info == nil || doc.Info.Title == "" || doc.Info.Version == "" {
+		return nil, errors.New("invalid OpenAPI 3.1 document: info.title and info.version are required")
+	}
+	// Unlike OpenAPI 3.0, the paths are optional in OpenAPI 3.1 as long as webhooks or components are given
+	if doc.Paths == nil && doc.Webhooks == nil && doc.Components == nil {
+		return nil, errors.New("invalid OpenAPI 3.1 document: at least one of paths, webhooks or components " +
+			"is required")
+	}
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if !strings.HasPrefix(p, "/") {
+			return nil, fmt.Errorf("invalid OpenAPI 3.1 document: path %q should begin with a slash", p)
+		}
+	}
+	return doc, nil
+}
+
+// OAI31Populate populates the API definition from an OpenAPI 3.1 document given in JSON, the same way
+// Swagger2Populate does for the Swagger 2.0 and OpenAPI 3.0 documents
+func OAI31Populate(def *APIDTODefinition, document []byte) error {
+	doc, err := parseOAI31(document)
+	if err != nil {
+		return err
+	}
+	def.Name = doc.Info.Title
+	def.Version = doc.Info.Version
+	def.Provider = "admin"
+	def.Description = doc.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	def.Tags = make([]string, len(doc.Tags))
+	for i, tag := range doc.Tags {
+		def.Tags[i] = tag.Name
+	}
+
+	// override basepath if wso2 extension provided
+	if doc.BasePath != nil {
+		populateWSO2BasePath(def, *doc.BasePath)
+	}
+	trimDefinitionSpaces(def)
+
+	if doc.Cors != nil {
+		doc.Cors.CorsConfigurationEnabled = true
+		def.CorsConfiguration = doc.Cors
+	}
+	if doc.ProductionEndpoints == nil && doc.SandboxEndpoints == nil {
+		return nil
+	}
+	if doc.ProductionEndpoints == nil {
+		doc.ProductionEndpoints = &Endpoints{}
+	}
+	if doc.SandboxEndpoints == nil {
+		doc.SandboxEndpoints = &Endpoints{}
+	}
+	return populateWSO2Endpoints(def, doc.ProductionEndpoints, doc.SandboxEndpoints)
+}
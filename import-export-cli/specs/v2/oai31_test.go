@@ -0,0 +1,84 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadOAI31TestDocument(t *testing.T) []byte {
+	content, err := ioutil.ReadFile("testdata/petstore_oas31.yaml")
+	assert.Nil(t, err, "err should be nil")
+	document, err := yaml.YAMLToJSON(content)
+	assert.Nil(t, err, "err should be nil")
+	return document
+}
+
+func TestIsOAI31(t *testing.T) {
+	assert.True(t, IsOAI31(loadOAI31TestDocument(t)))
+	assert.False(t, IsOAI31([]byte(`{"openapi": "3.0.3"}`)))
+	assert.False(t, IsOAI31([]byte(`{"swagger": "2.0"}`)))
+}
+
+func TestOAI31Populate(t *testing.T) {
+	var def APIDTODefinition
+	err := OAI31Populate(&def, loadOAI31TestDocument(t))
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "SwaggerPetstore", def.Name, "Should return correct api name")
+	assert.Equal(t, "1.0.0", def.Version)
+	assert.Equal(t, "/petstore/v1/1.0.0", def.Context)
+	assert.Equal(t, []string{"pet", "store"}, def.Tags)
+	cors, ok := def.CorsConfiguration.(*CorsConfiguration)
+	assert.True(t, ok, "should have cors configuration")
+	assert.True(t, cors.CorsConfigurationEnabled)
+	assert.Equal(t, []string{"example.com"}, cors.AccessControlAllowOrigins)
+	assert.NotNil(t, def.EndpointConfig, "should have endpoint config")
+}
+
+func TestOAI31PopulateInvalid(t *testing.T) {
+	invalidDocuments := map[string]string{
+		"info.title and info.version are required": `{"openapi": "3.1.0", "info": {"title": "Petstore"},
+			"paths": {}}`,
+		"at least one of paths, webhooks or components is required": `{"openapi": "3.1.0",
+			"info": {"title": "Petstore", "version": "1.0.0"}}`,
+		"path \"pets\" should begin with a slash": `{"openapi": "3.1.0",
+			"info": {"title": "Petstore", "version": "1.0.0"}, "paths": {"pets": {}}}`,
+	}
+	for expectedError, document := range invalidDocuments {
+		var def APIDTODefinition
+		err := OAI31Populate(&def, []byte(document))
+		if assert.NotNil(t, err, "err should not be nil") {
+			assert.Contains(t, err.Error(), expectedError)
+		}
+	}
+}
+
+func TestOAI31PopulateWebhooksOnly(t *testing.T) {
+	var def APIDTODefinition
+	err := OAI31Populate(&def, []byte(`{"openapi": "3.1.0", "info": {"title": "Events", "version": "2.0.0"},
+		"webhooks": {"newPet": {"post": {}}}}`))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "/Events", def.Context)
+	assert.Nil(t, def.EndpointConfig)
+}
@@ -0,0 +1,139 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// APITypeGRPC is the API type of the APIs defined with protocol buffers
+const APITypeGRPC = "GRPC"
+
+var (
+	// String literals are matched along with the comments, so that a "//" inside a string is not taken as a comment
+	protoCommentPattern = regexp.MustCompile(`(?s)"(?:[^"\\\n]|\\.)*"|//[^\n]*|/\*.*?\*/`)
+	protoPackagePattern = regexp.MustCompile(`\bpackage\s+([\w.]+)\s*;`)
+	protoImportPattern  = regexp.MustCompile(`\bimport\s+(?:public\s+|weak\s+)?"([^"]+)"\s*;`)
+	protoServicePattern = regexp.MustCompile(`\bservice\s+(\w+)\s*\{`)
+	protoMethodPattern  = regexp.MustCompile(`\brpc\s+(\w+)\s*\(`)
+)
+
+// ProtoService is a gRPC service declared in a proto file
+type ProtoService struct {
+	// Name of the service qualified with the package of the proto file
+	Name    string
+	Methods []string
+}
+
+// ProtoImports returns the files imported by the proto file
+func ProtoImports(document []byte) []string {
+	var imports []string
+	for _, match := range protoImportPattern.FindAllStringSubmatch(stripProtoComments(document), -1) {
+		imports = append(imports, match[1])
+	}
+	return imports
+}
+
+// ParseProtoServices returns the services declared in the proto file in the order they are declared
+func ParseProtoServices(document []byte) ([]ProtoService, error) {
+	content := stripProtoComments(document)
+	protoPackage := ""
+	if match := protoPackagePattern.FindStringSubmatch(content); match != nil {
+		protoPackage = match[1] + "."
+	}
+	var services []ProtoService
+	for _, location := range protoServicePattern.FindAllStringSubmatchIndex(content, -1) {
+		name := content[location[2]:location[3]]
+		body, err := protoBlockBody(content, location[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid proto file: service %s: %w", name, err)
+		}
+		service := ProtoService{Name: protoPackage + name}
+		for _, match := range protoMethodPattern.FindAllStringSubmatch(body, -1) {
+			service.Methods = append(service.Methods, match[1])
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// ProtoPopulate populates the API definition from a proto file. The API is named after the first service of the
+// file, and the methods of the services are mapped to the operations of the API.
+func ProtoPopulate(def *APIDTODefinition, document []byte) error {
+	services, err := ParseProtoServices(document)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		return errors.New("invalid proto file: at least one service is required")
+	}
+	operations := []interface{}{}
+	for _, service := range services {
+		for _, method := range service.Methods {
+			operations = append(operations, map[string]interface{}{
+				"target":           service.Name,
+				"verb":             method,
+				"authType":         "Application & Application User",
+				"throttlingPolicy": "Unlimited",
+			})
+		}
+	}
+	if len(operations) == 0 {
+		return errors.New("invalid proto file: at least one rpc method is required")
+	}
+
+	name := services[0].Name[strings.LastIndex(services[0].Name, ".")+1:]
+	def.Name = name
+	def.Provider = "admin"
+	def.Context = fmt.Sprintf("/%s", strings.ToLower(name))
+	def.Type = APITypeGRPC
+	def.Operations = operations
+	trimDefinitionSpaces(def)
+	return nil
+}
+
+// stripProtoComments removes the comments of the proto file
+func stripProtoComments(document []byte) string {
+	return protoCommentPattern.ReplaceAllStringFunc(string(document), func(match string) string {
+		if strings.HasPrefix(match, "\"") {
+			return match
+		}
+		return ""
+	})
+}
+
+// protoBlockBody returns the content of the block opened right before start, up to the matching closing brace
+func protoBlockBody(content string, start int) (string, error) {
+	depth := 1
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[start:i], nil
+			}
+		}
+	}
+	return "", errors.New("missing closing brace")
+}
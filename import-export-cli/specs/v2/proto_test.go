@@ -0,0 +1,65 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtoPopulate(t *testing.T) {
+	document, err := ioutil.ReadFile("testdata/order_service.proto")
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, []string{"google/protobuf/empty.proto", "shop/common/money.proto"}, ProtoImports(document))
+
+	var def APIDTODefinition
+	err = ProtoPopulate(&def, document)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "OrderService", def.Name, "Should return correct api name")
+	assert.Equal(t, "/orderservice", def.Context)
+	assert.Equal(t, APITypeGRPC, def.Type)
+
+	targets := []string{}
+	verbs := []string{}
+	for _, operation := range def.Operations {
+		targets = append(targets, operation.(map[string]interface{})["target"].(string))
+		verbs = append(verbs, operation.(map[string]interface{})["verb"].(string))
+	}
+	assert.Equal(t, []string{"shop.orders.v1.OrderService", "shop.orders.v1.OrderService",
+		"shop.orders.v1.OrderService", "shop.orders.v1.OrderAdmin"}, targets)
+	assert.Equal(t, []string{"CreateOrder", "GetOrder", "WatchOrders", "PurgeOrders"}, verbs)
+}
+
+func TestProtoPopulateInvalid(t *testing.T) {
+	invalidDocuments := map[string]string{
+		"at least one service is required":    `syntax = "proto3"; message Empty {}`,
+		"at least one rpc method is required": `syntax = "proto3"; service Empty {}`,
+		"missing closing brace":               `syntax = "proto3"; service Echo { rpc Echo (A) returns (A);`,
+	}
+	for expectedError, document := range invalidDocuments {
+		var def APIDTODefinition
+		err := ProtoPopulate(&def, []byte(document))
+		if assert.NotNil(t, err, "err should not be nil") {
+			assert.Contains(t, err.Error(), expectedError)
+		}
+	}
+}
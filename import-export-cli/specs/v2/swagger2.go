@@ -271,24 +271,9 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 
 	// override basepath if wso2 extension provided
 	if basepath, ok := swagger2XWO2BasePath(document); ok {
-		def.Context = path.Clean(basepath)
-		if !strings.Contains(basepath, "{version}") {
-			if strings.Contains(basepath, def.Version) {
-				def.Context = path.Clean(strings.Replace(basepath, def.Version, "",
-					strings.LastIndex(basepath, def.Version)))
-			} else {
-				def.Context = path.Clean(basepath)
-			}
-			def.IsDefaultVersion = true
-		} else {
-			def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
-		}
+		populateWSO2BasePath(def, basepath)
 	}
-
-	// trim spaces if available
-	def.Name = strings.ReplaceAll(def.Name, " ", "")
-	def.Version = strings.ReplaceAll(def.Version, " ", "")
-	def.Context = strings.ReplaceAll(def.Context, " ", "")
+	trimDefinitionSpaces(def)
 
 	cors, ok, err := swagger2XWSO2Cors(document)
 	if err != nil && ok {
@@ -306,17 +291,48 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 		return err
 	}
 	if foundProdEp || foundSandboxEp {
-		ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
-		if err != nil {
-			return err
-		}
-		var endpointConfig map[string]interface{}
-		err = json.Unmarshal([]byte(ep), &endpointConfig)
-		if err != nil {
-			return err
+		return populateWSO2Endpoints(def, prodEp, sandboxEp)
+	}
+	return nil
+}
+
+// populateWSO2BasePath sets the context of the API from the x-wso2-basePath extension. The API is marked as the
+// default version if the base path does not contain the version.
+func populateWSO2BasePath(def *APIDTODefinition, basepath string) {
+	def.Context = path.Clean(basepath)
+	if !strings.Contains(basepath, "{version}") {
+		if strings.Contains(basepath, def.Version) {
+			def.Context = path.Clean(strings.Replace(basepath, def.Version, "",
+				strings.LastIndex(basepath, def.Version)))
+		} else {
+			def.Context = path.Clean(basepath)
 		}
-		def.EndpointConfig = &endpointConfig
+		def.IsDefaultVersion = true
+	} else {
+		def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
 	}
+}
+
+// trimDefinitionSpaces removes the spaces in the name, version and context of the API
+func trimDefinitionSpaces(def *APIDTODefinition) {
+	def.Name = strings.ReplaceAll(def.Name, " ", "")
+	def.Version = strings.ReplaceAll(def.Version, " ", "")
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+}
+
+// populateWSO2Endpoints sets the endpoint config of the API from the x-wso2-production-endpoints and
+// x-wso2-sandbox-endpoints extensions
+func populateWSO2Endpoints(def *APIDTODefinition, prodEp, sandboxEp *Endpoints) error {
+	ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
+	if err != nil {
+		return err
+	}
+	var endpointConfig map[string]interface{}
+	err = json.Unmarshal([]byte(ep), &endpointConfig)
+	if err != nil {
+		return err
+	}
+	def.EndpointConfig = &endpointConfig
 	return nil
 }
 
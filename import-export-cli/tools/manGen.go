@@ -0,0 +1,23 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
+)
+
+// Generates the man pages of apictl, which are bundled with the distribution for offline help
+func main() {
+	outputDir := "man"
+	if len(os.Args) > 1 {
+		outputDir = os.Args[1]
+	}
+	log.Println("Generating man pages in " + outputDir + "...")
+	err := cmd.GenerateDocs("man", outputDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
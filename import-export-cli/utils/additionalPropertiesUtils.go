@@ -0,0 +1,152 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// reservedAdditionalPropertyNames are the names which cannot be used for the additional properties of an API as
+// they are used as the search prefixes of the Publisher and the Developer Portal
+var reservedAdditionalPropertyNames = []string{"name", "version", "context", "status", "description", "provider",
+	"tag", "tags", "doc", "subcontext", "api-category", "lcstate"}
+
+// AdditionalProperty is a custom property of an API (ex: owner team, cost center, runbook link). The property is
+// displayed in the Developer Portal only if Display is set.
+type AdditionalProperty struct {
+	Name    string `yaml:"name" json:"name"`
+	Value   string `yaml:"value" json:"value"`
+	Display bool   `yaml:"display" json:"display"`
+}
+
+// ValidateAdditionalProperties checks whether the names of the additional properties can be used in an API
+func ValidateAdditionalProperties(properties []AdditionalProperty) error {
+	var violations []string
+	for _, property := range properties {
+		name := property.Name
+		if strings.TrimSpace(name) == "" {
+			violations = append(violations, "name of an additional property should not be empty")
+		} else if strings.ContainsAny(name, " \t") {
+			violations = append(violations, fmt.Sprintf("name of the additional property %q should not "+
+				"contain spaces", name))
+		} else {
+			for _, reserved := range reservedAdditionalPropertyNames {
+				if strings.EqualFold(name, reserved) {
+					violations = append(violations, fmt.Sprintf("%q is reserved and cannot be used as the name "+
+						"of an additional property", name))
+					break
+				}
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return errors.New("invalid additional properties: " + strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// SetAdditionalProperties adds the given additional properties to the API definition, replacing the values and the
+// display setting of the properties with the same names. The additional properties map is updated as well when it
+// is present, as the Publisher may read the properties from either of them. Returns the number of properties
+// replaced.
+func SetAdditionalProperties(apiDefinition *gabs.Container, properties []AdditionalProperty) (int, error) {
+	existing, _ := apiDefinition.Path("data.additionalProperties").Data().([]interface{})
+	propertiesMap, _ := apiDefinition.Path("data.additionalPropertiesMap").Data().(map[string]interface{})
+	replaced := 0
+	for _, property := range properties {
+		value := map[string]interface{}{"name": property.Name, "value": property.Value, "display": property.Display}
+		found := false
+		for i, item := range existing {
+			if current, ok := item.(map[string]interface{}); ok && current["name"] == property.Name {
+				existing[i] = value
+				found = true
+			}
+		}
+		if found {
+			replaced++
+		} else {
+			existing = append(existing, value)
+		}
+		if len(propertiesMap) > 0 {
+			propertiesMap[property.Name] = value
+		}
+	}
+	if _, err := apiDefinition.SetP(existing, "data.additionalProperties"); err != nil {
+		return replaced, err
+	}
+	return replaced, nil
+}
+
+// SetProjectAdditionalProperties adds or overrides the additional properties of the API in the project directory.
+// Only the names of the properties are logged as their values may be sensitive.
+func SetProjectAdditionalProperties(projectPath string, properties []AdditionalProperty) error {
+	if err := ValidateAdditionalProperties(properties); err != nil {
+		return err
+	}
+	for _, fileName := range []string{APIDefinitionFileYaml, APIDefinitionFileJson} {
+		path := filepath.Join(projectPath, fileName)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		isYaml := fileName == APIDefinitionFileYaml
+		if isYaml {
+			content, err = YamlToJson(content)
+			if err != nil {
+				return err
+			}
+		}
+		apiDefinition, err := gabs.ParseJSON(content)
+		if err != nil {
+			return err
+		}
+		replaced, err := SetAdditionalProperties(apiDefinition, properties)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(properties))
+		for _, property := range properties {
+			names = append(names, property.Name)
+		}
+		Logln(LogPrefixInfo + fmt.Sprintf("Set the additional properties %s in %s (%d replaced)",
+			strings.Join(names, ", "), path, replaced))
+		content = apiDefinition.BytesIndent("", "  ")
+		if isYaml {
+			content, err = JsonToYaml(content)
+			if err != nil {
+				return err
+			}
+		}
+		return ioutil.WriteFile(path, content, info.Mode())
+	}
+	return fmt.Errorf("API definition file was not found in %s", projectPath)
+}
@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAdditionalProperties(t *testing.T) {
+	apiDefinition, _ := gabs.ParseJSON([]byte(`{"type": "api", "data": {"name": "PizzaShackAPI",
+		"additionalProperties": [{"name": "owner-team", "value": "pizza-dev", "display": true},
+			{"name": "runbook", "value": "https://wiki.dev/pizza", "display": false}],
+		"additionalPropertiesMap": {"owner-team": {"name": "owner-team", "value": "pizza-dev", "display": true}}}}`))
+
+	replaced, err := SetAdditionalProperties(apiDefinition, []AdditionalProperty{
+		{Name: "owner-team", Value: "pizza-ops"},
+		{Name: "cost-center", Value: "CC-1024", Display: true},
+	})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, 1, replaced, "Should replace only the existing property")
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "owner-team", "value": "pizza-ops", "display": false},
+		map[string]interface{}{"name": "runbook", "value": "https://wiki.dev/pizza", "display": false},
+		map[string]interface{}{"name": "cost-center", "value": "CC-1024", "display": true},
+	}, apiDefinition.Path("data.additionalProperties").Data(), "Should override the value and the display setting")
+	assert.Equal(t, "pizza-ops", apiDefinition.Path("data.additionalPropertiesMap.owner-team.value").Data(),
+		"Should update the additional properties map")
+	assert.Equal(t, "CC-1024", apiDefinition.Path("data.additionalPropertiesMap.cost-center.value").Data(),
+		"Should add the property to the additional properties map")
+}
+
+func TestSetProjectAdditionalProperties(t *testing.T) {
+	projectPath := t.TempDir()
+	_ = ioutil.WriteFile(filepath.Join(projectPath, APIDefinitionFileYaml),
+		[]byte("type: api\nversion: v4.2.0\ndata:\n  name: PizzaShackAPI\n"), 0644)
+
+	err := SetProjectAdditionalProperties(projectPath, []AdditionalProperty{{Name: "cost-center", Value: "CC-1024"}})
+	assert.Nil(t, err, "Error should be null")
+	content, _ := ioutil.ReadFile(filepath.Join(projectPath, APIDefinitionFileYaml))
+	assert.Contains(t, string(content), "additionalProperties:\n  - display: false\n    name: cost-center\n"+
+		"    value: CC-1024\n", "Should add the property to the API definition")
+}
+
+func TestValidateAdditionalProperties(t *testing.T) {
+	assert.Nil(t, ValidateAdditionalProperties([]AdditionalProperty{{Name: "owner-team", Value: "payments"}}))
+
+	err := ValidateAdditionalProperties([]AdditionalProperty{{Name: "", Value: "x"}, {Name: "owner team"},
+		{Name: "Provider"}})
+	assert.EqualError(t, err, `invalid additional properties: name of an additional property should not be empty, `+
+		`name of the additional property "owner team" should not contain spaces, `+
+		`"Provider" is reserved and cannot be used as the name of an additional property`)
+}
@@ -0,0 +1,166 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SetAlias adds an alias to the main config, or replaces the command of an existing alias
+// @param name : Name of the alias
+// @param command : Command the alias is expanded to, without the apictl prefix
+// @param mainConfigFilePath : Path to the main config file
+// @return error
+func SetAlias(name, command, mainConfigFilePath string) error {
+	if err := validateAliasName(name); err != nil {
+		return err
+	}
+	args, err := SplitCommandLine(command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("the command of the alias cannot be blank")
+	}
+	if args[0] == ProjectName {
+		return fmt.Errorf("the command of the alias should not start with %s", ProjectName)
+	}
+
+	unlock, err := LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	mainConfig := GetMainConfigFromFile(mainConfigFilePath)
+	if mainConfig.Aliases == nil {
+		mainConfig.Aliases = make(map[string]string)
+	}
+	mainConfig.Aliases[name] = command
+	WriteLockedConfigFile(mainConfig, mainConfigFilePath)
+	return nil
+}
+
+// RemoveAlias removes an alias from the main config
+// @param name : Name of the alias
+// @param mainConfigFilePath : Path to the main config file
+// @return error
+func RemoveAlias(name, mainConfigFilePath string) error {
+	unlock, err := LockConfigFile(mainConfigFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	mainConfig := GetMainConfigFromFile(mainConfigFilePath)
+	if _, ok := mainConfig.Aliases[name]; !ok {
+		return errors.New("alias '" + name + "' not found in " + mainConfigFilePath)
+	}
+	delete(mainConfig.Aliases, name)
+	WriteLockedConfigFile(mainConfig, mainConfigFilePath)
+	return nil
+}
+
+// GetAliases returns the aliases in the main config. No aliases are returned if the main config does not exist.
+// @param mainConfigFilePath : Path to the main config file
+// @return map of alias names to commands
+func GetAliases(mainConfigFilePath string) map[string]string {
+	return GetMainConfigFromFileSilently(mainConfigFilePath).Aliases
+}
+
+// ExpandAlias replaces the alias given as the first argument with the arguments of its command. The rest of the
+// arguments are passed through after the arguments of the command.
+// @param args : Command line arguments without the program name
+// @param aliases : Map of alias names to commands
+// @return expanded arguments, whether an alias was expanded, error
+func ExpandAlias(args []string, aliases map[string]string) ([]string, bool, error) {
+	if len(args) == 0 {
+		return args, false, nil
+	}
+	command, ok := aliases[args[0]]
+	if !ok {
+		return args, false, nil
+	}
+	commandArgs, err := SplitCommandLine(command)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid command of the alias '%s': %v", args[0], err)
+	}
+	return append(commandArgs, args[1:]...), true, nil
+}
+
+// SplitCommandLine splits a command into arguments similar to a POSIX shell. Single and double quotes group the
+// words of an argument and a backslash escapes the next character, except within single quotes
+// @param command : Command to split
+// @return arguments, error
+func SplitCommandLine(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, errors.New("unfinished escape sequence at the end of the command")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unclosed quote %c in the command", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func validateAliasName(name string) error {
+	if name == "" {
+		return errors.New("name of the alias cannot be blank")
+	}
+	if strings.HasPrefix(name, "-") {
+		return errors.New("name of the alias cannot start with '-'")
+	}
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return errors.New("name of the alias cannot contain spaces")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	args, err := SplitCommandLine(`import api --update  -e prod --params "prod params.yaml" --format '{{.Name}} \x' a\ b ""`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"import", "api", "--update", "-e", "prod", "--params", "prod params.yaml", "--format",
+		`{{.Name}} \x`, "a b", ""}, args)
+
+	_, err = SplitCommandLine(`get apis --format "{{.Name}}`)
+	assert.Error(t, err)
+	_, err = SplitCommandLine(`get apis \`)
+	assert.Error(t, err)
+}
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{"dep": "import api --update -e prod --params prod.yaml"}
+
+	args, expanded, err := ExpandAlias([]string{"dep", "-f", "./PizzaShackAPI"}, aliases)
+	assert.NoError(t, err)
+	assert.True(t, expanded)
+	assert.Equal(t, []string{"import", "api", "--update", "-e", "prod", "--params", "prod.yaml", "-f",
+		"./PizzaShackAPI"}, args)
+
+	args, expanded, err = ExpandAlias([]string{"get", "apis"}, aliases)
+	assert.NoError(t, err)
+	assert.False(t, expanded)
+	assert.Equal(t, []string{"get", "apis"}, args)
+
+	_, expanded, err = ExpandAlias(nil, aliases)
+	assert.NoError(t, err)
+	assert.False(t, expanded)
+}
+
+func TestSetAndRemoveAlias(t *testing.T) {
+	mainConfigFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	assert.NoError(t, ioutil.WriteFile(mainConfigFilePath, []byte("config:\n  export_directory: /tmp\n"), 0644))
+
+	assert.NoError(t, SetAlias("dep", "import api --update -e prod", mainConfigFilePath))
+	assert.NoError(t, SetAlias("apis", "get apis -e prod", mainConfigFilePath))
+	assert.NoError(t, SetAlias("dep", "import api --update -e dev", mainConfigFilePath))
+	assert.Equal(t, map[string]string{"dep": "import api --update -e dev", "apis": "get apis -e prod"},
+		GetAliases(mainConfigFilePath))
+
+	assert.Error(t, SetAlias("", "get apis", mainConfigFilePath))
+	assert.Error(t, SetAlias("-x", "get apis", mainConfigFilePath))
+	assert.Error(t, SetAlias("my apis", "get apis", mainConfigFilePath))
+	assert.Error(t, SetAlias("apis", " ", mainConfigFilePath))
+	assert.Error(t, SetAlias("apis", ProjectName+" get apis", mainConfigFilePath))
+
+	assert.NoError(t, RemoveAlias("dep", mainConfigFilePath))
+	assert.Equal(t, map[string]string{"apis": "get apis -e prod"}, GetAliases(mainConfigFilePath))
+	assert.Error(t, RemoveAlias("dep", mainConfigFilePath))
+	assert.Equal(t, "/tmp", GetMainConfigFromFile(mainConfigFilePath).Config.ExportDirectory)
+}
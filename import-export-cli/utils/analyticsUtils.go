@@ -0,0 +1,54 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTimePeriod parses a time period (ex: 7d, 12h, 30m) given to the analytics commands. In addition to the
+// units supported by time.ParseDuration, days can be specified using the "d" suffix.
+func ParseTimePeriod(period string) (time.Duration, error) {
+	period = strings.TrimSpace(period)
+	if period == "" {
+		return 0, errors.New("time period cannot be blank")
+	}
+	var duration time.Duration
+	if strings.HasSuffix(period, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(period, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid time period %q", period)
+		}
+		duration = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		duration, err = time.ParseDuration(period)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time period %q", period)
+		}
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("time period %q should be greater than zero", period)
+	}
+	return duration, nil
+}
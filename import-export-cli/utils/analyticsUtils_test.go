@@ -0,0 +1,45 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimePeriodInDays(t *testing.T) {
+	duration, err := ParseTimePeriod("7d")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, 7*24*time.Hour, duration, "Should convert days to hours")
+}
+
+func TestParseTimePeriodInHours(t *testing.T) {
+	duration, err := ParseTimePeriod("12h")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, 12*time.Hour, duration, "Should parse the duration in hours")
+}
+
+func TestParseTimePeriodInvalid(t *testing.T) {
+	_, err := ParseTimePeriod("week")
+	assert.Error(t, err, "Should return an error for an invalid time period")
+	_, err = ParseTimePeriod("0d")
+	assert.Error(t, err, "Should return an error for a zero time period")
+}
@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Match for the context of the API (ex: "  context: /pizzashack") in the data section of the api.yaml
+var reAPIYamlContext = regexp.MustCompile(`(?m)^  context:[ \t]*.*$`)
+
+// Match for the context of the API (ex: "context": "/pizzashack") in the api.json
+var reAPIJsonContext = regexp.MustCompile(`"context"\s*:\s*"[^"]*"`)
+
+// NormalizeAPIContext validates the given API context and converts it to the "/context" form
+func NormalizeAPIContext(context string) (string, error) {
+	normalized := strings.TrimSpace(context)
+	if normalized == "" || normalized == "/" {
+		return "", fmt.Errorf("API context cannot be empty")
+	}
+	if strings.ContainsAny(normalized, " \t\"") {
+		return "", fmt.Errorf("invalid API context: %s", context)
+	}
+	if !strings.HasPrefix(normalized, "/") {
+		normalized = "/" + normalized
+	}
+	return strings.TrimSuffix(normalized, "/"), nil
+}
+
+// ReplaceAPIContext replaces the context of the API definition with the given context
+func ReplaceAPIContext(content []byte, context string) []byte {
+	re, replacement := reAPIYamlContext, "  context: "+context
+	if !re.Match(content) {
+		re, replacement = reAPIJsonContext, `"context": "`+context+`"`
+	}
+	// Only the first match is the context of the API, hence the rest of the content is left as it is
+	location := re.FindIndex(content)
+	if location == nil {
+		return content
+	}
+	replaced := append([]byte{}, content[:location[0]]...)
+	replaced = append(replaced, []byte(replacement)...)
+	return append(replaced, content[location[1]:]...)
+}
+
+// SetProjectAPIContext sets the given context as the context of the API in the project directory
+func SetProjectAPIContext(projectPath, context string) error {
+	for _, fileName := range []string{APIDefinitionFileYaml, APIDefinitionFileJson} {
+		path := filepath.Join(projectPath, fileName)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		Logln(LogPrefixInfo + "Setting API context " + context + " in " + path)
+		return ioutil.WriteFile(path, ReplaceAPIContext(content, context), info.Mode())
+	}
+	return fmt.Errorf("API definition file was not found in %s", projectPath)
+}
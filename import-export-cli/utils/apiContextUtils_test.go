@@ -0,0 +1,52 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAPIContextWithoutSlash(t *testing.T) {
+	context, err := NormalizeAPIContext("tenant/pizzashack/")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "/tenant/pizzashack", context, "Should add the leading slash and remove the trailing slash")
+}
+
+func TestNormalizeAPIContextInvalid(t *testing.T) {
+	_, err := NormalizeAPIContext("/")
+	assert.Error(t, err, "Should return an error for an empty context")
+	_, err = NormalizeAPIContext("/pizza shack")
+	assert.Error(t, err, "Should return an error for a context with spaces")
+}
+
+func TestReplaceAPIContextYaml(t *testing.T) {
+	content := "type: api\ndata:\n  name: PizzaShackAPI\n  context: /pizzashack\n  operations:\n    -\n      target: /order\n"
+	replaced := ReplaceAPIContext([]byte(content), "/prod/pizzashack")
+	assert.Equal(t, "type: api\ndata:\n  name: PizzaShackAPI\n  context: /prod/pizzashack\n  operations:\n"+
+		"    -\n      target: /order\n", string(replaced), "Should only replace the context of the API")
+}
+
+func TestReplaceAPIContextJson(t *testing.T) {
+	content := `{"type": "api", "data": {"name": "PizzaShackAPI", "context": "/pizzashack"}}`
+	replaced := ReplaceAPIContext([]byte(content), "/prod/pizzashack")
+	assert.Equal(t, `{"type": "api", "data": {"name": "PizzaShackAPI", "context": "/prod/pizzashack"}}`,
+		string(replaced), "Should replace the context of the API")
+}
@@ -0,0 +1,93 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Match for the top level version of an artifact (ex: "version: v4.2.0") in yaml format
+var reArtifactYamlVersion = regexp.MustCompile(`(?m)^version:\s*v\d+\.\d+\.\d+[ \t]*$`)
+
+// Match for the top level version of an artifact (ex: "version": "v4.2.0") in json format
+var reArtifactJsonVersion = regexp.MustCompile(`"version"\s*:\s*"v\d+\.\d+\.\d+"`)
+
+// NormalizeAPIMVersion converts the given APIM version (ex: 4.6.0, v4.6.0 or 4.6) to the "vX.Y.Z" form used in the
+// artifacts and validates whether it is a supported target version
+func NormalizeAPIMVersion(version string) (string, error) {
+	normalized := strings.TrimSpace(version)
+	if normalized == "" {
+		return "", fmt.Errorf("APIM version cannot be empty")
+	}
+	if !strings.HasPrefix(normalized, "v") {
+		normalized = "v" + normalized
+	}
+	if strings.Count(normalized, ".") == 1 {
+		normalized += ".0"
+	}
+	for _, supportedVersion := range SupportedAPIMVersions {
+		if normalized == supportedVersion {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported APIM version: %s. Supported versions: %v", version, SupportedAPIMVersions)
+}
+
+// ReplaceArtifactAPIMVersion replaces the top level APIM version of an artifact with the given version
+func ReplaceArtifactAPIMVersion(content []byte, version string) []byte {
+	if reArtifactYamlVersion.Match(content) {
+		return reArtifactYamlVersion.ReplaceAll(content, []byte("version: "+version))
+	}
+	// In json artifacts the top level version is the first version field which holds an APIM version
+	location := reArtifactJsonVersion.FindIndex(content)
+	if location == nil {
+		return content
+	}
+	replaced := append([]byte{}, content[:location[0]]...)
+	replaced = append(replaced, []byte(`"version": "`+version+`"`)...)
+	return append(replaced, content[location[1]:]...)
+}
+
+// SetProjectAPIMVersion sets the given APIM version as the version of all the artifacts in the project directory
+func SetProjectAPIMVersion(projectPath, version string) error {
+	return filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if info.IsDir() || !(ext == ".yaml" || ext == ".yml" || ext == ".json") {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		replaced := ReplaceArtifactAPIMVersion(content, version)
+		if string(replaced) == string(content) {
+			return nil
+		}
+		Logln(LogPrefixInfo + "Setting APIM version " + version + " in " + path)
+		return ioutil.WriteFile(path, replaced, info.Mode())
+	})
+}
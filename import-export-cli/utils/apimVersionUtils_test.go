@@ -0,0 +1,56 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAPIMVersionWithoutPrefix(t *testing.T) {
+	version, err := NormalizeAPIMVersion("4.3.0")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "v4.3.0", version, "Should add the version prefix")
+}
+
+func TestNormalizeAPIMVersionWithoutPatch(t *testing.T) {
+	version, err := NormalizeAPIMVersion("4.6")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "v4.6.0", version, "Should add the patch version")
+}
+
+func TestNormalizeAPIMVersionUnsupported(t *testing.T) {
+	_, err := NormalizeAPIMVersion("3.2.0")
+	assert.Error(t, err, "Should return an error for an unsupported version")
+}
+
+func TestReplaceArtifactAPIMVersionYaml(t *testing.T) {
+	content := "type: api\nversion: v4.2.0\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n"
+	replaced := ReplaceArtifactAPIMVersion([]byte(content), "v4.5.0")
+	assert.Equal(t, "type: api\nversion: v4.5.0\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n",
+		string(replaced), "Should only replace the top level version")
+}
+
+func TestReplaceArtifactAPIMVersionJson(t *testing.T) {
+	content := `{"type": "api", "version": "v4.2.0", "data": {"name": "PizzaShackAPI", "version": "1.0.0"}}`
+	replaced := ReplaceArtifactAPIMVersion([]byte(content), "v4.5.0")
+	assert.Equal(t, `{"type": "api", "version": "v4.5.0", "data": {"name": "PizzaShackAPI", "version": "1.0.0"}}`,
+		string(replaced), "Should only replace the APIM version")
+}
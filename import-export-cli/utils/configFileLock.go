@@ -0,0 +1,173 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Suffix of the lock file created next to a config file while it is being written
+const configLockFileSuffix = ".lock"
+
+// Lock acquisition settings. A lock whose owner process is no longer running is removed right away. A lock whose
+// owner cannot be determined is removed only once the retries run out and it is older than configLockStaleTimeout.
+var (
+	configLockRetries       = 50
+	configLockRetryInterval = 100 * time.Millisecond
+	configLockStaleTimeout  = 30 * time.Second
+)
+
+// LockConfigFile acquires an advisory lock on the config file by exclusively creating a lock file holding the id of
+// the process next to it, so that apictl processes sharing the same config directory (ex: parallel CI jobs) do not
+// overwrite the changes of each other. For a read-modify-write, the file should be read after the lock is acquired
+// and written with WriteLockedConfigFile before the lock is released. The lock is retried while it is held by
+// another process. The returned function releases the lock.
+// @param configFilePath : Path of the config file to lock
+// @return func to release the lock
+// @return error
+func LockConfigFile(configFilePath string) (func(), error) {
+	lockFilePath := configFilePath + configLockFileSuffix
+	for attempt := 0; ; attempt++ {
+		lockFile, err := os.OpenFile(lockFilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, _ = lockFile.WriteString(strconv.Itoa(os.Getpid()))
+			_ = lockFile.Close()
+			return func() {
+				if err := os.Remove(lockFilePath); err != nil && !os.IsNotExist(err) {
+					Logln(LogPrefixWarning+"Unable to release the lock "+lockFilePath, err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		retriesExhausted := attempt >= configLockRetries
+		if removeStaleConfigLock(lockFilePath, retriesExhausted) {
+			continue
+		}
+		if retriesExhausted {
+			return nil, fmt.Errorf("timed out waiting for the lock %s held by another process; remove it if no "+
+				"other %s process is running", lockFilePath, ProjectName)
+		}
+		Logln(LogPrefixInfo + "Waiting for the lock " + lockFilePath)
+		time.Sleep(configLockRetryInterval)
+	}
+}
+
+// removeStaleConfigLock removes the lock file if the process which created it is no longer running. If the process
+// cannot be determined (ex: the lock file is being written), the lock is removed only if the retries ran out and the
+// lock is older than configLockStaleTimeout.
+// @param lockFilePath : Path of the lock file
+// @param retriesExhausted : Whether the retries to acquire the lock ran out
+// @return true if the stale lock was removed or released in the meantime
+func removeStaleConfigLock(lockFilePath string, retriesExhausted bool) bool {
+	info, err := os.Stat(lockFilePath)
+	if err != nil {
+		// The lock was released in the meantime
+		return os.IsNotExist(err)
+	}
+	content, err := ioutil.ReadFile(lockFilePath)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	if pid, err := strconv.Atoi(strings.TrimSpace(string(content))); err == nil && pid > 0 {
+		if isProcessRunning(pid) {
+			return false
+		}
+		Logln(LogPrefixWarning + "Removing the lock " + lockFilePath + " of the process " + strconv.Itoa(pid) +
+			" which is no longer running")
+	} else {
+		if !retriesExhausted || time.Since(info.ModTime()) < configLockStaleTimeout {
+			return false
+		}
+		Logln(LogPrefixWarning + "Removing the stale lock " + lockFilePath)
+	}
+	err = os.Remove(lockFilePath)
+	return err == nil || os.IsNotExist(err)
+}
+
+// isProcessRunning checks whether a process with the given id is running
+func isProcessRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		// Only returned on Windows, when the process does not exist
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	// Signal 0 checks the existence of the process without sending a signal. EPERM means that the process exists
+	// but is owned by another user.
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+// WriteFileAtomically writes the data to a temporary file in the same directory and renames it over the target, so
+// that readers never observe a partially written file
+// @param filePath : Path of the file to write
+// @param data : Content of the file
+// @param perm : Permissions of the file
+// @return error
+func WriteFileAtomically(filePath string, data []byte, perm os.FileMode) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	tempFilePath := tempFile.Name()
+	// The temporary file is left behind only if the rename fails
+	defer os.Remove(tempFilePath)
+
+	if _, err = tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tempFilePath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tempFilePath, filePath)
+}
+
+// writeConfigFileWithLock writes the config file atomically while holding the lock of the file
+// @param configFilePath : Path of the config file
+// @param data : Content of the config file
+// @return error
+func writeConfigFileWithLock(configFilePath string, data []byte) error {
+	unlock, err := LockConfigFile(configFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return WriteFileAtomically(configFilePath, data, 0644)
+}
@@ -0,0 +1,145 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestWriteConfigFileWithLockConcurrently(t *testing.T) {
+	defer func(retries int, interval time.Duration) {
+		configLockRetries, configLockRetryInterval = retries, interval
+	}(configLockRetries, configLockRetryInterval)
+	configLockRetries, configLockRetryInterval = 1000, time.Millisecond
+
+	configFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			config := MainConfig{Environments: map[string]EnvEndpoints{
+				fmt.Sprintf("env%d", i): {ApiManagerEndpoint: "https://localhost:9443"},
+			}}
+			data, _ := yaml.Marshal(&config)
+			errs <- writeConfigFileWithLock(configFilePath, data)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err, "err should be nil")
+	}
+
+	// The file should contain exactly one of the written configurations and no temporary or lock files remain
+	data, err := ioutil.ReadFile(configFilePath)
+	assert.Nil(t, err, "err should be nil")
+	var config MainConfig
+	assert.Nil(t, yaml.Unmarshal(data, &config), "config file should not be corrupted")
+	assert.Len(t, config.Environments, 1)
+	files, _ := ioutil.ReadDir(filepath.Dir(configFilePath))
+	assert.Len(t, files, 1, "only the config file should remain")
+}
+
+func TestLockConfigFileTimeout(t *testing.T) {
+	defer func(retries int, interval time.Duration) {
+		configLockRetries, configLockRetryInterval = retries, interval
+	}(configLockRetries, configLockRetryInterval)
+	configLockRetries, configLockRetryInterval = 2, time.Millisecond
+
+	configFilePath := filepath.Join(t.TempDir(), "env_keys_all.yaml")
+	unlock, err := LockConfigFile(configFilePath)
+	assert.Nil(t, err, "err should be nil")
+
+	_, err = LockConfigFile(configFilePath)
+	if assert.NotNil(t, err, "lock should not be acquired while held") {
+		assert.Contains(t, err.Error(), "timed out waiting for the lock")
+	}
+
+	unlock()
+	unlock, err = LockConfigFile(configFilePath)
+	assert.Nil(t, err, "lock should be acquired once released")
+	unlock()
+}
+
+func TestLockConfigFileRemovesLockOfStoppedProcess(t *testing.T) {
+	configFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	lockFilePath := configFilePath + configLockFileSuffix
+	// No process can have the maximum pid
+	assert.Nil(t, ioutil.WriteFile(lockFilePath, []byte(strconv.Itoa(math.MaxInt32)), 0644))
+
+	unlock, err := LockConfigFile(configFilePath)
+	assert.Nil(t, err, "lock of a stopped process should be removed")
+	unlock()
+	_, err = os.Stat(lockFilePath)
+	assert.True(t, os.IsNotExist(err), "lock file should be removed on unlock")
+}
+
+func TestLockConfigFileKeepsOldLockOfRunningProcess(t *testing.T) {
+	defer func(retries int, interval time.Duration) {
+		configLockRetries, configLockRetryInterval = retries, interval
+	}(configLockRetries, configLockRetryInterval)
+	configLockRetries, configLockRetryInterval = 2, time.Millisecond
+
+	configFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	lockFilePath := configFilePath + configLockFileSuffix
+	assert.Nil(t, ioutil.WriteFile(lockFilePath, []byte(strconv.Itoa(os.Getpid())), 0644))
+	oldTime := time.Now().Add(-2 * configLockStaleTimeout)
+	assert.Nil(t, os.Chtimes(lockFilePath, oldTime, oldTime))
+
+	_, err := LockConfigFile(configFilePath)
+	if assert.NotNil(t, err, "lock of a running process should not be removed") {
+		assert.Contains(t, err.Error(), "timed out waiting for the lock")
+	}
+	_, err = os.Stat(lockFilePath)
+	assert.Nil(t, err, "lock file should be kept")
+}
+
+func TestLockConfigFileRemovesStaleLockAfterRetries(t *testing.T) {
+	defer func(retries int, interval time.Duration) {
+		configLockRetries, configLockRetryInterval = retries, interval
+	}(configLockRetries, configLockRetryInterval)
+	configLockRetries, configLockRetryInterval = 2, time.Millisecond
+
+	configFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	lockFilePath := configFilePath + configLockFileSuffix
+	// The owner of an empty lock cannot be determined
+	assert.Nil(t, ioutil.WriteFile(lockFilePath, []byte{}, 0644))
+	staleTime := time.Now().Add(-2 * configLockStaleTimeout)
+	assert.Nil(t, os.Chtimes(lockFilePath, staleTime, staleTime))
+
+	unlock, err := LockConfigFile(configFilePath)
+	assert.Nil(t, err, "stale lock should be removed once the retries run out")
+	unlock()
+	_, err = os.Stat(lockFilePath)
+	assert.True(t, os.IsNotExist(err), "lock file should be removed on unlock")
+}
@@ -103,13 +103,19 @@ const ExportedAPIPoliciesDirName = "api"
 const ExportedApiProductsDirName = "api-products"
 const ExportedAppsDirName = "apps"
 const ExportedMigrationArtifactsDirName = "migration"
+const ExportedDefinitionsDirName = "definitions"
+const ExportedBackstageCatalogsDirName = "backstage"
+const ExportedKeyManagersDirName = "key-managers"
 const CertificatesDirName = "certs"
+const HttpCacheDirName = "http-cache"
 
 const (
 	InitProjectDefinitions              = "Definitions"
 	InitProjectDefinitionsSwagger       = InitProjectDefinitions + string(os.PathSeparator) + "swagger.yaml"
 	InitProjectDefinitionsGraphQLSchema = InitProjectDefinitions + string(os.PathSeparator) + "schema.graphql"
 	InitProjectDefinitionsAsyncAPI      = InitProjectDefinitions + string(os.PathSeparator) + "asyncapi.yaml"
+	InitProjectDefinitionsProto         = InitProjectDefinitions + string(os.PathSeparator) + "proto"
+	InitProjectDefinitionsProtoArchive  = InitProjectDefinitions + string(os.PathSeparator) + "proto_definition.zip"
 	InitProjectImage                    = "Image"
 	InitProjectDocs                     = "Docs"
 	InitProjectSequences                = "Policies"
@@ -125,6 +131,7 @@ const DeploymentCertificatesDirectory = "certificates"
 
 var DefaultExportDirPath = filepath.Join(GetConfigDirPath(), DefaultExportDirName)
 var DefaultCertDirPath = filepath.Join(ConfigDirPath, CertificatesDirName)
+var HttpCacheDirPath = filepath.Join(GetConfigDirPath(), HttpCacheDirName)
 
 const defaultApiApplicationImportExportSuffix = "api/am/admin/v4"
 const defaultPublisherApiImportExportSuffix = "api/am/publisher/v4"
@@ -135,6 +142,9 @@ const defaultUnifiedSearchEndpointSuffix = "api/am/publisher/v4/search"
 const defaultAdminApplicationListEndpointSuffix = "api/am/admin/v4/applications"
 const defaultDevPortalApplicationListEndpointSuffix = "api/am/devportal/v3/applications"
 const defaultDevPortalThrottlingPoliciesEndpointSuffix = "api/am/devportal/v3/throttling-policies"
+const defaultDevPortalApiListEndpointSuffix = "api/am/devportal/v3/apis"
+const defaultDevPortalSubscriptionsEndpointSuffix = "api/am/devportal/v3/subscriptions"
+const defaultDevPortalSettingsEndpointSuffix = "api/am/devportal/v3/settings"
 const defaultClientRegistrationEndpointSuffix = "client-registration/v0.17/register"
 const defaultTokenEndPoint = "oauth2/token"
 const defaultRevokeEndpointSuffix = "oauth2/revoke"
@@ -166,6 +176,7 @@ const HeaderAccept = "Accept"
 const HeaderProduces = "Produces"
 const HeaderConsumes = "Consumes"
 const HeaderContentEncoding = "Content-Encoding"
+const HeaderCacheControl = "Cache-Control"
 const HeaderTransferEncoding = "transfer-encoding"
 const HeaderValueChunked = "chunked"
 const HeaderValueGZIP = "gzip"
@@ -189,8 +200,17 @@ const SearchAndTag = "&"
 
 // Other
 const DefaultTokenValidityPeriod = 3600
+
+// Grant types supported when generating tokens with get keys
+const GrantTypeClientCredentials = "client_credentials"
+const GrantTypePassword = "password"
 const DefaultHttpRequestTimeout = 10000
 
+// Fields stripped from the exported artifacts by export api --normalize, unless export_normalize_excluded_fields is
+// set in the main config
+var DefaultExportNormalizeExcludedFields = []string{"data.id", "data.createdTime", "data.lastUpdatedTime",
+	"data.lastUpdatedTimestamp", "data.documentId"}
+
 // TLSRenegotiationNever : never negotiate
 const TLSRenegotiationNever = "never"
 
@@ -204,6 +224,7 @@ const TLSRenegotiationFreely = "freely"
 const MaxAPIsToExportOnce = 20
 const MigrationAPIsExportMetadataFileName = "migration-apis-export-metadata.yaml"
 const LastSucceededApiFileName = "last-succeeded-api.log"
+const MigrationAPIsExportSummaryFileName = "migration-apis-export-summary.yaml"
 const LastSuceededContentDelimiter = " " // space
 const DefaultResourceTenantDomain = "tenant-default"
 const ApplicationId = "applicationId"
@@ -216,6 +237,11 @@ const LifeCycleAction = "action"
 
 var ValidInitialStates = []string{"CREATED", "PUBLISHED"}
 
+// APIM versions which can be targeted by the generated and imported artifacts
+const DefaultAPIMVersion = "v4.2.0"
+
+var SupportedAPIMVersions = []string{"v4.0.0", "v4.1.0", "v4.2.0", "v4.3.0", "v4.4.0", "v4.5.0", "v4.6.0"}
+
 // The list of repos and directories that can be used when replcing env variables
 var EnvReplaceFilePaths = []string{
 	"Policies",
@@ -227,6 +253,15 @@ var EnvReplacePoliciesFileExtensions = []string{
 	"gotmpl",
 }
 
+// The list of file extensions supported for the thumbnail of an API
+var ThumbnailFileExtensions = []string{
+	"jpg",
+	"jpeg",
+	"png",
+	"gif",
+	"svg",
+}
+
 // project types
 const (
 	ProjectTypeNone        = "None"
@@ -263,6 +298,7 @@ const DeployImportRotateRevision = "deploy.import.rotateRevision"
 const DeployImportSkipSubscriptions = "deploy.import.skipSubscriptions"
 
 const DeploymentEnvFile = "deployment_environments.yaml"
+const ApplicationKeyMappingsFile = "key_mappings.yaml"
 const PrivateJetModeConst = "privateJet"
 const SidecarModeConst = "sidecar"
 
@@ -332,6 +368,10 @@ const ZipFileSuffix = ".zip"
 
 // Output format types
 const JsonArrayFormatType = "jsonArray"
+const JsonFormatType = "json"
+const YamlFormatType = "yaml"
+const TableFormatType = "table"
+const JsonPathFormatPrefix = "jsonpath="
 
 const ThrottlingPolicyTypeSub = "subscription"
 const ThrottlingPolicyTypeApp = "application"
@@ -0,0 +1,144 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/Jeffail/gabs"
+)
+
+// Key of the endpoint url in the endpoint config of an API
+const endpointConfigURLKey = "url"
+
+// EndpointRewriteRule rewrites the endpoint URLs matching the Match regex with Replace. Replace can refer to the
+// capture groups of the regex (ex: $1)
+type EndpointRewriteRule struct {
+	Match   string `yaml:"match" json:"match"`
+	Replace string `yaml:"replace" json:"replace"`
+}
+
+// endpointRewriter holds the compiled endpoint rewrite rules
+type endpointRewriter struct {
+	patterns     []*regexp.Regexp
+	replacements []string
+}
+
+func newEndpointRewriter(rules []EndpointRewriteRule) (*endpointRewriter, error) {
+	rewriter := &endpointRewriter{}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint rewrite rule %q: %v", rule.Match, err)
+		}
+		rewriter.patterns = append(rewriter.patterns, pattern)
+		rewriter.replacements = append(rewriter.replacements, rule.Replace)
+	}
+	return rewriter, nil
+}
+
+// rewrite applies the first rule matching the given url
+func (r *endpointRewriter) rewrite(url string) string {
+	for i, pattern := range r.patterns {
+		if pattern.MatchString(url) {
+			return pattern.ReplaceAllString(url, r.replacements[i])
+		}
+	}
+	return url
+}
+
+// rewriteEndpoints walks through the endpoint config and rewrites all the endpoint urls including the load
+// balanced and failover endpoints. Returns the number of rewritten urls.
+func (r *endpointRewriter) rewriteEndpoints(endpointConfig interface{}) int {
+	count := 0
+	switch config := endpointConfig.(type) {
+	case map[string]interface{}:
+		for key, value := range config {
+			if url, ok := value.(string); ok && key == endpointConfigURLKey {
+				if rewritten := r.rewrite(url); rewritten != url {
+					config[key] = rewritten
+					count++
+				}
+				continue
+			}
+			count += r.rewriteEndpoints(value)
+		}
+	case []interface{}:
+		for _, value := range config {
+			count += r.rewriteEndpoints(value)
+		}
+	}
+	return count
+}
+
+// RewriteEndpointConfig rewrites the endpoint urls of the given endpoint config in place using the rules.
+// Returns the number of rewritten urls.
+func RewriteEndpointConfig(endpointConfig interface{}, rules []EndpointRewriteRule) (int, error) {
+	rewriter, err := newEndpointRewriter(rules)
+	if err != nil {
+		return 0, err
+	}
+	return rewriter.rewriteEndpoints(endpointConfig), nil
+}
+
+// RewriteProjectEndpoints rewrites the endpoint urls of the API in the project directory using the rules
+func RewriteProjectEndpoints(projectPath string, rules []EndpointRewriteRule) error {
+	for _, fileName := range []string{APIDefinitionFileYaml, APIDefinitionFileJson} {
+		path := filepath.Join(projectPath, fileName)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		isYaml := fileName == APIDefinitionFileYaml
+		if isYaml {
+			content, err = YamlToJson(content)
+			if err != nil {
+				return err
+			}
+		}
+		apiDefinition, err := gabs.ParseJSON(content)
+		if err != nil {
+			return err
+		}
+		count, err := RewriteEndpointConfig(apiDefinition.Path("data.endpointConfig").Data(), rules)
+		if err != nil || count == 0 {
+			return err
+		}
+		Logln(LogPrefixInfo + fmt.Sprintf("Rewrote %d endpoint url(s) in %s", count, path))
+		content = apiDefinition.BytesIndent("", "  ")
+		if isYaml {
+			content, err = JsonToYaml(content)
+			if err != nil {
+				return err
+			}
+		}
+		return ioutil.WriteFile(path, content, info.Mode())
+	}
+	return fmt.Errorf("API definition file was not found in %s", projectPath)
+}
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEndpointRewriteRules = []EndpointRewriteRule{
+	{Match: `^https://dev-(.*)$`, Replace: "https://prod-$1"},
+}
+
+func TestRewriteEndpointConfigFailover(t *testing.T) {
+	var endpointConfig interface{}
+	_ = json.Unmarshal([]byte(`{"endpoint_type": "failover",
+		"production_endpoints": {"url": "https://dev-pizza.com/v1"},
+		"production_failovers": [{"url": "https://dev-pizza-backup.com/v1"}, {"url": "https://other.com"}]}`),
+		&endpointConfig)
+	count, err := RewriteEndpointConfig(endpointConfig, testEndpointRewriteRules)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, 2, count, "Should rewrite only the matching endpoints")
+
+	config := endpointConfig.(map[string]interface{})
+	assert.Equal(t, "https://prod-pizza.com/v1",
+		config["production_endpoints"].(map[string]interface{})["url"], "Should rewrite the primary endpoint")
+	failovers := config["production_failovers"].([]interface{})
+	assert.Equal(t, "https://prod-pizza-backup.com/v1", failovers[0].(map[string]interface{})["url"],
+		"Should rewrite the failover endpoint using the capture group")
+	assert.Equal(t, "https://other.com", failovers[1].(map[string]interface{})["url"],
+		"Should not rewrite endpoints which do not match")
+}
+
+func TestRewriteEndpointConfigLoadBalanced(t *testing.T) {
+	var endpointConfig interface{}
+	_ = json.Unmarshal([]byte(`{"endpoint_type": "load_balance", "algoCombo": "roundRobin",
+		"sandbox_endpoints": [{"url": "https://dev-a.com"}, {"url": "https://dev-b.com"}]}`), &endpointConfig)
+	count, err := RewriteEndpointConfig(endpointConfig, testEndpointRewriteRules)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, 2, count, "Should rewrite all the load balanced endpoints")
+}
+
+func TestRewriteEndpointConfigInvalidRule(t *testing.T) {
+	_, err := RewriteEndpointConfig(map[string]interface{}{}, []EndpointRewriteRule{{Match: "(", Replace: ""}})
+	assert.Error(t, err, "Should return an error for an invalid regex")
+}
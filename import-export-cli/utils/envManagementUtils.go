@@ -20,6 +20,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -59,6 +60,12 @@ func EnvExistsInMainConfigFile(env, filePath string) bool {
 // @param envKeys : EnvKeys object for the environment
 // @param filePath : Path to file where env keys are stored
 func AddNewEnvToKeysFile(name string, envKeys EnvKeys, filePath string) {
+	unlock, err := LockConfigFile(filePath)
+	if err != nil {
+		HandleErrorAndExit("Unable to lock "+filePath, err)
+	}
+	defer unlock()
+
 	envKeysAll := GetEnvKeysAllFromFile(filePath)
 	Logln(LogPrefixInfo+"EnvKeysAll:", envKeysAll)
 	if envKeysAll == nil {
@@ -70,7 +77,7 @@ func AddNewEnvToKeysFile(name string, envKeys EnvKeys, filePath string) {
 	}
 	envKeysAll.Environments[name] = envKeys
 
-	WriteConfigFile(envKeysAll, filePath)
+	WriteLockedConfigFile(envKeysAll, filePath)
 }
 
 // RemoveEnvFromKeysFiles
@@ -86,6 +93,11 @@ func RemoveEnvFromKeysFile(env, keysFilePath, mainConfigFilePath string) error {
 	if env == "" {
 		return errors.New("environment cannot be blank")
 	}
+	unlock, err := LockConfigFile(keysFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	envKeysAll := GetEnvKeysAllFromFile(keysFilePath)
 	if EnvExistsInMainConfigFile(env, mainConfigFilePath) {
 		Logln(LogPrefixInfo + "Environment '" + env + "' exists in file " + mainConfigFilePath)
@@ -93,7 +105,7 @@ func RemoveEnvFromKeysFile(env, keysFilePath, mainConfigFilePath string) error {
 			Logln(LogPrefixInfo + "Environment '" + env + "' exists in file " + keysFilePath)
 			delete(envKeysAll.Environments, env)
 			Logln(LogPrefixInfo + "removing environment '" + env + "' from '" + keysFilePath + "'")
-			WriteConfigFile(envKeysAll, keysFilePath)
+			WriteLockedConfigFile(envKeysAll, keysFilePath)
 			return nil
 		} else {
 			// env doesn't exist in keys file
@@ -112,11 +124,16 @@ func RemoveEnvFromMainConfigFile(env, endpointsFilePath string) error {
 	if env == "" {
 		return errors.New("environment cannot be blank")
 	}
+	unlock, err := LockConfigFile(endpointsFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	mainConfig := GetMainConfigFromFile(endpointsFilePath)
 	if EnvExistsInMainConfigFile(env, endpointsFilePath) {
 		Logln(LogPrefixInfo + "Environment '" + env + "' exists in file " + endpointsFilePath)
 		delete(mainConfig.Environments, env)
-		WriteConfigFile(mainConfig, endpointsFilePath)
+		WriteLockedConfigFile(mainConfig, endpointsFilePath)
 		return nil
 	} else {
 		// env doesn't exist in endpoints file
@@ -141,10 +158,10 @@ func GetKeysOfEnvironment(env string, filePath string) (*EnvKeys, error) {
 	return nil, errors.New("error getting keys of environment '" + env + "'")
 }
 
-// Return EnvEndpoints for a given environment
+// Return EnvEndpoints for a given environment. Environments defined using the APICTL_ENV_* environment variables
+// are looked up as well
 func GetEndpointsOfEnvironment(env string, filePath string) (*EnvEndpoints, error) {
-	mainConfig := GetMainConfigFromFile(filePath)
-	for _env, endpoints := range mainConfig.Environments {
+	for _env, endpoints := range GetEnvironments(filePath) {
 		if _env == env {
 			return &endpoints, nil
 		}
@@ -276,6 +293,45 @@ func GetDevPortalThrottlingPoliciesEndpointOfEnv(env, filePath string) string {
 	}
 }
 
+// Get DevPortal ApiListEndpoint of a given environment
+func GetDevPortalApiListEndpointOfEnv(env, filePath string) string {
+	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
+	if !(envEndpoints.DevPortalEndpoint == "" || envEndpoints == nil) {
+		envEndpoints.DevPortalEndpoint = AppendSlashToString(envEndpoints.DevPortalEndpoint)
+		return envEndpoints.DevPortalEndpoint + defaultDevPortalApiListEndpointSuffix
+	} else {
+		apiManagerEndpoint := GetApiManagerEndpointOfEnv(env, filePath)
+		apiManagerEndpoint = AppendSlashToString(apiManagerEndpoint)
+		return apiManagerEndpoint + defaultDevPortalApiListEndpointSuffix
+	}
+}
+
+// Get DevPortal SettingsEndpoint of a given environment
+func GetDevPortalSettingsEndpointOfEnv(env, filePath string) string {
+	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
+	if !(envEndpoints.DevPortalEndpoint == "" || envEndpoints == nil) {
+		envEndpoints.DevPortalEndpoint = AppendSlashToString(envEndpoints.DevPortalEndpoint)
+		return envEndpoints.DevPortalEndpoint + defaultDevPortalSettingsEndpointSuffix
+	} else {
+		apiManagerEndpoint := GetApiManagerEndpointOfEnv(env, filePath)
+		apiManagerEndpoint = AppendSlashToString(apiManagerEndpoint)
+		return apiManagerEndpoint + defaultDevPortalSettingsEndpointSuffix
+	}
+}
+
+// Get DevPortal SubscriptionsEndpoint of a given environment
+func GetDevPortalSubscriptionsEndpointOfEnv(env, filePath string) string {
+	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
+	if !(envEndpoints.DevPortalEndpoint == "" || envEndpoints == nil) {
+		envEndpoints.DevPortalEndpoint = AppendSlashToString(envEndpoints.DevPortalEndpoint)
+		return envEndpoints.DevPortalEndpoint + defaultDevPortalSubscriptionsEndpointSuffix
+	} else {
+		apiManagerEndpoint := GetApiManagerEndpointOfEnv(env, filePath)
+		apiManagerEndpoint = AppendSlashToString(apiManagerEndpoint)
+		return apiManagerEndpoint + defaultDevPortalSubscriptionsEndpointSuffix
+	}
+}
+
 // Get TokenEndpoint of a given environment
 func GetTokenEndpointOfEnv(env, filePath string) string {
 	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
@@ -443,6 +499,110 @@ func GetTokenRevokeEndpoint(env, filePath string) string {
 	return extractedTokenEndpoint + defaultRevokeEndpointSuffix
 }
 
+// GetAnalyticsEndpointOfEnv returns the analytics endpoint of a given environment
+func GetAnalyticsEndpointOfEnv(env, filePath string) (string, error) {
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil {
+		return "", err
+	}
+	if envEndpoints.AnalyticsEndpoint == "" {
+		return "", errors.New("analytics endpoint is not configured for the environment '" + env + "'")
+	}
+	return AppendSlashToString(envEndpoints.AnalyticsEndpoint), nil
+}
+
+// GetVaultEndpointOfEnv returns the HashiCorp Vault endpoint of a given environment
+func GetVaultEndpointOfEnv(env, filePath string) (string, error) {
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil {
+		return "", err
+	}
+	if envEndpoints.VaultEndpoint == "" {
+		return "", errors.New("vault endpoint is not configured for the environment '" + env + "'")
+	}
+	return AppendSlashToString(envEndpoints.VaultEndpoint), nil
+}
+
+// ReadOnlyCommands are the commands allowed in an environment added in read-only mode. Each command is listed with
+// its full path, so that a new get or export command is not allowed until it is verified not to change the
+// environment (eg: "get keys" is not listed, as it creates an application and subscribes it to the API).
+var ReadOnlyCommands = []string{
+	"get api-logging", "get api-product-revisions", "get api-products", "get api-revisions", "get api-usage",
+	"get apis", "get apps", "get claim-mappings", "get correlation-logging", "get deployments", "get monetization",
+	"get monetization-usage", "get policies api", "get policies rate-limiting", "get scope-bindings",
+	"get settings",
+	"export api", "export api-product", "export apis", "export app", "export definitions", "export keymanagers",
+	"export policy api", "export policy rate-limiting",
+	"mg get apis",
+	"mi get apis", "mi get composite-apps", "mi get connectors", "mi get data-services", "mi get endpoints",
+	"mi get inbound-endpoints", "mi get local-entries", "mi get log-levels", "mi get logs",
+	"mi get message-processors", "mi get message-stores", "mi get proxy-services", "mi get roles",
+	"mi get sequences", "mi get tasks", "mi get templates", "mi get transaction-counts",
+	"mi get transaction-reports", "mi get users",
+}
+
+// credentialCommands only manage the credentials of an environment stored in this machine and are needed to run any
+// of the allowed commands, hence they are allowed in every environment
+var credentialCommands = []string{"login", "logout", "mi login", "mi logout"}
+
+// CheckCommandAllowedInEnv returns an error if the environment restricts the commands which can be run against it
+// and the given command (ex: "import api") is not one of them
+func CheckCommandAllowedInEnv(env, commandPath, filePath string) error {
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil || (len(envEndpoints.AllowedCommands) == 0 && !envEndpoints.ReadOnly) {
+		return nil
+	}
+	if isCommandListed(credentialCommands, commandPath) {
+		return nil
+	}
+	if envEndpoints.ReadOnly {
+		if isCommandListed(ReadOnlyCommands, commandPath) {
+			return nil
+		}
+		return fmt.Errorf("'%s %s' is not allowed in the environment '%s' as it is read-only", ProjectName,
+			commandPath, env)
+	}
+	if isCommandAllowed(envEndpoints.AllowedCommands, commandPath) {
+		return nil
+	}
+	return fmt.Errorf("'%s %s' is not allowed in the environment '%s'. Allowed commands: %s", ProjectName,
+		commandPath, env, strings.Join(envEndpoints.AllowedCommands, ", "))
+}
+
+// isCommandListed checks whether the full path of the command is one of the given commands
+func isCommandListed(commands []string, commandPath string) bool {
+	path := strings.Join(strings.Fields(commandPath), " ")
+	for _, command := range commands {
+		if strings.EqualFold(command, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommandAllowed matches the command with the leading words of the allowed commands, so that "get" allows
+// "get apis" as well as "get apps"
+func isCommandAllowed(allowedCommands []string, commandPath string) bool {
+	words := strings.Fields(commandPath)
+	for _, allowedCommand := range allowedCommands {
+		allowedWords := strings.Fields(allowedCommand)
+		if len(allowedWords) == 0 || len(allowedWords) > len(words) {
+			continue
+		}
+		matched := true
+		for i, allowedWord := range allowedWords {
+			if !strings.EqualFold(allowedWord, words[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
 // RequiredAPIMEndpointsExists checks for required apim endpoints.
 // It returns true if all the endpoints are present
 func RequiredAPIMEndpointsExists(envEndpoints *EnvEndpoints) bool {
@@ -536,10 +696,15 @@ func RemoveMgwAdapterEnvFromMainConfigFile(env, endpointsFilePath string) error
 	if env == "" {
 		return errors.New("Environment cannot be blank")
 	}
+	unlock, err := LockConfigFile(endpointsFilePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	mainConfig := GetMainConfigFromFile(endpointsFilePath)
 	if MgwAdapterEnvExistsInMainConfigFile(env, endpointsFilePath) {
 		delete(mainConfig.MgwAdapterEnvs, env)
-		WriteConfigFile(mainConfig, endpointsFilePath)
+		WriteLockedConfigFile(mainConfig, endpointsFilePath)
 		Logln(LogPrefixInfo + "MgwAdapter Environment '" + env +
 			"' removed from config file: " + endpointsFilePath)
 		return nil
@@ -178,3 +178,37 @@ func TestGetKeysOfEnvironment(t *testing.T) {
 	defer os.Remove(testKeysFilePath)
 
 }
+
+func TestCheckCommandAllowedInEnv(t *testing.T) {
+	testMainConfigFilePath := filepath.Join(t.TempDir(), "test_main_config.yaml")
+	mainConfig := new(MainConfig)
+	mainConfig.Environments = map[string]EnvEndpoints{
+		"ci":      {ApiManagerEndpoint: "https://localhost:9443", ReadOnly: true},
+		"staging": {ApiManagerEndpoint: "https://localhost:9443", AllowedCommands: []string{"get", "import api"}},
+		"dev":     {ApiManagerEndpoint: "https://localhost:9443"},
+	}
+	WriteConfigFile(mainConfig, testMainConfigFilePath)
+
+	for _, command := range []string{"get apis", "export api", "mi get apis", "get policies rate-limiting", "login"} {
+		if err := CheckCommandAllowedInEnv("ci", command, testMainConfigFilePath); err != nil {
+			t.Errorf("Expected '%s' to be allowed, got '%v'\n", command, err)
+		}
+	}
+	for _, command := range []string{"get keys", "get", "import api", "delete api", "mi update user", "remove env",
+		"getter"} {
+		if err := CheckCommandAllowedInEnv("ci", command, testMainConfigFilePath); err == nil {
+			t.Errorf("Expected '%s' not to be allowed\n", command)
+		}
+	}
+	for _, command := range []string{"get keys", "import api", "logout"} {
+		if err := CheckCommandAllowedInEnv("staging", command, testMainConfigFilePath); err != nil {
+			t.Errorf("Expected '%s' to be allowed, got '%v'\n", command, err)
+		}
+	}
+	if err := CheckCommandAllowedInEnv("staging", "import api-product", testMainConfigFilePath); err == nil {
+		t.Errorf("Expected 'import api-product' not to be allowed\n")
+	}
+	if err := CheckCommandAllowedInEnv("dev", "import api", testMainConfigFilePath); err != nil {
+		t.Errorf("Expected all the commands to be allowed, got '%v'\n", err)
+	}
+}
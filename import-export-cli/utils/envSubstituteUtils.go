@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/hashicorp/go-multierror"
 )
@@ -52,6 +53,37 @@ func EnvSubstituteForCurlyBraces(content string) (string, error) {
 	return content, nil
 }
 
+// envTemplateData is the data passed to the Go templates in files, exposing the environment as {{ .Env.VAR }}
+type envTemplateData struct {
+	Env map[string]string
+}
+
+// EnvSubstituteTemplate renders the content as a Go template where the environment variables are available as
+// {{ .Env.VAR }}. Content without template actions is returned as it is.
+// returns an error if a referred environment variable is not set or the template is invalid
+func EnvSubstituteTemplate(content string) (string, error) {
+	if !strings.Contains(content, "{{") {
+		return content, nil
+	}
+	tmpl, err := template.New("env").Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	data := envTemplateData{Env: make(map[string]string)}
+	for _, env := range os.Environ() {
+		// Empty variables are treated as missing similar to ${VAR}
+		if pair := strings.SplitN(env, "=", 2); len(pair) == 2 && pair[1] != "" {
+			data.Env[pair[0]] = pair[1]
+		}
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("error substituting environment variables, please set the referred "+
+			"environment variables: %w", err)
+	}
+	return rendered.String(), nil
+}
+
 // Substitutes all the environment variables added in the file specified in the 'file' input and changes are
 // updated in the file.
 // If any required environment variable is not set will throw an error.
@@ -21,3 +21,24 @@ func TestInjectEnvShouldPassWhenEnvPresents(t *testing.T) {
 	assert.Nil(t, err, "Error should be null")
 	assert.Equal(t, "myval", str, "Should correctly replace environment variable")
 }
+
+func TestEnvSubstituteTemplate(t *testing.T) {
+	_ = os.Setenv("APICTL_TEST_HOST", "dev.example.com")
+	defer os.Unsetenv("APICTL_TEST_HOST")
+	str, err := EnvSubstituteTemplate(`url: 'https://{{ .Env.APICTL_TEST_HOST }}/v1'`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "url: 'https://dev.example.com/v1'", str, "Should correctly render the template")
+}
+
+func TestEnvSubstituteTemplateShouldFailWhenEnvNotPresent(t *testing.T) {
+	str, err := EnvSubstituteTemplate(`url: '{{ .Env.APICTL_TEST_MISSING }}'`)
+	assert.Equal(t, "", str, "Should return empty string")
+	assert.Error(t, err, "Should return an error")
+}
+
+func TestEnvSubstituteTemplateWithoutActions(t *testing.T) {
+	data := `url: '${APICTL_TEST_HOST}'`
+	str, err := EnvSubstituteTemplate(data)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, data, str, "Should return the content as it is")
+}
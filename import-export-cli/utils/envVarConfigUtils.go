@@ -0,0 +1,192 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvVarConfigPrefix is the prefix of the environment variables which define an environment along with its
+// credentials, so that the CLI can be used without writing any config files (eg: in a read-only container).
+// The variables are named APICTL_ENV_<ENV>_<FIELD>, where <ENV> is the upper cased name of the environment.
+const EnvVarConfigPrefix = "APICTL_ENV_"
+
+// Fields of the environment variables which define an environment
+const (
+	envVarFieldAPIM          = "_APIM"
+	envVarFieldPublisher     = "_PUBLISHER"
+	envVarFieldDevPortal     = "_DEVPORTAL"
+	envVarFieldRegistration  = "_REGISTRATION"
+	envVarFieldAdmin         = "_ADMIN"
+	envVarFieldTokenEndpoint = "_TOKEN_ENDPOINT"
+	envVarFieldMI            = "_MI"
+	envVarFieldUsername      = "_USERNAME"
+	envVarFieldPassword      = "_PASSWORD"
+	envVarFieldClientID      = "_CLIENT_ID"
+	envVarFieldClientSecret  = "_CLIENT_SECRET"
+	envVarFieldToken         = "_TOKEN"
+	envVarFieldVaultToken    = "_VAULT_TOKEN"
+)
+
+// VaultTokenEnvVar is the environment variable of the HashiCorp Vault token used when no token is set for the
+// environment using APICTL_ENV_<ENV>_VAULT_TOKEN
+const VaultTokenEnvVar = "VAULT_TOKEN"
+
+// envVarEndpointFields are the endpoint fields of an environment variable defined environment. _TOKEN_ENDPOINT is
+// listed before the other fields, so that it is not taken for an environment named <ENV>_TOKEN.
+var envVarEndpointFields = []string{envVarFieldTokenEndpoint, envVarFieldAPIM, envVarFieldPublisher,
+	envVarFieldDevPortal, envVarFieldRegistration, envVarFieldAdmin, envVarFieldMI}
+
+// EnvVarCredentials are the credentials of an environment read from the environment variables
+type EnvVarCredentials struct {
+	Username     string
+	Password     string
+	ClientID     string
+	ClientSecret string
+	// AccessToken is used as it is to invoke the APIs, without logging in
+	AccessToken string
+}
+
+// HasCredentials returns whether a username and a password or an access token is set
+func (c EnvVarCredentials) HasCredentials() bool {
+	return c.AccessToken != "" || (c.Username != "" && c.Password != "")
+}
+
+// GetEnvironmentsFromEnvVars returns the environments defined using the environment variables, keyed by the lower
+// cased name of the environment
+func GetEnvironmentsFromEnvVars() map[string]EnvEndpoints {
+	return getEnvironmentsFromEnvVars(os.Environ())
+}
+
+func getEnvironmentsFromEnvVars(environ []string) map[string]EnvEndpoints {
+	environments := make(map[string]EnvEndpoints)
+	for _, variable := range environ {
+		keyValue := strings.SplitN(variable, "=", 2)
+		if len(keyValue) != 2 || keyValue[1] == "" || !strings.HasPrefix(keyValue[0], EnvVarConfigPrefix) {
+			continue
+		}
+		key := strings.TrimPrefix(keyValue[0], EnvVarConfigPrefix)
+		for _, field := range envVarEndpointFields {
+			if !strings.HasSuffix(key, field) || len(key) == len(field) {
+				continue
+			}
+			name := strings.ToLower(strings.TrimSuffix(key, field))
+			endpoints := environments[name]
+			setEnvVarEndpoint(&endpoints, field, keyValue[1])
+			environments[name] = endpoints
+			break
+		}
+	}
+
+	for name, endpoints := range environments {
+		if endpoints.TokenEndpoint == "" && !HasOnlyMIEndpoint(&endpoints) {
+			if endpoints.ApiManagerEndpoint != "" {
+				endpoints.TokenEndpoint = GetTokenEndPointFromAPIMEndpoint(endpoints.ApiManagerEndpoint)
+			} else if endpoints.PublisherEndpoint != "" {
+				endpoints.TokenEndpoint = GetTokenEndPointFromPublisherEndpoint(endpoints.PublisherEndpoint)
+			}
+			environments[name] = endpoints
+		}
+	}
+	return environments
+}
+
+func setEnvVarEndpoint(endpoints *EnvEndpoints, field, value string) {
+	switch field {
+	case envVarFieldAPIM:
+		endpoints.ApiManagerEndpoint = value
+	case envVarFieldPublisher:
+		endpoints.PublisherEndpoint = value
+	case envVarFieldDevPortal:
+		endpoints.DevPortalEndpoint = value
+	case envVarFieldRegistration:
+		endpoints.RegistrationEndpoint = value
+	case envVarFieldAdmin:
+		endpoints.AdminEndpoint = value
+	case envVarFieldTokenEndpoint:
+		endpoints.TokenEndpoint = value
+	case envVarFieldMI:
+		endpoints.MiManagementEndpoint = value
+	}
+}
+
+// HasEnvironmentsInEnvVars returns whether any environment is defined using the environment variables
+func HasEnvironmentsInEnvVars() bool {
+	return len(GetEnvironmentsFromEnvVars()) > 0
+}
+
+// GetEnvVarCredentials returns the credentials of the environment env set in the environment variables
+func GetEnvVarCredentials(env string) EnvVarCredentials {
+	prefix := EnvVarConfigPrefix + strings.ToUpper(env)
+	return EnvVarCredentials{
+		Username:     os.Getenv(prefix + envVarFieldUsername),
+		Password:     os.Getenv(prefix + envVarFieldPassword),
+		ClientID:     os.Getenv(prefix + envVarFieldClientID),
+		ClientSecret: os.Getenv(prefix + envVarFieldClientSecret),
+		AccessToken:  os.Getenv(prefix + envVarFieldToken),
+	}
+}
+
+// GetEnvironments returns the environments in the main config file along with the environments defined using the
+// environment variables. An environment variable defined environment replaces an environment in the file with the
+// same name.
+// @param mainConfigFilePath : Path to file where env endpoints are stored
+// @return map of environment names to endpoints
+func GetEnvironments(mainConfigFilePath string) map[string]EnvEndpoints {
+	return mergeEnvironments(GetMainConfigFromFile(mainConfigFilePath).Environments, GetEnvironmentsFromEnvVars())
+}
+
+func mergeEnvironments(fileEnvironments, envVarEnvironments map[string]EnvEndpoints) map[string]EnvEndpoints {
+	environments := make(map[string]EnvEndpoints, len(fileEnvironments)+len(envVarEnvironments))
+	for name, endpoints := range fileEnvironments {
+		environments[name] = endpoints
+	}
+	for name, endpoints := range envVarEnvironments {
+		if _, ok := fileEnvironments[name]; ok {
+			Logln(LogPrefixInfo + "Environment '" + name + "' in the main config is overridden by the " +
+				EnvVarConfigPrefix + strings.ToUpper(name) + "_* environment variables")
+		}
+		environments[name] = endpoints
+	}
+	return environments
+}
+
+// getDefaultMainConfig returns the main config used when the main config file is not available and the
+// environments are defined using the environment variables
+func getDefaultMainConfig() *MainConfig {
+	return &MainConfig{
+		Config: Config{
+			HttpRequestTimeout:   DefaultHttpRequestTimeout,
+			ExportDirectory:      DefaultExportDirPath,
+			TokenType:            DefaultTokenType,
+			TLSRenegotiationMode: TLSRenegotiationNever,
+		},
+		Environments:   make(map[string]EnvEndpoints),
+		MgwAdapterEnvs: make(map[string]MgwEndpoints),
+	}
+}
+
+// GetVaultTokenOfEnv returns the HashiCorp Vault token of the environment env set in the environment variables
+func GetVaultTokenOfEnv(env string) string {
+	if token := os.Getenv(EnvVarConfigPrefix + strings.ToUpper(env) + envVarFieldVaultToken); token != "" {
+		return token
+	}
+	return os.Getenv(VaultTokenEnvVar)
+}
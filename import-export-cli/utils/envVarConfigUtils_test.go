@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnvironmentsFromEnvVars(t *testing.T) {
+	environments := getEnvironmentsFromEnvVars([]string{
+		"APICTL_ENV_PROD_APIM=https://prod.example.com:9443",
+		"APICTL_ENV_PROD_TOKEN=eyJ0eXAi",
+		"APICTL_ENV_PROD_USERNAME=admin",
+		"APICTL_ENV_STAGING_PUBLISHER=https://staging.example.com:9443",
+		"APICTL_ENV_STAGING_TOKEN_ENDPOINT=https://staging.example.com:8243/token",
+		"APICTL_ENV_MY_MI_MI=https://mi.example.com:9164",
+		"APICTL_ENV_EMPTY_APIM=",
+		"APICTL_ENV__APIM=https://unnamed.example.com",
+		"APICTL_CONFIG_DIR=/tmp",
+		"PATH=/usr/bin",
+	})
+
+	assert.Equal(t, map[string]EnvEndpoints{
+		"prod": {
+			ApiManagerEndpoint: "https://prod.example.com:9443",
+			TokenEndpoint:      "https://prod.example.com:9443/oauth2/token",
+		},
+		"staging": {
+			PublisherEndpoint: "https://staging.example.com:9443",
+			TokenEndpoint:     "https://staging.example.com:8243/token",
+		},
+		"my_mi": {
+			MiManagementEndpoint: "https://mi.example.com:9164",
+		},
+	}, environments)
+}
+
+func TestMergeEnvironments(t *testing.T) {
+	fileEnvironments := map[string]EnvEndpoints{
+		"dev":  {ApiManagerEndpoint: "https://dev.example.com"},
+		"prod": {ApiManagerEndpoint: "https://old-prod.example.com"},
+	}
+	envVarEnvironments := map[string]EnvEndpoints{
+		"prod": {ApiManagerEndpoint: "https://prod.example.com"},
+	}
+
+	environments := mergeEnvironments(fileEnvironments, envVarEnvironments)
+
+	assert.Equal(t, "https://dev.example.com", environments["dev"].ApiManagerEndpoint)
+	assert.Equal(t, "https://prod.example.com", environments["prod"].ApiManagerEndpoint)
+	assert.Equal(t, "https://old-prod.example.com", fileEnvironments["prod"].ApiManagerEndpoint)
+}
+
+func TestGetEnvVarCredentials(t *testing.T) {
+	t.Setenv("APICTL_ENV_PROD_USERNAME", "admin")
+	t.Setenv("APICTL_ENV_PROD_PASSWORD", "secret")
+	t.Setenv("APICTL_ENV_QA_TOKEN", "eyJ0eXAi")
+
+	prod := GetEnvVarCredentials("prod")
+	assert.Equal(t, EnvVarCredentials{Username: "admin", Password: "secret"}, prod)
+	assert.True(t, prod.HasCredentials())
+
+	qa := GetEnvVarCredentials("qa")
+	assert.Equal(t, "eyJ0eXAi", qa.AccessToken)
+	assert.True(t, qa.HasCredentials())
+
+	assert.False(t, GetEnvVarCredentials("dev").HasCredentials())
+}
+
+func TestGetVaultTokenOfEnv(t *testing.T) {
+	t.Setenv("APICTL_ENV_PROD_VAULT_TOKEN", "prod-vault-token")
+	t.Setenv(VaultTokenEnvVar, "vault-token")
+
+	assert.Equal(t, "prod-vault-token", GetVaultTokenOfEnv("prod"))
+	assert.Equal(t, "vault-token", GetVaultTokenOfEnv("dev"))
+	// The vault token of an environment does not define an environment
+	assert.Empty(t, getEnvironmentsFromEnvVars([]string{"APICTL_ENV_PROD_VAULT_TOKEN=prod-vault-token"}))
+}
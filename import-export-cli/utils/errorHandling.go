@@ -49,7 +49,25 @@ func HandleErrorAndContinue(msg string, err error) {
 
 func printAndExit() {
 	fmt.Println("Exit status 1")
-	os.Exit(1)
+	Exit(1)
+}
+
+// Exit discards the output redirected with --output-file and exits with the given status code. Commands should exit
+// through this function instead of os.Exit, so that the temporary output file is not left behind.
+// @param code : Exit status code
+func Exit(code int) {
+	DiscardOutputFile()
+	os.Exit(code)
+}
+
+// ExitWithOutput writes the output redirected with --output-file as for a successful command and exits with the given
+// status code. Used by the commands which report their result with the status code (ex: the compare commands).
+// @param code : Exit status code
+func ExitWithOutput(code int) {
+	if err := CommitOutputFile(); err != nil {
+		HandleErrorAndExit("Error writing the output file", err)
+	}
+	os.Exit(code)
 }
 
 // Log information of erroneous http response and exit program
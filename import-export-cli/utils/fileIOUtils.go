@@ -34,6 +34,9 @@ import (
 )
 
 // WriteConfigFile
+// The file is locked while it is written and replaced atomically, so that concurrent apictl processes sharing the
+// config directory do not corrupt it. Use LockConfigFile and WriteLockedConfigFile to update a config file which was
+// read, so that the changes of another process are not lost.
 // @param c : data
 // @param envConfigFilePath : Path to file where env endpoints are stored
 func WriteConfigFile(c interface{}, configFilePath string) {
@@ -42,7 +45,23 @@ func WriteConfigFile(c interface{}, configFilePath string) {
 		HandleErrorAndExit("Unable to write configuration to file.", err)
 	}
 
-	err = ioutil.WriteFile(configFilePath, data, 0644)
+	err = writeConfigFileWithLock(configFilePath, data)
+	if err != nil {
+		HandleErrorAndExit("Unable to write configuration to file.", err)
+	}
+}
+
+// WriteLockedConfigFile replaces the config file atomically. The lock of the file should be held with
+// LockConfigFile.
+// @param c : data
+// @param configFilePath : Path of the config file
+func WriteLockedConfigFile(c interface{}, configFilePath string) {
+	data, err := yaml.Marshal(&c)
+	if err != nil {
+		HandleErrorAndExit("Unable to write configuration to file.", err)
+	}
+
+	err = WriteFileAtomically(configFilePath, data, 0644)
 	if err != nil {
 		HandleErrorAndExit("Unable to write configuration to file.", err)
 	}
@@ -70,6 +89,11 @@ func GetEnvKeysAllFromFile(envKeysAllFilePath string) *EnvKeysAll {
 func GetMainConfigFromFile(filePath string) *MainConfig {
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
+		if os.IsNotExist(err) && HasEnvironmentsInEnvVars() {
+			// the environments are defined using the environment variables, so the config file is not required
+			Logln(LogPrefixInfo + "MainConfig: " + filePath + " not found, using the default configuration")
+			return getDefaultMainConfig()
+		}
 		HandleErrorAndExit("MainConfig: File Not Found: "+filePath, err)
 	}
 
@@ -413,8 +437,12 @@ func CreateZipFileFromProject(projectPath string, skipCleanup bool) (string, err
 		if err != nil {
 			return "", err, nil
 		}
+		ignore, err := LoadProjectIgnore(projectPath)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s: %w", ProjectIgnoreFileName, err), nil
+		}
 		Logln(LogPrefixInfo+"Creating the project artifact", tmp.Name())
-		err = Zip(projectPath, tmp.Name())
+		err = ZipIgnoring(projectPath, tmp.Name(), ignore)
 		if err != nil {
 			return "", err, nil
 		}
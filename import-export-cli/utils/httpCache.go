@@ -0,0 +1,269 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// HttpCacheDisabled is true if the responses of the GET requests should not be cached (--no-cache)
+var HttpCacheDisabled bool
+
+// Eviction settings of the http cache. Responses not used within httpCacheMaxAge are removed, and the least recently
+// used responses are removed while the cache is larger than httpCacheMaxSize bytes.
+var (
+	httpCacheMaxAge        = 7 * 24 * time.Hour
+	httpCacheMaxSize int64 = 50 * 1024 * 1024
+)
+
+// httpCacheEntry is a cached response along with the validators used to revalidate it
+type httpCacheEntry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// httpCacheTransport caches the json responses of the GET requests which have an ETag or a Last-Modified header.
+// A cached response is never used without revalidating it, hence the server still authorizes each request and only
+// the unchanged responses are not transferred again. Responses are cached only if the server allows it: never with
+// Cache-Control no-store or private, and for a request with credentials only if the response is explicitly public.
+type httpCacheTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+// enableHttpCache makes the client cache the responses of the GET requests in the http cache directory. It should
+// be called after configuring the TLS settings of the client, as they are applied to the transport being wrapped.
+func enableHttpCache(client *resty.Client) {
+	if HttpCacheDisabled {
+		return
+	}
+	client.SetTransport(&httpCacheTransport{base: client.GetClient().Transport, dir: HttpCacheDirPath})
+}
+
+// RoundTrip sends the request with the validators of the cached response, if any, and replaces a 304 Not Modified
+// response with the cached response
+func (t *httpCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" ||
+		req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+	entryPath := filepath.Join(t.dir, httpCacheKey(req)+".json")
+	entry := readHttpCacheEntry(entryPath, httpCacheMaxAge)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		_ = resp.Body.Close()
+		Logln(LogPrefixInfo + "Using the cached response of " + req.URL.String())
+		// Mark the response as recently used, so that it is not evicted
+		now := time.Now()
+		_ = os.Chtimes(entryPath, now, now)
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        entry.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if (etag == "" && lastModified == "") || !isJsonContentType(resp.Header.Get(HeaderContentType)) ||
+		!isHttpResponseCacheable(req, resp) {
+		if entry != nil {
+			_ = os.Remove(entryPath)
+		}
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	err = writeHttpCacheEntry(entryPath, &httpCacheEntry{ETag: etag, LastModified: lastModified,
+		Header: resp.Header.Clone(), Body: body})
+	if err != nil {
+		Logln(LogPrefixWarning + "Unable to cache the response of " + req.URL.String() + ": " + err.Error())
+	} else {
+		pruneHttpCache(t.dir, httpCacheMaxAge, httpCacheMaxSize)
+	}
+	return resp, nil
+}
+
+// httpCacheKey identifies a cached response by the URL and the accepted content type. The credentials of the request
+// are not a part of the key, as a response to a request with credentials is cached only if it is public and a cached
+// response is used only if the server authorizes the revalidation request.
+func httpCacheKey(req *http.Request) string {
+	hash := sha256.Sum256([]byte(req.URL.String() + "\n" + req.Header.Get(HeaderAccept)))
+	return hex.EncodeToString(hash[:])
+}
+
+// isHttpResponseCacheable checks whether the Cache-Control directives of the request and the response allow
+// storing the response. The response to a request with credentials is stored only if it is public.
+func isHttpResponseCacheable(req *http.Request, resp *http.Response) bool {
+	if hasCacheControlDirective(req.Header, "no-store") {
+		return false
+	}
+	if hasCacheControlDirective(resp.Header, "no-store") || hasCacheControlDirective(resp.Header, "private") {
+		return false
+	}
+	if req.Header.Get(HeaderAuthorization) != "" {
+		return hasCacheControlDirective(resp.Header, "public")
+	}
+	return true
+}
+
+// hasCacheControlDirective checks whether the Cache-Control headers have the directive, with or without a value
+func hasCacheControlDirective(header http.Header, directive string) bool {
+	for _, value := range header.Values(HeaderCacheControl) {
+		for _, part := range strings.Split(value, ",") {
+			name := strings.TrimSpace(part)
+			if i := strings.Index(name, "="); i >= 0 {
+				name = name[:i]
+			}
+			if strings.EqualFold(strings.TrimSpace(name), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isJsonContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// readHttpCacheEntry returns the cached response or nil if the response is not cached, not used within the max age
+// or cannot be read
+func readHttpCacheEntry(entryPath string, maxAge time.Duration) *httpCacheEntry {
+	info, err := os.Stat(entryPath)
+	if err != nil || time.Since(info.ModTime()) > maxAge {
+		return nil
+	}
+	content, err := ioutil.ReadFile(entryPath)
+	if err != nil {
+		return nil
+	}
+	entry := &httpCacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil
+	}
+	return entry
+}
+
+// writeHttpCacheEntry writes the cached response to a temporary file and renames it, so that concurrent commands
+// never read a partially written response. Only the owner can read the responses as they may have sensitive data.
+func writeHttpCacheEntry(entryPath string, entry *httpCacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(entryPath), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(entryPath), "response*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), entryPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// pruneHttpCache removes the cached responses which were not used within the max age, and then the least recently
+// used responses until the size of the cache is within the max size. The leftover temporary files of interrupted
+// writes are removed once they are older than the max age.
+func pruneHttpCache(dir string, maxAge time.Duration, maxSize int64) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var entries []os.FileInfo
+	var size int64
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if time.Since(file.ModTime()) > maxAge {
+			_ = os.Remove(filepath.Join(dir, file.Name()))
+			continue
+		}
+		if filepath.Ext(file.Name()) == ".json" {
+			entries = append(entries, file)
+			size += file.Size()
+		}
+	}
+	if size <= maxSize {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	for _, entry := range entries {
+		if size <= maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err == nil || os.IsNotExist(err) {
+			size -= entry.Size()
+		}
+	}
+}
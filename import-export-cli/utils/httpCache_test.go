@@ -0,0 +1,192 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpCacheTransport(t *testing.T) {
+	body := `{"count":1,"list":[{"name":"PizzaShackAPI"}]}`
+	etag := `"v1"`
+	served, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderAuthorization) == "Bearer invalid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served++
+		w.Header().Set("ETag", etag)
+		w.Header().Set(HeaderCacheControl, "public, no-cache")
+		w.Header().Set(HeaderContentType, "application/json; charset=UTF-8")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	client := &http.Client{Transport: &httpCacheTransport{base: http.DefaultTransport, dir: cacheDir}}
+	get := func(token string) (int, string) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/apis", nil)
+		req.Header.Set(HeaderAuthorization, "Bearer "+token)
+		resp, err := client.Do(req)
+		assert.Nil(t, err, "Error should be nil")
+		defer resp.Body.Close()
+		content, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(content)
+	}
+
+	status, content := get("token-1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, body, content)
+	status, content = get("token-1")
+	assert.Equal(t, http.StatusOK, status, "Not modified response should be replaced with the cached response")
+	assert.Equal(t, body, content)
+	assert.Equal(t, 1, served)
+	assert.Equal(t, 1, notModified)
+
+	// A public response should be used for another user only if the server authorizes the request
+	status, content = get("token-2")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, body, content)
+	assert.Equal(t, 2, notModified)
+	status, _ = get("invalid-token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// The cached response should be replaced when the resource changes
+	body, etag = `{"count":0,"list":[]}`, `"v2"`
+	_, content = get("token-1")
+	assert.Equal(t, body, content)
+	_, content = get("token-1")
+	assert.Equal(t, body, content)
+	assert.Equal(t, 2, served)
+	assert.Equal(t, 3, notModified)
+
+	files, _ := ioutil.ReadDir(cacheDir)
+	assert.Len(t, files, 1, "The credentials should not be a part of the cache key")
+	info, _ := os.Stat(filepath.Join(cacheDir, files[0].Name()))
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Only the owner should be able to read the cache")
+}
+
+func TestHttpCacheTransportWithoutValidators(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set(HeaderContentType, "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	client := &http.Client{Transport: &httpCacheTransport{base: http.DefaultTransport, dir: cacheDir}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err, "Error should be nil")
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, 2, requests)
+	files, _ := ioutil.ReadDir(cacheDir)
+	assert.Empty(t, files, "Responses without an ETag or a Last-Modified header should not be cached")
+}
+
+func TestHttpCacheTransportNotCacheable(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		cacheControl  string
+	}{
+		{name: "Request with credentials", authorization: "Bearer token-1"},
+		{name: "Private response", authorization: "Bearer token-1", cacheControl: "private, max-age=60"},
+		{name: "Private response without credentials", cacheControl: "PRIVATE"},
+		{name: "No store response", cacheControl: "public, no-store"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				if test.cacheControl != "" {
+					w.Header().Set(HeaderCacheControl, test.cacheControl)
+				}
+				w.Header().Set(HeaderContentType, "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			cacheDir := t.TempDir()
+			client := &http.Client{Transport: &httpCacheTransport{base: http.DefaultTransport, dir: cacheDir}}
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			if test.authorization != "" {
+				req.Header.Set(HeaderAuthorization, test.authorization)
+			}
+			resp, err := client.Do(req)
+			assert.Nil(t, err, "Error should be nil")
+			_ = resp.Body.Close()
+			files, _ := ioutil.ReadDir(cacheDir)
+			assert.Empty(t, files, "Response should not be cached")
+		})
+	}
+}
+
+func TestPruneHttpCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	now := time.Now()
+	writeEntry := func(name string, size int, usedAt time.Time) {
+		path := filepath.Join(cacheDir, name)
+		assert.Nil(t, ioutil.WriteFile(path, make([]byte, size), 0600))
+		assert.Nil(t, os.Chtimes(path, usedAt, usedAt))
+	}
+	writeEntry("expired.json", 10, now.Add(-2*time.Hour))
+	writeEntry("response1.tmp", 10, now.Add(-2*time.Hour))
+	writeEntry("oldest.json", 40, now.Add(-30*time.Minute))
+	writeEntry("older.json", 40, now.Add(-20*time.Minute))
+	writeEntry("recent.json", 40, now.Add(-10*time.Minute))
+
+	pruneHttpCache(cacheDir, time.Hour, 100)
+
+	files, _ := ioutil.ReadDir(cacheDir)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	assert.ElementsMatch(t, []string{"older.json", "recent.json"}, names,
+		"Expired and least recently used responses should be removed")
+}
+
+func TestReadHttpCacheEntryExpired(t *testing.T) {
+	entryPath := filepath.Join(t.TempDir(), "entry.json")
+	assert.Nil(t, writeHttpCacheEntry(entryPath, &httpCacheEntry{ETag: `"v1"`, Body: []byte(`{}`)}))
+	assert.NotNil(t, readHttpCacheEntry(entryPath, time.Hour))
+
+	usedAt := time.Now().Add(-2 * time.Hour)
+	assert.Nil(t, os.Chtimes(entryPath, usedAt, usedAt))
+	assert.Nil(t, readHttpCacheEntry(entryPath, time.Hour), "Expired response should not be used")
+}
@@ -0,0 +1,143 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectIgnoreFileName is the file listing the files of a project which are not packaged when importing it
+const ProjectIgnoreFileName = ".apictlignore"
+
+// ProjectIgnore holds the patterns of the ignore file of a project. The patterns follow the .gitignore syntax:
+// a pattern without a slash matches a file or directory at any level, a pattern with a slash is relative to the
+// project root, a trailing slash matches only directories and a leading ! re-includes the files matched earlier.
+// A ** path segment matches zero or more directories, hence **/name matches name at any level, dir/** matches
+// everything inside dir and a/**/b matches b in a and in any directory below a.
+type ProjectIgnore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	pattern  string
+	negated  bool
+	dirOnly  bool
+	anchored bool
+}
+
+// LoadProjectIgnore reads the ignore file of a project
+// @param projectPath : Path of the project directory
+// @return patterns of the ignore file, which is nil if the project has no ignore file, error
+func LoadProjectIgnore(projectPath string) (*ProjectIgnore, error) {
+	file, err := os.Open(filepath.Join(projectPath, ProjectIgnoreFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ignore := &ProjectIgnore{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p := ignorePattern{}
+		if strings.HasPrefix(line, "!") {
+			p.negated = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		p.anchored = strings.Contains(line, "/")
+		p.pattern = strings.TrimPrefix(line, "/")
+		if p.pattern == "" {
+			continue
+		}
+		if _, err := path.Match(p.pattern, ""); err != nil {
+			return nil, err
+		}
+		ignore.patterns = append(ignore.patterns, p)
+	}
+	return ignore, scanner.Err()
+}
+
+// Ignores returns true if a file or directory of the project is not packaged. The ignore file itself is never
+// packaged.
+// @param relativePath : Slash separated path of the file or directory relative to the project root
+// @param isDir : Whether the path is a directory
+// @return whether the path is ignored
+func (p *ProjectIgnore) Ignores(relativePath string, isDir bool) bool {
+	if relativePath == ProjectIgnoreFileName {
+		return true
+	}
+	ignored := false
+	for _, pattern := range p.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		target := relativePath
+		if !pattern.anchored {
+			target = path.Base(relativePath)
+		}
+		if matchIgnoreSegments(strings.Split(pattern.pattern, "/"), strings.Split(target, "/")) {
+			ignored = !pattern.negated
+		}
+	}
+	return ignored
+}
+
+// matchIgnoreSegments matches the segments of a path against the segments of a pattern, where a ** segment
+// matches zero or more segments of the path, except at the end of the pattern where it matches one or more
+// @param pattern : Segments of the pattern
+// @param name : Segments of the path
+// @return whether the path matches the pattern
+func matchIgnoreSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		if len(pattern) == 1 {
+			return len(name) > 0
+		}
+		for i := 0; i <= len(name); i++ {
+			if matchIgnoreSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	// The patterns were validated when loading, hence the error is always nil
+	if matched, _ := path.Match(pattern[0], name[0]); !matched {
+		return false
+	}
+	return matchIgnoreSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeIgnoreTestFiles(t *testing.T, projectPath string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(projectPath, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestProjectIgnore(t *testing.T) {
+	projectPath := t.TempDir()
+	writeIgnoreTestFiles(t, projectPath, map[string]string{ProjectIgnoreFileName: `# editor artifacts
+*.swp
+.idea/
+/tests
+params/*.yaml
+!params/prod.yaml
+`})
+	ignore, err := LoadProjectIgnore(projectPath)
+	assert.Nil(t, err)
+
+	assert.True(t, ignore.Ignores(ProjectIgnoreFileName, false))
+	assert.True(t, ignore.Ignores("api.yaml.swp", false))
+	assert.True(t, ignore.Ignores("Definitions/swagger.yaml.swp", false), "Should match at any level")
+	assert.True(t, ignore.Ignores("Docs/.idea", true))
+	assert.False(t, ignore.Ignores(".idea", false), "Should only match directories")
+	assert.True(t, ignore.Ignores("tests", true))
+	assert.False(t, ignore.Ignores("Docs/tests", true), "Should only match at the project root")
+	assert.True(t, ignore.Ignores("params/dev.yaml", false))
+	assert.False(t, ignore.Ignores("params/prod.yaml", false), "Should be re-included")
+	assert.False(t, ignore.Ignores("api.yaml", false))
+}
+
+func TestProjectIgnoreDoubleAsterisk(t *testing.T) {
+	projectPath := t.TempDir()
+	writeIgnoreTestFiles(t, projectPath, map[string]string{ProjectIgnoreFileName: `**/fixtures
+Docs/**
+!Docs/README.md
+Sequences/**/*.bak
+`})
+	ignore, err := LoadProjectIgnore(projectPath)
+	assert.Nil(t, err)
+
+	assert.True(t, ignore.Ignores("fixtures", true), "Should match at the project root")
+	assert.True(t, ignore.Ignores("tests/api/fixtures", true), "Should match at any level")
+	assert.False(t, ignore.Ignores("Docs", true), "Should only match the contents of the directory")
+	assert.True(t, ignore.Ignores("Docs/FileContents/guide.md", false))
+	assert.False(t, ignore.Ignores("Docs/README.md", false), "Should be re-included")
+	assert.True(t, ignore.Ignores("Sequences/in.xml.bak", false), "Should match zero directories")
+	assert.True(t, ignore.Ignores("Sequences/in-sequence/Custom/in.xml.bak", false))
+	assert.False(t, ignore.Ignores("Sequences/in-sequence/Custom/in.xml", false))
+}
+
+func TestLoadProjectIgnoreWithoutIgnoreFile(t *testing.T) {
+	ignore, err := LoadProjectIgnore(t.TempDir())
+	assert.Nil(t, err)
+	assert.Nil(t, ignore)
+}
+
+func TestZipIgnoring(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "PizzaShackAPI-1.0.0")
+	writeIgnoreTestFiles(t, projectPath, map[string]string{
+		ProjectIgnoreFileName:          "*.swp\ntests/\n",
+		"api.yaml":                     "type: api\n",
+		"api.yaml.swp":                 "",
+		"tests/fixtures/order.json":    "{}",
+		"Definitions/swagger.yaml":     "openapi: 3.0.1\n",
+		"Definitions/swagger.yaml.swp": "",
+	})
+	ignore, err := LoadProjectIgnore(projectPath)
+	assert.Nil(t, err)
+	archivePath := filepath.Join(t.TempDir(), "project.zip")
+	assert.Nil(t, ZipIgnoring(projectPath, archivePath, ignore))
+
+	reader, err := zip.OpenReader(archivePath)
+	assert.Nil(t, err)
+	defer reader.Close()
+	var files []string
+	for _, file := range reader.File {
+		files = append(files, file.Name)
+	}
+	sort.Strings(files)
+	assert.Equal(t, []string{"PizzaShackAPI-1.0.0/", "PizzaShackAPI-1.0.0/Definitions/",
+		"PizzaShackAPI-1.0.0/Definitions/swagger.yaml", "PizzaShackAPI-1.0.0/api.yaml"}, files)
+}
@@ -105,3 +105,12 @@ func selectTypeOfOutputEntry(data []byte, artifactType string) ([]byte, error) {
 		return json.MarshalIndent(revisionEntries, "", " ")
 	}
 }
+
+// PrintJsonOutput prints the result of a command as an indented json object so that it can be consumed by scripts
+func PrintJsonOutput(result interface{}) {
+	output, err := json.MarshalIndent(result, "", " ")
+	if err != nil {
+		HandleErrorAndExit("Error while formatting the output in json format", err)
+	}
+	fmt.Println(string(output))
+}
@@ -95,3 +95,10 @@ func WriteMigrationApisExportMetadataFile(apis []API, cmdResourceTenantDomain st
 
 	WriteConfigFile(exportMetaData, filepath.Join(exportRelatedFilesPath, MigrationAPIsExportMetadataFileName))
 }
+
+// Write the migration-apis-export-summary.yaml file. This includes whether each API was exported and the number of
+// archives (the working copy and the revisions) exported for it, so that the result can be checked once the
+// export is completed or halted
+func WriteMigrationApisExportSummaryFile(summary *MigrationApisExportSummary, exportRelatedFilesPath string) {
+	WriteConfigFile(summary, filepath.Join(exportRelatedFilesPath, MigrationAPIsExportSummaryFileName))
+}
@@ -0,0 +1,169 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Modification time set to the files of a normalized project, so that archiving the project yields the same bytes
+// for the same content
+var normalizedFileModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NormalizeProjectFiles rewrites the yaml and json files of a project with sorted keys and without the excluded
+// fields, and resets the modification times of the files, so that exporting the same API twice yields identical files
+// @param projectDir : Path to the project directory
+// @param excludedFields : Dot separated paths of the fields to strip. A * in a path matches any key and list items
+// are matched by the path of the list
+// @return error
+func NormalizeProjectFiles(projectDir string, excludedFields []string) error {
+	err := filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		var normalize func([]byte, []string) ([]byte, error)
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			normalize = NormalizeYaml
+		case ".json":
+			normalize = NormalizeJson
+		default:
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(content)) == 0 {
+			return nil
+		}
+		Logln(LogPrefixInfo + "Normalizing " + path)
+		normalized, err := normalize(content, excludedFields)
+		if err != nil {
+			return fmt.Errorf("error while normalizing %s: %v", path, err)
+		}
+		return ioutil.WriteFile(path, normalized, info.Mode())
+	})
+	if err != nil {
+		return err
+	}
+	// The modification times are reset after all the files are written, as writing a file changes the modification
+	// time of the parent directory
+	return filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, normalizedFileModTime, normalizedFileModTime)
+	})
+}
+
+// NormalizeYaml formats yaml content with sorted keys and without the excluded fields
+// @param content : Yaml content
+// @param excludedFields : Dot separated paths of the fields to strip
+// @return normalized content, error
+func NormalizeYaml(content []byte, excludedFields []string) ([]byte, error) {
+	jsonContent, err := YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	normalizedJson, err := NormalizeJson(jsonContent, excludedFields)
+	if err != nil {
+		return nil, err
+	}
+	return JsonToYaml(normalizedJson)
+}
+
+// NormalizeJson formats json content with sorted keys, an indentation of two spaces and without the excluded fields
+// @param content : Json content
+// @param excludedFields : Dot separated paths of the fields to strip
+// @return normalized content, error
+func NormalizeJson(content []byte, excludedFields []string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keep the numbers as they are instead of converting them to floats
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	patterns := make([][]string, 0, len(excludedFields))
+	for _, field := range excludedFields {
+		if field = strings.TrimSpace(field); field != "" {
+			patterns = append(patterns, strings.Split(field, "."))
+		}
+	}
+	stripExcludedFields(value, nil, patterns)
+
+	// Keys of the maps are sorted by the encoder
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return normalized.Bytes(), nil
+}
+
+// stripExcludedFields removes the fields matching the patterns from a value decoded from json
+// @param value : Value to strip the fields from
+// @param path : Keys from the root to the value
+// @param patterns : Paths of the fields to strip split by the dots
+func stripExcludedFields(value interface{}, path []string, patterns [][]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := append(append([]string{}, path...), key)
+			if matchesAnyFieldPattern(childPath, patterns) {
+				delete(v, key)
+				continue
+			}
+			stripExcludedFields(child, childPath, patterns)
+		}
+	case []interface{}:
+		for _, item := range v {
+			stripExcludedFields(item, path, patterns)
+		}
+	}
+}
+
+func matchesAnyFieldPattern(path []string, patterns [][]string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) != len(path) {
+			continue
+		}
+		matched := true
+		for i := range pattern {
+			if pattern[i] != "*" && pattern[i] != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
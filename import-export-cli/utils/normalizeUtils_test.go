@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNormalizeAPIYaml = `type: api
+version: v4.2.0
+data:
+  name: PizzaShackAPI
+  id: 8a6d1c49-2e1b-4d5f-9c0b-5b7d1e2f3a4c
+  lastUpdatedTime: "1700000000000"
+  context: /pizzashack
+  operations:
+    - target: /order
+      verb: POST
+      id: ""
+    - verb: GET
+      target: /menu
+      id: ""
+  maxTps:
+    production: 1000
+`
+
+const testNormalizedAPIYaml = `data:
+  context: /pizzashack
+  maxTps:
+    production: 1000
+  name: PizzaShackAPI
+  operations:
+  - target: /order
+    verb: POST
+  - target: /menu
+    verb: GET
+type: api
+version: v4.2.0
+`
+
+func TestNormalizeYaml(t *testing.T) {
+	normalized, err := NormalizeYaml([]byte(testNormalizeAPIYaml),
+		[]string{"data.id", "data.lastUpdatedTime", "data.operations.id"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, testNormalizedAPIYaml, string(normalized), "Should sort the keys and strip the excluded fields")
+}
+
+func TestNormalizeJsonWildcard(t *testing.T) {
+	normalized, err := NormalizeJson([]byte(`{"b": {"id": "1", "name": "x & y"}, "a": {"id": 12345678901234567890}}`),
+		[]string{"*.id"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "{\n  \"a\": {},\n  \"b\": {\n    \"name\": \"x & y\"\n  }\n}\n", string(normalized),
+		"Should strip the fields matching the wildcard")
+}
+
+func TestNormalizeProjectFiles(t *testing.T) {
+	projectDir, err := ioutil.TempDir("", "normalize")
+	assert.Nil(t, err, "Error should be null")
+	defer os.RemoveAll(projectDir)
+
+	apiYamlPath := filepath.Join(projectDir, "api.yaml")
+	_ = ioutil.WriteFile(apiYamlPath, []byte(testNormalizeAPIYaml), 0644)
+	_ = ioutil.WriteFile(filepath.Join(projectDir, "schema.graphql"), []byte("type Query {}"), 0644)
+
+	err = NormalizeProjectFiles(projectDir, DefaultExportNormalizeExcludedFields)
+	assert.Nil(t, err, "Error should be null")
+
+	content, _ := ioutil.ReadFile(apiYamlPath)
+	assert.NotContains(t, string(content), "8a6d1c49", "Should strip the API id")
+	assert.NotContains(t, string(content), "lastUpdatedTime", "Should strip the last updated time")
+	schema, _ := ioutil.ReadFile(filepath.Join(projectDir, "schema.graphql"))
+	assert.Equal(t, "type Query {}", string(schema), "Should not change the files other than yaml and json")
+
+	info, _ := os.Stat(apiYamlPath)
+	assert.True(t, info.ModTime().Equal(normalizedFileModTime), "Should reset the modification time")
+}
@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// outputFile holds the state of the command output redirected with --output-file
+type outputFile struct {
+	path   string
+	tmp    *os.File
+	stdout *os.File
+}
+
+var redirectedOutput *outputFile
+
+// RedirectOutputToFile writes the standard output of the command to a temporary file in the directory of path.
+// The temporary file replaces path only when CommitOutputFile is called, so that a failed command never leaves a
+// partially written file behind.
+func RedirectOutputToFile(path string) error {
+	if redirectedOutput != nil {
+		return nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(absPath), "."+filepath.Base(absPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	redirectedOutput = &outputFile{path: absPath, tmp: tmp, stdout: os.Stdout}
+	os.Stdout = tmp
+	Logln(LogPrefixInfo + "Writing the output to " + absPath)
+	return nil
+}
+
+// CommitOutputFile restores the standard output and atomically moves the output written so far to the file given
+// with RedirectOutputToFile. It does nothing if the output was not redirected.
+func CommitOutputFile() error {
+	output := restoreStdout()
+	if output == nil {
+		return nil
+	}
+	err := output.tmp.Sync()
+	if closeErr := output.tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(output.tmp.Name(), output.path)
+	}
+	if err != nil {
+		_ = os.Remove(output.tmp.Name())
+	}
+	return err
+}
+
+// DiscardOutputFile restores the standard output and removes the output written so far, leaving the file given
+// with RedirectOutputToFile untouched. It does nothing if the output was not redirected.
+func DiscardOutputFile() {
+	output := restoreStdout()
+	if output == nil {
+		return
+	}
+	_ = output.tmp.Close()
+	_ = os.Remove(output.tmp.Name())
+}
+
+func restoreStdout() *outputFile {
+	output := redirectedOutput
+	if output != nil {
+		os.Stdout = output.stdout
+		redirectedOutput = nil
+	}
+	return output
+}
@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitOutputFile(t *testing.T) {
+	stdout := os.Stdout
+	path := filepath.Join(t.TempDir(), "apis.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("old"), 0644))
+
+	assert.Nil(t, RedirectOutputToFile(path))
+	fmt.Print("new")
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "old", string(content), "Should not be replaced before the command completes")
+
+	assert.Nil(t, CommitOutputFile())
+	assert.Equal(t, stdout, os.Stdout)
+	content, err = ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "new", string(content))
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	assert.Len(t, files, 1, "Should not leave the temporary file behind")
+}
+
+func TestDiscardOutputFile(t *testing.T) {
+	stdout := os.Stdout
+	path := filepath.Join(t.TempDir(), "apis.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("old"), 0644))
+
+	assert.Nil(t, RedirectOutputToFile(path))
+	fmt.Print("partial")
+	DiscardOutputFile()
+
+	assert.Equal(t, stdout, os.Stdout)
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "old", string(content))
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	assert.Len(t, files, 1, "Should not leave the temporary file behind")
+}
+
+func TestCommitOutputFileWithoutRedirect(t *testing.T) {
+	assert.Nil(t, CommitOutputFile())
+	DiscardOutputFile()
+}
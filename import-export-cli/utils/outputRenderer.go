@@ -0,0 +1,229 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatFlagDescription describes the machine readable formats accepted by the --format flag of the get commands
+const FormatFlagDescription = "Use \"json\" or \"yaml\" to print the output in json or yaml format, " +
+	"\"jsonpath=<expression>\" to print the values selected by a JSONPath expression (eg: jsonpath={[*].name}) " +
+	"or \"table\" to print the default table"
+
+// PrintFormattedOutput prints the result of a get command in a machine readable format, so that it can be
+// consumed by scripts without parsing the table. The result is printed in json or yaml, or the values selected by
+// a JSONPath expression are printed one per line. It returns false without printing anything if the format is
+// not one of these (ie: a table or a Go template), so that the caller renders it.
+func PrintFormattedOutput(result interface{}, format string) bool {
+	if !IsMachineReadableFormat(format) {
+		return false
+	}
+	if value := reflect.ValueOf(result); value.Kind() == reflect.Slice && value.IsNil() {
+		// An empty list is printed instead of null
+		result = []interface{}{}
+	}
+	switch {
+	case format == JsonFormatType:
+		PrintJsonOutput(result)
+	case format == YamlFormatType:
+		PrintYamlOutput(result)
+	case strings.HasPrefix(format, JsonPathFormatPrefix):
+		values, err := EvaluateJsonPath(result, strings.TrimPrefix(format, JsonPathFormatPrefix))
+		if err != nil {
+			HandleErrorAndExit("Error while evaluating the JSONPath expression", err)
+		}
+		for _, value := range values {
+			fmt.Println(value)
+		}
+	}
+	return true
+}
+
+// IsMachineReadableFormat returns true if the format is one of the formats printed by PrintFormattedOutput
+func IsMachineReadableFormat(format string) bool {
+	return format == JsonFormatType || format == YamlFormatType || strings.HasPrefix(format, JsonPathFormatPrefix)
+}
+
+// ResolveTableFormat returns the default table format if the format is empty or "table"
+func ResolveTableFormat(format, defaultTableFormat string) string {
+	if format == "" || format == TableFormatType {
+		return defaultTableFormat
+	}
+	return format
+}
+
+// PrintYamlOutput prints the result of a command in yaml so that it can be consumed by scripts
+func PrintYamlOutput(result interface{}) {
+	content, err := json.Marshal(result)
+	if err != nil {
+		HandleErrorAndExit("Error while formatting the output in yaml format", err)
+	}
+	output, err := JsonToYaml(content)
+	if err != nil {
+		HandleErrorAndExit("Error while formatting the output in yaml format", err)
+	}
+	fmt.Print(string(output))
+}
+
+// EvaluateJsonPath returns the values of the json representation of the result selected by a JSONPath expression.
+// The expression may be wrapped in braces and may start with $ (eg: {$.list[0].name} or {[*].name}). Child
+// fields (.name or ['name']), array indexes ([0], [-1]) and wildcards ([*] or .*) are supported. Strings are
+// returned as they are and the other values are returned in json.
+func EvaluateJsonPath(result interface{}, expression string) ([]string, error) {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err = json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	steps, err := parseJsonPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []interface{}{data}
+	for _, step := range steps {
+		var next []interface{}
+		for _, node := range nodes {
+			next = append(next, step.apply(node)...)
+		}
+		nodes = next
+	}
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if s, ok := node.(string); ok {
+			values = append(values, s)
+			continue
+		}
+		value, err := json.Marshal(node)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(value))
+	}
+	return values, nil
+}
+
+// jsonPathStep selects the children of a node. A wildcard step selects all the children, otherwise the field
+// or the array index is selected.
+type jsonPathStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s jsonPathStep) apply(node interface{}) []interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			values := make([]interface{}, 0, len(n))
+			for _, key := range sortedKeys(n) {
+				values = append(values, n[key])
+			}
+			return values
+		}
+		if value, ok := n[s.field]; ok && !s.isIndex {
+			return []interface{}{value}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return n
+		}
+		if s.isIndex {
+			index := s.index
+			if index < 0 {
+				index += len(n)
+			}
+			if index >= 0 && index < len(n) {
+				return []interface{}{n[index]}
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseJsonPath splits a JSONPath expression into steps
+func parseJsonPath(expression string) ([]jsonPathStep, error) {
+	path := strings.TrimSpace(expression)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	path = strings.TrimPrefix(path, "$")
+	var steps []jsonPathStep
+	for path != "" {
+		switch {
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, errors.New("unclosed bracket in the JSONPath expression " + expression)
+			}
+			selector := strings.TrimSpace(path[1:end])
+			path = path[end+1:]
+			switch {
+			case selector == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') &&
+				selector[len(selector)-1] == selector[0]:
+				steps = append(steps, jsonPathStep{field: selector[1 : len(selector)-1]})
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, errors.New("invalid selector [" + selector + "] in the JSONPath expression " +
+						expression)
+				}
+				steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			}
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			field := path[:end]
+			path = path[end:]
+			if field == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else if field != "" {
+				steps = append(steps, jsonPathStep{field: field})
+			}
+		default:
+			return nil, errors.New("invalid JSONPath expression " + expression + ". The path should start " +
+				"with '.' or '['")
+		}
+	}
+	return steps, nil
+}
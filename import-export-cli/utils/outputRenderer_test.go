@@ -0,0 +1,65 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateJsonPath(t *testing.T) {
+	apis := []API{
+		{ID: "1", Name: "PizzaAPI", Version: "1.0.0", LifeCycleStatus: "PUBLISHED"},
+		{ID: "2", Name: "PetstoreAPI", Version: "2.0.0", LifeCycleStatus: "CREATED"},
+	}
+	for expression, expected := range map[string][]string{
+		"{[*].name}":              {"PizzaAPI", "PetstoreAPI"},
+		"{$[*].name}":             {"PizzaAPI", "PetstoreAPI"},
+		".[0].version":            {"1.0.0"},
+		"[-1]['lifeCycleStatus']": {"CREATED"},
+		"{[5].name}":              {},
+		"{[0].missing}":           {},
+	} {
+		values, err := EvaluateJsonPath(apis, expression)
+		assert.Nil(t, err, expression)
+		assert.Equal(t, expected, values, expression)
+	}
+
+	values, err := EvaluateJsonPath(map[string]interface{}{"count": 2, "list": apis[:1]}, "{.count}")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, values, "Values other than strings should be returned in json")
+
+	values, err = EvaluateJsonPath(map[string]string{"b": "2", "a": "1"}, "{.*}")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, values, "Fields should be selected in the order of the keys")
+
+	_, err = EvaluateJsonPath(apis, "{[0}")
+	assert.NotNil(t, err)
+	_, err = EvaluateJsonPath(apis, "{[first]}")
+	assert.NotNil(t, err)
+	_, err = EvaluateJsonPath(apis, "name")
+	assert.NotNil(t, err)
+}
+
+func TestResolveTableFormat(t *testing.T) {
+	assert.Equal(t, "table {{.Name}}", ResolveTableFormat("", "table {{.Name}}"))
+	assert.Equal(t, "table {{.Name}}", ResolveTableFormat(TableFormatType, "table {{.Name}}"))
+	assert.Equal(t, "{{.Id}}", ResolveTableFormat("{{.Id}}", "table {{.Name}}"))
+}
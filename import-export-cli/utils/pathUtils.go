@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+var (
+	// reWindowsPath matches an absolute Windows path with a drive letter (eg: C:\Users or C:/Users) or a UNC path
+	reWindowsPath = regexp.MustCompile(`(^|[\s'"=])([A-Za-z]:[\\/]|\\\\[^\\\s]+\\)`)
+	// reUnixPath matches an absolute Unix path
+	reUnixPath = regexp.MustCompile(`(^|[\s'"=])(/[^/\s])`)
+	// reMSYSPath matches a drive mounted by Git Bash/MSYS (eg: /c/Users), Cygwin (eg: /cygdrive/c/Users) or
+	// WSL (eg: /mnt/c/Users)
+	reMSYSPath = regexp.MustCompile(`^/(?:cygdrive/|mnt/)?([A-Za-z])(?:/(.*))?$`)
+)
+
+// ToNativePath converts a path given in a Git Bash, Cygwin or WSL shell (eg: /c/Users/admin/api.zip) to the path
+// understood by the platform the tool is running on (eg: C:\Users\admin\api.zip). The path is returned as it is on
+// the other platforms.
+func ToNativePath(path string) string {
+	return toNativePath(path, runtime.GOOS)
+}
+
+func toNativePath(path, goos string) string {
+	if goos != "windows" {
+		return path
+	}
+	matches := reMSYSPath.FindStringSubmatch(filepath.ToSlash(path))
+	if matches == nil {
+		return path
+	}
+	return strings.ToUpper(matches[1]) + `:\` + strings.ReplaceAll(matches[2], "/", `\`)
+}
+
+// ExtractPathFromOutput returns the absolute path printed at the end of the last line of the output containing one
+// (eg: the path printed by "Find the exported API at <path>"). Both the Windows and Unix paths are detected
+// regardless of the platform, as the output may have been produced on a different platform (eg: in WSL).
+// An empty string is returned if the output does not contain an absolute path.
+func ExtractPathFromOutput(output string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if path := extractPathFromLine(line); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+func extractPathFromLine(line string) string {
+	for _, re := range []*regexp.Regexp{reWindowsPath, reUnixPath} {
+		if loc := re.FindStringSubmatchIndex(line); loc != nil {
+			return strings.Trim(strings.TrimSpace(line[loc[4]:]), `'"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToNativePath(t *testing.T) {
+	assert.Equal(t, `C:\Users\admin\api.zip`, toNativePath("/c/Users/admin/api.zip", "windows"))
+	assert.Equal(t, `D:\exports`, toNativePath("/cygdrive/d/exports", "windows"))
+	assert.Equal(t, `C:\Users\admin`, toNativePath("/mnt/c/Users/admin", "windows"))
+	assert.Equal(t, `C:\`, toNativePath("/c", "windows"))
+	assert.Equal(t, `C:\Users\admin\api.zip`, toNativePath(`C:\Users\admin\api.zip`, "windows"))
+	assert.Equal(t, "api.zip", toNativePath("api.zip", "windows"))
+	assert.Equal(t, "/c/Users/admin/api.zip", toNativePath("/c/Users/admin/api.zip", "linux"),
+		"Should not be converted on other platforms")
+	assert.Equal(t, "/mnt/c/Users/admin", toNativePath("/mnt/c/Users/admin", "linux"))
+}
+
+func TestExtractPathFromOutput(t *testing.T) {
+	assert.Equal(t, "/home/admin/.wso2apictl/exported/apis/dev/PizzaAPI_1.0.0.zip",
+		ExtractPathFromOutput("Successfully exported API!\n"+
+			"Find the exported API at /home/admin/.wso2apictl/exported/apis/dev/PizzaAPI_1.0.0.zip\n"))
+	assert.Equal(t, `C:\Users\admin\.wso2apictl\exported\apis\dev\PizzaAPI_1.0.0.zip`,
+		ExtractPathFromOutput("Successfully exported API!\r\n"+
+			"Find the exported API at C:\\Users\\admin\\.wso2apictl\\exported\\apis\\dev\\PizzaAPI_1.0.0.zip\r\n"))
+	assert.Equal(t, "C:/Users/admin/exports/PizzaAPI_1.0.0.zip",
+		ExtractPathFromOutput("Find the exported API at C:/Users/admin/exports/PizzaAPI_1.0.0.zip"))
+	assert.Equal(t, "/c/Users/admin/my exports/PizzaAPI_1.0.0.zip",
+		ExtractPathFromOutput("Find the exported API at /c/Users/admin/my exports/PizzaAPI_1.0.0.zip"),
+		"Should keep the spaces in the path")
+	assert.Equal(t, `\\fileserver\exports\PizzaAPI_1.0.0.zip`,
+		ExtractPathFromOutput(`Find the exported API at \\fileserver\exports\PizzaAPI_1.0.0.zip`))
+	assert.Equal(t, "/tmp/apis",
+		ExtractPathFromOutput("Find the exported APIs at /tmp/apis\nCommand: export apis execution completed !"),
+		"Should return the path of the last line containing one")
+	assert.Equal(t, "", ExtractPathFromOutput("Successfully imported API.\nhttps://localhost:9443"))
+}
@@ -36,6 +36,8 @@ type MainConfig struct {
 	Config         Config                  `yaml:"config"`
 	Environments   map[string]EnvEndpoints `yaml:"environments"`
 	MgwAdapterEnvs map[string]MgwEndpoints `yaml:"mgw-clusters"`
+	// Aliases maps the alias names to the commands they are expanded to
+	Aliases map[string]string `yaml:"aliases,omitempty"`
 }
 
 type Config struct {
@@ -48,6 +50,8 @@ type Config struct {
 	VCSSourceRepoPath     string `yaml:"vcs_source_repo_path"`
 	VCSDeploymentRepoPath string `yaml:"vcs_deployment_repo_path"`
 	TLSRenegotiationMode  string `yaml:"tls-renegotiation-mode"`
+	// Dot separated paths of the fields stripped from the exported artifacts by export api --normalize
+	ExportNormalizeExcludedFields []string `yaml:"export_normalize_excluded_fields,omitempty"`
 }
 
 type EnvKeys struct {
@@ -64,6 +68,15 @@ type EnvEndpoints struct {
 	AdminEndpoint        string `yaml:"admin"`
 	TokenEndpoint        string `yaml:"token"`
 	MiManagementEndpoint string `yaml:"mi"`
+	AnalyticsEndpoint    string `yaml:"analytics,omitempty"`
+	// VaultEndpoint is the address of the HashiCorp Vault server used to resolve the vault: references in the
+	// params files
+	VaultEndpoint string `yaml:"vault,omitempty"`
+	// AllowedCommands restricts the commands which can be run against the environment. All the commands are
+	// allowed when empty.
+	AllowedCommands []string `yaml:"allowedCommands,omitempty"`
+	// ReadOnly allows only the ReadOnlyCommands to be run against the environment
+	ReadOnly bool `yaml:"readOnly,omitempty"`
 }
 
 type MgwEndpoints struct {
@@ -132,6 +145,29 @@ type TokenResponse struct {
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int32  `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+// DevPortalAPI holds the details of an API retrieved from the devportal REST API
+type DevPortalAPI struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Context      string                 `json:"context"`
+	Version      string                 `json:"version"`
+	Type         string                 `json:"type"`
+	EndpointURLs []DevPortalEndpointURL `json:"endpointURLs"`
+}
+
+// DevPortalEndpointURL holds the gateway URLs of an API in a gateway environment
+type DevPortalEndpointURL struct {
+	EnvironmentName string `json:"environmentName"`
+	EnvironmentType string `json:"environmentType"`
+	URLs            struct {
+		HTTP  string `json:"http"`
+		HTTPS string `json:"https"`
+		WS    string `json:"ws"`
+		WSS   string `json:"wss"`
+	} `json:"URLs"`
 }
 
 type APIListResponse struct {
@@ -160,6 +196,25 @@ type MigrationApisExportMetadata struct {
 	ApiListToExport []API  `yaml:"apis_to_export"`
 }
 
+type MigrationApisExportSummary struct {
+	Environment      string                     `yaml:"environment"`
+	OnTenant         string                     `yaml:"on_tenant"`
+	ExportDirectory  string                     `yaml:"export_directory"`
+	TotalApis        int                        `yaml:"total_apis"`
+	SucceededApis    int                        `yaml:"succeeded_apis"`
+	FailedApis       int                        `yaml:"failed_apis"`
+	ExportedArchives int                        `yaml:"exported_archives"`
+	Apis             []MigrationApiExportResult `yaml:"apis"`
+}
+
+type MigrationApiExportResult struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+	Provider string `yaml:"provider"`
+	Archives int    `yaml:"archives"`
+	Error    string `yaml:"error,omitempty"`
+}
+
 type HttpErrorResponse struct {
 	Code        int     `json:"code"`
 	Status      string  `json:"message"`
@@ -300,6 +355,24 @@ type Subscription struct {
 	RedirectionParams interface{} `json:"redirectionParams"`
 }
 
+// PublisherSubscriptionList Subscriptions of an API as returned by the Publisher REST API
+type PublisherSubscriptionList struct {
+	Count int                     `json:"count"`
+	List  []PublisherSubscription `json:"list"`
+}
+
+// PublisherSubscription Subscription of an application to an API as returned by the Publisher REST API
+type PublisherSubscription struct {
+	SubscriptionID  string `json:"subscriptionId"`
+	ApplicationInfo struct {
+		ApplicationID string `json:"applicationId"`
+		Name          string `json:"name"`
+		Subscriber    string `json:"subscriber"`
+	} `json:"applicationInfo"`
+	ThrottlingPolicy   string `json:"throttlingPolicy"`
+	SubscriptionStatus string `json:"subscriptionStatus"`
+}
+
 type ThrottlingPoliciesDetailsList struct {
 	Count int                       `json:"count"`
 	List  []ThrottlingPolicyDetails `json:"list"`
@@ -479,7 +552,19 @@ type Revisions struct {
 
 type Deployment struct {
 	Name               string `json:"name"`
+	Vhost              string `json:"vhost,omitempty"`
+	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+}
+
+// APIRevisionDeployment Deployment of a revision of an API in a gateway environment
+type APIRevisionDeployment struct {
+	RevisionID         string `json:"revisionId"`
+	Revision           string `json:"revision"`
+	GatewayEnv         string `json:"gatewayEnvironment"`
+	Vhost              string `json:"vhost"`
+	Status             string `json:"status"`
 	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+	DeployedTime       string `json:"deployedTime"`
 }
 
 // APIEntry Api List Entry struct to support  different formats of output in the list command
@@ -524,3 +609,90 @@ type PolicyEntry struct {
 	PolicyName string
 	Type       string
 }
+
+// ImportAPIResult Result of the import api command when the output is requested in json format
+type ImportAPIResult struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	Provider       string `json:"provider"`
+	RevisionId     string `json:"revisionId,omitempty"`
+	RevisionNumber string `json:"revisionNumber,omitempty"`
+}
+
+// ChangeAPIStatusResult Result of the change-status api command when the output is requested in json format
+type ChangeAPIStatusResult struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	Action         string `json:"action"`
+	State          string `json:"state,omitempty"`
+	WorkflowStatus string `json:"workflowStatus,omitempty"`
+}
+
+// LifecycleChangeResponse Response of the change-lifecycle resource of the Publisher REST API
+type LifecycleChangeResponse struct {
+	WorkflowStatus string `json:"workflowStatus"`
+	LifecycleState struct {
+		State string `json:"state"`
+	} `json:"lifecycleState"`
+}
+
+// GetKeysResult Result of the get keys command when the output is requested in json format
+type GetKeysResult struct {
+	AccessToken  string   `json:"accessToken"`
+	RefreshToken string   `json:"refreshToken,omitempty"`
+	TokenType    string   `json:"tokenType,omitempty"`
+	GrantType    string   `json:"grantType"`
+	Scopes       []string `json:"scopes"`
+	ExpiresIn    int32    `json:"expiresIn"`
+	ExpiresAt    string   `json:"expiresAt"`
+}
+
+// APIMonetizationInfo Monetization status and properties of an API
+type APIMonetizationInfo struct {
+	Enabled    bool              `json:"enabled"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// MonetizationUsagePublishInfo Status of the last monetization usage publish job of an environment
+type MonetizationUsagePublishInfo struct {
+	State           string `json:"state"`
+	Status          string `json:"status"`
+	StartedTime     string `json:"startedTime"`
+	LastPublishTime string `json:"lastPublishTime"`
+}
+
+// MonetizationUsagePublishResult Result of triggering a monetization usage publish job
+type MonetizationUsagePublishResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// APIUsageSummary Usage summary of an API returned by the analytics endpoint
+type APIUsageSummary struct {
+	APIId       string            `json:"apiId"`
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	TotalHits   int64             `json:"totalHits"`
+	SuccessHits int64             `json:"successHits"`
+	ErrorHits   int64             `json:"errorHits"`
+	Latency     APILatencySummary `json:"latency"`
+}
+
+// APILatencySummary Latency percentiles of an API in milliseconds
+type APILatencySummary struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+}
+
+// TokenGenerationOptions Options used when generating a token with the get keys command
+type TokenGenerationOptions struct {
+	// Validity period of the token in seconds
+	ValidityPeriod int
+	// Scopes to request. All the scopes of the subscribed APIs are requested if empty
+	Scopes []string
+	// Grant type used to generate the token (client_credentials or password)
+	GrantType string
+}
@@ -0,0 +1,127 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v2"
+)
+
+// Subtype of the exported subscription throttling policies
+const ThrottlePolicySubtypeSubscription = "subscription policy"
+
+// Billing plan of the subscription throttling policies which requires a monetization plan
+const SubscriptionPolicyBillingPlanCommercial = "COMMERCIAL"
+
+var (
+	subscriptionPolicyBurstTimeUnits    = []string{"sec", "min"}
+	subscriptionPolicyMonetizationPlans = []string{"FixedRate", "DynamicRate"}
+)
+
+// SubscriptionPolicyCustomAttribute represents a custom attribute of a subscription throttling policy
+type SubscriptionPolicyCustomAttribute struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// SubscriptionPolicyMonetization represents the monetization details of a subscription throttling policy
+type SubscriptionPolicyMonetization struct {
+	MonetizationPlan string            `yaml:"monetizationPlan"`
+	Properties       map[string]string `yaml:"properties"`
+}
+
+// SubscriptionPolicyFile represents the fields of an exported subscription throttling policy, which are validated
+// before importing the policy
+type SubscriptionPolicyFile struct {
+	Subtype string `yaml:"subtype"`
+	Data    struct {
+		PolicyName        string                              `yaml:"policyName"`
+		BillingPlan       string                              `yaml:"billingPlan"`
+		RateLimitCount    int                                 `yaml:"rateLimitCount"`
+		RateLimitTimeUnit string                              `yaml:"rateLimitTimeUnit"`
+		CustomAttributes  []SubscriptionPolicyCustomAttribute `yaml:"customAttributes"`
+		Monetization      *SubscriptionPolicyMonetization     `yaml:"monetization"`
+	} `yaml:"data"`
+}
+
+// ValidateSubscriptionThrottlePolicy validates the burst control, custom attributes and monetization fields of an
+// exported subscription throttling policy. Policies of other types are not validated.
+// @param content : Content of the exported policy in yaml or json format
+// @return error listing every invalid field
+func ValidateSubscriptionThrottlePolicy(content []byte) error {
+	var policy SubscriptionPolicyFile
+	if err := yaml.Unmarshal(content, &policy); err != nil {
+		return err
+	}
+	if policy.Subtype != ThrottlePolicySubtypeSubscription {
+		return nil
+	}
+	data := policy.Data
+	var errorResults error
+
+	// Burst control
+	if data.RateLimitCount < 0 {
+		errorResults = multierror.Append(errorResults,
+			fmt.Errorf("rateLimitCount of the burst control should not be negative"))
+	} else if data.RateLimitCount > 0 && !containsString(subscriptionPolicyBurstTimeUnits, data.RateLimitTimeUnit) {
+		errorResults = multierror.Append(errorResults, fmt.Errorf("rateLimitTimeUnit of the burst control should "+
+			"be one of %v, found '%s'", subscriptionPolicyBurstTimeUnits, data.RateLimitTimeUnit))
+	}
+
+	// Custom attributes
+	attributeNames := make(map[string]bool)
+	for _, attribute := range data.CustomAttributes {
+		if attribute.Name == "" {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("custom attributes should have a name"))
+			continue
+		}
+		if attributeNames[attribute.Name] {
+			errorResults = multierror.Append(errorResults,
+				fmt.Errorf("custom attribute '%s' is defined more than once", attribute.Name))
+		}
+		attributeNames[attribute.Name] = true
+	}
+
+	// Monetization
+	if data.Monetization != nil && data.Monetization.MonetizationPlan != "" {
+		if !containsString(subscriptionPolicyMonetizationPlans, data.Monetization.MonetizationPlan) {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("monetizationPlan should be one of %v, "+
+				"found '%s'", subscriptionPolicyMonetizationPlans, data.Monetization.MonetizationPlan))
+		}
+	} else if data.BillingPlan == SubscriptionPolicyBillingPlanCommercial {
+		errorResults = multierror.Append(errorResults,
+			fmt.Errorf("a monetizationPlan is required for the %s billing plan", SubscriptionPolicyBillingPlanCommercial))
+	}
+
+	if errorResults != nil {
+		return fmt.Errorf("invalid subscription policy %s: %v", data.PolicyName, errorResults)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
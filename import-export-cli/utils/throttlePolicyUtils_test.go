@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSubscriptionThrottlePolicyValid(t *testing.T) {
+	policy := `
+type: throttling policy
+subtype: subscription policy
+version: v4.3.0
+data:
+  policyName: Gold
+  billingPlan: COMMERCIAL
+  rateLimitCount: 10
+  rateLimitTimeUnit: sec
+  customAttributes:
+    - name: tier
+      value: premium
+  monetization:
+    monetizationPlan: FixedRate
+    properties:
+      fixedPrice: "10"
+      currencyType: USD
+`
+	assert.Nil(t, ValidateSubscriptionThrottlePolicy([]byte(policy)))
+}
+
+func TestValidateSubscriptionThrottlePolicyInvalid(t *testing.T) {
+	policy := `{
+  "type": "throttling policy",
+  "subtype": "subscription policy",
+  "version": "v4.3.0",
+  "data": {
+    "policyName": "Gold",
+    "billingPlan": "COMMERCIAL",
+    "rateLimitCount": 10,
+    "rateLimitTimeUnit": "hour",
+    "customAttributes": [{"name": "tier", "value": "a"}, {"name": "tier", "value": "b"}]
+  }
+}`
+	err := ValidateSubscriptionThrottlePolicy([]byte(policy))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rateLimitTimeUnit")
+	assert.Contains(t, err.Error(), "custom attribute 'tier'")
+	assert.Contains(t, err.Error(), "monetizationPlan is required")
+}
+
+func TestValidateSubscriptionThrottlePolicyOtherType(t *testing.T) {
+	policy := `
+type: throttling policy
+subtype: application policy
+data:
+  policyName: 10PerMin
+  rateLimitCount: -1
+`
+	assert.Nil(t, ValidateSubscriptionThrottlePolicy([]byte(policy)))
+}
@@ -313,11 +313,59 @@ func GetBase64EncodedCredentials(key, secret string) (encodedValue string) {
 // @return response as a map
 // @return error
 func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url string) (map[string]string, error) {
-	body := "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
+	data, err := requestOAuthTokens(getPasswordGrantBody(username, password), b64EncodedClientIDClientSecret, url)
+	if err != nil {
+		return nil, err
+	}
+
+	responseDataMap := make(map[string]string) // a map to hold response data
+	json.Unmarshal(data, &responseDataMap)     // add response data to the map
+
+	return responseDataMap, nil // contains 'access_token', 'refresh_token' etc
+}
+
+// GetOAuthTokenResponse requests tokens using the password grant
+// @param username
+// @param password
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return tokens along with the validity period of the access token
+// @return error
+func GetOAuthTokenResponse(username, password, b64EncodedClientIDClientSecret, url string) (*TokenResponse, error) {
+	data, err := requestOAuthTokens(getPasswordGrantBody(username, password), b64EncodedClientIDClientSecret, url)
+	if err != nil {
+		return nil, err
+	}
+	tokenResponse := &TokenResponse{}
+	err = json.Unmarshal(data, tokenResponse)
+	return tokenResponse, err
+}
+
+// RefreshOAuthTokens requests new tokens using the refresh token grant
+// @param refreshToken : Refresh token issued along with the previous access token
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return tokens along with the validity period of the access token
+// @return error
+func RefreshOAuthTokens(refreshToken, b64EncodedClientIDClientSecret, url string) (*TokenResponse, error) {
+	body := "grant_type=refresh_token&refresh_token=" + encodeURL.QueryEscape(refreshToken)
+	data, err := requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+	if err != nil {
+		return nil, err
+	}
+	tokenResponse := &TokenResponse{}
+	err = json.Unmarshal(data, tokenResponse)
+	return tokenResponse, err
+}
+
+func getPasswordGrantBody(username, password string) string {
+	return "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
 		"&scope=apim:app_import_export+apim:api_import_export+apim:api_product_import_export+apim:app_manage+" +
-		"apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+apim:api_publish+" +
-		"apim:admin+apim:policies_import_export"
+		"apim:sub_manage+apim:api_view+apim:api_create+apim:api_delete+apim:app_owner_change+apim:subscribe+" +
+		"apim:api_publish+apim:admin+apim:policies_import_export"
+}
 
+func requestOAuthTokens(body, b64EncodedClientIDClientSecret, url string) ([]byte, error) {
 	// set headers
 	headers := make(map[string]string)
 	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
@@ -335,10 +383,5 @@ func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url stri
 		return nil, errors.New("Unable to connect. " +
 			"Status: " + resp.Status())
 	}
-
-	responseDataMap := make(map[string]string) // a map to hold response data
-	data := []byte(resp.Body())
-	json.Unmarshal(data, &responseDataMap) // add response data to the map
-
-	return responseDataMap, nil // contains 'access_token', 'refresh_token' etc
+	return []byte(resp.Body()), nil
 }
@@ -136,6 +136,7 @@ func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, e
 		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
 	}
 
+	enableHttpCache(client)
 	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).Get(url)
 }
@@ -154,6 +155,7 @@ func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url st
 		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
 	}
 
+	enableHttpCache(client)
 	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParam(queryParam, paramValue).Get(url)
 }
@@ -172,6 +174,7 @@ func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url s
 		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
 	}
 
+	enableHttpCache(client)
 	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(queryParam).Get(url)
 }
@@ -190,6 +193,7 @@ func InvokeGETRequestWithQueryParamsString(url, queryParams string, headers map[
 		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
 	}
 
+	enableHttpCache(client)
 	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryString(queryParams).Get(url)
 }
@@ -226,6 +230,25 @@ func InvokePUTRequestWithoutQueryParams(url string, headers map[string]string, b
 	return client.R().SetHeaders(headers).SetBody(body).Put(url)
 }
 
+// Invoke http-put request with file using go-resty
+func InvokePUTRequestWithFile(url string, headers map[string]string,
+	fileParamName, filePath string) (*resty.Response, error) {
+
+	client := resty.New()
+
+	if Insecure {
+		client.SetTLSClientConfig(
+			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
+				Renegotiation: TLSRenegotiationMode})
+	} else {
+		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	}
+
+	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	return client.R().SetHeaders(headers).
+		SetFile(fileParamName, filePath).Put(url)
+}
+
 // Invoke http-delete request using go-resty
 func InvokeDELETERequest(url string, headers map[string]string) (*resty.Response, error) {
 	client := resty.New()
@@ -352,10 +375,16 @@ func WriteToFileSystem(exportAPIName, exportAPIVersion, exportEnvironment, expor
 
 // SetToK8sMode sets the "api-ctl" mode to kubernetes
 func SetToK8sMode() {
+	unlock, err := LockConfigFile(MainConfigFilePath)
+	if err != nil {
+		HandleErrorAndExit("Unable to lock "+MainConfigFilePath, err)
+	}
+	defer unlock()
+
 	// read the existing config vars
 	configVars := GetMainConfigFromFile(MainConfigFilePath)
 	configVars.Config.KubernetesMode = true
-	WriteConfigFile(configVars, MainConfigFilePath)
+	WriteLockedConfigFile(configVars, MainConfigFilePath)
 }
 
 // returns min of two ints
@@ -405,3 +434,16 @@ func ValidateFlagWithIntegerValues(value string) (int, error) {
 
 	return limit, nil
 }
+
+// ParseKeyValuePairs parses the key value pairs given in key=value format into a map
+func ParseKeyValuePairs(pairs []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range pairs {
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+			return nil, fmt.Errorf("invalid key value pair %s, expected the format key=value", pair)
+		}
+		values[strings.TrimSpace(keyValue[0])] = keyValue[1]
+	}
+	return values, nil
+}
@@ -84,3 +84,16 @@ func TestPromptForPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestParseKeyValuePairs(t *testing.T) {
+	values, err := ParseKeyValuePairs([]string{"currencyType=USD", "description=a=b"})
+	if err != nil {
+		t.Errorf("Error should be nil, got %v", err)
+	}
+	if values["currencyType"] != "USD" || values["description"] != "a=b" {
+		t.Errorf("Unexpected values %v", values)
+	}
+	if _, err = ParseKeyValuePairs([]string{"currencyType"}); err == nil {
+		t.Error("Should return an error for a pair without a value")
+	}
+}
@@ -29,6 +29,12 @@ import (
 
 // Zip will create an archive from source and store it in target
 func Zip(source, target string) error {
+	return ZipIgnoring(source, target, nil)
+}
+
+// ZipIgnoring will create an archive from source without the files ignored by the ignore file of the project and
+// store it in target. All the files are archived if ignore is nil.
+func ZipIgnoring(source, target string, ignore *ProjectIgnore) error {
 	zipFile, err := os.Create(target)
 	if err != nil {
 		return err
@@ -56,6 +62,19 @@ func Zip(source, target string) error {
 		if err != nil {
 			return err
 		}
+		if ignore != nil {
+			relativePath, err := filepath.Rel(source, path)
+			if err != nil {
+				return err
+			}
+			if relativePath != "." && ignore.Ignores(filepath.ToSlash(relativePath), info.IsDir()) {
+				Logln(LogPrefixInfo+"Ignoring:", relativePath)
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
 
 		// Create a partial zip header from current file or directory
 		header, err := zip.FileInfoHeader(info)